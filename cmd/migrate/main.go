@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"fin-flow-api/internal/infrastructure/config"
+	"fin-flow-api/internal/infrastructure/db"
+)
+
+const usage = `Usage: migrate <command>
+
+Commands:
+  up             Apply all pending migrations
+  down [steps]   Roll back the last applied migration, or the last <steps>
+  status         List migrations and whether they have been applied
+  to <version>   Migrate up or down to <version> (0 rolls back everything)`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("failed to load config: ", err)
+	}
+
+	database, err := db.NewDB(&cfg.Database)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer database.Close()
+
+	migrator, err := db.NewMigrator(database.Pool)
+	if err != nil {
+		log.Fatal("failed to load migrations: ", err)
+	}
+
+	if err := run(context.Background(), migrator, os.Args[1], os.Args[2:]); err != nil {
+		database.Close()
+		log.Fatal(err)
+	}
+}
+
+func run(ctx context.Context, migrator *db.Migrator, command string, args []string) error {
+	switch command {
+	case "up":
+		if err := migrator.Up(ctx); err != nil {
+			return err
+		}
+		log.Println("✅ All migrations applied")
+		return nil
+	case "down":
+		steps := 1
+		if len(args) > 0 {
+			parsed, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid number of steps %q", args[0])
+			}
+			steps = parsed
+		}
+		if err := migrator.Down(ctx, steps); err != nil {
+			return err
+		}
+		log.Printf("✅ Rolled back %d migration(s)", steps)
+		return nil
+	case "to":
+		if len(args) == 0 {
+			return fmt.Errorf("missing target version\n\n%s", usage)
+		}
+		version, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[0])
+		}
+		if err := migrator.To(ctx, version); err != nil {
+			return err
+		}
+		log.Printf("✅ Database is at version %d", version)
+		return nil
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printStatus(statuses)
+		return nil
+	}
+
+	return fmt.Errorf("unknown command %q\n\n%s", command, usage)
+}
+
+func printStatus(statuses []db.MigrationStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT\tNOTE")
+
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.Applied() {
+			appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+
+		note := ""
+		switch {
+		case status.Missing:
+			note = "not in this build"
+		case status.Modified:
+			note = "modified after it was applied"
+		}
+
+		fmt.Fprintf(w, "%03d\t%s\t%s\t%s\n", status.Version, status.Name, appliedAt, note)
+	}
+
+	w.Flush()
+}
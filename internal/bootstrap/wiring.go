@@ -1,6 +1,8 @@
 package bootstrap
 
 import (
+	"context"
+	"fmt"
 	"log"
 
 	"fin-flow-api/internal/infrastructure/config"
@@ -8,12 +10,56 @@ import (
 	"fin-flow-api/internal/infrastructure/hash"
 	"fin-flow-api/internal/infrastructure/jwt"
 	httptransport "fin-flow-api/internal/interfaces/http"
+	budgetservices "fin-flow-api/internal/modules/budgets/application/services"
+	budgetpostgres "fin-flow-api/internal/modules/budgets/infrastructure/persistence/postgres"
+	budgetshttp "fin-flow-api/internal/modules/budgets/interfaces/http"
 	categoryservices "fin-flow-api/internal/modules/categories/application/services"
+	categorydomain "fin-flow-api/internal/modules/categories/domain"
 	categorypostgres "fin-flow-api/internal/modules/categories/infrastructure/persistence/postgres"
 	categorieshttp "fin-flow-api/internal/modules/categories/interfaces/http"
+	categorizationservices "fin-flow-api/internal/modules/categorization/application/services"
+	categorizationpostgres "fin-flow-api/internal/modules/categorization/infrastructure/persistence/postgres"
+	categorizationhttp "fin-flow-api/internal/modules/categorization/interfaces/http"
+	creditcardservices "fin-flow-api/internal/modules/creditcards/application/services"
+	creditcardshttp "fin-flow-api/internal/modules/creditcards/interfaces/http"
+	duplicateservices "fin-flow-api/internal/modules/duplicates/application/services"
+	duplicatedomain "fin-flow-api/internal/modules/duplicates/domain"
+	duplicatepostgres "fin-flow-api/internal/modules/duplicates/infrastructure/persistence/postgres"
+	duplicateshttp "fin-flow-api/internal/modules/duplicates/interfaces/http"
+	exchangerateservices "fin-flow-api/internal/modules/exchangerates/application/services"
+	exchangeratepostgres "fin-flow-api/internal/modules/exchangerates/infrastructure/persistence/postgres"
+	exchangerateshttp "fin-flow-api/internal/modules/exchangerates/interfaces/http"
+	goalservices "fin-flow-api/internal/modules/goals/application/services"
+	goalpostgres "fin-flow-api/internal/modules/goals/infrastructure/persistence/postgres"
+	goalshttp "fin-flow-api/internal/modules/goals/interfaces/http"
+	importservices "fin-flow-api/internal/modules/imports/application/services"
+	importpostgres "fin-flow-api/internal/modules/imports/infrastructure/persistence/postgres"
+	importshttp "fin-flow-api/internal/modules/imports/interfaces/http"
+	inflationservices "fin-flow-api/internal/modules/inflation/application/services"
+	inflationpostgres "fin-flow-api/internal/modules/inflation/infrastructure/persistence/postgres"
+	inflationhttp "fin-flow-api/internal/modules/inflation/interfaces/http"
+	investmentservices "fin-flow-api/internal/modules/investments/application/services"
+	investmentpostgres "fin-flow-api/internal/modules/investments/infrastructure/persistence/postgres"
+	investmentshttp "fin-flow-api/internal/modules/investments/interfaces/http"
+	loanservices "fin-flow-api/internal/modules/loans/application/services"
+	loanpostgres "fin-flow-api/internal/modules/loans/infrastructure/persistence/postgres"
+	loanshttp "fin-flow-api/internal/modules/loans/interfaces/http"
+	reconciliationservices "fin-flow-api/internal/modules/reconciliations/application/services"
+	reconciliationpostgres "fin-flow-api/internal/modules/reconciliations/infrastructure/persistence/postgres"
+	reconciliationshttp "fin-flow-api/internal/modules/reconciliations/interfaces/http"
+	recurringservices "fin-flow-api/internal/modules/recurring/application/services"
+	recurringpostgres "fin-flow-api/internal/modules/recurring/infrastructure/persistence/postgres"
+	recurringhttp "fin-flow-api/internal/modules/recurring/interfaces/http"
+	reportservices "fin-flow-api/internal/modules/reports/application/services"
+	reportpostgres "fin-flow-api/internal/modules/reports/infrastructure/persistence/postgres"
+	reportshttp "fin-flow-api/internal/modules/reports/interfaces/http"
+	transactionservices "fin-flow-api/internal/modules/transactions/application/services"
+	transactionpostgres "fin-flow-api/internal/modules/transactions/infrastructure/persistence/postgres"
+	transactionshttp "fin-flow-api/internal/modules/transactions/interfaces/http"
 	userservices "fin-flow-api/internal/modules/users/application/services"
 	userpostgres "fin-flow-api/internal/modules/users/infrastructure/persistence/postgres"
 	usershttp "fin-flow-api/internal/modules/users/interfaces/http"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
 	walletservices "fin-flow-api/internal/modules/wallets/application/services"
 	walletpostgres "fin-flow-api/internal/modules/wallets/infrastructure/persistence/postgres"
 	walletshttp "fin-flow-api/internal/modules/wallets/interfaces/http"
@@ -33,34 +79,142 @@ func NewApp() (*App, error) {
 		return nil, err
 	}
 
+	if !walletdomain.IsValidCurrency(cfg.Exchange.BaseCurrency) {
+		return nil, fmt.Errorf("EXCHANGE_RATES_BASE_CURRENCY %q is not a supported currency", cfg.Exchange.BaseCurrency)
+	}
+
+	if cfg.Categories.SeedNewUsers {
+		if _, err := categorydomain.FindTemplate(cfg.Categories.DefaultTemplate, cfg.Categories.DefaultLanguage); err != nil {
+			return nil, fmt.Errorf("CATEGORIES_DEFAULT_TEMPLATE %q is not available in %q", cfg.Categories.DefaultTemplate, cfg.Categories.DefaultLanguage)
+		}
+	}
+
+	duplicateCriteria, err := duplicatedomain.NewCriteria(cfg.Duplicates.AmountTolerance, cfg.Duplicates.DateWindowDays, cfg.Duplicates.MinSimilarity)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DUPLICATES_* settings: %w", err)
+	}
+
 	database, err := db.NewDB(&cfg.Database)
 	if err != nil {
 		return nil, err
 	}
 
+	if cfg.Database.MigrateOnStartup {
+		if err := migrate(database); err != nil {
+			database.Close()
+			return nil, err
+		}
+	}
+
 	hashService := hash.NewService()
 	jwtService := jwt.NewService()
 
 	userRepo := userpostgres.NewRepository(database.Pool)
+	sessionRepo := userpostgres.NewSessionRepository(database.Pool)
 	categoryRepo := categorypostgres.NewRepository(database.Pool)
 	walletRepo := walletpostgres.NewRepository(database.Pool)
+	transactionRepo := transactionpostgres.NewRepository(database.Pool)
+	recurringRuleRepo := recurringpostgres.NewRepository(database.Pool)
+	budgetRepo := budgetpostgres.NewRepository(database.Pool)
+	exchangeRateRepo := exchangeratepostgres.NewRepository(database.Pool)
+	priceIndexRepo := inflationpostgres.NewRepository(database.Pool)
+	reportRepo := reportpostgres.NewRepository(database.Pool)
+	assetPriceRepo := investmentpostgres.NewAssetPriceRepository(database.Pool)
+	investmentRepo := investmentpostgres.NewInvestmentRepository(database.Pool)
+	loanRepo := loanpostgres.NewRepository(database.Pool)
+	goalRepo := goalpostgres.NewRepository(database.Pool)
+	categorizationRuleRepo := categorizationpostgres.NewRepository(database.Pool)
+	importProfileRepo := importpostgres.NewProfileRepository(database.Pool)
+	importBatchRepo := importpostgres.NewBatchRepository(database.Pool)
+	duplicateDismissalRepo := duplicatepostgres.NewRepository(database.Pool)
+	reconciliationRepo := reconciliationpostgres.NewRepository(database.Pool)
 
-	userService := userservices.NewUserService(userRepo, hashService, cfg.App.SystemUser)
 	categoryService := categoryservices.NewCategoryService(categoryRepo, cfg.App.SystemUser)
-	walletService := walletservices.NewWalletService(walletRepo, cfg.App.SystemUser)
+	categoryTemplate := ""
+	if cfg.Categories.SeedNewUsers {
+		categoryTemplate = cfg.Categories.DefaultTemplate
+	}
+	categorySeeder := categoryservices.NewTemplateSeeder(categoryService, categoryTemplate, cfg.Categories.DefaultLanguage)
+	userService := userservices.NewUserService(userRepo, hashService, categorySeeder, cfg.App.SystemUser)
+	sessionService := userservices.NewSessionService(sessionRepo, cfg.Auth.RefreshTokenTTL)
+	walletService := walletservices.NewWalletService(walletRepo, walletRepo, cfg.App.SystemUser)
+	categorizationRuleService := categorizationservices.NewRuleService(categorizationRuleRepo, transactionRepo, walletRepo, categoryRepo, cfg.App.SystemUser)
+	duplicateService := duplicateservices.NewDuplicateService(duplicateDismissalRepo, transactionRepo, duplicateCriteria, cfg.App.SystemUser)
+	transactionService := transactionservices.NewTransactionService(transactionRepo, walletRepo, categoryRepo, categorizationRuleService, duplicateService, cfg.App.SystemUser)
+	recurringRuleService := recurringservices.NewRecurringRuleService(recurringRuleRepo, walletRepo, categoryRepo, cfg.App.SystemUser)
+	converter := exchangerateservices.NewConverter(exchangeRateRepo, walletdomain.Currency(cfg.Exchange.BaseCurrency))
+	exchangeRateService := exchangerateservices.NewExchangeRateService(exchangeRateRepo, converter, cfg.App.SystemUser)
+	valuationService := walletservices.NewValuationService(walletRepo, converter)
+	priceIndexService := inflationservices.NewPriceIndexService(priceIndexRepo, cfg.App.SystemUser)
+	budgetService := budgetservices.NewBudgetService(budgetRepo, categoryRepo, priceIndexService, cfg.App.SystemUser)
+	reportService := reportservices.NewReportService(reportRepo, priceIndexService)
+	statementService := creditcardservices.NewStatementService(walletRepo, transactionRepo)
+	assetPriceService := investmentservices.NewAssetPriceService(assetPriceRepo, cfg.App.SystemUser)
+	investmentService := investmentservices.NewInvestmentService(investmentRepo, assetPriceRepo, walletRepo)
+	loanService := loanservices.NewLoanService(loanRepo, walletRepo, categoryRepo, transactionRepo, cfg.App.SystemUser)
+	goalService := goalservices.NewGoalService(goalRepo, walletRepo, transactionRepo, converter, cfg.App.SystemUser)
+	importProfileService := importservices.NewProfileService(importProfileRepo, cfg.App.SystemUser)
+	importService := importservices.NewImportService(importProfileRepo, importBatchRepo, transactionRepo, walletRepo, categoryRepo, categorizationRuleService, duplicateService, cfg.App.SystemUser)
+	reconciliationService := reconciliationservices.NewReconciliationService(reconciliationRepo, walletRepo, transactionRepo, cfg.App.SystemUser)
 
 	userHandler := usershttp.NewHandler(userService)
 	usershttp.SetHandler(userHandler)
 
-	authHandler := usershttp.NewAuthHandler(userRepo, hashService, jwtService)
+	authHandler := usershttp.NewAuthHandler(userRepo, hashService, jwtService, sessionService, cfg.Auth.TrustedProxies)
 	usershttp.SetAuthHandler(authHandler)
+	if cfg.Clerk.Issuer == "" {
+		log.Println("CLERK_ISSUER is not set; /users/sync will reject every token")
+	}
+	usershttp.SetClerkVerifier(jwt.NewClerkVerifierFromConfig(cfg.Clerk))
 
 	categoryHandler := categorieshttp.NewHandler(categoryService)
 	categorieshttp.SetHandler(categoryHandler)
 
-	walletHandler := walletshttp.NewHandler(walletService)
+	walletHandler := walletshttp.NewHandler(walletService, valuationService)
 	walletshttp.SetHandler(walletHandler)
 
+	transactionHandler := transactionshttp.NewHandler(transactionService)
+	transactionshttp.SetHandler(transactionHandler)
+
+	recurringRuleHandler := recurringhttp.NewHandler(recurringRuleService)
+	recurringhttp.SetHandler(recurringRuleHandler)
+
+	budgetHandler := budgetshttp.NewHandler(budgetService)
+	budgetshttp.SetHandler(budgetHandler)
+
+	exchangeRateHandler := exchangerateshttp.NewHandler(exchangeRateService)
+	exchangerateshttp.SetHandler(exchangeRateHandler)
+
+	priceIndexHandler := inflationhttp.NewHandler(priceIndexService)
+	inflationhttp.SetHandler(priceIndexHandler)
+
+	reportHandler := reportshttp.NewHandler(reportService)
+	reportshttp.SetHandler(reportHandler)
+
+	creditCardHandler := creditcardshttp.NewHandler(statementService)
+	creditcardshttp.SetHandler(creditCardHandler)
+
+	investmentHandler := investmentshttp.NewHandler(assetPriceService, investmentService)
+	investmentshttp.SetHandler(investmentHandler)
+
+	loanHandler := loanshttp.NewHandler(loanService)
+	loanshttp.SetHandler(loanHandler)
+
+	goalHandler := goalshttp.NewHandler(goalService)
+	goalshttp.SetHandler(goalHandler)
+
+	categorizationRuleHandler := categorizationhttp.NewHandler(categorizationRuleService)
+	categorizationhttp.SetHandler(categorizationRuleHandler)
+
+	importHandler := importshttp.NewHandler(importProfileService, importService)
+	importshttp.SetHandler(importHandler)
+
+	duplicateHandler := duplicateshttp.NewHandler(duplicateService)
+	duplicateshttp.SetHandler(duplicateHandler)
+
+	reconciliationHandler := reconciliationshttp.NewHandler(reconciliationService)
+	reconciliationshttp.SetHandler(reconciliationHandler)
+
 	httpCfg := httptransport.Config{
 		Addr:              cfg.Port,
 		ReadTimeout:       cfg.Server.ReadTimeout,
@@ -71,6 +225,10 @@ func NewApp() (*App, error) {
 	}
 	srv := httptransport.NewServer(httpCfg, jwtService)
 
+	if cfg.Scheduler.Enabled {
+		srv.AddJob(recurringservices.NewRecurringScheduler(recurringRuleRepo, transactionRepo, cfg.Scheduler.Interval, cfg.App.SystemUser))
+	}
+
 	log.Println("Application initialized successfully")
 
 	return &App{
@@ -82,6 +240,20 @@ func NewApp() (*App, error) {
 	}, nil
 }
 
+func migrate(database *db.DB) error {
+	migrator, err := db.NewMigrator(database.Pool)
+	if err != nil {
+		return fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	if err := migrator.Up(context.Background()); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	log.Println("Database migrations are up to date")
+	return nil
+}
+
 func (a *App) Close() error {
 	if a.DB != nil {
 		a.DB.Close()
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"net/netip"
 	"net/url"
 	"os"
 	"strconv"
@@ -12,10 +13,16 @@ import (
 )
 
 type Config struct {
-	Port      string
-	Database  DatabaseConfig
-	Server    ServerConfig
-	App       AppConfig
+	Port       string
+	Database   DatabaseConfig
+	Server     ServerConfig
+	App        AppConfig
+	Scheduler  SchedulerConfig
+	Clerk      ClerkConfig
+	Auth       AuthConfig
+	Exchange   ExchangeRatesConfig
+	Categories CategoriesConfig
+	Duplicates DuplicatesConfig
 }
 
 type ServerConfig struct {
@@ -30,6 +37,54 @@ type AppConfig struct {
 	SystemUser string
 }
 
+// SchedulerConfig controls the background job that posts recurring
+// transactions.
+type SchedulerConfig struct {
+	Enabled  bool
+	Interval time.Duration
+}
+
+type AuthConfig struct {
+	RefreshTokenTTL time.Duration
+	// TrustedProxies are the reverse proxies whose X-Forwarded-For header
+	// is believed when recording the address of a session.
+	TrustedProxies []netip.Prefix
+}
+
+// ExchangeRatesConfig names the currency conversions are triangulated
+// through when a pair has no stored rate.
+type ExchangeRatesConfig struct {
+	BaseCurrency string
+}
+
+// CategoriesConfig names the category template new users are seeded with.
+type CategoriesConfig struct {
+	SeedNewUsers    bool
+	DefaultTemplate string
+	DefaultLanguage string
+}
+
+// DuplicatesConfig sets how alike two transactions of a wallet must be to
+// be flagged as likely duplicates: amounts within AmountTolerance percent
+// of each other, dates at most DateWindowDays apart and descriptions at
+// least MinSimilarity alike, from 0 to 1.
+type DuplicatesConfig struct {
+	AmountTolerance string
+	DateWindowDays  int
+	MinSimilarity   float64
+}
+
+// ClerkConfig describes the issuer whose session tokens are accepted by
+// /users/sync. Keys are read from JWKSFile when set, otherwise from JWKSURL,
+// which defaults to the issuer's well-known JWKS endpoint.
+type ClerkConfig struct {
+	Issuer            string
+	JWKSURL           string
+	JWKSFile          string
+	Audiences         []string
+	AuthorizedParties []string
+}
+
 type DatabaseConfig struct {
 	DatabaseURL string
 	Host        string
@@ -38,6 +93,8 @@ type DatabaseConfig struct {
 	Password    string
 	DBName      string
 	SSLMode     string
+	// MigrateOnStartup applies pending migrations before the server starts.
+	MigrateOnStartup bool
 }
 
 func Load() (*Config, error) {
@@ -68,6 +125,13 @@ func Load() (*Config, error) {
 		}
 	}
 
+	dbConfig.MigrateOnStartup = getBoolEnv("DB_MIGRATE_ON_STARTUP", false)
+
+	trustedProxies, err := getPrefixListEnv("AUTH_TRUSTED_PROXIES")
+	if err != nil {
+		return nil, err
+	}
+
 	cfg := &Config{
 		Port:     getEnv("PORT", "8080"),
 		Database: dbConfig,
@@ -81,11 +145,50 @@ func Load() (*Config, error) {
 		App: AppConfig{
 			SystemUser: getEnv("APP_SYSTEM_USER", "system"),
 		},
+		Scheduler: SchedulerConfig{
+			Enabled:  getBoolEnv("RECURRING_SCHEDULER_ENABLED", true),
+			Interval: getDurationEnv("RECURRING_SCHEDULER_INTERVAL", 15*time.Minute),
+		},
+		Clerk: loadClerkConfig(),
+		Auth: AuthConfig{
+			RefreshTokenTTL: getDurationEnv("AUTH_REFRESH_TOKEN_TTL", 30*24*time.Hour),
+			TrustedProxies:  trustedProxies,
+		},
+		Exchange: ExchangeRatesConfig{
+			BaseCurrency: strings.ToUpper(getEnv("EXCHANGE_RATES_BASE_CURRENCY", "USD")),
+		},
+		Categories: CategoriesConfig{
+			SeedNewUsers:    getBoolEnv("CATEGORIES_SEED_NEW_USERS", true),
+			DefaultTemplate: strings.ToLower(getEnv("CATEGORIES_DEFAULT_TEMPLATE", "personal")),
+			DefaultLanguage: strings.ToLower(getEnv("CATEGORIES_DEFAULT_LANGUAGE", "es")),
+		},
+		Duplicates: DuplicatesConfig{
+			AmountTolerance: getEnv("DUPLICATES_AMOUNT_TOLERANCE", "1"),
+			DateWindowDays:  getIntEnv("DUPLICATES_DATE_WINDOW_DAYS", 3),
+			MinSimilarity:   getFloatEnv("DUPLICATES_MIN_SIMILARITY", 0.5),
+		},
 	}
 
 	return cfg, nil
 }
 
+func loadClerkConfig() ClerkConfig {
+	issuer := strings.TrimSuffix(getEnv("CLERK_ISSUER", ""), "/")
+
+	jwksURL := getEnv("CLERK_JWKS_URL", "")
+	if jwksURL == "" && issuer != "" {
+		jwksURL = issuer + "/.well-known/jwks.json"
+	}
+
+	return ClerkConfig{
+		Issuer:            issuer,
+		JWKSURL:           jwksURL,
+		JWKSFile:          getEnv("CLERK_JWKS_FILE", ""),
+		Audiences:         getListEnv("CLERK_AUDIENCE"),
+		AuthorizedParties: getListEnv("CLERK_AUTHORIZED_PARTIES"),
+	}
+}
+
 func (c *DatabaseConfig) ConnectionString() string {
 	if c.DatabaseURL != "" {
 		return c.DatabaseURL
@@ -144,3 +247,58 @@ func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 	}
 	return defaultValue
 }
+
+func getIntEnv(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+func getFloatEnv(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+func getBoolEnv(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+func getListEnv(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getPrefixListEnv reads a comma separated list of addresses and CIDR
+// ranges. A bare address stands for itself alone.
+func getPrefixListEnv(key string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, value := range getListEnv(key) {
+		if addr, err := netip.ParseAddr(value); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid address or range %q", key, value)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
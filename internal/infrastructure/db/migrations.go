@@ -0,0 +1,130 @@
+package db
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed migrations/*.sql
+var embeddedMigrations embed.FS
+
+const downSuffix = ".down.sql"
+
+// Migration is one numbered schema change. Up scripts live in
+// NNN_name.sql and the optional rollback in NNN_name.down.sql.
+type Migration struct {
+	Version  int
+	Name     string
+	UpSQL    string
+	DownSQL  string
+	Checksum string
+}
+
+// HasDown reports whether the migration can be rolled back.
+func (m Migration) HasDown() bool {
+	return strings.TrimSpace(m.DownSQL) != ""
+}
+
+func (m Migration) String() string {
+	return fmt.Sprintf("%03d_%s", m.Version, m.Name)
+}
+
+// EmbeddedMigrations returns the migrations compiled into the binary,
+// ordered by version.
+func EmbeddedMigrations() ([]Migration, error) {
+	sub, err := fs.Sub(embeddedMigrations, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return LoadMigrations(sub)
+}
+
+// LoadMigrations reads every *.sql file at the root of fsys and pairs up
+// and down scripts by version.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		fileName := entry.Name()
+		if entry.IsDir() || path.Ext(fileName) != ".sql" {
+			continue
+		}
+
+		isDown := strings.HasSuffix(fileName, downSuffix)
+		base := strings.TrimSuffix(fileName, downSuffix)
+		base = strings.TrimSuffix(base, ".sql")
+
+		version, name, err := parseMigrationName(base)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration file %s: %w", fileName, err)
+		}
+
+		content, err := fs.ReadFile(fsys, fileName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", fileName, err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		}
+		if migration.Name != name {
+			return nil, fmt.Errorf("migration version %d is used by both %s and %s", version, migration.Name, name)
+		}
+
+		if isDown {
+			migration.DownSQL = string(content)
+			continue
+		}
+		if migration.UpSQL != "" {
+			return nil, fmt.Errorf("migration version %d has more than one up script", version)
+		}
+		migration.UpSQL = string(content)
+		migration.Checksum = checksum(content)
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.UpSQL == "" {
+			return nil, fmt.Errorf("migration %s has a down script but no up script", migration)
+		}
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+func parseMigrationName(base string) (int, string, error) {
+	prefix, name, found := strings.Cut(base, "_")
+	if !found || name == "" {
+		return 0, "", fmt.Errorf("expected NNN_name.sql")
+	}
+
+	version, err := strconv.Atoi(prefix)
+	if err != nil || version <= 0 {
+		return 0, "", fmt.Errorf("version must be a positive number")
+	}
+
+	return version, name, nil
+}
+
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
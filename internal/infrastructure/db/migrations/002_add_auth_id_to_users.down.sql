@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS users_auth_id_key;
+ALTER TABLE users DROP COLUMN IF EXISTS auth_id;
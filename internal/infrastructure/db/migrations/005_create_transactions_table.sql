@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS transactions (
+    id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    wallet_id VARCHAR(255) NOT NULL,
+    destination_wallet_id VARCHAR(255),
+    category_id VARCHAR(255),
+    type INTEGER NOT NULL,
+    amount DECIMAL(18, 2) NOT NULL,
+    description VARCHAR(500) NOT NULL DEFAULT '',
+    date DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    modified_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL,
+    modified_by VARCHAR(255) NOT NULL,
+    CONSTRAINT fk_transactions_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_transactions_wallet FOREIGN KEY (wallet_id) REFERENCES wallets(id),
+    CONSTRAINT fk_transactions_destination_wallet FOREIGN KEY (destination_wallet_id) REFERENCES wallets(id),
+    CONSTRAINT fk_transactions_category FOREIGN KEY (category_id) REFERENCES categories(id),
+    CONSTRAINT chk_transactions_amount_positive CHECK (amount > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_user_id ON transactions(user_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_wallet_id ON transactions(wallet_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_destination_wallet_id ON transactions(destination_wallet_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_category_id ON transactions(category_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_user_date ON transactions(user_id, date);
@@ -0,0 +1,16 @@
+-- Fold each transfer back into a single row on the outgoing leg. The
+-- exchange rate of cross-currency transfers cannot be represented in the old
+-- layout and is lost.
+UPDATE transactions
+SET destination_wallet_id = counterpart_wallet_id
+WHERE type = 2 AND transfer_id IS NOT NULL AND NOT is_inbound;
+
+DELETE FROM transactions WHERE type = 2 AND transfer_id IS NOT NULL AND is_inbound;
+
+DROP INDEX IF EXISTS idx_transactions_counterpart_wallet_id;
+DROP INDEX IF EXISTS idx_transactions_transfer_id;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS is_inbound;
+ALTER TABLE transactions DROP COLUMN IF EXISTS exchange_rate;
+ALTER TABLE transactions DROP COLUMN IF EXISTS counterpart_wallet_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS transfer_id;
@@ -0,0 +1,20 @@
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS transfer_id VARCHAR(255);
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS counterpart_wallet_id VARCHAR(255) CONSTRAINT fk_transactions_counterpart_wallet REFERENCES wallets(id);
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS exchange_rate DECIMAL(24, 12);
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS is_inbound BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Transfers used to be a single row pointing at destination_wallet_id. Split
+-- them into an outgoing and an incoming leg so every wallet owns its entries.
+-- destination_wallet_id is NULL from now on and 026 drops it.
+INSERT INTO transactions (id, user_id, wallet_id, counterpart_wallet_id, category_id, type, amount, description, date, transfer_id, exchange_rate, is_inbound, created_at, modified_at, created_by, modified_by)
+SELECT id || '-in', user_id, destination_wallet_id, wallet_id, category_id, type, amount, description, date, id, 1, TRUE, created_at, modified_at, created_by, modified_by
+FROM transactions
+WHERE type = 2 AND destination_wallet_id IS NOT NULL
+ON CONFLICT (id) DO NOTHING;
+
+UPDATE transactions
+SET transfer_id = id, counterpart_wallet_id = destination_wallet_id, exchange_rate = 1, destination_wallet_id = NULL
+WHERE type = 2 AND destination_wallet_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_transfer_id ON transactions(transfer_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_counterpart_wallet_id ON transactions(counterpart_wallet_id);
@@ -0,0 +1,5 @@
+-- Amounts with more decimals than the old columns allow are rounded, and the
+-- rollback fails if a value no longer fits the old precision.
+ALTER TABLE transactions ALTER COLUMN exchange_rate TYPE DECIMAL(24, 12);
+ALTER TABLE transactions ALTER COLUMN amount TYPE DECIMAL(18, 2);
+ALTER TABLE wallets ALTER COLUMN balance TYPE DECIMAL(18, 2);
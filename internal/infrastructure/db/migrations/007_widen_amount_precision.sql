@@ -0,0 +1,5 @@
+-- Amounts are kept at the scale of their currency (2 for USD, 0 for JPY,
+-- 8 for BTC, 18 for ETH), so the columns must hold the widest of them.
+ALTER TABLE wallets ALTER COLUMN balance TYPE DECIMAL(38, 18);
+ALTER TABLE transactions ALTER COLUMN amount TYPE DECIMAL(38, 18);
+ALTER TABLE transactions ALTER COLUMN exchange_rate TYPE DECIMAL(38, 18);
@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS recurring_rules (
+    id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    wallet_id VARCHAR(255) NOT NULL,
+    category_id VARCHAR(255) NOT NULL,
+    type INTEGER NOT NULL,
+    amount DECIMAL(38, 18) NOT NULL,
+    description VARCHAR(500) NOT NULL DEFAULT '',
+    frequency INTEGER NOT NULL,
+    interval_count INTEGER NOT NULL DEFAULT 1,
+    day_of_month INTEGER,
+    start_date DATE NOT NULL,
+    end_date DATE,
+    next_occurrence DATE NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    modified_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL,
+    modified_by VARCHAR(255) NOT NULL,
+    CONSTRAINT fk_recurring_rules_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_recurring_rules_wallet FOREIGN KEY (wallet_id) REFERENCES wallets(id) ON DELETE CASCADE,
+    CONSTRAINT fk_recurring_rules_category FOREIGN KEY (category_id) REFERENCES categories(id),
+    CONSTRAINT chk_recurring_rules_amount_positive CHECK (amount > 0),
+    CONSTRAINT chk_recurring_rules_interval_positive CHECK (interval_count > 0),
+    CONSTRAINT chk_recurring_rules_day_of_month CHECK (day_of_month BETWEEN 1 AND 31)
+);
+
+CREATE INDEX IF NOT EXISTS idx_recurring_rules_user_id ON recurring_rules(user_id);
+CREATE INDEX IF NOT EXISTS idx_recurring_rules_due ON recurring_rules(next_occurrence) WHERE active;
+
+-- Entries posted by a rule remember the occurrence they belong to. The unique
+-- index is what keeps the scheduler from posting an occurrence twice, across
+-- restarts and when several API instances run it at the same time.
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS recurring_rule_id VARCHAR(255) CONSTRAINT fk_transactions_recurring_rule REFERENCES recurring_rules(id) ON DELETE SET NULL;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS occurrence_date DATE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_transactions_recurring_occurrence ON transactions(recurring_rule_id, occurrence_date) WHERE recurring_rule_id IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_transactions_category_date;
+DROP TABLE IF EXISTS budgets;
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS budgets (
+    id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    category_id VARCHAR(255) NOT NULL,
+    amount DECIMAL(38, 18) NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    start_month DATE NOT NULL,
+    rollover BOOLEAN NOT NULL DEFAULT FALSE,
+    alert_threshold INTEGER NOT NULL DEFAULT 80,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    modified_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL,
+    modified_by VARCHAR(255) NOT NULL,
+    CONSTRAINT fk_budgets_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_budgets_category FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE CASCADE,
+    CONSTRAINT unique_user_budget_category_currency UNIQUE (user_id, category_id, currency),
+    CONSTRAINT chk_budgets_amount_positive CHECK (amount > 0),
+    CONSTRAINT chk_budgets_alert_threshold CHECK (alert_threshold BETWEEN 1 AND 100)
+);
+
+CREATE INDEX IF NOT EXISTS idx_budgets_user_id ON budgets(user_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_category_date ON transactions(category_id, date);
@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'user' CONSTRAINT chk_users_role CHECK (role IN ('user', 'admin'));
@@ -0,0 +1,17 @@
+-- rate is the price of one unit of base_currency in quote_currency on
+-- rate_date. Rates are shared by all users.
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    id VARCHAR(255) PRIMARY KEY,
+    base_currency VARCHAR(10) NOT NULL,
+    quote_currency VARCHAR(10) NOT NULL,
+    rate_date DATE NOT NULL,
+    rate DECIMAL(38, 18) NOT NULL,
+    source VARCHAR(20) NOT NULL DEFAULT 'manual',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    modified_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL,
+    modified_by VARCHAR(255) NOT NULL,
+    CONSTRAINT unique_exchange_rate_pair_date UNIQUE (base_currency, quote_currency, rate_date),
+    CONSTRAINT chk_exchange_rates_rate_positive CHECK (rate > 0),
+    CONSTRAINT chk_exchange_rates_distinct_currencies CHECK (base_currency <> quote_currency)
+);
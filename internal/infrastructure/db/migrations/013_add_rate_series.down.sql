@@ -0,0 +1,11 @@
+-- Only the official series fits the old one-rate-per-pair-and-day layout;
+-- rates of the other series are lost.
+ALTER TABLE wallets DROP COLUMN IF EXISTS rate_series;
+
+DELETE FROM exchange_rates WHERE series <> 'official';
+
+ALTER TABLE exchange_rates DROP CONSTRAINT IF EXISTS unique_exchange_rate_pair_series_date;
+ALTER TABLE exchange_rates DROP CONSTRAINT IF EXISTS unique_exchange_rate_pair_date;
+ALTER TABLE exchange_rates ADD CONSTRAINT unique_exchange_rate_pair_date UNIQUE (base_currency, quote_currency, rate_date);
+
+ALTER TABLE exchange_rates DROP COLUMN IF EXISTS series;
@@ -0,0 +1,10 @@
+-- A currency pair can be quoted in several series (official, MEP, CCL, blue,
+-- crypto), so the series becomes part of a rate's identity. Existing rates
+-- and wallets use the official series.
+ALTER TABLE exchange_rates ADD COLUMN IF NOT EXISTS series VARCHAR(20) NOT NULL DEFAULT 'official';
+
+ALTER TABLE exchange_rates DROP CONSTRAINT IF EXISTS unique_exchange_rate_pair_date;
+ALTER TABLE exchange_rates DROP CONSTRAINT IF EXISTS unique_exchange_rate_pair_series_date;
+ALTER TABLE exchange_rates ADD CONSTRAINT unique_exchange_rate_pair_series_date UNIQUE (base_currency, quote_currency, series, rate_date);
+
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS rate_series VARCHAR(20) NOT NULL DEFAULT 'official';
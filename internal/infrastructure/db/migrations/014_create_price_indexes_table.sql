@@ -0,0 +1,16 @@
+-- value is the consumer price index of the country issuing currency for the
+-- month starting on index_month. Index values are shared by all users.
+CREATE TABLE IF NOT EXISTS price_indexes (
+    id VARCHAR(255) PRIMARY KEY,
+    currency VARCHAR(10) NOT NULL,
+    index_month DATE NOT NULL,
+    value DECIMAL(28, 8) NOT NULL,
+    source VARCHAR(20) NOT NULL DEFAULT 'manual',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    modified_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL,
+    modified_by VARCHAR(255) NOT NULL,
+    CONSTRAINT unique_price_index_currency_month UNIQUE (currency, index_month),
+    CONSTRAINT chk_price_indexes_value_positive CHECK (value > 0),
+    CONSTRAINT chk_price_indexes_first_of_month CHECK (EXTRACT(DAY FROM index_month) = 1)
+);
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_transactions_installment_plan_id;
+
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS chk_transactions_installments;
+ALTER TABLE transactions DROP COLUMN IF EXISTS installment_count;
+ALTER TABLE transactions DROP COLUMN IF EXISTS installment_number;
+ALTER TABLE transactions DROP COLUMN IF EXISTS installment_plan_id;
+
+ALTER TABLE wallets DROP CONSTRAINT IF EXISTS chk_wallets_credit_card_terms;
+ALTER TABLE wallets DROP COLUMN IF EXISTS payment_due_day;
+ALTER TABLE wallets DROP COLUMN IF EXISTS statement_closing_day;
+ALTER TABLE wallets DROP COLUMN IF EXISTS credit_limit;
@@ -0,0 +1,29 @@
+-- Credit card wallets bill in cycles: statements close on
+-- statement_closing_day and are due on the next payment_due_day. The three
+-- columns are set together and only on credit card wallets (type 2).
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS credit_limit DECIMAL(38, 18);
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS statement_closing_day INTEGER;
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS payment_due_day INTEGER;
+
+ALTER TABLE wallets DROP CONSTRAINT IF EXISTS chk_wallets_credit_card_terms;
+ALTER TABLE wallets ADD CONSTRAINT chk_wallets_credit_card_terms CHECK (
+    (credit_limit IS NULL AND statement_closing_day IS NULL AND payment_due_day IS NULL)
+    OR (type = 2 AND credit_limit > 0
+        AND statement_closing_day BETWEEN 1 AND 31
+        AND payment_due_day BETWEEN 1 AND 31)
+);
+
+-- A purchase paid in installments ("cuotas") is stored as one expense per
+-- installment, each dated in the month it is billed, sharing
+-- installment_plan_id.
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS installment_plan_id VARCHAR(255);
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS installment_number INTEGER;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS installment_count INTEGER;
+
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS chk_transactions_installments;
+ALTER TABLE transactions ADD CONSTRAINT chk_transactions_installments CHECK (
+    (installment_plan_id IS NULL AND installment_number IS NULL AND installment_count IS NULL)
+    OR (installment_count > 1 AND installment_number BETWEEN 1 AND installment_count)
+);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_installment_plan_id ON transactions(installment_plan_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS investment_income;
+DROP TABLE IF EXISTS investment_trades;
+DROP TABLE IF EXISTS asset_prices;
@@ -0,0 +1,71 @@
+-- price is the closing price of one unit of symbol in currency on
+-- price_date. Prices are shared by all users.
+CREATE TABLE IF NOT EXISTS asset_prices (
+    id VARCHAR(255) PRIMARY KEY,
+    symbol VARCHAR(20) NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    price_date DATE NOT NULL,
+    price DECIMAL(38, 18) NOT NULL,
+    source VARCHAR(20) NOT NULL DEFAULT 'manual',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    modified_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL,
+    modified_by VARCHAR(255) NOT NULL,
+    CONSTRAINT unique_asset_price_symbol_currency_date UNIQUE (symbol, currency, price_date),
+    CONSTRAINT chk_asset_prices_price_positive CHECK (price > 0)
+);
+
+-- Trades buy or sell quantity units of symbol at price, in the currency of
+-- the investment wallet. amount is quantity × price rounded to that
+-- currency; buys take amount + fees out of the wallet and sells pay
+-- amount - fees into it.
+CREATE TABLE IF NOT EXISTS investment_trades (
+    id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    wallet_id VARCHAR(255) NOT NULL,
+    symbol VARCHAR(20) NOT NULL,
+    side INTEGER NOT NULL,
+    quantity DECIMAL(38, 18) NOT NULL,
+    price DECIMAL(38, 18) NOT NULL,
+    amount DECIMAL(38, 18) NOT NULL,
+    fees DECIMAL(38, 18) NOT NULL DEFAULT 0,
+    trade_date DATE NOT NULL,
+    notes VARCHAR(500) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    modified_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL,
+    modified_by VARCHAR(255) NOT NULL,
+    CONSTRAINT fk_investment_trades_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_investment_trades_wallet FOREIGN KEY (wallet_id) REFERENCES wallets(id),
+    CONSTRAINT chk_investment_trades_side CHECK (side IN (0, 1)),
+    CONSTRAINT chk_investment_trades_quantity_positive CHECK (quantity > 0),
+    CONSTRAINT chk_investment_trades_price_positive CHECK (price > 0),
+    CONSTRAINT chk_investment_trades_fees_not_negative CHECK (fees >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_investment_trades_wallet_symbol ON investment_trades(wallet_id, symbol, trade_date);
+CREATE INDEX IF NOT EXISTS idx_investment_trades_user_id ON investment_trades(user_id);
+
+-- Dividends and interest paid into an investment wallet. symbol is empty
+-- for interest that is not tied to a holding, such as cash interest.
+CREATE TABLE IF NOT EXISTS investment_income (
+    id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    wallet_id VARCHAR(255) NOT NULL,
+    symbol VARCHAR(20) NOT NULL DEFAULT '',
+    type INTEGER NOT NULL,
+    amount DECIMAL(38, 18) NOT NULL,
+    income_date DATE NOT NULL,
+    notes VARCHAR(500) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    modified_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL,
+    modified_by VARCHAR(255) NOT NULL,
+    CONSTRAINT fk_investment_income_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_investment_income_wallet FOREIGN KEY (wallet_id) REFERENCES wallets(id),
+    CONSTRAINT chk_investment_income_type CHECK (type IN (0, 1)),
+    CONSTRAINT chk_investment_income_amount_positive CHECK (amount > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_investment_income_wallet_date ON investment_income(wallet_id, income_date);
+CREATE INDEX IF NOT EXISTS idx_investment_income_user_id ON investment_income(user_id);
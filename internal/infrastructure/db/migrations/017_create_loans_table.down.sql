@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS uq_transactions_loan_installment;
+ALTER TABLE transactions DROP COLUMN IF EXISTS loan_installment;
+ALTER TABLE transactions DROP COLUMN IF EXISTS loan_id;
+
+DROP TABLE IF EXISTS loans;
@@ -0,0 +1,42 @@
+-- A loan is money borrowed from (direction 0) or lent to (direction 1) a
+-- counterparty, repaid in term_months monthly installments starting one
+-- month after start_date. annual_rate is the nominal yearly rate in
+-- percent and method is the amortization system: french (equal
+-- payments), german (equal principal) or bullet (interest only, principal
+-- at the end).
+CREATE TABLE IF NOT EXISTS loans (
+    id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    wallet_id VARCHAR(255) NOT NULL,
+    category_id VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    counterparty VARCHAR(255) NOT NULL DEFAULT '',
+    direction INTEGER NOT NULL,
+    principal DECIMAL(38, 18) NOT NULL,
+    annual_rate DECIMAL(38, 18) NOT NULL,
+    term_months INTEGER NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    start_date DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    modified_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL,
+    modified_by VARCHAR(255) NOT NULL,
+    CONSTRAINT fk_loans_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_loans_wallet FOREIGN KEY (wallet_id) REFERENCES wallets(id) ON DELETE CASCADE,
+    CONSTRAINT fk_loans_category FOREIGN KEY (category_id) REFERENCES categories(id),
+    CONSTRAINT chk_loans_direction CHECK (direction IN (0, 1)),
+    CONSTRAINT chk_loans_principal_positive CHECK (principal > 0),
+    CONSTRAINT chk_loans_annual_rate CHECK (annual_rate >= 0),
+    CONSTRAINT chk_loans_term_months CHECK (term_months BETWEEN 1 AND 600),
+    CONSTRAINT chk_loans_method CHECK (method IN ('french', 'german', 'bullet'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_loans_user_id ON loans(user_id);
+
+-- Installment payments are posted as transactions of the loan's wallet
+-- that remember the loan and the installment they pay. The unique index
+-- keeps an installment from being paid twice.
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS loan_id VARCHAR(255) CONSTRAINT fk_transactions_loan REFERENCES loans(id) ON DELETE SET NULL;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS loan_installment INTEGER;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_transactions_loan_installment ON transactions(loan_id, loan_installment) WHERE loan_id IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS savings_goal_wallets;
+DROP TABLE IF EXISTS savings_goals;
@@ -0,0 +1,29 @@
+-- A savings goal is target_amount in currency to be reached by deadline
+-- with the balances of the savings wallets linked to it. Wallets in other
+-- currencies are converted to the goal currency.
+CREATE TABLE IF NOT EXISTS savings_goals (
+    id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    target_amount DECIMAL(38, 18) NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    deadline DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    modified_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL,
+    modified_by VARCHAR(255) NOT NULL,
+    CONSTRAINT fk_savings_goals_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT chk_savings_goals_target_positive CHECK (target_amount > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_savings_goals_user_id ON savings_goals(user_id);
+
+CREATE TABLE IF NOT EXISTS savings_goal_wallets (
+    goal_id VARCHAR(255) NOT NULL,
+    wallet_id VARCHAR(255) NOT NULL,
+    PRIMARY KEY (goal_id, wallet_id),
+    CONSTRAINT fk_savings_goal_wallets_goal FOREIGN KEY (goal_id) REFERENCES savings_goals(id) ON DELETE CASCADE,
+    CONSTRAINT fk_savings_goal_wallets_wallet FOREIGN KEY (wallet_id) REFERENCES wallets(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_savings_goal_wallets_wallet_id ON savings_goal_wallets(wallet_id);
@@ -0,0 +1,10 @@
+-- parent_id nests a category under another one of the same user and type.
+-- Deleting a parent is handled by the application, which reparents,
+-- deletes or keeps the subcategories as asked, so the reference has no ON
+-- DELETE action.
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS parent_id VARCHAR(255) CONSTRAINT fk_categories_parent REFERENCES categories(id);
+
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS chk_categories_parent_not_self;
+ALTER TABLE categories ADD CONSTRAINT chk_categories_parent_not_self CHECK (parent_id IS NULL OR parent_id <> id);
+
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories(parent_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS categorization_rules;
+ALTER TABLE transactions DROP COLUMN IF EXISTS tags;
+ALTER TABLE transactions DROP COLUMN IF EXISTS payee;
@@ -0,0 +1,35 @@
+-- payee is the cleaned-up name of who was paid or who paid, as opposed to
+-- the raw description, and tags label transactions across categories.
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS payee VARCHAR(255);
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
+-- A categorization rule assigns a category, a payee and tags to the
+-- expenses and income that meet all of its conditions. Rules are evaluated
+-- in priority order, lowest first.
+CREATE TABLE IF NOT EXISTS categorization_rules (
+    id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    priority INTEGER NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    description_contains VARCHAR(255),
+    payee_contains VARCHAR(255),
+    min_amount DECIMAL(38, 18),
+    max_amount DECIMAL(38, 18),
+    wallet_id VARCHAR(255),
+    currency VARCHAR(10),
+    category_id VARCHAR(255),
+    set_payee VARCHAR(255),
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    modified_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL,
+    modified_by VARCHAR(255) NOT NULL,
+    CONSTRAINT fk_categorization_rules_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_categorization_rules_wallet FOREIGN KEY (wallet_id) REFERENCES wallets(id) ON DELETE CASCADE,
+    CONSTRAINT fk_categorization_rules_category FOREIGN KEY (category_id) REFERENCES categories(id),
+    CONSTRAINT chk_categorization_rules_amount_range CHECK (min_amount IS NULL OR max_amount IS NULL OR min_amount <= max_amount),
+    CONSTRAINT chk_categorization_rules_action CHECK (category_id IS NOT NULL OR set_payee IS NOT NULL OR cardinality(tags) > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_categorization_rules_user_priority ON categorization_rules(user_id, priority);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_transactions_import_batch_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS import_batch_id;
+
+DROP TABLE IF EXISTS import_batches;
+DROP TABLE IF EXISTS import_profiles;
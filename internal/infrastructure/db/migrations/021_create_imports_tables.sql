@@ -0,0 +1,61 @@
+-- An import profile describes how the CSV statements of a bank are laid
+-- out. Columns are numbered from 1 and 0 means the column is not used.
+-- Amounts come either signed in amount_column (money in positive) or split
+-- between debit_column and credit_column. date_format uses DD, MM, YY and
+-- YYYY, such as DD/MM/YYYY.
+CREATE TABLE IF NOT EXISTS import_profiles (
+    id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    delimiter VARCHAR(1) NOT NULL DEFAULT ',',
+    encoding VARCHAR(20) NOT NULL DEFAULT 'utf-8',
+    skip_rows INTEGER NOT NULL DEFAULT 0,
+    has_header BOOLEAN NOT NULL DEFAULT TRUE,
+    date_column INTEGER NOT NULL,
+    date_format VARCHAR(20) NOT NULL,
+    description_column INTEGER NOT NULL,
+    payee_column INTEGER NOT NULL DEFAULT 0,
+    amount_column INTEGER NOT NULL DEFAULT 0,
+    debit_column INTEGER NOT NULL DEFAULT 0,
+    credit_column INTEGER NOT NULL DEFAULT 0,
+    decimal_separator VARCHAR(1) NOT NULL DEFAULT '.',
+    negate_amounts BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    modified_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL,
+    modified_by VARCHAR(255) NOT NULL,
+    CONSTRAINT fk_import_profiles_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT chk_import_profiles_encoding CHECK (encoding IN ('utf-8', 'iso-8859-1', 'windows-1252')),
+    CONSTRAINT chk_import_profiles_decimal_separator CHECK (decimal_separator IN ('.', ',')),
+    CONSTRAINT chk_import_profiles_amount_columns CHECK (amount_column > 0 OR (debit_column > 0 AND credit_column > 0))
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_profiles_user_id ON import_profiles(user_id);
+
+-- An import batch is one statement committed into a wallet. Undoing it
+-- deletes its transactions and keeps the batch with status 'undone'.
+CREATE TABLE IF NOT EXISTS import_batches (
+    id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    wallet_id VARCHAR(255) NOT NULL,
+    profile_id VARCHAR(255),
+    format VARCHAR(10) NOT NULL,
+    file_name VARCHAR(255) NOT NULL DEFAULT '',
+    transaction_count INTEGER NOT NULL,
+    status VARCHAR(10) NOT NULL,
+    undone_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    modified_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL,
+    modified_by VARCHAR(255) NOT NULL,
+    CONSTRAINT fk_import_batches_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_import_batches_wallet FOREIGN KEY (wallet_id) REFERENCES wallets(id) ON DELETE CASCADE,
+    CONSTRAINT fk_import_batches_profile FOREIGN KEY (profile_id) REFERENCES import_profiles(id) ON DELETE SET NULL,
+    CONSTRAINT chk_import_batches_status CHECK (status IN ('committed', 'undone'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_batches_user_id ON import_batches(user_id);
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS import_batch_id VARCHAR(255) CONSTRAINT fk_transactions_import_batch REFERENCES import_batches(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_import_batch_id ON transactions(import_batch_id) WHERE import_batch_id IS NOT NULL;
@@ -0,0 +1,8 @@
+DELETE FROM import_batches WHERE wallet_id IS NULL;
+ALTER TABLE import_batches ALTER COLUMN wallet_id SET NOT NULL;
+
+DROP INDEX IF EXISTS uq_transactions_wallet_external_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS external_id;
+
+DROP INDEX IF EXISTS uq_wallets_user_account_id;
+ALTER TABLE wallets DROP COLUMN IF EXISTS account_id;
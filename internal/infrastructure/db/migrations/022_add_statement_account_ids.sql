@@ -0,0 +1,16 @@
+-- account_id links a wallet to the bank account OFX and QIF files name, so
+-- their statements land in the right wallet.
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS account_id VARCHAR(64);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_wallets_user_account_id ON wallets(user_id, account_id) WHERE account_id IS NOT NULL;
+
+-- external_id is the id the bank gave an imported transaction (the FITID
+-- of OFX files). A wallet holds each one once, so re-importing a statement
+-- skips what is already there.
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS external_id VARCHAR(255);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_transactions_wallet_external_id ON transactions(wallet_id, external_id) WHERE external_id IS NOT NULL;
+
+-- A file with several accounts is imported as a single batch spread over
+-- several wallets, which leaves the batch without a wallet of its own.
+ALTER TABLE import_batches ALTER COLUMN wallet_id DROP NOT NULL;
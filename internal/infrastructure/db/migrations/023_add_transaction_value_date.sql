@@ -0,0 +1,4 @@
+-- value_date is the day an imported entry started or stopped earning
+-- interest, which camt.053 and MT940 statements report next to the day it
+-- was booked.
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS value_date DATE;
@@ -0,0 +1,15 @@
+-- A duplicate dismissal records two transactions the user decided are not
+-- duplicates of each other, so they are not suggested again. The ids are
+-- stored in order and go away with either transaction.
+CREATE TABLE IF NOT EXISTS duplicate_dismissals (
+    user_id VARCHAR(255) NOT NULL,
+    first_transaction_id VARCHAR(255) NOT NULL,
+    second_transaction_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL,
+    PRIMARY KEY (user_id, first_transaction_id, second_transaction_id),
+    CONSTRAINT fk_duplicate_dismissals_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_duplicate_dismissals_first FOREIGN KEY (first_transaction_id) REFERENCES transactions(id) ON DELETE CASCADE,
+    CONSTRAINT fk_duplicate_dismissals_second FOREIGN KEY (second_transaction_id) REFERENCES transactions(id) ON DELETE CASCADE,
+    CONSTRAINT chk_duplicate_dismissals_order CHECK (first_transaction_id < second_transaction_id)
+);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_transactions_reconciliation_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS reconciled;
+ALTER TABLE transactions DROP COLUMN IF EXISTS reconciliation_id;
+
+DROP TABLE IF EXISTS reconciliations;
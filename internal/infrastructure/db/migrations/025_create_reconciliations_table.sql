@@ -0,0 +1,32 @@
+-- A reconciliation checks a wallet against a bank statement: the balance
+-- the statement reports on statement_date. It stays open while the user
+-- clears transactions and is locked once they add up to ending_balance. A
+-- wallet has at most one open reconciliation.
+CREATE TABLE IF NOT EXISTS reconciliations (
+    id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    wallet_id VARCHAR(255) NOT NULL,
+    statement_date DATE NOT NULL,
+    ending_balance DECIMAL(38, 18) NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'open',
+    locked_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    modified_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_by VARCHAR(255) NOT NULL,
+    modified_by VARCHAR(255) NOT NULL,
+    CONSTRAINT fk_reconciliations_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_reconciliations_wallet FOREIGN KEY (wallet_id) REFERENCES wallets(id) ON DELETE CASCADE,
+    CONSTRAINT chk_reconciliations_status CHECK (status IN ('open', 'locked'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_reconciliations_user_id ON reconciliations(user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_reconciliations_open_wallet ON reconciliations(wallet_id) WHERE status = 'open';
+
+-- reconciliation_id is the reconciliation a transaction cleared in, and
+-- reconciled is set once that reconciliation is locked. Reconciled
+-- transactions keep their wallet, type, amount and date and cannot be
+-- deleted.
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS reconciliation_id VARCHAR(255) CONSTRAINT fk_transactions_reconciliation REFERENCES reconciliations(id) ON DELETE SET NULL;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS reconciled BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_reconciliation_id ON transactions(reconciliation_id);
@@ -0,0 +1,3 @@
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS destination_wallet_id VARCHAR(255) CONSTRAINT fk_transactions_destination_wallet REFERENCES wallets(id);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_destination_wallet_id ON transactions(destination_wallet_id);
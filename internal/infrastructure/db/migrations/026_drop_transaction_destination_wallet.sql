@@ -0,0 +1,5 @@
+-- Transfers are stored as two legs since 006, which left
+-- destination_wallet_id always NULL.
+DROP INDEX IF EXISTS idx_transactions_destination_wallet_id;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS fk_transactions_destination_wallet;
+ALTER TABLE transactions DROP COLUMN IF EXISTS destination_wallet_id;
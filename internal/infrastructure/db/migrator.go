@@ -0,0 +1,380 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// migrationLockKey identifies the advisory lock held while migrating. Every
+// instance uses the same key, so concurrent deploys run one after another.
+const migrationLockKey int64 = 4_621_907_113_385
+
+var (
+	ErrChecksumMismatch      = errors.New("applied migration has been modified")
+	ErrUnknownMigration      = errors.New("applied migration is not known to this build")
+	ErrIrreversibleMigration = errors.New("migration has no down script")
+	ErrInvalidSteps          = errors.New("steps must be greater than zero")
+	ErrInvalidTargetVersion  = errors.New("target version does not exist")
+)
+
+// AppliedMigration is a row of schema_migrations.
+type AppliedMigration struct {
+	Version   int
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// MigrationStatus describes one migration as seen by Status.
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+	// Modified is set when the script changed after it was applied.
+	Modified bool
+	// Missing is set when schema_migrations lists a version this build
+	// does not ship.
+	Missing bool
+}
+
+func (s MigrationStatus) Applied() bool {
+	return s.AppliedAt != nil
+}
+
+type Migrator struct {
+	pool       *pgxpool.Pool
+	migrations []Migration
+}
+
+// NewMigrator returns a migrator for the migrations embedded in the binary.
+func NewMigrator(pool *pgxpool.Pool) (*Migrator, error) {
+	migrations, err := EmbeddedMigrations()
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{pool: pool, migrations: migrations}, nil
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.withLock(ctx, func(conn *pgxpool.Conn, applied map[int]AppliedMigration) error {
+		if err := verifyApplied(m.migrations, applied); err != nil {
+			return err
+		}
+		return m.applyAll(ctx, conn, pendingUpTo(m.migrations, applied, latestVersion(m.migrations)))
+	})
+}
+
+// Down rolls back the given number of most recently applied migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	if steps <= 0 {
+		return ErrInvalidSteps
+	}
+
+	return m.withLock(ctx, func(conn *pgxpool.Conn, applied map[int]AppliedMigration) error {
+		if err := verifyApplied(m.migrations, applied); err != nil {
+			return err
+		}
+
+		versions := appliedVersionsDesc(applied)
+		if steps > len(versions) {
+			steps = len(versions)
+		}
+
+		rollback, err := rollbackAbove(m.migrations, applied, targetAfterSteps(versions, steps))
+		if err != nil {
+			return err
+		}
+		return m.revertAll(ctx, conn, rollback)
+	})
+}
+
+// To migrates up or down until exactly the migrations up to and including
+// version are applied. Version 0 rolls back everything.
+func (m *Migrator) To(ctx context.Context, version int) error {
+	if version != 0 && !hasVersion(m.migrations, version) {
+		return fmt.Errorf("%w: %d", ErrInvalidTargetVersion, version)
+	}
+
+	return m.withLock(ctx, func(conn *pgxpool.Conn, applied map[int]AppliedMigration) error {
+		if err := verifyApplied(m.migrations, applied); err != nil {
+			return err
+		}
+
+		rollback, err := rollbackAbove(m.migrations, applied, version)
+		if err != nil {
+			return err
+		}
+		if err := m.revertAll(ctx, conn, rollback); err != nil {
+			return err
+		}
+		return m.applyAll(ctx, conn, pendingUpTo(m.migrations, applied, version))
+	})
+}
+
+// Status lists every known or applied migration, oldest first. It only
+// reads schema_migrations, without the migration lock, so it does not wait
+// for a running migration; a database without the table has nothing
+// applied.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	applied, err := loadApplied(ctx, conn)
+	if isUndefinedTable(err) {
+		applied, err = map[int]AppliedMigration{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return buildStatus(m.migrations, applied), nil
+}
+
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *pgxpool.Conn, applied map[int]AppliedMigration) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, migrationLockKey); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockKey); err != nil {
+			log.Printf("failed to release migration lock: %v", err)
+		}
+	}()
+
+	createTable := `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			checksum VARCHAR(64) NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)
+	`
+	if _, err := conn.Exec(ctx, createTable); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	applied, err := loadApplied(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	return fn(conn, applied)
+}
+
+// isUndefinedTable reports whether err is PostgreSQL's undefined_table
+// error.
+func isUndefinedTable(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "42P01"
+}
+
+func loadApplied(ctx context.Context, conn *pgxpool.Conn) (map[int]AppliedMigration, error) {
+	rows, err := conn.Query(ctx, `SELECT version, name, checksum, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]AppliedMigration)
+	for rows.Next() {
+		var migration AppliedMigration
+		if err := rows.Scan(&migration.Version, &migration.Name, &migration.Checksum, &migration.AppliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan schema_migrations: %w", err)
+		}
+		applied[migration.Version] = migration
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate schema_migrations: %w", err)
+	}
+
+	return applied, nil
+}
+
+func (m *Migrator) applyAll(ctx context.Context, conn *pgxpool.Conn, migrations []Migration) error {
+	for _, migration := range migrations {
+		log.Printf("Applying migration %s", migration)
+
+		tx, err := conn.Begin(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to begin migration %s: %w", migration, err)
+		}
+
+		if _, err := tx.Exec(ctx, migration.UpSQL); err != nil {
+			_ = tx.Rollback(ctx)
+			return fmt.Errorf("failed to apply migration %s: %w", migration, err)
+		}
+
+		insert := `INSERT INTO schema_migrations (version, name, checksum, applied_at) VALUES ($1, $2, $3, $4)`
+		if _, err := tx.Exec(ctx, insert, migration.Version, migration.Name, migration.Checksum, time.Now().UTC()); err != nil {
+			_ = tx.Rollback(ctx)
+			return fmt.Errorf("failed to record migration %s: %w", migration, err)
+		}
+
+		if err := tx.Commit(ctx); err != nil {
+			return fmt.Errorf("failed to commit migration %s: %w", migration, err)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) revertAll(ctx context.Context, conn *pgxpool.Conn, migrations []Migration) error {
+	for _, migration := range migrations {
+		log.Printf("Reverting migration %s", migration)
+
+		tx, err := conn.Begin(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to begin rollback of %s: %w", migration, err)
+		}
+
+		if _, err := tx.Exec(ctx, migration.DownSQL); err != nil {
+			_ = tx.Rollback(ctx)
+			return fmt.Errorf("failed to revert migration %s: %w", migration, err)
+		}
+
+		if _, err := tx.Exec(ctx, `DELETE FROM schema_migrations WHERE version = $1`, migration.Version); err != nil {
+			_ = tx.Rollback(ctx)
+			return fmt.Errorf("failed to unrecord migration %s: %w", migration, err)
+		}
+
+		if err := tx.Commit(ctx); err != nil {
+			return fmt.Errorf("failed to commit rollback of %s: %w", migration, err)
+		}
+	}
+	return nil
+}
+
+// verifyApplied refuses to migrate a database whose history does not match
+// the scripts in this build.
+func verifyApplied(migrations []Migration, applied map[int]AppliedMigration) error {
+	known := make(map[int]Migration, len(migrations))
+	for _, migration := range migrations {
+		known[migration.Version] = migration
+	}
+
+	for _, version := range appliedVersionsDesc(applied) {
+		migration, ok := known[version]
+		if !ok {
+			return fmt.Errorf("%w: version %d (%s)", ErrUnknownMigration, version, applied[version].Name)
+		}
+		if migration.Checksum != applied[version].Checksum {
+			return fmt.Errorf("%w: %s", ErrChecksumMismatch, migration)
+		}
+	}
+	return nil
+}
+
+// pendingUpTo returns the unapplied migrations up to and including target,
+// oldest first.
+func pendingUpTo(migrations []Migration, applied map[int]AppliedMigration, target int) []Migration {
+	var pending []Migration
+	for _, migration := range migrations {
+		if migration.Version > target {
+			break
+		}
+		if _, ok := applied[migration.Version]; !ok {
+			pending = append(pending, migration)
+		}
+	}
+	return pending
+}
+
+// rollbackAbove returns the applied migrations newer than target, newest
+// first. It fails before anything runs if one of them cannot be reverted.
+func rollbackAbove(migrations []Migration, applied map[int]AppliedMigration, target int) ([]Migration, error) {
+	var rollback []Migration
+	for i := len(migrations) - 1; i >= 0; i-- {
+		migration := migrations[i]
+		if migration.Version <= target {
+			break
+		}
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		if !migration.HasDown() {
+			return nil, fmt.Errorf("%w: %s", ErrIrreversibleMigration, migration)
+		}
+		rollback = append(rollback, migration)
+	}
+	return rollback, nil
+}
+
+func buildStatus(migrations []Migration, applied map[int]AppliedMigration) []MigrationStatus {
+	seen := make(map[int]bool, len(migrations))
+	statuses := make([]MigrationStatus, 0, len(migrations))
+
+	for _, migration := range migrations {
+		seen[migration.Version] = true
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if record, ok := applied[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.AppliedAt = &appliedAt
+			status.Modified = record.Checksum != migration.Checksum
+		}
+		statuses = append(statuses, status)
+	}
+
+	for version, record := range applied {
+		if seen[version] {
+			continue
+		}
+		appliedAt := record.AppliedAt
+		statuses = append(statuses, MigrationStatus{Version: version, Name: record.Name, AppliedAt: &appliedAt, Missing: true})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+
+	return statuses
+}
+
+func appliedVersionsDesc(applied map[int]AppliedMigration) []int {
+	versions := make([]int, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(versions)))
+	return versions
+}
+
+// targetAfterSteps returns the version left as the newest applied one after
+// rolling back steps migrations from versions (sorted newest first).
+func targetAfterSteps(versions []int, steps int) int {
+	if steps >= len(versions) {
+		return 0
+	}
+	return versions[steps]
+}
+
+func latestVersion(migrations []Migration) int {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+func hasVersion(migrations []Migration, version int) bool {
+	for _, migration := range migrations {
+		if migration.Version == version {
+			return true
+		}
+	}
+	return false
+}
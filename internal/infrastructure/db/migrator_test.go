@@ -0,0 +1,266 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func testMigrations(t *testing.T) []Migration {
+	t.Helper()
+
+	migrations, err := LoadMigrations(fstest.MapFS{
+		"001_create_users.sql":      {Data: []byte("CREATE TABLE users (id TEXT);")},
+		"001_create_users.down.sql": {Data: []byte("DROP TABLE users;")},
+		"002_add_email.sql":         {Data: []byte("ALTER TABLE users ADD COLUMN email TEXT;")},
+		"002_add_email.down.sql":    {Data: []byte("ALTER TABLE users DROP COLUMN email;")},
+		"003_backfill.sql":          {Data: []byte("UPDATE users SET email = '';")},
+		"README.md":                 {Data: []byte("not a migration")},
+	})
+	if err != nil {
+		t.Fatalf("LoadMigrations failed: %v", err)
+	}
+	return migrations
+}
+
+func appliedFor(migrations []Migration, versions ...int) map[int]AppliedMigration {
+	applied := make(map[int]AppliedMigration)
+	for _, version := range versions {
+		for _, migration := range migrations {
+			if migration.Version == version {
+				applied[version] = AppliedMigration{
+					Version:   version,
+					Name:      migration.Name,
+					Checksum:  migration.Checksum,
+					AppliedAt: time.Date(2026, 1, version, 0, 0, 0, 0, time.UTC),
+				}
+			}
+		}
+	}
+	return applied
+}
+
+func versionsOf(migrations []Migration) []int {
+	versions := make([]int, len(migrations))
+	for i, migration := range migrations {
+		versions[i] = migration.Version
+	}
+	return versions
+}
+
+func equalVersions(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestLoadMigrations(t *testing.T) {
+	migrations := testMigrations(t)
+
+	if got := versionsOf(migrations); !equalVersions(got, []int{1, 2, 3}) {
+		t.Fatalf("expected versions [1 2 3], got %v", got)
+	}
+	if migrations[0].Name != "create_users" || migrations[0].String() != "001_create_users" {
+		t.Errorf("unexpected name %q", migrations[0].String())
+	}
+	if !migrations[1].HasDown() {
+		t.Error("expected 002 to have a down script")
+	}
+	if migrations[2].HasDown() {
+		t.Error("expected 003 to have no down script")
+	}
+	if len(migrations[0].Checksum) != 64 || migrations[0].Checksum == migrations[1].Checksum {
+		t.Errorf("unexpected checksums %q and %q", migrations[0].Checksum, migrations[1].Checksum)
+	}
+}
+
+func TestLoadMigrations_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		files fstest.MapFS
+	}{
+		{"missing version", fstest.MapFS{"create_users.sql": {Data: []byte("SELECT 1;")}}},
+		{"zero version", fstest.MapFS{"000_init.sql": {Data: []byte("SELECT 1;")}}},
+		{"duplicate version", fstest.MapFS{
+			"001_users.sql":  {Data: []byte("SELECT 1;")},
+			"001_wallet.sql": {Data: []byte("SELECT 2;")},
+		}},
+		{"down without up", fstest.MapFS{"001_users.down.sql": {Data: []byte("SELECT 1;")}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadMigrations(tt.files); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := EmbeddedMigrations()
+	if err != nil {
+		t.Fatalf("EmbeddedMigrations failed: %v", err)
+	}
+
+	if len(migrations) == 0 {
+		t.Fatal("expected embedded migrations")
+	}
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			t.Errorf("expected version %d, got %s", i+1, migration)
+		}
+		if !migration.HasDown() {
+			t.Errorf("expected %s to have a down script", migration)
+		}
+	}
+}
+
+func TestVerifyApplied(t *testing.T) {
+	migrations := testMigrations(t)
+
+	if err := verifyApplied(migrations, appliedFor(migrations, 1, 2)); err != nil {
+		t.Errorf("expected matching history to verify, got %v", err)
+	}
+
+	modified := appliedFor(migrations, 1, 2)
+	record := modified[2]
+	record.Checksum = "edited"
+	modified[2] = record
+	if err := verifyApplied(migrations, modified); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("expected ErrChecksumMismatch, got %v", err)
+	}
+
+	unknown := appliedFor(migrations, 1)
+	unknown[7] = AppliedMigration{Version: 7, Name: "from_the_future", Checksum: "abc"}
+	if err := verifyApplied(migrations, unknown); !errors.Is(err, ErrUnknownMigration) {
+		t.Errorf("expected ErrUnknownMigration, got %v", err)
+	}
+}
+
+func TestPendingUpTo(t *testing.T) {
+	migrations := testMigrations(t)
+
+	tests := []struct {
+		name    string
+		applied map[int]AppliedMigration
+		target  int
+		want    []int
+	}{
+		{"fresh database", appliedFor(migrations), 3, []int{1, 2, 3}},
+		{"partially applied", appliedFor(migrations, 1), 3, []int{2, 3}},
+		{"gap is filled", appliedFor(migrations, 1, 3), 3, []int{2}},
+		{"up to target", appliedFor(migrations), 2, []int{1, 2}},
+		{"up to date", appliedFor(migrations, 1, 2, 3), 3, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := versionsOf(pendingUpTo(migrations, tt.applied, tt.target))
+			if !equalVersions(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRollbackAbove(t *testing.T) {
+	migrations := testMigrations(t)
+
+	got, err := rollbackAbove(migrations, appliedFor(migrations, 1, 2), 0)
+	if err != nil {
+		t.Fatalf("rollbackAbove failed: %v", err)
+	}
+	if !equalVersions(versionsOf(got), []int{2, 1}) {
+		t.Errorf("expected newest first [2 1], got %v", versionsOf(got))
+	}
+
+	got, err = rollbackAbove(migrations, appliedFor(migrations, 1, 2), 1)
+	if err != nil {
+		t.Fatalf("rollbackAbove failed: %v", err)
+	}
+	if !equalVersions(versionsOf(got), []int{2}) {
+		t.Errorf("expected [2], got %v", versionsOf(got))
+	}
+
+	if _, err := rollbackAbove(migrations, appliedFor(migrations, 1, 2, 3), 1); !errors.Is(err, ErrIrreversibleMigration) {
+		t.Errorf("expected ErrIrreversibleMigration, got %v", err)
+	}
+}
+
+func TestTargetAfterSteps(t *testing.T) {
+	versions := []int{5, 3, 2}
+
+	tests := []struct {
+		steps int
+		want  int
+	}{
+		{1, 3},
+		{2, 2},
+		{3, 0},
+		{10, 0},
+	}
+
+	for _, tt := range tests {
+		if got := targetAfterSteps(versions, tt.steps); got != tt.want {
+			t.Errorf("targetAfterSteps(%d) = %d, want %d", tt.steps, got, tt.want)
+		}
+	}
+}
+
+func TestBuildStatus(t *testing.T) {
+	migrations := testMigrations(t)
+
+	applied := appliedFor(migrations, 1, 2)
+	record := applied[2]
+	record.Checksum = "edited"
+	applied[2] = record
+	applied[9] = AppliedMigration{Version: 9, Name: "removed", Checksum: "abc"}
+
+	statuses := buildStatus(migrations, applied)
+
+	if len(statuses) != 4 {
+		t.Fatalf("expected 4 statuses, got %d", len(statuses))
+	}
+	if !statuses[0].Applied() || statuses[0].Modified {
+		t.Errorf("expected 001 applied and unmodified, got %+v", statuses[0])
+	}
+	if !statuses[1].Modified {
+		t.Errorf("expected 002 to be reported as modified, got %+v", statuses[1])
+	}
+	if statuses[2].Applied() {
+		t.Errorf("expected 003 to be pending, got %+v", statuses[2])
+	}
+	if statuses[3].Version != 9 || !statuses[3].Missing {
+		t.Errorf("expected 009 to be reported as missing, got %+v", statuses[3])
+	}
+}
+
+func TestIsUndefinedTable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"missing table", fmt.Errorf("failed to read schema_migrations: %w", &pgconn.PgError{Code: "42P01"}), true},
+		{"other database error", &pgconn.PgError{Code: "42501"}, false},
+		{"other error", errors.New("connection refused"), false},
+		{"no error", nil, false},
+	}
+
+	for _, tt := range tests {
+		if got := isUndefinedTable(tt.err); got != tt.want {
+			t.Errorf("%s: isUndefinedTable() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"fin-flow-api/internal/infrastructure/config"
+	jwtinterface "fin-flow-api/internal/shared/interface/jwt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrIssuerNotConfigured    = errors.New("clerk issuer is not configured")
+	ErrInvalidAuthorizedParty = errors.New("token azp claim is not an authorized party")
+	ErrMissingSubject         = errors.New("token has no subject")
+)
+
+// clockSkew is the leeway allowed on exp, nbf and iat.
+const clockSkew = 5 * time.Second
+
+var asymmetricMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+type ClerkVerifier struct {
+	keys              *KeySet
+	issuer            string
+	audiences         []string
+	authorizedParties []string
+	now               func() time.Time
+}
+
+type clerkClaims struct {
+	AuthorizedParty string `json:"azp"`
+	FirstName       string `json:"first_name"`
+	GivenName       string `json:"given_name"`
+	LastName        string `json:"last_name"`
+	FamilyName      string `json:"family_name"`
+	Email           string `json:"email"`
+	jwt.RegisteredClaims
+}
+
+func NewClerkVerifier(keys *KeySet, issuer string, audiences, authorizedParties []string) *ClerkVerifier {
+	return &ClerkVerifier{
+		keys:              keys,
+		issuer:            issuer,
+		audiences:         audiences,
+		authorizedParties: authorizedParties,
+		now:               time.Now,
+	}
+}
+
+// NewClerkVerifierFromConfig builds a verifier whose keys come from the
+// configured JWKS file, or from the JWKS URL when no file is set.
+func NewClerkVerifierFromConfig(cfg config.ClerkConfig) jwtinterface.IdentityVerifier {
+	var source KeySource
+	if cfg.JWKSFile != "" {
+		source = NewFileKeySource(cfg.JWKSFile)
+	} else {
+		source = NewHTTPKeySource(cfg.JWKSURL, nil)
+	}
+	return NewClerkVerifier(NewKeySet(source), cfg.Issuer, cfg.Audiences, cfg.AuthorizedParties)
+}
+
+// Verify checks the token signature against the issuer's keys and
+// validates exp, nbf, iat, iss and, when configured, aud and azp.
+func (v *ClerkVerifier) Verify(ctx context.Context, tokenString string) (*jwtinterface.IdentityClaims, error) {
+	if v.issuer == "" {
+		return nil, ErrIssuerNotConfigured
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(asymmetricMethods),
+		jwt.WithIssuer(v.issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(clockSkew),
+		jwt.WithTimeFunc(v.now),
+	}
+	if len(v.audiences) > 0 {
+		options = append(options, jwt.WithAudience(v.audiences...))
+	}
+
+	claims := &clerkClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return v.keys.Key(ctx, kid)
+	}, options...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clerk token: %w", err)
+	}
+
+	if len(v.authorizedParties) > 0 && !slices.Contains(v.authorizedParties, claims.AuthorizedParty) {
+		return nil, ErrInvalidAuthorizedParty
+	}
+
+	if claims.Subject == "" {
+		return nil, ErrMissingSubject
+	}
+
+	identity := &jwtinterface.IdentityClaims{
+		Subject:   claims.Subject,
+		FirstName: claims.FirstName,
+		LastName:  claims.LastName,
+		Email:     claims.Email,
+	}
+	if identity.FirstName == "" {
+		identity.FirstName = claims.GivenName
+	}
+	if identity.LastName == "" {
+		identity.LastName = claims.FamilyName
+	}
+
+	return identity, nil
+}
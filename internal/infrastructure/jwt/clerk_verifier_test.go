@@ -0,0 +1,430 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testIssuer = "https://clerk.example.com"
+
+type testKey struct {
+	kid string
+	rsa *rsa.PrivateKey
+	ec  *ecdsa.PrivateKey
+}
+
+func newRSAKey(t *testing.T, kid string) testKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	return testKey{kid: kid, rsa: key}
+}
+
+func newECKey(t *testing.T, kid string) testKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+	return testKey{kid: kid, ec: key}
+}
+
+func (k testKey) jwk() map[string]string {
+	encode := func(i *big.Int) string {
+		return base64.RawURLEncoding.EncodeToString(i.Bytes())
+	}
+	if k.rsa != nil {
+		return map[string]string{
+			"kty": "RSA",
+			"kid": k.kid,
+			"use": "sig",
+			"n":   encode(k.rsa.N),
+			"e":   encode(big.NewInt(int64(k.rsa.E))),
+		}
+	}
+	return map[string]string{
+		"kty": "EC",
+		"kid": k.kid,
+		"crv": "P-256",
+		"x":   encode(k.ec.X),
+		"y":   encode(k.ec.Y),
+	}
+}
+
+func (k testKey) sign(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+
+	var token *jwt.Token
+	var signingKey interface{}
+	if k.rsa != nil {
+		token = jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		signingKey = k.rsa
+	} else {
+		token = jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+		signingKey = k.ec
+	}
+	token.Header["kid"] = k.kid
+
+	signed, err := token.SignedString(signingKey)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed
+}
+
+func jwksDocument(t *testing.T, keys ...testKey) []byte {
+	t.Helper()
+
+	jwks := struct {
+		Keys []map[string]string `json:"keys"`
+	}{}
+	for _, key := range keys {
+		jwks.Keys = append(jwks.Keys, key.jwk())
+	}
+
+	data, err := json.Marshal(jwks)
+	if err != nil {
+		t.Fatalf("failed to encode JWKS: %v", err)
+	}
+	return data
+}
+
+// staticKeySource serves data, or fails with err. When release is set,
+// fetches wait for it to be closed.
+type staticKeySource struct {
+	mu      sync.Mutex
+	data    []byte
+	err     error
+	release chan struct{}
+	fetches int
+}
+
+func (s *staticKeySource) FetchKeys(ctx context.Context) ([]byte, error) {
+	s.mu.Lock()
+	s.fetches++
+	data, err, release := s.data, s.err, s.release
+	s.mu.Unlock()
+
+	if release != nil {
+		<-release
+	}
+	return data, err
+}
+
+func (s *staticKeySource) fetchCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fetches
+}
+
+func (s *staticKeySource) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+func validClaims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"sub":        "user_2abc",
+		"iss":        testIssuer,
+		"aud":        "finflow-api",
+		"azp":        "http://localhost:3000",
+		"iat":        now.Unix(),
+		"nbf":        now.Unix(),
+		"exp":        now.Add(time.Minute).Unix(),
+		"first_name": "Ada",
+		"last_name":  "Lovelace",
+		"email":      "ada@example.com",
+	}
+}
+
+func TestClerkVerifier_ValidToken(t *testing.T) {
+	rsaKey := newRSAKey(t, "rsa-1")
+	ecKey := newECKey(t, "ec-1")
+	verifier := NewClerkVerifier(NewKeySet(&staticKeySource{data: jwksDocument(t, rsaKey, ecKey)}), testIssuer, []string{"finflow-api"}, []string{"http://localhost:3000"})
+
+	for _, key := range []testKey{rsaKey, ecKey} {
+		t.Run(key.kid, func(t *testing.T) {
+			identity, err := verifier.Verify(context.Background(), key.sign(t, validClaims()))
+			if err != nil {
+				t.Fatalf("Verify failed: %v", err)
+			}
+			if identity.Subject != "user_2abc" || identity.FirstName != "Ada" || identity.LastName != "Lovelace" || identity.Email != "ada@example.com" {
+				t.Errorf("unexpected identity %+v", identity)
+			}
+		})
+	}
+}
+
+func TestClerkVerifier_GivenAndFamilyNameFallback(t *testing.T) {
+	key := newRSAKey(t, "rsa-1")
+	verifier := NewClerkVerifier(NewKeySet(&staticKeySource{data: jwksDocument(t, key)}), testIssuer, nil, nil)
+
+	claims := validClaims()
+	delete(claims, "first_name")
+	delete(claims, "last_name")
+	claims["given_name"] = "Grace"
+	claims["family_name"] = "Hopper"
+
+	identity, err := verifier.Verify(context.Background(), key.sign(t, claims))
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if identity.FirstName != "Grace" || identity.LastName != "Hopper" {
+		t.Errorf("unexpected names %q %q", identity.FirstName, identity.LastName)
+	}
+}
+
+func TestClerkVerifier_RejectsInvalidTokens(t *testing.T) {
+	key := newRSAKey(t, "rsa-1")
+	forger := newRSAKey(t, "rsa-1")
+	verifier := NewClerkVerifier(NewKeySet(&staticKeySource{data: jwksDocument(t, key)}), testIssuer, []string{"finflow-api"}, []string{"http://localhost:3000"})
+
+	withClaim := func(name string, value interface{}) jwt.MapClaims {
+		claims := validClaims()
+		if value == nil {
+			delete(claims, name)
+		} else {
+			claims[name] = value
+		}
+		return claims
+	}
+
+	unsigned, err := jwt.NewWithClaims(jwt.SigningMethodNone, validClaims()).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatalf("failed to build unsigned token: %v", err)
+	}
+	hmac, err := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims()).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatalf("failed to build HMAC token: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"forged signature", forger.sign(t, validClaims())},
+		{"unsigned", unsigned},
+		{"symmetric algorithm", hmac},
+		{"expired", key.sign(t, withClaim("exp", time.Now().Add(-time.Minute).Unix()))},
+		{"missing expiry", key.sign(t, withClaim("exp", nil))},
+		{"not yet valid", key.sign(t, withClaim("nbf", time.Now().Add(time.Hour).Unix()))},
+		{"wrong issuer", key.sign(t, withClaim("iss", "https://evil.example.com"))},
+		{"wrong audience", key.sign(t, withClaim("aud", "someone-else"))},
+		{"missing audience", key.sign(t, withClaim("aud", nil))},
+		{"unauthorized party", key.sign(t, withClaim("azp", "https://evil.example.com"))},
+		{"missing subject", key.sign(t, withClaim("sub", nil))},
+		{"malformed", "not-a-jwt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := verifier.Verify(context.Background(), tt.token); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestClerkVerifier_RequiresIssuer(t *testing.T) {
+	key := newRSAKey(t, "rsa-1")
+	verifier := NewClerkVerifier(NewKeySet(&staticKeySource{data: jwksDocument(t, key)}), "", nil, nil)
+
+	if _, err := verifier.Verify(context.Background(), key.sign(t, validClaims())); !errors.Is(err, ErrIssuerNotConfigured) {
+		t.Errorf("expected ErrIssuerNotConfigured, got %v", err)
+	}
+}
+
+func TestClerkVerifier_RotatesKeysFromServer(t *testing.T) {
+	oldKey := newRSAKey(t, "old")
+	newKey := newRSAKey(t, "new")
+
+	var mu sync.Mutex
+	current := jwksDocument(t, oldKey)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(current)
+	}))
+	defer server.Close()
+
+	keys := NewKeySet(NewHTTPKeySource(server.URL, server.Client()))
+	verifier := NewClerkVerifier(keys, testIssuer, nil, nil)
+
+	if _, err := verifier.Verify(context.Background(), oldKey.sign(t, validClaims())); err != nil {
+		t.Fatalf("Verify with the old key failed: %v", err)
+	}
+
+	mu.Lock()
+	current = jwksDocument(t, newKey)
+	mu.Unlock()
+	keys.now = func() time.Time { return time.Now().Add(2 * defaultMinRefreshDelay) }
+
+	if _, err := verifier.Verify(context.Background(), newKey.sign(t, validClaims())); err != nil {
+		t.Fatalf("Verify with the rotated key failed: %v", err)
+	}
+}
+
+func TestKeySet_RateLimitsUnknownKeyRefresh(t *testing.T) {
+	key := newRSAKey(t, "rsa-1")
+	source := &staticKeySource{data: jwksDocument(t, key)}
+	keys := NewKeySet(source)
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	keys.now = func() time.Time { return now }
+
+	if _, err := keys.Key(context.Background(), "rsa-1"); err != nil {
+		t.Fatalf("Key failed: %v", err)
+	}
+	if _, err := keys.Key(context.Background(), "unknown"); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("expected ErrUnknownKey, got %v", err)
+	}
+	if source.fetches != 1 {
+		t.Errorf("expected unknown key ids not to refetch within the delay, got %d fetches", source.fetches)
+	}
+
+	now = now.Add(defaultMinRefreshDelay)
+	if _, err := keys.Key(context.Background(), "unknown"); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("expected ErrUnknownKey, got %v", err)
+	}
+	if source.fetches != 2 {
+		t.Errorf("expected a refetch after the delay, got %d fetches", source.fetches)
+	}
+
+	now = now.Add(defaultKeyTTL + time.Second)
+	if _, err := keys.Key(context.Background(), "rsa-1"); err != nil {
+		t.Fatalf("Key failed: %v", err)
+	}
+	if source.fetches != 3 {
+		t.Errorf("expected a refetch once the keys expired, got %d fetches", source.fetches)
+	}
+}
+
+func TestKeySet_ConcurrentCallersShareOneFetch(t *testing.T) {
+	key := newRSAKey(t, "rsa-1")
+	source := &staticKeySource{data: jwksDocument(t, key), release: make(chan struct{})}
+	keys := NewKeySet(source)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := keys.Key(context.Background(), "rsa-1")
+			errs <- err
+		}()
+	}
+
+	for source.fetchCount() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	close(source.release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Key failed: %v", err)
+		}
+	}
+	if source.fetchCount() != 1 {
+		t.Errorf("expected one fetch for concurrent callers, got %d", source.fetchCount())
+	}
+}
+
+func TestKeySet_BacksOffWhenSourceFails(t *testing.T) {
+	key := newRSAKey(t, "rsa-1")
+	source := &staticKeySource{data: jwksDocument(t, key), err: errors.New("failed to fetch JWKS: unexpected status 503")}
+	keys := NewKeySet(source)
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	keys.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if _, err := keys.Key(context.Background(), "rsa-1"); err == nil || err.Error() != "failed to fetch JWKS: unexpected status 503" {
+			t.Fatalf("expected the fetch error, got %v", err)
+		}
+	}
+	if source.fetches != 1 {
+		t.Errorf("expected a failed first fetch not to be retried within the delay, got %d fetches", source.fetches)
+	}
+
+	source.fail(nil)
+	now = now.Add(defaultMinRefreshDelay)
+	if _, err := keys.Key(context.Background(), "rsa-1"); err != nil {
+		t.Fatalf("Key failed: %v", err)
+	}
+
+	source.fail(errors.New("failed to fetch JWKS: unexpected status 503"))
+	now = now.Add(defaultKeyTTL + time.Second)
+	for i := 0; i < 3; i++ {
+		if _, err := keys.Key(context.Background(), "rsa-1"); err != nil {
+			t.Fatalf("expected the expired key to be served while the source fails, got %v", err)
+		}
+	}
+	if source.fetches != 3 {
+		t.Errorf("expected one retry once the keys expired, got %d fetches", source.fetches)
+	}
+}
+
+func TestFileKeySource(t *testing.T) {
+	key := newECKey(t, "ec-1")
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, jwksDocument(t, key), 0o600); err != nil {
+		t.Fatalf("failed to write JWKS file: %v", err)
+	}
+
+	verifier := NewClerkVerifier(NewKeySet(NewFileKeySource(path)), testIssuer, nil, nil)
+	if _, err := verifier.Verify(context.Background(), key.sign(t, validClaims())); err != nil {
+		t.Errorf("Verify failed: %v", err)
+	}
+}
+
+func TestParseJWKS(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		wantKeys int
+		wantErr  bool
+	}{
+		{"skips encryption and unknown keys", `{"keys":[{"kty":"RSA","kid":"enc","use":"enc","n":"AQAB","e":"AQAB"},{"kty":"OKP","kid":"ed"},{"kty":"RSA","kid":"sig","n":"AQAB","e":"AQAB"}]}`, 1, false},
+		{"no usable keys", `{"keys":[{"kty":"oct","kid":"hmac"}]}`, 0, true},
+		{"unsupported curve", `{"keys":[{"kty":"EC","kid":"ec","crv":"P-192","x":"AQ","y":"AQ"}]}`, 0, true},
+		{"point off the curve", `{"keys":[{"kty":"EC","kid":"ec","crv":"P-256","x":"AQ","y":"AQ"}]}`, 0, true},
+		{"missing modulus", `{"keys":[{"kty":"RSA","kid":"rsa","e":"AQAB"}]}`, 0, true},
+		{"not json", `keys`, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := parseJWKS([]byte(tt.document))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if len(keys) != tt.wantKeys {
+				t.Errorf("expected %d keys, got %d", tt.wantKeys, len(keys))
+			}
+		})
+	}
+}
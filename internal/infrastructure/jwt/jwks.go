@@ -0,0 +1,323 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+var (
+	ErrUnknownKey = errors.New("no key matches the token key id")
+	ErrNoKeys     = errors.New("key set contains no usable signing keys")
+)
+
+const (
+	defaultKeyTTL          = time.Hour
+	defaultMinRefreshDelay = time.Minute
+)
+
+// KeySource returns a JWKS document. It lets the key set be backed by the
+// issuer's endpoint in production and by a local file or test server
+// elsewhere.
+type KeySource interface {
+	FetchKeys(ctx context.Context) ([]byte, error)
+}
+
+type HTTPKeySource struct {
+	url    string
+	client *http.Client
+}
+
+func NewHTTPKeySource(url string, client *http.Client) *HTTPKeySource {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &HTTPKeySource{url: url, client: client}
+}
+
+func (s *HTTPKeySource) FetchKeys(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
+
+type FileKeySource struct {
+	path string
+}
+
+func NewFileKeySource(path string) *FileKeySource {
+	return &FileKeySource{path: path}
+}
+
+func (s *FileKeySource) FetchKeys(ctx context.Context) ([]byte, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+	}
+	return data, nil
+}
+
+// KeySet caches the public keys of a KeySource by key id. Keys are
+// refetched once they are older than the TTL, and early when a token names
+// a key id that is not cached, which is how issuers roll their keys. Only
+// one fetch runs at a time, outside the lock, and concurrent callers wait
+// for it. Fetches are at least minRefreshDelay apart, whatever prompted
+// them, so neither unknown key ids nor an unavailable source can hammer it;
+// while refetching is held back, keys past their TTL keep being served.
+type KeySet struct {
+	source          KeySource
+	ttl             time.Duration
+	minRefreshDelay time.Duration
+	now             func() time.Time
+
+	mu          sync.Mutex
+	keys        map[string]crypto.PublicKey
+	fetchedAt   time.Time
+	lastAttempt time.Time
+	lastErr     error
+	// fetching is closed when the fetch in progress, if any, is done.
+	fetching chan struct{}
+}
+
+func NewKeySet(source KeySource) *KeySet {
+	return &KeySet{
+		source:          source,
+		ttl:             defaultKeyTTL,
+		minRefreshDelay: defaultMinRefreshDelay,
+		now:             time.Now,
+	}
+}
+
+// errRefreshDelayed is returned by refresh when the previous fetch was
+// less than minRefreshDelay ago and succeeded.
+var errRefreshDelayed = errors.New("key set was refreshed too recently")
+
+// Key returns the public key for kid. An empty kid is accepted when the set
+// holds exactly one key.
+func (k *KeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	if k.expired() {
+		if err := k.refresh(ctx); err != nil && !k.loaded() {
+			return nil, err
+		}
+	}
+
+	if key, ok := k.lookup(kid); ok {
+		return key, nil
+	}
+
+	if err := k.refresh(ctx); err != nil && !errors.Is(err, errRefreshDelayed) {
+		return nil, err
+	}
+
+	if key, ok := k.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, ErrUnknownKey
+}
+
+func (k *KeySet) expired() bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.keys == nil || k.now().Sub(k.fetchedAt) > k.ttl
+}
+
+func (k *KeySet) loaded() bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.keys != nil
+}
+
+func (k *KeySet) lookup(kid string) (crypto.PublicKey, bool) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if kid == "" && len(k.keys) == 1 {
+		for _, key := range k.keys {
+			return key, true
+		}
+	}
+	key, ok := k.keys[kid]
+	return key, ok
+}
+
+// refresh fetches the keys, or waits for the fetch already in progress. It
+// does not fetch within minRefreshDelay of the previous attempt and returns
+// that attempt's error instead, or errRefreshDelayed if it succeeded.
+func (k *KeySet) refresh(ctx context.Context) error {
+	k.mu.Lock()
+	if fetching := k.fetching; fetching != nil {
+		k.mu.Unlock()
+		select {
+		case <-fetching:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		k.mu.Lock()
+		defer k.mu.Unlock()
+		return k.lastErr
+	}
+
+	if !k.lastAttempt.IsZero() && k.now().Sub(k.lastAttempt) < k.minRefreshDelay {
+		defer k.mu.Unlock()
+		if k.lastErr != nil {
+			return k.lastErr
+		}
+		return errRefreshDelayed
+	}
+
+	fetching := make(chan struct{})
+	k.fetching = fetching
+	k.lastAttempt = k.now()
+	k.mu.Unlock()
+
+	keys, err := k.fetch(ctx)
+
+	k.mu.Lock()
+	if err == nil {
+		k.keys = keys
+		k.fetchedAt = k.now()
+	}
+	k.lastErr = err
+	k.fetching = nil
+	k.mu.Unlock()
+	close(fetching)
+
+	return err
+}
+
+func (k *KeySet) fetch(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	data, err := k.source.FetchKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return parseJWKS(data)
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWKS decodes the RSA and EC signing keys of a JWKS document. Keys of
+// other types or meant for encryption are skipped.
+func parseJWKS(data []byte) (map[string]crypto.PublicKey, error) {
+	var document struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("invalid JWKS document: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey)
+	for _, jwk := range document.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		var key crypto.PublicKey
+		var err error
+		switch jwk.Kty {
+		case "RSA":
+			key, err = jwk.rsaPublicKey()
+		case "EC":
+			key, err = jwk.ecdsaPublicKey()
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q: %w", jwk.Kid, err)
+		}
+
+		keys[jwk.Kid] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+
+	return keys, nil
+}
+
+func (jwk jsonWebKey) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := decodeBigInt(jwk.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := decodeBigInt(jwk.E)
+	if err != nil {
+		return nil, err
+	}
+	if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+		return nil, errors.New("unsupported RSA exponent")
+	}
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+func (jwk jsonWebKey) ecdsaPublicKey() (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch jwk.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+	}
+
+	x, err := decodeBigInt(jwk.X)
+	if err != nil {
+		return nil, err
+	}
+	y, err := decodeBigInt(jwk.Y)
+	if err != nil {
+		return nil, err
+	}
+	if !curve.IsOnCurve(x, y) {
+		return nil, errors.New("point is not on the curve")
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	if value == "" {
+		return nil, errors.New("missing key parameter")
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(decoded), nil
+}
@@ -3,6 +3,7 @@ package jwt
 import (
 	"errors"
 	"os"
+	"strconv"
 	"time"
 
 	jwtinterface "fin-flow-api/internal/shared/interface/jwt"
@@ -10,12 +11,18 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// defaultAccessTokenTTL is kept short because access tokens cannot be
+// revoked; clients renew them through /auth/refresh.
+const defaultAccessTokenTTL = 15 * time.Minute
+
 type Service struct {
 	secretKey []byte
+	ttl       time.Duration
 }
 
 type Claims struct {
 	UserID string `json:"user_id"`
+	Role   string `json:"role"`
 	jwt.RegisteredClaims
 }
 
@@ -24,16 +31,22 @@ func NewService() jwtinterface.Service {
 	if secretKey == "" {
 		secretKey = "your-secret-key-change-in-production"
 	}
+	ttl := defaultAccessTokenTTL
+	if seconds, err := strconv.Atoi(os.Getenv("JWT_ACCESS_TOKEN_TTL")); err == nil && seconds > 0 {
+		ttl = time.Duration(seconds) * time.Second
+	}
 	return &Service{
 		secretKey: []byte(secretKey),
+		ttl:       ttl,
 	}
 }
 
-func (s *Service) GenerateToken(userID string) (string, error) {
+func (s *Service) GenerateToken(userID string, role string) (string, error) {
 	claims := Claims{
 		UserID: userID,
+		Role:   role,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.ttl)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 		},
@@ -48,7 +61,7 @@ func (s *Service) GenerateToken(userID string) (string, error) {
 	return tokenString, nil
 }
 
-func (s *Service) ValidateToken(tokenString string) (string, error) {
+func (s *Service) ValidateToken(tokenString string) (*jwtinterface.AccessClaims, error) {
 	claims := &Claims{}
 
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
@@ -59,12 +72,12 @@ func (s *Service) ValidateToken(tokenString string) (string, error) {
 	})
 
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if !token.Valid {
-		return "", errors.New("invalid token")
+		return nil, errors.New("invalid token")
 	}
 
-	return claims.UserID, nil
+	return &jwtinterface.AccessClaims{UserID: claims.UserID, Role: claims.Role}, nil
 }
\ No newline at end of file
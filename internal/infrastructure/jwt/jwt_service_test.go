@@ -30,7 +30,7 @@ func TestGenerateToken(t *testing.T) {
 	service := NewService()
 	userID := "test-user-id"
 
-	token, err := service.GenerateToken(userID)
+	token, err := service.GenerateToken(userID, "user")
 	if err != nil {
 		t.Fatalf("GenerateToken failed: %v", err)
 	}
@@ -45,12 +45,12 @@ func TestGenerateToken_DifferentUsers(t *testing.T) {
 	userID1 := "user-1"
 	userID2 := "user-2"
 
-	token1, err1 := service.GenerateToken(userID1)
+	token1, err1 := service.GenerateToken(userID1, "user")
 	if err1 != nil {
 		t.Fatalf("GenerateToken failed: %v", err1)
 	}
 
-	token2, err2 := service.GenerateToken(userID2)
+	token2, err2 := service.GenerateToken(userID2, "user")
 	if err2 != nil {
 		t.Fatalf("GenerateToken failed: %v", err2)
 	}
@@ -64,18 +64,36 @@ func TestValidateToken(t *testing.T) {
 	service := NewService()
 	userID := "test-user-id"
 
-	token, err := service.GenerateToken(userID)
+	token, err := service.GenerateToken(userID, "user")
 	if err != nil {
 		t.Fatalf("GenerateToken failed: %v", err)
 	}
 
-	validatedUserID, err := service.ValidateToken(token)
+	claims, err := service.ValidateToken(token)
 	if err != nil {
 		t.Fatalf("ValidateToken failed: %v", err)
 	}
 
-	if validatedUserID != userID {
-		t.Errorf("expected userID %s, got %s", userID, validatedUserID)
+	if claims.UserID != userID {
+		t.Errorf("expected userID %s, got %s", userID, claims.UserID)
+	}
+}
+
+func TestValidateToken_CarriesRole(t *testing.T) {
+	service := NewService()
+
+	token, err := service.GenerateToken("admin-id", "admin")
+	if err != nil {
+		t.Fatalf("GenerateToken failed: %v", err)
+	}
+
+	claims, err := service.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("ValidateToken failed: %v", err)
+	}
+
+	if claims.Role != "admin" {
+		t.Errorf("expected role admin, got %s", claims.Role)
 	}
 }
 
@@ -102,7 +120,7 @@ func TestValidateToken_WrongSecret(t *testing.T) {
 	service1 := NewService()
 	userID := "test-user-id"
 
-	token, err := service1.GenerateToken(userID)
+	token, err := service1.GenerateToken(userID, "user")
 	if err != nil {
 		t.Fatalf("GenerateToken failed: %v", err)
 	}
@@ -123,28 +141,28 @@ func TestTokenExpiration(t *testing.T) {
 	service := NewService()
 	userID := "test-user-id"
 
-	token, err := service.GenerateToken(userID)
+	token, err := service.GenerateToken(userID, "user")
 	if err != nil {
 		t.Fatalf("GenerateToken failed: %v", err)
 	}
 
-	validatedUserID, err := service.ValidateToken(token)
+	claims, err := service.ValidateToken(token)
 	if err != nil {
 		t.Fatalf("ValidateToken failed: %v", err)
 	}
 
-	if validatedUserID != userID {
-		t.Errorf("expected userID %s, got %s", userID, validatedUserID)
+	if claims.UserID != userID {
+		t.Errorf("expected userID %s, got %s", userID, claims.UserID)
 	}
 
 	time.Sleep(100 * time.Millisecond)
 
-	validatedUserID2, err := service.ValidateToken(token)
+	claims2, err := service.ValidateToken(token)
 	if err != nil {
 		t.Fatalf("ValidateToken failed after short delay: %v", err)
 	}
 
-	if validatedUserID2 != userID {
+	if claims2.UserID != userID {
 		t.Errorf("Token should still be valid after short delay")
 	}
 }
\ No newline at end of file
@@ -4,12 +4,12 @@ import "fin-flow-api/internal/shared/interface/jwt"
 
 type mockJWTService struct{}
 
-func (m *mockJWTService) GenerateToken(userID string) (string, error) {
+func (m *mockJWTService) GenerateToken(userID string, role string) (string, error) {
 	return "mock-token", nil
 }
 
-func (m *mockJWTService) ValidateToken(tokenString string) (string, error) {
-	return "mock-user-id", nil
+func (m *mockJWTService) ValidateToken(tokenString string) (*jwt.AccessClaims, error) {
+	return &jwt.AccessClaims{UserID: "mock-user-id", Role: "user"}, nil
 }
 
 func newMockJWTService() jwt.Service {
@@ -3,7 +3,21 @@ package http
 import (
 	"net/http"
 
+	budgetshttp "fin-flow-api/internal/modules/budgets/interfaces/http"
 	categorieshttp "fin-flow-api/internal/modules/categories/interfaces/http"
+	categorizationhttp "fin-flow-api/internal/modules/categorization/interfaces/http"
+	creditcardshttp "fin-flow-api/internal/modules/creditcards/interfaces/http"
+	duplicateshttp "fin-flow-api/internal/modules/duplicates/interfaces/http"
+	exchangerateshttp "fin-flow-api/internal/modules/exchangerates/interfaces/http"
+	goalshttp "fin-flow-api/internal/modules/goals/interfaces/http"
+	importshttp "fin-flow-api/internal/modules/imports/interfaces/http"
+	inflationhttp "fin-flow-api/internal/modules/inflation/interfaces/http"
+	investmentshttp "fin-flow-api/internal/modules/investments/interfaces/http"
+	loanshttp "fin-flow-api/internal/modules/loans/interfaces/http"
+	reconciliationshttp "fin-flow-api/internal/modules/reconciliations/interfaces/http"
+	recurringhttp "fin-flow-api/internal/modules/recurring/interfaces/http"
+	reportshttp "fin-flow-api/internal/modules/reports/interfaces/http"
+	transactionshttp "fin-flow-api/internal/modules/transactions/interfaces/http"
 	usershttp "fin-flow-api/internal/modules/users/interfaces/http"
 	walletshttp "fin-flow-api/internal/modules/wallets/interfaces/http"
 	"fin-flow-api/internal/shared/interface/jwt"
@@ -23,4 +37,18 @@ func mountV1(mux *http.ServeMux, jwtService jwt.Service) {
 	usershttp.SetupRoutes(mux, jwtService)
 	categorieshttp.SetupRoutes(mux, jwtService)
 	walletshttp.SetupRoutes(mux, jwtService)
+	transactionshttp.SetupRoutes(mux, jwtService)
+	recurringhttp.SetupRoutes(mux, jwtService)
+	budgetshttp.SetupRoutes(mux, jwtService)
+	exchangerateshttp.SetupRoutes(mux, jwtService)
+	inflationhttp.SetupRoutes(mux, jwtService)
+	reportshttp.SetupRoutes(mux, jwtService)
+	creditcardshttp.SetupRoutes(mux, jwtService)
+	investmentshttp.SetupRoutes(mux, jwtService)
+	loanshttp.SetupRoutes(mux, jwtService)
+	goalshttp.SetupRoutes(mux, jwtService)
+	categorizationhttp.SetupRoutes(mux, jwtService)
+	importshttp.SetupRoutes(mux, jwtService)
+	duplicateshttp.SetupRoutes(mux, jwtService)
+	reconciliationshttp.SetupRoutes(mux, jwtService)
 }
\ No newline at end of file
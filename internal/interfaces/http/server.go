@@ -2,6 +2,8 @@ package http
 
 import (
 	"context"
+	"errors"
+	"fin-flow-api/internal/shared/interface/jobs"
 	"fin-flow-api/internal/shared/interface/jwt"
 	"fin-flow-api/internal/shared/middleware"
 	"log"
@@ -9,6 +11,7 @@ import (
 	"os"
 	"os/signal"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 )
@@ -16,6 +19,7 @@ import (
 type Server struct {
 	httpServer     *http.Server
 	shutdownTimeout time.Duration
+	jobs            []jobs.Job
 }
 
 type Config struct {
@@ -70,10 +74,20 @@ func NewServer(cfg Config, jwtService jwt.Service) *Server {
 	}
 }
 
+// AddJob registers a background job. Jobs are started by Run together with
+// the HTTP listener and stopped, within the shutdown timeout, before Run
+// returns.
+func (s *Server) AddJob(job jobs.Job) {
+	s.jobs = append(s.jobs, job)
+}
+
 func (s *Server) Run() error {
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
 
+	jobsCtx, cancelJobs := context.WithCancel(context.Background())
+	jobsDone := s.startJobs(jobsCtx)
+
 	errChan := make(chan error, 1)
 	go func() {
 		log.Printf("server has started at %s", s.httpServer.Addr)
@@ -84,6 +98,10 @@ func (s *Server) Run() error {
 
 	select {
 	case err := <-errChan:
+		ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+		defer cancel()
+
+		s.stopJobs(ctx, cancelJobs, jobsDone)
 		return err
 	case <-stop:
 		log.Println("shutting down server...")
@@ -91,6 +109,10 @@ func (s *Server) Run() error {
 		defer cancel()
 
 		if err := s.httpServer.Shutdown(ctx); err != nil {
+			cancelJobs()
+			return err
+		}
+		if err := s.stopJobs(ctx, cancelJobs, jobsDone); err != nil {
 			return err
 		}
 		log.Println("server stopped")
@@ -98,6 +120,42 @@ func (s *Server) Run() error {
 	}
 }
 
+// startJobs runs every registered job in its own goroutine. The returned
+// channel is closed once all of them have returned.
+func (s *Server) startJobs(ctx context.Context) <-chan struct{} {
+	var wg sync.WaitGroup
+	for _, job := range s.jobs {
+		wg.Add(1)
+		go func(job jobs.Job) {
+			defer wg.Done()
+			log.Printf("background job %s started", job.Name())
+			if err := job.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				log.Printf("background job %s stopped with error: %v", job.Name(), err)
+				return
+			}
+			log.Printf("background job %s stopped", job.Name())
+		}(job)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	return done
+}
+
+// stopJobs cancels the jobs and waits for them until ctx expires.
+func (s *Server) stopJobs(ctx context.Context, cancel context.CancelFunc, done <-chan struct{}) error {
+	cancel()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return errors.New("background jobs did not stop before the shutdown timeout")
+	}
+}
+
 func normalizeAddr(addr string) string {
 	if addr == "" {
 		addr = "8080"
@@ -95,3 +95,60 @@ func TestServerShutdown(t *testing.T) {
 		t.Error("server did not stop in time")
 	}
 }
+
+type mockJob struct {
+	started chan struct{}
+	block   bool
+}
+
+func (m *mockJob) Name() string {
+	return "mock"
+}
+
+func (m *mockJob) Run(ctx context.Context) error {
+	close(m.started)
+	if m.block {
+		select {}
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestServerJobs_StartAndStop(t *testing.T) {
+	server := NewServer(Config{Addr: "0"}, newMockJWTService())
+	job := &mockJob{started: make(chan struct{})}
+	server.AddJob(job)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := server.startJobs(ctx)
+
+	select {
+	case <-job.started:
+	case <-time.After(time.Second):
+		t.Fatal("job did not start")
+	}
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), time.Second)
+	defer shutdownCancel()
+
+	if err := server.stopJobs(shutdownCtx, cancel, done); err != nil {
+		t.Errorf("stopJobs failed: %v", err)
+	}
+}
+
+func TestServerJobs_StopTimeout(t *testing.T) {
+	server := NewServer(Config{Addr: "0"}, newMockJWTService())
+	job := &mockJob{started: make(chan struct{}), block: true}
+	server.AddJob(job)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := server.startJobs(ctx)
+	<-job.started
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer shutdownCancel()
+
+	if err := server.stopJobs(shutdownCtx, cancel, done); err == nil {
+		t.Error("expected an error when a job ignores cancellation")
+	}
+}
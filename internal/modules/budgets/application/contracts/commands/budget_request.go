@@ -0,0 +1,16 @@
+package commands
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+type BudgetRequest struct {
+	CategoryID     string
+	Amount         domain.Amount
+	Currency       string
+	StartMonth     time.Time
+	Rollover       bool
+	AlertThreshold int
+}
@@ -0,0 +1,21 @@
+package queries
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+type BudgetResponse struct {
+	ID             string
+	CategoryID     string
+	Amount         domain.Amount
+	Currency       string
+	StartMonth     time.Time
+	Rollover       bool
+	AlertThreshold int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	CreatedBy      string
+	UpdatedBy      string
+}
@@ -0,0 +1,26 @@
+package queries
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+// BudgetStatusResponse is the status of a budget in a month. Reference is
+// the month whose money the figures are restated to, nil when they are
+// nominal.
+type BudgetStatusResponse struct {
+	BudgetID       string
+	CategoryID     string
+	Currency       string
+	Month          time.Time
+	Reference      *time.Time
+	Limit          domain.Amount
+	Carryover      domain.Amount
+	Available      domain.Amount
+	Spent          domain.Amount
+	Remaining      domain.Amount
+	PercentageUsed *domain.Amount
+	Alert          int
+	AlertName      string
+}
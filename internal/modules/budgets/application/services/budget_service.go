@@ -0,0 +1,281 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"fin-flow-api/internal/modules/budgets/application/contracts/commands"
+	"fin-flow-api/internal/modules/budgets/application/contracts/queries"
+	"fin-flow-api/internal/modules/budgets/domain"
+	categorydomain "fin-flow-api/internal/modules/categories/domain"
+	inflationdomain "fin-flow-api/internal/modules/inflation/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	"fin-flow-api/internal/shared/middleware"
+
+	"github.com/google/uuid"
+)
+
+type priceIndex interface {
+	Restater(currency walletdomain.Currency, reference, from, to time.Time) (*inflationdomain.Restater, error)
+}
+
+// BudgetService manages the budgets of the caller and compares them with
+// what was spent. Statuses can be restated to constant currency with the
+// price index of the budget's currency.
+type BudgetService struct {
+	repository         domain.BudgetRepository
+	categoryRepository categorydomain.CategoryRepository
+	priceIndex         priceIndex
+	systemUser         string
+}
+
+func NewBudgetService(repository domain.BudgetRepository, categoryRepository categorydomain.CategoryRepository, priceIndex priceIndex, systemUser string) *BudgetService {
+	return &BudgetService{
+		repository:         repository,
+		categoryRepository: categoryRepository,
+		priceIndex:         priceIndex,
+		systemUser:         systemUser,
+	}
+}
+
+func (s *BudgetService) getUserIDFromContext(ctx context.Context) (string, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return "", errors.New("user not authenticated")
+	}
+	return userID, nil
+}
+
+func (s *BudgetService) Create(ctx context.Context, req commands.BudgetRequest) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	budget := domain.NewBudget(
+		uuid.New().String(),
+		userID,
+		req.CategoryID,
+		req.Amount,
+		walletdomain.Currency(req.Currency),
+		req.StartMonth,
+		req.Rollover,
+		req.AlertThreshold,
+		s.systemUser,
+	)
+
+	if err := s.validate(userID, budget); err != nil {
+		return err
+	}
+
+	return s.repository.Create(budget)
+}
+
+func (s *BudgetService) Update(ctx context.Context, id string, req commands.BudgetRequest) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	budget, err := s.repository.GetByID(id, userID)
+	if err != nil {
+		return err
+	}
+
+	updated := domain.NewBudget(
+		budget.ID,
+		userID,
+		req.CategoryID,
+		req.Amount,
+		walletdomain.Currency(req.Currency),
+		req.StartMonth,
+		req.Rollover,
+		req.AlertThreshold,
+		budget.CreatedBy,
+	)
+	updated.Entity = budget.Entity
+
+	if err := s.validate(userID, updated); err != nil {
+		return err
+	}
+
+	updated.Entity.UpdateModified(s.systemUser)
+
+	return s.repository.Update(updated)
+}
+
+func (s *BudgetService) Delete(ctx context.Context, id string) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return s.repository.Delete(id, userID)
+}
+
+func (s *BudgetService) GetByID(ctx context.Context, id string) (*queries.BudgetResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	budget, err := s.repository.GetByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toBudgetResponse(budget), nil
+}
+
+func (s *BudgetService) List(ctx context.Context) ([]*queries.BudgetResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	budgets, err := s.repository.List(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*queries.BudgetResponse, len(budgets))
+	for i, budget := range budgets {
+		responses[i] = toBudgetResponse(budget)
+	}
+
+	return responses, nil
+}
+
+// Status returns budget vs. actual for a single budget in the month
+// containing month. A non-zero reference restates the figures to constant
+// currency of that month.
+func (s *BudgetService) Status(ctx context.Context, id string, month, reference time.Time) (*queries.BudgetStatusResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	budget, err := s.repository.GetByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.status(budget, month, reference)
+}
+
+// Summary returns budget vs. actual for every budget of the user that
+// covers the month containing month, restated like Status.
+func (s *BudgetService) Summary(ctx context.Context, month, reference time.Time) ([]*queries.BudgetStatusResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	budgets, err := s.repository.List(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []*queries.BudgetStatusResponse{}
+	for _, budget := range budgets {
+		if !budget.AppliesTo(month) {
+			continue
+		}
+		response, err := s.status(budget, month, reference)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
+
+func (s *BudgetService) status(budget *domain.Budget, month, reference time.Time) (*queries.BudgetStatusResponse, error) {
+	month = domain.MonthOf(month)
+	if !budget.AppliesTo(month) {
+		return nil, domain.ErrMonthBeforeBudgetStart
+	}
+
+	// Only rolling budgets need the months before the requested one.
+	from := month
+	if budget.Rollover {
+		from = budget.StartMonth
+	}
+
+	spending, err := s.repository.MonthlySpending(budget.UserID, budget.CategoryID, budget.Currency, from, month)
+	if err != nil {
+		return nil, err
+	}
+
+	var restater domain.Restater
+	if !reference.IsZero() {
+		indexes, err := s.priceIndex.Restater(budget.Currency, reference, from, month)
+		if err != nil {
+			return nil, err
+		}
+		restater = indexes
+	}
+
+	status, err := budget.StatusFor(month, spending, restater)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toBudgetStatusResponse(status)
+	if restater != nil {
+		reference = domain.MonthOf(reference)
+		response.Reference = &reference
+	}
+	return response, nil
+}
+
+func (s *BudgetService) validate(userID string, budget *domain.Budget) error {
+	if err := budget.Validate(); err != nil {
+		return err
+	}
+
+	category, err := s.categoryRepository.GetByID(budget.CategoryID, userID)
+	if err != nil {
+		return err
+	}
+
+	if category.Type != categorydomain.CategoryTypeExpense {
+		return domain.ErrCategoryNotExpense
+	}
+
+	return nil
+}
+
+func toBudgetResponse(budget *domain.Budget) *queries.BudgetResponse {
+	return &queries.BudgetResponse{
+		ID:             budget.ID,
+		CategoryID:     budget.CategoryID,
+		Amount:         budget.Amount,
+		Currency:       budget.Currency.String(),
+		StartMonth:     budget.StartMonth,
+		Rollover:       budget.Rollover,
+		AlertThreshold: budget.AlertThreshold,
+		CreatedAt:      budget.CreatedAt,
+		UpdatedAt:      budget.ModifiedAt,
+		CreatedBy:      budget.CreatedBy,
+		UpdatedBy:      budget.ModifiedBy,
+	}
+}
+
+func toBudgetStatusResponse(status *domain.BudgetStatus) *queries.BudgetStatusResponse {
+	return &queries.BudgetStatusResponse{
+		BudgetID:       status.Budget.ID,
+		CategoryID:     status.Budget.CategoryID,
+		Currency:       status.Budget.Currency.String(),
+		Month:          status.Month,
+		Limit:          status.Limit,
+		Carryover:      status.Carryover,
+		Available:      status.Available,
+		Spent:          status.Spent,
+		Remaining:      status.Remaining,
+		PercentageUsed: status.PercentageUsed,
+		Alert:          status.Alert.Value(),
+		AlertName:      status.Alert.String(),
+	}
+}
@@ -0,0 +1,410 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"fin-flow-api/internal/modules/budgets/application/contracts/commands"
+	"fin-flow-api/internal/modules/budgets/domain"
+	categorydomain "fin-flow-api/internal/modules/categories/domain"
+	inflationdomain "fin-flow-api/internal/modules/inflation/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+type spendingQuery struct {
+	categoryID string
+	currency   walletdomain.Currency
+	from       time.Time
+	to         time.Time
+}
+
+type mockBudgetRepository struct {
+	budgets       map[string]*domain.Budget
+	spending      []domain.MonthlySpending
+	lastSpending  spendingQuery
+	spendingErr   error
+	spendingCalls int
+}
+
+func newMockBudgetRepository(budgets ...*domain.Budget) *mockBudgetRepository {
+	repo := &mockBudgetRepository{budgets: make(map[string]*domain.Budget)}
+	for _, budget := range budgets {
+		repo.budgets[budget.ID] = budget
+	}
+	return repo
+}
+
+func (m *mockBudgetRepository) Create(budget *domain.Budget) error {
+	m.budgets[budget.ID] = budget
+	return nil
+}
+
+func (m *mockBudgetRepository) GetByID(id string, userID string) (*domain.Budget, error) {
+	budget, exists := m.budgets[id]
+	if !exists {
+		return nil, errors.New("budget not found")
+	}
+	if budget.UserID != userID {
+		return nil, errors.New("unauthorized access to budget")
+	}
+	return budget, nil
+}
+
+func (m *mockBudgetRepository) List(userID string) ([]*domain.Budget, error) {
+	var result []*domain.Budget
+	for _, budget := range m.budgets {
+		if budget.UserID == userID {
+			result = append(result, budget)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockBudgetRepository) Update(budget *domain.Budget) error {
+	if _, exists := m.budgets[budget.ID]; !exists {
+		return errors.New("budget not found")
+	}
+	m.budgets[budget.ID] = budget
+	return nil
+}
+
+func (m *mockBudgetRepository) Delete(id string, userID string) error {
+	if _, err := m.GetByID(id, userID); err != nil {
+		return err
+	}
+	delete(m.budgets, id)
+	return nil
+}
+
+func (m *mockBudgetRepository) MonthlySpending(userID, categoryID string, currency walletdomain.Currency, from, to time.Time) ([]domain.MonthlySpending, error) {
+	m.spendingCalls++
+	m.lastSpending = spendingQuery{categoryID: categoryID, currency: currency, from: from, to: to}
+	if m.spendingErr != nil {
+		return nil, m.spendingErr
+	}
+	return m.spending, nil
+}
+
+type mockCategoryRepository struct {
+	categories map[string]*categorydomain.Category
+}
+
+func (m *mockCategoryRepository) Create(category *categorydomain.Category) error {
+	return nil
+}
+
+func (m *mockCategoryRepository) CreateAll(categories []*categorydomain.Category) error {
+	return nil
+}
+
+func (m *mockCategoryRepository) GetByID(id string, userID string) (*categorydomain.Category, error) {
+	category, exists := m.categories[id]
+	if !exists {
+		return nil, errors.New("category not found")
+	}
+	if category.UserID != userID {
+		return nil, errors.New("unauthorized access to category")
+	}
+	return category, nil
+}
+
+func (m *mockCategoryRepository) List(userID string) ([]*categorydomain.Category, error) {
+	return nil, nil
+}
+
+func (m *mockCategoryRepository) Update(category *categorydomain.Category) error {
+	return nil
+}
+
+func (m *mockCategoryRepository) Delete(id string, userID string, strategy categorydomain.DeleteStrategy) error {
+	return nil
+}
+
+func (m *mockCategoryRepository) Merge(targetID string, sourceIDs []string, userID string) error {
+	return nil
+}
+
+type mockContext struct {
+	context.Context
+	userID string
+	hasID  bool
+}
+
+func (m *mockContext) Value(key interface{}) interface{} {
+	if key == middleware.UserIDKey {
+		if m.hasID {
+			return m.userID
+		}
+		return nil
+	}
+	return nil
+}
+
+type mockPriceIndex struct {
+	values []*inflationdomain.IndexValue
+	calls  int
+}
+
+func (m *mockPriceIndex) Restater(currency walletdomain.Currency, reference, from, to time.Time) (*inflationdomain.Restater, error) {
+	m.calls++
+	return inflationdomain.NewRestater(currency, reference, m.values)
+}
+
+func indexValue(m time.Time, value string) *inflationdomain.IndexValue {
+	return inflationdomain.NewIndexValue("index-"+m.Format("2006-01"), walletdomain.CurrencyUSD, m, shareddomain.MustParseAmount(value), inflationdomain.SourceManual, "system")
+}
+
+func month(year int, m time.Month) time.Time {
+	return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func newTestService(budgets ...*domain.Budget) (*BudgetService, *mockBudgetRepository) {
+	repo := newMockBudgetRepository(budgets...)
+	categories := &mockCategoryRepository{categories: map[string]*categorydomain.Category{
+		"cat-food":   categorydomain.NewCategory("cat-food", "user1", "Food", categorydomain.CategoryTypeExpense, "system"),
+		"cat-salary": categorydomain.NewCategory("cat-salary", "user1", "Salary", categorydomain.CategoryTypeIncome, "system"),
+		"cat-stocks": categorydomain.NewCategory("cat-stocks", "user1", "Stocks", categorydomain.CategoryTypeInvestment, "system"),
+	}}
+	return NewBudgetService(repo, categories, &mockPriceIndex{}, "system"), repo
+}
+
+func foodBudget(rollover bool) *domain.Budget {
+	return domain.NewBudget("budget-1", "user1", "cat-food", shareddomain.MustParseAmount("500"), walletdomain.CurrencyUSD, month(2026, 1), rollover, 80, "system")
+}
+
+func foodRequest() commands.BudgetRequest {
+	return commands.BudgetRequest{
+		CategoryID: "cat-food",
+		Amount:     shareddomain.MustParseAmount("500"),
+		Currency:   "USD",
+		StartMonth: month(2026, 1),
+		Rollover:   true,
+	}
+}
+
+func TestBudgetService_Create(t *testing.T) {
+	service, repo := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	if err := service.Create(ctx, foodRequest()); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if len(repo.budgets) != 1 {
+		t.Fatalf("expected 1 budget, got %d", len(repo.budgets))
+	}
+	for _, budget := range repo.budgets {
+		if budget.UserID != "user1" || budget.AlertThreshold != domain.DefaultAlertThreshold || !budget.Rollover {
+			t.Errorf("unexpected budget %+v", budget)
+		}
+	}
+}
+
+func TestBudgetService_Create_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(req *commands.BudgetRequest)
+		wantErr string
+	}{
+		{"income category", func(req *commands.BudgetRequest) { req.CategoryID = "cat-salary" }, "expense categories"},
+		{"investment category", func(req *commands.BudgetRequest) { req.CategoryID = "cat-stocks" }, "expense categories"},
+		{"unknown category", func(req *commands.BudgetRequest) { req.CategoryID = "missing" }, "category not found"},
+		{"invalid currency", func(req *commands.BudgetRequest) { req.Currency = "XXX" }, "invalid currency"},
+		{"zero amount", func(req *commands.BudgetRequest) { req.Amount = shareddomain.Amount{} }, "invalid amount"},
+		{"precision", func(req *commands.BudgetRequest) {
+			req.Currency = "JPY"
+			req.Amount = shareddomain.MustParseAmount("0.5")
+		}, "decimal places"},
+		{"threshold", func(req *commands.BudgetRequest) { req.AlertThreshold = 150 }, "alert threshold"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo := newTestService()
+			ctx := &mockContext{userID: "user1", hasID: true}
+
+			req := foodRequest()
+			tt.mutate(&req)
+
+			err := service.Create(ctx, req)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %q", tt.wantErr, err.Error())
+			}
+			if len(repo.budgets) != 0 {
+				t.Errorf("expected no budget to be stored, got %d", len(repo.budgets))
+			}
+		})
+	}
+}
+
+func TestBudgetService_Create_Unauthenticated(t *testing.T) {
+	service, _ := newTestService()
+
+	err := service.Create(&mockContext{hasID: false}, foodRequest())
+	if err == nil || !strings.Contains(err.Error(), "user not authenticated") {
+		t.Errorf("expected authentication error, got %v", err)
+	}
+}
+
+func TestBudgetService_Update(t *testing.T) {
+	budget := foodBudget(false)
+	service, repo := newTestService(budget)
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	req := foodRequest()
+	req.Amount = shareddomain.MustParseAmount("650")
+	req.AlertThreshold = 90
+
+	if err := service.Update(ctx, "budget-1", req); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	updated := repo.budgets["budget-1"]
+	if updated.Amount.String() != "650" || updated.AlertThreshold != 90 || !updated.Rollover {
+		t.Errorf("unexpected budget after update %+v", updated)
+	}
+	if !updated.CreatedAt.Equal(budget.CreatedAt) {
+		t.Error("expected creation time to be preserved")
+	}
+}
+
+func TestBudgetService_Status_Rollover(t *testing.T) {
+	service, repo := newTestService(foodBudget(true))
+	repo.spending = []domain.MonthlySpending{
+		{Month: month(2026, 1), Amount: shareddomain.MustParseAmount("300")},
+		{Month: month(2026, 2), Amount: shareddomain.MustParseAmount("560")},
+	}
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	status, err := service.Status(ctx, "budget-1", time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC), time.Time{})
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+
+	if !repo.lastSpending.from.Equal(month(2026, 1)) || !repo.lastSpending.to.Equal(month(2026, 2)) {
+		t.Errorf("expected spending from the budget start, got %s to %s", repo.lastSpending.from, repo.lastSpending.to)
+	}
+	if repo.lastSpending.currency != walletdomain.CurrencyUSD || repo.lastSpending.categoryID != "cat-food" {
+		t.Errorf("unexpected spending query %+v", repo.lastSpending)
+	}
+	if status.Carryover.String() != "200" || status.Available.String() != "700" || status.Remaining.String() != "140" {
+		t.Errorf("unexpected status: carryover %s available %s remaining %s", status.Carryover, status.Available, status.Remaining)
+	}
+	if status.PercentageUsed == nil || status.PercentageUsed.String() != "80" {
+		t.Errorf("expected 80%% used, got %v", status.PercentageUsed)
+	}
+	if status.AlertName != "Warning" {
+		t.Errorf("expected Warning alert, got %s", status.AlertName)
+	}
+}
+
+func TestBudgetService_Status_Restated(t *testing.T) {
+	service, repo := newTestService(foodBudget(true))
+	repo.spending = []domain.MonthlySpending{
+		{Month: month(2026, 1), Amount: shareddomain.MustParseAmount("300")},
+		{Month: month(2026, 2), Amount: shareddomain.MustParseAmount("560")},
+	}
+	priceIndex := &mockPriceIndex{values: []*inflationdomain.IndexValue{
+		indexValue(month(2026, 1), "100"),
+		indexValue(month(2026, 2), "110"),
+	}}
+	service.priceIndex = priceIndex
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	status, err := service.Status(ctx, "budget-1", month(2026, 2), time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+
+	// January's 200 left over is worth 220 in February money.
+	if status.Carryover.String() != "220" || status.Available.String() != "720" || status.Remaining.String() != "160" {
+		t.Errorf("unexpected status: carryover %s available %s remaining %s", status.Carryover, status.Available, status.Remaining)
+	}
+	if status.Reference == nil || !status.Reference.Equal(month(2026, 2)) {
+		t.Errorf("expected reference month 2026-02, got %v", status.Reference)
+	}
+
+	nominal, err := service.Status(ctx, "budget-1", month(2026, 2), time.Time{})
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if nominal.Reference != nil || nominal.Carryover.String() != "200" {
+		t.Errorf("expected a nominal status, got %+v", nominal)
+	}
+	if priceIndex.calls != 1 {
+		t.Errorf("expected 1 price index lookup, got %d", priceIndex.calls)
+	}
+
+	if _, err := service.Status(ctx, "budget-1", month(2026, 3), month(2026, 2)); err == nil {
+		t.Error("expected an error for a month without an index")
+	}
+}
+
+func TestBudgetService_Status_WithoutRolloverOnlyReadsRequestedMonth(t *testing.T) {
+	service, repo := newTestService(foodBudget(false))
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	if _, err := service.Status(ctx, "budget-1", month(2026, 5), time.Time{}); err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+
+	if !repo.lastSpending.from.Equal(month(2026, 5)) || !repo.lastSpending.to.Equal(month(2026, 5)) {
+		t.Errorf("expected spending for May only, got %s to %s", repo.lastSpending.from, repo.lastSpending.to)
+	}
+}
+
+func TestBudgetService_Status_Errors(t *testing.T) {
+	service, repo := newTestService(foodBudget(false))
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	if _, err := service.Status(ctx, "budget-1", month(2025, 12), time.Time{}); err == nil || !strings.Contains(err.Error(), "before the start") {
+		t.Errorf("expected month before start error, got %v", err)
+	}
+
+	if _, err := service.Status(&mockContext{userID: "user2", hasID: true}, "budget-1", month(2026, 2), time.Time{}); err == nil {
+		t.Error("expected error reading another user's budget")
+	}
+
+	repo.spendingErr = errors.New("database unavailable")
+	if _, err := service.Status(ctx, "budget-1", month(2026, 2), time.Time{}); err == nil {
+		t.Error("expected repository error to be returned")
+	}
+}
+
+func TestBudgetService_Summary_SkipsBudgetsNotStarted(t *testing.T) {
+	later := domain.NewBudget("budget-2", "user1", "cat-food", shareddomain.MustParseAmount("100"), walletdomain.CurrencyEUR, month(2026, 6), false, 80, "system")
+	service, repo := newTestService(foodBudget(false), later)
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	statuses, err := service.Summary(ctx, month(2026, 3), time.Time{})
+	if err != nil {
+		t.Fatalf("Summary failed: %v", err)
+	}
+
+	if len(statuses) != 1 || statuses[0].BudgetID != "budget-1" {
+		t.Errorf("expected only budget-1 in the summary, got %+v", statuses)
+	}
+	if repo.spendingCalls != 1 {
+		t.Errorf("expected 1 spending query, got %d", repo.spendingCalls)
+	}
+}
+
+func TestBudgetService_Delete(t *testing.T) {
+	service, repo := newTestService(foodBudget(false))
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	if err := service.Delete(ctx, "budget-1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if len(repo.budgets) != 0 {
+		t.Errorf("expected budget to be deleted, got %d budgets", len(repo.budgets))
+	}
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	"fin-flow-api/internal/shared/domain"
+)
+
+var (
+	ErrInvalidAmount          = errors.New("invalid amount")
+	ErrCategoryNotExpense     = errors.New("budgets can only be set on expense categories")
+	ErrInvalidAlertThreshold  = errors.New("alert threshold must be between 1 and 100")
+	ErrMonthBeforeBudgetStart = errors.New("month is before the start of the budget")
+	ErrBudgetAlreadyExists    = errors.New("a budget for this category and currency already exists")
+)
+
+// DefaultAlertThreshold is the percentage of the budget that has to be used
+// before a warning is raised, when none is given.
+const DefaultAlertThreshold = 80
+
+// Budget is a monthly spending limit for an expense category. It applies to
+// every month from StartMonth on, and only to expenses booked in wallets of
+// its currency. With Rollover, what is left over or overspent in a month is
+// carried into the next one.
+type Budget struct {
+	domain.Entity
+
+	ID             string
+	UserID         string
+	CategoryID     string
+	Amount         domain.Amount
+	Currency       walletdomain.Currency
+	StartMonth     time.Time
+	Rollover       bool
+	AlertThreshold int
+}
+
+func NewBudget(id, userID, categoryID string, amount domain.Amount, currency walletdomain.Currency, startMonth time.Time, rollover bool, alertThreshold int, createdBy string) *Budget {
+	if alertThreshold == 0 {
+		alertThreshold = DefaultAlertThreshold
+	}
+
+	return &Budget{
+		Entity:         domain.NewEntity(id, createdBy),
+		ID:             id,
+		UserID:         userID,
+		CategoryID:     categoryID,
+		Amount:         amount,
+		Currency:       currency,
+		StartMonth:     MonthOf(startMonth),
+		Rollover:       rollover,
+		AlertThreshold: alertThreshold,
+	}
+}
+
+// Validate checks the fields that do not depend on other aggregates. The
+// category type is checked by the service.
+func (b *Budget) Validate() error {
+	if !walletdomain.IsValidCurrency(string(b.Currency)) {
+		return walletdomain.ErrInvalidCurrency
+	}
+	if !b.Amount.IsPositive() {
+		return ErrInvalidAmount
+	}
+	if !b.Amount.FitsCurrency(string(b.Currency)) {
+		return domain.ErrAmountPrecision
+	}
+	if b.AlertThreshold < 1 || b.AlertThreshold > 100 {
+		return ErrInvalidAlertThreshold
+	}
+	return nil
+}
+
+// AppliesTo reports whether the budget covers the month containing date.
+func (b *Budget) AppliesTo(date time.Time) bool {
+	return !MonthOf(date).Before(b.StartMonth)
+}
+
+// MonthOf returns the first day of the month containing date, in UTC.
+func MonthOf(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
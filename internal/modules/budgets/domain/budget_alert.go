@@ -0,0 +1,26 @@
+package domain
+
+type BudgetAlert int
+
+const (
+	BudgetAlertOnTrack BudgetAlert = iota
+	BudgetAlertWarning
+	BudgetAlertExceeded
+)
+
+func (ba BudgetAlert) String() string {
+	switch ba {
+	case BudgetAlertOnTrack:
+		return "OnTrack"
+	case BudgetAlertWarning:
+		return "Warning"
+	case BudgetAlertExceeded:
+		return "Exceeded"
+	default:
+		return "Unknown"
+	}
+}
+
+func (ba BudgetAlert) Value() int {
+	return int(ba)
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+const monthKeyLayout = "2006-01"
+
+var hundred = domain.NewAmountFromInt(100)
+
+// MonthlySpending is the total spent in a category during one month.
+type MonthlySpending struct {
+	Month  time.Time
+	Amount domain.Amount
+}
+
+// Restater restates nominal amounts to money of a reference month.
+type Restater interface {
+	Restate(amount domain.Amount, month time.Time) (domain.Amount, error)
+}
+
+// BudgetStatus compares a budget with what was actually spent in a month.
+// Available is the monthly limit plus whatever was carried over from earlier
+// months; PercentageUsed is nil when nothing is available.
+type BudgetStatus struct {
+	Budget         *Budget
+	Month          time.Time
+	Limit          domain.Amount
+	Carryover      domain.Amount
+	Available      domain.Amount
+	Spent          domain.Amount
+	Remaining      domain.Amount
+	PercentageUsed *domain.Amount
+	Alert          BudgetAlert
+}
+
+// StatusFor computes the status of the budget for the month containing
+// date. spending must cover every month from the start of the budget up to
+// that month when the budget rolls over, and at least that month otherwise;
+// months without an entry count as nothing spent. restater may be nil for a
+// nominal status; otherwise the limit and spending of every month involved
+// are restated before they are added up, so it must know the index of each
+// of those months.
+func (b *Budget) StatusFor(date time.Time, spending []MonthlySpending, restater Restater) (*BudgetStatus, error) {
+	month := MonthOf(date)
+	if !b.AppliesTo(month) {
+		return nil, ErrMonthBeforeBudgetStart
+	}
+
+	spentByMonth := make(map[string]domain.Amount, len(spending))
+	for _, entry := range spending {
+		key := MonthOf(entry.Month).Format(monthKeyLayout)
+		spentByMonth[key] = spentByMonth[key].Add(entry.Amount)
+	}
+
+	var carryover domain.Amount
+	if b.Rollover {
+		for m := b.StartMonth; m.Before(month); m = m.AddDate(0, 1, 0) {
+			limit, spent, err := b.monthFigures(m, spentByMonth, restater)
+			if err != nil {
+				return nil, err
+			}
+			carryover = carryover.Add(limit).Sub(spent)
+		}
+	}
+
+	limit, spent, err := b.monthFigures(month, spentByMonth, restater)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &BudgetStatus{
+		Budget:    b,
+		Month:     month,
+		Limit:     limit,
+		Carryover: carryover,
+		Available: limit.Add(carryover),
+		Spent:     spent,
+	}
+	status.Remaining = status.Available.Sub(status.Spent)
+
+	if status.Available.IsPositive() {
+		percentage := status.Spent.Mul(hundred).DivRound(status.Available, 2)
+		status.PercentageUsed = &percentage
+	}
+
+	status.Alert = b.alertFor(status)
+	return status, nil
+}
+
+// monthFigures returns the limit and the spending of month, restated when
+// restater is not nil.
+func (b *Budget) monthFigures(month time.Time, spentByMonth map[string]domain.Amount, restater Restater) (domain.Amount, domain.Amount, error) {
+	limit, spent := b.Amount, spentByMonth[month.Format(monthKeyLayout)]
+	if restater == nil {
+		return limit, spent, nil
+	}
+
+	limit, err := restater.Restate(limit, month)
+	if err != nil {
+		return domain.Amount{}, domain.Amount{}, err
+	}
+	spent, err = restater.Restate(spent, month)
+	if err != nil {
+		return domain.Amount{}, domain.Amount{}, err
+	}
+	return limit, spent, nil
+}
+
+func (b *Budget) alertFor(status *BudgetStatus) BudgetAlert {
+	switch {
+	case status.Remaining.IsNegative():
+		return BudgetAlertExceeded
+	case status.PercentageUsed == nil:
+		// Earlier overspending used up this month's limit.
+		return BudgetAlertWarning
+	case status.Spent.Mul(hundred).Cmp(status.Available.Mul(domain.NewAmountFromInt(int64(b.AlertThreshold)))) >= 0:
+		// Compared without rounding so 79.998% does not count as 80%.
+		return BudgetAlertWarning
+	default:
+		return BudgetAlertOnTrack
+	}
+}
@@ -0,0 +1,240 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+func month(year int, m time.Month) time.Time {
+	return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func spent(year int, m time.Month, amount string) MonthlySpending {
+	return MonthlySpending{Month: month(year, m), Amount: shareddomain.MustParseAmount(amount)}
+}
+
+func TestNewBudget(t *testing.T) {
+	budget := NewBudget("budget-1", "user-1", "cat-food", shareddomain.MustParseAmount("500"), walletdomain.CurrencyUSD, time.Date(2026, 3, 17, 15, 0, 0, 0, time.UTC), true, 0, "system")
+
+	if !budget.StartMonth.Equal(month(2026, 3)) {
+		t.Errorf("expected start month 2026-03-01, got %s", budget.StartMonth)
+	}
+	if budget.AlertThreshold != DefaultAlertThreshold {
+		t.Errorf("expected default alert threshold %d, got %d", DefaultAlertThreshold, budget.AlertThreshold)
+	}
+	if budget.CreatedBy != "system" {
+		t.Errorf("expected CreatedBy system, got %s", budget.CreatedBy)
+	}
+}
+
+func TestBudget_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(b *Budget)
+		wantErr error
+	}{
+		{"valid", func(b *Budget) {}, nil},
+		{"invalid currency", func(b *Budget) { b.Currency = "XXX" }, walletdomain.ErrInvalidCurrency},
+		{"zero amount", func(b *Budget) { b.Amount = shareddomain.Amount{} }, ErrInvalidAmount},
+		{"precision", func(b *Budget) { b.Amount = shareddomain.MustParseAmount("10.001") }, shareddomain.ErrAmountPrecision},
+		{"threshold too high", func(b *Budget) { b.AlertThreshold = 101 }, ErrInvalidAlertThreshold},
+		{"negative threshold", func(b *Budget) { b.AlertThreshold = -5 }, ErrInvalidAlertThreshold},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			budget := NewBudget("budget-1", "user-1", "cat-food", shareddomain.MustParseAmount("500"), walletdomain.CurrencyUSD, month(2026, 1), false, 80, "system")
+			tt.mutate(budget)
+			if err := budget.Validate(); err != tt.wantErr {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestBudget_StatusFor_WithoutRollover(t *testing.T) {
+	budget := NewBudget("budget-1", "user-1", "cat-food", shareddomain.MustParseAmount("500"), walletdomain.CurrencyUSD, month(2026, 1), false, 80, "system")
+
+	status, err := budget.StatusFor(time.Date(2026, 2, 20, 0, 0, 0, 0, time.UTC), []MonthlySpending{
+		spent(2026, 1, "100"),
+		spent(2026, 2, "125.50"),
+	}, nil)
+	if err != nil {
+		t.Fatalf("StatusFor failed: %v", err)
+	}
+
+	if !status.Month.Equal(month(2026, 2)) {
+		t.Errorf("expected month 2026-02, got %s", status.Month)
+	}
+	if !status.Carryover.IsZero() {
+		t.Errorf("expected no carryover without rollover, got %s", status.Carryover)
+	}
+	if status.Available.String() != "500" || status.Spent.String() != "125.5" || status.Remaining.String() != "374.5" {
+		t.Errorf("unexpected amounts: available %s spent %s remaining %s", status.Available, status.Spent, status.Remaining)
+	}
+	if status.PercentageUsed == nil || status.PercentageUsed.String() != "25.1" {
+		t.Errorf("expected 25.1%% used, got %v", status.PercentageUsed)
+	}
+	if status.Alert != BudgetAlertOnTrack {
+		t.Errorf("expected OnTrack, got %s", status.Alert)
+	}
+}
+
+func TestBudget_StatusFor_RolloverCarriesUnspentAndOverspent(t *testing.T) {
+	budget := NewBudget("budget-1", "user-1", "cat-food", shareddomain.MustParseAmount("500"), walletdomain.CurrencyUSD, month(2026, 1), true, 80, "system")
+
+	spending := []MonthlySpending{
+		spent(2026, 1, "300"), // 200 left over
+		spent(2026, 2, "800"), // 100 overspent after the carryover
+		spent(2026, 4, "50"),
+	}
+
+	tests := []struct {
+		month         time.Time
+		wantCarryover string
+		wantAvailable string
+		wantRemaining string
+	}{
+		{month(2026, 1), "0", "500", "200"},
+		{month(2026, 2), "200", "700", "-100"},
+		{month(2026, 3), "-100", "400", "400"},
+		{month(2026, 4), "400", "900", "850"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.month.Format("2006-01"), func(t *testing.T) {
+			status, err := budget.StatusFor(tt.month, spending, nil)
+			if err != nil {
+				t.Fatalf("StatusFor failed: %v", err)
+			}
+			if status.Carryover.String() != tt.wantCarryover {
+				t.Errorf("expected carryover %s, got %s", tt.wantCarryover, status.Carryover)
+			}
+			if status.Available.String() != tt.wantAvailable {
+				t.Errorf("expected available %s, got %s", tt.wantAvailable, status.Available)
+			}
+			if status.Remaining.String() != tt.wantRemaining {
+				t.Errorf("expected remaining %s, got %s", tt.wantRemaining, status.Remaining)
+			}
+		})
+	}
+}
+
+func TestBudget_StatusFor_Alerts(t *testing.T) {
+	tests := []struct {
+		name      string
+		rollover  bool
+		spending  []MonthlySpending
+		wantAlert BudgetAlert
+	}{
+		{"below threshold", false, []MonthlySpending{spent(2026, 2, "399.99")}, BudgetAlertOnTrack},
+		{"at threshold", false, []MonthlySpending{spent(2026, 2, "400")}, BudgetAlertWarning},
+		{"fully used", false, []MonthlySpending{spent(2026, 2, "500")}, BudgetAlertWarning},
+		{"over budget", false, []MonthlySpending{spent(2026, 2, "500.01")}, BudgetAlertExceeded},
+		{"limit used up by earlier overspending", true, []MonthlySpending{spent(2026, 1, "1000")}, BudgetAlertWarning},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			budget := NewBudget("budget-1", "user-1", "cat-food", shareddomain.MustParseAmount("500"), walletdomain.CurrencyUSD, month(2026, 1), tt.rollover, 80, "system")
+
+			status, err := budget.StatusFor(month(2026, 2), tt.spending, nil)
+			if err != nil {
+				t.Fatalf("StatusFor failed: %v", err)
+			}
+			if status.Alert != tt.wantAlert {
+				t.Errorf("expected %s, got %s", tt.wantAlert, status.Alert)
+			}
+		})
+	}
+}
+
+func TestBudget_StatusFor_NoPercentageWithoutAvailableAmount(t *testing.T) {
+	budget := NewBudget("budget-1", "user-1", "cat-food", shareddomain.MustParseAmount("500"), walletdomain.CurrencyUSD, month(2026, 1), true, 80, "system")
+
+	status, err := budget.StatusFor(month(2026, 2), []MonthlySpending{spent(2026, 1, "1200"), spent(2026, 2, "10")}, nil)
+	if err != nil {
+		t.Fatalf("StatusFor failed: %v", err)
+	}
+
+	if status.PercentageUsed != nil {
+		t.Errorf("expected no percentage when nothing is available, got %s", status.PercentageUsed)
+	}
+	if status.Alert != BudgetAlertExceeded {
+		t.Errorf("expected Exceeded, got %s", status.Alert)
+	}
+}
+
+func TestBudget_StatusFor_BeforeStart(t *testing.T) {
+	budget := NewBudget("budget-1", "user-1", "cat-food", shareddomain.MustParseAmount("500"), walletdomain.CurrencyUSD, month(2026, 3), false, 80, "system")
+
+	if _, err := budget.StatusFor(month(2026, 2), nil, nil); err != ErrMonthBeforeBudgetStart {
+		t.Errorf("expected ErrMonthBeforeBudgetStart, got %v", err)
+	}
+}
+
+// factorRestater multiplies the amounts of each month by its factor and
+// fails for months without one.
+type factorRestater map[string]string
+
+func (r factorRestater) Restate(value shareddomain.Amount, m time.Time) (shareddomain.Amount, error) {
+	factor, ok := r[m.Format(monthKeyLayout)]
+	if !ok {
+		return shareddomain.Amount{}, fmt.Errorf("price index not found in %s", m.Format(monthKeyLayout))
+	}
+	return value.Mul(shareddomain.MustParseAmount(factor)), nil
+}
+
+func TestBudget_StatusFor_Restated(t *testing.T) {
+	budget := NewBudget("budget-1", "user-1", "cat-food", shareddomain.MustParseAmount("500"), walletdomain.CurrencyARS, month(2026, 1), true, 80, "system")
+	spending := []MonthlySpending{spent(2026, 1, "100"), spent(2026, 2, "200")}
+
+	status, err := budget.StatusFor(month(2026, 2), spending, factorRestater{"2026-01": "1.2", "2026-02": "1.1"})
+	if err != nil {
+		t.Fatalf("StatusFor failed: %v", err)
+	}
+
+	// January leaves 400 over, worth 480 in reference money; February's
+	// limit and spending are worth 550 and 220.
+	checks := map[string]struct{ got, want string }{
+		"limit":     {status.Limit.String(), "550"},
+		"carryover": {status.Carryover.String(), "480"},
+		"available": {status.Available.String(), "1030"},
+		"spent":     {status.Spent.String(), "220"},
+		"remaining": {status.Remaining.String(), "810"},
+	}
+	for name, check := range checks {
+		if check.got != check.want {
+			t.Errorf("expected %s %s, got %s", name, check.want, check.got)
+		}
+	}
+	if status.PercentageUsed == nil || status.PercentageUsed.String() != "21.36" {
+		t.Errorf("expected 21.36%% used, got %v", status.PercentageUsed)
+	}
+
+	if _, err := budget.StatusFor(month(2026, 2), spending, factorRestater{"2026-02": "1.1"}); err == nil {
+		t.Error("expected an error when a month has no index")
+	}
+}
+
+func TestBudgetAlert_String(t *testing.T) {
+	tests := []struct {
+		alert BudgetAlert
+		want  string
+	}{
+		{BudgetAlertOnTrack, "OnTrack"},
+		{BudgetAlertWarning, "Warning"},
+		{BudgetAlertExceeded, "Exceeded"},
+		{BudgetAlert(99), "Unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.alert.String(); got != tt.want {
+			t.Errorf("BudgetAlert(%d).String() = %s, want %s", tt.alert, got, tt.want)
+		}
+	}
+}
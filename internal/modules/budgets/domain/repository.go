@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"time"
+
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+)
+
+type BudgetRepository interface {
+	Create(budget *Budget) error
+	GetByID(id string, userID string) (*Budget, error)
+	List(userID string) ([]*Budget, error)
+	Update(budget *Budget) error
+	Delete(id string, userID string) error
+	// MonthlySpending returns the expenses of a user booked to categoryID or
+	// any of its subcategories in wallets of the given currency, grouped by
+	// month, for the months from from to to inclusive.
+	MonthlySpending(userID, categoryID string, currency walletdomain.Currency, from, to time.Time) ([]MonthlySpending, error)
+}
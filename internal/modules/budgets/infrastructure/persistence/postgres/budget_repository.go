@@ -0,0 +1,255 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"fin-flow-api/internal/modules/budgets/domain"
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const budgetColumns = `id, user_id, category_id, amount, currency, start_month, rollover, alert_threshold, created_at, modified_at, created_by, modified_by`
+
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+func (r *Repository) Create(budget *domain.Budget) error {
+	query := `
+		INSERT INTO budgets (` + budgetColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	_, err := r.pool.Exec(
+		context.Background(),
+		query,
+		budget.ID,
+		budget.UserID,
+		budget.CategoryID,
+		budget.Amount,
+		budget.Currency.String(),
+		budget.StartMonth,
+		budget.Rollover,
+		budget.AlertThreshold,
+		budget.CreatedAt,
+		budget.ModifiedAt,
+		budget.CreatedBy,
+		budget.ModifiedBy,
+	)
+	if err != nil {
+		return mapWriteError("failed to create budget", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetByID(id string, userID string) (*domain.Budget, error) {
+	checkQuery := `SELECT user_id FROM budgets WHERE id = $1`
+	var budgetUserID string
+	err := r.pool.QueryRow(context.Background(), checkQuery, id).Scan(&budgetUserID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("budget not found")
+		}
+		return nil, fmt.Errorf("failed to get budget: %w", err)
+	}
+
+	if budgetUserID != userID {
+		return nil, fmt.Errorf("unauthorized access to budget")
+	}
+
+	query := `SELECT ` + budgetColumns + ` FROM budgets WHERE id = $1 AND user_id = $2`
+
+	budget, err := scanBudget(r.pool.QueryRow(context.Background(), query, id, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("budget not found")
+		}
+		return nil, fmt.Errorf("failed to get budget: %w", err)
+	}
+
+	return budget, nil
+}
+
+func (r *Repository) List(userID string) ([]*domain.Budget, error) {
+	query := `SELECT ` + budgetColumns + ` FROM budgets WHERE user_id = $1 ORDER BY created_at ASC`
+
+	rows, err := r.pool.Query(context.Background(), query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list budgets: %w", err)
+	}
+	defer rows.Close()
+
+	var budgets []*domain.Budget
+	for rows.Next() {
+		budget, err := scanBudget(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan budget: %w", err)
+		}
+		budgets = append(budgets, budget)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate budgets: %w", err)
+	}
+
+	return budgets, nil
+}
+
+func (r *Repository) Update(budget *domain.Budget) error {
+	query := `
+		UPDATE budgets
+		SET category_id = $2, amount = $3, currency = $4, start_month = $5, rollover = $6,
+			alert_threshold = $7, modified_at = $8, modified_by = $9
+		WHERE id = $1 AND user_id = $10
+	`
+
+	result, err := r.pool.Exec(
+		context.Background(),
+		query,
+		budget.ID,
+		budget.CategoryID,
+		budget.Amount,
+		budget.Currency.String(),
+		budget.StartMonth,
+		budget.Rollover,
+		budget.AlertThreshold,
+		budget.ModifiedAt,
+		budget.ModifiedBy,
+		budget.UserID,
+	)
+	if err != nil {
+		return mapWriteError("failed to update budget", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("budget not found")
+	}
+
+	return nil
+}
+
+func (r *Repository) Delete(id string, userID string) error {
+	checkQuery := `SELECT user_id FROM budgets WHERE id = $1`
+	var budgetUserID string
+	err := r.pool.QueryRow(context.Background(), checkQuery, id).Scan(&budgetUserID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("budget not found")
+		}
+		return fmt.Errorf("failed to delete budget: %w", err)
+	}
+
+	if budgetUserID != userID {
+		return fmt.Errorf("unauthorized access to budget")
+	}
+
+	query := `DELETE FROM budgets WHERE id = $1 AND user_id = $2`
+
+	if _, err := r.pool.Exec(context.Background(), query, id, userID); err != nil {
+		return fmt.Errorf("failed to delete budget: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) MonthlySpending(userID, categoryID string, currency walletdomain.Currency, from, to time.Time) ([]domain.MonthlySpending, error) {
+	query := `
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM categories WHERE id = $2 AND user_id = $1
+			UNION
+			SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+		)
+		SELECT date_trunc('month', t.date)::date AS month, SUM(t.amount)
+		FROM transactions t
+		JOIN wallets w ON w.id = t.wallet_id
+		WHERE t.user_id = $1 AND t.category_id IN (SELECT id FROM subtree) AND t.type = $3 AND w.currency = $4
+			AND t.date >= $5 AND t.date < $6
+		GROUP BY month
+		ORDER BY month
+	`
+
+	rows, err := r.pool.Query(
+		context.Background(),
+		query,
+		userID,
+		categoryID,
+		transactiondomain.TransactionTypeExpense.Value(),
+		currency.String(),
+		domain.MonthOf(from),
+		domain.MonthOf(to).AddDate(0, 1, 0),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budget spending: %w", err)
+	}
+	defer rows.Close()
+
+	var spending []domain.MonthlySpending
+	for rows.Next() {
+		var entry domain.MonthlySpending
+		if err := rows.Scan(&entry.Month, &entry.Amount); err != nil {
+			return nil, fmt.Errorf("failed to scan budget spending: %w", err)
+		}
+		spending = append(spending, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate budget spending: %w", err)
+	}
+
+	return spending, nil
+}
+
+func scanBudget(row pgx.Row) (*domain.Budget, error) {
+	var budget domain.Budget
+	var currency string
+
+	err := row.Scan(
+		&budget.ID,
+		&budget.UserID,
+		&budget.CategoryID,
+		&budget.Amount,
+		&currency,
+		&budget.StartMonth,
+		&budget.Rollover,
+		&budget.AlertThreshold,
+		&budget.CreatedAt,
+		&budget.ModifiedAt,
+		&budget.CreatedBy,
+		&budget.ModifiedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	budget.Currency = walletdomain.Currency(currency)
+
+	return &budget, nil
+}
+
+func mapWriteError(message string, err error) error {
+	if pgErr, ok := err.(*pgconn.PgError); ok {
+		switch pgErr.Code {
+		case "23505": // unique_violation
+			return domain.ErrBudgetAlreadyExists
+		case "23503": // foreign_key_violation
+			return fmt.Errorf("invalid category reference")
+		case "23514": // check_violation
+			return domain.ErrInvalidAmount
+		}
+	}
+	return fmt.Errorf("%s: %w", message, err)
+}
@@ -0,0 +1,12 @@
+package http
+
+import shareddomain "fin-flow-api/internal/shared/domain"
+
+type BudgetRequest struct {
+	CategoryID     string               `json:"category_id"`
+	Amount         *shareddomain.Amount `json:"amount"`
+	Currency       string               `json:"currency"`
+	StartMonth     string               `json:"start_month"`
+	Rollover       bool                 `json:"rollover"`
+	AlertThreshold int                  `json:"alert_threshold"`
+}
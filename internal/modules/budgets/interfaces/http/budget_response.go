@@ -0,0 +1,37 @@
+package http
+
+import (
+	"time"
+
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+type BudgetResponse struct {
+	ID             string              `json:"id"`
+	CategoryID     string              `json:"category_id"`
+	Amount         shareddomain.Amount `json:"amount"`
+	Currency       string              `json:"currency"`
+	StartMonth     string              `json:"start_month"`
+	Rollover       bool                `json:"rollover"`
+	AlertThreshold int                 `json:"alert_threshold"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+	CreatedBy      string              `json:"created_by"`
+	UpdatedBy      string              `json:"updated_by"`
+}
+
+type BudgetStatusResponse struct {
+	BudgetID       string               `json:"budget_id"`
+	CategoryID     string               `json:"category_id"`
+	Currency       string               `json:"currency"`
+	Month          string               `json:"month"`
+	Reference      *string              `json:"reference_month"`
+	Limit          shareddomain.Amount  `json:"limit"`
+	Carryover      shareddomain.Amount  `json:"carryover"`
+	Available      shareddomain.Amount  `json:"available"`
+	Spent          shareddomain.Amount  `json:"spent"`
+	Remaining      shareddomain.Amount  `json:"remaining"`
+	PercentageUsed *shareddomain.Amount `json:"percentage_used"`
+	Alert          int                  `json:"alert"`
+	AlertName      string               `json:"alert_name"`
+}
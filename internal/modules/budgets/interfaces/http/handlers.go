@@ -0,0 +1,399 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"fin-flow-api/internal/modules/budgets/application/contracts/commands"
+	"fin-flow-api/internal/modules/budgets/application/contracts/queries"
+	basehandler "fin-flow-api/internal/shared/http"
+)
+
+const monthLayout = "2006-01"
+
+type budgetService interface {
+	Create(ctx context.Context, req commands.BudgetRequest) error
+	GetByID(ctx context.Context, id string) (*queries.BudgetResponse, error)
+	Update(ctx context.Context, id string, req commands.BudgetRequest) error
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context) ([]*queries.BudgetResponse, error)
+	Status(ctx context.Context, id string, month, reference time.Time) (*queries.BudgetStatusResponse, error)
+	Summary(ctx context.Context, month, reference time.Time) ([]*queries.BudgetStatusResponse, error)
+}
+
+type Handler struct {
+	budgetService budgetService
+}
+
+func NewHandler(budgetService budgetService) *Handler {
+	return &Handler{
+		budgetService: budgetService,
+	}
+}
+
+func (h *Handler) CreateBudget(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var reqDTO BudgetRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	cmd, err := toBudgetCommand(reqDTO)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.budgetService.Create(r.Context(), cmd); err != nil {
+		statusCode, errorMsg := budgetErrorResponse(err, "create")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Budget created successfully")
+}
+
+func (h *Handler) GetBudget(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/budgets/")
+	id := strings.Split(path, "/")[0]
+
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Budget ID is required in the URL path")
+		return
+	}
+
+	budget, err := h.budgetService.GetByID(r.Context(), id)
+	if err != nil {
+		statusCode, errorMsg := budgetErrorResponse(err, "access")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, toBudgetResponse(budget))
+}
+
+func (h *Handler) UpdateBudget(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/budgets/")
+	id := strings.Split(path, "/")[0]
+
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Budget ID is required in the URL path")
+		return
+	}
+
+	var reqDTO BudgetRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	cmd, err := toBudgetCommand(reqDTO)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.budgetService.Update(r.Context(), id, cmd); err != nil {
+		statusCode, errorMsg := budgetErrorResponse(err, "update")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Budget updated successfully")
+}
+
+func (h *Handler) DeleteBudget(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/budgets/")
+	id := strings.Split(path, "/")[0]
+
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Budget ID is required in the URL path")
+		return
+	}
+
+	if err := h.budgetService.Delete(r.Context(), id); err != nil {
+		statusCode, errorMsg := budgetErrorResponse(err, "delete")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Budget deleted successfully")
+}
+
+func (h *Handler) ListBudgets(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	budgets, err := h.budgetService.List(r.Context())
+	if err != nil {
+		statusCode, errorMsg := budgetErrorResponse(err, "access")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	responses := make([]BudgetResponse, len(budgets))
+	for i, budget := range budgets {
+		responses[i] = toBudgetResponse(budget)
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, responses)
+}
+
+// GetBudgetStatus handles GET /budgets/{id}/status?month=YYYY-MM. The
+// month defaults to the current one; reference=YYYY-MM restates the figures
+// to constant currency of that month.
+func (h *Handler) GetBudgetStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/budgets/")
+	id := strings.Split(path, "/")[0]
+
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Budget ID is required in the URL path")
+		return
+	}
+
+	month, err := parseMonth(r)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	reference, err := parseReference(r)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	status, err := h.budgetService.Status(r.Context(), id, month, reference)
+	if err != nil {
+		statusCode, errorMsg := budgetErrorResponse(err, "access")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, toBudgetStatusResponse(status))
+}
+
+// GetBudgetSummary handles GET /budgets/summary?month=YYYY-MM and returns
+// budget vs. actual for every budget active in that month, restated like
+// GetBudgetStatus when reference is given.
+func (h *Handler) GetBudgetSummary(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	month, err := parseMonth(r)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	reference, err := parseReference(r)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	statuses, err := h.budgetService.Summary(r.Context(), month, reference)
+	if err != nil {
+		statusCode, errorMsg := budgetErrorResponse(err, "access")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	responses := make([]BudgetStatusResponse, len(statuses))
+	for i, status := range statuses {
+		responses[i] = toBudgetStatusResponse(status)
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, responses)
+}
+
+func parseMonth(r *http.Request) (time.Time, error) {
+	value := r.URL.Query().Get("month")
+	if value == "" {
+		now := time.Now().UTC()
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC), nil
+	}
+
+	month, err := time.Parse(monthLayout, value)
+	if err != nil {
+		return time.Time{}, &ValidationError{Field: "month", Message: "Month must use the YYYY-MM format"}
+	}
+
+	return month, nil
+}
+
+// parseReference returns the reference month of the request, zero when
+// the figures are asked for in nominal terms.
+func parseReference(r *http.Request) (time.Time, error) {
+	value := r.URL.Query().Get("reference")
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	reference, err := time.Parse(monthLayout, value)
+	if err != nil {
+		return time.Time{}, &ValidationError{Field: "reference", Message: "Month must use the YYYY-MM format"}
+	}
+
+	return reference, nil
+}
+
+func toBudgetCommand(req BudgetRequest) (commands.BudgetRequest, error) {
+	if err := validateBudgetRequest(req); err != nil {
+		return commands.BudgetRequest{}, err
+	}
+
+	startMonth, err := time.Parse(monthLayout, req.StartMonth)
+	if err != nil {
+		return commands.BudgetRequest{}, &ValidationError{Field: "start_month", Message: "Start month must use the YYYY-MM format"}
+	}
+
+	return commands.BudgetRequest{
+		CategoryID:     strings.TrimSpace(req.CategoryID),
+		Amount:         *req.Amount,
+		Currency:       strings.ToUpper(strings.TrimSpace(req.Currency)),
+		StartMonth:     startMonth,
+		Rollover:       req.Rollover,
+		AlertThreshold: req.AlertThreshold,
+	}, nil
+}
+
+func validateBudgetRequest(req BudgetRequest) error {
+	if strings.TrimSpace(req.CategoryID) == "" {
+		return &ValidationError{Field: "category_id", Message: "Category ID is required"}
+	}
+
+	if req.Amount == nil {
+		return &ValidationError{Field: "amount", Message: "Amount is required"}
+	}
+
+	if !req.Amount.IsPositive() {
+		return &ValidationError{Field: "amount", Message: "Amount must be greater than zero"}
+	}
+
+	if strings.TrimSpace(req.Currency) == "" {
+		return &ValidationError{Field: "currency", Message: "Currency is required"}
+	}
+
+	if req.StartMonth == "" {
+		return &ValidationError{Field: "start_month", Message: "Start month is required"}
+	}
+
+	if req.AlertThreshold < 0 || req.AlertThreshold > 100 {
+		return &ValidationError{Field: "alert_threshold", Message: "Alert threshold must be between 1 and 100"}
+	}
+
+	return nil
+}
+
+func budgetErrorResponse(err error, action string) (int, string) {
+	errorMsg := err.Error()
+
+	switch {
+	case strings.Contains(errorMsg, "user not authenticated"):
+		return http.StatusUnauthorized, "Authentication required"
+	case strings.Contains(errorMsg, "unauthorized access to budget"):
+		return http.StatusForbidden, "You do not have permission to " + action + " this budget"
+	case strings.Contains(errorMsg, "unauthorized access to category"):
+		return http.StatusForbidden, "You do not have permission to use this category"
+	case strings.Contains(errorMsg, "budget not found"):
+		return http.StatusNotFound, "Budget not found"
+	case strings.Contains(errorMsg, "category not found"), strings.Contains(errorMsg, "invalid category reference"):
+		return http.StatusBadRequest, "Category not found"
+	case strings.Contains(errorMsg, "already exists"):
+		return http.StatusConflict, "A budget for this category and currency already exists"
+	case strings.Contains(errorMsg, "invalid amount"):
+		return http.StatusBadRequest, "Amount must be greater than zero"
+	case strings.Contains(errorMsg, "decimal places"):
+		return http.StatusBadRequest, "Amount has more decimal places than the currency allows"
+	case strings.Contains(errorMsg, "invalid currency"):
+		return http.StatusBadRequest, "Invalid currency"
+	case strings.Contains(errorMsg, "price index not found"):
+		return http.StatusNotFound, errorMsg
+	case strings.Contains(errorMsg, "expense categories"),
+		strings.Contains(errorMsg, "alert threshold"),
+		strings.Contains(errorMsg, "before the start of the budget"):
+		return http.StatusBadRequest, errorMsg
+	}
+
+	return http.StatusInternalServerError, errorMsg
+}
+
+func toBudgetResponse(budget *queries.BudgetResponse) BudgetResponse {
+	return BudgetResponse{
+		ID:             budget.ID,
+		CategoryID:     budget.CategoryID,
+		Amount:         budget.Amount,
+		Currency:       budget.Currency,
+		StartMonth:     budget.StartMonth.Format(monthLayout),
+		Rollover:       budget.Rollover,
+		AlertThreshold: budget.AlertThreshold,
+		CreatedAt:      budget.CreatedAt,
+		UpdatedAt:      budget.UpdatedAt,
+		CreatedBy:      budget.CreatedBy,
+		UpdatedBy:      budget.UpdatedBy,
+	}
+}
+
+func toBudgetStatusResponse(status *queries.BudgetStatusResponse) BudgetStatusResponse {
+	response := BudgetStatusResponse{
+		BudgetID:       status.BudgetID,
+		CategoryID:     status.CategoryID,
+		Currency:       status.Currency,
+		Month:          status.Month.Format(monthLayout),
+		Limit:          status.Limit,
+		Carryover:      status.Carryover,
+		Available:      status.Available,
+		Spent:          status.Spent,
+		Remaining:      status.Remaining,
+		PercentageUsed: status.PercentageUsed,
+		Alert:          status.Alert,
+		AlertName:      status.AlertName,
+	}
+	if status.Reference != nil {
+		reference := status.Reference.Format(monthLayout)
+		response.Reference = &reference
+	}
+	return response
+}
+
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
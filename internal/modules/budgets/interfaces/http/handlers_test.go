@@ -0,0 +1,363 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"fin-flow-api/internal/modules/budgets/application/contracts/commands"
+	"fin-flow-api/internal/modules/budgets/application/contracts/queries"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+type mockBudgetService struct {
+	createErr   error
+	getByIDErr  error
+	updateErr   error
+	deleteErr   error
+	listErr     error
+	statusErr   error
+	budget      *queries.BudgetResponse
+	budgets     []*queries.BudgetResponse
+	status      *queries.BudgetStatusResponse
+	statuses    []*queries.BudgetStatusResponse
+	lastCommand commands.BudgetRequest
+	lastID      string
+	lastMonth   time.Time
+	lastRef     time.Time
+}
+
+func (m *mockBudgetService) Create(ctx context.Context, req commands.BudgetRequest) error {
+	m.lastCommand = req
+	return m.createErr
+}
+
+func (m *mockBudgetService) GetByID(ctx context.Context, id string) (*queries.BudgetResponse, error) {
+	m.lastID = id
+	if m.getByIDErr != nil {
+		return nil, m.getByIDErr
+	}
+	return m.budget, nil
+}
+
+func (m *mockBudgetService) Update(ctx context.Context, id string, req commands.BudgetRequest) error {
+	m.lastID = id
+	m.lastCommand = req
+	return m.updateErr
+}
+
+func (m *mockBudgetService) Delete(ctx context.Context, id string) error {
+	m.lastID = id
+	return m.deleteErr
+}
+
+func (m *mockBudgetService) List(ctx context.Context) ([]*queries.BudgetResponse, error) {
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	return m.budgets, nil
+}
+
+func (m *mockBudgetService) Status(ctx context.Context, id string, month, reference time.Time) (*queries.BudgetStatusResponse, error) {
+	m.lastID = id
+	m.lastMonth = month
+	m.lastRef = reference
+	if m.statusErr != nil {
+		return nil, m.statusErr
+	}
+	return m.status, nil
+}
+
+func (m *mockBudgetService) Summary(ctx context.Context, month, reference time.Time) ([]*queries.BudgetStatusResponse, error) {
+	m.lastMonth = month
+	m.lastRef = reference
+	if m.statusErr != nil {
+		return nil, m.statusErr
+	}
+	return m.statuses, nil
+}
+
+func validBudgetBody() BudgetRequest {
+	amount := shareddomain.MustParseAmount("500.00")
+	return BudgetRequest{
+		CategoryID:     "category1",
+		Amount:         &amount,
+		Currency:       "usd",
+		StartMonth:     "2026-01",
+		Rollover:       true,
+		AlertThreshold: 90,
+	}
+}
+
+func sampleStatus() *queries.BudgetStatusResponse {
+	percentage := shareddomain.MustParseAmount("62.5")
+	return &queries.BudgetStatusResponse{
+		BudgetID:       "budget1",
+		CategoryID:     "category1",
+		Currency:       "USD",
+		Month:          time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		Limit:          shareddomain.MustParseAmount("500"),
+		Carryover:      shareddomain.MustParseAmount("300"),
+		Available:      shareddomain.MustParseAmount("800"),
+		Spent:          shareddomain.MustParseAmount("500"),
+		Remaining:      shareddomain.MustParseAmount("300"),
+		PercentageUsed: &percentage,
+		Alert:          0,
+		AlertName:      "OnTrack",
+	}
+}
+
+func TestCreateBudget_Success(t *testing.T) {
+	service := &mockBudgetService{}
+	handler := &Handler{budgetService: service}
+
+	jsonBody, _ := json.Marshal(validBudgetBody())
+
+	req := httptest.NewRequest("POST", "/budgets", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	handler.CreateBudget(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rr.Code)
+	}
+	if !service.lastCommand.StartMonth.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected start month %v", service.lastCommand.StartMonth)
+	}
+	if service.lastCommand.Currency != "USD" {
+		t.Errorf("expected currency USD, got %s", service.lastCommand.Currency)
+	}
+	if service.lastCommand.Amount.String() != "500" {
+		t.Errorf("expected amount 500, got %s", service.lastCommand.Amount)
+	}
+}
+
+func TestCreateBudget_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*BudgetRequest)
+	}{
+		{"missing category", func(r *BudgetRequest) { r.CategoryID = "" }},
+		{"missing amount", func(r *BudgetRequest) { r.Amount = nil }},
+		{"negative amount", func(r *BudgetRequest) { a := shareddomain.MustParseAmount("-1"); r.Amount = &a }},
+		{"missing currency", func(r *BudgetRequest) { r.Currency = " " }},
+		{"missing start month", func(r *BudgetRequest) { r.StartMonth = "" }},
+		{"invalid start month", func(r *BudgetRequest) { r.StartMonth = "2026-01-15" }},
+		{"threshold too high", func(r *BudgetRequest) { r.AlertThreshold = 150 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := validBudgetBody()
+			tt.mutate(&body)
+
+			if _, err := toBudgetCommand(body); err == nil {
+				t.Error("expected validation error, got nil")
+			}
+		})
+	}
+}
+
+func TestCreateBudget_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"unauthenticated", errors.New("user not authenticated"), http.StatusUnauthorized},
+		{"foreign category", errors.New("unauthorized access to category"), http.StatusForbidden},
+		{"missing category", errors.New("category not found"), http.StatusBadRequest},
+		{"income category", errors.New("budgets can only be set on expense categories"), http.StatusBadRequest},
+		{"duplicate", errors.New("a budget for this category and currency already exists"), http.StatusConflict},
+		{"currency", errors.New("invalid currency"), http.StatusBadRequest},
+		{"precision", shareddomain.ErrAmountPrecision, http.StatusBadRequest},
+		{"unexpected", errors.New("boom"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &Handler{budgetService: &mockBudgetService{createErr: tt.err}}
+
+			jsonBody, _ := json.Marshal(validBudgetBody())
+			req := httptest.NewRequest("POST", "/budgets", bytes.NewBuffer(jsonBody))
+			rr := httptest.NewRecorder()
+			handler.CreateBudget(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestGetBudget_NotFound(t *testing.T) {
+	service := &mockBudgetService{getByIDErr: errors.New("budget not found")}
+	handler := &Handler{budgetService: service}
+
+	req := httptest.NewRequest("GET", "/budgets/missing", nil)
+	rr := httptest.NewRecorder()
+	handler.GetBudget(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", rr.Code)
+	}
+	if service.lastID != "missing" {
+		t.Errorf("expected id missing, got %s", service.lastID)
+	}
+}
+
+func TestGetBudgetStatus_Success(t *testing.T) {
+	service := &mockBudgetService{status: sampleStatus()}
+	handler := &Handler{budgetService: service}
+
+	req := httptest.NewRequest("GET", "/budgets/budget1/status?month=2026-02", nil)
+	rr := httptest.NewRecorder()
+	handler.GetBudgetStatus(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if service.lastID != "budget1" {
+		t.Errorf("expected id budget1, got %s", service.lastID)
+	}
+	if !service.lastMonth.Equal(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected month %v", service.lastMonth)
+	}
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response["month"] != "2026-02" {
+		t.Errorf("expected month 2026-02, got %v", response["month"])
+	}
+	if response["remaining"] != "300" || response["percentage_used"] != "62.5" {
+		t.Errorf("unexpected amounts in response: %v", response)
+	}
+	if response["alert_name"] != "OnTrack" {
+		t.Errorf("expected alert OnTrack, got %v", response["alert_name"])
+	}
+	if !service.lastRef.IsZero() || response["reference_month"] != nil {
+		t.Errorf("expected a nominal status, got reference %v", response["reference_month"])
+	}
+}
+
+func TestGetBudgetStatus_Reference(t *testing.T) {
+	status := sampleStatus()
+	reference := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	status.Reference = &reference
+	service := &mockBudgetService{status: status}
+	handler := &Handler{budgetService: service}
+
+	req := httptest.NewRequest("GET", "/budgets/budget1/status?month=2026-02&reference=2026-03", nil)
+	rr := httptest.NewRecorder()
+	handler.GetBudgetStatus(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if !service.lastRef.Equal(reference) {
+		t.Errorf("expected reference 2026-03, got %v", service.lastRef)
+	}
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response["reference_month"] != "2026-03" {
+		t.Errorf("expected reference_month 2026-03, got %v", response["reference_month"])
+	}
+}
+
+func TestGetBudgetStatus_ReferenceErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		err      error
+		wantCode int
+	}{
+		{"invalid reference", "/budgets/budget1/status?reference=March", nil, http.StatusBadRequest},
+		{"missing index", "/budgets/budget1/status?reference=2026-03", errors.New("price index not found for USD in 2026-03"), http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &Handler{budgetService: &mockBudgetService{statusErr: tt.err}}
+
+			req := httptest.NewRequest("GET", tt.url, nil)
+			rr := httptest.NewRecorder()
+			handler.GetBudgetStatus(rr, req)
+
+			if rr.Code != tt.wantCode {
+				t.Errorf("expected status %d, got %d", tt.wantCode, rr.Code)
+			}
+		})
+	}
+}
+
+func TestGetBudgetStatus_InvalidMonth(t *testing.T) {
+	handler := &Handler{budgetService: &mockBudgetService{}}
+
+	req := httptest.NewRequest("GET", "/budgets/budget1/status?month=February", nil)
+	rr := httptest.NewRecorder()
+	handler.GetBudgetStatus(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rr.Code)
+	}
+}
+
+func TestGetBudgetStatus_BeforeStart(t *testing.T) {
+	handler := &Handler{budgetService: &mockBudgetService{statusErr: errors.New("month is before the start of the budget")}}
+
+	req := httptest.NewRequest("GET", "/budgets/budget1/status?month=2025-12", nil)
+	rr := httptest.NewRecorder()
+	handler.GetBudgetStatus(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rr.Code)
+	}
+}
+
+func TestGetBudgetSummary_DefaultsToCurrentMonth(t *testing.T) {
+	service := &mockBudgetService{statuses: []*queries.BudgetStatusResponse{sampleStatus()}}
+	handler := &Handler{budgetService: service}
+
+	req := httptest.NewRequest("GET", "/budgets/summary", nil)
+	rr := httptest.NewRecorder()
+	handler.GetBudgetSummary(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+
+	now := time.Now().UTC()
+	if service.lastMonth.Year() != now.Year() || service.lastMonth.Month() != now.Month() || service.lastMonth.Day() != 1 {
+		t.Errorf("expected the first of the current month, got %v", service.lastMonth)
+	}
+
+	var response []BudgetStatusResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response) != 1 {
+		t.Errorf("expected 1 status, got %d", len(response))
+	}
+}
+
+func TestDeleteBudget_Forbidden(t *testing.T) {
+	handler := &Handler{budgetService: &mockBudgetService{deleteErr: errors.New("unauthorized access to budget")}}
+
+	req := httptest.NewRequest("DELETE", "/budgets/budget1", nil)
+	rr := httptest.NewRecorder()
+	handler.DeleteBudget(rr, req)
+
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d", rr.Code)
+	}
+}
@@ -0,0 +1,59 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"fin-flow-api/internal/shared/interface/jwt"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+var budgetHandler *Handler
+
+func SetupRoutes(mux *http.ServeMux, jwtService jwt.Service) {
+	mountBudgets(mux, jwtService)
+}
+
+func mountBudgets(mux *http.ServeMux, jwtService jwt.Service) {
+	mux.HandleFunc("/budgets", handleBudgetsCollection(jwtService))
+
+	mux.Handle("/budgets/summary", middleware.RequireAuth(jwtService)(http.HandlerFunc(budgetHandler.GetBudgetSummary)))
+
+	protectedHandler := middleware.RequireAuth(jwtService)(http.HandlerFunc(handleBudgetsResource))
+	mux.Handle("/budgets/", protectedHandler)
+}
+
+func handleBudgetsCollection(jwtService jwt.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.RequireAuth(jwtService)(http.HandlerFunc(budgetHandler.ListBudgets)).ServeHTTP(w, r)
+		case http.MethodPost:
+			middleware.RequireAuth(jwtService)(http.HandlerFunc(budgetHandler.CreateBudget)).ServeHTTP(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func handleBudgetsResource(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(strings.TrimSuffix(r.URL.Path, "/"), "/status") {
+		budgetHandler.GetBudgetStatus(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		budgetHandler.GetBudget(w, r)
+	case http.MethodPut:
+		budgetHandler.UpdateBudget(w, r)
+	case http.MethodDelete:
+		budgetHandler.DeleteBudget(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func SetHandler(handler *Handler) {
+	budgetHandler = handler
+}
@@ -1,6 +1,7 @@
 package commands
 
 type CategoryRequest struct {
-	Name string
-	Type int
-}
\ No newline at end of file
+	Name     string
+	Type     int
+	ParentID string
+}
@@ -0,0 +1,5 @@
+package commands
+
+type MergeCategoriesRequest struct {
+	SourceIDs []string
+}
@@ -0,0 +1,6 @@
+package commands
+
+type ApplyTemplateRequest struct {
+	Template string
+	Language string
+}
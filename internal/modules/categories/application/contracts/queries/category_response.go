@@ -4,6 +4,7 @@ import "time"
 
 type CategoryResponse struct {
 	ID        string
+	ParentID  string
 	Name      string
 	Type      int
 	TypeName  string
@@ -11,4 +12,13 @@ type CategoryResponse struct {
 	UpdatedAt time.Time
 	CreatedBy string
 	UpdatedBy string
-}
\ No newline at end of file
+}
+
+// CategoryTreeResponse is a category with its subcategories nested in it.
+type CategoryTreeResponse struct {
+	ID       string
+	Name     string
+	Type     int
+	TypeName string
+	Children []*CategoryTreeResponse
+}
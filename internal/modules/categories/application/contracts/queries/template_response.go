@@ -0,0 +1,26 @@
+package queries
+
+type CategoryTemplateResponse struct {
+	Key        string
+	Version    int
+	Language   string
+	Name       string
+	Categories []CategoryTemplateCategoryResponse
+}
+
+type CategoryTemplateCategoryResponse struct {
+	Name     string
+	Type     int
+	TypeName string
+	Children []string
+}
+
+// ApplyTemplateResponse lists the categories a template added and the names
+// of those the user already had.
+type ApplyTemplateResponse struct {
+	Template string
+	Version  int
+	Language string
+	Created  []*CategoryResponse
+	Skipped  []string
+}
@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 	"errors"
+	"strings"
+
 	"fin-flow-api/internal/modules/categories/application/contracts/commands"
 	"fin-flow-api/internal/modules/categories/application/contracts/queries"
 	"fin-flow-api/internal/modules/categories/domain"
@@ -50,6 +52,11 @@ func (s *CategoryService) Create(ctx context.Context, req commands.CategoryReque
 		categoryType,
 		s.systemUser,
 	)
+	category.ParentID = req.ParentID
+
+	if err := s.validateHierarchy(userID, category); err != nil {
+		return err
+	}
 
 	return s.repository.Create(category)
 }
@@ -60,7 +67,7 @@ func (s *CategoryService) Update(ctx context.Context, categoryID string, req com
 		return err
 	}
 
-	category, err := s.repository.GetByID(categoryID, userID)
+	existing, err := s.repository.GetByID(categoryID, userID)
 	if err != nil {
 		return err
 	}
@@ -70,19 +77,83 @@ func (s *CategoryService) Update(ctx context.Context, categoryID string, req com
 		return domain.ErrInvalidCategoryType
 	}
 
+	category := *existing
 	category.Name = req.Name
 	category.Type = categoryType
+	category.ParentID = req.ParentID
+
+	if err := s.validateHierarchy(userID, &category); err != nil {
+		return err
+	}
+
 	category.Entity.UpdateModified(s.systemUser)
 
-	return s.repository.Update(category)
+	return s.repository.Update(&category)
 }
 
-func (s *CategoryService) Delete(ctx context.Context, categoryID string) error {
+// Delete removes a category. strategy says what happens to its
+// subcategories and defaults to blocking the deletion when it has any.
+func (s *CategoryService) Delete(ctx context.Context, categoryID string, strategy string) error {
 	userID, err := s.getUserIDFromContext(ctx)
 	if err != nil {
 		return err
 	}
-	return s.repository.Delete(categoryID, userID)
+
+	if strategy == "" {
+		strategy = string(domain.DeleteBlock)
+	}
+	if !domain.IsValidDeleteStrategy(strategy) {
+		return domain.ErrInvalidDeleteStrategy
+	}
+
+	return s.repository.Delete(categoryID, userID, domain.DeleteStrategy(strategy))
+}
+
+// Merge folds the source categories into the target one: their
+// transactions, recurring rules, loans, budgets and subcategories move to
+// the target and the sources are deleted.
+func (s *CategoryService) Merge(ctx context.Context, targetID string, req commands.MergeCategoriesRequest) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	var sourceIDs []string
+	seen := make(map[string]bool)
+	for _, id := range req.SourceIDs {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		sourceIDs = append(sourceIDs, id)
+	}
+	if len(sourceIDs) == 0 {
+		return domain.ErrMergeSourcesRequired
+	}
+
+	target, err := s.repository.GetByID(targetID, userID)
+	if err != nil {
+		return err
+	}
+
+	sources := make([]*domain.Category, len(sourceIDs))
+	for i, id := range sourceIDs {
+		if sources[i], err = s.repository.GetByID(id, userID); err != nil {
+			return err
+		}
+	}
+
+	categories, err := s.repository.List(userID)
+	if err != nil {
+		return err
+	}
+
+	if err := domain.NewTree(categories).ValidateMerge(target, sources); err != nil {
+		return err
+	}
+
+	return s.repository.Merge(target.ID, sourceIDs, userID)
 }
 
 func (s *CategoryService) GetByID(ctx context.Context, categoryID string) (*queries.CategoryResponse, error) {
@@ -98,6 +169,7 @@ func (s *CategoryService) GetByID(ctx context.Context, categoryID string) (*quer
 
 	return &queries.CategoryResponse{
 		ID:        category.ID,
+		ParentID:  category.ParentID,
 		Name:      category.Name,
 		Type:      category.Type.Value(),
 		TypeName:  category.Type.String(),
@@ -123,6 +195,7 @@ func (s *CategoryService) List(ctx context.Context) ([]*queries.CategoryResponse
 	for i, category := range categories {
 		responses[i] = &queries.CategoryResponse{
 			ID:        category.ID,
+			ParentID:  category.ParentID,
 			Name:      category.Name,
 			Type:      category.Type.Value(),
 			TypeName:  category.Type.String(),
@@ -136,6 +209,148 @@ func (s *CategoryService) List(ctx context.Context) ([]*queries.CategoryResponse
 	return responses, nil
 }
 
+// Tree lists the categories of the user with their subcategories nested in
+// them, sorted by name at every level.
+func (s *CategoryService) Tree(ctx context.Context) ([]*queries.CategoryTreeResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := s.repository.List(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := domain.NewTree(categories)
+
+	return toCategoryTreeResponses(tree, tree.Roots(), make(map[string]bool)), nil
+}
+
+// Templates lists the category templates a user can apply.
+func (s *CategoryService) Templates() []*queries.CategoryTemplateResponse {
+	templates := domain.Templates()
+
+	responses := make([]*queries.CategoryTemplateResponse, len(templates))
+	for i, template := range templates {
+		categories := make([]queries.CategoryTemplateCategoryResponse, len(template.Categories))
+		for j, category := range template.Categories {
+			categories[j] = queries.CategoryTemplateCategoryResponse{
+				Name:     category.Name,
+				Type:     category.Type.Value(),
+				TypeName: category.Type.String(),
+				Children: category.Children,
+			}
+		}
+
+		responses[i] = &queries.CategoryTemplateResponse{
+			Key:        template.Key,
+			Version:    template.Version,
+			Language:   template.Language,
+			Name:       template.Name,
+			Categories: categories,
+		}
+	}
+
+	return responses
+}
+
+// ApplyTemplate adds to the categories of the user the ones of a template
+// that it does not have yet.
+func (s *CategoryService) ApplyTemplate(ctx context.Context, req commands.ApplyTemplateRequest) (*queries.ApplyTemplateResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.SeedTemplate(userID, req.Template, req.Language)
+}
+
+// SeedTemplate applies a template for userID. It is ApplyTemplate for
+// callers that have no authenticated user, like the sign-up of one.
+func (s *CategoryService) SeedTemplate(userID, key, language string) (*queries.ApplyTemplateResponse, error) {
+	template, err := domain.FindTemplate(key, language)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repository.List(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	created, skipped := template.Plan(userID, existing, func() string { return uuid.New().String() }, s.systemUser)
+	if len(created) > 0 {
+		if err := s.repository.CreateAll(created); err != nil {
+			return nil, err
+		}
+	}
+
+	response := &queries.ApplyTemplateResponse{
+		Template: template.Key,
+		Version:  template.Version,
+		Language: template.Language,
+		Created:  make([]*queries.CategoryResponse, len(created)),
+		Skipped:  skipped,
+	}
+	for i, category := range created {
+		response.Created[i] = &queries.CategoryResponse{
+			ID:        category.ID,
+			ParentID:  category.ParentID,
+			Name:      category.Name,
+			Type:      category.Type.Value(),
+			TypeName:  category.Type.String(),
+			CreatedAt: category.CreatedAt,
+			UpdatedAt: category.ModifiedAt,
+			CreatedBy: category.CreatedBy,
+			UpdatedBy: category.ModifiedBy,
+		}
+	}
+
+	return response, nil
+}
+
+// validateHierarchy checks the parent of a category against the other
+// categories of the user.
+func (s *CategoryService) validateHierarchy(userID string, category *domain.Category) error {
+	categories, err := s.repository.List(userID)
+	if err != nil {
+		return err
+	}
+
+	var parent *domain.Category
+	if category.ParentID != "" {
+		parent, err = s.repository.GetByID(category.ParentID, userID)
+		if err != nil {
+			if strings.Contains(err.Error(), "category not found") {
+				return errors.New("parent category not found")
+			}
+			return err
+		}
+	}
+
+	return domain.NewTree(categories).ValidateHierarchy(category, parent)
+}
+
+func toCategoryTreeResponses(tree *domain.Tree, categories []*domain.Category, visited map[string]bool) []*queries.CategoryTreeResponse {
+	responses := make([]*queries.CategoryTreeResponse, 0, len(categories))
+	for _, category := range categories {
+		if visited[category.ID] {
+			continue
+		}
+		visited[category.ID] = true
+
+		responses = append(responses, &queries.CategoryTreeResponse{
+			ID:       category.ID,
+			Name:     category.Name,
+			Type:     category.Type.Value(),
+			TypeName: category.Type.String(),
+			Children: toCategoryTreeResponses(tree, tree.Children(category.ID), visited),
+		})
+	}
+	return responses
+}
+
 func isValidCategoryType(categoryType domain.CategoryType) bool {
 	return categoryType == domain.CategoryTypeExpense ||
 		categoryType == domain.CategoryTypeIncome ||
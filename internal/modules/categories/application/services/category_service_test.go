@@ -6,6 +6,7 @@ import (
 	"fin-flow-api/internal/modules/categories/application/contracts/commands"
 	"fin-flow-api/internal/modules/categories/domain"
 	"fin-flow-api/internal/shared/middleware"
+	"strings"
 	"testing"
 )
 
@@ -16,6 +17,10 @@ type mockCategoryRepository struct {
 	updateErr  error
 	deleteErr  error
 	listErr    error
+	mergeErr   error
+
+	lastStrategy domain.DeleteStrategy
+	lastMerge    []string
 }
 
 func newMockCategoryRepository() *mockCategoryRepository {
@@ -32,6 +37,16 @@ func (m *mockCategoryRepository) Create(category *domain.Category) error {
 	return nil
 }
 
+func (m *mockCategoryRepository) CreateAll(categories []*domain.Category) error {
+	if m.createErr != nil {
+		return m.createErr
+	}
+	for _, category := range categories {
+		m.categories[category.ID] = category
+	}
+	return nil
+}
+
 func (m *mockCategoryRepository) GetByID(id string, userID string) (*domain.Category, error) {
 	if m.getByIDErr != nil {
 		return nil, m.getByIDErr
@@ -74,7 +89,7 @@ func (m *mockCategoryRepository) Update(category *domain.Category) error {
 	return nil
 }
 
-func (m *mockCategoryRepository) Delete(id string, userID string) error {
+func (m *mockCategoryRepository) Delete(id string, userID string, strategy domain.DeleteStrategy) error {
 	if m.deleteErr != nil {
 		return m.deleteErr
 	}
@@ -85,10 +100,61 @@ func (m *mockCategoryRepository) Delete(id string, userID string) error {
 	if category.UserID != userID {
 		return errors.New("unauthorized access to category")
 	}
+	m.lastStrategy = strategy
+
+	var children []*domain.Category
+	for _, other := range m.categories {
+		if other.ParentID == id {
+			children = append(children, other)
+		}
+	}
+	if len(children) > 0 {
+		switch strategy {
+		case domain.DeleteReparent:
+			for _, child := range children {
+				child.ParentID = category.ParentID
+			}
+		case domain.DeleteCascade:
+			for _, child := range children {
+				if err := m.Delete(child.ID, userID, strategy); err != nil {
+					return err
+				}
+			}
+		default:
+			return domain.ErrCategoryHasChildren
+		}
+	}
+
 	delete(m.categories, id)
 	return nil
 }
 
+func (m *mockCategoryRepository) Merge(targetID string, sourceIDs []string, userID string) error {
+	if m.mergeErr != nil {
+		return m.mergeErr
+	}
+	for _, id := range append([]string{targetID}, sourceIDs...) {
+		category, exists := m.categories[id]
+		if !exists {
+			return errors.New("category not found")
+		}
+		if category.UserID != userID {
+			return errors.New("unauthorized access to category")
+		}
+	}
+	m.lastMerge = sourceIDs
+
+	for _, id := range sourceIDs {
+		for _, other := range m.categories {
+			if other.ParentID == id {
+				other.ParentID = targetID
+			}
+		}
+		delete(m.categories, id)
+	}
+	return nil
+}
+
 type mockContext struct {
 	context.Context
 	userID string
@@ -310,7 +376,7 @@ func TestCategoryService_Delete(t *testing.T) {
 
 	ctx := &mockContext{userID: "user1", hasID: true}
 
-	err := service.Delete(ctx, "cat1")
+	err := service.Delete(ctx, "cat1", "")
 	if err != nil {
 		t.Errorf("Delete failed: %v", err)
 	}
@@ -326,7 +392,7 @@ func TestCategoryService_Delete_NotFound(t *testing.T) {
 
 	ctx := &mockContext{userID: "user1", hasID: true}
 
-	err := service.Delete(ctx, "nonexistent")
+	err := service.Delete(ctx, "nonexistent", "")
 	if err == nil {
 		t.Error("Delete should fail when category not found")
 	}
@@ -344,7 +410,7 @@ func TestCategoryService_Delete_Unauthorized(t *testing.T) {
 
 	ctx := &mockContext{userID: "user2", hasID: true}
 
-	err := service.Delete(ctx, "cat1")
+	err := service.Delete(ctx, "cat1", "")
 	if err == nil {
 		t.Error("Delete should fail when user doesn't own category")
 	}
@@ -353,6 +419,136 @@ func TestCategoryService_Delete_Unauthorized(t *testing.T) {
 	}
 }
 
+func newCategoryTree(repo *mockCategoryRepository) {
+	food := domain.NewCategory("food", "user1", "Food", domain.CategoryTypeExpense, "system")
+	groceries := domain.NewCategory("groceries", "user1", "Groceries", domain.CategoryTypeExpense, "system")
+	groceries.ParentID = "food"
+	organic := domain.NewCategory("organic", "user1", "Organic", domain.CategoryTypeExpense, "system")
+	organic.ParentID = "groceries"
+	salary := domain.NewCategory("salary", "user1", "Salary", domain.CategoryTypeIncome, "system")
+	foreign := domain.NewCategory("foreign", "user2", "Rent", domain.CategoryTypeExpense, "system")
+
+	for _, category := range []*domain.Category{food, groceries, organic, salary, foreign} {
+		repo.categories[category.ID] = category
+	}
+}
+
+func TestCategoryService_Create_WithParent(t *testing.T) {
+	repo := newMockCategoryRepository()
+	newCategoryTree(repo)
+	service := NewCategoryService(repo, "system")
+
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	err := service.Create(ctx, commands.CategoryRequest{Name: "Restaurants", Type: 0, ParentID: "food"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	tree, err := service.Tree(ctx)
+	if err != nil {
+		t.Fatalf("Tree failed: %v", err)
+	}
+	if len(tree) != 2 || tree[0].ID != "food" || len(tree[0].Children) != 2 || tree[0].Children[1].Name != "Restaurants" {
+		t.Errorf("expected Restaurants under Food, got %+v", tree)
+	}
+}
+
+func TestCategoryService_Hierarchy_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		req     commands.CategoryRequest
+		wantErr string
+	}{
+		{"type mismatch", "", commands.CategoryRequest{Name: "Bonus", Type: 0, ParentID: "salary"}, "subcategory type must match"},
+		{"missing parent", "", commands.CategoryRequest{Name: "Bonus", Type: 1, ParentID: "missing"}, "parent category not found"},
+		{"foreign parent", "", commands.CategoryRequest{Name: "Bonus", Type: 0, ParentID: "foreign"}, "unauthorized access to category"},
+		{"own parent", "food", commands.CategoryRequest{Name: "Food", Type: 0, ParentID: "food"}, "nested under itself"},
+		{"under grandchild", "food", commands.CategoryRequest{Name: "Food", Type: 0, ParentID: "organic"}, "nested under itself"},
+		{"type change with children", "food", commands.CategoryRequest{Name: "Food", Type: 1}, "subcategory type must match"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockCategoryRepository()
+			newCategoryTree(repo)
+			service := NewCategoryService(repo, "system")
+			ctx := &mockContext{userID: "user1", hasID: true}
+
+			var err error
+			if tt.id == "" {
+				err = service.Create(ctx, tt.req)
+			} else {
+				err = service.Update(ctx, tt.id, tt.req)
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if food := repo.categories["food"]; food.ParentID != "" || food.Type != domain.CategoryTypeExpense {
+				t.Errorf("expected food to be left unchanged, got %+v", food)
+			}
+		})
+	}
+}
+
+func TestCategoryService_Update_MoveSubtree(t *testing.T) {
+	repo := newMockCategoryRepository()
+	newCategoryTree(repo)
+	service := NewCategoryService(repo, "system")
+
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	err := service.Update(ctx, "organic", commands.CategoryRequest{Name: "Organic", Type: 0, ParentID: "food"})
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if repo.categories["organic"].ParentID != "food" {
+		t.Errorf("expected organic under food, got %s", repo.categories["organic"].ParentID)
+	}
+}
+
+func TestCategoryService_Delete_Strategies(t *testing.T) {
+	tests := []struct {
+		name      string
+		strategy  string
+		wantErr   error
+		remaining []string
+	}{
+		{"default blocks", "", domain.ErrCategoryHasChildren, []string{"food", "groceries", "organic"}},
+		{"block", "block", domain.ErrCategoryHasChildren, []string{"food", "groceries", "organic"}},
+		{"reparent", "reparent", nil, []string{"food", "organic"}},
+		{"cascade", "cascade", nil, []string{"food"}},
+		{"invalid", "orphan", domain.ErrInvalidDeleteStrategy, []string{"food", "groceries", "organic"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockCategoryRepository()
+			newCategoryTree(repo)
+			service := NewCategoryService(repo, "system")
+			ctx := &mockContext{userID: "user1", hasID: true}
+
+			if err := service.Delete(ctx, "groceries", tt.strategy); err != tt.wantErr {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+
+			for _, id := range tt.remaining {
+				if _, exists := repo.categories[id]; !exists {
+					t.Errorf("expected %s to remain", id)
+				}
+			}
+			if len(repo.categories) != len(tt.remaining)+2 {
+				t.Errorf("expected %d categories, got %d", len(tt.remaining)+2, len(repo.categories))
+			}
+			if tt.strategy == "reparent" && repo.categories["organic"].ParentID != "food" {
+				t.Errorf("expected organic to move under food, got %s", repo.categories["organic"].ParentID)
+			}
+		})
+	}
+}
+
 func TestCategoryService_List(t *testing.T) {
 	repo := newMockCategoryRepository()
 	service := NewCategoryService(repo, "system")
@@ -390,4 +586,153 @@ func TestCategoryService_List_Empty(t *testing.T) {
 	if len(result) != 0 {
 		t.Errorf("expected 0 categories, got %d", len(result))
 	}
-}
\ No newline at end of file
+}
+func TestCategoryService_ApplyTemplate(t *testing.T) {
+	repo := newMockCategoryRepository()
+	service := NewCategoryService(repo, "system")
+	repo.categories["food"] = domain.NewCategory("food", "user1", "Comida", domain.CategoryTypeExpense, "system")
+
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	result, err := service.ApplyTemplate(ctx, commands.ApplyTemplateRequest{Template: "personal", Language: "es"})
+	if err != nil {
+		t.Fatalf("ApplyTemplate failed: %v", err)
+	}
+
+	template, _ := domain.FindTemplate("personal", "es")
+	if len(result.Created) != template.Count()-1 || len(result.Skipped) != 1 || result.Skipped[0] != "Comida" {
+		t.Fatalf("expected Comida to be skipped, got %d created and %v skipped", len(result.Created), result.Skipped)
+	}
+	if len(repo.categories) != template.Count() {
+		t.Errorf("expected %d categories, got %d", template.Count(), len(repo.categories))
+	}
+	for _, category := range repo.categories {
+		if category.Name == "Supermercado" && category.ParentID != "food" {
+			t.Errorf("expected Supermercado under the existing Comida, got %s", category.ParentID)
+		}
+	}
+
+	again, err := service.ApplyTemplate(ctx, commands.ApplyTemplateRequest{Template: "personal", Language: "es"})
+	if err != nil {
+		t.Fatalf("ApplyTemplate failed: %v", err)
+	}
+	if len(again.Created) != 0 || len(repo.categories) != template.Count() {
+		t.Errorf("expected applying twice to create nothing, got %d", len(again.Created))
+	}
+}
+
+func TestCategoryService_ApplyTemplate_Errors(t *testing.T) {
+	repo := newMockCategoryRepository()
+	service := NewCategoryService(repo, "system")
+
+	_, err := service.ApplyTemplate(&mockContext{hasID: false}, commands.ApplyTemplateRequest{Template: "personal", Language: "es"})
+	if err == nil || err.Error() != "user not authenticated" {
+		t.Errorf("expected 'user not authenticated', got %v", err)
+	}
+
+	ctx := &mockContext{userID: "user1", hasID: true}
+	if _, err := service.ApplyTemplate(ctx, commands.ApplyTemplateRequest{Template: "business", Language: "es"}); err != domain.ErrTemplateNotFound {
+		t.Errorf("expected ErrTemplateNotFound, got %v", err)
+	}
+
+	repo.createErr = errors.New("duplicate key value violates unique constraint")
+	if _, err := service.ApplyTemplate(ctx, commands.ApplyTemplateRequest{Template: "personal", Language: "en"}); err == nil {
+		t.Error("expected the repository error")
+	}
+}
+
+func TestTemplateSeeder_SeedCategories(t *testing.T) {
+	repo := newMockCategoryRepository()
+	service := NewCategoryService(repo, "system")
+
+	if err := NewTemplateSeeder(service, "", "es").SeedCategories("user1"); err != nil {
+		t.Fatalf("SeedCategories failed: %v", err)
+	}
+	if len(repo.categories) != 0 {
+		t.Fatalf("expected no categories without a template, got %d", len(repo.categories))
+	}
+
+	if err := NewTemplateSeeder(service, "personal", "en").SeedCategories("user1"); err != nil {
+		t.Fatalf("SeedCategories failed: %v", err)
+	}
+	template, _ := domain.FindTemplate("personal", "en")
+	if len(repo.categories) != template.Count() {
+		t.Errorf("expected %d categories, got %d", template.Count(), len(repo.categories))
+	}
+	for _, category := range repo.categories {
+		if category.UserID != "user1" {
+			t.Errorf("expected categories of user1, got %s", category.UserID)
+		}
+	}
+}
+
+func TestCategoryService_Merge(t *testing.T) {
+	repo := newMockCategoryRepository()
+	repo.categories["market"] = domain.NewCategory("market", "user1", "Supermarket", domain.CategoryTypeExpense, "system")
+	repo.categories["super"] = domain.NewCategory("super", "user1", "Super", domain.CategoryTypeExpense, "system")
+	organic := domain.NewCategory("organic", "user1", "Organic", domain.CategoryTypeExpense, "system")
+	organic.ParentID = "super"
+	repo.categories["organic"] = organic
+	service := NewCategoryService(repo, "system")
+
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	err := service.Merge(ctx, "market", commands.MergeCategoriesRequest{SourceIDs: []string{"super", " super ", ""}})
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+
+	if len(repo.lastMerge) != 1 || repo.lastMerge[0] != "super" {
+		t.Errorf("expected sources to be deduplicated, got %v", repo.lastMerge)
+	}
+	if _, exists := repo.categories["super"]; exists {
+		t.Error("expected the source to be deleted")
+	}
+	if organic.ParentID != "market" {
+		t.Errorf("expected subcategories to move to the target, got %s", organic.ParentID)
+	}
+}
+
+func TestCategoryService_Merge_Errors(t *testing.T) {
+	newRepo := func() *mockCategoryRepository {
+		repo := newMockCategoryRepository()
+		repo.categories["food"] = domain.NewCategory("food", "user1", "Food", domain.CategoryTypeExpense, "system")
+		groceries := domain.NewCategory("groceries", "user1", "Groceries", domain.CategoryTypeExpense, "system")
+		groceries.ParentID = "food"
+		repo.categories["groceries"] = groceries
+		repo.categories["salary"] = domain.NewCategory("salary", "user1", "Salary", domain.CategoryTypeIncome, "system")
+		repo.categories["rent"] = domain.NewCategory("rent", "user2", "Rent", domain.CategoryTypeExpense, "system")
+		return repo
+	}
+
+	tests := []struct {
+		name    string
+		target  string
+		sources []string
+		wantErr string
+	}{
+		{"no sources", "food", []string{" "}, domain.ErrMergeSourcesRequired.Error()},
+		{"target not found", "missing", []string{"food"}, "category not found"},
+		{"source not found", "food", []string{"missing"}, "category not found"},
+		{"source of another user", "food", []string{"rent"}, "unauthorized access to category"},
+		{"into itself", "food", []string{"food"}, domain.ErrMergeIntoItself.Error()},
+		{"into subcategory", "groceries", []string{"food"}, domain.ErrMergeIntoSubcategory.Error()},
+		{"type mismatch", "food", []string{"salary"}, domain.ErrMergeTypeMismatch.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newRepo()
+			service := NewCategoryService(repo, "system")
+			ctx := &mockContext{userID: "user1", hasID: true}
+
+			err := service.Merge(ctx, tt.target, commands.MergeCategoriesRequest{SourceIDs: tt.sources})
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("expected %q, got %v", tt.wantErr, err)
+			}
+			if len(repo.categories) != 4 {
+				t.Errorf("expected no category to be deleted, got %d", len(repo.categories))
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package services
+
+// TemplateSeeder gives new users the categories of a template, so they do
+// not start from an empty list.
+type TemplateSeeder struct {
+	categoryService *CategoryService
+	template        string
+	language        string
+}
+
+// NewTemplateSeeder returns a seeder for template in language. An empty
+// template turns seeding off.
+func NewTemplateSeeder(categoryService *CategoryService, template, language string) *TemplateSeeder {
+	return &TemplateSeeder{
+		categoryService: categoryService,
+		template:        template,
+		language:        language,
+	}
+}
+
+func (s *TemplateSeeder) SeedCategories(userID string) error {
+	if s.template == "" {
+		return nil
+	}
+
+	_, err := s.categoryService.SeedTemplate(userID, s.template, s.language)
+	return err
+}
@@ -1,16 +1,32 @@
 package domain
 
 import (
+	"errors"
+
 	"fin-flow-api/internal/shared/domain"
 )
 
+var (
+	ErrCategoryCycle       = errors.New("a category cannot be nested under itself or one of its subcategories")
+	ErrParentTypeMismatch  = errors.New("subcategory type must match its parent's")
+	ErrCategoryHasChildren = errors.New("category has subcategories")
+
+	ErrMergeSourcesRequired = errors.New("at least one category to merge is required")
+	ErrMergeIntoItself      = errors.New("a category cannot be merged into itself")
+	ErrMergeIntoSubcategory = errors.New("a category cannot be merged into one of its subcategories")
+	ErrMergeTypeMismatch    = errors.New("merged categories must have the same type")
+)
+
 type Category struct {
 	domain.Entity
 
-	ID         string
-	UserID     string
-	Name       string
-	Type       CategoryType
+	ID     string
+	UserID string
+	// ParentID is the category this one is nested under, or empty for a
+	// top-level category.
+	ParentID string
+	Name     string
+	Type     CategoryType
 }
 
 func NewCategory(id, userID, name string, categoryType CategoryType, createdBy string) *Category {
@@ -21,4 +37,4 @@ func NewCategory(id, userID, name string, categoryType CategoryType, createdBy s
 		Name:   name,
 		Type:   categoryType,
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "errors"
+
+// DeleteStrategy says what happens to the subcategories of a deleted
+// category.
+type DeleteStrategy string
+
+const (
+	// DeleteBlock refuses to delete a category that has subcategories.
+	DeleteBlock DeleteStrategy = "block"
+	// DeleteReparent moves the subcategories up to the parent of the
+	// deleted category.
+	DeleteReparent DeleteStrategy = "reparent"
+	// DeleteCascade deletes every subcategory along with the category.
+	DeleteCascade DeleteStrategy = "cascade"
+)
+
+var ErrInvalidDeleteStrategy = errors.New("invalid delete strategy")
+
+func IsValidDeleteStrategy(strategy string) bool {
+	switch DeleteStrategy(strategy) {
+	case DeleteBlock, DeleteReparent, DeleteCascade:
+		return true
+	}
+	return false
+}
@@ -2,8 +2,15 @@ package domain
 
 type CategoryRepository interface {
 	Create(category *Category) error
+	// CreateAll creates the categories in order, all or none of them.
+	CreateAll(categories []*Category) error
 	GetByID(id string, userID string) (*Category, error)
 	List(userID string) ([]*Category, error)
 	Update(category *Category) error
-	Delete(id string, userID string) error
-}
\ No newline at end of file
+	// Delete removes a category and deals with its subcategories as the
+	// strategy says, failing with ErrCategoryHasChildren when it blocks.
+	Delete(id string, userID string, strategy DeleteStrategy) error
+	// Merge moves everything that references the source categories to the
+	// target and deletes the sources, all or nothing.
+	Merge(targetID string, sourceIDs []string, userID string) error
+}
@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrTemplateNotFound = errors.New("category template not found")
+
+// Template is a versioned set of categories in one language that a user can
+// start from. Names are unique across the whole template, since a user
+// cannot have two categories with the same name.
+type Template struct {
+	Key        string
+	Version    int
+	Language   string
+	Name       string
+	Categories []TemplateCategory
+}
+
+// TemplateCategory is a category of a template. Its subcategories take its
+// type.
+type TemplateCategory struct {
+	Name     string
+	Type     CategoryType
+	Children []string
+}
+
+// Count is how many categories, subcategories included, the template has.
+func (t *Template) Count() int {
+	count := 0
+	for _, category := range t.Categories {
+		count += 1 + len(category.Children)
+	}
+	return count
+}
+
+// Plan returns the categories of the template that userID does not have yet,
+// parents before their subcategories, and the names of those it already
+// has. Names are matched regardless of case. An existing category of the
+// same name and type takes the place of the template one, so missing
+// subcategories are added under it; one of another type is left alone along
+// with the template subcategories that would go under it.
+func (t *Template) Plan(userID string, existing []*Category, newID func() string, createdBy string) (created []*Category, skipped []string) {
+	byName := make(map[string]*Category, len(existing))
+	for _, category := range existing {
+		byName[strings.ToLower(category.Name)] = category
+	}
+
+	place := func(name string, categoryType CategoryType, parentID string) (*Category, bool) {
+		if current, ok := byName[strings.ToLower(name)]; ok {
+			skipped = append(skipped, name)
+			return current, current.Type == categoryType
+		}
+
+		category := NewCategory(newID(), userID, name, categoryType, createdBy)
+		category.ParentID = parentID
+		byName[strings.ToLower(name)] = category
+		created = append(created, category)
+		return category, true
+	}
+
+	for _, templateCategory := range t.Categories {
+		parent, ok := place(templateCategory.Name, templateCategory.Type, "")
+		if !ok {
+			skipped = append(skipped, templateCategory.Children...)
+			continue
+		}
+
+		for _, child := range templateCategory.Children {
+			place(child, templateCategory.Type, parent.ID)
+		}
+	}
+
+	return created, skipped
+}
+
+// Templates returns the latest version of every template.
+func Templates() []*Template {
+	return templates
+}
+
+// FindTemplate returns the template with key in language.
+func FindTemplate(key, language string) (*Template, error) {
+	for _, template := range templates {
+		if template.Key == strings.ToLower(key) && template.Language == strings.ToLower(language) {
+			return template, nil
+		}
+	}
+	return nil, ErrTemplateNotFound
+}
+
+var templates = []*Template{
+	{
+		Key:      "personal",
+		Version:  1,
+		Language: "en",
+		Name:     "Personal finances",
+		Categories: []TemplateCategory{
+			{Name: "Housing", Type: CategoryTypeExpense, Children: []string{"Rent", "Utilities", "Home Maintenance"}},
+			{Name: "Food", Type: CategoryTypeExpense, Children: []string{"Groceries", "Restaurants"}},
+			{Name: "Transportation", Type: CategoryTypeExpense, Children: []string{"Fuel", "Public Transit"}},
+			{Name: "Health", Type: CategoryTypeExpense, Children: []string{"Pharmacy", "Health Insurance"}},
+			{Name: "Subscriptions", Type: CategoryTypeExpense, Children: []string{"Phone & Internet", "Streaming"}},
+			{Name: "Shopping", Type: CategoryTypeExpense, Children: []string{"Clothing"}},
+			{Name: "Entertainment", Type: CategoryTypeExpense},
+			{Name: "Education", Type: CategoryTypeExpense},
+			{Name: "Taxes", Type: CategoryTypeExpense},
+			{Name: "Other Expenses", Type: CategoryTypeExpense},
+			{Name: "Salary", Type: CategoryTypeIncome},
+			{Name: "Freelance", Type: CategoryTypeIncome},
+			{Name: "Interest & Dividends", Type: CategoryTypeIncome},
+			{Name: "Other Income", Type: CategoryTypeIncome},
+			{Name: "Investments", Type: CategoryTypeInvestment, Children: []string{"Stocks", "Bonds"}},
+		},
+	},
+	{
+		Key:      "personal",
+		Version:  1,
+		Language: "es",
+		Name:     "Finanzas personales",
+		Categories: []TemplateCategory{
+			{Name: "Vivienda", Type: CategoryTypeExpense, Children: []string{"Alquiler", "Servicios del hogar", "Mantenimiento"}},
+			{Name: "Comida", Type: CategoryTypeExpense, Children: []string{"Supermercado", "Restaurantes"}},
+			{Name: "Transporte", Type: CategoryTypeExpense, Children: []string{"Combustible", "Transporte público"}},
+			{Name: "Salud", Type: CategoryTypeExpense, Children: []string{"Farmacia", "Cobertura médica"}},
+			{Name: "Suscripciones", Type: CategoryTypeExpense, Children: []string{"Telefonía e internet", "Streaming"}},
+			{Name: "Compras", Type: CategoryTypeExpense, Children: []string{"Ropa"}},
+			{Name: "Entretenimiento", Type: CategoryTypeExpense},
+			{Name: "Educación", Type: CategoryTypeExpense},
+			{Name: "Impuestos", Type: CategoryTypeExpense},
+			{Name: "Otros gastos", Type: CategoryTypeExpense},
+			{Name: "Sueldo", Type: CategoryTypeIncome},
+			{Name: "Trabajos independientes", Type: CategoryTypeIncome},
+			{Name: "Intereses y dividendos", Type: CategoryTypeIncome},
+			{Name: "Otros ingresos", Type: CategoryTypeIncome},
+			{Name: "Inversiones", Type: CategoryTypeInvestment, Children: []string{"Acciones", "Bonos"}},
+		},
+	},
+}
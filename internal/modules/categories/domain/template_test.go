@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestTemplates_UniqueNames(t *testing.T) {
+	for _, template := range Templates() {
+		t.Run(template.Key+"-"+template.Language, func(t *testing.T) {
+			seen := make(map[string]bool)
+			for _, category := range template.Categories {
+				for _, name := range append([]string{category.Name}, category.Children...) {
+					if len(strings.TrimSpace(name)) < 2 || len(name) > 255 {
+						t.Errorf("invalid category name %q", name)
+					}
+					if seen[strings.ToLower(name)] {
+						t.Errorf("duplicate category name %q", name)
+					}
+					seen[strings.ToLower(name)] = true
+				}
+			}
+			if template.Version < 1 {
+				t.Errorf("expected a version, got %d", template.Version)
+			}
+		})
+	}
+}
+
+func TestFindTemplate(t *testing.T) {
+	template, err := FindTemplate("Personal", "ES")
+	if err != nil {
+		t.Fatalf("FindTemplate failed: %v", err)
+	}
+	if template.Language != "es" || template.Categories[0].Name != "Vivienda" {
+		t.Errorf("unexpected template %s/%s", template.Key, template.Language)
+	}
+
+	if _, err := FindTemplate("personal", "fr"); err != ErrTemplateNotFound {
+		t.Errorf("expected ErrTemplateNotFound, got %v", err)
+	}
+}
+
+func sequentialIDs() func() string {
+	next := 0
+	return func() string {
+		next++
+		return fmt.Sprintf("new-%d", next)
+	}
+}
+
+func TestTemplate_Plan_Empty(t *testing.T) {
+	template, _ := FindTemplate("personal", "en")
+
+	created, skipped := template.Plan("user-1", nil, sequentialIDs(), "system")
+
+	if len(created) != template.Count() || len(skipped) != 0 {
+		t.Fatalf("expected %d created and none skipped, got %d and %v", template.Count(), len(created), skipped)
+	}
+	if created[0].Name != "Housing" || created[1].Name != "Rent" || created[1].ParentID != created[0].ID {
+		t.Errorf("expected Rent under Housing, got %+v and %+v", created[0], created[1])
+	}
+	if created[0].UserID != "user-1" || created[0].CreatedBy != "system" {
+		t.Errorf("unexpected owner %+v", created[0])
+	}
+}
+
+func TestTemplate_Plan_Existing(t *testing.T) {
+	template := &Template{
+		Key:      "test",
+		Version:  1,
+		Language: "en",
+		Categories: []TemplateCategory{
+			{Name: "Food", Type: CategoryTypeExpense, Children: []string{"Groceries", "Restaurants"}},
+			{Name: "Salary", Type: CategoryTypeIncome, Children: []string{"Bonus"}},
+			{Name: "Taxes", Type: CategoryTypeExpense},
+		},
+	}
+	existing := []*Category{
+		nested("food", "", "food", CategoryTypeExpense),
+		nested("groceries", "", "Groceries", CategoryTypeExpense),
+		nested("salary", "", "Salary", CategoryTypeExpense),
+	}
+
+	created, skipped := template.Plan("user-1", existing, sequentialIDs(), "system")
+
+	if len(created) != 2 || created[0].Name != "Restaurants" || created[1].Name != "Taxes" {
+		t.Fatalf("expected Restaurants and Taxes to be created, got %v", ids(created))
+	}
+	if created[0].ParentID != "food" {
+		t.Errorf("expected Restaurants under the existing Food, got %q", created[0].ParentID)
+	}
+	if strings.Join(skipped, ",") != "Food,Groceries,Salary,Bonus" {
+		t.Errorf("unexpected skipped categories %v", skipped)
+	}
+}
+
+func TestTemplate_Plan_Idempotent(t *testing.T) {
+	template, _ := FindTemplate("personal", "es")
+
+	created, _ := template.Plan("user-1", nil, sequentialIDs(), "system")
+	again, skipped := template.Plan("user-1", created, sequentialIDs(), "system")
+
+	if len(again) != 0 || len(skipped) != template.Count() {
+		t.Errorf("expected nothing to create the second time, got %d created and %d skipped", len(again), len(skipped))
+	}
+}
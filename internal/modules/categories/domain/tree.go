@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+)
+
+// Tree indexes the categories of a user by parent. Roots and children are
+// sorted by name.
+type Tree struct {
+	byID     map[string]*Category
+	children map[string][]*Category
+	roots    []*Category
+}
+
+func NewTree(categories []*Category) *Tree {
+	tree := &Tree{
+		byID:     make(map[string]*Category, len(categories)),
+		children: make(map[string][]*Category),
+	}
+
+	for _, category := range categories {
+		tree.byID[category.ID] = category
+	}
+
+	for _, category := range categories {
+		if _, ok := tree.byID[category.ParentID]; ok && category.ParentID != category.ID {
+			tree.children[category.ParentID] = append(tree.children[category.ParentID], category)
+		} else {
+			tree.roots = append(tree.roots, category)
+		}
+	}
+
+	sortByName(tree.roots)
+	for _, children := range tree.children {
+		sortByName(children)
+	}
+
+	return tree
+}
+
+func (t *Tree) Roots() []*Category {
+	return t.roots
+}
+
+func (t *Tree) Children(id string) []*Category {
+	return t.children[id]
+}
+
+// Descendants returns every category nested under id, at any depth.
+func (t *Tree) Descendants(id string) []*Category {
+	var result []*Category
+	visited := map[string]bool{id: true}
+	pending := append([]*Category(nil), t.children[id]...)
+
+	for len(pending) > 0 {
+		category := pending[0]
+		pending = pending[1:]
+		if visited[category.ID] {
+			continue
+		}
+		visited[category.ID] = true
+		result = append(result, category)
+		pending = append(pending, t.children[category.ID]...)
+	}
+
+	return result
+}
+
+// ValidateHierarchy checks that category can take its place in the tree:
+// its parent must be of the same type and must not be the category itself
+// or one of its subcategories, and its subcategories must share its type.
+// parent is the category referenced by ParentID, or nil for a top-level
+// category.
+func (t *Tree) ValidateHierarchy(category, parent *Category) error {
+	if parent != nil {
+		if parent.ID == category.ID {
+			return ErrCategoryCycle
+		}
+
+		visited := make(map[string]bool)
+		for ancestor := t.byID[parent.ParentID]; ancestor != nil && !visited[ancestor.ID]; ancestor = t.byID[ancestor.ParentID] {
+			if ancestor.ID == category.ID {
+				return ErrCategoryCycle
+			}
+			visited[ancestor.ID] = true
+		}
+
+		if parent.Type != category.Type {
+			return ErrParentTypeMismatch
+		}
+	}
+
+	for _, child := range t.children[category.ID] {
+		if child.Type != category.Type {
+			return ErrParentTypeMismatch
+		}
+	}
+
+	return nil
+}
+
+// ValidateMerge checks that sources can be merged into target. They must
+// share its type, and target cannot be one of them or be nested under one,
+// since the subcategories of the sources move under target.
+func (t *Tree) ValidateMerge(target *Category, sources []*Category) error {
+	for _, source := range sources {
+		if source.ID == target.ID {
+			return ErrMergeIntoItself
+		}
+		if source.Type != target.Type {
+			return ErrMergeTypeMismatch
+		}
+		for _, descendant := range t.Descendants(source.ID) {
+			if descendant.ID == target.ID {
+				return ErrMergeIntoSubcategory
+			}
+		}
+	}
+
+	return nil
+}
+
+func sortByName(categories []*Category) {
+	sort.Slice(categories, func(i, j int) bool {
+		return strings.ToLower(categories[i].Name) < strings.ToLower(categories[j].Name)
+	})
+}
@@ -0,0 +1,125 @@
+package domain
+
+import "testing"
+
+func nested(id, parentID, name string, categoryType CategoryType) *Category {
+	category := NewCategory(id, "user-1", name, categoryType, "system")
+	category.ParentID = parentID
+	return category
+}
+
+func sampleTree() *Tree {
+	return NewTree([]*Category{
+		nested("restaurants", "food", "Restaurants", CategoryTypeExpense),
+		nested("food", "", "Food", CategoryTypeExpense),
+		nested("groceries", "food", "Groceries", CategoryTypeExpense),
+		nested("organic", "groceries", "Organic", CategoryTypeExpense),
+		nested("salary", "", "Salary", CategoryTypeIncome),
+	})
+}
+
+func TestTree_RootsAndChildren(t *testing.T) {
+	tree := sampleTree()
+
+	roots := tree.Roots()
+	if len(roots) != 2 || roots[0].ID != "food" || roots[1].ID != "salary" {
+		t.Fatalf("expected food and salary as roots, got %v", ids(roots))
+	}
+
+	children := tree.Children("food")
+	if len(children) != 2 || children[0].ID != "groceries" || children[1].ID != "restaurants" {
+		t.Errorf("expected children sorted by name, got %v", ids(children))
+	}
+}
+
+func TestTree_Descendants(t *testing.T) {
+	tree := sampleTree()
+
+	if got := ids(tree.Descendants("food")); len(got) != 3 || got[2] != "organic" {
+		t.Errorf("expected groceries, restaurants and organic, got %v", got)
+	}
+	if got := tree.Descendants("salary"); len(got) != 0 {
+		t.Errorf("expected no descendants, got %v", ids(got))
+	}
+}
+
+func TestTree_ValidateHierarchy(t *testing.T) {
+	tree := sampleTree()
+	food := nested("food", "", "Food", CategoryTypeExpense)
+	groceries := nested("groceries", "food", "Groceries", CategoryTypeExpense)
+	organic := nested("organic", "groceries", "Organic", CategoryTypeExpense)
+	restaurants := nested("restaurants", "food", "Restaurants", CategoryTypeExpense)
+	salary := nested("salary", "", "Salary", CategoryTypeIncome)
+
+	tests := []struct {
+		name     string
+		category *Category
+		parent   *Category
+		wantErr  error
+	}{
+		{"top level", nested("new", "", "New", CategoryTypeExpense), nil, nil},
+		{"new child", nested("new", "groceries", "Coffee", CategoryTypeExpense), groceries, nil},
+		{"move subtree", nested("groceries", "restaurants", "Groceries", CategoryTypeExpense), restaurants, nil},
+		{"own parent", nested("food", "food", "Food", CategoryTypeExpense), food, ErrCategoryCycle},
+		{"under grandchild", nested("food", "organic", "Food", CategoryTypeExpense), organic, ErrCategoryCycle},
+		{"type mismatch", nested("bonus", "salary", "Bonus", CategoryTypeExpense), salary, ErrParentTypeMismatch},
+		{"parent type change", nested("food", "", "Food", CategoryTypeIncome), nil, ErrParentTypeMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tree.ValidateHierarchy(tt.category, tt.parent); err != tt.wantErr {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestTree_ValidateMerge(t *testing.T) {
+	tree := sampleTree()
+	food := nested("food", "", "Food", CategoryTypeExpense)
+	groceries := nested("groceries", "food", "Groceries", CategoryTypeExpense)
+	organic := nested("organic", "groceries", "Organic", CategoryTypeExpense)
+	restaurants := nested("restaurants", "food", "Restaurants", CategoryTypeExpense)
+	salary := nested("salary", "", "Salary", CategoryTypeIncome)
+
+	tests := []struct {
+		name    string
+		target  *Category
+		sources []*Category
+		wantErr error
+	}{
+		{"siblings", restaurants, []*Category{groceries}, nil},
+		{"into parent", food, []*Category{groceries, organic}, nil},
+		{"into itself", food, []*Category{restaurants, food}, ErrMergeIntoItself},
+		{"into subcategory", organic, []*Category{food}, ErrMergeIntoSubcategory},
+		{"type mismatch", food, []*Category{salary}, ErrMergeTypeMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tree.ValidateMerge(tt.target, tt.sources); err != tt.wantErr {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestIsValidDeleteStrategy(t *testing.T) {
+	for _, strategy := range []string{"block", "reparent", "cascade"} {
+		if !IsValidDeleteStrategy(strategy) {
+			t.Errorf("expected %s to be valid", strategy)
+		}
+	}
+	if IsValidDeleteStrategy("orphan") {
+		t.Error("expected orphan to be invalid")
+	}
+}
+
+func ids(categories []*Category) []string {
+	result := make([]string, len(categories))
+	for i, category := range categories {
+		result[i] = category.ID
+	}
+	return result
+}
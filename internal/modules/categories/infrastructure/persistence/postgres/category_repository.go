@@ -8,6 +8,7 @@ import (
 	"fin-flow-api/internal/modules/categories/domain"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -21,8 +22,8 @@ func NewRepository(pool *pgxpool.Pool) *Repository {
 
 func (r *Repository) Create(category *domain.Category) error {
 	query := `
-		INSERT INTO categories (id, user_id, name, type, created_at, modified_at, created_by, modified_by)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO categories (id, user_id, parent_id, name, type, created_at, modified_at, created_by, modified_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 
 	_, err := r.pool.Exec(
@@ -30,6 +31,7 @@ func (r *Repository) Create(category *domain.Category) error {
 		query,
 		category.ID,
 		category.UserID,
+		nullableString(category.ParentID),
 		category.Name,
 		category.Type.Value(),
 		category.CreatedAt,
@@ -39,12 +41,58 @@ func (r *Repository) Create(category *domain.Category) error {
 	)
 
 	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" { // foreign_key_violation
+			return fmt.Errorf("invalid parent category reference")
+		}
 		return fmt.Errorf("failed to create category: %w", err)
 	}
 
 	return nil
 }
 
+func (r *Repository) CreateAll(categories []*domain.Category) error {
+	ctx := context.Background()
+
+	dbTx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create categories: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	query := `
+		INSERT INTO categories (id, user_id, parent_id, name, type, created_at, modified_at, created_by, modified_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	for _, category := range categories {
+		_, err := dbTx.Exec(
+			ctx,
+			query,
+			category.ID,
+			category.UserID,
+			nullableString(category.ParentID),
+			category.Name,
+			category.Type.Value(),
+			category.CreatedAt,
+			category.ModifiedAt,
+			category.CreatedBy,
+			category.ModifiedBy,
+		)
+		if err != nil {
+			if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" { // foreign_key_violation
+				return fmt.Errorf("invalid parent category reference")
+			}
+			return fmt.Errorf("failed to create categories: %w", err)
+		}
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to create categories: %w", err)
+	}
+
+	return nil
+}
+
 func (r *Repository) GetByID(id string, userID string) (*domain.Category, error) {
 	checkQuery := `SELECT user_id FROM categories WHERE id = $1`
 	var categoryUserID string
@@ -62,16 +110,18 @@ func (r *Repository) GetByID(id string, userID string) (*domain.Category, error)
 	}
 
 	query := `
-		SELECT id, user_id, name, type, created_at, modified_at, created_by, modified_by
+		SELECT id, user_id, parent_id, name, type, created_at, modified_at, created_by, modified_by
 		FROM categories
 		WHERE id = $1 AND user_id = $2
 	`
 
 	var category domain.Category
+	var parentID *string
 	var typeValue int
 	err = r.pool.QueryRow(context.Background(), query, id, userID).Scan(
 		&category.ID,
 		&category.UserID,
+		&parentID,
 		&category.Name,
 		&typeValue,
 		&category.CreatedAt,
@@ -88,13 +138,16 @@ func (r *Repository) GetByID(id string, userID string) (*domain.Category, error)
 	}
 
 	category.Type = domain.CategoryType(typeValue)
+	if parentID != nil {
+		category.ParentID = *parentID
+	}
 
 	return &category, nil
 }
 
 func (r *Repository) List(userID string) ([]*domain.Category, error) {
 	query := `
-		SELECT id, user_id, name, type, created_at, modified_at, created_by, modified_by
+		SELECT id, user_id, parent_id, name, type, created_at, modified_at, created_by, modified_by
 		FROM categories
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -109,10 +162,12 @@ func (r *Repository) List(userID string) ([]*domain.Category, error) {
 	var categories []*domain.Category
 	for rows.Next() {
 		var category domain.Category
+		var parentID *string
 		var typeValue int
 		err := rows.Scan(
 			&category.ID,
 			&category.UserID,
+			&parentID,
 			&category.Name,
 			&typeValue,
 			&category.CreatedAt,
@@ -124,6 +179,9 @@ func (r *Repository) List(userID string) ([]*domain.Category, error) {
 			return nil, fmt.Errorf("failed to scan category: %w", err)
 		}
 		category.Type = domain.CategoryType(typeValue)
+		if parentID != nil {
+			category.ParentID = *parentID
+		}
 		categories = append(categories, &category)
 	}
 
@@ -152,8 +210,8 @@ func (r *Repository) Update(category *domain.Category) error {
 
 	query := `
 		UPDATE categories
-		SET name = $2, type = $3, modified_at = $4, modified_by = $5
-		WHERE id = $1 AND user_id = $6
+		SET name = $2, type = $3, parent_id = $4, modified_at = $5, modified_by = $6
+		WHERE id = $1 AND user_id = $7
 	`
 
 	result, err := r.pool.Exec(
@@ -162,12 +220,16 @@ func (r *Repository) Update(category *domain.Category) error {
 		category.ID,
 		category.Name,
 		category.Type.Value(),
+		nullableString(category.ParentID),
 		category.ModifiedAt,
 		category.ModifiedBy,
 		category.UserID,
 	)
 
 	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" { // foreign_key_violation
+			return fmt.Errorf("invalid parent category reference")
+		}
 		return fmt.Errorf("failed to update category: %w", err)
 	}
 
@@ -178,26 +240,66 @@ func (r *Repository) Update(category *domain.Category) error {
 	return nil
 }
 
-func (r *Repository) Delete(id string, userID string) error {
-	checkQuery := `SELECT user_id FROM categories WHERE id = $1`
+func (r *Repository) Delete(id string, userID string, strategy domain.DeleteStrategy) error {
+	ctx := context.Background()
+
+	dbTx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	checkQuery := `SELECT user_id, parent_id FROM categories WHERE id = $1 FOR UPDATE`
 	var categoryUserID string
-	err := r.pool.QueryRow(context.Background(), checkQuery, id).Scan(&categoryUserID)
-	
+	var parentID *string
+	err = dbTx.QueryRow(ctx, checkQuery, id).Scan(&categoryUserID, &parentID)
+
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return fmt.Errorf("category not found")
 		}
 		return fmt.Errorf("failed to delete category: %w", err)
 	}
-	
+
 	if categoryUserID != userID {
 		return fmt.Errorf("unauthorized access to category")
 	}
 
+	var hasChildren bool
+	if err := dbTx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM categories WHERE parent_id = $1)`, id).Scan(&hasChildren); err != nil {
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+
 	query := `DELETE FROM categories WHERE id = $1 AND user_id = $2`
 
-	result, err := r.pool.Exec(context.Background(), query, id, userID)
+	if hasChildren {
+		switch strategy {
+		case domain.DeleteReparent:
+			reparentQuery := `UPDATE categories SET parent_id = $2 WHERE parent_id = $1 AND user_id = $3`
+			if _, err := dbTx.Exec(ctx, reparentQuery, id, parentID, userID); err != nil {
+				return fmt.Errorf("failed to delete category: %w", err)
+			}
+		case domain.DeleteCascade:
+			// The whole subtree goes in one statement, so the parent
+			// references between the deleted rows are never left dangling.
+			query = `
+				WITH RECURSIVE subtree AS (
+					SELECT id FROM categories WHERE id = $1 AND user_id = $2
+					UNION
+					SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+				)
+				DELETE FROM categories WHERE id IN (SELECT id FROM subtree)
+			`
+		default:
+			return domain.ErrCategoryHasChildren
+		}
+	}
+
+	result, err := dbTx.Exec(ctx, query, id, userID)
 	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" { // foreign_key_violation
+			return fmt.Errorf("category is in use")
+		}
 		return fmt.Errorf("failed to delete category: %w", err)
 	}
 
@@ -205,5 +307,97 @@ func (r *Repository) Delete(id string, userID string) error {
 		return fmt.Errorf("category not found")
 	}
 
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) Merge(targetID string, sourceIDs []string, userID string) error {
+	ctx := context.Background()
+
+	dbTx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to merge categories: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	ids := append([]string{targetID}, sourceIDs...)
+	rows, err := dbTx.Query(ctx, `SELECT id, user_id FROM categories WHERE id = ANY($1) FOR UPDATE`, ids)
+	if err != nil {
+		return fmt.Errorf("failed to merge categories: %w", err)
+	}
+	found := 0
+	for rows.Next() {
+		var id, categoryUserID string
+		if err := rows.Scan(&id, &categoryUserID); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to merge categories: %w", err)
+		}
+		if categoryUserID != userID {
+			rows.Close()
+			return fmt.Errorf("unauthorized access to category")
+		}
+		found++
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to merge categories: %w", err)
+	}
+	if found != len(ids) {
+		return fmt.Errorf("category not found")
+	}
+
+	// A user has at most one budget per category and currency, so the
+	// budget of the target wins and, among the sources, the oldest one
+	// moves.
+	budgetsQuery := `
+		DELETE FROM budgets b
+		WHERE b.category_id = ANY($2) AND b.user_id = $3
+		AND (
+			EXISTS (SELECT 1 FROM budgets t WHERE t.category_id = $1 AND t.currency = b.currency)
+			OR EXISTS (
+				SELECT 1 FROM budgets o
+				WHERE o.category_id = ANY($2) AND o.currency = b.currency
+				AND (o.created_at, o.id) < (b.created_at, b.id)
+			)
+		)
+	`
+
+	moveArgs := []interface{}{targetID, sourceIDs, userID}
+	statements := []struct {
+		query string
+		args  []interface{}
+	}{
+		{budgetsQuery, moveArgs},
+		{`UPDATE budgets SET category_id = $1 WHERE category_id = ANY($2) AND user_id = $3`, moveArgs},
+		{`UPDATE transactions SET category_id = $1 WHERE category_id = ANY($2) AND user_id = $3`, moveArgs},
+		{`UPDATE recurring_rules SET category_id = $1 WHERE category_id = ANY($2) AND user_id = $3`, moveArgs},
+		{`UPDATE loans SET category_id = $1 WHERE category_id = ANY($2) AND user_id = $3`, moveArgs},
+		{`UPDATE categorization_rules SET category_id = $1 WHERE category_id = ANY($2) AND user_id = $3`, moveArgs},
+		{`UPDATE categories SET parent_id = $1 WHERE parent_id = ANY($2) AND user_id = $3`, moveArgs},
+		{`DELETE FROM categories WHERE id = ANY($1) AND user_id = $2`, []interface{}{sourceIDs, userID}},
+	}
+	for _, statement := range statements {
+		if _, err := dbTx.Exec(ctx, statement.query, statement.args...); err != nil {
+			if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" { // foreign_key_violation
+				return fmt.Errorf("category is in use")
+			}
+			return fmt.Errorf("failed to merge categories: %w", err)
+		}
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to merge categories: %w", err)
+	}
+
 	return nil
-}
\ No newline at end of file
+}
+
+func nullableString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
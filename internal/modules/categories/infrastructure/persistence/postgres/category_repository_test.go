@@ -50,6 +50,53 @@ func TestRepository_Create(t *testing.T) {
 	}
 }
 
+func TestRepository_CreateAll(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	userID := uuid.New().String()
+	parent := domain.NewCategory(uuid.New().String(), userID, "Food", domain.CategoryTypeExpense, "test-user")
+	child := domain.NewCategory(uuid.New().String(), userID, "Groceries", domain.CategoryTypeExpense, "test-user")
+	child.ParentID = parent.ID
+
+	if err := repo.CreateAll([]*domain.Category{parent, child}); err != nil {
+		t.Fatalf("CreateAll failed: %v", err)
+	}
+
+	found, err := repo.GetByID(child.ID, userID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if found.ParentID != parent.ID {
+		t.Errorf("expected parent %s, got %s", parent.ID, found.ParentID)
+	}
+}
+
+func TestRepository_CreateAll_Duplicate(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	userID := uuid.New().String()
+	existing := domain.NewCategory(uuid.New().String(), userID, "Salary", domain.CategoryTypeIncome, "test-user")
+	if err := repo.Create(existing); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	fresh := domain.NewCategory(uuid.New().String(), userID, "Freelance", domain.CategoryTypeIncome, "test-user")
+	duplicate := domain.NewCategory(uuid.New().String(), userID, "Salary", domain.CategoryTypeIncome, "test-user")
+	if err := repo.CreateAll([]*domain.Category{fresh, duplicate}); err == nil {
+		t.Fatal("expected CreateAll to fail on a duplicate name")
+	}
+
+	categories, err := repo.List(userID)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(categories) != 1 {
+		t.Errorf("expected the failed batch to be rolled back, got %d categories", len(categories))
+	}
+}
+
 func TestRepository_GetByID(t *testing.T) {
 	repo, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -271,7 +318,7 @@ func TestRepository_Delete(t *testing.T) {
 		t.Fatalf("Create failed: %v", err)
 	}
 
-	err = repo.Delete(categoryID, userID)
+	err = repo.Delete(categoryID, userID, domain.DeleteBlock)
 	if err != nil {
 		t.Fatalf("Delete failed: %v", err)
 	}
@@ -302,7 +349,7 @@ func TestRepository_Delete_WrongUser(t *testing.T) {
 		t.Fatalf("Create failed: %v", err)
 	}
 
-	err = repo.Delete(categoryID, userID2)
+	err = repo.Delete(categoryID, userID2, domain.DeleteBlock)
 	if err == nil {
 		t.Error("Delete should fail when category belongs to different user")
 	}
@@ -313,12 +360,130 @@ func TestRepository_Delete_NotFound(t *testing.T) {
 	defer cleanup()
 
 	userID := uuid.New().String()
-	err := repo.Delete("nonexistent", userID)
+	err := repo.Delete("nonexistent", userID, domain.DeleteBlock)
 	if err == nil {
 		t.Error("Delete should fail when category not found")
 	}
 }
 
+func createCategoryTree(t *testing.T, repo *Repository, userID string) (parent, child, grandchild *domain.Category) {
+	parent = domain.NewCategory(uuid.New().String(), userID, "Food "+uuid.New().String(), domain.CategoryTypeExpense, "test-user")
+	child = domain.NewCategory(uuid.New().String(), userID, "Groceries "+uuid.New().String(), domain.CategoryTypeExpense, "test-user")
+	child.ParentID = parent.ID
+	grandchild = domain.NewCategory(uuid.New().String(), userID, "Organic "+uuid.New().String(), domain.CategoryTypeExpense, "test-user")
+	grandchild.ParentID = child.ID
+
+	for _, category := range []*domain.Category{parent, child, grandchild} {
+		if err := repo.Create(category); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	return parent, child, grandchild
+}
+
+func TestRepository_Delete_BlockedByChildren(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	userID := uuid.New().String()
+	parent, _, _ := createCategoryTree(t, repo, userID)
+
+	err := repo.Delete(parent.ID, userID, domain.DeleteBlock)
+	if err != domain.ErrCategoryHasChildren {
+		t.Fatalf("expected ErrCategoryHasChildren, got %v", err)
+	}
+
+	if _, err := repo.GetByID(parent.ID, userID); err != nil {
+		t.Errorf("Category should not be deleted: %v", err)
+	}
+}
+
+func TestRepository_Delete_Reparent(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	userID := uuid.New().String()
+	parent, child, grandchild := createCategoryTree(t, repo, userID)
+
+	if err := repo.Delete(child.ID, userID, domain.DeleteReparent); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	found, err := repo.GetByID(grandchild.ID, userID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if found.ParentID != parent.ID {
+		t.Errorf("expected grandchild to move under %s, got %s", parent.ID, found.ParentID)
+	}
+}
+
+func TestRepository_Delete_Cascade(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	userID := uuid.New().String()
+	parent, child, grandchild := createCategoryTree(t, repo, userID)
+
+	if err := repo.Delete(parent.ID, userID, domain.DeleteCascade); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	for _, category := range []*domain.Category{parent, child, grandchild} {
+		if _, err := repo.GetByID(category.ID, userID); err == nil {
+			t.Errorf("Category %s should be deleted", category.Name)
+		}
+	}
+}
+
+func TestRepository_Merge(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	userID := uuid.New().String()
+	parent, child, grandchild := createCategoryTree(t, repo, userID)
+	target := domain.NewCategory(uuid.New().String(), userID, "Target", domain.CategoryTypeExpense, "test-user")
+	if err := repo.Create(target); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if err := repo.Merge(target.ID, []string{parent.ID, child.ID}, userID); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+
+	for _, id := range []string{parent.ID, child.ID} {
+		if _, err := repo.GetByID(id, userID); err == nil {
+			t.Errorf("expected %s to be deleted", id)
+		}
+	}
+	found, err := repo.GetByID(grandchild.ID, userID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if found.ParentID != target.ID {
+		t.Errorf("expected %s to move under the target, got %s", grandchild.ID, found.ParentID)
+	}
+}
+
+func TestRepository_Merge_WrongUser(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	target := domain.NewCategory(uuid.New().String(), uuid.New().String(), "Target", domain.CategoryTypeExpense, "test-user")
+	source := domain.NewCategory(uuid.New().String(), uuid.New().String(), "Source", domain.CategoryTypeExpense, "test-user")
+	if err := repo.CreateAll([]*domain.Category{target}); err != nil {
+		t.Fatalf("CreateAll failed: %v", err)
+	}
+	if err := repo.Create(source); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if err := repo.Merge(target.ID, []string{source.ID}, target.UserID); err == nil || err.Error() != "unauthorized access to category" {
+		t.Errorf("expected 'unauthorized access to category', got %v", err)
+	}
+}
+
 func TestRepository_CategoryTypes(t *testing.T) {
 	repo, cleanup := setupTestDB(t)
 	defer cleanup()
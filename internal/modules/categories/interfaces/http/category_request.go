@@ -1,6 +1,16 @@
 package http
 
 type CategoryRequest struct {
-	Name string `json:"name"`
-	Type *int   `json:"type"`
+	Name     string `json:"name"`
+	Type     *int   `json:"type"`
+	ParentID string `json:"parent_id"`
+}
+
+type ApplyTemplateRequest struct {
+	Template string `json:"template"`
+	Language string `json:"language"`
+}
+
+type MergeCategoriesRequest struct {
+	SourceIDs []string `json:"source_ids"`
 }
\ No newline at end of file
@@ -4,6 +4,7 @@ import "time"
 
 type CategoryResponse struct {
 	ID        string    `json:"id"`
+	ParentID  string    `json:"parent_id,omitempty"`
 	Name      string    `json:"name"`
 	Type      int       `json:"type"`
 	TypeName  string    `json:"type_name"`
@@ -11,4 +12,35 @@ type CategoryResponse struct {
 	UpdatedAt time.Time `json:"updated_at"`
 	CreatedBy string    `json:"created_by"`
 	UpdatedBy string    `json:"updated_by"`
-}
\ No newline at end of file
+}
+
+type CategoryTreeResponse struct {
+	ID       string                 `json:"id"`
+	Name     string                 `json:"name"`
+	Type     int                    `json:"type"`
+	TypeName string                 `json:"type_name"`
+	Children []CategoryTreeResponse `json:"children"`
+}
+
+type CategoryTemplateResponse struct {
+	Key        string                             `json:"key"`
+	Version    int                                `json:"version"`
+	Language   string                             `json:"language"`
+	Name       string                             `json:"name"`
+	Categories []CategoryTemplateCategoryResponse `json:"categories"`
+}
+
+type CategoryTemplateCategoryResponse struct {
+	Name     string   `json:"name"`
+	Type     int      `json:"type"`
+	TypeName string   `json:"type_name"`
+	Children []string `json:"children"`
+}
+
+type ApplyTemplateResponse struct {
+	Template string             `json:"template"`
+	Version  int                `json:"version"`
+	Language string             `json:"language"`
+	Created  []CategoryResponse `json:"created"`
+	Skipped  []string           `json:"skipped"`
+}
@@ -15,8 +15,12 @@ type categoryService interface {
 	Create(ctx context.Context, req commands.CategoryRequest) error
 	GetByID(ctx context.Context, id string) (*queries.CategoryResponse, error)
 	Update(ctx context.Context, id string, req commands.CategoryRequest) error
-	Delete(ctx context.Context, id string) error
+	Delete(ctx context.Context, id string, strategy string) error
+	Merge(ctx context.Context, targetID string, req commands.MergeCategoriesRequest) error
 	List(ctx context.Context) ([]*queries.CategoryResponse, error)
+	Tree(ctx context.Context) ([]*queries.CategoryTreeResponse, error)
+	Templates() []*queries.CategoryTemplateResponse
+	ApplyTemplate(ctx context.Context, req commands.ApplyTemplateRequest) (*queries.ApplyTemplateResponse, error)
 }
 
 type Handler struct {
@@ -47,15 +51,22 @@ func (h *Handler) CreateCategory(w http.ResponseWriter, r *http.Request) {
 	}
 
 	cmd := commands.CategoryRequest{
-		Name: reqDTO.Name,
-		Type: *reqDTO.Type,
+		Name:     reqDTO.Name,
+		Type:     *reqDTO.Type,
+		ParentID: strings.TrimSpace(reqDTO.ParentID),
 	}
 
 	if err := h.categoryService.Create(r.Context(), cmd); err != nil {
 		statusCode := http.StatusInternalServerError
 		errorMsg := err.Error()
 		
-		if strings.Contains(errorMsg, "invalid category type") {
+		if status, message, ok := hierarchyErrorResponse(errorMsg); ok {
+			statusCode = status
+			errorMsg = message
+		} else if strings.Contains(errorMsg, "unauthorized access") {
+			statusCode = http.StatusForbidden
+			errorMsg = "You do not have permission to use this parent category"
+		} else if strings.Contains(errorMsg, "invalid category type") {
 			statusCode = http.StatusBadRequest
 			errorMsg = "Invalid category type. Must be 0 (Expense), 1 (Income), or 2 (Investment)"
 		} else if strings.Contains(errorMsg, "user not authenticated") {
@@ -108,6 +119,7 @@ func (h *Handler) GetCategory(w http.ResponseWriter, r *http.Request) {
 
 	response := CategoryResponse{
 		ID:        category.ID,
+		ParentID:  category.ParentID,
 		Name:      category.Name,
 		Type:      category.Type,
 		TypeName:  category.TypeName,
@@ -146,15 +158,19 @@ func (h *Handler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
 	}
 
 	cmd := commands.CategoryRequest{
-		Name: reqDTO.Name,
-		Type: *reqDTO.Type,
+		Name:     reqDTO.Name,
+		Type:     *reqDTO.Type,
+		ParentID: strings.TrimSpace(reqDTO.ParentID),
 	}
 
 	if err := h.categoryService.Update(r.Context(), id, cmd); err != nil {
 		statusCode := http.StatusInternalServerError
 		errorMsg := err.Error()
 		
-		if strings.Contains(errorMsg, "invalid category type") {
+		if status, message, ok := hierarchyErrorResponse(errorMsg); ok {
+			statusCode = status
+			errorMsg = message
+		} else if strings.Contains(errorMsg, "invalid category type") {
 			statusCode = http.StatusBadRequest
 			errorMsg = "Invalid category type. Must be 0 (Expense), 1 (Income), or 2 (Investment)"
 		} else if strings.Contains(errorMsg, "user not authenticated") {
@@ -192,7 +208,9 @@ func (h *Handler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.categoryService.Delete(r.Context(), id); err != nil {
+	strategy := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("strategy")))
+
+	if err := h.categoryService.Delete(r.Context(), id, strategy); err != nil {
 		statusCode := http.StatusInternalServerError
 		errorMsg := err.Error()
 		
@@ -205,6 +223,15 @@ func (h *Handler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
 		} else if strings.Contains(errorMsg, "category not found") {
 			statusCode = http.StatusNotFound
 			errorMsg = "Category not found"
+		} else if strings.Contains(errorMsg, "category is in use") {
+			statusCode = http.StatusConflict
+			errorMsg = "Category is used by transactions and cannot be deleted"
+		} else if strings.Contains(errorMsg, "category has subcategories") {
+			statusCode = http.StatusConflict
+			errorMsg = "Category has subcategories. Delete it with strategy=reparent to move them up or strategy=cascade to delete them too"
+		} else if strings.Contains(errorMsg, "invalid delete strategy") {
+			statusCode = http.StatusBadRequest
+			errorMsg = "Strategy must be reparent, cascade or block"
 		}
 		
 		basehandler.WriteError(w, statusCode, errorMsg)
@@ -214,6 +241,62 @@ func (h *Handler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
 	basehandler.WriteSuccess(w, "Category deleted successfully")
 }
 
+// MergeCategories handles POST /categories/{id}/merge and folds the
+// categories in source_ids into the one in the path.
+func (h *Handler) MergeCategories(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/categories/")
+	id := strings.Split(path, "/")[0]
+
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Category ID is required in the URL path")
+		return
+	}
+
+	var reqDTO MergeCategoriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	if err := validateMergeCategoriesRequest(reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.categoryService.Merge(r.Context(), id, commands.MergeCategoriesRequest{SourceIDs: reqDTO.SourceIDs}); err != nil {
+		statusCode := http.StatusInternalServerError
+		errorMsg := err.Error()
+
+		if strings.Contains(errorMsg, "user not authenticated") {
+			statusCode = http.StatusUnauthorized
+			errorMsg = "Authentication required"
+		} else if strings.Contains(errorMsg, "unauthorized access") {
+			statusCode = http.StatusForbidden
+			errorMsg = "You do not have permission to merge these categories"
+		} else if strings.Contains(errorMsg, "category not found") {
+			statusCode = http.StatusNotFound
+			errorMsg = "Category not found"
+		} else if strings.Contains(errorMsg, "category to merge is required") ||
+			strings.Contains(errorMsg, "cannot be merged into") ||
+			strings.Contains(errorMsg, "merged categories must have the same type") {
+			statusCode = http.StatusBadRequest
+		} else if strings.Contains(errorMsg, "category is in use") {
+			statusCode = http.StatusConflict
+			errorMsg = "Category is still in use and cannot be merged"
+		}
+
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Categories merged successfully")
+}
+
 func (h *Handler) ListCategories(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
@@ -230,6 +313,7 @@ func (h *Handler) ListCategories(w http.ResponseWriter, r *http.Request) {
 	for i, category := range categories {
 		responses[i] = CategoryResponse{
 			ID:        category.ID,
+			ParentID:  category.ParentID,
 			Name:      category.Name,
 			Type:      category.Type,
 			TypeName:  category.TypeName,
@@ -243,6 +327,163 @@ func (h *Handler) ListCategories(w http.ResponseWriter, r *http.Request) {
 	basehandler.WriteJSON(w, http.StatusOK, responses)
 }
 
+// ListCategoryTree handles GET /categories/tree and lists the categories with
+// their subcategories nested in them.
+func (h *Handler) ListCategoryTree(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	tree, err := h.categoryService.Tree(r.Context())
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		errorMsg := err.Error()
+
+		if strings.Contains(errorMsg, "user not authenticated") {
+			statusCode = http.StatusUnauthorized
+			errorMsg = "Authentication required"
+		}
+
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, toCategoryTreeResponses(tree))
+}
+
+// ListCategoryTemplates handles GET /categories/templates.
+func (h *Handler) ListCategoryTemplates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	templates := h.categoryService.Templates()
+
+	responses := make([]CategoryTemplateResponse, len(templates))
+	for i, template := range templates {
+		categories := make([]CategoryTemplateCategoryResponse, len(template.Categories))
+		for j, category := range template.Categories {
+			children := category.Children
+			if children == nil {
+				children = []string{}
+			}
+			categories[j] = CategoryTemplateCategoryResponse{
+				Name:     category.Name,
+				Type:     category.Type,
+				TypeName: category.TypeName,
+				Children: children,
+			}
+		}
+
+		responses[i] = CategoryTemplateResponse{
+			Key:        template.Key,
+			Version:    template.Version,
+			Language:   template.Language,
+			Name:       template.Name,
+			Categories: categories,
+		}
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, responses)
+}
+
+// ApplyCategoryTemplate handles POST /categories/templates/apply and adds the
+// categories of a template the user does not have yet.
+func (h *Handler) ApplyCategoryTemplate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var reqDTO ApplyTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	if err := validateApplyTemplateRequest(reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.categoryService.ApplyTemplate(r.Context(), commands.ApplyTemplateRequest{
+		Template: strings.TrimSpace(reqDTO.Template),
+		Language: strings.TrimSpace(reqDTO.Language),
+	})
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		errorMsg := err.Error()
+
+		if strings.Contains(errorMsg, "user not authenticated") {
+			statusCode = http.StatusUnauthorized
+			errorMsg = "Authentication required"
+		} else if strings.Contains(errorMsg, "category template not found") {
+			statusCode = http.StatusNotFound
+			errorMsg = "Category template not found"
+		} else if strings.Contains(errorMsg, "duplicate") || strings.Contains(errorMsg, "unique") {
+			statusCode = http.StatusConflict
+			errorMsg = "A category with this name already exists"
+		}
+
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	response := ApplyTemplateResponse{
+		Template: result.Template,
+		Version:  result.Version,
+		Language: result.Language,
+		Created:  make([]CategoryResponse, len(result.Created)),
+		Skipped:  result.Skipped,
+	}
+	if response.Skipped == nil {
+		response.Skipped = []string{}
+	}
+	for i, category := range result.Created {
+		response.Created[i] = CategoryResponse{
+			ID:        category.ID,
+			ParentID:  category.ParentID,
+			Name:      category.Name,
+			Type:      category.Type,
+			TypeName:  category.TypeName,
+			CreatedAt: category.CreatedAt,
+			UpdatedAt: category.UpdatedAt,
+			CreatedBy: category.CreatedBy,
+			UpdatedBy: category.UpdatedBy,
+		}
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, response)
+}
+
+func toCategoryTreeResponses(nodes []*queries.CategoryTreeResponse) []CategoryTreeResponse {
+	responses := make([]CategoryTreeResponse, len(nodes))
+	for i, node := range nodes {
+		responses[i] = CategoryTreeResponse{
+			ID:       node.ID,
+			Name:     node.Name,
+			Type:     node.Type,
+			TypeName: node.TypeName,
+			Children: toCategoryTreeResponses(node.Children),
+		}
+	}
+	return responses
+}
+
+// hierarchyErrorResponse maps the errors about a category's parent, which
+// are the same on create and update.
+func hierarchyErrorResponse(errorMsg string) (int, string, bool) {
+	switch {
+	case strings.Contains(errorMsg, "parent category not found"), strings.Contains(errorMsg, "invalid parent category reference"):
+		return http.StatusBadRequest, "Parent category not found", true
+	case strings.Contains(errorMsg, "nested under itself"), strings.Contains(errorMsg, "subcategory type must match"):
+		return http.StatusBadRequest, errorMsg, true
+	}
+	return 0, "", false
+}
+
 func validateCategoryRequest(req CategoryRequest) error {
 	req.Name = strings.TrimSpace(req.Name)
 	if req.Name == "" {
@@ -266,6 +507,23 @@ func validateCategoryRequest(req CategoryRequest) error {
 	return nil
 }
 
+func validateApplyTemplateRequest(req ApplyTemplateRequest) error {
+	if strings.TrimSpace(req.Template) == "" {
+		return &ValidationError{Field: "template", Message: "Template is required"}
+	}
+	if strings.TrimSpace(req.Language) == "" {
+		return &ValidationError{Field: "language", Message: "Language is required"}
+	}
+	return nil
+}
+
+func validateMergeCategoriesRequest(req MergeCategoriesRequest) error {
+	if len(req.SourceIDs) == 0 {
+		return &ValidationError{Field: "source_ids", Message: "At least one category to merge is required"}
+	}
+	return nil
+}
+
 func isValidCategoryType(typeValue int) bool {
 	return typeValue >= 0 && typeValue <= 2
 }
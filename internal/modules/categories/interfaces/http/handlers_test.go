@@ -21,8 +21,20 @@ type mockCategoryService struct {
 	updateErr  error
 	deleteErr  error
 	listErr    error
+	treeErr    error
 	category   *queries.CategoryResponse
 	categories []*queries.CategoryResponse
+	tree       []*queries.CategoryTreeResponse
+	templates  []*queries.CategoryTemplateResponse
+	applyErr   error
+	mergeErr   error
+	applied    *queries.ApplyTemplateResponse
+
+	lastCommand  commands.CategoryRequest
+	lastStrategy string
+	lastApply    commands.ApplyTemplateRequest
+	lastTarget   string
+	lastMerge    commands.MergeCategoriesRequest
 }
 
 func newMockCategoryService() *mockCategoryService {
@@ -32,6 +44,7 @@ func newMockCategoryService() *mockCategoryService {
 }
 
 func (m *mockCategoryService) Create(ctx context.Context, req commands.CategoryRequest) error {
+	m.lastCommand = req
 	return m.createErr
 }
 
@@ -46,10 +59,17 @@ func (m *mockCategoryService) Update(ctx context.Context, id string, req command
 	return m.updateErr
 }
 
-func (m *mockCategoryService) Delete(ctx context.Context, id string) error {
+func (m *mockCategoryService) Delete(ctx context.Context, id string, strategy string) error {
+	m.lastStrategy = strategy
 	return m.deleteErr
 }
 
+func (m *mockCategoryService) Merge(ctx context.Context, targetID string, req commands.MergeCategoriesRequest) error {
+	m.lastTarget = targetID
+	m.lastMerge = req
+	return m.mergeErr
+}
+
 func (m *mockCategoryService) List(ctx context.Context) ([]*queries.CategoryResponse, error) {
 	if m.listErr != nil {
 		return nil, m.listErr
@@ -57,6 +77,25 @@ func (m *mockCategoryService) List(ctx context.Context) ([]*queries.CategoryResp
 	return m.categories, nil
 }
 
+func (m *mockCategoryService) Tree(ctx context.Context) ([]*queries.CategoryTreeResponse, error) {
+	if m.treeErr != nil {
+		return nil, m.treeErr
+	}
+	return m.tree, nil
+}
+
+func (m *mockCategoryService) Templates() []*queries.CategoryTemplateResponse {
+	return m.templates
+}
+
+func (m *mockCategoryService) ApplyTemplate(ctx context.Context, req commands.ApplyTemplateRequest) (*queries.ApplyTemplateResponse, error) {
+	m.lastApply = req
+	if m.applyErr != nil {
+		return nil, m.applyErr
+	}
+	return m.applied, nil
+}
+
 func createContextWithUserID(userID string) context.Context {
 	ctx := context.Background()
 	return context.WithValue(ctx, middleware.UserIDKey, userID)
@@ -302,6 +341,312 @@ func TestDeleteCategory_Forbidden(t *testing.T) {
 	}
 }
 
+func TestDeleteCategory_InUse(t *testing.T) {
+	service := newMockCategoryService()
+	service.deleteErr = errors.New("category is in use")
+	handler := &Handler{categoryService: service}
+
+	req := httptest.NewRequest("DELETE", "/categories/cat1", nil)
+	req = req.WithContext(createContextWithUserID("user1"))
+	rr := httptest.NewRecorder()
+	handler.DeleteCategory(rr, req)
+
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409, got %d", rr.Code)
+	}
+}
+
+func TestCreateCategory_WithParent(t *testing.T) {
+	service := newMockCategoryService()
+	handler := &Handler{categoryService: service}
+
+	jsonBody, _ := json.Marshal(CategoryRequest{Name: "Groceries", Type: intPtr(0), ParentID: " food "})
+
+	req := httptest.NewRequest("POST", "/categories", bytes.NewBuffer(jsonBody))
+	req = req.WithContext(createContextWithUserID("user1"))
+	rr := httptest.NewRecorder()
+	handler.CreateCategory(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if service.lastCommand.ParentID != "food" {
+		t.Errorf("expected parent food, got %q", service.lastCommand.ParentID)
+	}
+}
+
+func TestCreateCategory_HierarchyErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"missing parent", errors.New("parent category not found"), http.StatusBadRequest},
+		{"foreign parent", errors.New("unauthorized access to category"), http.StatusForbidden},
+		{"type mismatch", errors.New("subcategory type must match its parent's"), http.StatusBadRequest},
+		{"cycle", errors.New("a category cannot be nested under itself or one of its subcategories"), http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newMockCategoryService()
+			service.createErr = tt.err
+			handler := &Handler{categoryService: service}
+
+			jsonBody, _ := json.Marshal(CategoryRequest{Name: "Groceries", Type: intPtr(0), ParentID: "food"})
+			req := httptest.NewRequest("POST", "/categories", bytes.NewBuffer(jsonBody))
+			req = req.WithContext(createContextWithUserID("user1"))
+			rr := httptest.NewRecorder()
+			handler.CreateCategory(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestUpdateCategory_ParentNotFound(t *testing.T) {
+	service := newMockCategoryService()
+	service.updateErr = errors.New("parent category not found")
+	handler := &Handler{categoryService: service}
+
+	jsonBody, _ := json.Marshal(CategoryRequest{Name: "Food", Type: intPtr(0), ParentID: "missing"})
+	req := httptest.NewRequest("PUT", "/categories/cat1", bytes.NewBuffer(jsonBody))
+	req = req.WithContext(createContextWithUserID("user1"))
+	rr := httptest.NewRecorder()
+	handler.UpdateCategory(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rr.Code)
+	}
+}
+
+func TestDeleteCategory_Strategy(t *testing.T) {
+	service := newMockCategoryService()
+	handler := &Handler{categoryService: service}
+
+	req := httptest.NewRequest("DELETE", "/categories/cat1?strategy=Cascade", nil)
+	req = req.WithContext(createContextWithUserID("user1"))
+	rr := httptest.NewRecorder()
+	handler.DeleteCategory(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if service.lastStrategy != "cascade" {
+		t.Errorf("expected cascade strategy, got %q", service.lastStrategy)
+	}
+}
+
+func TestDeleteCategory_StrategyErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"has subcategories", errors.New("category has subcategories"), http.StatusConflict},
+		{"invalid strategy", errors.New("invalid delete strategy"), http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newMockCategoryService()
+			service.deleteErr = tt.err
+			handler := &Handler{categoryService: service}
+
+			req := httptest.NewRequest("DELETE", "/categories/cat1", nil)
+			req = req.WithContext(createContextWithUserID("user1"))
+			rr := httptest.NewRecorder()
+			handler.DeleteCategory(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestMergeCategories_Success(t *testing.T) {
+	service := newMockCategoryService()
+	handler := &Handler{categoryService: service}
+
+	body, _ := json.Marshal(MergeCategoriesRequest{SourceIDs: []string{"super", "market"}})
+	req := httptest.NewRequest("POST", "/categories/groceries/merge", bytes.NewBuffer(body))
+	req = req.WithContext(createContextWithUserID("user1"))
+	rr := httptest.NewRecorder()
+	handler.MergeCategories(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if service.lastTarget != "groceries" || len(service.lastMerge.SourceIDs) != 2 {
+		t.Errorf("unexpected merge of %v into %s", service.lastMerge.SourceIDs, service.lastTarget)
+	}
+}
+
+func TestMergeCategories_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{name: "no sources", body: `{"source_ids":[]}`, wantStatus: http.StatusBadRequest},
+		{name: "not found", body: `{"source_ids":["super"]}`, err: errors.New("category not found"), wantStatus: http.StatusNotFound},
+		{name: "forbidden", body: `{"source_ids":["super"]}`, err: errors.New("unauthorized access to category"), wantStatus: http.StatusForbidden},
+		{name: "into subcategory", body: `{"source_ids":["food"]}`, err: errors.New("a category cannot be merged into one of its subcategories"), wantStatus: http.StatusBadRequest},
+		{name: "type mismatch", body: `{"source_ids":["salary"]}`, err: errors.New("merged categories must have the same type"), wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newMockCategoryService()
+			service.mergeErr = tt.err
+			handler := &Handler{categoryService: service}
+
+			req := httptest.NewRequest("POST", "/categories/groceries/merge", bytes.NewBufferString(tt.body))
+			req = req.WithContext(createContextWithUserID("user1"))
+			rr := httptest.NewRecorder()
+			handler.MergeCategories(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestListCategoryTree_Success(t *testing.T) {
+	service := newMockCategoryService()
+	service.tree = []*queries.CategoryTreeResponse{
+		{
+			ID:       "food",
+			Name:     "Food",
+			TypeName: "Expense",
+			Children: []*queries.CategoryTreeResponse{
+				{ID: "groceries", Name: "Groceries", TypeName: "Expense", Children: []*queries.CategoryTreeResponse{}},
+			},
+		},
+	}
+	handler := &Handler{categoryService: service}
+
+	req := httptest.NewRequest("GET", "/categories/tree", nil)
+	req = req.WithContext(createContextWithUserID("user1"))
+	rr := httptest.NewRecorder()
+	handler.ListCategoryTree(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+
+	var response []CategoryTreeResponse
+	json.NewDecoder(rr.Body).Decode(&response)
+	if len(response) != 1 || len(response[0].Children) != 1 || response[0].Children[0].ID != "groceries" {
+		t.Errorf("unexpected tree %+v", response)
+	}
+	if response[0].Children[0].Children == nil {
+		t.Error("expected leaves to have an empty children list")
+	}
+}
+
+func TestListCategoryTemplates_Success(t *testing.T) {
+	service := newMockCategoryService()
+	service.templates = []*queries.CategoryTemplateResponse{
+		{
+			Key:      "personal",
+			Version:  1,
+			Language: "en",
+			Name:     "Personal finances",
+			Categories: []queries.CategoryTemplateCategoryResponse{
+				{Name: "Food", TypeName: "Expense", Children: []string{"Groceries"}},
+				{Name: "Salary", Type: 1, TypeName: "Income"},
+			},
+		},
+	}
+	handler := &Handler{categoryService: service}
+
+	req := httptest.NewRequest("GET", "/categories/templates", nil)
+	req = req.WithContext(createContextWithUserID("user1"))
+	rr := httptest.NewRecorder()
+	handler.ListCategoryTemplates(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+
+	var response []CategoryTemplateResponse
+	json.NewDecoder(rr.Body).Decode(&response)
+	if len(response) != 1 || len(response[0].Categories) != 2 || response[0].Categories[0].Children[0] != "Groceries" {
+		t.Fatalf("unexpected templates %+v", response)
+	}
+	if response[0].Categories[1].Children == nil {
+		t.Error("expected categories without subcategories to have an empty children list")
+	}
+}
+
+func TestApplyCategoryTemplate_Success(t *testing.T) {
+	service := newMockCategoryService()
+	service.applied = &queries.ApplyTemplateResponse{
+		Template: "personal",
+		Version:  1,
+		Language: "es",
+		Created:  []*queries.CategoryResponse{{ID: "cat1", Name: "Vivienda", TypeName: "Expense"}},
+		Skipped:  []string{"Comida"},
+	}
+	handler := &Handler{categoryService: service}
+
+	body, _ := json.Marshal(ApplyTemplateRequest{Template: "personal", Language: "es"})
+	req := httptest.NewRequest("POST", "/categories/templates/apply", bytes.NewBuffer(body))
+	req = req.WithContext(createContextWithUserID("user1"))
+	rr := httptest.NewRecorder()
+	handler.ApplyCategoryTemplate(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if service.lastApply.Template != "personal" || service.lastApply.Language != "es" {
+		t.Errorf("unexpected command %+v", service.lastApply)
+	}
+
+	var response ApplyTemplateResponse
+	json.NewDecoder(rr.Body).Decode(&response)
+	if len(response.Created) != 1 || response.Created[0].Name != "Vivienda" || len(response.Skipped) != 1 {
+		t.Errorf("unexpected response %+v", response)
+	}
+}
+
+func TestApplyCategoryTemplate_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{name: "missing template", body: `{"language":"es"}`, wantStatus: http.StatusBadRequest},
+		{name: "missing language", body: `{"template":"personal"}`, wantStatus: http.StatusBadRequest},
+		{name: "unknown template", body: `{"template":"business","language":"es"}`, err: errors.New("category template not found"), wantStatus: http.StatusNotFound},
+		{name: "duplicate", body: `{"template":"personal","language":"es"}`, err: errors.New("duplicate key value violates unique constraint"), wantStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newMockCategoryService()
+			service.applyErr = tt.err
+			handler := &Handler{categoryService: service}
+
+			req := httptest.NewRequest("POST", "/categories/templates/apply", bytes.NewBufferString(tt.body))
+			req = req.WithContext(createContextWithUserID("user1"))
+			rr := httptest.NewRecorder()
+			handler.ApplyCategoryTemplate(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rr.Code)
+			}
+		})
+	}
+}
+
 func TestListCategories_Success(t *testing.T) {
 	service := newMockCategoryService()
 	service.categories = []*queries.CategoryResponse{
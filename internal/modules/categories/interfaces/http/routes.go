@@ -2,6 +2,7 @@ package http
 
 import (
 	"net/http"
+	"strings"
 
 	"fin-flow-api/internal/shared/interface/jwt"
 	"fin-flow-api/internal/shared/middleware"
@@ -34,6 +35,23 @@ func handleCategoriesCollection(jwtService jwt.Service) http.HandlerFunc {
 }
 
 func handleCategoriesResource(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/categories/tree":
+		categoryHandler.ListCategoryTree(w, r)
+		return
+	case "/categories/templates":
+		categoryHandler.ListCategoryTemplates(w, r)
+		return
+	case "/categories/templates/apply":
+		categoryHandler.ApplyCategoryTemplate(w, r)
+		return
+	}
+
+	if strings.HasSuffix(r.URL.Path, "/merge") {
+		categoryHandler.MergeCategories(w, r)
+		return
+	}
+
 	switch r.Method {
 	case http.MethodGet:
 		categoryHandler.GetCategory(w, r)
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+type RuleRequest struct {
+	Name string
+	// Priority places the rule among the others, lowest first. Without one
+	// the rule goes after all of them when created and keeps its place when
+	// updated.
+	Priority            *int
+	Enabled             *bool
+	DescriptionContains string
+	PayeeContains       string
+	MinAmount           *domain.Amount
+	MaxAmount           *domain.Amount
+	WalletID            string
+	Currency            string
+	CategoryID          string
+	SetPayee            string
+	Tags                []string
+}
+
+// RuleScope picks the existing expenses and income to run rules over.
+type RuleScope struct {
+	WalletID string
+	From     *time.Time
+	To       *time.Time
+}
+
+// DryRunRequest previews the saved rules over Scope or, when Rule is set,
+// that rule alone before saving it.
+type DryRunRequest struct {
+	Rule  *RuleRequest
+	Scope RuleScope
+}
+
+type LearnRuleRequest struct {
+	TransactionID string
+}
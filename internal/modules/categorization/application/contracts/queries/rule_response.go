@@ -0,0 +1,50 @@
+package queries
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+type RuleResponse struct {
+	ID                  string
+	Name                string
+	Priority            int
+	Enabled             bool
+	DescriptionContains string
+	PayeeContains       string
+	MinAmount           *domain.Amount
+	MaxAmount           *domain.Amount
+	WalletID            string
+	Currency            string
+	CategoryID          string
+	SetPayee            string
+	Tags                []string
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	CreatedBy           string
+	UpdatedBy           string
+}
+
+// RuleChangeResponse is how rules change an existing transaction. Fields
+// the rules leave alone keep their current value.
+type RuleChangeResponse struct {
+	TransactionID string
+	Date          time.Time
+	Description   string
+	Amount        domain.Amount
+	CategoryID    string
+	NewCategoryID string
+	Payee         string
+	NewPayee      string
+	Tags          []string
+	NewTags       []string
+	RuleIDs       []string
+}
+
+// LearnRuleResponse is a rule proposed from a transaction, not saved yet,
+// and the changes it would make to the other transactions of the user.
+type LearnRuleResponse struct {
+	Rule    *RuleResponse
+	Changes []*RuleChangeResponse
+}
@@ -0,0 +1,462 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	categorydomain "fin-flow-api/internal/modules/categories/domain"
+	"fin-flow-api/internal/modules/categorization/application/contracts/commands"
+	"fin-flow-api/internal/modules/categorization/application/contracts/queries"
+	"fin-flow-api/internal/modules/categorization/domain"
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	"fin-flow-api/internal/shared/middleware"
+
+	"github.com/google/uuid"
+)
+
+type RuleService struct {
+	repository            domain.RuleRepository
+	transactionRepository transactiondomain.TransactionRepository
+	walletRepository      walletdomain.WalletRepository
+	categoryRepository    categorydomain.CategoryRepository
+	systemUser            string
+}
+
+func NewRuleService(repository domain.RuleRepository, transactionRepository transactiondomain.TransactionRepository, walletRepository walletdomain.WalletRepository, categoryRepository categorydomain.CategoryRepository, systemUser string) *RuleService {
+	return &RuleService{
+		repository:            repository,
+		transactionRepository: transactionRepository,
+		walletRepository:      walletRepository,
+		categoryRepository:    categoryRepository,
+		systemUser:            systemUser,
+	}
+}
+
+func (s *RuleService) getUserIDFromContext(ctx context.Context) (string, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return "", errors.New("user not authenticated")
+	}
+	return userID, nil
+}
+
+func (s *RuleService) Create(ctx context.Context, req commands.RuleRequest) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	priority, err := s.nextPriority(userID)
+	if err != nil {
+		return err
+	}
+
+	rule := domain.NewRule(uuid.New().String(), userID, req.Name, priority, s.systemUser)
+	applyRuleRequest(rule, req)
+
+	if err := s.validate(userID, rule); err != nil {
+		return err
+	}
+
+	return s.repository.Create(rule)
+}
+
+func (s *RuleService) Update(ctx context.Context, id string, req commands.RuleRequest) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	rule, err := s.repository.GetByID(id, userID)
+	if err != nil {
+		return err
+	}
+
+	rule.Name = req.Name
+	applyRuleRequest(rule, req)
+
+	if err := s.validate(userID, rule); err != nil {
+		return err
+	}
+
+	rule.Entity.UpdateModified(s.systemUser)
+
+	return s.repository.Update(rule)
+}
+
+func (s *RuleService) Delete(ctx context.Context, id string) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return s.repository.Delete(id, userID)
+}
+
+func (s *RuleService) GetByID(ctx context.Context, id string) (*queries.RuleResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := s.repository.GetByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toRuleResponse(rule), nil
+}
+
+func (s *RuleService) List(ctx context.Context) ([]*queries.RuleResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := s.repository.List(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*queries.RuleResponse, len(rules))
+	for i, rule := range rules {
+		responses[i] = toRuleResponse(rule)
+	}
+
+	return responses, nil
+}
+
+// DryRun returns the existing transactions in scope that the rules would
+// change, and how, without changing them. Unlike new transactions, whose
+// category is only filled in when missing, the category a rule assigns
+// replaces the current one.
+func (s *RuleService) DryRun(ctx context.Context, req commands.DryRunRequest) ([]*queries.RuleChangeResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []*domain.Rule
+	if req.Rule != nil {
+		rule := domain.NewRule("", userID, req.Rule.Name, 0, s.systemUser)
+		applyRuleRequest(rule, *req.Rule)
+		if err := s.validate(userID, rule); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	} else {
+		rules, err = s.repository.List(userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.changes(userID, rules, req.Scope, "")
+}
+
+// Apply makes the changes DryRun previews for the saved rules and returns
+// them.
+func (s *RuleService) Apply(ctx context.Context, scope commands.RuleScope) ([]*queries.RuleChangeResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := s.repository.List(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := s.changes(userID, rules, scope, "")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, change := range changes {
+		transaction, err := s.transactionRepository.GetByID(change.TransactionID, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		transaction.CategoryID = change.NewCategoryID
+		transaction.Payee = change.NewPayee
+		transaction.Tags = change.NewTags
+		transaction.Entity.UpdateModified(s.systemUser)
+
+		if err := s.transactionRepository.Update(transaction); err != nil {
+			return nil, err
+		}
+	}
+
+	return changes, nil
+}
+
+// Learn proposes a rule that gives transactions like the given one, which
+// the user has just corrected, the same category, payee and tags. The rule
+// is not saved; it comes with the changes it would make so the user can
+// decide.
+func (s *RuleService) Learn(ctx context.Context, req commands.LearnRuleRequest) (*queries.LearnRuleResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.transactionRepository.GetByID(req.TransactionID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	priority, err := s.nextPriority(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := domain.Suggest("", transaction, priority, s.systemUser)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := s.changes(userID, []*domain.Rule{rule}, commands.RuleScope{}, transaction.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &queries.LearnRuleResponse{
+		Rule:    toRuleResponse(rule),
+		Changes: changes,
+	}, nil
+}
+
+// Categorize applies the rules of its owner to a new expense or income in
+// a wallet of currency. Rules only fill in the category when the
+// transaction has none, since one picked by hand is a decision of the
+// user, while the payee they assign replaces the raw one and their tags are
+// added to those it has.
+func (s *RuleService) Categorize(transaction *transactiondomain.Transaction, currency string) error {
+	engine, err := s.engine(transaction.UserID)
+	if err != nil || engine == nil {
+		return err
+	}
+
+	categorize(engine, transaction, currency)
+	return nil
+}
+
+// CategorizeAll is Categorize for new transactions of a single user, such
+// as the lines of an imported statement. The rules are loaded only once.
+func (s *RuleService) CategorizeAll(transactions []*transactiondomain.Transaction) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+	userID := transactions[0].UserID
+
+	engine, err := s.engine(userID)
+	if err != nil || engine == nil {
+		return err
+	}
+
+	currencies := make(map[string]string)
+	for _, transaction := range transactions {
+		currency, ok := currencies[transaction.WalletID]
+		if !ok {
+			wallet, err := s.walletRepository.GetByID(transaction.WalletID, userID)
+			if err != nil {
+				return err
+			}
+			currency = wallet.Currency.String()
+			currencies[transaction.WalletID] = currency
+		}
+
+		categorize(engine, transaction, currency)
+	}
+
+	return nil
+}
+
+// engine returns the rules of the user ready to be evaluated, or nil when
+// the user has none.
+func (s *RuleService) engine(userID string) (*domain.Engine, error) {
+	rules, err := s.repository.List(userID)
+	if err != nil || len(rules) == 0 {
+		return nil, err
+	}
+
+	categories, err := s.categoryRepository.List(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewEngine(rules, categories), nil
+}
+
+func categorize(engine *domain.Engine, transaction *transactiondomain.Transaction, currency string) {
+	outcome := engine.Evaluate(domain.CandidateFor(transaction, currency))
+
+	if transaction.CategoryID == "" {
+		transaction.CategoryID = outcome.CategoryID
+	}
+	if outcome.Payee != "" {
+		transaction.Payee = outcome.Payee
+	}
+	transaction.AddTags(outcome.Tags...)
+}
+
+// changes runs rules over the expenses and income of the user in scope,
+// other than skipID, and returns those that would change. Loan payments are
+// left out, since their category comes from the loan.
+func (s *RuleService) changes(userID string, rules []*domain.Rule, scope commands.RuleScope, skipID string) ([]*queries.RuleChangeResponse, error) {
+	categories, err := s.categoryRepository.List(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	wallets, err := s.walletRepository.List(userID)
+	if err != nil {
+		return nil, err
+	}
+	currencies := make(map[string]string, len(wallets))
+	for _, wallet := range wallets {
+		currencies[wallet.ID] = wallet.Currency.String()
+	}
+
+	transactions, err := s.transactionRepository.List(userID, transactiondomain.TransactionFilter{
+		WalletID: scope.WalletID,
+		From:     scope.From,
+		To:       scope.To,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	engine := domain.NewEngine(rules, categories)
+	changes := make([]*queries.RuleChangeResponse, 0)
+	for _, transaction := range transactions {
+		if transaction.ID == skipID || transaction.IsLoanPayment() {
+			continue
+		}
+
+		outcome := engine.Evaluate(domain.CandidateFor(transaction, currencies[transaction.WalletID]))
+		if change := toRuleChangeResponse(transaction, outcome); change != nil {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes, nil
+}
+
+// nextPriority is the priority that places a new rule after all the others.
+func (s *RuleService) nextPriority(userID string) (int, error) {
+	rules, err := s.repository.List(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	priority := 1
+	for _, rule := range rules {
+		if rule.Priority >= priority {
+			priority = rule.Priority + 1
+		}
+	}
+	return priority, nil
+}
+
+func (s *RuleService) validate(userID string, rule *domain.Rule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+
+	if rule.Currency != "" && !walletdomain.IsValidCurrency(rule.Currency) {
+		return walletdomain.ErrInvalidCurrency
+	}
+
+	if rule.WalletID != "" {
+		if _, err := s.walletRepository.GetByID(rule.WalletID, userID); err != nil {
+			return err
+		}
+	}
+
+	if rule.CategoryID != "" {
+		if _, err := s.categoryRepository.GetByID(rule.CategoryID, userID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func applyRuleRequest(rule *domain.Rule, req commands.RuleRequest) {
+	if req.Priority != nil {
+		rule.Priority = *req.Priority
+	}
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+	rule.DescriptionContains = req.DescriptionContains
+	rule.PayeeContains = req.PayeeContains
+	rule.MinAmount = req.MinAmount
+	rule.MaxAmount = req.MaxAmount
+	rule.WalletID = req.WalletID
+	rule.Currency = strings.ToUpper(req.Currency)
+	rule.CategoryID = req.CategoryID
+	rule.SetPayee = req.SetPayee
+	rule.Tags = transactiondomain.NormalizeTags(req.Tags)
+}
+
+// toRuleChangeResponse describes what outcome changes in transaction, or
+// returns nil when it changes nothing.
+func toRuleChangeResponse(transaction *transactiondomain.Transaction, outcome domain.Outcome) *queries.RuleChangeResponse {
+	tags := transactiondomain.NormalizeTags(transaction.Tags)
+	change := &queries.RuleChangeResponse{
+		TransactionID: transaction.ID,
+		Date:          transaction.Date,
+		Description:   transaction.Description,
+		Amount:        transaction.Amount,
+		CategoryID:    transaction.CategoryID,
+		NewCategoryID: transaction.CategoryID,
+		Payee:         transaction.Payee,
+		NewPayee:      transaction.Payee,
+		Tags:          transaction.Tags,
+		NewTags:       transactiondomain.NormalizeTags(append(tags, outcome.Tags...)),
+	}
+	if outcome.CategoryID != "" {
+		change.NewCategoryID = outcome.CategoryID
+	}
+	if outcome.Payee != "" {
+		change.NewPayee = outcome.Payee
+	}
+
+	// Draft rules have no ID yet.
+	for _, ruleID := range outcome.RuleIDs {
+		if ruleID != "" {
+			change.RuleIDs = append(change.RuleIDs, ruleID)
+		}
+	}
+
+	if change.NewCategoryID == change.CategoryID && change.NewPayee == change.Payee && len(change.NewTags) == len(tags) {
+		return nil
+	}
+	return change
+}
+
+func toRuleResponse(rule *domain.Rule) *queries.RuleResponse {
+	return &queries.RuleResponse{
+		ID:                  rule.ID,
+		Name:                rule.Name,
+		Priority:            rule.Priority,
+		Enabled:             rule.Enabled,
+		DescriptionContains: rule.DescriptionContains,
+		PayeeContains:       rule.PayeeContains,
+		MinAmount:           rule.MinAmount,
+		MaxAmount:           rule.MaxAmount,
+		WalletID:            rule.WalletID,
+		Currency:            rule.Currency,
+		CategoryID:          rule.CategoryID,
+		SetPayee:            rule.SetPayee,
+		Tags:                rule.Tags,
+		CreatedAt:           rule.CreatedAt,
+		UpdatedAt:           rule.ModifiedAt,
+		CreatedBy:           rule.CreatedBy,
+		UpdatedBy:           rule.ModifiedBy,
+	}
+}
@@ -0,0 +1,409 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	categorydomain "fin-flow-api/internal/modules/categories/domain"
+	"fin-flow-api/internal/modules/categorization/application/contracts/commands"
+	"fin-flow-api/internal/modules/categorization/domain"
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	"fin-flow-api/internal/modules/transactions/domain/transactiontest"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+type mockRuleRepository struct {
+	rules map[string]*domain.Rule
+}
+
+func newMockRuleRepository(rules ...*domain.Rule) *mockRuleRepository {
+	repo := &mockRuleRepository{rules: make(map[string]*domain.Rule)}
+	for _, rule := range rules {
+		repo.rules[rule.ID] = rule
+	}
+	return repo
+}
+
+func (m *mockRuleRepository) Create(rule *domain.Rule) error {
+	m.rules[rule.ID] = rule
+	return nil
+}
+
+func (m *mockRuleRepository) GetByID(id string, userID string) (*domain.Rule, error) {
+	rule, exists := m.rules[id]
+	if !exists {
+		return nil, errors.New("categorization rule not found")
+	}
+	if rule.UserID != userID {
+		return nil, errors.New("unauthorized access to categorization rule")
+	}
+	copied := *rule
+	return &copied, nil
+}
+
+func (m *mockRuleRepository) List(userID string) ([]*domain.Rule, error) {
+	var result []*domain.Rule
+	for _, rule := range m.rules {
+		if rule.UserID == userID {
+			result = append(result, rule)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Priority < result[j].Priority })
+	return result, nil
+}
+
+func (m *mockRuleRepository) Update(rule *domain.Rule) error {
+	if _, exists := m.rules[rule.ID]; !exists {
+		return errors.New("categorization rule not found")
+	}
+	m.rules[rule.ID] = rule
+	return nil
+}
+
+func (m *mockRuleRepository) Delete(id string, userID string) error {
+	rule, exists := m.rules[id]
+	if !exists {
+		return errors.New("categorization rule not found")
+	}
+	if rule.UserID != userID {
+		return errors.New("unauthorized access to categorization rule")
+	}
+	delete(m.rules, id)
+	return nil
+}
+
+type mockWalletRepository struct {
+	wallets map[string]*walletdomain.Wallet
+}
+
+func (m *mockWalletRepository) Create(wallet *walletdomain.Wallet) error {
+	return nil
+}
+
+func (m *mockWalletRepository) GetByID(id string, userID string) (*walletdomain.Wallet, error) {
+	wallet, exists := m.wallets[id]
+	if !exists {
+		return nil, errors.New("wallet not found")
+	}
+	if wallet.UserID != userID {
+		return nil, errors.New("unauthorized access to wallet")
+	}
+	return wallet, nil
+}
+
+func (m *mockWalletRepository) List(userID string) ([]*walletdomain.Wallet, error) {
+	var result []*walletdomain.Wallet
+	for _, wallet := range m.wallets {
+		if wallet.UserID == userID {
+			result = append(result, wallet)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockWalletRepository) Update(wallet *walletdomain.Wallet) error {
+	return nil
+}
+
+func (m *mockWalletRepository) Delete(id string, userID string) error {
+	return nil
+}
+
+type mockCategoryRepository struct {
+	categories map[string]*categorydomain.Category
+}
+
+func (m *mockCategoryRepository) Create(category *categorydomain.Category) error {
+	return nil
+}
+
+func (m *mockCategoryRepository) CreateAll(categories []*categorydomain.Category) error {
+	return nil
+}
+
+func (m *mockCategoryRepository) GetByID(id string, userID string) (*categorydomain.Category, error) {
+	category, exists := m.categories[id]
+	if !exists {
+		return nil, errors.New("category not found")
+	}
+	if category.UserID != userID {
+		return nil, errors.New("unauthorized access to category")
+	}
+	return category, nil
+}
+
+func (m *mockCategoryRepository) List(userID string) ([]*categorydomain.Category, error) {
+	var result []*categorydomain.Category
+	for _, category := range m.categories {
+		if category.UserID == userID {
+			result = append(result, category)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockCategoryRepository) Update(category *categorydomain.Category) error {
+	return nil
+}
+
+func (m *mockCategoryRepository) Delete(id string, userID string, strategy categorydomain.DeleteStrategy) error {
+	return nil
+}
+
+func (m *mockCategoryRepository) Merge(targetID string, sourceIDs []string, userID string) error {
+	return nil
+}
+
+func contextWithUser(userID string) context.Context {
+	return context.WithValue(context.Background(), middleware.UserIDKey, userID)
+}
+
+func expense(id, description, categoryID, amount string) *transactiondomain.Transaction {
+	return transactiondomain.NewTransaction(id, "user1", "wallet1", categoryID, transactiondomain.TransactionTypeExpense, shareddomain.MustParseAmount(amount), description, time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), "system")
+}
+
+func coffeeRule(id string, priority int) *domain.Rule {
+	rule := domain.NewRule(id, "user1", "Coffee", priority, "system")
+	rule.DescriptionContains = "coffee"
+	rule.CategoryID = "cat-coffee"
+	rule.SetPayee = "Coffee Shop"
+	rule.Tags = []string{"treats"}
+	return rule
+}
+
+func newTestService(rules []*domain.Rule, transactions ...*transactiondomain.Transaction) (*RuleService, *mockRuleRepository, *transactiontest.Repository) {
+	repo := newMockRuleRepository(rules...)
+	transactionRepo := transactiontest.NewRepository(transactions...)
+	wallets := &mockWalletRepository{wallets: map[string]*walletdomain.Wallet{
+		"wallet1": walletdomain.NewWallet("wallet1", "user1", "Checking", walletdomain.WalletTypeBank, shareddomain.Amount{}, walletdomain.CurrencyUSD, "system"),
+		"wallet2": walletdomain.NewWallet("wallet2", "user2", "Other", walletdomain.WalletTypeBank, shareddomain.Amount{}, walletdomain.CurrencyUSD, "system"),
+	}}
+	categories := &mockCategoryRepository{categories: map[string]*categorydomain.Category{
+		"cat-food":   categorydomain.NewCategory("cat-food", "user1", "Food", categorydomain.CategoryTypeExpense, "system"),
+		"cat-coffee": categorydomain.NewCategory("cat-coffee", "user1", "Coffee", categorydomain.CategoryTypeExpense, "system"),
+		"cat-other":  categorydomain.NewCategory("cat-other", "user2", "Other", categorydomain.CategoryTypeExpense, "system"),
+	}}
+	return NewRuleService(repo, transactionRepo, wallets, categories, "system"), repo, transactionRepo
+}
+
+func intPtr(value int) *int {
+	return &value
+}
+
+func TestRuleService_Create(t *testing.T) {
+	service, repo, _ := newTestService([]*domain.Rule{coffeeRule("rule1", 4)})
+
+	err := service.Create(contextWithUser("user1"), commands.RuleRequest{
+		Name:          "Supermarket",
+		PayeeContains: "market",
+		Currency:      "usd",
+		CategoryID:    "cat-food",
+		Tags:          []string{"home", " Home"},
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	rules, _ := repo.List("user1")
+	if len(rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(rules))
+	}
+	created := rules[1]
+	if created.Priority != 5 || !created.Enabled {
+		t.Errorf("expected an enabled rule after the others, got priority %d", created.Priority)
+	}
+	if created.Currency != "USD" || len(created.Tags) != 1 {
+		t.Errorf("expected normalized currency and tags, got %q and %v", created.Currency, created.Tags)
+	}
+}
+
+func TestRuleService_Create_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      commands.RuleRequest
+		expected string
+	}{
+		{"no condition", commands.RuleRequest{Name: "Food", CategoryID: "cat-food"}, "at least one condition"},
+		{"no action", commands.RuleRequest{Name: "Food", DescriptionContains: "food"}, "a category, a payee or tags"},
+		{"invalid currency", commands.RuleRequest{Name: "Food", Currency: "XYZ", CategoryID: "cat-food"}, "invalid currency"},
+		{"unknown category", commands.RuleRequest{Name: "Food", DescriptionContains: "food", CategoryID: "cat-missing"}, "category not found"},
+		{"category of another user", commands.RuleRequest{Name: "Food", DescriptionContains: "food", CategoryID: "cat-other"}, "unauthorized access to category"},
+		{"wallet of another user", commands.RuleRequest{Name: "Food", WalletID: "wallet2", CategoryID: "cat-food"}, "unauthorized access to wallet"},
+		{"negative priority", commands.RuleRequest{Name: "Food", Priority: intPtr(-1), DescriptionContains: "food", CategoryID: "cat-food"}, "priority must not be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _, _ := newTestService(nil)
+
+			err := service.Create(contextWithUser("user1"), tt.req)
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("expected error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestRuleService_Update_KeepsPriority(t *testing.T) {
+	service, repo, _ := newTestService([]*domain.Rule{coffeeRule("rule1", 3)})
+
+	err := service.Update(contextWithUser("user1"), "rule1", commands.RuleRequest{
+		Name:                "Coffee",
+		DescriptionContains: "espresso",
+		CategoryID:          "cat-coffee",
+		Enabled:             boolPtr(false),
+	})
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	rule := repo.rules["rule1"]
+	if rule.Priority != 3 || rule.Enabled || rule.DescriptionContains != "espresso" || rule.SetPayee != "" {
+		t.Errorf("unexpected rule after update %+v", rule)
+	}
+
+	if err := service.Update(contextWithUser("user2"), "rule1", commands.RuleRequest{Name: "Coffee"}); err == nil || !strings.Contains(err.Error(), "unauthorized") {
+		t.Errorf("expected unauthorized error, got %v", err)
+	}
+}
+
+func boolPtr(value bool) *bool {
+	return &value
+}
+
+func TestRuleService_Categorize(t *testing.T) {
+	service, _, _ := newTestService([]*domain.Rule{coffeeRule("rule1", 1)})
+
+	transaction := expense("tx1", "COFFEE BAR 123", "", "4.50")
+	transaction.Tags = []string{"work"}
+	if err := service.Categorize(transaction, "USD"); err != nil {
+		t.Fatalf("Categorize failed: %v", err)
+	}
+
+	if transaction.CategoryID != "cat-coffee" || transaction.Payee != "Coffee Shop" {
+		t.Errorf("expected the rule to set category and payee, got %q and %q", transaction.CategoryID, transaction.Payee)
+	}
+	if strings.Join(transaction.Tags, ",") != "work,treats" {
+		t.Errorf("expected the rule tags to be added, got %v", transaction.Tags)
+	}
+
+	chosen := expense("tx2", "coffee beans", "cat-food", "12")
+	if err := service.Categorize(chosen, "USD"); err != nil {
+		t.Fatalf("Categorize failed: %v", err)
+	}
+	if chosen.CategoryID != "cat-food" {
+		t.Errorf("expected the category picked by hand to be kept, got %q", chosen.CategoryID)
+	}
+}
+
+func TestRuleService_DryRun(t *testing.T) {
+	matching := expense("tx1", "Coffee Bar", "cat-food", "4.50")
+	done := expense("tx2", "coffee", "cat-coffee", "3")
+	done.Payee = "Coffee Shop"
+	done.Tags = []string{"treats"}
+	other := expense("tx3", "Rent", "cat-food", "900")
+	service, _, transactionRepo := newTestService([]*domain.Rule{coffeeRule("rule1", 1)}, matching, done, other)
+
+	changes, err := service.DryRun(contextWithUser("user1"), commands.DryRunRequest{})
+	if err != nil {
+		t.Fatalf("DryRun failed: %v", err)
+	}
+
+	if len(changes) != 1 || changes[0].TransactionID != "tx1" {
+		t.Fatalf("expected only tx1 to change, got %d changes", len(changes))
+	}
+	change := changes[0]
+	if change.CategoryID != "cat-food" || change.NewCategoryID != "cat-coffee" || change.NewPayee != "Coffee Shop" {
+		t.Errorf("unexpected change %+v", change)
+	}
+	if strings.Join(change.RuleIDs, ",") != "rule1" {
+		t.Errorf("expected rule1 to match, got %v", change.RuleIDs)
+	}
+	if len(transactionRepo.Updated) != 0 {
+		t.Error("expected a dry run not to update transactions")
+	}
+}
+
+func TestRuleService_DryRun_Draft(t *testing.T) {
+	rent := expense("tx1", "RENT MARCH", "cat-coffee", "900")
+	service, _, _ := newTestService([]*domain.Rule{coffeeRule("rule1", 1)}, rent, expense("tx2", "Coffee", "cat-food", "3"))
+
+	changes, err := service.DryRun(contextWithUser("user1"), commands.DryRunRequest{
+		Rule: &commands.RuleRequest{Name: "Rent", DescriptionContains: "rent", MinAmount: amountPtr("500"), CategoryID: "cat-food"},
+	})
+	if err != nil {
+		t.Fatalf("DryRun failed: %v", err)
+	}
+
+	if len(changes) != 1 || changes[0].TransactionID != "tx1" || changes[0].NewCategoryID != "cat-food" {
+		t.Fatalf("expected only the draft rule to be previewed, got %+v", changes)
+	}
+	if len(changes[0].RuleIDs) != 0 {
+		t.Errorf("expected no rule IDs for a draft, got %v", changes[0].RuleIDs)
+	}
+
+	if _, err := service.DryRun(contextWithUser("user1"), commands.DryRunRequest{Rule: &commands.RuleRequest{Name: "Rent"}}); err != domain.ErrConditionRequired {
+		t.Errorf("expected ErrConditionRequired, got %v", err)
+	}
+}
+
+func amountPtr(value string) *shareddomain.Amount {
+	amount := shareddomain.MustParseAmount(value)
+	return &amount
+}
+
+func TestRuleService_Apply(t *testing.T) {
+	service, _, transactionRepo := newTestService([]*domain.Rule{coffeeRule("rule1", 1)}, expense("tx1", "Coffee Bar", "cat-food", "4.50"), expense("tx2", "Rent", "cat-food", "900"))
+
+	changes, err := service.Apply(contextWithUser("user1"), commands.RuleScope{})
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	if len(changes) != 1 || len(transactionRepo.Updated) != 1 {
+		t.Fatalf("expected one transaction to be updated, got %d changes and %d updates", len(changes), len(transactionRepo.Updated))
+	}
+	updated := transactionRepo.Updated[0]
+	if updated.CategoryID != "cat-coffee" || updated.Payee != "Coffee Shop" || strings.Join(updated.Tags, ",") != "treats" {
+		t.Errorf("unexpected updated transaction %+v", updated)
+	}
+}
+
+func TestRuleService_Learn(t *testing.T) {
+	corrected := expense("tx1", "NETFLIX.COM 866-579", "cat-food", "12.99")
+	corrected.Payee = "Netflix"
+	service, _, _ := newTestService([]*domain.Rule{coffeeRule("rule1", 2)}, corrected, expense("tx2", "netflix.com 0001", "", "12.99"), expense("tx3", "Coffee", "", "3"))
+
+	result, err := service.Learn(contextWithUser("user1"), commands.LearnRuleRequest{TransactionID: "tx1"})
+	if err != nil {
+		t.Fatalf("Learn failed: %v", err)
+	}
+
+	if result.Rule.DescriptionContains != "NETFLIX.COM" || result.Rule.SetPayee != "Netflix" || result.Rule.CategoryID != "cat-food" {
+		t.Errorf("unexpected proposed rule %+v", result.Rule)
+	}
+	if result.Rule.Priority != 3 {
+		t.Errorf("expected the proposed rule after the others, got priority %d", result.Rule.Priority)
+	}
+	if len(result.Changes) != 1 || result.Changes[0].TransactionID != "tx2" {
+		t.Errorf("expected the rule to change tx2 only, got %+v", result.Changes)
+	}
+
+	if _, err := service.Learn(contextWithUser("user2"), commands.LearnRuleRequest{TransactionID: "tx1"}); err == nil || !strings.Contains(err.Error(), "unauthorized") {
+		t.Errorf("expected unauthorized error, got %v", err)
+	}
+}
+
+func TestRuleService_Unauthenticated(t *testing.T) {
+	service, _, _ := newTestService(nil)
+
+	if _, err := service.List(context.Background()); err == nil || err.Error() != "user not authenticated" {
+		t.Errorf("expected authentication error, got %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"sort"
+
+	categorydomain "fin-flow-api/internal/modules/categories/domain"
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	"fin-flow-api/internal/shared/domain"
+)
+
+// Candidate is what rules look at in an expense or income.
+type Candidate struct {
+	Type        transactiondomain.TransactionType
+	Description string
+	Payee       string
+	Amount      domain.Amount
+	WalletID    string
+	Currency    string
+}
+
+// CandidateFor describes transaction, which is in a wallet of currency, to
+// the rules.
+func CandidateFor(transaction *transactiondomain.Transaction, currency string) Candidate {
+	return Candidate{
+		Type:        transaction.Type,
+		Description: transaction.Description,
+		Payee:       transaction.Payee,
+		Amount:      transaction.Amount,
+		WalletID:    transaction.WalletID,
+		Currency:    currency,
+	}
+}
+
+// Outcome is what the matching rules assign to a candidate. RuleIDs lists
+// the rules that matched, in the order they were evaluated.
+type Outcome struct {
+	CategoryID string
+	Payee      string
+	Tags       []string
+	RuleIDs    []string
+}
+
+// Matched reports whether any rule matched.
+func (o *Outcome) Matched() bool {
+	return len(o.RuleIDs) > 0
+}
+
+// Engine evaluates the enabled rules of a user in priority order.
+type Engine struct {
+	rules         []*Rule
+	categoryTypes map[string]categorydomain.CategoryType
+}
+
+// NewEngine prepares rules for evaluation. categories are those of the same
+// user, so a rule only assigns its category to transactions of a type the
+// category accepts.
+func NewEngine(rules []*Rule, categories []*categorydomain.Category) *Engine {
+	enabled := make([]*Rule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Enabled {
+			enabled = append(enabled, rule)
+		}
+	}
+	sort.SliceStable(enabled, func(i, j int) bool {
+		return enabled[i].Priority < enabled[j].Priority
+	})
+
+	categoryTypes := make(map[string]categorydomain.CategoryType, len(categories))
+	for _, category := range categories {
+		categoryTypes[category.ID] = category.Type
+	}
+
+	return &Engine{rules: enabled, categoryTypes: categoryTypes}
+}
+
+// Evaluate runs every rule against candidate. The first matching rule that
+// assigns a category or a payee decides it, and the tags of all matching
+// rules add up. Transfers are left alone.
+func (e *Engine) Evaluate(candidate Candidate) Outcome {
+	var outcome Outcome
+	if candidate.Type != transactiondomain.TransactionTypeExpense && candidate.Type != transactiondomain.TransactionTypeIncome {
+		return outcome
+	}
+
+	for _, rule := range e.rules {
+		if !rule.Matches(candidate) {
+			continue
+		}
+		outcome.RuleIDs = append(outcome.RuleIDs, rule.ID)
+
+		if outcome.CategoryID == "" && rule.CategoryID != "" {
+			if categoryType, ok := e.categoryTypes[rule.CategoryID]; ok && candidate.Type.AcceptsCategory(categoryType) {
+				outcome.CategoryID = rule.CategoryID
+			}
+		}
+		if outcome.Payee == "" {
+			outcome.Payee = rule.SetPayee
+		}
+		outcome.Tags = transactiondomain.NormalizeTags(append(outcome.Tags, rule.Tags...))
+	}
+
+	return outcome
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	categorydomain "fin-flow-api/internal/modules/categories/domain"
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+func descriptionRule(id string, priority int, contains string) *Rule {
+	rule := NewRule(id, "user-1", contains, priority, "system")
+	rule.DescriptionContains = contains
+	return rule
+}
+
+func TestEngine_Evaluate(t *testing.T) {
+	categories := []*categorydomain.Category{
+		categorydomain.NewCategory("food", "user-1", "Food", categorydomain.CategoryTypeExpense, "system"),
+		categorydomain.NewCategory("groceries", "user-1", "Groceries", categorydomain.CategoryTypeExpense, "system"),
+		categorydomain.NewCategory("refunds", "user-1", "Refunds", categorydomain.CategoryTypeIncome, "system"),
+	}
+
+	generic := descriptionRule("generic", 5, "market")
+	generic.CategoryID = "food"
+	generic.SetPayee = "Some market"
+	generic.Tags = []string{"food"}
+
+	specific := descriptionRule("specific", 1, "super market")
+	specific.CategoryID = "groceries"
+	specific.Tags = []string{"weekly", "Food"}
+
+	refund := descriptionRule("refund", 0, "market")
+	refund.CategoryID = "refunds"
+
+	disabled := descriptionRule("disabled", 0, "market")
+	disabled.SetPayee = "Disabled"
+	disabled.Enabled = false
+
+	engine := NewEngine([]*Rule{generic, specific, refund, disabled}, categories)
+
+	outcome := engine.Evaluate(Candidate{
+		Type:        transactiondomain.TransactionTypeExpense,
+		Description: "SUPER MARKET 24",
+		Amount:      shareddomain.MustParseAmount("80"),
+	})
+
+	if outcome.CategoryID != "groceries" {
+		t.Errorf("expected the first rule with an expense category to win, got %q", outcome.CategoryID)
+	}
+	if outcome.Payee != "Some market" {
+		t.Errorf("expected the payee of the generic rule, got %q", outcome.Payee)
+	}
+	if strings.Join(outcome.Tags, ",") != "weekly,Food" {
+		t.Errorf("expected the tags of both rules, got %v", outcome.Tags)
+	}
+	if strings.Join(outcome.RuleIDs, ",") != "refund,specific,generic" {
+		t.Errorf("unexpected matching rules %v", outcome.RuleIDs)
+	}
+}
+
+func TestEngine_Evaluate_NoMatch(t *testing.T) {
+	rule := descriptionRule("rule-1", 1, "coffee")
+	rule.CategoryID = "food"
+	engine := NewEngine([]*Rule{rule}, nil)
+
+	outcome := engine.Evaluate(Candidate{Type: transactiondomain.TransactionTypeExpense, Description: "Rent"})
+	if outcome.Matched() {
+		t.Errorf("expected no match, got %+v", outcome)
+	}
+
+	outcome = engine.Evaluate(Candidate{Type: transactiondomain.TransactionTypeTransfer, Description: "coffee"})
+	if outcome.Matched() {
+		t.Errorf("expected transfers to be left alone, got %+v", outcome)
+	}
+
+	outcome = engine.Evaluate(Candidate{Type: transactiondomain.TransactionTypeExpense, Description: "coffee"})
+	if !outcome.Matched() || outcome.CategoryID != "" {
+		t.Errorf("expected an unknown category not to be assigned, got %+v", outcome)
+	}
+}
@@ -0,0 +1,10 @@
+package domain
+
+type RuleRepository interface {
+	Create(rule *Rule) error
+	GetByID(id string, userID string) (*Rule, error)
+	// List returns the rules of a user in priority order.
+	List(userID string) ([]*Rule, error)
+	Update(rule *Rule) error
+	Delete(id string, userID string) error
+}
@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	"fin-flow-api/internal/shared/domain"
+)
+
+var (
+	ErrConditionRequired  = errors.New("a rule needs at least one condition")
+	ErrActionRequired     = errors.New("a rule needs a category, a payee or tags to assign")
+	ErrInvalidAmountRange = errors.New("minimum amount must not be greater than maximum amount")
+	ErrInvalidPriority    = errors.New("priority must not be negative")
+	ErrUnsupportedType    = errors.New("rules only apply to expenses and income")
+	ErrNothingToLearn     = errors.New("transaction has no description or payee to learn a rule from")
+)
+
+// Rule assigns a category, a payee and tags to the expenses and income that
+// meet all of its conditions. Text conditions match anywhere in the text,
+// regardless of case and spacing, and the amount range is inclusive.
+type Rule struct {
+	domain.Entity
+
+	ID       string
+	UserID   string
+	Name     string
+	Priority int
+	Enabled  bool
+
+	DescriptionContains string
+	PayeeContains       string
+	MinAmount           *domain.Amount
+	MaxAmount           *domain.Amount
+	WalletID            string
+	Currency            string
+
+	CategoryID string
+	SetPayee   string
+	Tags       []string
+}
+
+func NewRule(id, userID, name string, priority int, createdBy string) *Rule {
+	return &Rule{
+		Entity:   domain.NewEntity(id, createdBy),
+		ID:       id,
+		UserID:   userID,
+		Name:     name,
+		Priority: priority,
+		Enabled:  true,
+	}
+}
+
+// Validate checks that the rule has something to match on and something to
+// assign. The wallet, category and currency are validated by the service,
+// which has access to them.
+func (r *Rule) Validate() error {
+	if r.Priority < 0 {
+		return ErrInvalidPriority
+	}
+	if r.DescriptionContains == "" && r.PayeeContains == "" && r.MinAmount == nil && r.MaxAmount == nil && r.WalletID == "" && r.Currency == "" {
+		return ErrConditionRequired
+	}
+	if r.CategoryID == "" && r.SetPayee == "" && len(r.Tags) == 0 {
+		return ErrActionRequired
+	}
+	if r.MinAmount != nil && r.MaxAmount != nil && r.MinAmount.Cmp(*r.MaxAmount) > 0 {
+		return ErrInvalidAmountRange
+	}
+	return nil
+}
+
+// Matches reports whether the candidate meets every condition of the rule.
+func (r *Rule) Matches(candidate Candidate) bool {
+	if r.DescriptionContains != "" && !strings.Contains(normalizeText(candidate.Description), normalizeText(r.DescriptionContains)) {
+		return false
+	}
+	if r.PayeeContains != "" && !strings.Contains(normalizeText(candidate.Payee), normalizeText(r.PayeeContains)) {
+		return false
+	}
+	if r.MinAmount != nil && candidate.Amount.Cmp(*r.MinAmount) < 0 {
+		return false
+	}
+	if r.MaxAmount != nil && candidate.Amount.Cmp(*r.MaxAmount) > 0 {
+		return false
+	}
+	if r.WalletID != "" && candidate.WalletID != r.WalletID {
+		return false
+	}
+	if r.Currency != "" && !strings.EqualFold(candidate.Currency, r.Currency) {
+		return false
+	}
+	return true
+}
+
+// Suggest proposes a rule that would have given other transactions like
+// transaction the category, payee and tags the user gave it. It matches on
+// the leading words of the description, up to the first one with digits
+// since those tend to be references or dates that change every time, and
+// falls back to the payee when the description has no such words.
+func Suggest(id string, transaction *transactiondomain.Transaction, priority int, createdBy string) (*Rule, error) {
+	if transaction.Type != transactiondomain.TransactionTypeExpense && transaction.Type != transactiondomain.TransactionTypeIncome {
+		return nil, ErrUnsupportedType
+	}
+
+	keywords := leadingWords(transaction.Description, 3)
+
+	rule := NewRule(id, transaction.UserID, keywords, priority, createdBy)
+	switch {
+	case keywords != "":
+		rule.DescriptionContains = keywords
+		rule.SetPayee = transaction.Payee
+	case transaction.Payee != "":
+		rule.Name = transaction.Payee
+		rule.PayeeContains = transaction.Payee
+	default:
+		return nil, ErrNothingToLearn
+	}
+	rule.CategoryID = transaction.CategoryID
+	rule.Tags = transaction.Tags
+
+	return rule, nil
+}
+
+// leadingWords returns up to max words from the start of text, stopping at
+// the first one that contains a digit.
+func leadingWords(text string, max int) string {
+	var words []string
+	for _, word := range strings.Fields(text) {
+		if len(words) == max || strings.ContainsAny(word, "0123456789") {
+			break
+		}
+		words = append(words, word)
+	}
+	return strings.Join(words, " ")
+}
+
+func normalizeText(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
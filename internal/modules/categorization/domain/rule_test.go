@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+func amountPtr(value string) *shareddomain.Amount {
+	amount := shareddomain.MustParseAmount(value)
+	return &amount
+}
+
+func TestRule_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     func(rule *Rule)
+		expected error
+	}{
+		{"valid", func(rule *Rule) {}, nil},
+		{"no condition", func(rule *Rule) { rule.DescriptionContains = "" }, ErrConditionRequired},
+		{"amount only", func(rule *Rule) { rule.DescriptionContains = ""; rule.MinAmount = amountPtr("10") }, nil},
+		{"no action", func(rule *Rule) { rule.CategoryID = "" }, ErrActionRequired},
+		{"tags only", func(rule *Rule) { rule.CategoryID = ""; rule.Tags = []string{"work"} }, nil},
+		{"inverted range", func(rule *Rule) { rule.MinAmount = amountPtr("100"); rule.MaxAmount = amountPtr("10") }, ErrInvalidAmountRange},
+		{"negative priority", func(rule *Rule) { rule.Priority = -1 }, ErrInvalidPriority},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := NewRule("rule-1", "user-1", "Coffee", 1, "system")
+			rule.DescriptionContains = "coffee"
+			rule.CategoryID = "category-1"
+			tt.rule(rule)
+
+			if err := rule.Validate(); err != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestRule_Matches(t *testing.T) {
+	rule := NewRule("rule-1", "user-1", "Supermarket", 1, "system")
+	rule.DescriptionContains = "super  DIA"
+	rule.MinAmount = amountPtr("10")
+	rule.MaxAmount = amountPtr("500")
+	rule.Currency = "ars"
+	rule.CategoryID = "category-1"
+
+	candidate := Candidate{
+		Type:        transactiondomain.TransactionTypeExpense,
+		Description: "COMPRA SUPER DIA 0042",
+		Amount:      shareddomain.MustParseAmount("500"),
+		WalletID:    "wallet-1",
+		Currency:    "ARS",
+	}
+
+	if !rule.Matches(candidate) {
+		t.Error("expected the rule to match")
+	}
+
+	tests := []struct {
+		name   string
+		change func(candidate *Candidate)
+	}{
+		{"other description", func(candidate *Candidate) { candidate.Description = "SUPERMERCADO" }},
+		{"below range", func(candidate *Candidate) { candidate.Amount = shareddomain.MustParseAmount("9.99") }},
+		{"above range", func(candidate *Candidate) { candidate.Amount = shareddomain.MustParseAmount("500.01") }},
+		{"other currency", func(candidate *Candidate) { candidate.Currency = "USD" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed := candidate
+			tt.change(&changed)
+			if rule.Matches(changed) {
+				t.Error("expected the rule not to match")
+			}
+		})
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	transaction := transactiondomain.NewTransaction("tx-1", "user-1", "wallet-1", "category-1", transactiondomain.TransactionTypeExpense, shareddomain.MustParseAmount("12.99"), "NETFLIX.COM  866-579 AMSTERDAM", time.Now(), "system")
+	transaction.Payee = "Netflix"
+	transaction.Tags = []string{"streaming"}
+
+	rule, err := Suggest("rule-1", transaction, 3, "system")
+	if err != nil {
+		t.Fatalf("Suggest failed: %v", err)
+	}
+
+	if rule.DescriptionContains != "NETFLIX.COM" || rule.PayeeContains != "" {
+		t.Errorf("expected to match on the description, got %q and %q", rule.DescriptionContains, rule.PayeeContains)
+	}
+	if rule.CategoryID != "category-1" || rule.SetPayee != "Netflix" || len(rule.Tags) != 1 {
+		t.Errorf("unexpected actions %+v", rule)
+	}
+	if rule.Priority != 3 || rule.Validate() != nil {
+		t.Errorf("expected a valid rule with priority 3, got %d and %v", rule.Priority, rule.Validate())
+	}
+}
+
+func TestSuggest_FallsBackToPayee(t *testing.T) {
+	transaction := transactiondomain.NewTransaction("tx-1", "user-1", "wallet-1", "category-1", transactiondomain.TransactionTypeIncome, shareddomain.MustParseAmount("1000"), "0042-1234", time.Now(), "system")
+
+	if _, err := Suggest("rule-1", transaction, 1, "system"); err != ErrNothingToLearn {
+		t.Errorf("expected ErrNothingToLearn, got %v", err)
+	}
+
+	transaction.Payee = "ACME Corp"
+	rule, err := Suggest("rule-1", transaction, 1, "system")
+	if err != nil {
+		t.Fatalf("Suggest failed: %v", err)
+	}
+	if rule.PayeeContains != "ACME Corp" || rule.DescriptionContains != "" || rule.Name != "ACME Corp" {
+		t.Errorf("expected to match on the payee, got %+v", rule)
+	}
+}
+
+func TestSuggest_Transfer(t *testing.T) {
+	transaction := transactiondomain.NewTransaction("tx-1", "user-1", "wallet-1", "", transactiondomain.TransactionTypeTransfer, shareddomain.MustParseAmount("10"), "Savings", time.Now(), "system")
+
+	if _, err := Suggest("rule-1", transaction, 1, "system"); err != ErrUnsupportedType {
+		t.Errorf("expected ErrUnsupportedType, got %v", err)
+	}
+}
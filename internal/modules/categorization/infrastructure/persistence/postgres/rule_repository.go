@@ -0,0 +1,259 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"fin-flow-api/internal/modules/categorization/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const ruleColumns = `id, user_id, name, priority, enabled, description_contains, payee_contains, min_amount, max_amount, wallet_id, currency, category_id, set_payee, tags, created_at, modified_at, created_by, modified_by`
+
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+func (r *Repository) Create(rule *domain.Rule) error {
+	query := `
+		INSERT INTO categorization_rules (` + ruleColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+	`
+
+	_, err := r.pool.Exec(
+		context.Background(),
+		query,
+		rule.ID,
+		rule.UserID,
+		rule.Name,
+		rule.Priority,
+		rule.Enabled,
+		nullableString(rule.DescriptionContains),
+		nullableString(rule.PayeeContains),
+		rule.MinAmount,
+		rule.MaxAmount,
+		nullableString(rule.WalletID),
+		nullableString(rule.Currency),
+		nullableString(rule.CategoryID),
+		nullableString(rule.SetPayee),
+		tagsOrEmpty(rule.Tags),
+		rule.CreatedAt,
+		rule.ModifiedAt,
+		rule.CreatedBy,
+		rule.ModifiedBy,
+	)
+	if err != nil {
+		return mapWriteError("failed to create categorization rule", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetByID(id string, userID string) (*domain.Rule, error) {
+	checkQuery := `SELECT user_id FROM categorization_rules WHERE id = $1`
+	var ruleUserID string
+	err := r.pool.QueryRow(context.Background(), checkQuery, id).Scan(&ruleUserID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("categorization rule not found")
+		}
+		return nil, fmt.Errorf("failed to get categorization rule: %w", err)
+	}
+
+	if ruleUserID != userID {
+		return nil, fmt.Errorf("unauthorized access to categorization rule")
+	}
+
+	query := `SELECT ` + ruleColumns + ` FROM categorization_rules WHERE id = $1 AND user_id = $2`
+
+	rule, err := scanRule(r.pool.QueryRow(context.Background(), query, id, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("categorization rule not found")
+		}
+		return nil, fmt.Errorf("failed to get categorization rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+func (r *Repository) List(userID string) ([]*domain.Rule, error) {
+	query := `SELECT ` + ruleColumns + ` FROM categorization_rules WHERE user_id = $1 ORDER BY priority ASC, created_at ASC`
+
+	rows, err := r.pool.Query(context.Background(), query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categorization rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []*domain.Rule
+	for rows.Next() {
+		rule, err := scanRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan categorization rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate categorization rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+func (r *Repository) Update(rule *domain.Rule) error {
+	query := `
+		UPDATE categorization_rules
+		SET name = $2, priority = $3, enabled = $4, description_contains = $5, payee_contains = $6,
+			min_amount = $7, max_amount = $8, wallet_id = $9, currency = $10, category_id = $11,
+			set_payee = $12, tags = $13, modified_at = $14, modified_by = $15
+		WHERE id = $1 AND user_id = $16
+	`
+
+	result, err := r.pool.Exec(
+		context.Background(),
+		query,
+		rule.ID,
+		rule.Name,
+		rule.Priority,
+		rule.Enabled,
+		nullableString(rule.DescriptionContains),
+		nullableString(rule.PayeeContains),
+		rule.MinAmount,
+		rule.MaxAmount,
+		nullableString(rule.WalletID),
+		nullableString(rule.Currency),
+		nullableString(rule.CategoryID),
+		nullableString(rule.SetPayee),
+		tagsOrEmpty(rule.Tags),
+		rule.ModifiedAt,
+		rule.ModifiedBy,
+		rule.UserID,
+	)
+	if err != nil {
+		return mapWriteError("failed to update categorization rule", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("categorization rule not found")
+	}
+
+	return nil
+}
+
+func (r *Repository) Delete(id string, userID string) error {
+	checkQuery := `SELECT user_id FROM categorization_rules WHERE id = $1`
+	var ruleUserID string
+	err := r.pool.QueryRow(context.Background(), checkQuery, id).Scan(&ruleUserID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("categorization rule not found")
+		}
+		return fmt.Errorf("failed to delete categorization rule: %w", err)
+	}
+
+	if ruleUserID != userID {
+		return fmt.Errorf("unauthorized access to categorization rule")
+	}
+
+	query := `DELETE FROM categorization_rules WHERE id = $1 AND user_id = $2`
+
+	if _, err := r.pool.Exec(context.Background(), query, id, userID); err != nil {
+		return fmt.Errorf("failed to delete categorization rule: %w", err)
+	}
+
+	return nil
+}
+
+func scanRule(row pgx.Row) (*domain.Rule, error) {
+	var rule domain.Rule
+	var descriptionContains, payeeContains *string
+	var walletID, currency *string
+	var categoryID, setPayee *string
+
+	err := row.Scan(
+		&rule.ID,
+		&rule.UserID,
+		&rule.Name,
+		&rule.Priority,
+		&rule.Enabled,
+		&descriptionContains,
+		&payeeContains,
+		&rule.MinAmount,
+		&rule.MaxAmount,
+		&walletID,
+		&currency,
+		&categoryID,
+		&setPayee,
+		&rule.Tags,
+		&rule.CreatedAt,
+		&rule.ModifiedAt,
+		&rule.CreatedBy,
+		&rule.ModifiedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rule.DescriptionContains = valueOrEmpty(descriptionContains)
+	rule.PayeeContains = valueOrEmpty(payeeContains)
+	rule.WalletID = valueOrEmpty(walletID)
+	rule.Currency = valueOrEmpty(currency)
+	rule.CategoryID = valueOrEmpty(categoryID)
+	rule.SetPayee = valueOrEmpty(setPayee)
+
+	return &rule, nil
+}
+
+func mapWriteError(message string, err error) error {
+	if pgErr, ok := err.(*pgconn.PgError); ok {
+		switch pgErr.Code {
+		case "23503": // foreign_key_violation
+			if strings.Contains(pgErr.ConstraintName, "category") {
+				return fmt.Errorf("invalid category reference")
+			}
+			return fmt.Errorf("invalid wallet reference")
+		case "23514": // check_violation
+			if pgErr.ConstraintName == "chk_categorization_rules_action" {
+				return domain.ErrActionRequired
+			}
+			return domain.ErrInvalidAmountRange
+		}
+	}
+	return fmt.Errorf("%s: %w", message, err)
+}
+
+func nullableString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// tagsOrEmpty stores rules without tags as an empty array, since the column
+// is not nullable.
+func tagsOrEmpty(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
@@ -0,0 +1,473 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"fin-flow-api/internal/modules/categorization/application/contracts/commands"
+	"fin-flow-api/internal/modules/categorization/application/contracts/queries"
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	basehandler "fin-flow-api/internal/shared/http"
+)
+
+const dateLayout = "2006-01-02"
+
+type ruleService interface {
+	Create(ctx context.Context, req commands.RuleRequest) error
+	GetByID(ctx context.Context, id string) (*queries.RuleResponse, error)
+	Update(ctx context.Context, id string, req commands.RuleRequest) error
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context) ([]*queries.RuleResponse, error)
+	DryRun(ctx context.Context, req commands.DryRunRequest) ([]*queries.RuleChangeResponse, error)
+	Apply(ctx context.Context, scope commands.RuleScope) ([]*queries.RuleChangeResponse, error)
+	Learn(ctx context.Context, req commands.LearnRuleRequest) (*queries.LearnRuleResponse, error)
+}
+
+type Handler struct {
+	ruleService ruleService
+}
+
+func NewHandler(ruleService ruleService) *Handler {
+	return &Handler{
+		ruleService: ruleService,
+	}
+}
+
+func (h *Handler) CreateRule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var reqDTO RuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	cmd, err := toRuleCommand(reqDTO)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.ruleService.Create(r.Context(), cmd); err != nil {
+		statusCode, errorMsg := ruleErrorResponse(err, "create")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Categorization rule created successfully")
+}
+
+func (h *Handler) GetRule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/categorization-rules/")
+	id := strings.Split(path, "/")[0]
+
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Categorization rule ID is required in the URL path")
+		return
+	}
+
+	rule, err := h.ruleService.GetByID(r.Context(), id)
+	if err != nil {
+		statusCode, errorMsg := ruleErrorResponse(err, "access")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, toRuleResponse(rule))
+}
+
+func (h *Handler) UpdateRule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/categorization-rules/")
+	id := strings.Split(path, "/")[0]
+
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Categorization rule ID is required in the URL path")
+		return
+	}
+
+	var reqDTO RuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	cmd, err := toRuleCommand(reqDTO)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.ruleService.Update(r.Context(), id, cmd); err != nil {
+		statusCode, errorMsg := ruleErrorResponse(err, "update")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Categorization rule updated successfully")
+}
+
+func (h *Handler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/categorization-rules/")
+	id := strings.Split(path, "/")[0]
+
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Categorization rule ID is required in the URL path")
+		return
+	}
+
+	if err := h.ruleService.Delete(r.Context(), id); err != nil {
+		statusCode, errorMsg := ruleErrorResponse(err, "delete")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Categorization rule deleted successfully")
+}
+
+func (h *Handler) ListRules(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	rules, err := h.ruleService.List(r.Context())
+	if err != nil {
+		statusCode, errorMsg := ruleErrorResponse(err, "access")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	responses := make([]RuleResponse, len(rules))
+	for i, rule := range rules {
+		responses[i] = toRuleResponse(rule)
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, responses)
+}
+
+// DryRunRules previews the changes the rules would make to existing
+// transactions without making them.
+func (h *Handler) DryRunRules(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var reqDTO DryRunRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	scope, err := toRuleScope(RuleScopeRequest{WalletID: reqDTO.WalletID, From: reqDTO.From, To: reqDTO.To})
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	cmd := commands.DryRunRequest{Scope: scope}
+	if reqDTO.Rule != nil {
+		rule, err := toRuleCommand(*reqDTO.Rule)
+		if err != nil {
+			basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		cmd.Rule = &rule
+	}
+
+	changes, err := h.ruleService.DryRun(r.Context(), cmd)
+	if err != nil {
+		statusCode, errorMsg := ruleErrorResponse(err, "access")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, toRuleChangeResponses(changes))
+}
+
+// ApplyRules runs the saved rules over existing transactions and returns
+// the changes made.
+func (h *Handler) ApplyRules(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var reqDTO RuleScopeRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	scope, err := toRuleScope(reqDTO)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	changes, err := h.ruleService.Apply(r.Context(), scope)
+	if err != nil {
+		statusCode, errorMsg := ruleErrorResponse(err, "access")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, toRuleChangeResponses(changes))
+}
+
+// LearnRule proposes a rule from a transaction the user corrected.
+func (h *Handler) LearnRule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var reqDTO LearnRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	if err := validateLearnRuleRequest(reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.ruleService.Learn(r.Context(), commands.LearnRuleRequest{
+		TransactionID: strings.TrimSpace(reqDTO.TransactionID),
+	})
+	if err != nil {
+		statusCode, errorMsg := ruleErrorResponse(err, "access")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, LearnRuleResponse{
+		Rule:    toProposedRule(result.Rule),
+		Changes: toRuleChangeResponses(result.Changes),
+	})
+}
+
+func toRuleCommand(req RuleRequest) (commands.RuleRequest, error) {
+	if err := validateRuleRequest(req); err != nil {
+		return commands.RuleRequest{}, err
+	}
+
+	return commands.RuleRequest{
+		Name:                strings.TrimSpace(req.Name),
+		Priority:            req.Priority,
+		Enabled:             req.Enabled,
+		DescriptionContains: strings.TrimSpace(req.DescriptionContains),
+		PayeeContains:       strings.TrimSpace(req.PayeeContains),
+		MinAmount:           req.MinAmount,
+		MaxAmount:           req.MaxAmount,
+		WalletID:            strings.TrimSpace(req.WalletID),
+		Currency:            strings.TrimSpace(req.Currency),
+		CategoryID:          strings.TrimSpace(req.CategoryID),
+		SetPayee:            strings.TrimSpace(req.SetPayee),
+		Tags:                req.Tags,
+	}, nil
+}
+
+func toRuleScope(req RuleScopeRequest) (commands.RuleScope, error) {
+	scope := commands.RuleScope{WalletID: strings.TrimSpace(req.WalletID)}
+
+	if req.From != "" {
+		date, err := time.Parse(dateLayout, req.From)
+		if err != nil {
+			return scope, &ValidationError{Field: "from", Message: "From date must use the YYYY-MM-DD format"}
+		}
+		scope.From = &date
+	}
+
+	if req.To != "" {
+		date, err := time.Parse(dateLayout, req.To)
+		if err != nil {
+			return scope, &ValidationError{Field: "to", Message: "To date must use the YYYY-MM-DD format"}
+		}
+		scope.To = &date
+	}
+
+	return scope, nil
+}
+
+func validateRuleRequest(req RuleRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return &ValidationError{Field: "name", Message: "Name is required"}
+	}
+
+	if len(name) > 255 {
+		return &ValidationError{Field: "name", Message: "Name must not exceed 255 characters"}
+	}
+
+	if req.Priority != nil && *req.Priority < 0 {
+		return &ValidationError{Field: "priority", Message: "Priority must not be negative"}
+	}
+
+	if len(req.DescriptionContains) > 255 {
+		return &ValidationError{Field: "description_contains", Message: "Description text must not exceed 255 characters"}
+	}
+
+	if len(req.PayeeContains) > 255 {
+		return &ValidationError{Field: "payee_contains", Message: "Payee text must not exceed 255 characters"}
+	}
+
+	if req.MinAmount != nil && req.MinAmount.IsNegative() {
+		return &ValidationError{Field: "min_amount", Message: "Minimum amount must not be negative"}
+	}
+
+	if req.MaxAmount != nil && req.MaxAmount.IsNegative() {
+		return &ValidationError{Field: "max_amount", Message: "Maximum amount must not be negative"}
+	}
+
+	if len(req.SetPayee) > 255 {
+		return &ValidationError{Field: "set_payee", Message: "Payee must not exceed 255 characters"}
+	}
+
+	if len(req.Tags) > transactiondomain.MaxTags {
+		return &ValidationError{Field: "tags", Message: "A rule can assign at most " + strconv.Itoa(transactiondomain.MaxTags) + " tags"}
+	}
+
+	for _, tag := range req.Tags {
+		if len(strings.TrimSpace(tag)) > transactiondomain.MaxTagLength {
+			return &ValidationError{Field: "tags", Message: "Tags must not exceed " + strconv.Itoa(transactiondomain.MaxTagLength) + " characters"}
+		}
+	}
+
+	return nil
+}
+
+func validateLearnRuleRequest(req LearnRuleRequest) error {
+	if strings.TrimSpace(req.TransactionID) == "" {
+		return &ValidationError{Field: "transaction_id", Message: "Transaction ID is required"}
+	}
+
+	return nil
+}
+
+func ruleErrorResponse(err error, action string) (int, string) {
+	errorMsg := err.Error()
+
+	switch {
+	case strings.Contains(errorMsg, "user not authenticated"):
+		return http.StatusUnauthorized, "Authentication required"
+	case strings.Contains(errorMsg, "unauthorized access to categorization rule"):
+		return http.StatusForbidden, "You do not have permission to " + action + " this categorization rule"
+	case strings.Contains(errorMsg, "unauthorized access to wallet"):
+		return http.StatusForbidden, "You do not have permission to use this wallet"
+	case strings.Contains(errorMsg, "unauthorized access to category"):
+		return http.StatusForbidden, "You do not have permission to use this category"
+	case strings.Contains(errorMsg, "unauthorized access to transaction"):
+		return http.StatusForbidden, "You do not have permission to access this transaction"
+	case strings.Contains(errorMsg, "categorization rule not found"):
+		return http.StatusNotFound, "Categorization rule not found"
+	case strings.Contains(errorMsg, "transaction not found"):
+		return http.StatusNotFound, "Transaction not found"
+	case strings.Contains(errorMsg, "wallet not found"), strings.Contains(errorMsg, "invalid wallet reference"):
+		return http.StatusBadRequest, "Wallet not found"
+	case strings.Contains(errorMsg, "category not found"), strings.Contains(errorMsg, "invalid category reference"):
+		return http.StatusBadRequest, "Category not found"
+	case strings.Contains(errorMsg, "invalid currency"):
+		return http.StatusBadRequest, "Invalid currency code"
+	case strings.Contains(errorMsg, "at least one condition"),
+		strings.Contains(errorMsg, "a category, a payee or tags"),
+		strings.Contains(errorMsg, "minimum amount"),
+		strings.Contains(errorMsg, "priority must not"),
+		strings.Contains(errorMsg, "only apply to expenses and income"),
+		strings.Contains(errorMsg, "to learn a rule from"):
+		return http.StatusBadRequest, errorMsg
+	}
+
+	return http.StatusInternalServerError, errorMsg
+}
+
+func toRuleResponse(rule *queries.RuleResponse) RuleResponse {
+	return RuleResponse{
+		ID:                  rule.ID,
+		Name:                rule.Name,
+		Priority:            rule.Priority,
+		Enabled:             rule.Enabled,
+		DescriptionContains: rule.DescriptionContains,
+		PayeeContains:       rule.PayeeContains,
+		MinAmount:           rule.MinAmount,
+		MaxAmount:           rule.MaxAmount,
+		WalletID:            rule.WalletID,
+		Currency:            rule.Currency,
+		CategoryID:          rule.CategoryID,
+		SetPayee:            rule.SetPayee,
+		Tags:                rule.Tags,
+		CreatedAt:           rule.CreatedAt,
+		UpdatedAt:           rule.UpdatedAt,
+		CreatedBy:           rule.CreatedBy,
+		UpdatedBy:           rule.UpdatedBy,
+	}
+}
+
+func toProposedRule(rule *queries.RuleResponse) RuleRequest {
+	priority := rule.Priority
+	return RuleRequest{
+		Name:                rule.Name,
+		Priority:            &priority,
+		DescriptionContains: rule.DescriptionContains,
+		PayeeContains:       rule.PayeeContains,
+		MinAmount:           rule.MinAmount,
+		MaxAmount:           rule.MaxAmount,
+		WalletID:            rule.WalletID,
+		Currency:            rule.Currency,
+		CategoryID:          rule.CategoryID,
+		SetPayee:            rule.SetPayee,
+		Tags:                rule.Tags,
+	}
+}
+
+func toRuleChangeResponses(changes []*queries.RuleChangeResponse) []RuleChangeResponse {
+	responses := make([]RuleChangeResponse, len(changes))
+	for i, change := range changes {
+		responses[i] = RuleChangeResponse{
+			TransactionID: change.TransactionID,
+			Date:          change.Date.Format(dateLayout),
+			Description:   change.Description,
+			Amount:        change.Amount,
+			CategoryID:    change.CategoryID,
+			NewCategoryID: change.NewCategoryID,
+			Payee:         change.Payee,
+			NewPayee:      change.NewPayee,
+			Tags:          change.Tags,
+			NewTags:       change.NewTags,
+			RuleIDs:       change.RuleIDs,
+		}
+	}
+	return responses
+}
+
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,402 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"fin-flow-api/internal/modules/categorization/application/contracts/commands"
+	"fin-flow-api/internal/modules/categorization/application/contracts/queries"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+type mockRuleService struct {
+	createErr   error
+	getByIDErr  error
+	updateErr   error
+	deleteErr   error
+	listErr     error
+	dryRunErr   error
+	applyErr    error
+	learnErr    error
+	rule        *queries.RuleResponse
+	rules       []*queries.RuleResponse
+	changes     []*queries.RuleChangeResponse
+	learned     *queries.LearnRuleResponse
+	lastCommand commands.RuleRequest
+	lastDryRun  commands.DryRunRequest
+	lastScope   commands.RuleScope
+	lastLearn   commands.LearnRuleRequest
+	lastID      string
+}
+
+func (m *mockRuleService) Create(ctx context.Context, req commands.RuleRequest) error {
+	m.lastCommand = req
+	return m.createErr
+}
+
+func (m *mockRuleService) GetByID(ctx context.Context, id string) (*queries.RuleResponse, error) {
+	m.lastID = id
+	if m.getByIDErr != nil {
+		return nil, m.getByIDErr
+	}
+	return m.rule, nil
+}
+
+func (m *mockRuleService) Update(ctx context.Context, id string, req commands.RuleRequest) error {
+	m.lastID = id
+	m.lastCommand = req
+	return m.updateErr
+}
+
+func (m *mockRuleService) Delete(ctx context.Context, id string) error {
+	m.lastID = id
+	return m.deleteErr
+}
+
+func (m *mockRuleService) List(ctx context.Context) ([]*queries.RuleResponse, error) {
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	return m.rules, nil
+}
+
+func (m *mockRuleService) DryRun(ctx context.Context, req commands.DryRunRequest) ([]*queries.RuleChangeResponse, error) {
+	m.lastDryRun = req
+	if m.dryRunErr != nil {
+		return nil, m.dryRunErr
+	}
+	return m.changes, nil
+}
+
+func (m *mockRuleService) Apply(ctx context.Context, scope commands.RuleScope) ([]*queries.RuleChangeResponse, error) {
+	m.lastScope = scope
+	if m.applyErr != nil {
+		return nil, m.applyErr
+	}
+	return m.changes, nil
+}
+
+func (m *mockRuleService) Learn(ctx context.Context, req commands.LearnRuleRequest) (*queries.LearnRuleResponse, error) {
+	m.lastLearn = req
+	if m.learnErr != nil {
+		return nil, m.learnErr
+	}
+	return m.learned, nil
+}
+
+func createContextWithUserID(userID string) context.Context {
+	return context.WithValue(context.Background(), middleware.UserIDKey, userID)
+}
+
+func amountPtr(value string) *shareddomain.Amount {
+	amount := shareddomain.MustParseAmount(value)
+	return &amount
+}
+
+func validRuleBody() RuleRequest {
+	return RuleRequest{
+		Name:                " Coffee ",
+		DescriptionContains: " coffee ",
+		MinAmount:           amountPtr("1"),
+		MaxAmount:           amountPtr("20"),
+		CategoryID:          "cat-coffee",
+		SetPayee:            "Coffee Shop",
+		Tags:                []string{"treats"},
+	}
+}
+
+func sampleChange() *queries.RuleChangeResponse {
+	return &queries.RuleChangeResponse{
+		TransactionID: "tx1",
+		Date:          time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC),
+		Description:   "COFFEE BAR",
+		Amount:        shareddomain.MustParseAmount("4.5"),
+		CategoryID:    "cat-food",
+		NewCategoryID: "cat-coffee",
+		NewPayee:      "Coffee Shop",
+		Tags:          []string{},
+		NewTags:       []string{"treats"},
+		RuleIDs:       []string{"rule1"},
+	}
+}
+
+func TestCreateRule_Success(t *testing.T) {
+	service := &mockRuleService{}
+	handler := &Handler{ruleService: service}
+
+	jsonBody, _ := json.Marshal(validRuleBody())
+	req := httptest.NewRequest("POST", "/categorization-rules", bytes.NewBuffer(jsonBody))
+	req = req.WithContext(createContextWithUserID("user1"))
+	rr := httptest.NewRecorder()
+
+	handler.CreateRule(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if service.lastCommand.Name != "Coffee" || service.lastCommand.DescriptionContains != "coffee" {
+		t.Errorf("expected trimmed fields, got %+v", service.lastCommand)
+	}
+	if service.lastCommand.Priority != nil {
+		t.Errorf("expected no priority, got %d", *service.lastCommand.Priority)
+	}
+}
+
+func TestCreateRule_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*RuleRequest)
+	}{
+		{"missing name", func(r *RuleRequest) { r.Name = " " }},
+		{"long name", func(r *RuleRequest) { r.Name = strings.Repeat("a", 256) }},
+		{"negative priority", func(r *RuleRequest) { priority := -1; r.Priority = &priority }},
+		{"negative minimum", func(r *RuleRequest) { r.MinAmount = amountPtr("-1") }},
+		{"long payee", func(r *RuleRequest) { r.SetPayee = strings.Repeat("a", 256) }},
+		{"long tag", func(r *RuleRequest) { r.Tags = []string{strings.Repeat("a", 51)} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := validRuleBody()
+			tt.mutate(&body)
+
+			if _, err := toRuleCommand(body); err == nil {
+				t.Error("expected validation error, got nil")
+			}
+		})
+	}
+}
+
+func TestCreateRule_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{"unauthenticated", errors.New("user not authenticated"), http.StatusUnauthorized},
+		{"no condition", errors.New("a rule needs at least one condition"), http.StatusBadRequest},
+		{"inverted range", errors.New("minimum amount must not be greater than maximum amount"), http.StatusBadRequest},
+		{"category", errors.New("category not found"), http.StatusBadRequest},
+		{"foreign wallet", errors.New("unauthorized access to wallet"), http.StatusForbidden},
+		{"currency", errors.New("invalid currency"), http.StatusBadRequest},
+		{"database", errors.New("failed to create categorization rule: boom"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &Handler{ruleService: &mockRuleService{createErr: tt.err}}
+
+			jsonBody, _ := json.Marshal(validRuleBody())
+			req := httptest.NewRequest("POST", "/categorization-rules", bytes.NewBuffer(jsonBody))
+			rr := httptest.NewRecorder()
+
+			handler.CreateRule(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestGetRule_NotFound(t *testing.T) {
+	service := &mockRuleService{getByIDErr: errors.New("categorization rule not found")}
+	handler := &Handler{ruleService: service}
+
+	req := httptest.NewRequest("GET", "/categorization-rules/rule1", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetRule(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", rr.Code)
+	}
+	if service.lastID != "rule1" {
+		t.Errorf("expected id rule1, got %q", service.lastID)
+	}
+}
+
+func TestListRules_Success(t *testing.T) {
+	service := &mockRuleService{rules: []*queries.RuleResponse{
+		{ID: "rule1", Name: "Coffee", Priority: 1, Enabled: true, DescriptionContains: "coffee", MinAmount: amountPtr("1"), CategoryID: "cat-coffee"},
+	}}
+	handler := &Handler{ruleService: service}
+
+	req := httptest.NewRequest("GET", "/categorization-rules", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ListRules(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+
+	var body []map[string]interface{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(body) != 1 || body[0]["min_amount"] != "1" || body[0]["priority"] != float64(1) {
+		t.Errorf("unexpected response %v", body)
+	}
+	if _, ok := body[0]["max_amount"]; ok {
+		t.Error("expected max_amount to be omitted")
+	}
+}
+
+func TestDryRunRules_Success(t *testing.T) {
+	service := &mockRuleService{changes: []*queries.RuleChangeResponse{sampleChange()}}
+	handler := &Handler{ruleService: service}
+
+	jsonBody, _ := json.Marshal(DryRunRequest{Rule: &RuleRequest{Name: "Coffee", DescriptionContains: "coffee", CategoryID: "cat-coffee"}, From: "2026-03-01"})
+	req := httptest.NewRequest("POST", "/categorization-rules/dry-run", bytes.NewBuffer(jsonBody))
+	rr := httptest.NewRecorder()
+
+	handler.DryRunRules(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if service.lastDryRun.Rule == nil || service.lastDryRun.Rule.Name != "Coffee" {
+		t.Errorf("expected the draft rule to be passed, got %+v", service.lastDryRun.Rule)
+	}
+	if service.lastDryRun.Scope.From == nil || service.lastDryRun.Scope.From.Day() != 1 {
+		t.Errorf("expected from date, got %v", service.lastDryRun.Scope.From)
+	}
+
+	var body []RuleChangeResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(body) != 1 || body[0].Date != "2026-03-15" || body[0].NewCategoryID != "cat-coffee" {
+		t.Errorf("unexpected response %+v", body)
+	}
+}
+
+func TestDryRunRules_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		err            error
+		expectedStatus int
+	}{
+		{"invalid json", "{", nil, http.StatusBadRequest},
+		{"invalid date", `{"to":"15/03/2026"}`, nil, http.StatusBadRequest},
+		{"invalid draft", `{"rule":{"name":""}}`, nil, http.StatusBadRequest},
+		{"draft without action", `{"rule":{"name":"Coffee","description_contains":"coffee"}}`, errors.New("a rule needs a category, a payee or tags to assign"), http.StatusBadRequest},
+		{"unauthenticated", `{}`, errors.New("user not authenticated"), http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &Handler{ruleService: &mockRuleService{dryRunErr: tt.err}}
+
+			req := httptest.NewRequest("POST", "/categorization-rules/dry-run", bytes.NewBufferString(tt.body))
+			rr := httptest.NewRecorder()
+
+			handler.DryRunRules(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestApplyRules_Success(t *testing.T) {
+	service := &mockRuleService{changes: []*queries.RuleChangeResponse{sampleChange()}}
+	handler := &Handler{ruleService: service}
+
+	req := httptest.NewRequest("POST", "/categorization-rules/apply", bytes.NewBufferString(`{"wallet_id":" wallet1 "}`))
+	rr := httptest.NewRecorder()
+
+	handler.ApplyRules(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if service.lastScope.WalletID != "wallet1" {
+		t.Errorf("expected wallet1, got %q", service.lastScope.WalletID)
+	}
+}
+
+func TestLearnRule_Success(t *testing.T) {
+	service := &mockRuleService{learned: &queries.LearnRuleResponse{
+		Rule:    &queries.RuleResponse{Name: "NETFLIX.COM", Priority: 3, DescriptionContains: "NETFLIX.COM", CategoryID: "cat-streaming", SetPayee: "Netflix"},
+		Changes: []*queries.RuleChangeResponse{sampleChange()},
+	}}
+	handler := &Handler{ruleService: service}
+
+	req := httptest.NewRequest("POST", "/categorization-rules/learn", bytes.NewBufferString(`{"transaction_id":"tx9"}`))
+	rr := httptest.NewRecorder()
+
+	handler.LearnRule(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if service.lastLearn.TransactionID != "tx9" {
+		t.Errorf("expected tx9, got %q", service.lastLearn.TransactionID)
+	}
+
+	var body LearnRuleResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body.Rule.Priority == nil || *body.Rule.Priority != 3 || body.Rule.SetPayee != "Netflix" || len(body.Changes) != 1 {
+		t.Errorf("unexpected response %+v", body)
+	}
+	if _, err := toRuleCommand(body.Rule); err != nil {
+		t.Errorf("expected the proposed rule to be valid to create, got %v", err)
+	}
+}
+
+func TestLearnRule_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		err            error
+		expectedStatus int
+	}{
+		{"missing transaction", `{}`, nil, http.StatusBadRequest},
+		{"not found", `{"transaction_id":"tx1"}`, errors.New("transaction not found"), http.StatusNotFound},
+		{"foreign transaction", `{"transaction_id":"tx1"}`, errors.New("unauthorized access to transaction"), http.StatusForbidden},
+		{"transfer", `{"transaction_id":"tx1"}`, errors.New("rules only apply to expenses and income"), http.StatusBadRequest},
+		{"nothing to learn", `{"transaction_id":"tx1"}`, errors.New("transaction has no description or payee to learn a rule from"), http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &Handler{ruleService: &mockRuleService{learnErr: tt.err}}
+
+			req := httptest.NewRequest("POST", "/categorization-rules/learn", bytes.NewBufferString(tt.body))
+			rr := httptest.NewRecorder()
+
+			handler.LearnRule(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestDeleteRule_Forbidden(t *testing.T) {
+	handler := &Handler{ruleService: &mockRuleService{deleteErr: errors.New("unauthorized access to categorization rule")}}
+
+	req := httptest.NewRequest("DELETE", "/categorization-rules/rule1", nil)
+	rr := httptest.NewRecorder()
+
+	handler.DeleteRule(rr, req)
+
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d", rr.Code)
+	}
+}
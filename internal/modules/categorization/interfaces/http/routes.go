@@ -0,0 +1,63 @@
+package http
+
+import (
+	"net/http"
+
+	"fin-flow-api/internal/shared/interface/jwt"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+var ruleHandler *Handler
+
+func SetupRoutes(mux *http.ServeMux, jwtService jwt.Service) {
+	mountRules(mux, jwtService)
+}
+
+func mountRules(mux *http.ServeMux, jwtService jwt.Service) {
+	mux.HandleFunc("/categorization-rules", handleRulesCollection(jwtService))
+
+	protectedHandler := middleware.RequireAuth(jwtService)(http.HandlerFunc(handleRulesResource))
+	mux.Handle("/categorization-rules/", protectedHandler)
+}
+
+func handleRulesCollection(jwtService jwt.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.RequireAuth(jwtService)(http.HandlerFunc(ruleHandler.ListRules)).ServeHTTP(w, r)
+		case http.MethodPost:
+			middleware.RequireAuth(jwtService)(http.HandlerFunc(ruleHandler.CreateRule)).ServeHTTP(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func handleRulesResource(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/categorization-rules/dry-run":
+		ruleHandler.DryRunRules(w, r)
+		return
+	case "/categorization-rules/apply":
+		ruleHandler.ApplyRules(w, r)
+		return
+	case "/categorization-rules/learn":
+		ruleHandler.LearnRule(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		ruleHandler.GetRule(w, r)
+	case http.MethodPut:
+		ruleHandler.UpdateRule(w, r)
+	case http.MethodDelete:
+		ruleHandler.DeleteRule(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func SetHandler(handler *Handler) {
+	ruleHandler = handler
+}
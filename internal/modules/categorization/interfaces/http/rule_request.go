@@ -0,0 +1,39 @@
+package http
+
+import shareddomain "fin-flow-api/internal/shared/domain"
+
+type RuleRequest struct {
+	Name                string               `json:"name"`
+	Priority            *int                 `json:"priority,omitempty"`
+	Enabled             *bool                `json:"enabled,omitempty"`
+	DescriptionContains string               `json:"description_contains,omitempty"`
+	PayeeContains       string               `json:"payee_contains,omitempty"`
+	MinAmount           *shareddomain.Amount `json:"min_amount,omitempty"`
+	MaxAmount           *shareddomain.Amount `json:"max_amount,omitempty"`
+	WalletID            string               `json:"wallet_id,omitempty"`
+	Currency            string               `json:"currency,omitempty"`
+	CategoryID          string               `json:"category_id,omitempty"`
+	SetPayee            string               `json:"set_payee,omitempty"`
+	Tags                []string             `json:"tags,omitempty"`
+}
+
+// RuleScopeRequest picks the existing transactions to run rules over. Dates
+// use the YYYY-MM-DD format and are inclusive.
+type RuleScopeRequest struct {
+	WalletID string `json:"wallet_id"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+}
+
+// DryRunRequest previews a rule that is not saved yet when Rule is set, or
+// the saved rules otherwise.
+type DryRunRequest struct {
+	Rule     *RuleRequest `json:"rule"`
+	WalletID string       `json:"wallet_id"`
+	From     string       `json:"from"`
+	To       string       `json:"to"`
+}
+
+type LearnRuleRequest struct {
+	TransactionID string `json:"transaction_id"`
+}
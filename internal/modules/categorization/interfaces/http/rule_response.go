@@ -0,0 +1,48 @@
+package http
+
+import (
+	"time"
+
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+type RuleResponse struct {
+	ID                  string               `json:"id"`
+	Name                string               `json:"name"`
+	Priority            int                  `json:"priority"`
+	Enabled             bool                 `json:"enabled"`
+	DescriptionContains string               `json:"description_contains,omitempty"`
+	PayeeContains       string               `json:"payee_contains,omitempty"`
+	MinAmount           *shareddomain.Amount `json:"min_amount,omitempty"`
+	MaxAmount           *shareddomain.Amount `json:"max_amount,omitempty"`
+	WalletID            string               `json:"wallet_id,omitempty"`
+	Currency            string               `json:"currency,omitempty"`
+	CategoryID          string               `json:"category_id,omitempty"`
+	SetPayee            string               `json:"set_payee,omitempty"`
+	Tags                []string             `json:"tags,omitempty"`
+	CreatedAt           time.Time            `json:"created_at"`
+	UpdatedAt           time.Time            `json:"updated_at"`
+	CreatedBy           string               `json:"created_by"`
+	UpdatedBy           string               `json:"updated_by"`
+}
+
+type RuleChangeResponse struct {
+	TransactionID string              `json:"transaction_id"`
+	Date          string              `json:"date"`
+	Description   string              `json:"description"`
+	Amount        shareddomain.Amount `json:"amount"`
+	CategoryID    string              `json:"category_id"`
+	NewCategoryID string              `json:"new_category_id"`
+	Payee         string              `json:"payee,omitempty"`
+	NewPayee      string              `json:"new_payee,omitempty"`
+	Tags          []string            `json:"tags"`
+	NewTags       []string            `json:"new_tags"`
+	RuleIDs       []string            `json:"rule_ids,omitempty"`
+}
+
+// LearnRuleResponse carries the proposed rule in the shape it is created
+// with, so it can be sent back as is to save it.
+type LearnRuleResponse struct {
+	Rule    RuleRequest          `json:"rule"`
+	Changes []RuleChangeResponse `json:"changes"`
+}
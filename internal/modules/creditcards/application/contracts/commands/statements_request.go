@@ -0,0 +1,11 @@
+package commands
+
+import "time"
+
+// StatementsRequest asks for the statements of a credit card wallet that
+// close in the months from From to To.
+type StatementsRequest struct {
+	WalletID string
+	From     time.Time
+	To       time.Time
+}
@@ -0,0 +1,47 @@
+package queries
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+type StatementLineResponse struct {
+	TransactionID     string
+	Date              time.Time
+	Description       string
+	Type              int
+	TypeName          string
+	Amount            domain.Amount
+	InstallmentNumber int
+	InstallmentCount  int
+}
+
+type StatementResponse struct {
+	Start        time.Time
+	Closing      time.Time
+	Due          time.Time
+	Charges      domain.Amount
+	Credits      domain.Amount
+	Balance      domain.Amount
+	Transactions []StatementLineResponse
+}
+
+type StatementsResponse struct {
+	WalletID   string
+	Currency   string
+	Statements []StatementResponse
+}
+
+// SummaryResponse is where a credit card stands on Date. Owed is the
+// balance of the card as a positive amount.
+type SummaryResponse struct {
+	WalletID        string
+	Currency        string
+	Date            time.Time
+	CreditLimit     domain.Amount
+	Owed            domain.Amount
+	AvailableCredit domain.Amount
+	Current         StatementResponse
+	Next            StatementResponse
+}
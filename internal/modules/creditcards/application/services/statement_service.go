@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"fin-flow-api/internal/modules/creditcards/application/contracts/commands"
+	"fin-flow-api/internal/modules/creditcards/application/contracts/queries"
+	"fin-flow-api/internal/modules/creditcards/domain"
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+// StatementService bills the transactions of credit card wallets into
+// statements, one per billing cycle.
+type StatementService struct {
+	walletRepository      walletdomain.WalletRepository
+	transactionRepository transactiondomain.TransactionRepository
+}
+
+func NewStatementService(walletRepository walletdomain.WalletRepository, transactionRepository transactiondomain.TransactionRepository) *StatementService {
+	return &StatementService{
+		walletRepository:      walletRepository,
+		transactionRepository: transactionRepository,
+	}
+}
+
+func (s *StatementService) getUserIDFromContext(ctx context.Context) (string, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return "", errors.New("user not authenticated")
+	}
+	return userID, nil
+}
+
+// Statements returns the statements of a card that close in the requested
+// months, oldest first.
+func (s *StatementService) Statements(ctx context.Context, req commands.StatementsRequest) (*queries.StatementsResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if domain.MonthOf(req.To).Before(domain.MonthOf(req.From)) {
+		return nil, domain.ErrInvalidPeriod
+	}
+
+	wallet, err := s.creditCard(req.WalletID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	first := wallet.CreditCard.CycleClosingIn(req.From)
+	last := wallet.CreditCard.CycleClosingIn(req.To)
+
+	transactions, err := s.transactions(userID, wallet.ID, first.Start, last.Closing)
+	if err != nil {
+		return nil, err
+	}
+
+	statements, err := domain.NewStatements(wallet.CreditCard, req.From, req.To, transactions)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &queries.StatementsResponse{
+		WalletID:   wallet.ID,
+		Currency:   wallet.Currency.String(),
+		Statements: make([]queries.StatementResponse, len(statements)),
+	}
+	for i, statement := range statements {
+		response.Statements[i] = toStatementResponse(statement)
+	}
+
+	return response, nil
+}
+
+// Summary returns the statement of the cycle open on date, the one after it
+// (where upcoming installments are billed) and how much credit is left.
+func (s *StatementService) Summary(ctx context.Context, walletID string, date time.Time) (*queries.SummaryResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := s.creditCard(walletID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	current := wallet.CreditCard.CycleFor(date)
+	next := wallet.CreditCard.Next(current)
+
+	transactions, err := s.transactions(userID, wallet.ID, current.Start, next.Closing)
+	if err != nil {
+		return nil, err
+	}
+
+	available, err := wallet.AvailableCredit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &queries.SummaryResponse{
+		WalletID:        wallet.ID,
+		Currency:        wallet.Currency.String(),
+		Date:            date,
+		CreditLimit:     wallet.CreditCard.CreditLimit,
+		Owed:            wallet.Balance.Neg(),
+		AvailableCredit: available,
+		Current:         toStatementResponse(domain.NewStatement(current, transactions)),
+		Next:            toStatementResponse(domain.NewStatement(next, transactions)),
+	}, nil
+}
+
+func (s *StatementService) creditCard(walletID, userID string) (*walletdomain.Wallet, error) {
+	wallet, err := s.walletRepository.GetByID(walletID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if wallet.Type != walletdomain.WalletTypeCreditCard {
+		return nil, walletdomain.ErrNotCreditCard
+	}
+	if wallet.CreditCard == nil {
+		return nil, walletdomain.ErrCreditCardNotConfigured
+	}
+
+	return wallet, nil
+}
+
+func (s *StatementService) transactions(userID, walletID string, from, to time.Time) ([]*transactiondomain.Transaction, error) {
+	return s.transactionRepository.List(userID, transactiondomain.TransactionFilter{
+		WalletID: walletID,
+		From:     &from,
+		To:       &to,
+	})
+}
+
+func toStatementResponse(statement *domain.Statement) queries.StatementResponse {
+	response := queries.StatementResponse{
+		Start:        statement.Cycle.Start,
+		Closing:      statement.Cycle.Closing,
+		Due:          statement.Cycle.Due,
+		Charges:      statement.Charges,
+		Credits:      statement.Credits,
+		Balance:      statement.Balance,
+		Transactions: make([]queries.StatementLineResponse, len(statement.Transactions)),
+	}
+
+	for i, transaction := range statement.Transactions {
+		response.Transactions[i] = queries.StatementLineResponse{
+			TransactionID:     transaction.ID,
+			Date:              transaction.Date,
+			Description:       transaction.Description,
+			Type:              transaction.Type.Value(),
+			TypeName:          transaction.Type.String(),
+			Amount:            transaction.Amount,
+			InstallmentNumber: transaction.InstallmentNumber,
+			InstallmentCount:  transaction.InstallmentCount,
+		}
+	}
+
+	return response
+}
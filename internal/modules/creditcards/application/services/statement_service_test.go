@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"fin-flow-api/internal/modules/creditcards/application/contracts/commands"
+	"fin-flow-api/internal/modules/creditcards/domain"
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	"fin-flow-api/internal/modules/transactions/domain/transactiontest"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+type mockWalletRepository struct {
+	wallets map[string]*walletdomain.Wallet
+}
+
+func (m *mockWalletRepository) Create(wallet *walletdomain.Wallet) error {
+	m.wallets[wallet.ID] = wallet
+	return nil
+}
+
+func (m *mockWalletRepository) GetByID(id string, userID string) (*walletdomain.Wallet, error) {
+	wallet, exists := m.wallets[id]
+	if !exists {
+		return nil, errors.New("wallet not found")
+	}
+	if wallet.UserID != userID {
+		return nil, errors.New("unauthorized access to wallet")
+	}
+	return wallet, nil
+}
+
+func (m *mockWalletRepository) List(userID string) ([]*walletdomain.Wallet, error) {
+	return nil, nil
+}
+
+func (m *mockWalletRepository) Update(wallet *walletdomain.Wallet) error {
+	return nil
+}
+
+func (m *mockWalletRepository) Delete(id string, userID string) error {
+	return nil
+}
+
+type mockContext struct {
+	context.Context
+	userID string
+	hasID  bool
+}
+
+func (m *mockContext) Value(key interface{}) interface{} {
+	if key == middleware.UserIDKey {
+		if m.hasID {
+			return m.userID
+		}
+		return nil
+	}
+	return nil
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func newTestService(transactions ...*transactiondomain.Transaction) (*StatementService, *transactiontest.Repository) {
+	card := walletdomain.NewWallet("card", "user1", "Visa", walletdomain.WalletTypeCreditCard, shareddomain.MustParseAmount("-400"), walletdomain.CurrencyUSD, "system")
+	card.CreditCard = &walletdomain.CreditCardTerms{CreditLimit: shareddomain.MustParseAmount("1000"), ClosingDay: 25, DueDay: 5}
+
+	wallets := &mockWalletRepository{wallets: map[string]*walletdomain.Wallet{
+		"card":   card,
+		"legacy": walletdomain.NewWallet("legacy", "user1", "Old card", walletdomain.WalletTypeCreditCard, shareddomain.Amount{}, walletdomain.CurrencyUSD, "system"),
+		"bank":   walletdomain.NewWallet("bank", "user1", "Bank", walletdomain.WalletTypeBank, shareddomain.Amount{}, walletdomain.CurrencyUSD, "system"),
+	}}
+	repo := transactiontest.NewRepository(transactions...)
+
+	return NewStatementService(wallets, repo), repo
+}
+
+func purchase(id, amount string, on time.Time) *transactiondomain.Transaction {
+	return transactiondomain.NewTransaction(id, "user1", "card", "cat", transactiondomain.TransactionTypeExpense, shareddomain.MustParseAmount(amount), "", on, "system")
+}
+
+func TestStatementService_Statements(t *testing.T) {
+	installments, err := transactiondomain.NewInstallments([]string{"i1", "i2", "i3"}, "plan", "user1", "card", "cat", shareddomain.MustParseAmount("300"), "USD", 3, "TV", date(2026, 2, 10), "system")
+	if err != nil {
+		t.Fatalf("NewInstallments failed: %v", err)
+	}
+	service, repo := newTestService(append(installments, purchase("p1", "50", date(2026, 2, 20)))...)
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	response, err := service.Statements(ctx, commands.StatementsRequest{WalletID: "card", From: date(2026, 2, 1), To: date(2026, 4, 1)})
+	if err != nil {
+		t.Fatalf("Statements failed: %v", err)
+	}
+
+	if !repo.LastFilter().From.Equal(date(2026, 1, 26)) || !repo.LastFilter().To.Equal(date(2026, 4, 25)) {
+		t.Errorf("unexpected filter %s..%s", repo.LastFilter().From.Format("2006-01-02"), repo.LastFilter().To.Format("2006-01-02"))
+	}
+
+	expected := []string{"150", "100", "100"}
+	if len(response.Statements) != len(expected) {
+		t.Fatalf("expected %d statements, got %d", len(expected), len(response.Statements))
+	}
+	for i, statement := range response.Statements {
+		if !statement.Balance.Equal(shareddomain.MustParseAmount(expected[i])) {
+			t.Errorf("statement %d: expected %s, got %s", i, expected[i], statement.Balance)
+		}
+	}
+	if line := response.Statements[1].Transactions[0]; line.InstallmentNumber != 2 || line.InstallmentCount != 3 {
+		t.Errorf("expected installment 2 of 3, got %d of %d", line.InstallmentNumber, line.InstallmentCount)
+	}
+}
+
+func TestStatementService_Summary(t *testing.T) {
+	service, _ := newTestService(
+		purchase("current", "150", date(2026, 3, 10)),
+		purchase("next", "250", date(2026, 3, 30)),
+		purchase("closed", "999", date(2026, 2, 20)),
+	)
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	summary, err := service.Summary(ctx, "card", date(2026, 3, 15))
+	if err != nil {
+		t.Fatalf("Summary failed: %v", err)
+	}
+
+	if !summary.Current.Closing.Equal(date(2026, 3, 25)) || !summary.Next.Closing.Equal(date(2026, 4, 25)) {
+		t.Errorf("unexpected cycles closing %s and %s", summary.Current.Closing.Format("2006-01-02"), summary.Next.Closing.Format("2006-01-02"))
+	}
+	if !summary.Current.Balance.Equal(shareddomain.MustParseAmount("150")) {
+		t.Errorf("expected current balance 150, got %s", summary.Current.Balance)
+	}
+	if !summary.Next.Balance.Equal(shareddomain.MustParseAmount("250")) {
+		t.Errorf("expected next balance 250, got %s", summary.Next.Balance)
+	}
+	if !summary.Owed.Equal(shareddomain.MustParseAmount("400")) || !summary.AvailableCredit.Equal(shareddomain.MustParseAmount("600")) {
+		t.Errorf("expected 400 owed and 600 available, got %s and %s", summary.Owed, summary.AvailableCredit)
+	}
+}
+
+func TestStatementService_Errors(t *testing.T) {
+	service, _ := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	tests := []struct {
+		name     string
+		walletID string
+		wantErr  error
+	}{
+		{"not a credit card", "bank", walletdomain.ErrNotCreditCard},
+		{"card without terms", "legacy", walletdomain.ErrCreditCardNotConfigured},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.Summary(ctx, tt.walletID, date(2026, 3, 15)); err != tt.wantErr {
+				t.Errorf("Summary: expected %v, got %v", tt.wantErr, err)
+			}
+			req := commands.StatementsRequest{WalletID: tt.walletID, From: date(2026, 1, 1), To: date(2026, 3, 1)}
+			if _, err := service.Statements(ctx, req); err != tt.wantErr {
+				t.Errorf("Statements: expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	req := commands.StatementsRequest{WalletID: "card", From: date(2026, 3, 1), To: date(2026, 1, 1)}
+	if _, err := service.Statements(ctx, req); err != domain.ErrInvalidPeriod {
+		t.Errorf("expected ErrInvalidPeriod, got %v", err)
+	}
+
+	if _, err := service.Summary(&mockContext{}, "card", date(2026, 3, 15)); err == nil || err.Error() != "user not authenticated" {
+		t.Errorf("expected 'user not authenticated', got %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	"fin-flow-api/internal/shared/domain"
+)
+
+var ErrInvalidPeriod = errors.New("statement period must not end before it starts")
+
+// Statement is what a credit card bills for one cycle. Charges are purchases,
+// installments and money taken out of the card; credits are refunds and
+// payments into it. Balance is what the statement asks to be paid and is
+// negative when the card was overpaid.
+type Statement struct {
+	Cycle        walletdomain.BillingCycle
+	Charges      domain.Amount
+	Credits      domain.Amount
+	Balance      domain.Amount
+	Transactions []*transactiondomain.Transaction
+}
+
+// NewStatement bills the transactions that fall within cycle, oldest first.
+// Transactions outside the cycle are ignored.
+func NewStatement(cycle walletdomain.BillingCycle, transactions []*transactiondomain.Transaction) *Statement {
+	statement := &Statement{Cycle: cycle}
+
+	for _, transaction := range transactions {
+		if !cycle.Contains(transaction.Date) {
+			continue
+		}
+
+		if isCredit(transaction) {
+			statement.Credits = statement.Credits.Add(transaction.Amount)
+		} else {
+			statement.Charges = statement.Charges.Add(transaction.Amount)
+		}
+		statement.Transactions = append(statement.Transactions, transaction)
+	}
+
+	sort.SliceStable(statement.Transactions, func(i, j int) bool {
+		return statement.Transactions[i].Date.Before(statement.Transactions[j].Date)
+	})
+	statement.Balance = statement.Charges.Sub(statement.Credits)
+
+	return statement
+}
+
+// NewStatements bills one statement per cycle closing in the months from
+// from to to, in order.
+func NewStatements(terms *walletdomain.CreditCardTerms, from, to time.Time, transactions []*transactiondomain.Transaction) ([]*Statement, error) {
+	from, to = MonthOf(from), MonthOf(to)
+	if to.Before(from) {
+		return nil, ErrInvalidPeriod
+	}
+
+	var statements []*Statement
+	for month := from; !month.After(to); month = month.AddDate(0, 1, 0) {
+		statements = append(statements, NewStatement(terms.CycleClosingIn(month), transactions))
+	}
+
+	return statements, nil
+}
+
+// MonthOf returns the first day of the month of t.
+func MonthOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// isCredit reports whether the transaction pays the card down: income such
+// as refunds, and transfers into the card.
+func isCredit(transaction *transactiondomain.Transaction) bool {
+	return transaction.WalletDelta().IsPositive()
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func expense(id, amount string, on time.Time) *transactiondomain.Transaction {
+	return transactiondomain.NewTransaction(id, "user", "card", "cat", transactiondomain.TransactionTypeExpense, shareddomain.MustParseAmount(amount), "", on, "system")
+}
+
+func TestNewStatement(t *testing.T) {
+	terms := &walletdomain.CreditCardTerms{ClosingDay: 25, DueDay: 5}
+	cycle := terms.CycleClosingIn(date(2026, 3, 1))
+
+	_, payment := transactiondomain.NewTransfer("out", "in", "transfer", "user", "bank", "card", "", shareddomain.MustParseAmount("300"), shareddomain.MustParseAmount("300"), shareddomain.MustParseAmount("1"), "", date(2026, 3, 10), "system")
+	refund := transactiondomain.NewTransaction("refund", "user", "card", "cat", transactiondomain.TransactionTypeIncome, shareddomain.MustParseAmount("50"), "", date(2026, 3, 1), "system")
+
+	transactions := []*transactiondomain.Transaction{
+		expense("late", "200", date(2026, 3, 25)),
+		expense("early", "1000", date(2026, 2, 26)),
+		expense("before", "999", date(2026, 2, 25)),
+		expense("after", "999", date(2026, 3, 26)),
+		payment,
+		refund,
+	}
+
+	statement := NewStatement(cycle, transactions)
+
+	if !statement.Charges.Equal(shareddomain.MustParseAmount("1200")) {
+		t.Errorf("expected charges 1200, got %s", statement.Charges)
+	}
+	if !statement.Credits.Equal(shareddomain.MustParseAmount("350")) {
+		t.Errorf("expected credits 350, got %s", statement.Credits)
+	}
+	if !statement.Balance.Equal(shareddomain.MustParseAmount("850")) {
+		t.Errorf("expected balance 850, got %s", statement.Balance)
+	}
+	if len(statement.Transactions) != 4 {
+		t.Fatalf("expected 4 transactions, got %d", len(statement.Transactions))
+	}
+	if statement.Transactions[0].ID != "early" || statement.Transactions[3].ID != "late" {
+		t.Errorf("expected transactions oldest first, got %s..%s", statement.Transactions[0].ID, statement.Transactions[3].ID)
+	}
+}
+
+func TestNewStatements_Installments(t *testing.T) {
+	terms := &walletdomain.CreditCardTerms{ClosingDay: 25, DueDay: 5}
+
+	installments, err := transactiondomain.NewInstallments([]string{"i1", "i2", "i3"}, "plan", "user", "card", "cat", shareddomain.MustParseAmount("300"), "USD", 3, "", date(2026, 1, 10), "system")
+	if err != nil {
+		t.Fatalf("NewInstallments failed: %v", err)
+	}
+
+	statements, err := NewStatements(terms, date(2026, 1, 1), date(2026, 4, 1), installments)
+	if err != nil {
+		t.Fatalf("NewStatements failed: %v", err)
+	}
+
+	expected := []string{"100", "100", "100", "0"}
+	if len(statements) != len(expected) {
+		t.Fatalf("expected %d statements, got %d", len(expected), len(statements))
+	}
+	for i, statement := range statements {
+		if !statement.Balance.Equal(shareddomain.MustParseAmount(expected[i])) {
+			t.Errorf("statement closing %s: expected %s, got %s", statement.Cycle.Closing.Format("2006-01-02"), expected[i], statement.Balance)
+		}
+	}
+}
+
+func TestNewStatements_InvalidPeriod(t *testing.T) {
+	terms := &walletdomain.CreditCardTerms{ClosingDay: 25, DueDay: 5}
+
+	if _, err := NewStatements(terms, date(2026, 3, 1), date(2026, 2, 1), nil); err != ErrInvalidPeriod {
+		t.Errorf("expected ErrInvalidPeriod, got %v", err)
+	}
+}
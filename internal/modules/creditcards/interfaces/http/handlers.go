@@ -0,0 +1,216 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"fin-flow-api/internal/modules/creditcards/application/contracts/commands"
+	"fin-flow-api/internal/modules/creditcards/application/contracts/queries"
+	basehandler "fin-flow-api/internal/shared/http"
+)
+
+const (
+	dateLayout  = "2006-01-02"
+	monthLayout = "2006-01"
+)
+
+// defaultStatementMonths is how many statements are listed when no start
+// month is given.
+const defaultStatementMonths = 6
+
+type statementService interface {
+	Statements(ctx context.Context, req commands.StatementsRequest) (*queries.StatementsResponse, error)
+	Summary(ctx context.Context, walletID string, date time.Time) (*queries.SummaryResponse, error)
+}
+
+type Handler struct {
+	statementService statementService
+}
+
+func NewHandler(statementService statementService) *Handler {
+	return &Handler{
+		statementService: statementService,
+	}
+}
+
+// GetStatements handles GET /credit-cards/{id}/statements?from=YYYY-MM&to=YYYY-MM
+// and lists the statements closing in those months. It ends in the current
+// month and covers six statements unless told otherwise.
+func (h *Handler) GetStatements(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	walletID := walletIDFromPath(r.URL.Path)
+	if walletID == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Wallet ID is required in the URL path")
+		return
+	}
+
+	query := r.URL.Query()
+
+	to, err := parseOptionalMonth(query.Get("to"), "to")
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if to.IsZero() {
+		now := time.Now().UTC()
+		to = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	from, err := parseOptionalMonth(query.Get("from"), "from")
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 1-defaultStatementMonths, 0)
+	}
+
+	statements, err := h.statementService.Statements(r.Context(), commands.StatementsRequest{
+		WalletID: walletID,
+		From:     from,
+		To:       to,
+	})
+	if err != nil {
+		statusCode, errorMsg := creditCardErrorResponse(err)
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	response := StatementsResponse{
+		WalletID:   statements.WalletID,
+		Currency:   statements.Currency,
+		Statements: make([]StatementResponse, len(statements.Statements)),
+	}
+	for i, statement := range statements.Statements {
+		response.Statements[i] = toStatementResponse(statement)
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, response)
+}
+
+// GetSummary handles GET /credit-cards/{id}/summary?date=YYYY-MM-DD and shows
+// the open and next statements and the available credit on that date,
+// today by default.
+func (h *Handler) GetSummary(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	walletID := walletIDFromPath(r.URL.Path)
+	if walletID == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Wallet ID is required in the URL path")
+		return
+	}
+
+	date := time.Now().UTC()
+	if value := strings.TrimSpace(r.URL.Query().Get("date")); value != "" {
+		parsed, err := time.Parse(dateLayout, value)
+		if err != nil {
+			basehandler.WriteError(w, http.StatusBadRequest, "Date must use the YYYY-MM-DD format")
+			return
+		}
+		date = parsed
+	}
+
+	summary, err := h.statementService.Summary(r.Context(), walletID, date)
+	if err != nil {
+		statusCode, errorMsg := creditCardErrorResponse(err)
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, SummaryResponse{
+		WalletID:        summary.WalletID,
+		Currency:        summary.Currency,
+		Date:            summary.Date.Format(dateLayout),
+		CreditLimit:     summary.CreditLimit,
+		Owed:            summary.Owed,
+		AvailableCredit: summary.AvailableCredit,
+		Current:         toStatementResponse(summary.Current),
+		Next:            toStatementResponse(summary.Next),
+	})
+}
+
+// walletIDFromPath extracts the wallet ID from /credit-cards/{id}/....
+func walletIDFromPath(path string) string {
+	path = strings.TrimPrefix(path, "/credit-cards/")
+	return strings.Split(path, "/")[0]
+}
+
+func parseOptionalMonth(value, field string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	month, err := time.Parse(monthLayout, value)
+	if err != nil {
+		return time.Time{}, &ValidationError{Field: field, Message: "Month must use the YYYY-MM format"}
+	}
+
+	return month, nil
+}
+
+func creditCardErrorResponse(err error) (int, string) {
+	errorMsg := err.Error()
+
+	switch {
+	case strings.Contains(errorMsg, "user not authenticated"):
+		return http.StatusUnauthorized, "Authentication required"
+	case strings.Contains(errorMsg, "unauthorized access"):
+		return http.StatusForbidden, "You do not have permission to access this wallet"
+	case strings.Contains(errorMsg, "wallet not found"):
+		return http.StatusNotFound, "Wallet not found"
+	case strings.Contains(errorMsg, "wallet is not a credit card"):
+		return http.StatusBadRequest, "Wallet is not a credit card"
+	case strings.Contains(errorMsg, "billing cycle is not configured"):
+		return http.StatusConflict, "Set the credit limit, statement closing day and payment due day of this card first"
+	case strings.Contains(errorMsg, "statement period"):
+		return http.StatusBadRequest, errorMsg
+	}
+
+	return http.StatusInternalServerError, errorMsg
+}
+
+func toStatementResponse(statement queries.StatementResponse) StatementResponse {
+	response := StatementResponse{
+		Start:        statement.Start.Format(dateLayout),
+		Closing:      statement.Closing.Format(dateLayout),
+		Due:          statement.Due.Format(dateLayout),
+		Charges:      statement.Charges,
+		Credits:      statement.Credits,
+		Balance:      statement.Balance,
+		Transactions: make([]StatementLineResponse, len(statement.Transactions)),
+	}
+
+	for i, line := range statement.Transactions {
+		response.Transactions[i] = StatementLineResponse{
+			TransactionID:     line.TransactionID,
+			Date:              line.Date.Format(dateLayout),
+			Description:       line.Description,
+			Type:              line.Type,
+			TypeName:          line.TypeName,
+			Amount:            line.Amount,
+			InstallmentNumber: line.InstallmentNumber,
+			InstallmentCount:  line.InstallmentCount,
+		}
+	}
+
+	return response
+}
+
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
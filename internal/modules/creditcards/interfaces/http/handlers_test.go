@@ -0,0 +1,193 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"fin-flow-api/internal/modules/creditcards/application/contracts/commands"
+	"fin-flow-api/internal/modules/creditcards/application/contracts/queries"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+type mockStatementService struct {
+	err         error
+	statements  *queries.StatementsResponse
+	summary     *queries.SummaryResponse
+	lastRequest commands.StatementsRequest
+	lastWallet  string
+	lastDate    time.Time
+}
+
+func (m *mockStatementService) Statements(ctx context.Context, req commands.StatementsRequest) (*queries.StatementsResponse, error) {
+	m.lastRequest = req
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.statements, nil
+}
+
+func (m *mockStatementService) Summary(ctx context.Context, walletID string, date time.Time) (*queries.SummaryResponse, error) {
+	m.lastWallet = walletID
+	m.lastDate = date
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.summary, nil
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestGetStatements_Success(t *testing.T) {
+	service := &mockStatementService{statements: &queries.StatementsResponse{
+		WalletID: "card",
+		Currency: "ARS",
+		Statements: []queries.StatementResponse{{
+			Start:   date(2026, 2, 26),
+			Closing: date(2026, 3, 25),
+			Due:     date(2026, 4, 5),
+			Charges: shareddomain.MustParseAmount("100"),
+			Balance: shareddomain.MustParseAmount("100"),
+			Transactions: []queries.StatementLineResponse{{
+				TransactionID:     "tx-1",
+				Date:              date(2026, 3, 10),
+				Amount:            shareddomain.MustParseAmount("100"),
+				InstallmentNumber: 2,
+				InstallmentCount:  3,
+			}},
+		}},
+	}}
+	handler := &Handler{statementService: service}
+
+	req := httptest.NewRequest("GET", "/credit-cards/card/statements?from=2026-01&to=2026-03", nil)
+	rr := httptest.NewRecorder()
+	handler.GetStatements(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if service.lastRequest.WalletID != "card" || !service.lastRequest.From.Equal(date(2026, 1, 1)) || !service.lastRequest.To.Equal(date(2026, 3, 1)) {
+		t.Errorf("unexpected request %+v", service.lastRequest)
+	}
+
+	var response StatementsResponse
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Statements) != 1 || response.Statements[0].Closing != "2026-03-25" || response.Statements[0].Due != "2026-04-05" {
+		t.Fatalf("unexpected statements %+v", response.Statements)
+	}
+	if line := response.Statements[0].Transactions[0]; line.InstallmentNumber != 2 || line.InstallmentCount != 3 {
+		t.Errorf("expected installment 2 of 3, got %+v", line)
+	}
+}
+
+func TestGetStatements_DefaultsToSixMonths(t *testing.T) {
+	service := &mockStatementService{statements: &queries.StatementsResponse{}}
+	handler := &Handler{statementService: service}
+
+	req := httptest.NewRequest("GET", "/credit-cards/card/statements?to=2026-06", nil)
+	rr := httptest.NewRecorder()
+	handler.GetStatements(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if !service.lastRequest.From.Equal(date(2026, 1, 1)) {
+		t.Errorf("expected statements from 2026-01, got %s", service.lastRequest.From)
+	}
+}
+
+func TestGetSummary_Success(t *testing.T) {
+	service := &mockStatementService{summary: &queries.SummaryResponse{
+		WalletID:        "card",
+		Currency:        "ARS",
+		Date:            date(2026, 3, 15),
+		CreditLimit:     shareddomain.MustParseAmount("1000"),
+		Owed:            shareddomain.MustParseAmount("400"),
+		AvailableCredit: shareddomain.MustParseAmount("600"),
+		Current:         queries.StatementResponse{Balance: shareddomain.MustParseAmount("150")},
+		Next:            queries.StatementResponse{Balance: shareddomain.MustParseAmount("250")},
+	}}
+	handler := &Handler{statementService: service}
+
+	req := httptest.NewRequest("GET", "/credit-cards/card/summary?date=2026-03-15", nil)
+	rr := httptest.NewRecorder()
+	handler.GetSummary(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if service.lastWallet != "card" || !service.lastDate.Equal(date(2026, 3, 15)) {
+		t.Errorf("unexpected request for %s on %s", service.lastWallet, service.lastDate)
+	}
+
+	var response SummaryResponse
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.AvailableCredit.String() != "600" || response.Current.Balance.String() != "150" || response.Next.Balance.String() != "250" {
+		t.Errorf("unexpected summary %+v", response)
+	}
+}
+
+func TestCreditCardHandlers_BadRequests(t *testing.T) {
+	handler := &Handler{statementService: &mockStatementService{}}
+
+	for _, target := range []string{
+		"/credit-cards//statements",
+		"/credit-cards/card/statements?from=2026",
+		"/credit-cards/card/summary?date=2026-03",
+	} {
+		req := httptest.NewRequest("GET", target, nil)
+		rr := httptest.NewRecorder()
+		handleCreditCardsWith(handler, rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", target, rr.Code)
+		}
+	}
+}
+
+func TestCreditCardHandlers_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"unauthenticated", errors.New("user not authenticated"), http.StatusUnauthorized},
+		{"other user", errors.New("unauthorized access to wallet"), http.StatusForbidden},
+		{"missing wallet", errors.New("wallet not found"), http.StatusNotFound},
+		{"not a card", walletdomain.ErrNotCreditCard, http.StatusBadRequest},
+		{"no terms", walletdomain.ErrCreditCardNotConfigured, http.StatusConflict},
+		{"unexpected", errors.New("boom"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &Handler{statementService: &mockStatementService{err: tt.err}}
+
+			req := httptest.NewRequest("GET", "/credit-cards/card/summary", nil)
+			rr := httptest.NewRecorder()
+			handler.GetSummary(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func handleCreditCardsWith(handler *Handler, w http.ResponseWriter, r *http.Request) {
+	previous := creditCardHandler
+	creditCardHandler = handler
+	defer func() { creditCardHandler = previous }()
+	handleCreditCardsResource(w, r)
+}
@@ -0,0 +1,34 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"fin-flow-api/internal/shared/interface/jwt"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+var creditCardHandler *Handler
+
+func SetupRoutes(mux *http.ServeMux, jwtService jwt.Service) {
+	mountCreditCards(mux, jwtService)
+}
+
+func mountCreditCards(mux *http.ServeMux, jwtService jwt.Service) {
+	mux.Handle("/credit-cards/", middleware.RequireAuth(jwtService)(http.HandlerFunc(handleCreditCardsResource)))
+}
+
+func handleCreditCardsResource(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/statements"):
+		creditCardHandler.GetStatements(w, r)
+	case strings.HasSuffix(r.URL.Path, "/summary"):
+		creditCardHandler.GetSummary(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func SetHandler(handler *Handler) {
+	creditCardHandler = handler
+}
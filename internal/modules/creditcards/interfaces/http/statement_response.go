@@ -0,0 +1,41 @@
+package http
+
+import shareddomain "fin-flow-api/internal/shared/domain"
+
+type StatementLineResponse struct {
+	TransactionID     string              `json:"transaction_id"`
+	Date              string              `json:"date"`
+	Description       string              `json:"description"`
+	Type              int                 `json:"type"`
+	TypeName          string              `json:"type_name"`
+	Amount            shareddomain.Amount `json:"amount"`
+	InstallmentNumber int                 `json:"installment_number,omitempty"`
+	InstallmentCount  int                 `json:"installment_count,omitempty"`
+}
+
+type StatementResponse struct {
+	Start        string                  `json:"start"`
+	Closing      string                  `json:"closing"`
+	Due          string                  `json:"due"`
+	Charges      shareddomain.Amount     `json:"charges"`
+	Credits      shareddomain.Amount     `json:"credits"`
+	Balance      shareddomain.Amount     `json:"balance"`
+	Transactions []StatementLineResponse `json:"transactions"`
+}
+
+type StatementsResponse struct {
+	WalletID   string              `json:"wallet_id"`
+	Currency   string              `json:"currency"`
+	Statements []StatementResponse `json:"statements"`
+}
+
+type SummaryResponse struct {
+	WalletID        string              `json:"wallet_id"`
+	Currency        string              `json:"currency"`
+	Date            string              `json:"date"`
+	CreditLimit     shareddomain.Amount `json:"credit_limit"`
+	Owed            shareddomain.Amount `json:"owed"`
+	AvailableCredit shareddomain.Amount `json:"available_credit"`
+	Current         StatementResponse   `json:"current_statement"`
+	Next            StatementResponse   `json:"next_statement"`
+}
@@ -0,0 +1,10 @@
+package commands
+
+// ResolveRequest settles whether DuplicateID is a duplicate of
+// TransactionID. Merging keeps TransactionID and deletes DuplicateID, while
+// dismissing keeps both and stops suggesting the pair.
+type ResolveRequest struct {
+	TransactionID string
+	DuplicateID   string
+	Action        string
+}
@@ -0,0 +1,40 @@
+package queries
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+// ListDuplicatesRequest narrows the search for suspected duplicates to a
+// wallet and to the transactions dated within From and To, inclusive.
+type ListDuplicatesRequest struct {
+	WalletID string
+	From     *time.Time
+	To       *time.Time
+}
+
+// DuplicatePairResponse is two transactions likely to be the same one, the
+// older first, and how alike they are.
+type DuplicatePairResponse struct {
+	Transaction      *DuplicateTransactionResponse
+	Duplicate        *DuplicateTransactionResponse
+	Similarity       float64
+	DayDifference    int
+	AmountDifference domain.Amount
+	Score            float64
+}
+
+type DuplicateTransactionResponse struct {
+	ID            string
+	WalletID      string
+	CategoryID    string
+	Type          int
+	TypeName      string
+	Amount        domain.Amount
+	Description   string
+	Payee         string
+	Date          time.Time
+	ImportBatchID string
+	ExternalID    string
+}
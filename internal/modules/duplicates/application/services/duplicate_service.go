@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"fin-flow-api/internal/modules/duplicates/application/contracts/commands"
+	"fin-flow-api/internal/modules/duplicates/application/contracts/queries"
+	"fin-flow-api/internal/modules/duplicates/domain"
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+type DuplicateService struct {
+	repository            domain.DismissalRepository
+	transactionRepository transactiondomain.TransactionRepository
+	criteria              domain.Criteria
+	systemUser            string
+}
+
+func NewDuplicateService(repository domain.DismissalRepository, transactionRepository transactiondomain.TransactionRepository, criteria domain.Criteria, systemUser string) *DuplicateService {
+	return &DuplicateService{
+		repository:            repository,
+		transactionRepository: transactionRepository,
+		criteria:              criteria,
+		systemUser:            systemUser,
+	}
+}
+
+func (s *DuplicateService) getUserIDFromContext(ctx context.Context) (string, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return "", errors.New("user not authenticated")
+	}
+	return userID, nil
+}
+
+// List returns the pairs of existing transactions that are likely
+// duplicates of each other, best match first. Pairs the user dismissed
+// are left out.
+func (s *DuplicateService) List(ctx context.Context, req queries.ListDuplicatesRequest) ([]*queries.DuplicatePairResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := s.transactionRepository.List(userID, transactiondomain.TransactionFilter{
+		WalletID: req.WalletID,
+		From:     req.From,
+		To:       req.To,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dismissals, err := s.repository.List(userID)
+	if err != nil {
+		return nil, err
+	}
+	dismissed := make(map[string]bool, len(dismissals))
+	for _, dismissal := range dismissals {
+		dismissed[dismissal.Key()] = true
+	}
+
+	pairs := s.criteria.FindPairs(transactions, dismissed)
+	responses := make([]*queries.DuplicatePairResponse, len(pairs))
+	for i, pair := range pairs {
+		responses[i] = toDuplicatePairResponse(pair)
+	}
+
+	return responses, nil
+}
+
+// Resolve merges or dismisses a suspected duplicate. Any two expenses or
+// income of the same type and wallet can be resolved, whether or not they
+// meet the criteria.
+func (s *DuplicateService) Resolve(ctx context.Context, req commands.ResolveRequest) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !domain.IsValidAction(req.Action) {
+		return domain.ErrInvalidAction
+	}
+
+	transaction, err := s.transactionRepository.GetByID(req.TransactionID, userID)
+	if err != nil {
+		return err
+	}
+
+	duplicate, err := s.transactionRepository.GetByID(req.DuplicateID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := domain.CheckPair(transaction, duplicate); err != nil {
+		return err
+	}
+
+	if domain.Action(req.Action) == domain.ActionDismiss {
+		return s.repository.Create(domain.NewDismissal(userID, transaction.ID, duplicate.ID, s.systemUser))
+	}
+
+	if err := duplicate.CheckDelete(); err != nil {
+		return err
+	}
+
+	domain.Merge(transaction, duplicate)
+	transaction.Entity.UpdateModified(s.systemUser)
+
+	return s.transactionRepository.MergeDuplicate(transaction, duplicate.ID)
+}
+
+// FindDuplicates returns the ids of the existing transactions a new
+// expense or income is likely a duplicate of, best match first.
+func (s *DuplicateService) FindDuplicates(transaction *transactiondomain.Transaction) ([]string, error) {
+	duplicates, err := s.FindDuplicatesAll([]*transactiondomain.Transaction{transaction})
+	if err != nil {
+		return nil, err
+	}
+	return duplicates[transaction.ID], nil
+}
+
+// FindDuplicatesAll is FindDuplicates for new transactions of a single
+// user, such as the lines of an imported statement, keyed by their id.
+// They are only compared with the transactions already stored, not with
+// each other. The transactions of each wallet are loaded only once.
+func (s *DuplicateService) FindDuplicatesAll(transactions []*transactiondomain.Transaction) (map[string][]string, error) {
+	duplicates := make(map[string][]string)
+
+	byWallet := make(map[string][]*transactiondomain.Transaction)
+	var walletIDs []string
+	for _, transaction := range transactions {
+		if !domain.Eligible(transaction) {
+			continue
+		}
+		if byWallet[transaction.WalletID] == nil {
+			walletIDs = append(walletIDs, transaction.WalletID)
+		}
+		byWallet[transaction.WalletID] = append(byWallet[transaction.WalletID], transaction)
+	}
+
+	for _, walletID := range walletIDs {
+		candidates := byWallet[walletID]
+
+		first, last := candidates[0].Date, candidates[0].Date
+		for _, candidate := range candidates[1:] {
+			if candidate.Date.Before(first) {
+				first = candidate.Date
+			}
+			if candidate.Date.After(last) {
+				last = candidate.Date
+			}
+		}
+		from, to := s.criteria.Window(first, last)
+
+		existing, err := s.transactionRepository.List(candidates[0].UserID, transactiondomain.TransactionFilter{
+			WalletID: walletID,
+			From:     &from,
+			To:       &to,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, candidate := range candidates {
+			if ids := s.criteria.FindDuplicates(candidate, existing); len(ids) > 0 {
+				duplicates[candidate.ID] = ids
+			}
+		}
+	}
+
+	return duplicates, nil
+}
+
+func toDuplicatePairResponse(pair domain.Pair) *queries.DuplicatePairResponse {
+	return &queries.DuplicatePairResponse{
+		Transaction:      toDuplicateTransactionResponse(pair.First),
+		Duplicate:        toDuplicateTransactionResponse(pair.Second),
+		Similarity:       pair.Match.Similarity,
+		DayDifference:    pair.Match.DayDifference,
+		AmountDifference: pair.Match.AmountDifference,
+		Score:            pair.Match.Score,
+	}
+}
+
+func toDuplicateTransactionResponse(transaction *transactiondomain.Transaction) *queries.DuplicateTransactionResponse {
+	return &queries.DuplicateTransactionResponse{
+		ID:            transaction.ID,
+		WalletID:      transaction.WalletID,
+		CategoryID:    transaction.CategoryID,
+		Type:          transaction.Type.Value(),
+		TypeName:      transaction.Type.String(),
+		Amount:        transaction.Amount,
+		Description:   transaction.Description,
+		Payee:         transaction.Payee,
+		Date:          transaction.Date,
+		ImportBatchID: transaction.ImportBatchID,
+		ExternalID:    transaction.ExternalID,
+	}
+}
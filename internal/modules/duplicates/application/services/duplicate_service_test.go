@@ -0,0 +1,242 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"fin-flow-api/internal/modules/duplicates/application/contracts/commands"
+	"fin-flow-api/internal/modules/duplicates/application/contracts/queries"
+	"fin-flow-api/internal/modules/duplicates/domain"
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	"fin-flow-api/internal/modules/transactions/domain/transactiontest"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+type mockDismissalRepository struct {
+	dismissals []*domain.Dismissal
+}
+
+func (m *mockDismissalRepository) Create(dismissal *domain.Dismissal) error {
+	for _, existing := range m.dismissals {
+		if existing.Key() == dismissal.Key() {
+			return nil
+		}
+	}
+	m.dismissals = append(m.dismissals, dismissal)
+	return nil
+}
+
+func (m *mockDismissalRepository) List(userID string) ([]*domain.Dismissal, error) {
+	var dismissals []*domain.Dismissal
+	for _, dismissal := range m.dismissals {
+		if dismissal.UserID == userID {
+			dismissals = append(dismissals, dismissal)
+		}
+	}
+	return dismissals, nil
+}
+
+func contextWithUser(userID string) context.Context {
+	return context.WithValue(context.Background(), middleware.UserIDKey, userID)
+}
+
+func date(value string) time.Time {
+	day, _ := time.Parse("2006-01-02", value)
+	return day
+}
+
+func expense(id, userID, amount, description, day string) *transactiondomain.Transaction {
+	return transactiondomain.NewTransaction(id, userID, "wallet1", "cat-food", transactiondomain.TransactionTypeExpense, shareddomain.MustParseAmount(amount), description, date(day), "system")
+}
+
+func newTestDuplicateService() (*DuplicateService, *mockDismissalRepository, *transactiontest.Repository) {
+	imported := expense("imported", "user1", "54.50", "SEPA DIRECT DEBIT ELECTRICITY", "2026-04-02")
+	imported.ImportBatchID, imported.ExternalID, imported.Payee = "batch1", "BANK-REF-1", "Power Co"
+
+	transactions := transactiontest.NewRepository(
+		expense("manual", "user1", "54.50", "Electricity", "2026-04-01"),
+		imported,
+		expense("rent", "user1", "800", "Rent", "2026-04-01"),
+		expense("lunch", "user1", "12", "Lunch", "2026-04-03"),
+		expense("lunch2", "user1", "12", "Lunch", "2026-04-03"),
+		expense("other", "user2", "54.50", "Electricity", "2026-04-01"),
+	)
+	dismissals := &mockDismissalRepository{}
+
+	return NewDuplicateService(dismissals, transactions, domain.DefaultCriteria(), "system"), dismissals, transactions
+}
+
+func TestDuplicateService_List(t *testing.T) {
+	service, dismissals, _ := newTestDuplicateService()
+	ctx := contextWithUser("user1")
+
+	pairs, err := service.List(ctx, queries.ListDuplicatesRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pairs) != 2 {
+		t.Fatalf("expected 2 pairs, got %d", len(pairs))
+	}
+
+	var electricity *queries.DuplicatePairResponse
+	for _, pair := range pairs {
+		if pair.Transaction.ID == "manual" {
+			electricity = pair
+		}
+	}
+	if electricity == nil || electricity.Duplicate.ID != "imported" || electricity.DayDifference != 1 || !electricity.AmountDifference.IsZero() {
+		t.Fatalf("expected the manual and imported electricity bills, got %+v", pairs)
+	}
+
+	dismissals.Create(domain.NewDismissal("user1", "lunch2", "lunch", "system"))
+
+	pairs, err = service.List(ctx, queries.ListDuplicatesRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pairs) != 1 || pairs[0].Transaction.ID != "manual" {
+		t.Errorf("expected the dismissed lunches to be left out, got %d pairs", len(pairs))
+	}
+}
+
+func TestDuplicateService_List_Unauthenticated(t *testing.T) {
+	service, _, _ := newTestDuplicateService()
+
+	if _, err := service.List(context.Background(), queries.ListDuplicatesRequest{}); err == nil || err.Error() != "user not authenticated" {
+		t.Errorf("expected user not authenticated, got %v", err)
+	}
+}
+
+func TestDuplicateService_Resolve_Merge(t *testing.T) {
+	service, _, transactions := newTestDuplicateService()
+	transactions.Transactions["manual"].Tags = []string{"home"}
+	transactions.Transactions["imported"].Tags = []string{"bills"}
+
+	err := service.Resolve(contextWithUser("user1"), commands.ResolveRequest{TransactionID: "manual", DuplicateID: "imported", Action: "merge"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, exists := transactions.Transactions["imported"]; exists {
+		t.Error("expected the duplicate to be deleted")
+	}
+	kept := transactions.Transactions["manual"]
+	if kept.Description != "Electricity" || kept.Payee != "Power Co" || kept.ExternalID != "BANK-REF-1" || kept.ImportBatchID != "" {
+		t.Errorf("unexpected merged transaction %+v", kept)
+	}
+	if len(kept.Tags) != 2 {
+		t.Errorf("expected the tags of both, got %v", kept.Tags)
+	}
+}
+
+func TestDuplicateService_Resolve_MergeReconciled(t *testing.T) {
+	service, _, transactions := newTestDuplicateService()
+	transactions.Transactions["imported"].Reconciled = true
+	ctx := contextWithUser("user1")
+
+	err := service.Resolve(ctx, commands.ResolveRequest{TransactionID: "manual", DuplicateID: "imported", Action: "merge"})
+	if err != transactiondomain.ErrReconciledNotDeletable {
+		t.Fatalf("expected ErrReconciledNotDeletable, got %v", err)
+	}
+
+	if err := service.Resolve(ctx, commands.ResolveRequest{TransactionID: "imported", DuplicateID: "manual", Action: "merge"}); err != nil {
+		t.Fatalf("expected the reconciled transaction to be kept, got %v", err)
+	}
+	if kept := transactions.Transactions["imported"]; kept.Description != "SEPA DIRECT DEBIT ELECTRICITY" || kept.Amount.String() != "54.5" {
+		t.Errorf("unexpected merged transaction %+v", kept)
+	}
+}
+
+func TestDuplicateService_Resolve_Dismiss(t *testing.T) {
+	service, dismissals, transactions := newTestDuplicateService()
+	ctx := contextWithUser("user1")
+
+	for i := 0; i < 2; i++ {
+		if err := service.Resolve(ctx, commands.ResolveRequest{TransactionID: "manual", DuplicateID: "imported", Action: "dismiss"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if len(dismissals.dismissals) != 1 || dismissals.dismissals[0].FirstTransactionID != "imported" {
+		t.Errorf("expected a single dismissal in id order, got %+v", dismissals.dismissals)
+	}
+	if len(transactions.Transactions) != 6 {
+		t.Error("expected both transactions to be kept")
+	}
+}
+
+func TestDuplicateService_Resolve_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     commands.ResolveRequest
+		wantErr string
+	}{
+		{"invalid action", commands.ResolveRequest{TransactionID: "manual", DuplicateID: "imported", Action: "keep"}, domain.ErrInvalidAction.Error()},
+		{"same transaction", commands.ResolveRequest{TransactionID: "manual", DuplicateID: "manual", Action: "merge"}, domain.ErrSameTransaction.Error()},
+		{"missing duplicate", commands.ResolveRequest{TransactionID: "manual", DuplicateID: "missing", Action: "merge"}, "transaction not found"},
+		{"someone else's", commands.ResolveRequest{TransactionID: "manual", DuplicateID: "other", Action: "dismiss"}, "unauthorized access to transaction"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, dismissals, transactions := newTestDuplicateService()
+
+			err := service.Resolve(contextWithUser("user1"), tt.req)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("expected %q, got %v", tt.wantErr, err)
+			}
+			if len(dismissals.dismissals) != 0 || len(transactions.Transactions) != 6 {
+				t.Error("expected nothing to change")
+			}
+		})
+	}
+}
+
+func TestDuplicateService_FindDuplicatesAll(t *testing.T) {
+	service, _, transactions := newTestDuplicateService()
+
+	electricity := expense("new-1", "user1", "54.50", "Electricity April", "2026-04-02")
+	rent := expense("new-2", "user1", "800", "RENT APRIL", "2026-04-05")
+	transfer := expense("new-3", "user1", "12", "Lunch", "2026-04-03")
+	transfer.Type, transfer.TransferID = transactiondomain.TransactionTypeTransfer, "transfer1"
+
+	duplicates, err := service.FindDuplicatesAll([]*transactiondomain.Transaction{electricity, rent, transfer})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(duplicates) != 1 || len(duplicates["new-1"]) != 2 {
+		t.Fatalf("expected the electricity bill to match the manual and imported ones, got %v", duplicates)
+	}
+	if duplicates["new-1"][0] != "manual" && duplicates["new-1"][0] != "imported" {
+		t.Errorf("unexpected duplicates %v", duplicates["new-1"])
+	}
+
+	if len(transactions.Filters) != 1 {
+		t.Fatalf("expected the wallet to be listed once, got %d", len(transactions.Filters))
+	}
+	filter := transactions.Filters[0]
+	if filter.WalletID != "wallet1" || !filter.From.Equal(date("2026-03-30")) || !filter.To.Equal(date("2026-04-08")) {
+		t.Errorf("expected the dates of the statement widened by the window, got %+v", filter)
+	}
+}
+
+func TestDuplicateService_FindDuplicates(t *testing.T) {
+	service, _, transactions := newTestDuplicateService()
+
+	ids, err := service.FindDuplicates(expense("new", "user1", "12", "lunch", "2026-04-04"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ids) != 2 {
+		t.Errorf("expected both lunches, got %v", ids)
+	}
+
+	transactions.ListErr = errors.New("failed to list transactions")
+	if _, err := service.FindDuplicates(expense("new", "user1", "12", "lunch", "2026-04-04")); err == nil {
+		t.Error("expected the list error")
+	}
+}
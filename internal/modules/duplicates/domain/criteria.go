@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	"fin-flow-api/internal/shared/domain"
+)
+
+var (
+	ErrInvalidAmountTolerance = errors.New("amount tolerance must be a percentage from 0 to 100")
+	ErrInvalidDateWindow      = errors.New("date window must be from 0 to 31 days")
+	ErrInvalidMinSimilarity   = errors.New("minimum similarity must be from 0 to 1")
+)
+
+// MaxDateWindowDays is the widest date window duplicates are looked for in.
+const MaxDateWindowDays = 31
+
+var hundred = domain.NewAmountFromInt(100)
+
+// Criteria decide when two transactions are likely the same one entered
+// twice, such as an expense typed in by hand that later arrives in an
+// imported statement: they must be expenses or income of the same type in
+// the same wallet, their amounts must be within AmountTolerance percent of
+// the larger one, their dates at most DateWindowDays apart and their
+// descriptions and payees at least MinSimilarity alike.
+type Criteria struct {
+	AmountTolerance domain.Amount
+	DateWindowDays  int
+	MinSimilarity   float64
+}
+
+// DefaultCriteria allows a 1% difference in amount, 3 days in date and
+// descriptions that are half alike.
+func DefaultCriteria() Criteria {
+	return Criteria{
+		AmountTolerance: domain.NewAmountFromInt(1),
+		DateWindowDays:  3,
+		MinSimilarity:   0.5,
+	}
+}
+
+// NewCriteria returns the criteria for an amount tolerance given as a
+// percentage, a date window in days and a minimum similarity from 0 to 1.
+func NewCriteria(amountTolerance string, dateWindowDays int, minSimilarity float64) (Criteria, error) {
+	tolerance, err := domain.ParseAmount(amountTolerance)
+	if err != nil || tolerance.IsNegative() || tolerance.Cmp(hundred) > 0 {
+		return Criteria{}, ErrInvalidAmountTolerance
+	}
+	if dateWindowDays < 0 || dateWindowDays > MaxDateWindowDays {
+		return Criteria{}, ErrInvalidDateWindow
+	}
+	if math.IsNaN(minSimilarity) || minSimilarity < 0 || minSimilarity > 1 {
+		return Criteria{}, ErrInvalidMinSimilarity
+	}
+
+	return Criteria{
+		AmountTolerance: tolerance,
+		DateWindowDays:  dateWindowDays,
+		MinSimilarity:   minSimilarity,
+	}, nil
+}
+
+// Match is how alike two transactions taken for duplicates are. Score
+// weighs the similarity of their text, amounts and dates equally, from 0
+// to 1, to rank the matches.
+type Match struct {
+	Similarity       float64
+	DayDifference    int
+	AmountDifference domain.Amount
+	Score            float64
+}
+
+// Eligible reports whether transaction can be taken for a duplicate.
+// Transfers, installments and loan payments are left out, since each of
+// them is created along with other transactions it must stay in step
+// with.
+func Eligible(transaction *transactiondomain.Transaction) bool {
+	if transaction.Type != transactiondomain.TransactionTypeExpense && transaction.Type != transactiondomain.TransactionTypeIncome {
+		return false
+	}
+	return transaction.TransferID == "" && transaction.InstallmentPlanID == "" && !transaction.IsLoanPayment()
+}
+
+// Compare reports whether a and b are likely the same transaction and, if
+// so, how alike they are. Two lines of the same imported statement are
+// never duplicates, since the bank listed both, and neither are two
+// transactions the bank gave different ids to.
+func (c Criteria) Compare(a, b *transactiondomain.Transaction) (Match, bool) {
+	if a.ID == b.ID || !Eligible(a) || !Eligible(b) || a.WalletID != b.WalletID || a.Type != b.Type {
+		return Match{}, false
+	}
+	if a.ImportBatchID != "" && a.ImportBatchID == b.ImportBatchID {
+		return Match{}, false
+	}
+	if a.ExternalID != "" && b.ExternalID != "" && a.ExternalID != b.ExternalID {
+		return Match{}, false
+	}
+
+	days := dayDifference(a.Date, b.Date)
+	if days > c.DateWindowDays {
+		return Match{}, false
+	}
+
+	difference := a.Amount.Sub(b.Amount).Abs()
+	larger := a.Amount
+	if b.Amount.Cmp(larger) > 0 {
+		larger = b.Amount
+	}
+	if difference.Mul(hundred).Cmp(c.AmountTolerance.Mul(larger)) > 0 {
+		return Match{}, false
+	}
+
+	similarity := Similarity(text(a), text(b))
+	if similarity < c.MinSimilarity {
+		return Match{}, false
+	}
+
+	amountScore := 1.0
+	if !difference.IsZero() {
+		amountScore = 0.5
+	}
+	dateScore := 1 - float64(days)/float64(c.DateWindowDays+1)
+
+	return Match{
+		Similarity:       math.Round(similarity*100) / 100,
+		DayDifference:    days,
+		AmountDifference: difference,
+		Score:            math.Round((similarity+amountScore+dateScore)/3*100) / 100,
+	}, true
+}
+
+// text is what the description similarity of a transaction is measured
+// on.
+func text(transaction *transactiondomain.Transaction) string {
+	return transaction.Description + " " + transaction.Payee
+}
+
+// dayDifference is how many calendar days apart a and b are.
+func dayDifference(a, b time.Time) int {
+	dayA := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	dayB := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+
+	days := int(dayA.Sub(dayB).Hours() / 24)
+	if days < 0 {
+		return -days
+	}
+	return days
+}
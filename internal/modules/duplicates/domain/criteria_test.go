@@ -0,0 +1,214 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+func expense(id, amount, description, date string) *transactiondomain.Transaction {
+	day, _ := time.Parse("2006-01-02", date)
+	return transactiondomain.NewTransaction(id, "user-1", "wallet-1", "food", transactiondomain.TransactionTypeExpense, shareddomain.MustParseAmount(amount), description, day, "system")
+}
+
+func TestNewCriteria(t *testing.T) {
+	tests := []struct {
+		name      string
+		tolerance string
+		days      int
+		minimum   float64
+		wantErr   error
+	}{
+		{"valid", "2.5", 5, 0.6, nil},
+		{"exact", "0", 0, 1, nil},
+		{"negative tolerance", "-1", 3, 0.5, ErrInvalidAmountTolerance},
+		{"tolerance over 100", "101", 3, 0.5, ErrInvalidAmountTolerance},
+		{"tolerance not a number", "one", 3, 0.5, ErrInvalidAmountTolerance},
+		{"negative window", "1", -1, 0.5, ErrInvalidDateWindow},
+		{"window too wide", "1", 32, 0.5, ErrInvalidDateWindow},
+		{"similarity over 1", "1", 3, 1.5, ErrInvalidMinSimilarity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			criteria, err := NewCriteria(tt.tolerance, tt.days, tt.minimum)
+			if err != tt.wantErr {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if err == nil && (criteria.DateWindowDays != tt.days || criteria.MinSimilarity != tt.minimum) {
+				t.Errorf("unexpected criteria %+v", criteria)
+			}
+		})
+	}
+}
+
+func TestCriteria_Compare(t *testing.T) {
+	criteria := DefaultCriteria()
+	manual := expense("manual", "54.50", "Electricity", "2026-04-01")
+
+	tests := []struct {
+		name  string
+		other func() *transactiondomain.Transaction
+		want  bool
+	}{
+		{"imported a day later", func() *transactiondomain.Transaction {
+			other := expense("imported", "54.50", "SEPA DIRECT DEBIT ELECTRICITY", "2026-04-02")
+			other.ImportBatchID, other.ExternalID = "batch-1", "BANK-REF-1"
+			return other
+		}, true},
+		{"amount within tolerance", func() *transactiondomain.Transaction {
+			return expense("other", "55", "Electricity", "2026-04-01")
+		}, true},
+		{"amount beyond tolerance", func() *transactiondomain.Transaction {
+			return expense("other", "56", "Electricity", "2026-04-01")
+		}, false},
+		{"outside the date window", func() *transactiondomain.Transaction {
+			return expense("other", "54.50", "Electricity", "2026-04-05")
+		}, false},
+		{"different description", func() *transactiondomain.Transaction {
+			return expense("other", "54.50", "Groceries", "2026-04-01")
+		}, false},
+		{"other wallet", func() *transactiondomain.Transaction {
+			other := expense("other", "54.50", "Electricity", "2026-04-01")
+			other.WalletID = "wallet-2"
+			return other
+		}, false},
+		{"income", func() *transactiondomain.Transaction {
+			other := expense("other", "54.50", "Electricity", "2026-04-01")
+			other.Type = transactiondomain.TransactionTypeIncome
+			return other
+		}, false},
+		{"transfer", func() *transactiondomain.Transaction {
+			other := expense("other", "54.50", "Electricity", "2026-04-01")
+			other.Type, other.TransferID = transactiondomain.TransactionTypeTransfer, "transfer-1"
+			return other
+		}, false},
+		{"itself", func() *transactiondomain.Transaction {
+			return manual
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, ok := criteria.Compare(manual, tt.other())
+			if ok != tt.want {
+				t.Fatalf("expected %v, got %v (%+v)", tt.want, ok, match)
+			}
+			if ok && (match.Score <= 0 || match.Score > 1) {
+				t.Errorf("expected a score from 0 to 1, got %v", match.Score)
+			}
+		})
+	}
+}
+
+func TestCriteria_Compare_StatementLines(t *testing.T) {
+	criteria := DefaultCriteria()
+
+	first := expense("first", "3.20", "Coffee", "2026-04-01")
+	second := expense("second", "3.20", "Coffee", "2026-04-01")
+	first.ImportBatchID, second.ImportBatchID = "batch-1", "batch-1"
+
+	if _, ok := criteria.Compare(first, second); ok {
+		t.Error("expected two lines of the same statement not to be duplicates")
+	}
+
+	second.ImportBatchID = "batch-2"
+	first.ExternalID, second.ExternalID = "REF-1", "REF-2"
+	if _, ok := criteria.Compare(first, second); ok {
+		t.Error("expected transactions with different bank ids not to be duplicates")
+	}
+
+	second.ExternalID = ""
+	if _, ok := criteria.Compare(first, second); !ok {
+		t.Error("expected overlapping statements to make duplicates")
+	}
+}
+
+func TestCriteria_FindPairs(t *testing.T) {
+	criteria := DefaultCriteria()
+
+	exact := expense("a", "54.50", "Electricity", "2026-04-01")
+	exactCopy := expense("b", "54.50", "Electricity", "2026-04-01")
+	later := expense("c", "54.50", "Electricity April", "2026-04-03")
+	rent := expense("d", "800", "Rent", "2026-04-01")
+	rentCopy := expense("e", "800", "Rent", "2026-04-02")
+
+	pairs := criteria.FindPairs([]*transactiondomain.Transaction{later, rentCopy, exactCopy, rent, exact}, map[string]bool{
+		PairKey("e", "d"): true,
+	})
+
+	if len(pairs) != 3 {
+		t.Fatalf("expected 3 pairs, got %d", len(pairs))
+	}
+	if PairKey(pairs[0].First.ID, pairs[0].Second.ID) != "a/b" {
+		t.Errorf("expected the exact copy first, got %s and %s", pairs[0].First.ID, pairs[0].Second.ID)
+	}
+	for _, pair := range pairs {
+		if pair.First.Date.After(pair.Second.Date) {
+			t.Errorf("expected the older transaction first in %s and %s", pair.First.ID, pair.Second.ID)
+		}
+		if pair.First.ID == "d" || pair.First.ID == "e" {
+			t.Error("expected the dismissed pair to be left out")
+		}
+	}
+}
+
+func TestCriteria_FindDuplicates(t *testing.T) {
+	criteria := DefaultCriteria()
+
+	imported := expense("new", "54.50", "SEPA ELECTRICITY", "2026-04-02")
+	existing := []*transactiondomain.Transaction{
+		expense("far", "54.50", "Electricity", "2026-04-01"),
+		expense("close", "54.50", "Electricity", "2026-04-02"),
+		expense("other", "12", "Lunch", "2026-04-02"),
+	}
+
+	ids := criteria.FindDuplicates(imported, existing)
+	if len(ids) != 2 || ids[0] != "close" || ids[1] != "far" {
+		t.Errorf("expected close and far, best first, got %v", ids)
+	}
+}
+
+func TestCheckPair(t *testing.T) {
+	a := expense("a", "10", "Lunch", "2026-04-01")
+	b := expense("b", "99", "Books", "2026-03-01")
+
+	if err := CheckPair(a, b); err != nil {
+		t.Errorf("expected any two expenses of a wallet to be resolvable, got %v", err)
+	}
+	if err := CheckPair(a, a); err != ErrSameTransaction {
+		t.Errorf("expected ErrSameTransaction, got %v", err)
+	}
+
+	b.WalletID = "wallet-2"
+	if err := CheckPair(a, b); err != ErrNotComparable {
+		t.Errorf("expected ErrNotComparable, got %v", err)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	valueDate, _ := time.Parse("2006-01-02", "2026-03-31")
+
+	kept := expense("manual", "54.50", "Electricity", "2026-04-01")
+	kept.Tags = []string{"home"}
+	duplicate := expense("imported", "54.50", "SEPA ELECTRICITY", "2026-04-02")
+	duplicate.Payee = "Power Co"
+	duplicate.Tags = []string{"Home", "bills"}
+	duplicate.ExternalID = "BANK-REF-1"
+	duplicate.ImportBatchID = "batch-1"
+	duplicate.ValueDate = &valueDate
+
+	Merge(kept, duplicate)
+
+	if kept.Description != "Electricity" || kept.Payee != "Power Co" || kept.ExternalID != "BANK-REF-1" || kept.ValueDate != &valueDate {
+		t.Errorf("unexpected merged transaction %+v", kept)
+	}
+	if len(kept.Tags) != 2 || kept.Tags[0] != "home" || kept.Tags[1] != "bills" {
+		t.Errorf("expected the tags of both, got %v", kept.Tags)
+	}
+	if kept.ImportBatchID != "" {
+		t.Error("expected the import batch not to be taken")
+	}
+}
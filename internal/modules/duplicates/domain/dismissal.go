@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// Dismissal records that the user looked at two transactions taken for
+// duplicates and decided they are not, so they are not suggested again.
+// The ids are stored in order, which makes each pair a single dismissal
+// whichever way round it was given.
+type Dismissal struct {
+	UserID              string
+	FirstTransactionID  string
+	SecondTransactionID string
+	CreatedAt           time.Time
+	CreatedBy           string
+}
+
+func NewDismissal(userID, transactionID, otherID, createdBy string) *Dismissal {
+	if otherID < transactionID {
+		transactionID, otherID = otherID, transactionID
+	}
+	return &Dismissal{
+		UserID:              userID,
+		FirstTransactionID:  transactionID,
+		SecondTransactionID: otherID,
+		CreatedAt:           time.Now(),
+		CreatedBy:           createdBy,
+	}
+}
+
+// Key is the PairKey of the dismissed transactions.
+func (d *Dismissal) Key() string {
+	return PairKey(d.FirstTransactionID, d.SecondTransactionID)
+}
+
+// PairKey identifies the pair of transactions a and b, in either order.
+func PairKey(a, b string) string {
+	if b < a {
+		a, b = b, a
+	}
+	return a + "/" + b
+}
@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+)
+
+var (
+	ErrInvalidAction   = errors.New("action must be merge or dismiss")
+	ErrSameTransaction = errors.New("a transaction cannot be a duplicate of itself")
+	ErrNotComparable   = errors.New("only expenses or income of the same type and wallet can be duplicates")
+)
+
+// Action is how the user resolves a suspected duplicate: by merging the
+// two transactions into one or by dismissing the suspicion.
+type Action string
+
+const (
+	ActionMerge   Action = "merge"
+	ActionDismiss Action = "dismiss"
+)
+
+func IsValidAction(action string) bool {
+	return action == string(ActionMerge) || action == string(ActionDismiss)
+}
+
+// Pair is two transactions likely to be the same one, the older first.
+type Pair struct {
+	First  *transactiondomain.Transaction
+	Second *transactiondomain.Transaction
+	Match  Match
+}
+
+// FindPairs returns the likely duplicates among transactions, best match
+// first, leaving out the pairs whose key is in dismissed.
+func (c Criteria) FindPairs(transactions []*transactiondomain.Transaction, dismissed map[string]bool) []Pair {
+	sorted := make([]*transactiondomain.Transaction, 0, len(transactions))
+	for _, transaction := range transactions {
+		if Eligible(transaction) {
+			sorted = append(sorted, transaction)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+
+	pairs := make([]Pair, 0)
+	for i, first := range sorted {
+		for _, second := range sorted[i+1:] {
+			if dayDifference(first.Date, second.Date) > c.DateWindowDays {
+				break
+			}
+			if dismissed[PairKey(first.ID, second.ID)] {
+				continue
+			}
+			if match, ok := c.Compare(first, second); ok {
+				pairs = append(pairs, Pair{First: first, Second: second, Match: match})
+			}
+		}
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].Match.Score > pairs[j].Match.Score
+	})
+	return pairs
+}
+
+// FindDuplicates returns the ids of the transactions in existing that
+// transaction is likely a duplicate of, best match first.
+func (c Criteria) FindDuplicates(transaction *transactiondomain.Transaction, existing []*transactiondomain.Transaction) []string {
+	var pairs []Pair
+	for _, other := range existing {
+		if match, ok := c.Compare(transaction, other); ok {
+			pairs = append(pairs, Pair{First: other, Match: match})
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].Match.Score > pairs[j].Match.Score
+	})
+
+	ids := make([]string, len(pairs))
+	for i, pair := range pairs {
+		ids[i] = pair.First.ID
+	}
+	return ids
+}
+
+// Window is the range of dates the duplicates of transactions dated from
+// from to to can have.
+func (c Criteria) Window(from, to time.Time) (time.Time, time.Time) {
+	return from.AddDate(0, 0, -c.DateWindowDays), to.AddDate(0, 0, c.DateWindowDays)
+}
+
+// CheckPair checks that a and b can be resolved as duplicates of each
+// other, whether or not they match the criteria: the user may know better.
+func CheckPair(a, b *transactiondomain.Transaction) error {
+	if a.ID == b.ID {
+		return ErrSameTransaction
+	}
+	if !Eligible(a) || !Eligible(b) || a.WalletID != b.WalletID || a.Type != b.Type {
+		return ErrNotComparable
+	}
+	return nil
+}
+
+// Merge folds duplicate into kept before duplicate is deleted. kept keeps
+// its own amount, date and category and takes from duplicate what it
+// lacks: the description, payee, bank id and value date, and the tags it
+// does not have yet. The import batch is not taken, so undoing the import
+// duplicate came in with leaves kept alone.
+func Merge(kept, duplicate *transactiondomain.Transaction) {
+	if kept.Description == "" {
+		kept.Description = duplicate.Description
+	}
+	if kept.Payee == "" {
+		kept.Payee = duplicate.Payee
+	}
+	if kept.ExternalID == "" {
+		kept.ExternalID = duplicate.ExternalID
+	}
+	if kept.ValueDate == nil {
+		kept.ValueDate = duplicate.ValueDate
+	}
+
+	kept.AddTags(duplicate.Tags...)
+	if len(kept.Tags) > transactiondomain.MaxTags {
+		kept.Tags = kept.Tags[:transactiondomain.MaxTags]
+	}
+}
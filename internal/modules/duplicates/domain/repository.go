@@ -0,0 +1,7 @@
+package domain
+
+type DismissalRepository interface {
+	// Create stores a dismissal; dismissing a pair again changes nothing.
+	Create(dismissal *Dismissal) error
+	List(userID string) ([]*Dismissal, error)
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Similarity rates how alike two descriptions are, from 0 for nothing in
+// common to 1. Case and punctuation are ignored. It is the higher of two
+// measures: the share of the words of the shorter text that the other one
+// has, so that "Mercadona" matches "COMPRA TARJ. MERCADONA VALENCIA", and
+// how many letter pairs the texts share, which tolerates typos. Empty
+// texts are alike to nothing.
+func Similarity(a, b string) float64 {
+	wordsA, wordsB := words(a), words(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0
+	}
+
+	return max(overlap(wordsA, wordsB), dice(strings.Join(wordsA, ""), strings.Join(wordsB, "")))
+}
+
+// words splits text into its distinct lower case words, leaving out those
+// of a single character.
+func words(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	seen := make(map[string]bool, len(fields))
+	var result []string
+	for _, field := range fields {
+		if len([]rune(field)) < 2 || seen[field] {
+			continue
+		}
+		seen[field] = true
+		result = append(result, field)
+	}
+	return result
+}
+
+// overlap is the share of the words of the shorter list found in the
+// other one.
+func overlap(a, b []string) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+
+	inB := make(map[string]bool, len(b))
+	for _, word := range b {
+		inB[word] = true
+	}
+
+	shared := 0
+	for _, word := range a {
+		if inB[word] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a))
+}
+
+// dice is the Sørensen–Dice coefficient of the letter pairs of a and b.
+func dice(a, b string) float64 {
+	pairsA, pairsB := bigrams(a), bigrams(b)
+	total := 0
+	for _, count := range pairsA {
+		total += count
+	}
+	for _, count := range pairsB {
+		total += count
+	}
+	if total == 0 {
+		if a == b {
+			return 1
+		}
+		return 0
+	}
+
+	shared := 0
+	for pair, count := range pairsA {
+		shared += min(count, pairsB[pair])
+	}
+	return 2 * float64(shared) / float64(total)
+}
+
+func bigrams(text string) map[string]int {
+	runes := []rune(text)
+	pairs := make(map[string]int, len(runes))
+	for i := 0; i+1 < len(runes); i++ {
+		pairs[string(runes[i:i+2])]++
+	}
+	return pairs
+}
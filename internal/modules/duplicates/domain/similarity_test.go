@@ -0,0 +1,32 @@
+package domain
+
+import "testing"
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		min  float64
+		max  float64
+	}{
+		{"same text", "Coffee shop", "coffee SHOP", 1, 1},
+		{"words of the shorter text", "Mercadona", "COMPRA TARJ. MERCADONA VALENCIA", 1, 1},
+		{"typo", "Netflix subscription", "Netflx subscripton", 0.8, 0.95},
+		{"unrelated", "Rent", "Electricity bill", 0, 0.2},
+		{"single characters are ignored", "a b c", "a b c", 0, 0},
+		{"empty", "", "Coffee", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			similarity := Similarity(tt.a, tt.b)
+			if similarity < tt.min || similarity > tt.max {
+				t.Errorf("expected a similarity from %.2f to %.2f, got %.2f", tt.min, tt.max, similarity)
+			}
+			if Similarity(tt.b, tt.a) != similarity {
+				t.Error("expected the similarity to be symmetric")
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"fin-flow-api/internal/modules/duplicates/domain"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+func (r *Repository) Create(dismissal *domain.Dismissal) error {
+	query := `
+		INSERT INTO duplicate_dismissals (user_id, first_transaction_id, second_transaction_id, created_at, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, first_transaction_id, second_transaction_id) DO NOTHING
+	`
+
+	_, err := r.pool.Exec(
+		context.Background(),
+		query,
+		dismissal.UserID,
+		dismissal.FirstTransactionID,
+		dismissal.SecondTransactionID,
+		dismissal.CreatedAt,
+		dismissal.CreatedBy,
+	)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" { // foreign_key_violation
+			return fmt.Errorf("transaction not found")
+		}
+		return fmt.Errorf("failed to dismiss duplicate: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) List(userID string) ([]*domain.Dismissal, error) {
+	query := `
+		SELECT user_id, first_transaction_id, second_transaction_id, created_at, created_by
+		FROM duplicate_dismissals
+		WHERE user_id = $1
+	`
+
+	rows, err := r.pool.Query(context.Background(), query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list duplicate dismissals: %w", err)
+	}
+	defer rows.Close()
+
+	var dismissals []*domain.Dismissal
+	for rows.Next() {
+		var dismissal domain.Dismissal
+		if err := rows.Scan(
+			&dismissal.UserID,
+			&dismissal.FirstTransactionID,
+			&dismissal.SecondTransactionID,
+			&dismissal.CreatedAt,
+			&dismissal.CreatedBy,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan duplicate dismissal: %w", err)
+		}
+		dismissals = append(dismissals, &dismissal)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate duplicate dismissals: %w", err)
+	}
+
+	return dismissals, nil
+}
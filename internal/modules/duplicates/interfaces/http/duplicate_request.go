@@ -0,0 +1,10 @@
+package http
+
+// ResolveRequest merges DuplicateID into TransactionID, deleting it, when
+// Action is "merge", or keeps both and stops suggesting them when it is
+// "dismiss".
+type ResolveRequest struct {
+	TransactionID string `json:"transaction_id"`
+	DuplicateID   string `json:"duplicate_id"`
+	Action        string `json:"action"`
+}
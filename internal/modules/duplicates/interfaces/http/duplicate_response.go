@@ -0,0 +1,28 @@
+package http
+
+import shareddomain "fin-flow-api/internal/shared/domain"
+
+// DuplicatePairResponse is two transactions likely to be the same one, the
+// older first. Similarity and Score go from 0 to 1.
+type DuplicatePairResponse struct {
+	Transaction      DuplicateTransactionResponse `json:"transaction"`
+	Duplicate        DuplicateTransactionResponse `json:"duplicate"`
+	Similarity       float64                      `json:"similarity"`
+	DayDifference    int                          `json:"day_difference"`
+	AmountDifference shareddomain.Amount          `json:"amount_difference"`
+	Score            float64                      `json:"score"`
+}
+
+type DuplicateTransactionResponse struct {
+	ID            string              `json:"id"`
+	WalletID      string              `json:"wallet_id"`
+	CategoryID    string              `json:"category_id,omitempty"`
+	Type          int                 `json:"type"`
+	TypeName      string              `json:"type_name"`
+	Amount        shareddomain.Amount `json:"amount"`
+	Description   string              `json:"description"`
+	Payee         string              `json:"payee,omitempty"`
+	Date          string              `json:"date"`
+	ImportBatchID string              `json:"import_batch_id,omitempty"`
+	ExternalID    string              `json:"external_id,omitempty"`
+}
@@ -0,0 +1,201 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"fin-flow-api/internal/modules/duplicates/application/contracts/commands"
+	"fin-flow-api/internal/modules/duplicates/application/contracts/queries"
+	"fin-flow-api/internal/modules/duplicates/domain"
+	basehandler "fin-flow-api/internal/shared/http"
+)
+
+const dateLayout = "2006-01-02"
+
+type duplicateService interface {
+	List(ctx context.Context, req queries.ListDuplicatesRequest) ([]*queries.DuplicatePairResponse, error)
+	Resolve(ctx context.Context, req commands.ResolveRequest) error
+}
+
+type Handler struct {
+	duplicateService duplicateService
+}
+
+func NewHandler(duplicateService duplicateService) *Handler {
+	return &Handler{
+		duplicateService: duplicateService,
+	}
+}
+
+// ListDuplicates handles GET /duplicates and returns the pairs of
+// transactions that are likely duplicates, best match first.
+func (h *Handler) ListDuplicates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	req, err := parseListDuplicatesRequest(r)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	pairs, err := h.duplicateService.List(r.Context(), req)
+	if err != nil {
+		statusCode, errorMsg := duplicateErrorResponse(err)
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	responses := make([]DuplicatePairResponse, len(pairs))
+	for i, pair := range pairs {
+		responses[i] = toDuplicatePairResponse(pair)
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, responses)
+}
+
+// ResolveDuplicate handles POST /duplicates/resolve and merges or dismisses
+// a suspected duplicate.
+func (h *Handler) ResolveDuplicate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var reqDTO ResolveRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	cmd, err := toResolveCommand(reqDTO)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.duplicateService.Resolve(r.Context(), cmd); err != nil {
+		statusCode, errorMsg := duplicateErrorResponse(err)
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	if cmd.Action == string(domain.ActionMerge) {
+		basehandler.WriteSuccess(w, "Transactions merged successfully")
+		return
+	}
+	basehandler.WriteSuccess(w, "Duplicate dismissed successfully")
+}
+
+func parseListDuplicatesRequest(r *http.Request) (queries.ListDuplicatesRequest, error) {
+	values := r.URL.Query()
+	req := queries.ListDuplicatesRequest{
+		WalletID: values.Get("wallet_id"),
+	}
+
+	if from := values.Get("from"); from != "" {
+		date, err := time.Parse(dateLayout, from)
+		if err != nil {
+			return req, &ValidationError{Field: "from", Message: "From date must use the YYYY-MM-DD format"}
+		}
+		req.From = &date
+	}
+
+	if to := values.Get("to"); to != "" {
+		date, err := time.Parse(dateLayout, to)
+		if err != nil {
+			return req, &ValidationError{Field: "to", Message: "To date must use the YYYY-MM-DD format"}
+		}
+		req.To = &date
+	}
+
+	if req.From != nil && req.To != nil && req.To.Before(*req.From) {
+		return req, &ValidationError{Field: "to", Message: "To date must not be before the from date"}
+	}
+
+	return req, nil
+}
+
+func toResolveCommand(req ResolveRequest) (commands.ResolveRequest, error) {
+	cmd := commands.ResolveRequest{
+		TransactionID: strings.TrimSpace(req.TransactionID),
+		DuplicateID:   strings.TrimSpace(req.DuplicateID),
+		Action:        strings.ToLower(strings.TrimSpace(req.Action)),
+	}
+
+	if cmd.TransactionID == "" {
+		return cmd, &ValidationError{Field: "transaction_id", Message: "Transaction ID is required"}
+	}
+
+	if cmd.DuplicateID == "" {
+		return cmd, &ValidationError{Field: "duplicate_id", Message: "Duplicate ID is required"}
+	}
+
+	if !domain.IsValidAction(cmd.Action) {
+		return cmd, &ValidationError{Field: "action", Message: "Action must be merge or dismiss"}
+	}
+
+	return cmd, nil
+}
+
+func duplicateErrorResponse(err error) (int, string) {
+	errorMsg := err.Error()
+
+	switch {
+	case strings.Contains(errorMsg, "user not authenticated"):
+		return http.StatusUnauthorized, "Authentication required"
+	case strings.Contains(errorMsg, "unauthorized access to transaction"):
+		return http.StatusForbidden, "You do not have permission to access this transaction"
+	case strings.Contains(errorMsg, "transaction not found"):
+		return http.StatusNotFound, "Transaction not found"
+	case strings.Contains(errorMsg, "reconciled transactions cannot be deleted"):
+		return http.StatusConflict, "The duplicate is reconciled and cannot be deleted, keep it instead"
+	case strings.Contains(errorMsg, "action must be"),
+		strings.Contains(errorMsg, "cannot be a duplicate of itself"),
+		strings.Contains(errorMsg, "can be duplicates"):
+		return http.StatusBadRequest, errorMsg
+	}
+
+	return http.StatusInternalServerError, errorMsg
+}
+
+func toDuplicatePairResponse(pair *queries.DuplicatePairResponse) DuplicatePairResponse {
+	return DuplicatePairResponse{
+		Transaction:      toDuplicateTransactionResponse(pair.Transaction),
+		Duplicate:        toDuplicateTransactionResponse(pair.Duplicate),
+		Similarity:       pair.Similarity,
+		DayDifference:    pair.DayDifference,
+		AmountDifference: pair.AmountDifference,
+		Score:            pair.Score,
+	}
+}
+
+func toDuplicateTransactionResponse(transaction *queries.DuplicateTransactionResponse) DuplicateTransactionResponse {
+	return DuplicateTransactionResponse{
+		ID:            transaction.ID,
+		WalletID:      transaction.WalletID,
+		CategoryID:    transaction.CategoryID,
+		Type:          transaction.Type,
+		TypeName:      transaction.TypeName,
+		Amount:        transaction.Amount,
+		Description:   transaction.Description,
+		Payee:         transaction.Payee,
+		Date:          transaction.Date.Format(dateLayout),
+		ImportBatchID: transaction.ImportBatchID,
+		ExternalID:    transaction.ExternalID,
+	}
+}
+
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
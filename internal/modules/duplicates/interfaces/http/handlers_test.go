@@ -0,0 +1,226 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"fin-flow-api/internal/modules/duplicates/application/contracts/commands"
+	"fin-flow-api/internal/modules/duplicates/application/contracts/queries"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+type mockDuplicateService struct {
+	listErr     error
+	resolveErr  error
+	pairs       []*queries.DuplicatePairResponse
+	lastList    queries.ListDuplicatesRequest
+	lastCommand commands.ResolveRequest
+}
+
+func (m *mockDuplicateService) List(ctx context.Context, req queries.ListDuplicatesRequest) ([]*queries.DuplicatePairResponse, error) {
+	m.lastList = req
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	return m.pairs, nil
+}
+
+func (m *mockDuplicateService) Resolve(ctx context.Context, req commands.ResolveRequest) error {
+	m.lastCommand = req
+	return m.resolveErr
+}
+
+func createContextWithUserID(userID string) context.Context {
+	return context.WithValue(context.Background(), middleware.UserIDKey, userID)
+}
+
+func sampleTransaction(id string, day int) *queries.DuplicateTransactionResponse {
+	return &queries.DuplicateTransactionResponse{
+		ID:          id,
+		WalletID:    "wallet1",
+		CategoryID:  "cat-home",
+		Type:        2,
+		TypeName:    "Expense",
+		Amount:      shareddomain.MustParseAmount("54.5"),
+		Description: "Electricity",
+		Date:        time.Date(2026, 4, day, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestListDuplicates_Success(t *testing.T) {
+	service := &mockDuplicateService{pairs: []*queries.DuplicatePairResponse{{
+		Transaction:   sampleTransaction("manual", 1),
+		Duplicate:     sampleTransaction("imported", 2),
+		Similarity:    1,
+		DayDifference: 1,
+		Score:         0.92,
+	}}}
+	handler := &Handler{duplicateService: service}
+
+	req := httptest.NewRequest("GET", "/duplicates?wallet_id=wallet1&from=2026-04-01&to=2026-04-30", nil)
+	req = req.WithContext(createContextWithUserID("user1"))
+	rr := httptest.NewRecorder()
+
+	handler.ListDuplicates(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	got := service.lastList
+	if got.WalletID != "wallet1" || got.From == nil || got.From.Day() != 1 || got.To == nil || got.To.Day() != 30 {
+		t.Errorf("unexpected request %+v", got)
+	}
+
+	var response []DuplicatePairResponse
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response) != 1 || response[0].Transaction.ID != "manual" || response[0].Duplicate.Date != "2026-04-02" || response[0].Score != 0.92 {
+		t.Errorf("unexpected response %+v", response)
+	}
+}
+
+func TestListDuplicates_InvalidDates(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"bad from", "from=01/04/2026"},
+		{"bad to", "to=2026-13-01"},
+		{"to before from", "from=2026-04-30&to=2026-04-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &Handler{duplicateService: &mockDuplicateService{}}
+
+			req := httptest.NewRequest("GET", "/duplicates?"+tt.query, nil)
+			rr := httptest.NewRecorder()
+
+			handler.ListDuplicates(rr, req)
+
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rr.Code)
+			}
+		})
+	}
+}
+
+func TestListDuplicates_MethodNotAllowed(t *testing.T) {
+	handler := &Handler{duplicateService: &mockDuplicateService{}}
+
+	req := httptest.NewRequest("POST", "/duplicates", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ListDuplicates(rr, req)
+
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status 405, got %d", rr.Code)
+	}
+}
+
+func TestResolveDuplicate_Success(t *testing.T) {
+	tests := []struct {
+		action  string
+		message string
+	}{
+		{" Merge ", "Transactions merged successfully"},
+		{"dismiss", "Duplicate dismissed successfully"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.action, func(t *testing.T) {
+			service := &mockDuplicateService{}
+			handler := &Handler{duplicateService: service}
+
+			jsonBody, _ := json.Marshal(ResolveRequest{TransactionID: "manual", DuplicateID: "imported", Action: tt.action})
+			req := httptest.NewRequest("POST", "/duplicates/resolve", bytes.NewBuffer(jsonBody))
+			req = req.WithContext(createContextWithUserID("user1"))
+			rr := httptest.NewRecorder()
+
+			handler.ResolveDuplicate(rr, req)
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+			}
+			if !bytes.Contains(rr.Body.Bytes(), []byte(tt.message)) {
+				t.Errorf("expected %q, got %s", tt.message, rr.Body.String())
+			}
+			if service.lastCommand.TransactionID != "manual" || service.lastCommand.DuplicateID != "imported" {
+				t.Errorf("unexpected command %+v", service.lastCommand)
+			}
+		})
+	}
+}
+
+func TestResolveDuplicate_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		body ResolveRequest
+	}{
+		{"missing transaction", ResolveRequest{DuplicateID: "imported", Action: "merge"}},
+		{"missing duplicate", ResolveRequest{TransactionID: "manual", Action: "merge"}},
+		{"invalid action", ResolveRequest{TransactionID: "manual", DuplicateID: "imported", Action: "keep"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := toResolveCommand(tt.body); err == nil {
+				t.Error("expected validation error, got nil")
+			}
+		})
+	}
+}
+
+func TestResolveDuplicate_InvalidJSON(t *testing.T) {
+	handler := &Handler{duplicateService: &mockDuplicateService{}}
+
+	req := httptest.NewRequest("POST", "/duplicates/resolve", bytes.NewBufferString("{"))
+	rr := httptest.NewRecorder()
+
+	handler.ResolveDuplicate(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rr.Code)
+	}
+}
+
+func TestResolveDuplicate_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{"unauthenticated", errors.New("user not authenticated"), http.StatusUnauthorized},
+		{"forbidden", errors.New("unauthorized access to transaction"), http.StatusForbidden},
+		{"not found", errors.New("transaction not found"), http.StatusNotFound},
+		{"same transaction", errors.New("a transaction cannot be a duplicate of itself"), http.StatusBadRequest},
+		{"reconciled", errors.New("reconciled transactions cannot be deleted"), http.StatusConflict},
+		{"not comparable", errors.New("only expenses or income of the same type and wallet can be duplicates"), http.StatusBadRequest},
+		{"database", errors.New("failed to merge transactions: boom"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &Handler{duplicateService: &mockDuplicateService{resolveErr: tt.err}}
+
+			jsonBody, _ := json.Marshal(ResolveRequest{TransactionID: "manual", DuplicateID: "imported", Action: "merge"})
+			req := httptest.NewRequest("POST", "/duplicates/resolve", bytes.NewBuffer(jsonBody))
+			rr := httptest.NewRecorder()
+
+			handler.ResolveDuplicate(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package http
+
+import (
+	"net/http"
+
+	"fin-flow-api/internal/shared/interface/jwt"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+var duplicateHandler *Handler
+
+func SetupRoutes(mux *http.ServeMux, jwtService jwt.Service) {
+	mountDuplicates(mux, jwtService)
+}
+
+func mountDuplicates(mux *http.ServeMux, jwtService jwt.Service) {
+	mux.Handle("/duplicates", middleware.RequireAuth(jwtService)(http.HandlerFunc(duplicateHandler.ListDuplicates)))
+	mux.Handle("/duplicates/resolve", middleware.RequireAuth(jwtService)(http.HandlerFunc(duplicateHandler.ResolveDuplicate)))
+}
+
+func SetHandler(handler *Handler) {
+	duplicateHandler = handler
+}
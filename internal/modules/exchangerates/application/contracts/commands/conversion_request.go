@@ -0,0 +1,15 @@
+package commands
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+type ConversionRequest struct {
+	Amount domain.Amount
+	From   string
+	To     string
+	Series string
+	Date   time.Time
+}
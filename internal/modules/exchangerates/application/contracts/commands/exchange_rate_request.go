@@ -0,0 +1,15 @@
+package commands
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+type ExchangeRateRequest struct {
+	Base   string
+	Quote  string
+	Series string
+	Date   time.Time
+	Rate   domain.Amount
+}
@@ -0,0 +1,18 @@
+package queries
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+type ConversionResponse struct {
+	Amount    domain.Amount
+	From      string
+	To        string
+	Converted domain.Amount
+	Rate      domain.Amount
+	AsOf      time.Time
+	Via       string
+	Series    string
+}
@@ -0,0 +1,21 @@
+package queries
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+type ExchangeRateResponse struct {
+	ID        string
+	Base      string
+	Quote     string
+	Series    string
+	Date      time.Time
+	Rate      domain.Amount
+	Source    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	CreatedBy string
+	UpdatedBy string
+}
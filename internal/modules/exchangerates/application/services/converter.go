@@ -0,0 +1,131 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"fin-flow-api/internal/modules/exchangerates/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+var one = shareddomain.NewAmountFromInt(1)
+
+// Converter turns amounts from one currency into another with the rates of
+// a RateProvider. A pair is looked up directly, then inverted, and when
+// neither is stored it is triangulated through the base currency.
+type Converter struct {
+	provider domain.RateProvider
+	base     walletdomain.Currency
+}
+
+func NewConverter(provider domain.RateProvider, base walletdomain.Currency) *Converter {
+	return &Converter{
+		provider: provider,
+		base:     base,
+	}
+}
+
+// Base returns the currency rates are triangulated through.
+func (c *Converter) Base() walletdomain.Currency {
+	return c.base
+}
+
+// Rate returns the rate from one currency into another on date, quoted in
+// series. A pair or leg that is not quoted in series uses the default
+// series instead, and the returned rate says which one was used.
+func (c *Converter) Rate(from, to walletdomain.Currency, series walletdomain.RateSeries, date time.Time) (*domain.CrossRate, error) {
+	if !walletdomain.IsValidCurrency(string(from)) || !walletdomain.IsValidCurrency(string(to)) {
+		return nil, walletdomain.ErrInvalidCurrency
+	}
+	if series == "" {
+		series = walletdomain.DefaultRateSeries
+	}
+	if !walletdomain.IsValidRateSeries(string(series)) {
+		return nil, walletdomain.ErrInvalidRateSeries
+	}
+
+	date = domain.DayOf(date)
+	if from == to {
+		return &domain.CrossRate{From: from, To: to, Rate: one, AsOf: date}, nil
+	}
+
+	rate, err := c.seriesRate(from, to, series, date)
+	if err == nil || !errors.Is(err, domain.ErrRateNotFound) {
+		return rate, err
+	}
+
+	if from == c.base || to == c.base {
+		return nil, domain.ErrRateNotFound
+	}
+
+	toBase, err := c.seriesRate(from, c.base, series, date)
+	if err != nil {
+		return nil, err
+	}
+	fromBase, err := c.seriesRate(c.base, to, series, date)
+	if err != nil {
+		return nil, err
+	}
+
+	asOf := toBase.AsOf
+	if fromBase.AsOf.Before(asOf) {
+		asOf = fromBase.AsOf
+	}
+
+	used := walletdomain.DefaultRateSeries
+	if toBase.Series == series || fromBase.Series == series {
+		used = series
+	}
+
+	return &domain.CrossRate{
+		From:   from,
+		To:     to,
+		Rate:   toBase.Rate.Mul(fromBase.Rate).Round(domain.RateScale),
+		AsOf:   asOf,
+		Via:    c.base,
+		Series: used,
+	}, nil
+}
+
+// Convert converts amount on date, rounding to the target currency.
+func (c *Converter) Convert(amount shareddomain.Amount, from, to walletdomain.Currency, series walletdomain.RateSeries, date time.Time) (shareddomain.Amount, *domain.CrossRate, error) {
+	rate, err := c.Rate(from, to, series, date)
+	if err != nil {
+		return shareddomain.Amount{}, nil, err
+	}
+	return rate.Convert(amount), rate, nil
+}
+
+// seriesRate looks the pair up in series and falls back to the default
+// series, which is how pairs quoted only once are stored.
+func (c *Converter) seriesRate(from, to walletdomain.Currency, series walletdomain.RateSeries, date time.Time) (*domain.CrossRate, error) {
+	rate, err := c.pairRate(from, to, series, date)
+	if series == walletdomain.DefaultRateSeries || !errors.Is(err, domain.ErrRateNotFound) {
+		return rate, err
+	}
+	return c.pairRate(from, to, walletdomain.DefaultRateSeries, date)
+}
+
+// pairRate uses the stored pair or its inverse. When both are stored the
+// more recent one wins, so a pair that is only maintained in one direction
+// is not shadowed by a stale rate in the other.
+func (c *Converter) pairRate(from, to walletdomain.Currency, series walletdomain.RateSeries, date time.Time) (*domain.CrossRate, error) {
+	direct, err := c.provider.Rate(from, to, series, date)
+	if err != nil && !errors.Is(err, domain.ErrRateNotFound) {
+		return nil, err
+	}
+	inverse, err := c.provider.Rate(to, from, series, date)
+	if err != nil && !errors.Is(err, domain.ErrRateNotFound) {
+		return nil, err
+	}
+
+	switch {
+	case direct != nil && (inverse == nil || !inverse.Date.After(direct.Date)):
+		return &domain.CrossRate{From: from, To: to, Rate: direct.Rate, AsOf: direct.Date, Series: series}, nil
+	case inverse != nil:
+		return &domain.CrossRate{From: from, To: to, Rate: one.DivRound(inverse.Rate, domain.RateScale), AsOf: inverse.Date, Series: series}, nil
+	}
+
+	return nil, domain.ErrRateNotFound
+}
@@ -0,0 +1,289 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"fin-flow-api/internal/modules/exchangerates/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+type mockExchangeRateRepository struct {
+	rates    []*domain.ExchangeRate
+	upserted []*domain.ExchangeRate
+	rateErr  error
+}
+
+func (m *mockExchangeRateRepository) Rate(base, quote walletdomain.Currency, series walletdomain.RateSeries, date time.Time) (*domain.ExchangeRate, error) {
+	if m.rateErr != nil {
+		return nil, m.rateErr
+	}
+
+	var latest *domain.ExchangeRate
+	for _, rate := range m.rates {
+		if rate.Base != base || rate.Quote != quote || rate.Series != series || rate.Date.After(date) {
+			continue
+		}
+		if latest == nil || rate.Date.After(latest.Date) {
+			latest = rate
+		}
+	}
+	if latest == nil {
+		return nil, domain.ErrRateNotFound
+	}
+	return latest, nil
+}
+
+func (m *mockExchangeRateRepository) Upsert(rates []*domain.ExchangeRate) error {
+	m.upserted = append(m.upserted, rates...)
+	return nil
+}
+
+func (m *mockExchangeRateRepository) List(base, quote walletdomain.Currency, series walletdomain.RateSeries, from, to time.Time) ([]*domain.ExchangeRate, error) {
+	var result []*domain.ExchangeRate
+	for _, rate := range m.rates {
+		if rate.Base == base && rate.Quote == quote && rate.Series == series {
+			result = append(result, rate)
+		}
+	}
+	return result, nil
+}
+
+func day(year int, m time.Month, d int) time.Time {
+	return time.Date(year, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func storedRate(base, quote walletdomain.Currency, date time.Time, rate string) *domain.ExchangeRate {
+	return storedSeriesRate(base, quote, walletdomain.RateSeriesOfficial, date, rate)
+}
+
+func storedSeriesRate(base, quote walletdomain.Currency, series walletdomain.RateSeries, date time.Time, rate string) *domain.ExchangeRate {
+	return domain.NewExchangeRate("rate-"+string(base)+string(quote)+string(series)+date.Format(dateLayout), base, quote, series, date, shareddomain.MustParseAmount(rate), domain.SourceManual, "system")
+}
+
+func newTestConverter(rates ...*domain.ExchangeRate) *Converter {
+	return NewConverter(&mockExchangeRateRepository{rates: rates}, walletdomain.CurrencyUSD)
+}
+
+func TestConverter_SameCurrency(t *testing.T) {
+	converter := newTestConverter()
+
+	converted, rate, err := converter.Convert(shareddomain.MustParseAmount("12.34"), walletdomain.CurrencyEUR, walletdomain.CurrencyEUR, walletdomain.RateSeriesOfficial, day(2026, 1, 5))
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if converted.String() != "12.34" || rate.Rate.String() != "1" {
+		t.Errorf("expected 12.34 at rate 1, got %s at rate %s", converted, rate.Rate)
+	}
+}
+
+func TestConverter_UsesLatestRateOnOrBeforeDate(t *testing.T) {
+	converter := newTestConverter(
+		storedRate(walletdomain.CurrencyUSD, walletdomain.CurrencyEUR, day(2026, 1, 2), "0.90"),
+		storedRate(walletdomain.CurrencyUSD, walletdomain.CurrencyEUR, day(2026, 1, 9), "0.95"),
+	)
+
+	converted, rate, err := converter.Convert(shareddomain.MustParseAmount("100"), walletdomain.CurrencyUSD, walletdomain.CurrencyEUR, walletdomain.RateSeriesOfficial, time.Date(2026, 1, 8, 18, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if converted.String() != "90" {
+		t.Errorf("expected 90, got %s", converted)
+	}
+	if !rate.AsOf.Equal(day(2026, 1, 2)) {
+		t.Errorf("expected rate as of 2026-01-02, got %s", rate.AsOf)
+	}
+	if rate.Via != "" {
+		t.Errorf("expected a direct rate, got one via %s", rate.Via)
+	}
+}
+
+func TestConverter_InvertsStoredPair(t *testing.T) {
+	converter := newTestConverter(storedRate(walletdomain.CurrencyUSD, walletdomain.CurrencyARS, day(2026, 1, 2), "1000"))
+
+	converted, rate, err := converter.Convert(shareddomain.MustParseAmount("2500"), walletdomain.CurrencyARS, walletdomain.CurrencyUSD, walletdomain.RateSeriesOfficial, day(2026, 1, 5))
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if rate.Rate.String() != "0.001" {
+		t.Errorf("expected rate 0.001, got %s", rate.Rate)
+	}
+	if converted.String() != "2.5" {
+		t.Errorf("expected 2.5, got %s", converted)
+	}
+}
+
+func TestConverter_MoreRecentDirectionWins(t *testing.T) {
+	converter := newTestConverter(
+		storedRate(walletdomain.CurrencyUSD, walletdomain.CurrencyEUR, day(2026, 1, 2), "0.50"),
+		storedRate(walletdomain.CurrencyEUR, walletdomain.CurrencyUSD, day(2026, 1, 4), "1.25"),
+	)
+
+	rate, err := converter.Rate(walletdomain.CurrencyUSD, walletdomain.CurrencyEUR, walletdomain.RateSeriesOfficial, day(2026, 1, 5))
+	if err != nil {
+		t.Fatalf("Rate failed: %v", err)
+	}
+	if rate.Rate.String() != "0.8" {
+		t.Errorf("expected the inverse of the newer EUR/USD rate, got %s", rate.Rate)
+	}
+
+	rate, err = converter.Rate(walletdomain.CurrencyUSD, walletdomain.CurrencyEUR, walletdomain.RateSeriesOfficial, day(2026, 1, 3))
+	if err != nil {
+		t.Fatalf("Rate failed: %v", err)
+	}
+	if rate.Rate.String() != "0.5" {
+		t.Errorf("expected the direct rate before EUR/USD was recorded, got %s", rate.Rate)
+	}
+}
+
+func TestConverter_TriangulatesThroughBase(t *testing.T) {
+	converter := newTestConverter(
+		storedRate(walletdomain.CurrencyUSD, walletdomain.CurrencyEUR, day(2026, 1, 3), "0.80"),
+		storedRate(walletdomain.CurrencyUSD, walletdomain.CurrencyBRL, day(2026, 1, 2), "5"),
+	)
+
+	converted, rate, err := converter.Convert(shareddomain.MustParseAmount("10"), walletdomain.CurrencyEUR, walletdomain.CurrencyBRL, walletdomain.RateSeriesOfficial, day(2026, 1, 5))
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if rate.Rate.String() != "6.25" {
+		t.Errorf("expected rate 6.25, got %s", rate.Rate)
+	}
+	if converted.String() != "62.5" {
+		t.Errorf("expected 62.5, got %s", converted)
+	}
+	if rate.Via != walletdomain.CurrencyUSD {
+		t.Errorf("expected triangulation via USD, got %q", rate.Via)
+	}
+	if !rate.AsOf.Equal(day(2026, 1, 2)) {
+		t.Errorf("expected the older leg's date 2026-01-02, got %s", rate.AsOf)
+	}
+}
+
+func TestConverter_UsesRequestedSeries(t *testing.T) {
+	converter := newTestConverter(
+		storedRate(walletdomain.CurrencyUSD, walletdomain.CurrencyARS, day(2026, 1, 2), "1000"),
+		storedSeriesRate(walletdomain.CurrencyUSD, walletdomain.CurrencyARS, walletdomain.RateSeriesBlue, day(2026, 1, 2), "1250"),
+	)
+
+	converted, rate, err := converter.Convert(shareddomain.MustParseAmount("100"), walletdomain.CurrencyUSD, walletdomain.CurrencyARS, walletdomain.RateSeriesBlue, day(2026, 1, 5))
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if converted.String() != "125000" {
+		t.Errorf("expected 125000, got %s", converted)
+	}
+	if rate.Series != walletdomain.RateSeriesBlue {
+		t.Errorf("expected series blue, got %s", rate.Series)
+	}
+
+	converted, rate, err = converter.Convert(shareddomain.MustParseAmount("100"), walletdomain.CurrencyUSD, walletdomain.CurrencyARS, "", day(2026, 1, 5))
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if converted.String() != "100000" || rate.Series != walletdomain.RateSeriesOfficial {
+		t.Errorf("expected 100000 at the official rate, got %s at the %s rate", converted, rate.Series)
+	}
+}
+
+func TestConverter_FallsBackToDefaultSeries(t *testing.T) {
+	converter := newTestConverter(storedRate(walletdomain.CurrencyUSD, walletdomain.CurrencyEUR, day(2026, 1, 2), "0.80"))
+
+	rate, err := converter.Rate(walletdomain.CurrencyUSD, walletdomain.CurrencyEUR, walletdomain.RateSeriesMEP, day(2026, 1, 5))
+	if err != nil {
+		t.Fatalf("Rate failed: %v", err)
+	}
+	if rate.Rate.String() != "0.8" || rate.Series != walletdomain.RateSeriesOfficial {
+		t.Errorf("expected the official 0.8 rate, got %s from the %s series", rate.Rate, rate.Series)
+	}
+}
+
+func TestConverter_TriangulationReportsRequestedSeries(t *testing.T) {
+	converter := newTestConverter(
+		storedRate(walletdomain.CurrencyUSD, walletdomain.CurrencyARS, day(2026, 1, 2), "1000"),
+		storedSeriesRate(walletdomain.CurrencyUSD, walletdomain.CurrencyARS, walletdomain.RateSeriesBlue, day(2026, 1, 2), "1250"),
+		storedRate(walletdomain.CurrencyUSD, walletdomain.CurrencyEUR, day(2026, 1, 2), "0.80"),
+	)
+
+	converted, rate, err := converter.Convert(shareddomain.MustParseAmount("125000"), walletdomain.CurrencyARS, walletdomain.CurrencyEUR, walletdomain.RateSeriesBlue, day(2026, 1, 5))
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if converted.String() != "80" {
+		t.Errorf("expected 80, got %s", converted)
+	}
+	if rate.Series != walletdomain.RateSeriesBlue || rate.Via != walletdomain.CurrencyUSD {
+		t.Errorf("expected the blue series via USD, got %s via %s", rate.Series, rate.Via)
+	}
+}
+
+func TestConverter_InvalidSeries(t *testing.T) {
+	converter := newTestConverter()
+
+	if _, err := converter.Rate(walletdomain.CurrencyUSD, walletdomain.CurrencyARS, "tarjeta", day(2026, 1, 5)); !errors.Is(err, walletdomain.ErrInvalidRateSeries) {
+		t.Errorf("expected %v, got %v", walletdomain.ErrInvalidRateSeries, err)
+	}
+}
+
+func TestConverter_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		repo    *mockExchangeRateRepository
+		from    walletdomain.Currency
+		to      walletdomain.Currency
+		wantErr error
+	}{
+		{
+			name:    "invalid currency",
+			repo:    &mockExchangeRateRepository{},
+			from:    "XXX",
+			to:      walletdomain.CurrencyEUR,
+			wantErr: walletdomain.ErrInvalidCurrency,
+		},
+		{
+			name:    "no rate",
+			repo:    &mockExchangeRateRepository{},
+			from:    walletdomain.CurrencyEUR,
+			to:      walletdomain.CurrencyUSD,
+			wantErr: domain.ErrRateNotFound,
+		},
+		{
+			name: "missing triangulation leg",
+			repo: &mockExchangeRateRepository{rates: []*domain.ExchangeRate{
+				storedRate(walletdomain.CurrencyUSD, walletdomain.CurrencyEUR, day(2026, 1, 2), "0.80"),
+			}},
+			from:    walletdomain.CurrencyEUR,
+			to:      walletdomain.CurrencyBRL,
+			wantErr: domain.ErrRateNotFound,
+		},
+		{
+			name: "only later rates",
+			repo: &mockExchangeRateRepository{rates: []*domain.ExchangeRate{
+				storedRate(walletdomain.CurrencyUSD, walletdomain.CurrencyEUR, day(2026, 2, 1), "0.80"),
+			}},
+			from:    walletdomain.CurrencyUSD,
+			to:      walletdomain.CurrencyEUR,
+			wantErr: domain.ErrRateNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converter := NewConverter(tt.repo, walletdomain.CurrencyUSD)
+			if _, err := converter.Rate(tt.from, tt.to, walletdomain.RateSeriesOfficial, day(2026, 1, 5)); !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestConverter_PropagatesProviderErrors(t *testing.T) {
+	failure := errors.New("connection refused")
+	converter := NewConverter(&mockExchangeRateRepository{rateErr: failure}, walletdomain.CurrencyUSD)
+
+	if _, err := converter.Rate(walletdomain.CurrencyUSD, walletdomain.CurrencyEUR, walletdomain.RateSeriesOfficial, day(2026, 1, 5)); !errors.Is(err, failure) {
+		t.Errorf("expected %v, got %v", failure, err)
+	}
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"fin-flow-api/internal/modules/exchangerates/application/contracts/commands"
+	"fin-flow-api/internal/modules/exchangerates/application/contracts/queries"
+	"fin-flow-api/internal/modules/exchangerates/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	"fin-flow-api/internal/shared/middleware"
+
+	"github.com/google/uuid"
+)
+
+type ExchangeRateService struct {
+	repository domain.ExchangeRateRepository
+	converter  *Converter
+	systemUser string
+}
+
+func NewExchangeRateService(repository domain.ExchangeRateRepository, converter *Converter, systemUser string) *ExchangeRateService {
+	return &ExchangeRateService{
+		repository: repository,
+		converter:  converter,
+		systemUser: systemUser,
+	}
+}
+
+func (s *ExchangeRateService) getUserIDFromContext(ctx context.Context) (string, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return "", errors.New("user not authenticated")
+	}
+	return userID, nil
+}
+
+// Upsert stores the rate of a pair's series for a day, replacing any rate
+// already stored for that day. The series defaults to the official one.
+func (s *ExchangeRateService) Upsert(ctx context.Context, req commands.ExchangeRateRequest) error {
+	if _, err := s.getUserIDFromContext(ctx); err != nil {
+		return err
+	}
+
+	rate := s.newRate(req, domain.SourceManual)
+	if err := rate.Validate(); err != nil {
+		return err
+	}
+
+	return s.repository.Upsert([]*domain.ExchangeRate{rate})
+}
+
+// Import stores all the rates or none of them. Errors name the CSV line
+// of the offending rate.
+func (s *ExchangeRateService) Import(ctx context.Context, reqs []commands.ExchangeRateRequest) (int, error) {
+	if _, err := s.getUserIDFromContext(ctx); err != nil {
+		return 0, err
+	}
+
+	rates := make([]*domain.ExchangeRate, len(reqs))
+	for i, req := range reqs {
+		rate := s.newRate(req, domain.SourceCSV)
+		if err := rate.Validate(); err != nil {
+			return 0, &ImportError{Line: i + 2, Message: err.Error()}
+		}
+		rates[i] = rate
+	}
+
+	if err := s.repository.Upsert(rates); err != nil {
+		return 0, err
+	}
+
+	return len(rates), nil
+}
+
+func (s *ExchangeRateService) List(ctx context.Context, base, quote, series string, from, to time.Time) ([]*queries.ExchangeRateResponse, error) {
+	if _, err := s.getUserIDFromContext(ctx); err != nil {
+		return nil, err
+	}
+
+	if !walletdomain.IsValidCurrency(base) || !walletdomain.IsValidCurrency(quote) {
+		return nil, walletdomain.ErrInvalidCurrency
+	}
+
+	rateSeries := seriesOrDefault(series)
+	if !walletdomain.IsValidRateSeries(string(rateSeries)) {
+		return nil, walletdomain.ErrInvalidRateSeries
+	}
+
+	rates, err := s.repository.List(walletdomain.Currency(base), walletdomain.Currency(quote), rateSeries, domain.DayOf(from), domain.DayOf(to))
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*queries.ExchangeRateResponse, len(rates))
+	for i, rate := range rates {
+		responses[i] = toExchangeRateResponse(rate)
+	}
+
+	return responses, nil
+}
+
+// Convert converts an amount with the rates of the requested series in
+// effect on the given date.
+func (s *ExchangeRateService) Convert(ctx context.Context, req commands.ConversionRequest) (*queries.ConversionResponse, error) {
+	if _, err := s.getUserIDFromContext(ctx); err != nil {
+		return nil, err
+	}
+
+	converted, rate, err := s.converter.Convert(req.Amount, walletdomain.Currency(req.From), walletdomain.Currency(req.To), seriesOrDefault(req.Series), req.Date)
+	if err != nil {
+		if errors.Is(err, domain.ErrRateNotFound) {
+			return nil, fmt.Errorf("%w for %s/%s on or before %s", err, req.From, req.To, req.Date.Format(dateLayout))
+		}
+		return nil, err
+	}
+
+	return &queries.ConversionResponse{
+		Amount:    req.Amount,
+		From:      string(rate.From),
+		To:        string(rate.To),
+		Converted: converted,
+		Rate:      rate.Rate,
+		AsOf:      rate.AsOf,
+		Via:       string(rate.Via),
+		Series:    string(rate.Series),
+	}, nil
+}
+
+func (s *ExchangeRateService) newRate(req commands.ExchangeRateRequest, source string) *domain.ExchangeRate {
+	return domain.NewExchangeRate(
+		uuid.New().String(),
+		walletdomain.Currency(req.Base),
+		walletdomain.Currency(req.Quote),
+		seriesOrDefault(req.Series),
+		req.Date,
+		req.Rate,
+		source,
+		s.systemUser,
+	)
+}
+
+func seriesOrDefault(series string) walletdomain.RateSeries {
+	if series == "" {
+		return walletdomain.DefaultRateSeries
+	}
+	return walletdomain.RateSeries(series)
+}
+
+func toExchangeRateResponse(rate *domain.ExchangeRate) *queries.ExchangeRateResponse {
+	return &queries.ExchangeRateResponse{
+		ID:        rate.ID,
+		Base:      string(rate.Base),
+		Quote:     string(rate.Quote),
+		Series:    string(rate.Series),
+		Date:      rate.Date,
+		Rate:      rate.Rate,
+		Source:    rate.Source,
+		CreatedAt: rate.CreatedAt,
+		UpdatedAt: rate.ModifiedAt,
+		CreatedBy: rate.CreatedBy,
+		UpdatedBy: rate.ModifiedBy,
+	}
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"fin-flow-api/internal/modules/exchangerates/application/contracts/commands"
+	"fin-flow-api/internal/modules/exchangerates/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+type mockContext struct {
+	context.Context
+	userID string
+	hasID  bool
+}
+
+func (m *mockContext) Value(key interface{}) interface{} {
+	if key == middleware.UserIDKey {
+		if m.hasID {
+			return m.userID
+		}
+		return nil
+	}
+	return nil
+}
+
+func authenticated() context.Context {
+	return &mockContext{userID: "admin-1", hasID: true}
+}
+
+func newTestService(rates ...*domain.ExchangeRate) (*ExchangeRateService, *mockExchangeRateRepository) {
+	repo := &mockExchangeRateRepository{rates: rates}
+	return NewExchangeRateService(repo, NewConverter(repo, walletdomain.CurrencyUSD), "system"), repo
+}
+
+func rateRequest(base, quote, rate string) commands.ExchangeRateRequest {
+	return commands.ExchangeRateRequest{
+		Base:  base,
+		Quote: quote,
+		Date:  day(2026, 1, 5),
+		Rate:  shareddomain.MustParseAmount(rate),
+	}
+}
+
+func TestExchangeRateService_Upsert(t *testing.T) {
+	service, repo := newTestService()
+
+	if err := service.Upsert(authenticated(), rateRequest("USD", "EUR", "0.92")); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+
+	if len(repo.upserted) != 1 {
+		t.Fatalf("expected 1 stored rate, got %d", len(repo.upserted))
+	}
+	if rate := repo.upserted[0]; rate.Source != domain.SourceManual || rate.Series != walletdomain.RateSeriesOfficial || rate.CreatedBy != "system" {
+		t.Errorf("unexpected rate %+v", rate)
+	}
+}
+
+func TestExchangeRateService_Upsert_Errors(t *testing.T) {
+	service, repo := newTestService()
+
+	if err := service.Upsert(&mockContext{}, rateRequest("USD", "EUR", "0.92")); err == nil || err.Error() != "user not authenticated" {
+		t.Errorf("expected user not authenticated, got %v", err)
+	}
+	if err := service.Upsert(authenticated(), rateRequest("USD", "USD", "1")); !errors.Is(err, domain.ErrSameCurrency) {
+		t.Errorf("expected %v, got %v", domain.ErrSameCurrency, err)
+	}
+	req := rateRequest("USD", "ARS", "1250")
+	req.Series = "tarjeta"
+	if err := service.Upsert(authenticated(), req); !errors.Is(err, walletdomain.ErrInvalidRateSeries) {
+		t.Errorf("expected %v, got %v", walletdomain.ErrInvalidRateSeries, err)
+	}
+	if len(repo.upserted) != 0 {
+		t.Errorf("expected nothing stored, got %d rates", len(repo.upserted))
+	}
+}
+
+func TestExchangeRateService_Import(t *testing.T) {
+	service, repo := newTestService()
+
+	imported, err := service.Import(authenticated(), []commands.ExchangeRateRequest{
+		rateRequest("USD", "EUR", "0.92"),
+		rateRequest("USD", "BRL", "5.1"),
+	})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	if imported != 2 || len(repo.upserted) != 2 {
+		t.Fatalf("expected 2 imported rates, got %d (%d stored)", imported, len(repo.upserted))
+	}
+	for _, rate := range repo.upserted {
+		if rate.Source != domain.SourceCSV {
+			t.Errorf("expected source csv, got %s", rate.Source)
+		}
+	}
+}
+
+func TestExchangeRateService_Import_ReportsLineAndStoresNothing(t *testing.T) {
+	service, repo := newTestService()
+
+	_, err := service.Import(authenticated(), []commands.ExchangeRateRequest{
+		rateRequest("USD", "EUR", "0.92"),
+		rateRequest("USD", "BRL", "0"),
+	})
+
+	var importErr *ImportError
+	if !errors.As(err, &importErr) {
+		t.Fatalf("expected an ImportError, got %v", err)
+	}
+	if importErr.Line != 3 {
+		t.Errorf("expected line 3, got %d", importErr.Line)
+	}
+	if len(repo.upserted) != 0 {
+		t.Errorf("expected nothing stored, got %d rates", len(repo.upserted))
+	}
+}
+
+func TestExchangeRateService_List_InvalidCurrency(t *testing.T) {
+	service, _ := newTestService()
+
+	if _, err := service.List(authenticated(), "USD", "XXX", "", day(2026, 1, 1), day(2026, 1, 31)); !errors.Is(err, walletdomain.ErrInvalidCurrency) {
+		t.Errorf("expected %v, got %v", walletdomain.ErrInvalidCurrency, err)
+	}
+}
+
+func TestExchangeRateService_Convert(t *testing.T) {
+	service, _ := newTestService(storedRate(walletdomain.CurrencyUSD, walletdomain.CurrencyEUR, day(2026, 1, 2), "0.9"))
+
+	result, err := service.Convert(authenticated(), commands.ConversionRequest{
+		Amount: shareddomain.MustParseAmount("10"),
+		From:   "USD",
+		To:     "EUR",
+		Date:   day(2026, 1, 5),
+	})
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if result.Converted.String() != "9" || !result.AsOf.Equal(day(2026, 1, 2)) || result.Series != "official" {
+		t.Errorf("unexpected conversion %+v", result)
+	}
+}
+
+func TestExchangeRateService_Convert_NotFoundNamesPair(t *testing.T) {
+	service, _ := newTestService()
+
+	_, err := service.Convert(authenticated(), commands.ConversionRequest{
+		Amount: shareddomain.MustParseAmount("10"),
+		From:   "EUR",
+		To:     "BRL",
+		Date:   day(2026, 1, 5),
+	})
+	if !errors.Is(err, domain.ErrRateNotFound) {
+		t.Fatalf("expected %v, got %v", domain.ErrRateNotFound, err)
+	}
+	if !strings.Contains(err.Error(), "EUR/BRL on or before 2026-01-05") {
+		t.Errorf("expected the pair and date in the error, got %q", err.Error())
+	}
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"fin-flow-api/internal/modules/exchangerates/application/contracts/commands"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+const dateLayout = "2006-01-02"
+
+// MaxImportRows bounds a single CSV import.
+const MaxImportRows = 50000
+
+var rateCSVColumns = []string{"date", "base", "quote", "rate"}
+
+// ImportError reports the first CSV line that could not be read. Line 1 is
+// the header.
+type ImportError struct {
+	Line    int
+	Message string
+}
+
+func (e *ImportError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// ParseRatesCSV reads rates from CSV with a header row naming the columns
+// date (YYYY-MM-DD), base, quote and rate, in any order. An optional series
+// column names the rate series; rows without one use the official series.
+// Other columns are ignored.
+func ParseRatesCSV(r io.Reader) ([]commands.ExchangeRateRequest, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, &ImportError{Line: 1, Message: "file is empty"}
+	}
+	if err != nil {
+		return nil, importReadError(err)
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, column := range rateCSVColumns {
+		if _, ok := index[column]; !ok {
+			return nil, &ImportError{Line: 1, Message: fmt.Sprintf("missing column %q", column)}
+		}
+	}
+
+	var requests []commands.ExchangeRateRequest
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, importReadError(err)
+		}
+		if len(requests) == MaxImportRows {
+			return nil, &ImportError{Line: line, Message: fmt.Sprintf("more than %d rows", MaxImportRows)}
+		}
+
+		field := func(name string) string {
+			return strings.TrimSpace(record[index[name]])
+		}
+
+		date, err := time.Parse(dateLayout, field("date"))
+		if err != nil {
+			return nil, &ImportError{Line: line, Message: "date must use the YYYY-MM-DD format"}
+		}
+
+		rate, err := shareddomain.ParseAmount(field("rate"))
+		if err != nil {
+			return nil, &ImportError{Line: line, Message: "rate is not a number"}
+		}
+
+		var series string
+		if _, ok := index["series"]; ok {
+			series = strings.ToLower(field("series"))
+		}
+
+		requests = append(requests, commands.ExchangeRateRequest{
+			Base:   strings.ToUpper(field("base")),
+			Quote:  strings.ToUpper(field("quote")),
+			Series: series,
+			Date:   date,
+			Rate:   rate,
+		})
+	}
+
+	if len(requests) == 0 {
+		return nil, &ImportError{Line: 2, Message: "no rates found"}
+	}
+
+	return requests, nil
+}
+
+// importReadError reports malformed CSV against its line and passes errors
+// of the underlying reader, such as a size limit, through unchanged.
+func importReadError(err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return &ImportError{Line: parseErr.Line, Message: parseErr.Err.Error()}
+	}
+	return err
+}
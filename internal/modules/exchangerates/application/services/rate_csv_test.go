@@ -0,0 +1,94 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseRatesCSV(t *testing.T) {
+	input := "\ufeffRate, Quote,date,base,note\n0.92,eur,2026-01-05,usd,ecb\n5.1, BRL ,2026-01-06,USD,\n"
+
+	reqs, err := ParseRatesCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseRatesCSV failed: %v", err)
+	}
+
+	if len(reqs) != 2 {
+		t.Fatalf("expected 2 rates, got %d", len(reqs))
+	}
+	if reqs[0].Base != "USD" || reqs[0].Quote != "EUR" || reqs[0].Rate.String() != "0.92" || reqs[0].Date.Format(dateLayout) != "2026-01-05" {
+		t.Errorf("unexpected first rate %+v", reqs[0])
+	}
+	if reqs[1].Quote != "BRL" || reqs[1].Rate.String() != "5.1" {
+		t.Errorf("unexpected second rate %+v", reqs[1])
+	}
+}
+
+func TestParseRatesCSV_SeriesColumn(t *testing.T) {
+	input := "date,base,quote,series,rate\n2026-01-05,USD,ARS,Blue,1250\n2026-01-05,USD,ARS,,1000\n"
+
+	reqs, err := ParseRatesCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseRatesCSV failed: %v", err)
+	}
+
+	if len(reqs) != 2 {
+		t.Fatalf("expected 2 rates, got %d", len(reqs))
+	}
+	if reqs[0].Series != "blue" {
+		t.Errorf("expected series blue, got %q", reqs[0].Series)
+	}
+	if reqs[1].Series != "" {
+		t.Errorf("expected no series, got %q", reqs[1].Series)
+	}
+}
+
+func TestParseRatesCSV_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantLine int
+		wantMsg  string
+	}{
+		{"empty", "", 1, "file is empty"},
+		{"missing column", "date,base,rate\n2026-01-05,USD,0.9\n", 1, `missing column "quote"`},
+		{"no rows", "date,base,quote,rate\n", 2, "no rates found"},
+		{"bad date", "date,base,quote,rate\n2026-01-05,USD,EUR,0.9\n05/01/2026,USD,EUR,0.9\n", 3, "YYYY-MM-DD"},
+		{"bad rate", "date,base,quote,rate\n2026-01-05,USD,EUR,abc\n", 2, "rate is not a number"},
+		{"wrong field count", "date,base,quote,rate\n2026-01-05,USD,EUR\n", 2, "wrong number of fields"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRatesCSV(strings.NewReader(tt.input))
+
+			var importErr *ImportError
+			if !errors.As(err, &importErr) {
+				t.Fatalf("expected an ImportError, got %v", err)
+			}
+			if importErr.Line != tt.wantLine {
+				t.Errorf("expected line %d, got %d", tt.wantLine, importErr.Line)
+			}
+			if !strings.Contains(importErr.Message, tt.wantMsg) {
+				t.Errorf("expected message containing %q, got %q", tt.wantMsg, importErr.Message)
+			}
+		})
+	}
+}
+
+type failingReader struct {
+	err error
+}
+
+func (r failingReader) Read(p []byte) (int, error) {
+	return 0, r.err
+}
+
+func TestParseRatesCSV_PassesReaderErrorsThrough(t *testing.T) {
+	failure := errors.New("request body too large")
+
+	if _, err := ParseRatesCSV(failingReader{err: failure}); !errors.Is(err, failure) {
+		t.Errorf("expected %v, got %v", failure, err)
+	}
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"time"
+
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	"fin-flow-api/internal/shared/domain"
+)
+
+// CrossRate is the rate used to convert From into To. It may come from a
+// stored pair, from its inverse, or from two pairs through Via.
+type CrossRate struct {
+	From walletdomain.Currency
+	To   walletdomain.Currency
+	Rate domain.Amount
+	// AsOf is the date of the oldest stored rate that went into Rate.
+	AsOf time.Time
+	// Via is the currency the rate was triangulated through, or empty.
+	Via walletdomain.Currency
+	// Series is the series the rate was quoted in. It is the default series
+	// when the requested one is not quoted for the pair, and empty when no
+	// stored rate was needed.
+	Series walletdomain.RateSeries
+}
+
+// Convert applies the rate to amount and rounds the result to the scale of
+// the target currency.
+func (c *CrossRate) Convert(amount domain.Amount) domain.Amount {
+	return amount.Mul(c.Rate).RoundToCurrency(string(c.To))
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	"fin-flow-api/internal/shared/domain"
+)
+
+var (
+	ErrRateNotFound   = errors.New("exchange rate not found")
+	ErrInvalidRate    = errors.New("rate must be greater than zero")
+	ErrRatePrecision  = errors.New("rate has more than 18 decimal places")
+	ErrSameCurrency   = errors.New("base and quote currencies must differ")
+	ErrInvalidRateDay = errors.New("rate date is required")
+)
+
+// RateScale is the number of decimal places rates are stored with.
+const RateScale int32 = 18
+
+// Sources a rate can be recorded from.
+const (
+	SourceManual = "manual"
+	SourceCSV    = "csv"
+)
+
+// ExchangeRate is the price of one unit of Base in Quote on Date, as quoted
+// by Series. There is at most one rate per pair, series and day.
+type ExchangeRate struct {
+	domain.Entity
+
+	ID     string
+	Base   walletdomain.Currency
+	Quote  walletdomain.Currency
+	Series walletdomain.RateSeries
+	Date   time.Time
+	Rate   domain.Amount
+	Source string
+}
+
+func NewExchangeRate(id string, base, quote walletdomain.Currency, series walletdomain.RateSeries, date time.Time, rate domain.Amount, source, createdBy string) *ExchangeRate {
+	return &ExchangeRate{
+		Entity: domain.NewEntity(id, createdBy),
+		ID:     id,
+		Base:   base,
+		Quote:  quote,
+		Series: series,
+		Date:   DayOf(date),
+		Rate:   rate,
+		Source: source,
+	}
+}
+
+func (r *ExchangeRate) Validate() error {
+	if !walletdomain.IsValidCurrency(string(r.Base)) || !walletdomain.IsValidCurrency(string(r.Quote)) {
+		return walletdomain.ErrInvalidCurrency
+	}
+	if r.Base == r.Quote {
+		return ErrSameCurrency
+	}
+	if !walletdomain.IsValidRateSeries(string(r.Series)) {
+		return walletdomain.ErrInvalidRateSeries
+	}
+	if r.Date.IsZero() {
+		return ErrInvalidRateDay
+	}
+	if !r.Rate.IsPositive() {
+		return ErrInvalidRate
+	}
+	if !r.Rate.Round(RateScale).Equal(r.Rate) {
+		return ErrRatePrecision
+	}
+	return nil
+}
+
+// DayOf returns the start of the day containing date, in UTC.
+func DayOf(date time.Time) time.Time {
+	if date.IsZero() {
+		return date
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+func TestNewExchangeRate_TruncatesDate(t *testing.T) {
+	rate := NewExchangeRate("rate-1", walletdomain.CurrencyUSD, walletdomain.CurrencyEUR, walletdomain.RateSeriesBlue, time.Date(2026, 3, 17, 23, 30, 0, 0, time.FixedZone("ART", -3*3600)), shareddomain.MustParseAmount("0.92"), SourceManual, "system")
+
+	if !rate.Date.Equal(time.Date(2026, 3, 17, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected date 2026-03-17, got %s", rate.Date)
+	}
+	if rate.Series != walletdomain.RateSeriesBlue {
+		t.Errorf("expected series blue, got %s", rate.Series)
+	}
+	if rate.CreatedBy != "system" {
+		t.Errorf("expected CreatedBy system, got %s", rate.CreatedBy)
+	}
+}
+
+func TestExchangeRate_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(r *ExchangeRate)
+		wantErr error
+	}{
+		{"valid", func(r *ExchangeRate) {}, nil},
+		{"invalid base", func(r *ExchangeRate) { r.Base = "XXX" }, walletdomain.ErrInvalidCurrency},
+		{"invalid quote", func(r *ExchangeRate) { r.Quote = "" }, walletdomain.ErrInvalidCurrency},
+		{"same currency", func(r *ExchangeRate) { r.Quote = walletdomain.CurrencyUSD }, ErrSameCurrency},
+		{"invalid series", func(r *ExchangeRate) { r.Series = "tarjeta" }, walletdomain.ErrInvalidRateSeries},
+		{"missing series", func(r *ExchangeRate) { r.Series = "" }, walletdomain.ErrInvalidRateSeries},
+		{"missing date", func(r *ExchangeRate) { r.Date = time.Time{} }, ErrInvalidRateDay},
+		{"zero rate", func(r *ExchangeRate) { r.Rate = shareddomain.Amount{} }, ErrInvalidRate},
+		{"negative rate", func(r *ExchangeRate) { r.Rate = shareddomain.MustParseAmount("-1") }, ErrInvalidRate},
+		{"precision", func(r *ExchangeRate) { r.Rate = shareddomain.MustParseAmount("0.1234567890123456789") }, ErrRatePrecision},
+		{"max precision", func(r *ExchangeRate) { r.Rate = shareddomain.MustParseAmount("0.123456789012345678") }, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate := NewExchangeRate("rate-1", walletdomain.CurrencyUSD, walletdomain.CurrencyEUR, walletdomain.RateSeriesBlue, time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), shareddomain.MustParseAmount("0.92"), SourceManual, "system")
+			tt.mutate(rate)
+			if err := rate.Validate(); err != tt.wantErr {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCrossRate_ConvertRoundsToTargetCurrency(t *testing.T) {
+	tests := []struct {
+		name     string
+		to       walletdomain.Currency
+		rate     string
+		amount   string
+		expected string
+	}{
+		{"cents", walletdomain.CurrencyEUR, "0.923456", "100", "92.35"},
+		{"no minor unit", walletdomain.CurrencyJPY, "151.678", "10.50", "1593"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate := &CrossRate{From: walletdomain.CurrencyUSD, To: tt.to, Rate: shareddomain.MustParseAmount(tt.rate)}
+			if got := rate.Convert(shareddomain.MustParseAmount(tt.amount)); got.String() != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
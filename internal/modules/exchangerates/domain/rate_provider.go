@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"time"
+
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+)
+
+// RateProvider looks up stored rates. Rate returns the most recent rate of
+// the series for exactly the base/quote pair on or before date, or
+// ErrRateNotFound. It does not invert or combine pairs, nor fall back to
+// another series; that is the converter's job.
+type RateProvider interface {
+	Rate(base, quote walletdomain.Currency, series walletdomain.RateSeries, date time.Time) (*ExchangeRate, error)
+}
@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"time"
+
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+)
+
+type ExchangeRateRepository interface {
+	RateProvider
+	// Upsert stores the rates in one transaction, replacing the rate already
+	// stored for the same pair, series and day.
+	Upsert(rates []*ExchangeRate) error
+	// List returns the rates of a pair's series between from and to
+	// inclusive, oldest first. Zero bounds are open.
+	List(base, quote walletdomain.Currency, series walletdomain.RateSeries, from, to time.Time) ([]*ExchangeRate, error)
+}
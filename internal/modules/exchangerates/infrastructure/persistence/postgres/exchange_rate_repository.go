@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"fin-flow-api/internal/modules/exchangerates/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const exchangeRateColumns = `id, base_currency, quote_currency, series, rate_date, rate, source, created_at, modified_at, created_by, modified_by`
+
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+func (r *Repository) Upsert(rates []*domain.ExchangeRate) error {
+	ctx := context.Background()
+
+	dbTx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to store exchange rates: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	query := `
+		INSERT INTO exchange_rates (` + exchangeRateColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (base_currency, quote_currency, series, rate_date) DO UPDATE
+		SET rate = EXCLUDED.rate, source = EXCLUDED.source, modified_at = EXCLUDED.modified_at, modified_by = EXCLUDED.modified_by
+	`
+
+	batch := &pgx.Batch{}
+	for _, rate := range rates {
+		batch.Queue(
+			query,
+			rate.ID,
+			rate.Base.String(),
+			rate.Quote.String(),
+			rate.Series.String(),
+			rate.Date,
+			rate.Rate,
+			rate.Source,
+			rate.CreatedAt,
+			rate.ModifiedAt,
+			rate.CreatedBy,
+			rate.ModifiedBy,
+		)
+	}
+
+	if err := dbTx.SendBatch(ctx, batch).Close(); err != nil {
+		return mapWriteError("failed to store exchange rates", err)
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to store exchange rates: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) Rate(base, quote walletdomain.Currency, series walletdomain.RateSeries, date time.Time) (*domain.ExchangeRate, error) {
+	query := `
+		SELECT ` + exchangeRateColumns + `
+		FROM exchange_rates
+		WHERE base_currency = $1 AND quote_currency = $2 AND series = $3 AND rate_date <= $4
+		ORDER BY rate_date DESC
+		LIMIT 1
+	`
+
+	rate, err := scanExchangeRate(r.pool.QueryRow(context.Background(), query, base.String(), quote.String(), series.String(), date))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrRateNotFound
+		}
+		return nil, fmt.Errorf("failed to get exchange rate: %w", err)
+	}
+
+	return rate, nil
+}
+
+func (r *Repository) List(base, quote walletdomain.Currency, series walletdomain.RateSeries, from, to time.Time) ([]*domain.ExchangeRate, error) {
+	query := `
+		SELECT ` + exchangeRateColumns + `
+		FROM exchange_rates
+		WHERE base_currency = $1 AND quote_currency = $2 AND series = $3
+			AND ($4::date IS NULL OR rate_date >= $4)
+			AND ($5::date IS NULL OR rate_date <= $5)
+		ORDER BY rate_date ASC
+	`
+
+	rows, err := r.pool.Query(context.Background(), query, base.String(), quote.String(), series.String(), nullableDate(from), nullableDate(to))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list exchange rates: %w", err)
+	}
+	defer rows.Close()
+
+	var rates []*domain.ExchangeRate
+	for rows.Next() {
+		rate, err := scanExchangeRate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan exchange rate: %w", err)
+		}
+		rates = append(rates, rate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate exchange rates: %w", err)
+	}
+
+	return rates, nil
+}
+
+func nullableDate(date time.Time) *time.Time {
+	if date.IsZero() {
+		return nil
+	}
+	return &date
+}
+
+func scanExchangeRate(row pgx.Row) (*domain.ExchangeRate, error) {
+	var rate domain.ExchangeRate
+	var base, quote, series string
+
+	err := row.Scan(
+		&rate.ID,
+		&base,
+		&quote,
+		&series,
+		&rate.Date,
+		&rate.Rate,
+		&rate.Source,
+		&rate.CreatedAt,
+		&rate.ModifiedAt,
+		&rate.CreatedBy,
+		&rate.ModifiedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rate.Base = walletdomain.Currency(base)
+	rate.Quote = walletdomain.Currency(quote)
+	rate.Series = walletdomain.RateSeries(series)
+
+	return &rate, nil
+}
+
+func mapWriteError(message string, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23514": // check_violation
+			if pgErr.ConstraintName == "chk_exchange_rates_distinct_currencies" {
+				return domain.ErrSameCurrency
+			}
+			return domain.ErrInvalidRate
+		}
+	}
+	return fmt.Errorf("%s: %w", message, err)
+}
@@ -0,0 +1,11 @@
+package http
+
+import shareddomain "fin-flow-api/internal/shared/domain"
+
+type ExchangeRateRequest struct {
+	Base   string               `json:"base"`
+	Quote  string               `json:"quote"`
+	Series string               `json:"series"`
+	Date   string               `json:"date"`
+	Rate   *shareddomain.Amount `json:"rate"`
+}
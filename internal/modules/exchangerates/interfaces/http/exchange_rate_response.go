@@ -0,0 +1,36 @@
+package http
+
+import (
+	"time"
+
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+type ExchangeRateResponse struct {
+	ID        string              `json:"id"`
+	Base      string              `json:"base"`
+	Quote     string              `json:"quote"`
+	Series    string              `json:"series"`
+	Date      string              `json:"date"`
+	Rate      shareddomain.Amount `json:"rate"`
+	Source    string              `json:"source"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+	CreatedBy string              `json:"created_by"`
+	UpdatedBy string              `json:"updated_by"`
+}
+
+type ImportResponse struct {
+	Imported int `json:"imported"`
+}
+
+type ConversionResponse struct {
+	Amount    shareddomain.Amount `json:"amount"`
+	From      string              `json:"from"`
+	To        string              `json:"to"`
+	Converted shareddomain.Amount `json:"converted"`
+	Rate      shareddomain.Amount `json:"rate"`
+	AsOf      string              `json:"as_of"`
+	Via       string              `json:"via,omitempty"`
+	Series    string              `json:"series,omitempty"`
+}
@@ -0,0 +1,325 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"fin-flow-api/internal/modules/exchangerates/application/contracts/commands"
+	"fin-flow-api/internal/modules/exchangerates/application/contracts/queries"
+	"fin-flow-api/internal/modules/exchangerates/application/services"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	basehandler "fin-flow-api/internal/shared/http"
+)
+
+const dateLayout = "2006-01-02"
+
+// maxImportBytes bounds the size of an uploaded CSV file.
+const maxImportBytes = 10 << 20
+
+type exchangeRateService interface {
+	Upsert(ctx context.Context, req commands.ExchangeRateRequest) error
+	Import(ctx context.Context, reqs []commands.ExchangeRateRequest) (int, error)
+	List(ctx context.Context, base, quote, series string, from, to time.Time) ([]*queries.ExchangeRateResponse, error)
+	Convert(ctx context.Context, req commands.ConversionRequest) (*queries.ConversionResponse, error)
+}
+
+type Handler struct {
+	exchangeRateService exchangeRateService
+}
+
+func NewHandler(exchangeRateService exchangeRateService) *Handler {
+	return &Handler{
+		exchangeRateService: exchangeRateService,
+	}
+}
+
+// UpsertRate handles PUT /exchange-rates and stores the rate of a pair's
+// series for a day, replacing the one already stored.
+func (h *Handler) UpsertRate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var reqDTO ExchangeRateRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	cmd, err := toExchangeRateCommand(reqDTO)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.exchangeRateService.Upsert(r.Context(), cmd); err != nil {
+		statusCode, errorMsg := exchangeRateErrorResponse(err)
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Exchange rate saved successfully")
+}
+
+// ImportRates handles POST /exchange-rates/import. The CSV is sent either
+// as the request body or as the "file" field of a multipart form.
+func (h *Handler) ImportRates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportBytes)
+
+	body, err := csvBody(r)
+	if err != nil {
+		writeImportReadError(w, err)
+		return
+	}
+	defer body.Close()
+
+	reqs, err := services.ParseRatesCSV(body)
+	if err != nil {
+		writeImportReadError(w, err)
+		return
+	}
+
+	imported, err := h.exchangeRateService.Import(r.Context(), reqs)
+	if err != nil {
+		statusCode, errorMsg := exchangeRateErrorResponse(err)
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, ImportResponse{Imported: imported})
+}
+
+// ListRates handles GET /exchange-rates?base=USD&quote=EUR&series=&from=&to=.
+// The series defaults to the official one.
+func (h *Handler) ListRates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	base := strings.ToUpper(strings.TrimSpace(query.Get("base")))
+	quote := strings.ToUpper(strings.TrimSpace(query.Get("quote")))
+
+	if base == "" || quote == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Both base and quote currencies are required")
+		return
+	}
+
+	from, err := parseOptionalDate(query.Get("from"), "from")
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	to, err := parseOptionalDate(query.Get("to"), "to")
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	series := strings.ToLower(strings.TrimSpace(query.Get("series")))
+
+	rates, err := h.exchangeRateService.List(r.Context(), base, quote, series, from, to)
+	if err != nil {
+		statusCode, errorMsg := exchangeRateErrorResponse(err)
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	responses := make([]ExchangeRateResponse, len(rates))
+	for i, rate := range rates {
+		responses[i] = toExchangeRateResponse(rate)
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, responses)
+}
+
+// Convert handles GET /exchange-rates/convert?amount=&from=&to=&series=&date=.
+// The series defaults to the official one and the date to today.
+func (h *Handler) Convert(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+
+	amount, err := shareddomain.ParseAmount(strings.TrimSpace(query.Get("amount")))
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Amount must be a number")
+		return
+	}
+
+	from := strings.ToUpper(strings.TrimSpace(query.Get("from")))
+	to := strings.ToUpper(strings.TrimSpace(query.Get("to")))
+	if from == "" || to == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Both from and to currencies are required")
+		return
+	}
+
+	date, err := parseOptionalDate(query.Get("date"), "date")
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if date.IsZero() {
+		date = time.Now().UTC()
+	}
+
+	conversion, err := h.exchangeRateService.Convert(r.Context(), commands.ConversionRequest{
+		Amount: amount,
+		From:   from,
+		To:     to,
+		Series: strings.ToLower(strings.TrimSpace(query.Get("series"))),
+		Date:   date,
+	})
+	if err != nil {
+		statusCode, errorMsg := exchangeRateErrorResponse(err)
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, ConversionResponse{
+		Amount:    conversion.Amount,
+		From:      conversion.From,
+		To:        conversion.To,
+		Converted: conversion.Converted,
+		Rate:      conversion.Rate,
+		AsOf:      conversion.AsOf.Format(dateLayout),
+		Via:       conversion.Via,
+		Series:    conversion.Series,
+	})
+}
+
+func csvBody(r *http.Request) (io.ReadCloser, error) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		return r.Body, nil
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, err
+		}
+		return nil, &ValidationError{Field: "file", Message: "A CSV file is required in the \"file\" form field"}
+	}
+	return file, nil
+}
+
+func writeImportReadError(w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		basehandler.WriteError(w, http.StatusRequestEntityTooLarge, "CSV file is too large")
+		return
+	}
+	basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+}
+
+func parseOptionalDate(value, field string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, &ValidationError{Field: field, Message: "Date must use the YYYY-MM-DD format"}
+	}
+
+	return date, nil
+}
+
+func toExchangeRateCommand(req ExchangeRateRequest) (commands.ExchangeRateRequest, error) {
+	if strings.TrimSpace(req.Base) == "" {
+		return commands.ExchangeRateRequest{}, &ValidationError{Field: "base", Message: "Base currency is required"}
+	}
+
+	if strings.TrimSpace(req.Quote) == "" {
+		return commands.ExchangeRateRequest{}, &ValidationError{Field: "quote", Message: "Quote currency is required"}
+	}
+
+	if req.Rate == nil {
+		return commands.ExchangeRateRequest{}, &ValidationError{Field: "rate", Message: "Rate is required"}
+	}
+
+	if req.Date == "" {
+		return commands.ExchangeRateRequest{}, &ValidationError{Field: "date", Message: "Date is required"}
+	}
+
+	date, err := time.Parse(dateLayout, req.Date)
+	if err != nil {
+		return commands.ExchangeRateRequest{}, &ValidationError{Field: "date", Message: "Date must use the YYYY-MM-DD format"}
+	}
+
+	return commands.ExchangeRateRequest{
+		Base:   strings.ToUpper(strings.TrimSpace(req.Base)),
+		Quote:  strings.ToUpper(strings.TrimSpace(req.Quote)),
+		Series: strings.ToLower(strings.TrimSpace(req.Series)),
+		Date:   date,
+		Rate:   *req.Rate,
+	}, nil
+}
+
+func exchangeRateErrorResponse(err error) (int, string) {
+	errorMsg := err.Error()
+
+	var importErr *services.ImportError
+	if errors.As(err, &importErr) {
+		return http.StatusBadRequest, errorMsg
+	}
+
+	switch {
+	case strings.Contains(errorMsg, "user not authenticated"):
+		return http.StatusUnauthorized, "Authentication required"
+	case strings.Contains(errorMsg, "exchange rate not found"):
+		return http.StatusNotFound, errorMsg
+	case strings.Contains(errorMsg, "invalid currency"):
+		return http.StatusBadRequest, "Invalid currency"
+	case strings.Contains(errorMsg, "invalid rate series"):
+		return http.StatusBadRequest, "Invalid rate series"
+	case strings.Contains(errorMsg, "currencies must differ"),
+		strings.Contains(errorMsg, "rate must be greater than zero"),
+		strings.Contains(errorMsg, "decimal places"),
+		strings.Contains(errorMsg, "rate date is required"):
+		return http.StatusBadRequest, errorMsg
+	}
+
+	return http.StatusInternalServerError, errorMsg
+}
+
+func toExchangeRateResponse(rate *queries.ExchangeRateResponse) ExchangeRateResponse {
+	return ExchangeRateResponse{
+		ID:        rate.ID,
+		Base:      rate.Base,
+		Quote:     rate.Quote,
+		Series:    rate.Series,
+		Date:      rate.Date.Format(dateLayout),
+		Rate:      rate.Rate,
+		Source:    rate.Source,
+		CreatedAt: rate.CreatedAt,
+		UpdatedAt: rate.UpdatedAt,
+		CreatedBy: rate.CreatedBy,
+		UpdatedBy: rate.UpdatedBy,
+	}
+}
+
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,390 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"fin-flow-api/internal/modules/exchangerates/application/contracts/commands"
+	"fin-flow-api/internal/modules/exchangerates/application/contracts/queries"
+	"fin-flow-api/internal/modules/exchangerates/application/services"
+	"fin-flow-api/internal/modules/exchangerates/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+type mockExchangeRateService struct {
+	upsertErr      error
+	importErr      error
+	listErr        error
+	convertErr     error
+	rates          []*queries.ExchangeRateResponse
+	conversion     *queries.ConversionResponse
+	lastCommand    commands.ExchangeRateRequest
+	lastImport     []commands.ExchangeRateRequest
+	lastConversion commands.ConversionRequest
+	lastBase       string
+	lastQuote      string
+	lastSeries     string
+	lastFrom       time.Time
+	lastTo         time.Time
+}
+
+func (m *mockExchangeRateService) Upsert(ctx context.Context, req commands.ExchangeRateRequest) error {
+	m.lastCommand = req
+	return m.upsertErr
+}
+
+func (m *mockExchangeRateService) Import(ctx context.Context, reqs []commands.ExchangeRateRequest) (int, error) {
+	m.lastImport = reqs
+	if m.importErr != nil {
+		return 0, m.importErr
+	}
+	return len(reqs), nil
+}
+
+func (m *mockExchangeRateService) List(ctx context.Context, base, quote, series string, from, to time.Time) ([]*queries.ExchangeRateResponse, error) {
+	m.lastBase, m.lastQuote, m.lastSeries, m.lastFrom, m.lastTo = base, quote, series, from, to
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	return m.rates, nil
+}
+
+func (m *mockExchangeRateService) Convert(ctx context.Context, req commands.ConversionRequest) (*queries.ConversionResponse, error) {
+	m.lastConversion = req
+	if m.convertErr != nil {
+		return nil, m.convertErr
+	}
+	return m.conversion, nil
+}
+
+func validRateBody() ExchangeRateRequest {
+	rate := shareddomain.MustParseAmount("0.92")
+	return ExchangeRateRequest{
+		Base:  "usd",
+		Quote: " eur ",
+		Date:  "2026-01-05",
+		Rate:  &rate,
+	}
+}
+
+const ratesCSV = "date,base,quote,rate\n2026-01-05,USD,EUR,0.92\n2026-01-05,USD,BRL,5.1\n"
+
+func TestUpsertRate_Success(t *testing.T) {
+	service := &mockExchangeRateService{}
+	handler := &Handler{exchangeRateService: service}
+
+	jsonBody, _ := json.Marshal(validRateBody())
+	req := httptest.NewRequest("PUT", "/exchange-rates", bytes.NewBuffer(jsonBody))
+	rr := httptest.NewRecorder()
+	handler.UpsertRate(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rr.Code)
+	}
+	if service.lastCommand.Base != "USD" || service.lastCommand.Quote != "EUR" {
+		t.Errorf("expected pair USD/EUR, got %s/%s", service.lastCommand.Base, service.lastCommand.Quote)
+	}
+	if !service.lastCommand.Date.Equal(time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected date %v", service.lastCommand.Date)
+	}
+}
+
+func TestUpsertRate_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*ExchangeRateRequest)
+	}{
+		{"missing base", func(r *ExchangeRateRequest) { r.Base = " " }},
+		{"missing quote", func(r *ExchangeRateRequest) { r.Quote = "" }},
+		{"missing rate", func(r *ExchangeRateRequest) { r.Rate = nil }},
+		{"missing date", func(r *ExchangeRateRequest) { r.Date = "" }},
+		{"invalid date", func(r *ExchangeRateRequest) { r.Date = "05/01/2026" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := validRateBody()
+			tt.mutate(&body)
+
+			if _, err := toExchangeRateCommand(body); err == nil {
+				t.Error("expected validation error, got nil")
+			}
+		})
+	}
+}
+
+func TestUpsertRate_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"unauthenticated", errors.New("user not authenticated"), http.StatusUnauthorized},
+		{"currency", errors.New("invalid currency"), http.StatusBadRequest},
+		{"same currency", domain.ErrSameCurrency, http.StatusBadRequest},
+		{"rate", domain.ErrInvalidRate, http.StatusBadRequest},
+		{"precision", domain.ErrRatePrecision, http.StatusBadRequest},
+		{"unexpected", errors.New("boom"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &Handler{exchangeRateService: &mockExchangeRateService{upsertErr: tt.err}}
+
+			jsonBody, _ := json.Marshal(validRateBody())
+			req := httptest.NewRequest("PUT", "/exchange-rates", bytes.NewBuffer(jsonBody))
+			rr := httptest.NewRecorder()
+			handler.UpsertRate(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestImportRates_RawBody(t *testing.T) {
+	service := &mockExchangeRateService{}
+	handler := &Handler{exchangeRateService: service}
+
+	req := httptest.NewRequest("POST", "/exchange-rates/import", strings.NewReader(ratesCSV))
+	req.Header.Set("Content-Type", "text/csv")
+	rr := httptest.NewRecorder()
+	handler.ImportRates(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var response ImportResponse
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Imported != 2 {
+		t.Errorf("expected 2 imported rates, got %d", response.Imported)
+	}
+}
+
+func TestImportRates_Multipart(t *testing.T) {
+	service := &mockExchangeRateService{}
+	handler := &Handler{exchangeRateService: service}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile("file", "rates.csv")
+	part.Write([]byte(ratesCSV))
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/exchange-rates/import", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	rr := httptest.NewRecorder()
+	handler.ImportRates(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if len(service.lastImport) != 2 {
+		t.Errorf("expected 2 rates passed to the service, got %d", len(service.lastImport))
+	}
+}
+
+func TestImportRates_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		serviceErr  error
+		wantStatus  int
+	}{
+		{"malformed csv", "date,base,quote,rate\n2026-01-05,USD,EUR,abc\n", "text/csv", nil, http.StatusBadRequest},
+		{"invalid rate", ratesCSV, "text/csv", &services.ImportError{Line: 3, Message: "rate must be greater than zero"}, http.StatusBadRequest},
+		{"missing file field", "", "multipart/form-data; boundary=x", nil, http.StatusBadRequest},
+		{"too large", "date,base,quote,rate,note\n2026-01-05,USD,EUR,0.92," + strings.Repeat("x", maxImportBytes) + "\n", "text/csv", nil, http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &Handler{exchangeRateService: &mockExchangeRateService{importErr: tt.serviceErr}}
+
+			req := httptest.NewRequest("POST", "/exchange-rates/import", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rr := httptest.NewRecorder()
+			handler.ImportRates(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestListRates(t *testing.T) {
+	service := &mockExchangeRateService{rates: []*queries.ExchangeRateResponse{
+		{ID: "rate-1", Base: "USD", Quote: "EUR", Date: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), Rate: shareddomain.MustParseAmount("0.92"), Source: "manual"},
+	}}
+	handler := &Handler{exchangeRateService: service}
+
+	req := httptest.NewRequest("GET", "/exchange-rates?base=usd&quote=eur&series=Blue&from=2026-01-01", nil)
+	rr := httptest.NewRecorder()
+	handler.ListRates(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if service.lastBase != "USD" || service.lastQuote != "EUR" || service.lastSeries != "blue" {
+		t.Errorf("expected pair USD/EUR in the blue series, got %s/%s in %q", service.lastBase, service.lastQuote, service.lastSeries)
+	}
+	if !service.lastFrom.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) || !service.lastTo.IsZero() {
+		t.Errorf("unexpected range %v - %v", service.lastFrom, service.lastTo)
+	}
+
+	var response []ExchangeRateResponse
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response) != 1 || response[0].Date != "2026-01-05" {
+		t.Errorf("unexpected response %+v", response)
+	}
+}
+
+func TestListRates_BadRequests(t *testing.T) {
+	tests := []string{
+		"/exchange-rates?quote=EUR",
+		"/exchange-rates?base=USD",
+		"/exchange-rates?base=USD&quote=EUR&to=2026-13-01",
+	}
+
+	for _, target := range tests {
+		t.Run(target, func(t *testing.T) {
+			handler := &Handler{exchangeRateService: &mockExchangeRateService{}}
+
+			req := httptest.NewRequest("GET", target, nil)
+			rr := httptest.NewRecorder()
+			handler.ListRates(rr, req)
+
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rr.Code)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	service := &mockExchangeRateService{conversion: &queries.ConversionResponse{
+		Amount:    shareddomain.MustParseAmount("10"),
+		From:      "EUR",
+		To:        "BRL",
+		Converted: shareddomain.MustParseAmount("62.5"),
+		Rate:      shareddomain.MustParseAmount("6.25"),
+		AsOf:      time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+		Via:       "USD",
+		Series:    "official",
+	}}
+	handler := &Handler{exchangeRateService: service}
+
+	req := httptest.NewRequest("GET", "/exchange-rates/convert?amount=10&from=eur&to=brl&date=2026-01-05", nil)
+	rr := httptest.NewRecorder()
+	handler.Convert(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if service.lastConversion.From != "EUR" || service.lastConversion.To != "BRL" {
+		t.Errorf("expected EUR to BRL, got %s to %s", service.lastConversion.From, service.lastConversion.To)
+	}
+
+	var response ConversionResponse
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Converted.String() != "62.5" || response.AsOf != "2026-01-02" || response.Via != "USD" || response.Series != "official" {
+		t.Errorf("unexpected response %+v", response)
+	}
+}
+
+func TestConvert_DefaultsToToday(t *testing.T) {
+	service := &mockExchangeRateService{conversion: &queries.ConversionResponse{}}
+	handler := &Handler{exchangeRateService: service}
+
+	req := httptest.NewRequest("GET", "/exchange-rates/convert?amount=10&from=USD&to=EUR", nil)
+	rr := httptest.NewRecorder()
+	handler.Convert(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if time.Since(service.lastConversion.Date) > time.Minute {
+		t.Errorf("expected today's date, got %v", service.lastConversion.Date)
+	}
+}
+
+func TestConvert_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		err        error
+		wantStatus int
+	}{
+		{"bad amount", "/exchange-rates/convert?amount=ten&from=USD&to=EUR", nil, http.StatusBadRequest},
+		{"missing currency", "/exchange-rates/convert?amount=10&from=USD", nil, http.StatusBadRequest},
+		{"bad date", "/exchange-rates/convert?amount=10&from=USD&to=EUR&date=yesterday", nil, http.StatusBadRequest},
+		{"no rate", "/exchange-rates/convert?amount=10&from=USD&to=EUR", fmt.Errorf("%w for USD/EUR", domain.ErrRateNotFound), http.StatusNotFound},
+		{"invalid currency", "/exchange-rates/convert?amount=10&from=USD&to=XXX", errors.New("invalid currency"), http.StatusBadRequest},
+		{"invalid series", "/exchange-rates/convert?amount=10&from=USD&to=ARS&series=tarjeta", errors.New("invalid rate series"), http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &Handler{exchangeRateService: &mockExchangeRateService{convertErr: tt.err}}
+
+			req := httptest.NewRequest("GET", tt.target, nil)
+			rr := httptest.NewRecorder()
+			handler.Convert(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestRequireManageRates(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           shareddomain.Role
+		expectedStatus int
+	}{
+		{"user", shareddomain.RoleUser, http.StatusForbidden},
+		{"admin", shareddomain.RoleAdmin, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest("PUT", "/exchange-rates", nil)
+			ctx := context.WithValue(req.Context(), middleware.UserIDKey, "user-1")
+			ctx = context.WithValue(ctx, middleware.RoleKey, tt.role)
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			requireManageRates(next).ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package http
+
+import (
+	"net/http"
+
+	"fin-flow-api/internal/shared/interface/jwt"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+var exchangeRateHandler *Handler
+
+func SetupRoutes(mux *http.ServeMux, jwtService jwt.Service) {
+	mountExchangeRates(mux, jwtService)
+}
+
+func mountExchangeRates(mux *http.ServeMux, jwtService jwt.Service) {
+	mux.HandleFunc("/exchange-rates", handleExchangeRatesCollection(jwtService))
+
+	mux.Handle("/exchange-rates/import", middleware.RequireAuth(jwtService)(requireManageRates(http.HandlerFunc(exchangeRateHandler.ImportRates))))
+
+	mux.Handle("/exchange-rates/convert", middleware.RequireAuth(jwtService)(http.HandlerFunc(exchangeRateHandler.Convert)))
+}
+
+// Rates are shared by every user, so only callers allowed to manage them
+// may write.
+func handleExchangeRatesCollection(jwtService jwt.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.RequireAuth(jwtService)(http.HandlerFunc(exchangeRateHandler.ListRates)).ServeHTTP(w, r)
+		case http.MethodPut:
+			middleware.RequireAuth(jwtService)(requireManageRates(http.HandlerFunc(exchangeRateHandler.UpsertRate))).ServeHTTP(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func requireManageRates(next http.Handler) http.Handler {
+	return middleware.RequirePermission(middleware.PermissionManageExchangeRates, nil)(next)
+}
+
+func SetHandler(handler *Handler) {
+	exchangeRateHandler = handler
+}
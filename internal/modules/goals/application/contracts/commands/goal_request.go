@@ -0,0 +1,15 @@
+package commands
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+type GoalRequest struct {
+	Name         string
+	TargetAmount domain.Amount
+	Currency     string
+	Deadline     time.Time
+	WalletIDs    []string
+}
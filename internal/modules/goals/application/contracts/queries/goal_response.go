@@ -0,0 +1,59 @@
+package queries
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+type GoalResponse struct {
+	ID           string
+	Name         string
+	TargetAmount domain.Amount
+	Currency     string
+	Deadline     time.Time
+	WalletIDs    []string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	CreatedBy    string
+	UpdatedBy    string
+}
+
+// GoalProgressResponse measures a goal on Date. Contributions holds the net
+// amount the goal wallets received in each of the HistoryMonths complete
+// months before the current one, which the projection is based on.
+type GoalProgressResponse struct {
+	GoalID                     string
+	Name                       string
+	Currency                   string
+	TargetAmount               domain.Amount
+	Deadline                   time.Time
+	Date                       time.Time
+	Saved                      domain.Amount
+	Remaining                  domain.Amount
+	Percent                    domain.Amount
+	Completed                  bool
+	MonthsRemaining            int
+	MonthlyContributionNeeded  domain.Amount
+	AverageMonthlyContribution domain.Amount
+	HistoryMonths              int
+	ProjectedCompletion        *time.Time
+	OnTrack                    bool
+	Wallets                    []*GoalWalletResponse
+	Contributions              []*MonthlyContributionResponse
+}
+
+// GoalWalletResponse is the balance of one goal wallet and its value in the
+// goal currency.
+type GoalWalletResponse struct {
+	WalletID  string
+	Name      string
+	Currency  string
+	Balance   domain.Amount
+	Converted domain.Amount
+}
+
+type MonthlyContributionResponse struct {
+	Month  time.Time
+	Amount domain.Amount
+}
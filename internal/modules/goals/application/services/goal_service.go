@@ -0,0 +1,320 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	exchangeratedomain "fin-flow-api/internal/modules/exchangerates/domain"
+	"fin-flow-api/internal/modules/goals/application/contracts/commands"
+	"fin-flow-api/internal/modules/goals/application/contracts/queries"
+	"fin-flow-api/internal/modules/goals/domain"
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/middleware"
+
+	"github.com/google/uuid"
+)
+
+// DefaultHistoryMonths is how many complete months of contributions the
+// projection looks at when the caller does not say.
+const DefaultHistoryMonths = 6
+
+type currencyConverter interface {
+	Convert(amount shareddomain.Amount, from, to walletdomain.Currency, series walletdomain.RateSeries, date time.Time) (shareddomain.Amount, *exchangeratedomain.CrossRate, error)
+}
+
+type GoalService struct {
+	repository            domain.GoalRepository
+	walletRepository      walletdomain.WalletRepository
+	transactionRepository transactiondomain.TransactionRepository
+	converter             currencyConverter
+	systemUser            string
+	now                   func() time.Time
+}
+
+func NewGoalService(repository domain.GoalRepository, walletRepository walletdomain.WalletRepository, transactionRepository transactiondomain.TransactionRepository, converter currencyConverter, systemUser string) *GoalService {
+	return &GoalService{
+		repository:            repository,
+		walletRepository:      walletRepository,
+		transactionRepository: transactionRepository,
+		converter:             converter,
+		systemUser:            systemUser,
+		now:                   time.Now,
+	}
+}
+
+func (s *GoalService) getUserIDFromContext(ctx context.Context) (string, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return "", errors.New("user not authenticated")
+	}
+	return userID, nil
+}
+
+func (s *GoalService) Create(ctx context.Context, req commands.GoalRequest) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	goal := domain.NewGoal(
+		uuid.New().String(),
+		userID,
+		req.Name,
+		req.TargetAmount,
+		walletdomain.Currency(strings.ToUpper(req.Currency)),
+		req.Deadline,
+		req.WalletIDs,
+		s.systemUser,
+	)
+
+	if err := s.validate(userID, goal); err != nil {
+		return err
+	}
+
+	return s.repository.Create(goal)
+}
+
+func (s *GoalService) Update(ctx context.Context, id string, req commands.GoalRequest) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	existing, err := s.repository.GetByID(id, userID)
+	if err != nil {
+		return err
+	}
+
+	goal := domain.NewGoal(
+		existing.ID,
+		userID,
+		req.Name,
+		req.TargetAmount,
+		walletdomain.Currency(strings.ToUpper(req.Currency)),
+		req.Deadline,
+		req.WalletIDs,
+		existing.CreatedBy,
+	)
+	goal.Entity = existing.Entity
+
+	if err := s.validate(userID, goal); err != nil {
+		return err
+	}
+
+	goal.Entity.UpdateModified(s.systemUser)
+
+	return s.repository.Update(goal)
+}
+
+func (s *GoalService) Delete(ctx context.Context, id string) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return s.repository.Delete(id, userID)
+}
+
+func (s *GoalService) GetByID(ctx context.Context, id string) (*queries.GoalResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	goal, err := s.repository.GetByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGoalResponse(goal), nil
+}
+
+func (s *GoalService) List(ctx context.Context) ([]*queries.GoalResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	goals, err := s.repository.List(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*queries.GoalResponse, len(goals))
+	for i, goal := range goals {
+		responses[i] = toGoalResponse(goal)
+	}
+
+	return responses, nil
+}
+
+// Progress measures a goal today. Wallet balances are converted to the goal
+// currency at today's rates and each month of contributions at the rates
+// of its last day. historyMonths is how many complete months before the
+// current one the projection is based on; zero means DefaultHistoryMonths.
+func (s *GoalService) Progress(ctx context.Context, id string, historyMonths int) (*queries.GoalProgressResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if historyMonths <= 0 {
+		historyMonths = DefaultHistoryMonths
+	}
+
+	goal, err := s.repository.GetByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := s.now().UTC()
+	currentMonth := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	from := currentMonth.AddDate(0, -historyMonths, 0)
+
+	response := &queries.GoalProgressResponse{
+		GoalID:        goal.ID,
+		Name:          goal.Name,
+		Currency:      goal.Currency.String(),
+		TargetAmount:  goal.TargetAmount,
+		Deadline:      goal.Deadline,
+		Date:          exchangeratedomain.DayOf(today),
+		HistoryMonths: historyMonths,
+		Wallets:       make([]*queries.GoalWalletResponse, 0, len(goal.WalletIDs)),
+		Contributions: make([]*queries.MonthlyContributionResponse, historyMonths),
+	}
+	for i := range response.Contributions {
+		response.Contributions[i] = &queries.MonthlyContributionResponse{Month: from.AddDate(0, i, 0)}
+	}
+
+	var saved shareddomain.Amount
+	for _, walletID := range goal.WalletIDs {
+		wallet, err := s.walletRepository.GetByID(walletID, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		converted, err := s.convert(wallet, wallet.Balance, goal.Currency, today)
+		if err != nil {
+			return nil, err
+		}
+		saved = saved.Add(converted)
+
+		response.Wallets = append(response.Wallets, &queries.GoalWalletResponse{
+			WalletID:  wallet.ID,
+			Name:      wallet.Name,
+			Currency:  wallet.Currency.String(),
+			Balance:   wallet.Balance,
+			Converted: converted,
+		})
+
+		flows, err := s.monthlyFlows(userID, wallet.ID, from, currentMonth, historyMonths)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, flow := range flows {
+			if flow.IsZero() {
+				continue
+			}
+			month := response.Contributions[i]
+			converted, err := s.convert(wallet, flow, goal.Currency, month.Month.AddDate(0, 1, -1))
+			if err != nil {
+				return nil, err
+			}
+			month.Amount = month.Amount.Add(converted)
+		}
+	}
+
+	contributions := make([]shareddomain.Amount, len(response.Contributions))
+	for i, month := range response.Contributions {
+		contributions[i] = month.Amount
+	}
+
+	progress := goal.Progress(saved, contributions, today)
+
+	response.Saved = progress.Saved
+	response.Remaining = progress.Remaining
+	response.Percent = progress.Percent
+	response.Completed = progress.Completed
+	response.MonthsRemaining = progress.MonthsRemaining
+	response.MonthlyContributionNeeded = progress.MonthlyContributionNeeded
+	response.AverageMonthlyContribution = progress.AverageMonthlyContribution
+	response.ProjectedCompletion = progress.ProjectedCompletion
+	response.OnTrack = progress.OnTrack
+
+	return response, nil
+}
+
+// monthlyFlows sums the net change of a wallet in each month from from up
+// to, but not including, the month of to.
+func (s *GoalService) monthlyFlows(userID, walletID string, from, to time.Time, months int) ([]shareddomain.Amount, error) {
+	until := to.Add(-time.Microsecond)
+	transactions, err := s.transactionRepository.List(userID, transactiondomain.TransactionFilter{
+		WalletID: walletID,
+		From:     &from,
+		To:       &until,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	flows := make([]shareddomain.Amount, months)
+	for _, transaction := range transactions {
+		date := transaction.Date.UTC()
+		index := (date.Year()-from.Year())*12 + int(date.Month()-from.Month())
+		if index < 0 || index >= months {
+			continue
+		}
+		flows[index] = flows[index].Add(transaction.WalletDelta())
+	}
+
+	return flows, nil
+}
+
+func (s *GoalService) convert(wallet *walletdomain.Wallet, amount shareddomain.Amount, currency walletdomain.Currency, date time.Time) (shareddomain.Amount, error) {
+	converted, _, err := s.converter.Convert(amount, wallet.Currency, currency, wallet.RateSeries, date)
+	if err != nil {
+		if errors.Is(err, exchangeratedomain.ErrRateNotFound) {
+			return shareddomain.Amount{}, fmt.Errorf("%w for wallet %q (%s/%s, %s series)", err, wallet.Name, wallet.Currency, currency, wallet.RateSeries)
+		}
+		return shareddomain.Amount{}, err
+	}
+	return converted, nil
+}
+
+func (s *GoalService) validate(userID string, goal *domain.Goal) error {
+	if err := goal.Validate(); err != nil {
+		return err
+	}
+
+	for _, walletID := range goal.WalletIDs {
+		wallet, err := s.walletRepository.GetByID(walletID, userID)
+		if err != nil {
+			return err
+		}
+		if wallet.Type != walletdomain.WalletTypeSavings {
+			return domain.ErrNotSavingsWallet
+		}
+	}
+
+	return nil
+}
+
+func toGoalResponse(goal *domain.Goal) *queries.GoalResponse {
+	return &queries.GoalResponse{
+		ID:           goal.ID,
+		Name:         goal.Name,
+		TargetAmount: goal.TargetAmount,
+		Currency:     goal.Currency.String(),
+		Deadline:     goal.Deadline,
+		WalletIDs:    goal.WalletIDs,
+		CreatedAt:    goal.CreatedAt,
+		UpdatedAt:    goal.ModifiedAt,
+		CreatedBy:    goal.CreatedBy,
+		UpdatedBy:    goal.ModifiedBy,
+	}
+}
@@ -0,0 +1,369 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	exchangeratedomain "fin-flow-api/internal/modules/exchangerates/domain"
+	"fin-flow-api/internal/modules/goals/application/contracts/commands"
+	"fin-flow-api/internal/modules/goals/domain"
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	"fin-flow-api/internal/modules/transactions/domain/transactiontest"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+type mockGoalRepository struct {
+	goals map[string]*domain.Goal
+}
+
+func (m *mockGoalRepository) Create(goal *domain.Goal) error {
+	m.goals[goal.ID] = goal
+	return nil
+}
+
+func (m *mockGoalRepository) GetByID(id string, userID string) (*domain.Goal, error) {
+	goal, exists := m.goals[id]
+	if !exists {
+		return nil, errors.New("goal not found")
+	}
+	if goal.UserID != userID {
+		return nil, errors.New("unauthorized access to goal")
+	}
+	copied := *goal
+	return &copied, nil
+}
+
+func (m *mockGoalRepository) List(userID string) ([]*domain.Goal, error) {
+	var result []*domain.Goal
+	for _, goal := range m.goals {
+		if goal.UserID == userID {
+			result = append(result, goal)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockGoalRepository) Update(goal *domain.Goal) error {
+	if _, exists := m.goals[goal.ID]; !exists {
+		return errors.New("goal not found")
+	}
+	m.goals[goal.ID] = goal
+	return nil
+}
+
+func (m *mockGoalRepository) Delete(id string, userID string) error {
+	goal, exists := m.goals[id]
+	if !exists {
+		return errors.New("goal not found")
+	}
+	if goal.UserID != userID {
+		return errors.New("unauthorized access to goal")
+	}
+	delete(m.goals, id)
+	return nil
+}
+
+type mockWalletRepository struct {
+	wallets map[string]*walletdomain.Wallet
+}
+
+func (m *mockWalletRepository) Create(wallet *walletdomain.Wallet) error {
+	return nil
+}
+
+func (m *mockWalletRepository) GetByID(id string, userID string) (*walletdomain.Wallet, error) {
+	wallet, exists := m.wallets[id]
+	if !exists {
+		return nil, errors.New("wallet not found")
+	}
+	if wallet.UserID != userID {
+		return nil, errors.New("unauthorized access to wallet")
+	}
+	return wallet, nil
+}
+
+func (m *mockWalletRepository) List(userID string) ([]*walletdomain.Wallet, error) {
+	return nil, nil
+}
+
+func (m *mockWalletRepository) Update(wallet *walletdomain.Wallet) error {
+	return nil
+}
+
+func (m *mockWalletRepository) Delete(id string, userID string) error {
+	return nil
+}
+
+// mockConverter quotes a fixed rate into the target currency per source
+// currency and records the dates it was asked for.
+type mockConverter struct {
+	rates map[string]string
+	dates []time.Time
+}
+
+func (m *mockConverter) Convert(amount shareddomain.Amount, from, to walletdomain.Currency, series walletdomain.RateSeries, date time.Time) (shareddomain.Amount, *exchangeratedomain.CrossRate, error) {
+	rate := &exchangeratedomain.CrossRate{From: from, To: to, Rate: shareddomain.MustParseAmount("1"), AsOf: exchangeratedomain.DayOf(date)}
+	if from != to {
+		m.dates = append(m.dates, date)
+		value, ok := m.rates[from.String()]
+		if !ok {
+			return shareddomain.Amount{}, nil, exchangeratedomain.ErrRateNotFound
+		}
+		rate.Rate = shareddomain.MustParseAmount(value)
+	}
+	return rate.Convert(amount), rate, nil
+}
+
+type mockContext struct {
+	context.Context
+	userID string
+	hasID  bool
+}
+
+func (m *mockContext) Value(key interface{}) interface{} {
+	if key == middleware.UserIDKey {
+		if m.hasID {
+			return m.userID
+		}
+		return nil
+	}
+	return nil
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+type fixture struct {
+	service      *GoalService
+	goals        *mockGoalRepository
+	transactions *transactiontest.Repository
+	converter    *mockConverter
+}
+
+func newFixture() *fixture {
+	goals := &mockGoalRepository{goals: make(map[string]*domain.Goal)}
+	wallets := &mockWalletRepository{wallets: map[string]*walletdomain.Wallet{
+		"savings-usd": walletdomain.NewWallet("savings-usd", "user1", "Savings", walletdomain.WalletTypeSavings, shareddomain.MustParseAmount("3000"), walletdomain.CurrencyUSD, "system"),
+		"savings-ars": walletdomain.NewWallet("savings-ars", "user1", "Plazo fijo", walletdomain.WalletTypeSavings, shareddomain.MustParseAmount("1000000"), walletdomain.CurrencyARS, "system"),
+		"checking":    walletdomain.NewWallet("checking", "user1", "Checking", walletdomain.WalletTypeBank, shareddomain.Amount{}, walletdomain.CurrencyUSD, "system"),
+		"foreign":     walletdomain.NewWallet("foreign", "user2", "Theirs", walletdomain.WalletTypeSavings, shareddomain.Amount{}, walletdomain.CurrencyUSD, "system"),
+	}}
+	transactions := transactiontest.NewRepository()
+	converter := &mockConverter{rates: map[string]string{"ARS": "0.001"}}
+	service := NewGoalService(goals, wallets, transactions, converter, "system")
+	service.now = func() time.Time { return time.Date(2026, 5, 10, 9, 0, 0, 0, time.UTC) }
+	return &fixture{service: service, goals: goals, transactions: transactions, converter: converter}
+}
+
+func (f *fixture) deposit(walletID string, amount string, when time.Time) {
+	id := fmt.Sprintf("deposit-%d", len(f.transactions.Transactions)+1)
+	transaction := transactiondomain.NewTransaction(id, "user1", walletID, "", transactiondomain.TransactionTypeIncome, shareddomain.MustParseAmount(amount), "", when, "system")
+	f.transactions.Transactions[id] = transaction
+}
+
+func houseRequest() commands.GoalRequest {
+	return commands.GoalRequest{
+		Name:         "House",
+		TargetAmount: shareddomain.MustParseAmount("10000"),
+		Currency:     "usd",
+		Deadline:     date(2026, 12, 31),
+		WalletIDs:    []string{"savings-usd", "savings-ars"},
+	}
+}
+
+func TestGoalService_Create(t *testing.T) {
+	f := newFixture()
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	if err := f.service.Create(ctx, houseRequest()); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if len(f.goals.goals) != 1 {
+		t.Fatalf("expected 1 goal, got %d", len(f.goals.goals))
+	}
+	for _, goal := range f.goals.goals {
+		if goal.Currency != walletdomain.CurrencyUSD || len(goal.WalletIDs) != 2 || goal.UserID != "user1" {
+			t.Errorf("unexpected goal %+v", goal)
+		}
+	}
+}
+
+func TestGoalService_Create_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(req *commands.GoalRequest)
+		wantErr string
+	}{
+		{"missing name", func(req *commands.GoalRequest) { req.Name = "" }, "goal name is required"},
+		{"invalid currency", func(req *commands.GoalRequest) { req.Currency = "XYZ" }, "invalid currency"},
+		{"zero target", func(req *commands.GoalRequest) { req.TargetAmount = shareddomain.Amount{} }, "target amount"},
+		{"no wallets", func(req *commands.GoalRequest) { req.WalletIDs = nil }, "at least one savings wallet"},
+		{"unknown wallet", func(req *commands.GoalRequest) { req.WalletIDs = []string{"missing"} }, "wallet not found"},
+		{"foreign wallet", func(req *commands.GoalRequest) { req.WalletIDs = []string{"foreign"} }, "unauthorized access to wallet"},
+		{"not a savings wallet", func(req *commands.GoalRequest) { req.WalletIDs = []string{"checking"} }, "must be savings wallets"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture()
+			ctx := &mockContext{userID: "user1", hasID: true}
+
+			req := houseRequest()
+			tt.mutate(&req)
+
+			err := f.service.Create(ctx, req)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %q", tt.wantErr, err.Error())
+			}
+			if len(f.goals.goals) != 0 {
+				t.Errorf("expected no goal to be stored, got %d", len(f.goals.goals))
+			}
+		})
+	}
+}
+
+func TestGoalService_Create_Unauthenticated(t *testing.T) {
+	f := newFixture()
+
+	err := f.service.Create(&mockContext{hasID: false}, houseRequest())
+	if err == nil || !strings.Contains(err.Error(), "user not authenticated") {
+		t.Errorf("expected authentication error, got %v", err)
+	}
+}
+
+func TestGoalService_Update(t *testing.T) {
+	f := newFixture()
+	ctx := &mockContext{userID: "user1", hasID: true}
+	goal := domain.NewGoal("goal-1", "user1", "House", shareddomain.MustParseAmount("10000"), walletdomain.CurrencyUSD, date(2026, 12, 31), []string{"savings-usd"}, "system")
+	f.goals.goals[goal.ID] = goal
+
+	req := houseRequest()
+	req.TargetAmount = shareddomain.MustParseAmount("12000")
+	req.WalletIDs = []string{"savings-ars"}
+
+	if err := f.service.Update(ctx, goal.ID, req); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	updated := f.goals.goals[goal.ID]
+	if updated.TargetAmount.String() != "12000" || len(updated.WalletIDs) != 1 || updated.WalletIDs[0] != "savings-ars" {
+		t.Errorf("unexpected goal after update %+v", updated)
+	}
+	if !updated.CreatedAt.Equal(goal.CreatedAt) {
+		t.Error("expected creation audit fields to be preserved")
+	}
+}
+
+func TestGoalService_Delete_Forbidden(t *testing.T) {
+	f := newFixture()
+	goal := domain.NewGoal("goal-1", "user1", "House", shareddomain.MustParseAmount("10000"), walletdomain.CurrencyUSD, date(2026, 12, 31), []string{"savings-usd"}, "system")
+	f.goals.goals[goal.ID] = goal
+
+	err := f.service.Delete(&mockContext{userID: "user2", hasID: true}, goal.ID)
+	if err == nil || !strings.Contains(err.Error(), "unauthorized access to goal") {
+		t.Errorf("expected unauthorized error, got %v", err)
+	}
+}
+
+func TestGoalService_Progress(t *testing.T) {
+	f := newFixture()
+	ctx := &mockContext{userID: "user1", hasID: true}
+	goal := domain.NewGoal("goal-1", "user1", "House", shareddomain.MustParseAmount("10000"), walletdomain.CurrencyUSD, date(2026, 12, 31), []string{"savings-usd", "savings-ars"}, "system")
+	f.goals.goals[goal.ID] = goal
+
+	f.deposit("savings-usd", "500", time.Date(2026, 2, 3, 12, 0, 0, 0, time.UTC))
+	f.deposit("savings-usd", "400", time.Date(2026, 3, 31, 23, 30, 0, 0, time.UTC))
+	f.deposit("savings-ars", "200000", date(2026, 4, 15))
+	// Outside the window: before it and in the current month.
+	f.deposit("savings-usd", "9999", date(2025, 10, 31))
+	f.deposit("savings-usd", "9999", date(2026, 5, 1))
+
+	progress, err := f.service.Progress(ctx, goal.ID, 3)
+	if err != nil {
+		t.Fatalf("Progress failed: %v", err)
+	}
+
+	if progress.Saved.String() != "4000" || progress.Remaining.String() != "6000" {
+		t.Errorf("expected 4000 saved and 6000 remaining, got %s and %s", progress.Saved, progress.Remaining)
+	}
+	if len(progress.Wallets) != 2 || progress.Wallets[1].Converted.String() != "1000" {
+		t.Errorf("expected pesos to be converted to 1000, got %+v", progress.Wallets)
+	}
+
+	if progress.HistoryMonths != 3 || len(progress.Contributions) != 3 {
+		t.Fatalf("expected 3 months of history, got %d", len(progress.Contributions))
+	}
+	want := []struct {
+		month  time.Time
+		amount string
+	}{
+		{date(2026, 2, 1), "500"},
+		{date(2026, 3, 1), "400"},
+		{date(2026, 4, 1), "200"},
+	}
+	for i, w := range want {
+		got := progress.Contributions[i]
+		if !got.Month.Equal(w.month) || got.Amount.String() != w.amount {
+			t.Errorf("month %d: expected %s in %s, got %s in %s", i, w.amount, w.month.Format("2006-01"), got.Amount, got.Month.Format("2006-01"))
+		}
+	}
+
+	if len(f.converter.dates) == 0 || !f.converter.dates[len(f.converter.dates)-1].Equal(date(2026, 4, 30)) {
+		t.Errorf("expected April contributions to be converted at the end of April, got %v", f.converter.dates)
+	}
+
+	if progress.AverageMonthlyContribution.String() != "366.67" {
+		t.Errorf("expected average of 366.67, got %s", progress.AverageMonthlyContribution)
+	}
+	if progress.MonthlyContributionNeeded.String() != "750" {
+		t.Errorf("expected 750 a month, got %s", progress.MonthlyContributionNeeded)
+	}
+	if progress.ProjectedCompletion == nil || !progress.ProjectedCompletion.Equal(date(2027, 10, 10)) {
+		t.Errorf("expected completion on 2027-10-10, got %v", progress.ProjectedCompletion)
+	}
+	if progress.OnTrack {
+		t.Error("expected goal to be behind")
+	}
+}
+
+func TestGoalService_Progress_DefaultHistory(t *testing.T) {
+	f := newFixture()
+	ctx := &mockContext{userID: "user1", hasID: true}
+	goal := domain.NewGoal("goal-1", "user1", "House", shareddomain.MustParseAmount("10000"), walletdomain.CurrencyUSD, date(2026, 12, 31), []string{"savings-usd"}, "system")
+	f.goals.goals[goal.ID] = goal
+
+	progress, err := f.service.Progress(ctx, goal.ID, 0)
+	if err != nil {
+		t.Fatalf("Progress failed: %v", err)
+	}
+
+	if progress.HistoryMonths != DefaultHistoryMonths || !progress.Contributions[0].Month.Equal(date(2025, 11, 1)) {
+		t.Errorf("expected %d months from 2025-11, got %d from %s", DefaultHistoryMonths, progress.HistoryMonths, progress.Contributions[0].Month.Format("2006-01"))
+	}
+}
+
+func TestGoalService_Progress_MissingRate(t *testing.T) {
+	f := newFixture()
+	ctx := &mockContext{userID: "user1", hasID: true}
+	goal := domain.NewGoal("goal-1", "user1", "House", shareddomain.MustParseAmount("10000"), walletdomain.CurrencyEUR, date(2026, 12, 31), []string{"savings-usd"}, "system")
+	f.goals.goals[goal.ID] = goal
+
+	_, err := f.service.Progress(ctx, goal.ID, 3)
+	if !errors.Is(err, exchangeratedomain.ErrRateNotFound) {
+		t.Fatalf("expected ErrRateNotFound, got %v", err)
+	}
+	if !strings.Contains(err.Error(), `"Savings"`) {
+		t.Errorf("expected error to name the wallet, got %q", err.Error())
+	}
+}
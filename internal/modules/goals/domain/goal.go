@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	"fin-flow-api/internal/shared/domain"
+)
+
+var (
+	ErrNameRequired     = errors.New("goal name is required")
+	ErrInvalidTarget    = errors.New("target amount must be greater than zero")
+	ErrDeadlineRequired = errors.New("deadline is required")
+	ErrWalletsRequired  = errors.New("a goal needs at least one savings wallet")
+	ErrDuplicateWallet  = errors.New("a wallet can only be linked to a goal once")
+	ErrNotSavingsWallet = errors.New("goal wallets must be savings wallets")
+	ErrTooManyWallets   = errors.New("a goal can link at most 20 wallets")
+)
+
+const MaxGoalWallets = 20
+
+// Goal is an amount to be saved by a deadline in the savings wallets linked
+// to it. Wallets may hold other currencies; their balances are converted to
+// Currency when progress is measured.
+type Goal struct {
+	domain.Entity
+
+	ID           string
+	UserID       string
+	Name         string
+	TargetAmount domain.Amount
+	Currency     walletdomain.Currency
+	Deadline     time.Time
+	WalletIDs    []string
+}
+
+func NewGoal(id, userID, name string, targetAmount domain.Amount, currency walletdomain.Currency, deadline time.Time, walletIDs []string, createdBy string) *Goal {
+	return &Goal{
+		Entity:       domain.NewEntity(id, createdBy),
+		ID:           id,
+		UserID:       userID,
+		Name:         name,
+		TargetAmount: targetAmount,
+		Currency:     currency,
+		Deadline:     truncateToDate(deadline),
+		WalletIDs:    walletIDs,
+	}
+}
+
+// Validate checks the goal fields. That the wallets exist, belong to the
+// user and are savings wallets is checked by the service.
+func (g *Goal) Validate() error {
+	if strings.TrimSpace(g.Name) == "" {
+		return ErrNameRequired
+	}
+	if !walletdomain.IsValidCurrency(g.Currency.String()) {
+		return walletdomain.ErrInvalidCurrency
+	}
+	if !g.TargetAmount.IsPositive() {
+		return ErrInvalidTarget
+	}
+	if !g.TargetAmount.FitsCurrency(g.Currency.String()) {
+		return domain.ErrAmountPrecision
+	}
+	if g.Deadline.IsZero() {
+		return ErrDeadlineRequired
+	}
+	if len(g.WalletIDs) == 0 {
+		return ErrWalletsRequired
+	}
+	if len(g.WalletIDs) > MaxGoalWallets {
+		return ErrTooManyWallets
+	}
+
+	seen := make(map[string]bool, len(g.WalletIDs))
+	for _, walletID := range g.WalletIDs {
+		if seen[walletID] {
+			return ErrDuplicateWallet
+		}
+		seen[walletID] = true
+	}
+	return nil
+}
+
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
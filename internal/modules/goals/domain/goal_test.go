@@ -0,0 +1,183 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func newGoal(target string, deadline time.Time) *Goal {
+	return NewGoal("goal-1", "user-1", "Emergency fund", shareddomain.MustParseAmount(target), walletdomain.CurrencyUSD, deadline, []string{"wallet-1"}, "system")
+}
+
+func amounts(values ...string) []shareddomain.Amount {
+	result := make([]shareddomain.Amount, len(values))
+	for i, value := range values {
+		result[i] = shareddomain.MustParseAmount(value)
+	}
+	return result
+}
+
+func TestGoal_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*Goal)
+		wantErr error
+	}{
+		{"valid", func(g *Goal) {}, nil},
+		{"missing name", func(g *Goal) { g.Name = "" }, ErrNameRequired},
+		{"invalid currency", func(g *Goal) { g.Currency = "XYZ" }, walletdomain.ErrInvalidCurrency},
+		{"zero target", func(g *Goal) { g.TargetAmount = shareddomain.Amount{} }, ErrInvalidTarget},
+		{"target too precise", func(g *Goal) { g.TargetAmount = shareddomain.MustParseAmount("10.001") }, shareddomain.ErrAmountPrecision},
+		{"missing deadline", func(g *Goal) { g.Deadline = time.Time{} }, ErrDeadlineRequired},
+		{"no wallets", func(g *Goal) { g.WalletIDs = nil }, ErrWalletsRequired},
+		{"duplicate wallet", func(g *Goal) { g.WalletIDs = []string{"wallet-1", "wallet-1"} }, ErrDuplicateWallet},
+		{"too many wallets", func(g *Goal) { g.WalletIDs = strings.Split(strings.Repeat("w,", MaxGoalWallets+1), ",") }, ErrTooManyWallets},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			goal := newGoal("10000", date(2026, 12, 31))
+			tt.mutate(goal)
+
+			if err := goal.Validate(); err != tt.wantErr {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestGoal_Progress_OnTrack(t *testing.T) {
+	goal := newGoal("10000", date(2026, 12, 31))
+
+	progress := goal.Progress(shareddomain.MustParseAmount("4000"), amounts("900", "1100", "1000"), date(2026, 5, 10))
+
+	if progress.Remaining.String() != "6000" || progress.Percent.String() != "40" {
+		t.Errorf("expected 6000 remaining at 40%%, got %s at %s", progress.Remaining, progress.Percent)
+	}
+	if progress.MonthsRemaining != 8 {
+		t.Errorf("expected 8 months from May to December, got %d", progress.MonthsRemaining)
+	}
+	if progress.MonthlyContributionNeeded.String() != "750" {
+		t.Errorf("expected 750 a month, got %s", progress.MonthlyContributionNeeded)
+	}
+	if progress.AverageMonthlyContribution.String() != "1000" {
+		t.Errorf("expected average of 1000, got %s", progress.AverageMonthlyContribution)
+	}
+	if progress.ProjectedCompletion == nil || !progress.ProjectedCompletion.Equal(date(2026, 11, 10)) {
+		t.Errorf("expected completion on 2026-11-10, got %v", progress.ProjectedCompletion)
+	}
+	if !progress.OnTrack || progress.Completed {
+		t.Errorf("expected goal to be on track and not completed, got %+v", progress)
+	}
+}
+
+func TestGoal_Progress_Behind(t *testing.T) {
+	goal := newGoal("10000", date(2026, 8, 31))
+
+	progress := goal.Progress(shareddomain.MustParseAmount("1000"), amounts("500", "400"), date(2026, 5, 31))
+
+	if progress.MonthlyContributionNeeded.String() != "2250" {
+		t.Errorf("expected 2250 a month, got %s", progress.MonthlyContributionNeeded)
+	}
+	if progress.ProjectedCompletion == nil || !progress.ProjectedCompletion.Equal(date(2028, 1, 31)) {
+		t.Errorf("expected completion on 2028-01-31, got %v", progress.ProjectedCompletion)
+	}
+	if progress.OnTrack {
+		t.Error("expected goal to be behind")
+	}
+}
+
+func TestGoal_Progress_RoundsNeededUp(t *testing.T) {
+	goal := newGoal("1000", date(2026, 7, 15))
+
+	progress := goal.Progress(shareddomain.Amount{}, nil, date(2026, 5, 1))
+
+	if progress.MonthlyContributionNeeded.String() != "333.34" {
+		t.Errorf("expected 333.34 a month, got %s", progress.MonthlyContributionNeeded)
+	}
+	if progress.ProjectedCompletion != nil || progress.OnTrack {
+		t.Error("expected no projection without contribution history")
+	}
+}
+
+func TestGoal_Progress_NoPositivePace(t *testing.T) {
+	goal := newGoal("1000", date(2026, 12, 31))
+
+	progress := goal.Progress(shareddomain.MustParseAmount("200"), amounts("100", "-300"), date(2026, 5, 1))
+
+	if progress.AverageMonthlyContribution.String() != "-100" {
+		t.Errorf("expected average of -100, got %s", progress.AverageMonthlyContribution)
+	}
+	if progress.ProjectedCompletion != nil {
+		t.Errorf("expected no projection when savings shrink, got %v", progress.ProjectedCompletion)
+	}
+}
+
+func TestGoal_Progress_TooSlowToProject(t *testing.T) {
+	goal := newGoal("1000000", date(2026, 12, 31))
+
+	progress := goal.Progress(shareddomain.Amount{}, amounts("0.01"), date(2026, 5, 1))
+
+	if progress.ProjectedCompletion != nil {
+		t.Errorf("expected no projection beyond %d months, got %v", maxProjectionMonths, progress.ProjectedCompletion)
+	}
+}
+
+func TestGoal_Progress_Completed(t *testing.T) {
+	goal := newGoal("1000", date(2026, 12, 31))
+
+	progress := goal.Progress(shareddomain.MustParseAmount("1250"), nil, date(2026, 5, 1))
+
+	if !progress.Completed || !progress.OnTrack {
+		t.Error("expected completed goal")
+	}
+	if !progress.Remaining.IsZero() || !progress.MonthlyContributionNeeded.IsZero() {
+		t.Errorf("expected nothing left to save, got %s and %s", progress.Remaining, progress.MonthlyContributionNeeded)
+	}
+	if progress.Percent.String() != "125" {
+		t.Errorf("expected 125%%, got %s", progress.Percent)
+	}
+}
+
+func TestGoal_Progress_PastDeadline(t *testing.T) {
+	goal := newGoal("1000", date(2026, 3, 31))
+
+	progress := goal.Progress(shareddomain.MustParseAmount("400"), nil, date(2026, 5, 1))
+
+	if progress.MonthsRemaining != 0 {
+		t.Errorf("expected no months left, got %d", progress.MonthsRemaining)
+	}
+	if progress.MonthlyContributionNeeded.String() != "600" {
+		t.Errorf("expected everything left to be needed now, got %s", progress.MonthlyContributionNeeded)
+	}
+}
+
+func TestMonthsUntil(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     time.Time
+		deadline time.Time
+		want     int
+	}{
+		{"same day", date(2026, 5, 10), date(2026, 5, 10), 1},
+		{"later this month", date(2026, 5, 10), date(2026, 5, 31), 1},
+		{"next year", date(2026, 5, 10), date(2027, 2, 1), 10},
+		{"passed", date(2026, 5, 10), date(2026, 5, 9), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MonthsUntil(tt.from, tt.deadline); got != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
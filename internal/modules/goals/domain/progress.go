@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"strconv"
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+// maxProjectionMonths caps how far ahead a completion date is projected.
+const maxProjectionMonths = 1200
+
+// Progress is how far a goal is from its target on a given day, what it
+// takes to reach it by the deadline and when it will be reached at the
+// recent pace of contributions.
+type Progress struct {
+	Saved     domain.Amount
+	Remaining domain.Amount
+	// Percent is Saved over the target, in percent with two decimals. It
+	// goes over 100 once the target is exceeded.
+	Percent   domain.Amount
+	Completed bool
+	// MonthsRemaining counts the current month and every month up to the
+	// one of the deadline. It is zero once the deadline has passed.
+	MonthsRemaining int
+	// MonthlyContributionNeeded spreads what is left over the remaining
+	// months. Past the deadline it is everything that is left.
+	MonthlyContributionNeeded  domain.Amount
+	AverageMonthlyContribution domain.Amount
+	// ProjectedCompletion is nil when recent contributions do not add up
+	// to a positive pace, or to one that would take more than
+	// maxProjectionMonths, since the goal would practically never be
+	// reached.
+	ProjectedCompletion *time.Time
+	OnTrack             bool
+}
+
+// Progress measures the goal on today given the converted balance of its
+// wallets and the net amount they received in each of the recent months.
+func (g *Goal) Progress(saved domain.Amount, monthlyContributions []domain.Amount, today time.Time) Progress {
+	currency := g.Currency.String()
+	today = truncateToDate(today)
+
+	progress := Progress{
+		Saved:           saved,
+		Remaining:       g.TargetAmount.Sub(saved),
+		Percent:         saved.Mul(domain.NewAmountFromInt(100)).DivRound(g.TargetAmount, 2),
+		MonthsRemaining: MonthsUntil(today, g.Deadline),
+	}
+
+	if len(monthlyContributions) > 0 {
+		var total domain.Amount
+		for _, contribution := range monthlyContributions {
+			total = total.Add(contribution)
+		}
+		progress.AverageMonthlyContribution = total.DivRound(domain.NewAmountFromInt(int64(len(monthlyContributions))), domain.CurrencyScale(currency))
+	}
+
+	if !progress.Remaining.IsPositive() {
+		progress.Remaining = domain.Amount{}
+		progress.Completed = true
+		progress.OnTrack = true
+		progress.ProjectedCompletion = &today
+		return progress
+	}
+
+	months := progress.MonthsRemaining
+	if months < 1 {
+		months = 1
+	}
+	progress.MonthlyContributionNeeded = ceilToCurrency(progress.Remaining.DivRound(domain.NewAmountFromInt(int64(months)), 18), currency)
+
+	if progress.AverageMonthlyContribution.IsPositive() {
+		if needed, ok := ceilDiv(progress.Remaining, progress.AverageMonthlyContribution); ok {
+			projected := AddMonths(today, needed)
+			progress.ProjectedCompletion = &projected
+			progress.OnTrack = !projected.After(g.Deadline)
+		}
+	}
+
+	return progress
+}
+
+// MonthsUntil counts the months from the one of from up to and including
+// the one of deadline, so a deadline later this month leaves one month.
+// It is zero when the deadline is before from.
+func MonthsUntil(from, deadline time.Time) int {
+	if deadline.Before(truncateToDate(from)) {
+		return 0
+	}
+	return (deadline.Year()-from.Year())*12 + int(deadline.Month()-from.Month()) + 1
+}
+
+// AddMonths moves date forward by months, keeping the day or using the last
+// day of shorter months.
+func AddMonths(date time.Time, months int) time.Time {
+	firstOfMonth := time.Date(date.Year(), date.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	day := date.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
+
+// ceilDiv returns how many whole times divisor has to be added to reach
+// amount, or false when that is more than maxProjectionMonths. Both must
+// be positive.
+func ceilDiv(amount, divisor domain.Amount) (int, bool) {
+	if divisor.Mul(domain.NewAmountFromInt(maxProjectionMonths)).Cmp(amount) < 0 {
+		return 0, false
+	}
+	count, err := strconv.Atoi(amount.DivRound(divisor, 0).String())
+	if err != nil {
+		return 0, false
+	}
+	if divisor.Mul(domain.NewAmountFromInt(int64(count))).Cmp(amount) < 0 {
+		count++
+	}
+	return count, true
+}
+
+// ceilToCurrency rounds up to the currency so that paying the amount every
+// month never falls short of the target.
+func ceilToCurrency(amount domain.Amount, currency string) domain.Amount {
+	rounded := amount.RoundToCurrency(currency)
+	if rounded.Cmp(amount) < 0 {
+		step := domain.NewAmountFromInt(1).DivRound(pow10(domain.CurrencyScale(currency)), domain.CurrencyScale(currency))
+		rounded = rounded.Add(step)
+	}
+	return rounded
+}
+
+func pow10(scale int32) domain.Amount {
+	result := domain.NewAmountFromInt(1)
+	for i := int32(0); i < scale; i++ {
+		result = result.Mul(domain.NewAmountFromInt(10))
+	}
+	return result
+}
@@ -0,0 +1,10 @@
+package domain
+
+type GoalRepository interface {
+	Create(goal *Goal) error
+	GetByID(id string, userID string) (*Goal, error)
+	List(userID string) ([]*Goal, error)
+	// Update stores the goal and replaces the wallets linked to it.
+	Update(goal *Goal) error
+	Delete(id string, userID string) error
+}
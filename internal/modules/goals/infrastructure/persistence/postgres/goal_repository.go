@@ -0,0 +1,283 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"fin-flow-api/internal/modules/goals/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const goalColumns = `id, user_id, name, target_amount, currency, deadline, created_at, modified_at, created_by, modified_by`
+
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+func (r *Repository) Create(goal *domain.Goal) error {
+	ctx := context.Background()
+
+	dbTx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create goal: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	query := `
+		INSERT INTO savings_goals (` + goalColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err = dbTx.Exec(
+		ctx,
+		query,
+		goal.ID,
+		goal.UserID,
+		goal.Name,
+		goal.TargetAmount,
+		goal.Currency.String(),
+		goal.Deadline,
+		goal.CreatedAt,
+		goal.ModifiedAt,
+		goal.CreatedBy,
+		goal.ModifiedBy,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create goal: %w", err)
+	}
+
+	if err := insertGoalWallets(ctx, dbTx, goal); err != nil {
+		return err
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to create goal: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetByID(id string, userID string) (*domain.Goal, error) {
+	checkQuery := `SELECT user_id FROM savings_goals WHERE id = $1`
+	var goalUserID string
+	err := r.pool.QueryRow(context.Background(), checkQuery, id).Scan(&goalUserID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("goal not found")
+		}
+		return nil, fmt.Errorf("failed to get goal: %w", err)
+	}
+
+	if goalUserID != userID {
+		return nil, fmt.Errorf("unauthorized access to goal")
+	}
+
+	query := `SELECT ` + goalColumns + ` FROM savings_goals WHERE id = $1 AND user_id = $2`
+
+	goal, err := scanGoal(r.pool.QueryRow(context.Background(), query, id, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("goal not found")
+		}
+		return nil, fmt.Errorf("failed to get goal: %w", err)
+	}
+
+	if err := r.loadWallets([]*domain.Goal{goal}); err != nil {
+		return nil, err
+	}
+
+	return goal, nil
+}
+
+func (r *Repository) List(userID string) ([]*domain.Goal, error) {
+	query := `SELECT ` + goalColumns + ` FROM savings_goals WHERE user_id = $1 ORDER BY deadline ASC, created_at ASC`
+
+	rows, err := r.pool.Query(context.Background(), query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list goals: %w", err)
+	}
+	defer rows.Close()
+
+	var goals []*domain.Goal
+	for rows.Next() {
+		goal, err := scanGoal(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan goal: %w", err)
+		}
+		goals = append(goals, goal)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate goals: %w", err)
+	}
+
+	if err := r.loadWallets(goals); err != nil {
+		return nil, err
+	}
+
+	return goals, nil
+}
+
+func (r *Repository) Update(goal *domain.Goal) error {
+	ctx := context.Background()
+
+	dbTx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update goal: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	query := `
+		UPDATE savings_goals
+		SET name = $2, target_amount = $3, currency = $4, deadline = $5, modified_at = $6, modified_by = $7
+		WHERE id = $1 AND user_id = $8
+	`
+
+	result, err := dbTx.Exec(
+		ctx,
+		query,
+		goal.ID,
+		goal.Name,
+		goal.TargetAmount,
+		goal.Currency.String(),
+		goal.Deadline,
+		goal.ModifiedAt,
+		goal.ModifiedBy,
+		goal.UserID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update goal: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("goal not found")
+	}
+
+	if _, err := dbTx.Exec(ctx, `DELETE FROM savings_goal_wallets WHERE goal_id = $1`, goal.ID); err != nil {
+		return fmt.Errorf("failed to update goal: %w", err)
+	}
+
+	if err := insertGoalWallets(ctx, dbTx, goal); err != nil {
+		return err
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to update goal: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) Delete(id string, userID string) error {
+	checkQuery := `SELECT user_id FROM savings_goals WHERE id = $1`
+	var goalUserID string
+	err := r.pool.QueryRow(context.Background(), checkQuery, id).Scan(&goalUserID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("goal not found")
+		}
+		return fmt.Errorf("failed to delete goal: %w", err)
+	}
+
+	if goalUserID != userID {
+		return fmt.Errorf("unauthorized access to goal")
+	}
+
+	// The wallet links go with the goal; the wallets themselves are kept.
+	query := `DELETE FROM savings_goals WHERE id = $1 AND user_id = $2`
+
+	if _, err := r.pool.Exec(context.Background(), query, id, userID); err != nil {
+		return fmt.Errorf("failed to delete goal: %w", err)
+	}
+
+	return nil
+}
+
+// loadWallets fills in the linked wallets of goals with a single query.
+func (r *Repository) loadWallets(goals []*domain.Goal) error {
+	if len(goals) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(goals))
+	byID := make(map[string]*domain.Goal, len(goals))
+	for i, goal := range goals {
+		ids[i] = goal.ID
+		byID[goal.ID] = goal
+	}
+
+	query := `SELECT goal_id, wallet_id FROM savings_goal_wallets WHERE goal_id = ANY($1) ORDER BY wallet_id`
+
+	rows, err := r.pool.Query(context.Background(), query, ids)
+	if err != nil {
+		return fmt.Errorf("failed to load goal wallets: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var goalID, walletID string
+		if err := rows.Scan(&goalID, &walletID); err != nil {
+			return fmt.Errorf("failed to scan goal wallet: %w", err)
+		}
+		if goal, ok := byID[goalID]; ok {
+			goal.WalletIDs = append(goal.WalletIDs, walletID)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate goal wallets: %w", err)
+	}
+
+	return nil
+}
+
+func insertGoalWallets(ctx context.Context, dbTx pgx.Tx, goal *domain.Goal) error {
+	query := `INSERT INTO savings_goal_wallets (goal_id, wallet_id) VALUES ($1, $2)`
+
+	for _, walletID := range goal.WalletIDs {
+		if _, err := dbTx.Exec(ctx, query, goal.ID, walletID); err != nil {
+			if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" { // foreign_key_violation
+				return fmt.Errorf("invalid wallet reference")
+			}
+			return fmt.Errorf("failed to link goal wallet: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func scanGoal(row pgx.Row) (*domain.Goal, error) {
+	var goal domain.Goal
+	var currency string
+
+	err := row.Scan(
+		&goal.ID,
+		&goal.UserID,
+		&goal.Name,
+		&goal.TargetAmount,
+		&currency,
+		&goal.Deadline,
+		&goal.CreatedAt,
+		&goal.ModifiedAt,
+		&goal.CreatedBy,
+		&goal.ModifiedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	goal.Currency = walletdomain.Currency(currency)
+
+	return &goal, nil
+}
@@ -0,0 +1,11 @@
+package http
+
+import shareddomain "fin-flow-api/internal/shared/domain"
+
+type GoalRequest struct {
+	Name         string               `json:"name"`
+	TargetAmount *shareddomain.Amount `json:"target_amount"`
+	Currency     string               `json:"currency"`
+	Deadline     string               `json:"deadline"`
+	WalletIDs    []string             `json:"wallet_ids"`
+}
@@ -0,0 +1,54 @@
+package http
+
+import (
+	"time"
+
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+type GoalResponse struct {
+	ID           string              `json:"id"`
+	Name         string              `json:"name"`
+	TargetAmount shareddomain.Amount `json:"target_amount"`
+	Currency     string              `json:"currency"`
+	Deadline     string              `json:"deadline"`
+	WalletIDs    []string            `json:"wallet_ids"`
+	CreatedAt    time.Time           `json:"created_at"`
+	UpdatedAt    time.Time           `json:"updated_at"`
+	CreatedBy    string              `json:"created_by"`
+	UpdatedBy    string              `json:"updated_by"`
+}
+
+type GoalWalletResponse struct {
+	WalletID  string              `json:"wallet_id"`
+	Name      string              `json:"name"`
+	Currency  string              `json:"currency"`
+	Balance   shareddomain.Amount `json:"balance"`
+	Converted shareddomain.Amount `json:"converted"`
+}
+
+type MonthlyContributionResponse struct {
+	Month  string              `json:"month"`
+	Amount shareddomain.Amount `json:"amount"`
+}
+
+type GoalProgressResponse struct {
+	GoalID                     string                        `json:"goal_id"`
+	Name                       string                        `json:"name"`
+	Currency                   string                        `json:"currency"`
+	TargetAmount               shareddomain.Amount           `json:"target_amount"`
+	Deadline                   string                        `json:"deadline"`
+	Date                       string                        `json:"date"`
+	Saved                      shareddomain.Amount           `json:"saved"`
+	Remaining                  shareddomain.Amount           `json:"remaining"`
+	Percent                    shareddomain.Amount           `json:"percent"`
+	Completed                  bool                          `json:"completed"`
+	MonthsRemaining            int                           `json:"months_remaining"`
+	MonthlyContributionNeeded  shareddomain.Amount           `json:"monthly_contribution_needed"`
+	AverageMonthlyContribution shareddomain.Amount           `json:"average_monthly_contribution"`
+	HistoryMonths              int                           `json:"history_months"`
+	ProjectedCompletion        string                        `json:"projected_completion,omitempty"`
+	OnTrack                    bool                          `json:"on_track"`
+	Wallets                    []GoalWalletResponse          `json:"wallets"`
+	Contributions              []MonthlyContributionResponse `json:"contributions"`
+}
@@ -0,0 +1,378 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"fin-flow-api/internal/modules/goals/application/contracts/commands"
+	"fin-flow-api/internal/modules/goals/application/contracts/queries"
+	"fin-flow-api/internal/modules/goals/domain"
+	basehandler "fin-flow-api/internal/shared/http"
+)
+
+const (
+	dateLayout  = "2006-01-02"
+	monthLayout = "2006-01"
+	// maxHistoryMonths bounds the contribution history a projection can be
+	// asked to look at.
+	maxHistoryMonths = 24
+)
+
+type goalService interface {
+	Create(ctx context.Context, req commands.GoalRequest) error
+	GetByID(ctx context.Context, id string) (*queries.GoalResponse, error)
+	Update(ctx context.Context, id string, req commands.GoalRequest) error
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context) ([]*queries.GoalResponse, error)
+	Progress(ctx context.Context, id string, historyMonths int) (*queries.GoalProgressResponse, error)
+}
+
+type Handler struct {
+	goalService goalService
+}
+
+func NewHandler(goalService goalService) *Handler {
+	return &Handler{
+		goalService: goalService,
+	}
+}
+
+func (h *Handler) CreateGoal(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var reqDTO GoalRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	cmd, err := toGoalCommand(reqDTO)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.goalService.Create(r.Context(), cmd); err != nil {
+		statusCode, errorMsg := goalErrorResponse(err, "create")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Goal created successfully")
+}
+
+func (h *Handler) GetGoal(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id := goalIDFromPath(r.URL.Path)
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Goal ID is required in the URL path")
+		return
+	}
+
+	goal, err := h.goalService.GetByID(r.Context(), id)
+	if err != nil {
+		statusCode, errorMsg := goalErrorResponse(err, "access")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, toGoalResponse(goal))
+}
+
+func (h *Handler) UpdateGoal(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id := goalIDFromPath(r.URL.Path)
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Goal ID is required in the URL path")
+		return
+	}
+
+	var reqDTO GoalRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	cmd, err := toGoalCommand(reqDTO)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.goalService.Update(r.Context(), id, cmd); err != nil {
+		statusCode, errorMsg := goalErrorResponse(err, "update")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Goal updated successfully")
+}
+
+func (h *Handler) DeleteGoal(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id := goalIDFromPath(r.URL.Path)
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Goal ID is required in the URL path")
+		return
+	}
+
+	if err := h.goalService.Delete(r.Context(), id); err != nil {
+		statusCode, errorMsg := goalErrorResponse(err, "delete")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Goal deleted successfully")
+}
+
+func (h *Handler) ListGoals(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	goals, err := h.goalService.List(r.Context())
+	if err != nil {
+		statusCode, errorMsg := goalErrorResponse(err, "access")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	responses := make([]GoalResponse, len(goals))
+	for i, goal := range goals {
+		responses[i] = toGoalResponse(goal)
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, responses)
+}
+
+// GetProgress handles GET /goals/{id}/progress?months=6 and measures the
+// goal today. months is how many complete months of contributions the
+// projection is based on.
+func (h *Handler) GetProgress(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id := goalIDFromPath(r.URL.Path)
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Goal ID is required in the URL path")
+		return
+	}
+
+	months := 0
+	if value := strings.TrimSpace(r.URL.Query().Get("months")); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxHistoryMonths {
+			basehandler.WriteError(w, http.StatusBadRequest, "Months must be a number between 1 and 24")
+			return
+		}
+		months = parsed
+	}
+
+	progress, err := h.goalService.Progress(r.Context(), id, months)
+	if err != nil {
+		statusCode, errorMsg := goalErrorResponse(err, "access")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, toGoalProgressResponse(progress))
+}
+
+func goalIDFromPath(path string) string {
+	path = strings.TrimPrefix(path, "/goals/")
+	return strings.Split(path, "/")[0]
+}
+
+func toGoalCommand(req GoalRequest) (commands.GoalRequest, error) {
+	if err := validateGoalRequest(req); err != nil {
+		return commands.GoalRequest{}, err
+	}
+
+	deadline, err := time.Parse(dateLayout, req.Deadline)
+	if err != nil {
+		return commands.GoalRequest{}, &ValidationError{Field: "deadline", Message: "Deadline must use the YYYY-MM-DD format"}
+	}
+
+	walletIDs := make([]string, len(req.WalletIDs))
+	for i, walletID := range req.WalletIDs {
+		walletIDs[i] = strings.TrimSpace(walletID)
+	}
+
+	return commands.GoalRequest{
+		Name:         strings.TrimSpace(req.Name),
+		TargetAmount: *req.TargetAmount,
+		Currency:     strings.TrimSpace(req.Currency),
+		Deadline:     deadline,
+		WalletIDs:    walletIDs,
+	}, nil
+}
+
+func validateGoalRequest(req GoalRequest) error {
+	if strings.TrimSpace(req.Name) == "" {
+		return &ValidationError{Field: "name", Message: "Name is required"}
+	}
+
+	if len(req.Name) > 255 {
+		return &ValidationError{Field: "name", Message: "Name must not exceed 255 characters"}
+	}
+
+	if req.TargetAmount == nil {
+		return &ValidationError{Field: "target_amount", Message: "Target amount is required"}
+	}
+
+	if !req.TargetAmount.IsPositive() {
+		return &ValidationError{Field: "target_amount", Message: "Target amount must be greater than zero"}
+	}
+
+	if strings.TrimSpace(req.Currency) == "" {
+		return &ValidationError{Field: "currency", Message: "Currency is required"}
+	}
+
+	if req.Deadline == "" {
+		return &ValidationError{Field: "deadline", Message: "Deadline is required"}
+	}
+
+	if len(req.WalletIDs) == 0 {
+		return &ValidationError{Field: "wallet_ids", Message: "At least one savings wallet is required"}
+	}
+
+	if len(req.WalletIDs) > domain.MaxGoalWallets {
+		return &ValidationError{Field: "wallet_ids", Message: "A goal can link at most 20 wallets"}
+	}
+
+	for _, walletID := range req.WalletIDs {
+		if strings.TrimSpace(walletID) == "" {
+			return &ValidationError{Field: "wallet_ids", Message: "Wallet IDs must not be empty"}
+		}
+	}
+
+	return nil
+}
+
+func goalErrorResponse(err error, action string) (int, string) {
+	errorMsg := err.Error()
+
+	switch {
+	case strings.Contains(errorMsg, "user not authenticated"):
+		return http.StatusUnauthorized, "Authentication required"
+	case strings.Contains(errorMsg, "unauthorized access to goal"):
+		return http.StatusForbidden, "You do not have permission to " + action + " this goal"
+	case strings.Contains(errorMsg, "unauthorized access to wallet"):
+		return http.StatusForbidden, "You do not have permission to use this wallet"
+	case strings.Contains(errorMsg, "goal not found"):
+		return http.StatusNotFound, "Goal not found"
+	case strings.Contains(errorMsg, "wallet not found"), strings.Contains(errorMsg, "invalid wallet reference"):
+		return http.StatusBadRequest, "Wallet not found"
+	case strings.Contains(errorMsg, "invalid currency"):
+		return http.StatusBadRequest, "Invalid currency code"
+	case strings.Contains(errorMsg, "decimal places"):
+		return http.StatusBadRequest, "Target amount has more decimal places than the currency allows"
+	case strings.Contains(errorMsg, "exchange rate not found"):
+		return http.StatusNotFound, errorMsg
+	case strings.Contains(errorMsg, "goal name is required"),
+		strings.Contains(errorMsg, "target amount must be"),
+		strings.Contains(errorMsg, "deadline is required"),
+		strings.Contains(errorMsg, "savings wallet"),
+		strings.Contains(errorMsg, "linked to a goal once"),
+		strings.Contains(errorMsg, "at most 20 wallets"):
+		return http.StatusBadRequest, errorMsg
+	}
+
+	return http.StatusInternalServerError, errorMsg
+}
+
+func toGoalResponse(goal *queries.GoalResponse) GoalResponse {
+	walletIDs := goal.WalletIDs
+	if walletIDs == nil {
+		walletIDs = []string{}
+	}
+
+	return GoalResponse{
+		ID:           goal.ID,
+		Name:         goal.Name,
+		TargetAmount: goal.TargetAmount,
+		Currency:     goal.Currency,
+		Deadline:     goal.Deadline.Format(dateLayout),
+		WalletIDs:    walletIDs,
+		CreatedAt:    goal.CreatedAt,
+		UpdatedAt:    goal.UpdatedAt,
+		CreatedBy:    goal.CreatedBy,
+		UpdatedBy:    goal.UpdatedBy,
+	}
+}
+
+func toGoalProgressResponse(progress *queries.GoalProgressResponse) GoalProgressResponse {
+	response := GoalProgressResponse{
+		GoalID:                     progress.GoalID,
+		Name:                       progress.Name,
+		Currency:                   progress.Currency,
+		TargetAmount:               progress.TargetAmount,
+		Deadline:                   progress.Deadline.Format(dateLayout),
+		Date:                       progress.Date.Format(dateLayout),
+		Saved:                      progress.Saved,
+		Remaining:                  progress.Remaining,
+		Percent:                    progress.Percent,
+		Completed:                  progress.Completed,
+		MonthsRemaining:            progress.MonthsRemaining,
+		MonthlyContributionNeeded:  progress.MonthlyContributionNeeded,
+		AverageMonthlyContribution: progress.AverageMonthlyContribution,
+		HistoryMonths:              progress.HistoryMonths,
+		OnTrack:                    progress.OnTrack,
+		Wallets:                    make([]GoalWalletResponse, len(progress.Wallets)),
+		Contributions:              make([]MonthlyContributionResponse, len(progress.Contributions)),
+	}
+
+	if progress.ProjectedCompletion != nil {
+		response.ProjectedCompletion = progress.ProjectedCompletion.Format(dateLayout)
+	}
+
+	for i, wallet := range progress.Wallets {
+		response.Wallets[i] = GoalWalletResponse{
+			WalletID:  wallet.WalletID,
+			Name:      wallet.Name,
+			Currency:  wallet.Currency,
+			Balance:   wallet.Balance,
+			Converted: wallet.Converted,
+		}
+	}
+
+	for i, contribution := range progress.Contributions {
+		response.Contributions[i] = MonthlyContributionResponse{
+			Month:  contribution.Month.Format(monthLayout),
+			Amount: contribution.Amount,
+		}
+	}
+
+	return response
+}
+
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
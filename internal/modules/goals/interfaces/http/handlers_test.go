@@ -0,0 +1,360 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	exchangeratedomain "fin-flow-api/internal/modules/exchangerates/domain"
+	"fin-flow-api/internal/modules/goals/application/contracts/commands"
+	"fin-flow-api/internal/modules/goals/application/contracts/queries"
+	"fin-flow-api/internal/modules/goals/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+type mockGoalService struct {
+	createErr   error
+	getByIDErr  error
+	updateErr   error
+	deleteErr   error
+	listErr     error
+	progressErr error
+	goal        *queries.GoalResponse
+	goals       []*queries.GoalResponse
+	progress    *queries.GoalProgressResponse
+	lastCommand commands.GoalRequest
+	lastID      string
+	lastMonths  int
+}
+
+func (m *mockGoalService) Create(ctx context.Context, req commands.GoalRequest) error {
+	m.lastCommand = req
+	return m.createErr
+}
+
+func (m *mockGoalService) GetByID(ctx context.Context, id string) (*queries.GoalResponse, error) {
+	m.lastID = id
+	if m.getByIDErr != nil {
+		return nil, m.getByIDErr
+	}
+	return m.goal, nil
+}
+
+func (m *mockGoalService) Update(ctx context.Context, id string, req commands.GoalRequest) error {
+	m.lastID = id
+	m.lastCommand = req
+	return m.updateErr
+}
+
+func (m *mockGoalService) Delete(ctx context.Context, id string) error {
+	m.lastID = id
+	return m.deleteErr
+}
+
+func (m *mockGoalService) List(ctx context.Context) ([]*queries.GoalResponse, error) {
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	return m.goals, nil
+}
+
+func (m *mockGoalService) Progress(ctx context.Context, id string, historyMonths int) (*queries.GoalProgressResponse, error) {
+	m.lastID = id
+	m.lastMonths = historyMonths
+	if m.progressErr != nil {
+		return nil, m.progressErr
+	}
+	return m.progress, nil
+}
+
+func validGoalBody() GoalRequest {
+	return GoalRequest{
+		Name:         " House ",
+		TargetAmount: amountPtr("10000"),
+		Currency:     "usd",
+		Deadline:     "2026-12-31",
+		WalletIDs:    []string{"wallet1", " wallet2 "},
+	}
+}
+
+func sampleGoal() *queries.GoalResponse {
+	return &queries.GoalResponse{
+		ID:           "goal1",
+		Name:         "House",
+		TargetAmount: shareddomain.MustParseAmount("10000"),
+		Currency:     "USD",
+		Deadline:     time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC),
+		WalletIDs:    []string{"wallet1"},
+	}
+}
+
+func TestCreateGoal_Success(t *testing.T) {
+	service := &mockGoalService{}
+	handler := &Handler{goalService: service}
+
+	jsonBody, _ := json.Marshal(validGoalBody())
+
+	req := httptest.NewRequest("POST", "/goals", bytes.NewBuffer(jsonBody))
+	rr := httptest.NewRecorder()
+	handler.CreateGoal(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if service.lastCommand.Name != "House" || service.lastCommand.WalletIDs[1] != "wallet2" {
+		t.Errorf("expected trimmed command, got %+v", service.lastCommand)
+	}
+	if !service.lastCommand.Deadline.Equal(time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected deadline %v", service.lastCommand.Deadline)
+	}
+}
+
+func TestCreateGoal_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*GoalRequest)
+	}{
+		{"missing name", func(r *GoalRequest) { r.Name = " " }},
+		{"missing target", func(r *GoalRequest) { r.TargetAmount = nil }},
+		{"negative target", func(r *GoalRequest) { r.TargetAmount = amountPtr("-5") }},
+		{"missing currency", func(r *GoalRequest) { r.Currency = "" }},
+		{"missing deadline", func(r *GoalRequest) { r.Deadline = "" }},
+		{"invalid deadline", func(r *GoalRequest) { r.Deadline = "31/12/2026" }},
+		{"no wallets", func(r *GoalRequest) { r.WalletIDs = nil }},
+		{"empty wallet", func(r *GoalRequest) { r.WalletIDs = []string{""} }},
+		{"too many wallets", func(r *GoalRequest) { r.WalletIDs = make([]string, domain.MaxGoalWallets+1) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := validGoalBody()
+			tt.mutate(&body)
+
+			if _, err := toGoalCommand(body); err == nil {
+				t.Error("expected validation error, got nil")
+			}
+		})
+	}
+}
+
+func TestCreateGoal_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"unauthenticated", errors.New("user not authenticated"), http.StatusUnauthorized},
+		{"foreign wallet", errors.New("unauthorized access to wallet"), http.StatusForbidden},
+		{"missing wallet", errors.New("wallet not found"), http.StatusBadRequest},
+		{"not savings", domain.ErrNotSavingsWallet, http.StatusBadRequest},
+		{"duplicate wallet", domain.ErrDuplicateWallet, http.StatusBadRequest},
+		{"invalid currency", errors.New("invalid currency"), http.StatusBadRequest},
+		{"precision", shareddomain.ErrAmountPrecision, http.StatusBadRequest},
+		{"unexpected", errors.New("boom"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &Handler{goalService: &mockGoalService{createErr: tt.err}}
+
+			jsonBody, _ := json.Marshal(validGoalBody())
+			req := httptest.NewRequest("POST", "/goals", bytes.NewBuffer(jsonBody))
+			rr := httptest.NewRecorder()
+			handler.CreateGoal(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestGetGoal_Success(t *testing.T) {
+	service := &mockGoalService{goal: sampleGoal()}
+	handler := &Handler{goalService: service}
+
+	req := httptest.NewRequest("GET", "/goals/goal1", nil)
+	rr := httptest.NewRecorder()
+	handler.GetGoal(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if service.lastID != "goal1" {
+		t.Errorf("expected id goal1, got %s", service.lastID)
+	}
+
+	var response GoalResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Deadline != "2026-12-31" || len(response.WalletIDs) != 1 {
+		t.Errorf("unexpected response: %+v", response)
+	}
+}
+
+func TestGetGoal_NotFound(t *testing.T) {
+	handler := &Handler{goalService: &mockGoalService{getByIDErr: errors.New("goal not found")}}
+
+	req := httptest.NewRequest("GET", "/goals/missing", nil)
+	rr := httptest.NewRecorder()
+	handler.GetGoal(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", rr.Code)
+	}
+}
+
+func TestUpdateGoal_Success(t *testing.T) {
+	service := &mockGoalService{}
+	handler := &Handler{goalService: service}
+
+	jsonBody, _ := json.Marshal(validGoalBody())
+	req := httptest.NewRequest("PUT", "/goals/goal1", bytes.NewBuffer(jsonBody))
+	rr := httptest.NewRecorder()
+	handler.UpdateGoal(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if service.lastID != "goal1" || service.lastCommand.TargetAmount.String() != "10000" {
+		t.Errorf("unexpected update %s %+v", service.lastID, service.lastCommand)
+	}
+}
+
+func TestDeleteGoal_Forbidden(t *testing.T) {
+	handler := &Handler{goalService: &mockGoalService{deleteErr: errors.New("unauthorized access to goal")}}
+
+	req := httptest.NewRequest("DELETE", "/goals/goal1", nil)
+	rr := httptest.NewRecorder()
+	handler.DeleteGoal(rr, req)
+
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d", rr.Code)
+	}
+}
+
+func TestListGoals_Success(t *testing.T) {
+	handler := &Handler{goalService: &mockGoalService{goals: []*queries.GoalResponse{sampleGoal()}}}
+
+	req := httptest.NewRequest("GET", "/goals", nil)
+	rr := httptest.NewRecorder()
+	handler.ListGoals(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+
+	var response []GoalResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response) != 1 {
+		t.Errorf("expected 1 goal, got %d", len(response))
+	}
+}
+
+func TestGetProgress_Success(t *testing.T) {
+	projected := time.Date(2026, 11, 10, 0, 0, 0, 0, time.UTC)
+	service := &mockGoalService{progress: &queries.GoalProgressResponse{
+		GoalID:                    "goal1",
+		Currency:                  "USD",
+		Deadline:                  time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC),
+		Date:                      time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC),
+		Saved:                     shareddomain.MustParseAmount("4000"),
+		MonthlyContributionNeeded: shareddomain.MustParseAmount("750"),
+		ProjectedCompletion:       &projected,
+		OnTrack:                   true,
+		Contributions: []*queries.MonthlyContributionResponse{
+			{Month: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), Amount: shareddomain.MustParseAmount("1000")},
+		},
+	}}
+	handler := &Handler{goalService: service}
+
+	req := httptest.NewRequest("GET", "/goals/goal1/progress?months=3", nil)
+	rr := httptest.NewRecorder()
+	handler.GetProgress(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if service.lastID != "goal1" || service.lastMonths != 3 {
+		t.Errorf("expected goal1 over 3 months, got %s over %d", service.lastID, service.lastMonths)
+	}
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response["projected_completion"] != "2026-11-10" || response["monthly_contribution_needed"] != "750" {
+		t.Errorf("unexpected response: %v", response)
+	}
+	contributions := response["contributions"].([]interface{})
+	if contributions[0].(map[string]interface{})["month"] != "2026-04" {
+		t.Errorf("unexpected contributions: %v", contributions)
+	}
+}
+
+func TestGetProgress_NoProjection(t *testing.T) {
+	service := &mockGoalService{progress: &queries.GoalProgressResponse{GoalID: "goal1"}}
+	handler := &Handler{goalService: service}
+
+	req := httptest.NewRequest("GET", "/goals/goal1/progress", nil)
+	rr := httptest.NewRecorder()
+	handler.GetProgress(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if service.lastMonths != 0 {
+		t.Errorf("expected the default history, got %d months", service.lastMonths)
+	}
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if _, ok := response["projected_completion"]; ok {
+		t.Errorf("expected no projected completion, got %v", response["projected_completion"])
+	}
+}
+
+func TestGetProgress_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		err        error
+		wantStatus int
+	}{
+		{"months not a number", "?months=six", nil, http.StatusBadRequest},
+		{"months too low", "?months=0", nil, http.StatusBadRequest},
+		{"months too high", "?months=25", nil, http.StatusBadRequest},
+		{"missing rate", "", fmt.Errorf("%w for wallet %q", exchangeratedomain.ErrRateNotFound, "Savings"), http.StatusNotFound},
+		{"foreign goal", "", errors.New("unauthorized access to goal"), http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &Handler{goalService: &mockGoalService{progressErr: tt.err, progress: &queries.GoalProgressResponse{}}}
+
+			req := httptest.NewRequest("GET", "/goals/goal1/progress"+tt.query, nil)
+			rr := httptest.NewRecorder()
+			handler.GetProgress(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func amountPtr(value string) *shareddomain.Amount {
+	amount := shareddomain.MustParseAmount(value)
+	return &amount
+}
@@ -0,0 +1,57 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"fin-flow-api/internal/shared/interface/jwt"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+var goalHandler *Handler
+
+func SetupRoutes(mux *http.ServeMux, jwtService jwt.Service) {
+	mountGoals(mux, jwtService)
+}
+
+func mountGoals(mux *http.ServeMux, jwtService jwt.Service) {
+	mux.HandleFunc("/goals", handleGoalsCollection(jwtService))
+
+	protectedHandler := middleware.RequireAuth(jwtService)(http.HandlerFunc(handleGoalsResource))
+	mux.Handle("/goals/", protectedHandler)
+}
+
+func handleGoalsCollection(jwtService jwt.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.RequireAuth(jwtService)(http.HandlerFunc(goalHandler.ListGoals)).ServeHTTP(w, r)
+		case http.MethodPost:
+			middleware.RequireAuth(jwtService)(http.HandlerFunc(goalHandler.CreateGoal)).ServeHTTP(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func handleGoalsResource(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(r.URL.Path, "/progress") {
+		goalHandler.GetProgress(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		goalHandler.GetGoal(w, r)
+	case http.MethodPut:
+		goalHandler.UpdateGoal(w, r)
+	case http.MethodDelete:
+		goalHandler.DeleteGoal(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func SetHandler(handler *Handler) {
+	goalHandler = handler
+}
@@ -0,0 +1,19 @@
+package commands
+
+// ImportRequest is a statement to preview or commit into a wallet.
+// Format is csv, ofx, qif, camt053 or mt940, guessed from the file when
+// empty. CSV statements need a ProfileID. WalletID may be left out for
+// files whose accounts are matched to wallets by their account id.
+// Expenses and income no categorization rule picks a category for fall
+// back to ExpenseCategoryID and IncomeCategoryID. ExcludeLines lists the
+// lines of the file to leave out.
+type ImportRequest struct {
+	Format            string
+	WalletID          string
+	ProfileID         string
+	FileName          string
+	Content           []byte
+	ExpenseCategoryID string
+	IncomeCategoryID  string
+	ExcludeLines      []int
+}
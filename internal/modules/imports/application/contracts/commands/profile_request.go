@@ -0,0 +1,18 @@
+package commands
+
+type ProfileRequest struct {
+	Name              string
+	Delimiter         string
+	Encoding          string
+	SkipRows          int
+	HasHeader         bool
+	DateColumn        int
+	DateFormat        string
+	DescriptionColumn int
+	PayeeColumn       int
+	AmountColumn      int
+	DebitColumn       int
+	CreditColumn      int
+	DecimalSeparator  string
+	NegateAmounts     bool
+}
@@ -0,0 +1,90 @@
+package queries
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+// ImportLineResponse is a statement line as it would be committed into
+// WalletID. Amount is positive and Type tells expenses from income. Lines
+// with an Error keep the whole statement from being committed unless they
+// are excluded. AlreadyImported lines are skipped, since the wallet has a
+// transaction with the same ExternalID. PossibleDuplicateIDs are the
+// transactions of the wallet a line that is ready is likely a duplicate of,
+// such as one entered by hand; the line is committed all the same unless it
+// is excluded.
+type ImportLineResponse struct {
+	Line                 int
+	WalletID             string
+	Date                 time.Time
+	ValueDate            *time.Time
+	Description          string
+	Payee                string
+	Amount               domain.Amount
+	Type                 int
+	TypeName             string
+	CategoryID           string
+	Tags                 []string
+	ExternalID           string
+	Excluded             bool
+	AlreadyImported      bool
+	PossibleDuplicateIDs []string
+	Error                string
+}
+
+// ImportPreviewResponse lists the lines of a statement and adds up those
+// that are ready to be committed. WalletID is empty when the lines go to
+// several wallets.
+type ImportPreviewResponse struct {
+	Format               string
+	WalletID             string
+	ProfileID            string
+	Lines                []*ImportLineResponse
+	ReadyCount           int
+	ExcludedCount        int
+	AlreadyImportedCount int
+	ErrorCount           int
+	// PossibleDuplicateCount is how many of the lines that are ready are
+	// likely duplicates.
+	PossibleDuplicateCount int
+	TotalIncome            domain.Amount
+	TotalExpenses          domain.Amount
+	Statements             []*ImportStatementResponse
+}
+
+// ImportStatementResponse reconciles a statement of the file. Movement is
+// what its lines add up to, and Balanced tells whether it takes the
+// opening balance to the closing balance. BalanceAfterImport is what
+// WalletBalance becomes once the lines that are ready are committed, and
+// Difference what it falls short of the closing balance. Balances the file
+// or the wallet do not give are nil.
+type ImportStatementResponse struct {
+	Account            string
+	WalletID           string
+	Currency           string
+	LineCount          int
+	OpeningBalance     *domain.Amount
+	ClosingBalance     *domain.Amount
+	Movement           domain.Amount
+	Balanced           bool
+	WalletBalance      *domain.Amount
+	BalanceAfterImport *domain.Amount
+	Difference         *domain.Amount
+	Reconciled         bool
+}
+
+type BatchResponse struct {
+	ID               string
+	WalletID         string
+	ProfileID        string
+	Format           string
+	FileName         string
+	TransactionCount int
+	Status           string
+	UndoneAt         *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	CreatedBy        string
+	UpdatedBy        string
+}
@@ -0,0 +1,25 @@
+package queries
+
+import "time"
+
+type ProfileResponse struct {
+	ID                string
+	Name              string
+	Delimiter         string
+	Encoding          string
+	SkipRows          int
+	HasHeader         bool
+	DateColumn        int
+	DateFormat        string
+	DescriptionColumn int
+	PayeeColumn       int
+	AmountColumn      int
+	DebitColumn       int
+	CreditColumn      int
+	DecimalSeparator  string
+	NegateAmounts     bool
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	CreatedBy         string
+	UpdatedBy         string
+}
@@ -0,0 +1,634 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	categorydomain "fin-flow-api/internal/modules/categories/domain"
+	"fin-flow-api/internal/modules/imports/application/contracts/commands"
+	"fin-flow-api/internal/modules/imports/application/contracts/queries"
+	"fin-flow-api/internal/modules/imports/domain"
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	"fin-flow-api/internal/shared/middleware"
+
+	"github.com/google/uuid"
+)
+
+// categorizer applies the categorization rules of a user to the
+// transactions of a statement.
+type categorizer interface {
+	CategorizeAll(transactions []*transactiondomain.Transaction) error
+}
+
+// duplicateFinder looks for the existing transactions that the
+// transactions of a statement are likely duplicates of.
+type duplicateFinder interface {
+	FindDuplicatesAll(transactions []*transactiondomain.Transaction) (map[string][]string, error)
+}
+
+type ImportService struct {
+	profileRepository     domain.ProfileRepository
+	batchRepository       domain.BatchRepository
+	transactionRepository transactiondomain.TransactionRepository
+	walletRepository      walletdomain.WalletRepository
+	categoryRepository    categorydomain.CategoryRepository
+	categorizer           categorizer
+	duplicateFinder       duplicateFinder
+	systemUser            string
+}
+
+// NewImportService returns the import service. categorizer may be nil, in
+// which case every line takes the default category of its type, and so may
+// duplicateFinder, in which case previews do not look for duplicates.
+func NewImportService(profileRepository domain.ProfileRepository, batchRepository domain.BatchRepository, transactionRepository transactiondomain.TransactionRepository, walletRepository walletdomain.WalletRepository, categoryRepository categorydomain.CategoryRepository, categorizer categorizer, duplicateFinder duplicateFinder, systemUser string) *ImportService {
+	return &ImportService{
+		profileRepository:     profileRepository,
+		batchRepository:       batchRepository,
+		transactionRepository: transactionRepository,
+		walletRepository:      walletRepository,
+		categoryRepository:    categoryRepository,
+		categorizer:           categorizer,
+		duplicateFinder:       duplicateFinder,
+		systemUser:            systemUser,
+	}
+}
+
+func (s *ImportService) getUserIDFromContext(ctx context.Context) (string, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return "", errors.New("user not authenticated")
+	}
+	return userID, nil
+}
+
+// importLine is a statement line with the wallet it goes to and the
+// transaction it becomes.
+type importLine struct {
+	statement       int
+	line            *domain.StatementLine
+	wallet          *walletdomain.Wallet
+	transaction     *transactiondomain.Transaction
+	excluded        bool
+	alreadyImported bool
+	err             string
+}
+
+// preparedImport is a statement file read into lines for the wallets of
+// the user. Each line knows the index of its statement, which goes to the
+// wallet at the same index, if any.
+type preparedImport struct {
+	format     string
+	statements []*domain.Statement
+	wallets    []*walletdomain.Wallet
+	lines      []*importLine
+}
+
+// Preview reads a statement and shows the transactions it would create,
+// without creating them.
+func (s *ImportService) Preview(ctx context.Context, req commands.ImportRequest) (*queries.ImportPreviewResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prepared, err := s.prepare(userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	duplicates, err := s.findDuplicates(prepared)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &queries.ImportPreviewResponse{
+		Format:    prepared.format,
+		WalletID:  commonWalletID(prepared.lines),
+		ProfileID: req.ProfileID,
+		Lines:     make([]*queries.ImportLineResponse, len(prepared.lines)),
+	}
+	for i, line := range prepared.lines {
+		preview.Lines[i] = toImportLineResponse(line)
+
+		switch {
+		case line.excluded:
+			preview.ExcludedCount++
+		case line.alreadyImported:
+			preview.AlreadyImportedCount++
+		case line.err != "":
+			preview.ErrorCount++
+		default:
+			preview.ReadyCount++
+			if ids := duplicates[line.transaction.ID]; len(ids) > 0 {
+				preview.Lines[i].PossibleDuplicateIDs = ids
+				preview.PossibleDuplicateCount++
+			}
+			if line.transaction.Type == transactiondomain.TransactionTypeIncome {
+				preview.TotalIncome = preview.TotalIncome.Add(line.transaction.Amount)
+			} else {
+				preview.TotalExpenses = preview.TotalExpenses.Add(line.transaction.Amount)
+			}
+		}
+	}
+
+	preview.Statements = reconcileStatements(prepared)
+
+	return preview, nil
+}
+
+// findDuplicates looks for the existing transactions that the lines ready
+// to be committed are likely duplicates of, keyed by the id of the
+// transaction each line becomes.
+func (s *ImportService) findDuplicates(prepared *preparedImport) (map[string][]string, error) {
+	if s.duplicateFinder == nil {
+		return nil, nil
+	}
+
+	var transactions []*transactiondomain.Transaction
+	for _, line := range prepared.lines {
+		if line.transaction != nil && line.err == "" {
+			transactions = append(transactions, line.transaction)
+		}
+	}
+	if len(transactions) == 0 {
+		return nil, nil
+	}
+
+	return s.duplicateFinder.FindDuplicatesAll(transactions)
+}
+
+// reconcileStatements checks the balances each statement reports against
+// its own lines and against its wallet: the opening balance plus the lines
+// must make the closing balance, and the wallet, once the lines that are
+// ready are committed, should hold the closing balance as well.
+func reconcileStatements(prepared *preparedImport) []*queries.ImportStatementResponse {
+	responses := make([]*queries.ImportStatementResponse, len(prepared.statements))
+	for i, statement := range prepared.statements {
+		response := &queries.ImportStatementResponse{
+			Account:        statement.Account,
+			Currency:       statement.Currency,
+			OpeningBalance: statement.OpeningBalance,
+			ClosingBalance: statement.ClosingBalance,
+		}
+		responses[i] = response
+
+		readable := true
+		for _, line := range statement.Lines {
+			if line.Error != "" {
+				readable = false
+				continue
+			}
+			response.Movement = response.Movement.Add(line.Amount)
+		}
+		response.LineCount = len(statement.Lines)
+		if readable && statement.OpeningBalance != nil && statement.ClosingBalance != nil {
+			response.Balanced = statement.OpeningBalance.Add(response.Movement).Equal(*statement.ClosingBalance)
+		}
+
+		wallet := prepared.wallets[i]
+		if wallet == nil {
+			continue
+		}
+		response.WalletID = wallet.ID
+		if statement.Currency != "" && statement.Currency != wallet.Currency.String() {
+			continue
+		}
+
+		balance := wallet.Balance
+		for _, line := range prepared.lines {
+			if line.statement == i && line.transaction != nil && line.err == "" {
+				balance = balance.Add(line.line.Amount)
+			}
+		}
+		walletBalance := wallet.Balance
+		response.WalletBalance = &walletBalance
+		response.BalanceAfterImport = &balance
+		if statement.ClosingBalance != nil {
+			difference := statement.ClosingBalance.Sub(balance)
+			response.Difference = &difference
+			response.Reconciled = difference.IsZero()
+		}
+	}
+	return responses
+}
+
+// Commit creates the transactions of a statement as a new import batch.
+// Either every line that is neither excluded nor already imported is
+// committed or, if one of them has an error, none is.
+func (s *ImportService) Commit(ctx context.Context, req commands.ImportRequest) (*queries.BatchResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prepared, err := s.prepare(userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []*importLine
+	var transactions []*transactiondomain.Transaction
+	for _, line := range prepared.lines {
+		if line.excluded || line.alreadyImported {
+			continue
+		}
+		if line.err != "" {
+			return nil, &ImportError{Line: line.line.Line, Message: line.err}
+		}
+		lines = append(lines, line)
+		transactions = append(transactions, line.transaction)
+	}
+
+	if len(transactions) == 0 {
+		return nil, domain.ErrNothingToImport
+	}
+
+	batch := domain.NewBatch(uuid.New().String(), userID, commonWalletID(lines), req.ProfileID, prepared.format, req.FileName, len(transactions), s.systemUser)
+	for _, transaction := range transactions {
+		transaction.ImportBatchID = batch.ID
+	}
+
+	if err := s.batchRepository.Create(batch, transactions); err != nil {
+		return nil, err
+	}
+
+	return toBatchResponse(batch), nil
+}
+
+// Undo deletes the transactions of an import batch and marks it as undone.
+// Transactions edited since the import are deleted as well.
+func (s *ImportService) Undo(ctx context.Context, id string) (*queries.BatchResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	batch, err := s.batchRepository.GetByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := batch.Undo(s.systemUser); err != nil {
+		return nil, err
+	}
+
+	if err := s.batchRepository.Undo(batch); err != nil {
+		return nil, err
+	}
+
+	return toBatchResponse(batch), nil
+}
+
+func (s *ImportService) GetByID(ctx context.Context, id string) (*queries.BatchResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	batch, err := s.batchRepository.GetByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toBatchResponse(batch), nil
+}
+
+func (s *ImportService) List(ctx context.Context) ([]*queries.BatchResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	batches, err := s.batchRepository.List(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*queries.BatchResponse, len(batches))
+	for i, batch := range batches {
+		responses[i] = toBatchResponse(batch)
+	}
+
+	return responses, nil
+}
+
+// prepare reads a statement file and turns each line that is neither
+// excluded nor already imported into a transaction of its wallet,
+// categorized by the rules of the user or else with the default category
+// of its type.
+func (s *ImportService) prepare(userID string, req commands.ImportRequest) (*preparedImport, error) {
+	format := req.Format
+	if format == "" {
+		format = domain.DetectFormat(req.FileName, req.Content)
+	}
+	if !domain.IsValidFormat(format) {
+		return nil, domain.ErrInvalidFormat
+	}
+
+	var profile *domain.Profile
+	if req.ProfileID != "" {
+		var err error
+		if profile, err = s.profileRepository.GetByID(req.ProfileID, userID); err != nil {
+			return nil, err
+		}
+	} else if format == domain.FormatCSV {
+		return nil, domain.ErrProfileRequired
+	}
+
+	defaults, err := s.defaultCategories(userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	statements, err := parseStatement(format, req.Content, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	wallets, err := s.statementWallets(userID, req.WalletID, statements)
+	if err != nil {
+		return nil, err
+	}
+
+	imported, err := s.importedExternalIDs(userID, statements, wallets)
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := make(map[int]bool, len(req.ExcludeLines))
+	for _, number := range req.ExcludeLines {
+		excluded[number] = true
+	}
+
+	var lines []*importLine
+	var transactions []*transactiondomain.Transaction
+	for i, statement := range statements {
+		wallet := wallets[i]
+		statementErr := ""
+		switch {
+		case wallet == nil:
+			statementErr = fmt.Sprintf("no wallet has the account id %q", statement.Account)
+		case statement.Currency != "" && statement.Currency != wallet.Currency.String():
+			statementErr = fmt.Sprintf("the statement is in %s but the wallet is in %s", statement.Currency, wallet.Currency)
+		}
+
+		for _, statementLine := range statement.Lines {
+			line := &importLine{statement: i, line: statementLine, wallet: wallet, excluded: excluded[statementLine.Line], err: statementLine.Error}
+			lines = append(lines, line)
+			if line.err == "" {
+				line.err = statementErr
+			}
+			if line.err != "" {
+				continue
+			}
+
+			// A transaction repeated within the file is imported once.
+			if id := statementLine.ExternalID; id != "" {
+				if imported[wallet.ID][id] {
+					line.alreadyImported = true
+					continue
+				}
+				if !line.excluded {
+					imported[wallet.ID][id] = true
+				}
+			}
+
+			currency := wallet.Currency.String()
+			amount := statementLine.Amount.Abs()
+			switch {
+			case !amount.FitsCurrency(currency):
+				line.err = fmt.Sprintf("amount has more decimal places than %s allows", currency)
+			case utf8.RuneCountInString(statementLine.Description) > 500:
+				line.err = "description is longer than 500 characters"
+			case utf8.RuneCountInString(statementLine.Payee) > 255:
+				line.err = "payee is longer than 255 characters"
+			case len(statementLine.ExternalID) > 255:
+				line.err = "transaction id is longer than 255 characters"
+			}
+			if line.err != "" || line.excluded {
+				continue
+			}
+
+			line.transaction = transactiondomain.NewTransaction(
+				uuid.New().String(),
+				userID,
+				wallet.ID,
+				"",
+				statementLine.TransactionType(),
+				amount,
+				statementLine.Description,
+				statementLine.Date,
+				s.systemUser,
+			)
+			line.transaction.Payee = statementLine.Payee
+			line.transaction.ExternalID = statementLine.ExternalID
+			line.transaction.ValueDate = statementLine.ValueDate
+			transactions = append(transactions, line.transaction)
+		}
+	}
+
+	if s.categorizer != nil {
+		if err := s.categorizer.CategorizeAll(transactions); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, line := range lines {
+		if line.transaction == nil || line.transaction.CategoryID != "" {
+			continue
+		}
+		line.transaction.CategoryID = defaults[line.transaction.Type]
+		if line.transaction.CategoryID == "" {
+			line.err = fmt.Sprintf("no rule picks a category and no default %s category was given", strings.ToLower(line.transaction.Type.String()))
+		}
+	}
+
+	return &preparedImport{format: format, statements: statements, wallets: wallets, lines: lines}, nil
+}
+
+func parseStatement(format string, content []byte, profile *domain.Profile) ([]*domain.Statement, error) {
+	switch format {
+	case domain.FormatOFX:
+		return ParseStatementOFX(content)
+	case domain.FormatQIF:
+		return ParseStatementQIF(content, profile)
+	case domain.FormatCAMT053:
+		return ParseStatementCAMT053(content)
+	case domain.FormatMT940:
+		return ParseStatementMT940(content)
+	}
+
+	lines, err := ParseStatementCSV(content, profile)
+	if err != nil {
+		return nil, err
+	}
+	return []*domain.Statement{{Lines: lines}}, nil
+}
+
+// statementWallets finds the wallet each statement goes to. The wallet of
+// the request takes a file with a single statement and the statements
+// that do not name their account; any other statement goes to the wallet
+// whose account id is its account, or to none if no wallet has it.
+// Account ids are compared ignoring case and spaces, which IBANs are often
+// written with.
+func (s *ImportService) statementWallets(userID string, walletID string, statements []*domain.Statement) ([]*walletdomain.Wallet, error) {
+	wallets := make([]*walletdomain.Wallet, len(statements))
+	var requested *walletdomain.Wallet
+	var byAccount map[string]*walletdomain.Wallet
+
+	for i, statement := range statements {
+		switch {
+		case walletID != "" && (len(statements) == 1 || statement.Account == ""):
+			if requested == nil {
+				wallet, err := s.walletRepository.GetByID(walletID, userID)
+				if err != nil {
+					return nil, err
+				}
+				requested = wallet
+			}
+			wallets[i] = requested
+		case statement.Account == "":
+			return nil, domain.ErrWalletRequired
+		default:
+			if byAccount == nil {
+				list, err := s.walletRepository.List(userID)
+				if err != nil {
+					return nil, err
+				}
+				byAccount = make(map[string]*walletdomain.Wallet, len(list))
+				for _, wallet := range list {
+					if wallet.AccountID != "" {
+						byAccount[normalizeAccountID(wallet.AccountID)] = wallet
+					}
+				}
+			}
+			wallets[i] = byAccount[normalizeAccountID(statement.Account)]
+		}
+	}
+
+	return wallets, nil
+}
+
+func normalizeAccountID(accountID string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(accountID), ""))
+}
+
+// importedExternalIDs returns, for each wallet statements go to, which of
+// the external ids of their lines the wallet already has.
+func (s *ImportService) importedExternalIDs(userID string, statements []*domain.Statement, wallets []*walletdomain.Wallet) (map[string]map[string]bool, error) {
+	externalIDs := make(map[string][]string)
+	for i, statement := range statements {
+		if wallets[i] == nil {
+			continue
+		}
+		ids := externalIDs[wallets[i].ID]
+		for _, line := range statement.Lines {
+			if line.ExternalID != "" {
+				ids = append(ids, line.ExternalID)
+			}
+		}
+		externalIDs[wallets[i].ID] = ids
+	}
+
+	imported := make(map[string]map[string]bool, len(externalIDs))
+	for walletID, ids := range externalIDs {
+		if len(ids) == 0 {
+			imported[walletID] = make(map[string]bool)
+			continue
+		}
+		found, err := s.transactionRepository.FindExternalIDs(walletID, ids, userID)
+		if err != nil {
+			return nil, err
+		}
+		imported[walletID] = found
+	}
+
+	return imported, nil
+}
+
+// commonWalletID returns the wallet all the lines go to, or an empty
+// string when they go to several.
+func commonWalletID(lines []*importLine) string {
+	walletID := ""
+	for _, line := range lines {
+		if line.wallet == nil {
+			continue
+		}
+		if walletID != "" && walletID != line.wallet.ID {
+			return ""
+		}
+		walletID = line.wallet.ID
+	}
+	return walletID
+}
+
+// defaultCategories checks the fallback categories of a request and maps
+// them to the transaction type they are for.
+func (s *ImportService) defaultCategories(userID string, req commands.ImportRequest) (map[transactiondomain.TransactionType]string, error) {
+	defaults := map[transactiondomain.TransactionType]string{
+		transactiondomain.TransactionTypeExpense: req.ExpenseCategoryID,
+		transactiondomain.TransactionTypeIncome:  req.IncomeCategoryID,
+	}
+
+	for transactionType, categoryID := range defaults {
+		if categoryID == "" {
+			continue
+		}
+		category, err := s.categoryRepository.GetByID(categoryID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !transactionType.AcceptsCategory(category.Type) {
+			return nil, transactiondomain.ErrCategoryTypeMismatch
+		}
+	}
+
+	return defaults, nil
+}
+
+func toImportLineResponse(line *importLine) *queries.ImportLineResponse {
+	response := &queries.ImportLineResponse{
+		Line:            line.line.Line,
+		Date:            line.line.Date,
+		ValueDate:       line.line.ValueDate,
+		Description:     line.line.Description,
+		Payee:           line.line.Payee,
+		Amount:          line.line.Amount.Abs(),
+		Type:            line.line.TransactionType().Value(),
+		TypeName:        line.line.TransactionType().String(),
+		ExternalID:      line.line.ExternalID,
+		Excluded:        line.excluded,
+		AlreadyImported: line.alreadyImported,
+		Error:           line.err,
+	}
+	if line.wallet != nil {
+		response.WalletID = line.wallet.ID
+	}
+	if line.transaction != nil {
+		response.Payee = line.transaction.Payee
+		response.CategoryID = line.transaction.CategoryID
+		response.Tags = line.transaction.Tags
+	}
+	return response
+}
+
+func toBatchResponse(batch *domain.Batch) *queries.BatchResponse {
+	return &queries.BatchResponse{
+		ID:               batch.ID,
+		WalletID:         batch.WalletID,
+		ProfileID:        batch.ProfileID,
+		Format:           batch.Format,
+		FileName:         batch.FileName,
+		TransactionCount: batch.TransactionCount,
+		Status:           string(batch.Status),
+		UndoneAt:         batch.UndoneAt,
+		CreatedAt:        batch.CreatedAt,
+		UpdatedAt:        batch.ModifiedAt,
+		CreatedBy:        batch.CreatedBy,
+		UpdatedBy:        batch.ModifiedBy,
+	}
+}
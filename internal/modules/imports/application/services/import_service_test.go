@@ -0,0 +1,745 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+
+	categorydomain "fin-flow-api/internal/modules/categories/domain"
+	"fin-flow-api/internal/modules/imports/application/contracts/commands"
+	"fin-flow-api/internal/modules/imports/domain"
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	"fin-flow-api/internal/modules/transactions/domain/transactiontest"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+type mockProfileRepository struct {
+	profiles map[string]*domain.Profile
+}
+
+func (m *mockProfileRepository) Create(profile *domain.Profile) error {
+	m.profiles[profile.ID] = profile
+	return nil
+}
+
+func (m *mockProfileRepository) GetByID(id string, userID string) (*domain.Profile, error) {
+	profile, exists := m.profiles[id]
+	if !exists {
+		return nil, errors.New("import profile not found")
+	}
+	if profile.UserID != userID {
+		return nil, errors.New("unauthorized access to import profile")
+	}
+	return profile, nil
+}
+
+func (m *mockProfileRepository) List(userID string) ([]*domain.Profile, error) {
+	var result []*domain.Profile
+	for _, profile := range m.profiles {
+		if profile.UserID == userID {
+			result = append(result, profile)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockProfileRepository) Update(profile *domain.Profile) error {
+	m.profiles[profile.ID] = profile
+	return nil
+}
+
+func (m *mockProfileRepository) Delete(id string, userID string) error {
+	delete(m.profiles, id)
+	return nil
+}
+
+type mockBatchRepository struct {
+	batches      map[string]*domain.Batch
+	transactions *transactiontest.Repository
+	undoErr      error
+}
+
+func (m *mockBatchRepository) Create(batch *domain.Batch, transactions []*transactiondomain.Transaction) error {
+	if err := m.transactions.CreateAll(transactions); err != nil {
+		return err
+	}
+	m.batches[batch.ID] = batch
+	return nil
+}
+
+func (m *mockBatchRepository) GetByID(id string, userID string) (*domain.Batch, error) {
+	batch, exists := m.batches[id]
+	if !exists {
+		return nil, errors.New("import batch not found")
+	}
+	if batch.UserID != userID {
+		return nil, errors.New("unauthorized access to import batch")
+	}
+	copied := *batch
+	return &copied, nil
+}
+
+func (m *mockBatchRepository) List(userID string) ([]*domain.Batch, error) {
+	var result []*domain.Batch
+	for _, batch := range m.batches {
+		if batch.UserID == userID {
+			result = append(result, batch)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockBatchRepository) Undo(batch *domain.Batch) error {
+	if m.undoErr != nil {
+		return m.undoErr
+	}
+	m.transactions.DeleteImportBatch(batch.ID, batch.UserID)
+	m.batches[batch.ID] = batch
+	return nil
+}
+
+type mockWalletRepository struct {
+	wallets map[string]*walletdomain.Wallet
+}
+
+func (m *mockWalletRepository) Create(wallet *walletdomain.Wallet) error {
+	return nil
+}
+
+func (m *mockWalletRepository) GetByID(id string, userID string) (*walletdomain.Wallet, error) {
+	wallet, exists := m.wallets[id]
+	if !exists {
+		return nil, errors.New("wallet not found")
+	}
+	if wallet.UserID != userID {
+		return nil, errors.New("unauthorized access to wallet")
+	}
+	return wallet, nil
+}
+
+func (m *mockWalletRepository) List(userID string) ([]*walletdomain.Wallet, error) {
+	var result []*walletdomain.Wallet
+	for _, wallet := range m.wallets {
+		if wallet.UserID == userID {
+			result = append(result, wallet)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockWalletRepository) Update(wallet *walletdomain.Wallet) error {
+	return nil
+}
+
+func (m *mockWalletRepository) Delete(id string, userID string) error {
+	return nil
+}
+
+type mockCategoryRepository struct {
+	categories map[string]*categorydomain.Category
+}
+
+func (m *mockCategoryRepository) Create(category *categorydomain.Category) error {
+	return nil
+}
+
+func (m *mockCategoryRepository) CreateAll(categories []*categorydomain.Category) error {
+	return nil
+}
+
+func (m *mockCategoryRepository) GetByID(id string, userID string) (*categorydomain.Category, error) {
+	category, exists := m.categories[id]
+	if !exists {
+		return nil, errors.New("category not found")
+	}
+	if category.UserID != userID {
+		return nil, errors.New("unauthorized access to category")
+	}
+	return category, nil
+}
+
+func (m *mockCategoryRepository) List(userID string) ([]*categorydomain.Category, error) {
+	return nil, nil
+}
+
+func (m *mockCategoryRepository) Update(category *categorydomain.Category) error {
+	return nil
+}
+
+func (m *mockCategoryRepository) Delete(id string, userID string, strategy categorydomain.DeleteStrategy) error {
+	return nil
+}
+
+func (m *mockCategoryRepository) Merge(targetID string, sourceIDs []string, userID string) error {
+	return nil
+}
+
+// mockCategorizer puts expenses described as coffee in cat-coffee.
+type mockCategorizer struct {
+	calls int
+}
+
+func (m *mockCategorizer) CategorizeAll(transactions []*transactiondomain.Transaction) error {
+	m.calls++
+	for _, transaction := range transactions {
+		if transaction.Description == "Coffee" && transaction.CategoryID == "" {
+			transaction.CategoryID = "cat-coffee"
+			transaction.Payee = "Coffee Shop"
+		}
+	}
+	return nil
+}
+
+// mockDuplicateFinder takes every expense described as groceries for a
+// duplicate of manual-groceries.
+type mockDuplicateFinder struct {
+	checked int
+}
+
+func (m *mockDuplicateFinder) FindDuplicatesAll(transactions []*transactiondomain.Transaction) (map[string][]string, error) {
+	duplicates := make(map[string][]string)
+	for _, transaction := range transactions {
+		m.checked++
+		if transaction.Description == "Groceries" {
+			duplicates[transaction.ID] = []string{"manual-groceries"}
+		}
+	}
+	return duplicates, nil
+}
+
+func contextWithUser(userID string) context.Context {
+	return context.WithValue(context.Background(), middleware.UserIDKey, userID)
+}
+
+const testStatement = "Date,Description,Amount\n" +
+	"15/03/2026,Coffee,-4.50\n" +
+	"16/03/2026,Salary,2500.00\n" +
+	"17/03/2026,Groceries,-80.25\n"
+
+func newTestImportService() (*ImportService, *mockBatchRepository, *transactiontest.Repository, *mockCategorizer) {
+	profiles := &mockProfileRepository{profiles: map[string]*domain.Profile{"profile1": newTestProfile()}}
+	transactions := transactiontest.NewRepository()
+	batches := &mockBatchRepository{batches: make(map[string]*domain.Batch), transactions: transactions}
+	wallets := &mockWalletRepository{wallets: map[string]*walletdomain.Wallet{
+		"wallet1": walletdomain.NewWallet("wallet1", "user1", "Checking", walletdomain.WalletTypeBank, shareddomain.Amount{}, walletdomain.CurrencyUSD, "system"),
+		"wallet2": walletdomain.NewWallet("wallet2", "user2", "Other", walletdomain.WalletTypeBank, shareddomain.Amount{}, walletdomain.CurrencyUSD, "system"),
+		"wallet3": walletdomain.NewWallet("wallet3", "user1", "Visa", walletdomain.WalletTypeCreditCard, shareddomain.Amount{}, walletdomain.CurrencyUSD, "system"),
+	}}
+	wallets.wallets["wallet1"].AccountID = "0001-234"
+	wallets.wallets["wallet3"].AccountID = "4111"
+	wallets.wallets["wallet4"] = walletdomain.NewWallet("wallet4", "user1", "Girokonto", walletdomain.WalletTypeBank, shareddomain.MustParseAmount("100"), walletdomain.CurrencyEUR, "system")
+	wallets.wallets["wallet4"].AccountID = "DE89 3704 0044 0532 0130 00"
+	categories := &mockCategoryRepository{categories: map[string]*categorydomain.Category{
+		"cat-food":   categorydomain.NewCategory("cat-food", "user1", "Food", categorydomain.CategoryTypeExpense, "system"),
+		"cat-coffee": categorydomain.NewCategory("cat-coffee", "user1", "Coffee", categorydomain.CategoryTypeExpense, "system"),
+		"cat-salary": categorydomain.NewCategory("cat-salary", "user1", "Salary", categorydomain.CategoryTypeIncome, "system"),
+	}}
+	categorizer := &mockCategorizer{}
+	service := NewImportService(profiles, batches, transactions, wallets, categories, categorizer, nil, "system")
+	return service, batches, transactions, categorizer
+}
+
+func testImportRequest() commands.ImportRequest {
+	return commands.ImportRequest{
+		WalletID:          "wallet1",
+		ProfileID:         "profile1",
+		FileName:          "march.csv",
+		Content:           []byte(testStatement),
+		ExpenseCategoryID: "cat-food",
+		IncomeCategoryID:  "cat-salary",
+	}
+}
+
+func TestImportService_Preview(t *testing.T) {
+	service, batches, transactions, categorizer := newTestImportService()
+	req := testImportRequest()
+	req.ExcludeLines = []int{4}
+
+	preview, err := service.Preview(contextWithUser("user1"), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if preview.ReadyCount != 2 || preview.ExcludedCount != 1 || preview.ErrorCount != 0 {
+		t.Errorf("unexpected counts %+v", preview)
+	}
+	if !preview.TotalIncome.Equal(shareddomain.MustParseAmount("2500")) || !preview.TotalExpenses.Equal(shareddomain.MustParseAmount("4.5")) {
+		t.Errorf("unexpected totals %s / %s", preview.TotalIncome, preview.TotalExpenses)
+	}
+
+	coffee := preview.Lines[0]
+	if coffee.CategoryID != "cat-coffee" || coffee.Payee != "Coffee Shop" || coffee.Type != transactiondomain.TransactionTypeExpense.Value() {
+		t.Errorf("expected the rule to categorize the coffee, got %+v", coffee)
+	}
+	if preview.Lines[1].CategoryID != "cat-salary" {
+		t.Errorf("expected the default income category, got %q", preview.Lines[1].CategoryID)
+	}
+	if categorizer.calls != 1 {
+		t.Errorf("expected the rules to be applied once, got %d", categorizer.calls)
+	}
+
+	if len(batches.batches) != 0 || len(transactions.Transactions) != 0 {
+		t.Error("expected the preview to store nothing")
+	}
+}
+
+func TestImportService_Preview_PossibleDuplicates(t *testing.T) {
+	service, _, _, _ := newTestImportService()
+	finder := &mockDuplicateFinder{}
+	service.duplicateFinder = finder
+	req := testImportRequest()
+	req.ExcludeLines = []int{2}
+
+	preview, err := service.Preview(contextWithUser("user1"), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if finder.checked != 2 {
+		t.Errorf("expected only the lines that are ready to be checked, got %d", finder.checked)
+	}
+	if preview.PossibleDuplicateCount != 1 || preview.ReadyCount != 2 {
+		t.Errorf("unexpected counts %+v", preview)
+	}
+	groceries := preview.Lines[2]
+	if len(groceries.PossibleDuplicateIDs) != 1 || groceries.PossibleDuplicateIDs[0] != "manual-groceries" {
+		t.Errorf("expected the groceries to be flagged, got %v", groceries.PossibleDuplicateIDs)
+	}
+	if len(preview.Lines[1].PossibleDuplicateIDs) != 0 {
+		t.Errorf("expected the salary not to be flagged, got %v", preview.Lines[1].PossibleDuplicateIDs)
+	}
+}
+
+func TestImportService_Preview_MissingDefaultCategory(t *testing.T) {
+	service, _, _, _ := newTestImportService()
+	req := testImportRequest()
+	req.ExpenseCategoryID = ""
+
+	preview, err := service.Preview(contextWithUser("user1"), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if preview.ErrorCount != 1 || preview.Lines[2].Error == "" {
+		t.Errorf("expected the groceries to have no category, got %+v", preview.Lines[2])
+	}
+}
+
+func TestImportService_Preview_Validation(t *testing.T) {
+	tests := []struct {
+		name     string
+		userID   string
+		modify   func(req *commands.ImportRequest)
+		expected string
+	}{
+		{"foreign wallet", "user1", func(req *commands.ImportRequest) { req.WalletID = "wallet2" }, "unauthorized access to wallet"},
+		{"unknown profile", "user1", func(req *commands.ImportRequest) { req.ProfileID = "missing" }, "import profile not found"},
+		{"unknown category", "user1", func(req *commands.ImportRequest) { req.IncomeCategoryID = "missing" }, "category not found"},
+		{"wrong category type", "user1", func(req *commands.ImportRequest) { req.IncomeCategoryID = "cat-food" }, transactiondomain.ErrCategoryTypeMismatch.Error()},
+		{"unauthenticated", "", func(req *commands.ImportRequest) {}, "user not authenticated"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _, _, _ := newTestImportService()
+			req := testImportRequest()
+			tt.modify(&req)
+
+			ctx := context.Background()
+			if tt.userID != "" {
+				ctx = contextWithUser(tt.userID)
+			}
+
+			_, err := service.Preview(ctx, req)
+			if err == nil || err.Error() != tt.expected {
+				t.Errorf("expected %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestImportService_Commit(t *testing.T) {
+	service, batches, transactions, _ := newTestImportService()
+
+	batch, err := service.Commit(contextWithUser("user1"), testImportRequest())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if batch.TransactionCount != 3 || batch.Status != string(domain.BatchStatusCommitted) || batch.FileName != "march.csv" {
+		t.Errorf("unexpected batch %+v", batch)
+	}
+	if _, exists := batches.batches[batch.ID]; !exists {
+		t.Error("expected the batch to be stored")
+	}
+
+	var descriptions []string
+	for _, transaction := range transactions.Transactions {
+		if transaction.ImportBatchID != batch.ID || transaction.WalletID != "wallet1" {
+			t.Errorf("unexpected transaction %+v", transaction)
+		}
+		if !transaction.Amount.IsPositive() {
+			t.Errorf("expected a positive amount, got %s", transaction.Amount)
+		}
+		descriptions = append(descriptions, transaction.Description)
+	}
+	sort.Strings(descriptions)
+	if len(descriptions) != 3 || descriptions[0] != "Coffee" || descriptions[2] != "Salary" {
+		t.Errorf("unexpected transactions %v", descriptions)
+	}
+}
+
+func TestImportService_Commit_AllOrNothing(t *testing.T) {
+	service, batches, transactions, _ := newTestImportService()
+	req := testImportRequest()
+	req.Content = []byte(testStatement + "18/03/2026,Total,abc\n")
+
+	_, err := service.Commit(contextWithUser("user1"), req)
+
+	var importErr *ImportError
+	if !errors.As(err, &importErr) || importErr.Line != 5 {
+		t.Fatalf("expected an ImportError on line 5, got %v", err)
+	}
+	if len(batches.batches) != 0 || len(transactions.Transactions) != 0 {
+		t.Error("expected nothing to be stored")
+	}
+
+	req.ExcludeLines = []int{5}
+	if _, err := service.Commit(contextWithUser("user1"), req); err != nil {
+		t.Fatalf("expected the excluded line to be left out, got %v", err)
+	}
+	if len(transactions.Transactions) != 3 {
+		t.Errorf("expected 3 transactions, got %d", len(transactions.Transactions))
+	}
+}
+
+func TestImportService_Commit_StoreFails(t *testing.T) {
+	service, batches, transactions, _ := newTestImportService()
+	transactions.CreateErr = errors.New("failed to create transactions")
+
+	if _, err := service.Commit(contextWithUser("user1"), testImportRequest()); err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(batches.batches) != 0 || len(transactions.Transactions) != 0 {
+		t.Error("expected neither the batch nor its transactions to be stored")
+	}
+}
+
+func TestImportService_Commit_NothingToImport(t *testing.T) {
+	service, _, _, _ := newTestImportService()
+	req := testImportRequest()
+	req.ExcludeLines = []int{2, 3, 4}
+
+	if _, err := service.Commit(contextWithUser("user1"), req); err != domain.ErrNothingToImport {
+		t.Errorf("expected ErrNothingToImport, got %v", err)
+	}
+}
+
+func TestImportService_Undo(t *testing.T) {
+	service, batches, transactions, _ := newTestImportService()
+	ctx := contextWithUser("user1")
+
+	batch, err := service.Commit(ctx, testImportRequest())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	undone, err := service.Undo(ctx, batch.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if undone.Status != string(domain.BatchStatusUndone) || undone.UndoneAt == nil {
+		t.Errorf("expected the batch to be undone, got %+v", undone)
+	}
+	if batches.batches[batch.ID].Status != domain.BatchStatusUndone {
+		t.Error("expected the undone batch to be stored")
+	}
+	if len(transactions.Transactions) != 0 {
+		t.Errorf("expected the transactions to be deleted, got %d", len(transactions.Transactions))
+	}
+
+	if _, err := service.Undo(ctx, batch.ID); err != domain.ErrBatchAlreadyUndone {
+		t.Errorf("expected ErrBatchAlreadyUndone, got %v", err)
+	}
+	if _, err := service.Undo(contextWithUser("user2"), batch.ID); err == nil || err.Error() != "unauthorized access to import batch" {
+		t.Errorf("expected unauthorized access, got %v", err)
+	}
+}
+
+func TestImportService_Undo_StoreFails(t *testing.T) {
+	service, batches, transactions, _ := newTestImportService()
+	ctx := contextWithUser("user1")
+
+	batch, err := service.Commit(ctx, testImportRequest())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	batches.undoErr = errors.New("failed to update import batch: boom")
+	if _, err := service.Undo(ctx, batch.ID); err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(transactions.Transactions) != 3 {
+		t.Errorf("expected the transactions to be kept, got %d", len(transactions.Transactions))
+	}
+	if batches.batches[batch.ID].Status != domain.BatchStatusCommitted {
+		t.Errorf("expected the batch to stay committed, got %s", batches.batches[batch.ID].Status)
+	}
+}
+
+const testOFX = `<?xml version="1.0" encoding="UTF-8"?>
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>USD</CURDEF>
+<BANKACCTFROM><ACCTID>0001-234</ACCTID></BANKACCTFROM>
+<BANKTRANLIST>
+<STMTTRN><DTPOSTED>20260315</DTPOSTED><TRNAMT>-4.50</TRNAMT><FITID>A1</FITID><NAME>Coffee</NAME></STMTTRN>
+<STMTTRN><DTPOSTED>20260316</DTPOSTED><TRNAMT>2500.00</TRNAMT><FITID>A2</FITID><NAME>Salary</NAME></STMTTRN>
+<STMTTRN><DTPOSTED>20260316</DTPOSTED><TRNAMT>2500.00</TRNAMT><FITID>A2</FITID><NAME>Salary</NAME></STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+<CREDITCARDMSGSRSV1><CCSTMTTRNRS><CCSTMTRS>
+<CURDEF>USD</CURDEF>
+<CCACCTFROM><ACCTID>4111</ACCTID></CCACCTFROM>
+<BANKTRANLIST>
+<STMTTRN><DTPOSTED>20260317</DTPOSTED><TRNAMT>-80.25</TRNAMT><FITID>C1</FITID><NAME>Groceries</NAME></STMTTRN>
+</BANKTRANLIST>
+</CCSTMTRS></CCSTMTTRNRS></CREDITCARDMSGSRSV1>
+</OFX>
+`
+
+func testOFXRequest() commands.ImportRequest {
+	return commands.ImportRequest{
+		FileName:          "march.qfx",
+		Content:           []byte(testOFX),
+		ExpenseCategoryID: "cat-food",
+		IncomeCategoryID:  "cat-salary",
+	}
+}
+
+func TestImportService_OFX_MatchesAccounts(t *testing.T) {
+	service, _, transactions, _ := newTestImportService()
+	ctx := contextWithUser("user1")
+
+	preview, err := service.Preview(ctx, testOFXRequest())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if preview.Format != domain.FormatOFX || preview.WalletID != "" {
+		t.Errorf("expected an OFX preview over several wallets, got %q %q", preview.Format, preview.WalletID)
+	}
+	if preview.ReadyCount != 3 || preview.AlreadyImportedCount != 1 || preview.ErrorCount != 0 {
+		t.Errorf("unexpected counts %+v", preview)
+	}
+	if preview.Lines[0].WalletID != "wallet1" || preview.Lines[3].WalletID != "wallet3" {
+		t.Errorf("expected the accounts to be matched, got %q and %q", preview.Lines[0].WalletID, preview.Lines[3].WalletID)
+	}
+	if !preview.Lines[2].AlreadyImported {
+		t.Error("expected the repeated FITID to be skipped")
+	}
+
+	batch, err := service.Commit(ctx, testOFXRequest())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if batch.Format != domain.FormatOFX || batch.WalletID != "" || batch.TransactionCount != 3 {
+		t.Errorf("unexpected batch %+v", batch)
+	}
+	for _, transaction := range transactions.Transactions {
+		if transaction.ExternalID == "" {
+			t.Errorf("expected the FITID to be kept, got %+v", transaction)
+		}
+		if transaction.ExternalID == "C1" && transaction.WalletID != "wallet3" {
+			t.Errorf("expected the card transaction in wallet3, got %q", transaction.WalletID)
+		}
+	}
+
+	again, err := service.Preview(ctx, testOFXRequest())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again.ReadyCount != 0 || again.AlreadyImportedCount != 4 {
+		t.Errorf("expected everything to be imported already, got %+v", again)
+	}
+	if _, err := service.Commit(ctx, testOFXRequest()); err != domain.ErrNothingToImport {
+		t.Errorf("expected ErrNothingToImport, got %v", err)
+	}
+}
+
+func TestImportService_OFX_SingleAccountIntoWallet(t *testing.T) {
+	service, _, _, _ := newTestImportService()
+	content := `<OFX><STMTRS><CURDEF>USD<BANKACCTFROM><ACCTID>9999</BANKACCTFROM><BANKTRANLIST>` +
+		`<STMTTRN><DTPOSTED>20260315<TRNAMT>-4.50<FITID>Z1<NAME>Coffee</STMTTRN>` +
+		`</BANKTRANLIST></STMTRS></OFX>`
+
+	req := testOFXRequest()
+	req.Content = []byte(content)
+
+	preview, err := service.Preview(contextWithUser("user1"), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if preview.Lines[0].Error != `no wallet has the account id "9999"` {
+		t.Errorf("expected an unknown account error, got %q", preview.Lines[0].Error)
+	}
+
+	req.WalletID = "wallet1"
+	preview, err = service.Preview(contextWithUser("user1"), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if preview.WalletID != "wallet1" || preview.ReadyCount != 1 {
+		t.Errorf("expected the line to go to the given wallet, got %+v", preview)
+	}
+}
+
+func TestImportService_OFX_CurrencyMismatch(t *testing.T) {
+	service, _, _, _ := newTestImportService()
+	req := testOFXRequest()
+	req.Content = []byte(strings.Replace(testOFX, "<CURDEF>USD</CURDEF>", "<CURDEF>EUR</CURDEF>", 1))
+
+	preview, err := service.Preview(contextWithUser("user1"), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if preview.ErrorCount != 3 || preview.Lines[0].Error != "the statement is in EUR but the wallet is in USD" {
+		t.Errorf("expected the bank lines to fail, got %+v", preview.Lines[0])
+	}
+}
+
+func TestImportService_FormatRequirements(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      commands.ImportRequest
+		expected error
+	}{
+		{"csv without profile", commands.ImportRequest{WalletID: "wallet1", FileName: "march.csv", Content: []byte(testStatement)}, domain.ErrProfileRequired},
+		{"qif without account", commands.ImportRequest{Content: []byte("!Type:Bank\nD3/15/26\nT-4.50\n^\n")}, domain.ErrWalletRequired},
+		{"unknown format", commands.ImportRequest{Format: "xls", WalletID: "wallet1", Content: []byte("x")}, domain.ErrInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _, _, _ := newTestImportService()
+
+			if _, err := service.Preview(contextWithUser("user1"), tt.req); err != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestImportService_QIF(t *testing.T) {
+	service, _, transactions, _ := newTestImportService()
+	req := commands.ImportRequest{
+		WalletID:          "wallet1",
+		Content:           []byte("!Type:Bank\nD3/15'26\nT-4.50\nPCoffee\n^\nD3/16'26\nT2500\nPEmployer\n^\n"),
+		ExpenseCategoryID: "cat-food",
+		IncomeCategoryID:  "cat-salary",
+	}
+
+	batch, err := service.Commit(contextWithUser("user1"), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if batch.Format != domain.FormatQIF || batch.WalletID != "wallet1" || len(transactions.Transactions) != 2 {
+		t.Errorf("unexpected batch %+v", batch)
+	}
+}
+
+const testCAMT = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+  <BkToCstmrStmt>
+    <Stmt>
+      <Acct><Id><IBAN>DE89370400440532013000</IBAN></Id><Ccy>EUR</Ccy></Acct>
+      <Bal><Tp><CdOrPrtry><Cd>OPBD</Cd></CdOrPrtry></Tp><Amt Ccy="EUR">100.00</Amt><CdtDbtInd>CRDT</CdtDbtInd></Bal>
+      <Bal><Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp><Amt Ccy="EUR">2045.50</Amt><CdtDbtInd>CRDT</CdtDbtInd></Bal>
+      <Ntry>
+        <Amt Ccy="EUR">54.50</Amt><CdtDbtInd>DBIT</CdtDbtInd><Sts>BOOK</Sts>
+        <BookgDt><Dt>2026-04-01</Dt></BookgDt><ValDt><Dt>2026-03-31</Dt></ValDt>
+        <AcctSvcrRef>REF-1</AcctSvcrRef>
+        <NtryDtls><TxDtls><RltdPties><Cdtr><Nm>Stadtwerke</Nm></Cdtr></RltdPties><RmtInf><Ustrd>Abschlag</Ustrd></RmtInf></TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">2000.00</Amt><CdtDbtInd>CRDT</CdtDbtInd><Sts>BOOK</Sts>
+        <BookgDt><Dt>2026-04-01</Dt></BookgDt>
+        <AcctSvcrRef>REF-2</AcctSvcrRef>
+        <AddtlNtryInf>Salary</AddtlNtryInf>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>
+`
+
+func TestImportService_CAMT053_Reconciles(t *testing.T) {
+	service, _, transactions, _ := newTestImportService()
+	ctx := contextWithUser("user1")
+	req := commands.ImportRequest{
+		FileName:          "april.xml",
+		Content:           []byte(testCAMT),
+		ExpenseCategoryID: "cat-food",
+		IncomeCategoryID:  "cat-salary",
+	}
+
+	preview, err := service.Preview(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if preview.Format != domain.FormatCAMT053 || preview.WalletID != "wallet4" || preview.ReadyCount != 2 {
+		t.Fatalf("expected both entries to go to the EUR wallet, got %+v", preview)
+	}
+
+	if len(preview.Statements) != 1 {
+		t.Fatalf("expected 1 statement, got %d", len(preview.Statements))
+	}
+	statement := preview.Statements[0]
+	if !statement.Balanced || !statement.Movement.Equal(shareddomain.MustParseAmount("1945.50")) {
+		t.Errorf("expected the lines to take the opening balance to the closing one, got %+v", statement)
+	}
+	if !statement.BalanceAfterImport.Equal(shareddomain.MustParseAmount("2045.50")) || !statement.Difference.IsZero() || !statement.Reconciled {
+		t.Errorf("expected the wallet to reconcile, got %+v", statement)
+	}
+
+	excluded := req
+	excluded.ExcludeLines = []int{2}
+	preview, err = service.Preview(ctx, excluded)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	statement = preview.Statements[0]
+	if statement.Reconciled || !statement.Difference.Equal(shareddomain.MustParseAmount("2000")) {
+		t.Errorf("expected the excluded salary to be missing, got %+v", statement)
+	}
+
+	if _, err := service.Commit(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, transaction := range transactions.Transactions {
+		if transaction.ExternalID == "REF-1" && (transaction.ValueDate == nil || transaction.ValueDate.Format("2006-01-02") != "2026-03-31") {
+			t.Errorf("expected the value date to be kept, got %v", transaction.ValueDate)
+		}
+	}
+}
+
+func TestImportService_MT940_CurrencyMismatch(t *testing.T) {
+	service, _, _, _ := newTestImportService()
+	content := ":20:STMT\n:25:DE89370400440532013000\n:60F:C260401GBP0,00\n:61:260401C10,00NTRFNONREF\n:62F:C260401GBP10,00\n"
+
+	preview, err := service.Preview(contextWithUser("user1"), commands.ImportRequest{Content: []byte(content), IncomeCategoryID: "cat-salary"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if preview.Format != domain.FormatMT940 || preview.Lines[0].Error != "the statement is in GBP but the wallet is in EUR" {
+		t.Errorf("expected a currency error, got %+v", preview.Lines[0])
+	}
+	if statement := preview.Statements[0]; !statement.Balanced || statement.WalletID != "wallet4" || statement.BalanceAfterImport != nil {
+		t.Errorf("expected no wallet reconciliation across currencies, got %+v", statement)
+	}
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"fin-flow-api/internal/modules/imports/application/contracts/commands"
+	"fin-flow-api/internal/modules/imports/application/contracts/queries"
+	"fin-flow-api/internal/modules/imports/domain"
+	"fin-flow-api/internal/shared/middleware"
+
+	"github.com/google/uuid"
+)
+
+type ProfileService struct {
+	repository domain.ProfileRepository
+	systemUser string
+}
+
+func NewProfileService(repository domain.ProfileRepository, systemUser string) *ProfileService {
+	return &ProfileService{
+		repository: repository,
+		systemUser: systemUser,
+	}
+}
+
+func (s *ProfileService) getUserIDFromContext(ctx context.Context) (string, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return "", errors.New("user not authenticated")
+	}
+	return userID, nil
+}
+
+func (s *ProfileService) Create(ctx context.Context, req commands.ProfileRequest) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	profile := domain.NewProfile(uuid.New().String(), userID, req.Name, s.systemUser)
+	applyProfileRequest(profile, req)
+
+	if err := profile.Validate(); err != nil {
+		return err
+	}
+
+	return s.repository.Create(profile)
+}
+
+func (s *ProfileService) Update(ctx context.Context, id string, req commands.ProfileRequest) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	profile, err := s.repository.GetByID(id, userID)
+	if err != nil {
+		return err
+	}
+
+	profile.Name = req.Name
+	applyProfileRequest(profile, req)
+
+	if err := profile.Validate(); err != nil {
+		return err
+	}
+
+	profile.Entity.UpdateModified(s.systemUser)
+
+	return s.repository.Update(profile)
+}
+
+func (s *ProfileService) Delete(ctx context.Context, id string) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return s.repository.Delete(id, userID)
+}
+
+func (s *ProfileService) GetByID(ctx context.Context, id string) (*queries.ProfileResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := s.repository.GetByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toProfileResponse(profile), nil
+}
+
+func (s *ProfileService) List(ctx context.Context) ([]*queries.ProfileResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles, err := s.repository.List(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*queries.ProfileResponse, len(profiles))
+	for i, profile := range profiles {
+		responses[i] = toProfileResponse(profile)
+	}
+
+	return responses, nil
+}
+
+// applyProfileRequest copies the layout in req to the profile. Empty
+// settings keep the defaults of a new profile.
+func applyProfileRequest(profile *domain.Profile, req commands.ProfileRequest) {
+	if req.Delimiter != "" {
+		profile.Delimiter = req.Delimiter
+	}
+	if req.Encoding != "" {
+		profile.Encoding = req.Encoding
+	}
+	if req.DecimalSeparator != "" {
+		profile.DecimalSeparator = req.DecimalSeparator
+	}
+	profile.SkipRows = req.SkipRows
+	profile.HasHeader = req.HasHeader
+	profile.DateColumn = req.DateColumn
+	profile.DateFormat = req.DateFormat
+	profile.DescriptionColumn = req.DescriptionColumn
+	profile.PayeeColumn = req.PayeeColumn
+	profile.AmountColumn = req.AmountColumn
+	profile.DebitColumn = req.DebitColumn
+	profile.CreditColumn = req.CreditColumn
+	profile.NegateAmounts = req.NegateAmounts
+}
+
+func toProfileResponse(profile *domain.Profile) *queries.ProfileResponse {
+	return &queries.ProfileResponse{
+		ID:                profile.ID,
+		Name:              profile.Name,
+		Delimiter:         profile.Delimiter,
+		Encoding:          profile.Encoding,
+		SkipRows:          profile.SkipRows,
+		HasHeader:         profile.HasHeader,
+		DateColumn:        profile.DateColumn,
+		DateFormat:        profile.DateFormat,
+		DescriptionColumn: profile.DescriptionColumn,
+		PayeeColumn:       profile.PayeeColumn,
+		AmountColumn:      profile.AmountColumn,
+		DebitColumn:       profile.DebitColumn,
+		CreditColumn:      profile.CreditColumn,
+		DecimalSeparator:  profile.DecimalSeparator,
+		NegateAmounts:     profile.NegateAmounts,
+		CreatedAt:         profile.CreatedAt,
+		UpdatedAt:         profile.ModifiedAt,
+		CreatedBy:         profile.CreatedBy,
+		UpdatedBy:         profile.ModifiedBy,
+	}
+}
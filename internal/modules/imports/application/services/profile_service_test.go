@@ -0,0 +1,81 @@
+package services
+
+import (
+	"testing"
+
+	"fin-flow-api/internal/modules/imports/application/contracts/commands"
+	"fin-flow-api/internal/modules/imports/domain"
+)
+
+func TestProfileService_Create(t *testing.T) {
+	repo := &mockProfileRepository{profiles: make(map[string]*domain.Profile)}
+	service := NewProfileService(repo, "system")
+
+	err := service.Create(contextWithUser("user1"), commands.ProfileRequest{
+		Name:              "Bank",
+		HasHeader:         true,
+		DateColumn:        1,
+		DateFormat:        "DD/MM/YYYY",
+		DescriptionColumn: 2,
+		DebitColumn:       3,
+		CreditColumn:      4,
+		DecimalSeparator:  ",",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(repo.profiles) != 1 {
+		t.Fatalf("expected 1 profile, got %d", len(repo.profiles))
+	}
+	for _, profile := range repo.profiles {
+		if profile.UserID != "user1" || profile.Delimiter != "," || profile.Encoding != domain.EncodingUTF8 || profile.DecimalSeparator != "," {
+			t.Errorf("unexpected profile %+v", profile)
+		}
+	}
+}
+
+func TestProfileService_Create_Invalid(t *testing.T) {
+	repo := &mockProfileRepository{profiles: make(map[string]*domain.Profile)}
+	service := NewProfileService(repo, "system")
+
+	err := service.Create(contextWithUser("user1"), commands.ProfileRequest{
+		Name:              "Bank",
+		DateColumn:        1,
+		DateFormat:        "DD/MM/YYYY",
+		DescriptionColumn: 2,
+		DebitColumn:       3,
+	})
+	if err != domain.ErrAmountColumnsRequired {
+		t.Errorf("expected ErrAmountColumnsRequired, got %v", err)
+	}
+	if len(repo.profiles) != 0 {
+		t.Error("expected no profile to be stored")
+	}
+}
+
+func TestProfileService_Update(t *testing.T) {
+	profile := newTestProfile()
+	repo := &mockProfileRepository{profiles: map[string]*domain.Profile{profile.ID: profile}}
+	service := NewProfileService(repo, "system")
+
+	err := service.Update(contextWithUser("user1"), profile.ID, commands.ProfileRequest{
+		Name:              "Renamed",
+		Delimiter:         ";",
+		DateColumn:        2,
+		DateFormat:        "YYYY-MM-DD",
+		DescriptionColumn: 3,
+		AmountColumn:      4,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if profile.Name != "Renamed" || profile.Delimiter != ";" || profile.DateColumn != 2 || profile.HasHeader {
+		t.Errorf("unexpected profile %+v", profile)
+	}
+
+	if err := service.Update(contextWithUser("user2"), profile.ID, commands.ProfileRequest{Name: "Mine"}); err == nil {
+		t.Error("expected another user to be rejected")
+	}
+}
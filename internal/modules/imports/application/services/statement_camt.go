@@ -0,0 +1,279 @@
+package services
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"fin-flow-api/internal/modules/imports/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+// camtDocument is the part of an ISO 20022 camt.053 bank-to-customer
+// statement the import reads. Elements are matched by their local name, so
+// every version of the message is read alike whatever its namespace.
+type camtDocument struct {
+	Statements []camtStatement `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtStatement struct {
+	IBAN     string        `xml:"Acct>Id>IBAN"`
+	Other    string        `xml:"Acct>Id>Othr>Id"`
+	Currency string        `xml:"Acct>Ccy"`
+	Balances []camtBalance `xml:"Bal"`
+	Entries  []camtEntry   `xml:"Ntry"`
+}
+
+type camtBalance struct {
+	Code      string     `xml:"Tp>CdOrPrtry>Cd"`
+	Amount    camtAmount `xml:"Amt"`
+	Indicator string     `xml:"CdtDbtInd"`
+}
+
+type camtAmount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+// camtStatus is the status of an entry, which older versions of the
+// message give as text and newer ones as a code.
+type camtStatus struct {
+	Code string `xml:"Cd"`
+	Text string `xml:",chardata"`
+}
+
+type camtEntry struct {
+	Reference         string            `xml:"NtryRef"`
+	Amount            camtAmount        `xml:"Amt"`
+	Indicator         string            `xml:"CdtDbtInd"`
+	Status            camtStatus        `xml:"Sts"`
+	BookingDate       camtDate          `xml:"BookgDt"`
+	ValueDate         camtDate          `xml:"ValDt"`
+	ServicerReference string            `xml:"AcctSvcrRef"`
+	AdditionalInfo    string            `xml:"AddtlNtryInf"`
+	Details           []camtTransaction `xml:"NtryDtls>TxDtls"`
+}
+
+type camtTransaction struct {
+	ServicerReference  string    `xml:"Refs>AcctSvcrRef"`
+	Debtor             camtParty `xml:"RltdPties>Dbtr"`
+	Creditor           camtParty `xml:"RltdPties>Cdtr"`
+	Unstructured       []string  `xml:"RmtInf>Ustrd"`
+	CreditorReferences []string  `xml:"RmtInf>Strd>CdtrRefInf>Ref"`
+	AdditionalInfo     string    `xml:"AddtlTxInf"`
+}
+
+// camtParty is a debtor or creditor, whose name is nested one level
+// deeper since camt.053.001.08.
+type camtParty struct {
+	Name      string `xml:"Nm"`
+	PartyName string `xml:"Pty>Nm"`
+}
+
+func (p camtParty) name() string {
+	if p.Name != "" {
+		return p.Name
+	}
+	return p.PartyName
+}
+
+// ParseStatementCAMT053 reads the statements of an ISO 20022 camt.053 file,
+// one per account, with their opening and closing booked balances. Each
+// booked entry becomes a line, numbered in the order it appears; pending
+// entries are left out, since they are not part of the balance yet. An
+// entry that batches several transactions is read as a single line.
+func ParseStatementCAMT053(content []byte) ([]*domain.Statement, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))))
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		data, err := io.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		return strings.NewReader(decodeLegacyText(data, charset)), nil
+	}
+
+	var document camtDocument
+	if err := decoder.Decode(&document); err != nil {
+		var syntaxErr *xml.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return nil, &ImportError{Line: syntaxErr.Line, Message: "invalid XML: " + syntaxErr.Msg}
+		}
+		return nil, &ImportError{Line: 1, Message: "file is not a camt.053 statement"}
+	}
+	if len(document.Statements) == 0 {
+		return nil, &ImportError{Line: 1, Message: "file is not a camt.053 statement"}
+	}
+
+	var statements []*domain.Statement
+	number := 0
+	for _, camt := range document.Statements {
+		statement := &domain.Statement{
+			Account:  strings.TrimSpace(camt.IBAN),
+			Currency: strings.ToUpper(strings.TrimSpace(camt.Currency)),
+		}
+		if statement.Account == "" {
+			statement.Account = strings.TrimSpace(camt.Other)
+		}
+
+		for _, balance := range camt.Balances {
+			if statement.Currency == "" {
+				statement.Currency = strings.ToUpper(strings.TrimSpace(balance.Amount.Currency))
+			}
+
+			var target **shareddomain.Amount
+			switch strings.TrimSpace(balance.Code) {
+			case "OPBD", "PRCD":
+				// Banks give the opening balance either as such or as the
+				// closing balance of the previous statement.
+				if statement.OpeningBalance != nil {
+					continue
+				}
+				target = &statement.OpeningBalance
+			case "CLBD":
+				target = &statement.ClosingBalance
+			default:
+				continue
+			}
+
+			amount, ok := camtSignedAmount(balance.Amount.Value, balance.Indicator)
+			if !ok {
+				return nil, &ImportError{Line: 1, Message: fmt.Sprintf("balance %q is not a number", strings.TrimSpace(balance.Amount.Value))}
+			}
+			*target = &amount
+		}
+
+		for _, entry := range camt.Entries {
+			status := strings.TrimSpace(entry.Status.Code)
+			if status == "" {
+				status = strings.TrimSpace(entry.Status.Text)
+			}
+			if status != "" && status != "BOOK" {
+				continue
+			}
+
+			number++
+			if number > MaxImportRows {
+				return nil, &ImportError{Line: number, Message: fmt.Sprintf("more than %d lines", MaxImportRows)}
+			}
+			statement.Lines = append(statement.Lines, parseCAMTEntry(entry, statement.Currency, number))
+		}
+
+		statements = append(statements, statement)
+	}
+
+	if number == 0 {
+		return nil, &ImportError{Line: 1, Message: "no statement lines found"}
+	}
+
+	return statements, nil
+}
+
+func parseCAMTEntry(entry camtEntry, currency string, number int) *domain.StatementLine {
+	line := &domain.StatementLine{Line: number}
+
+	// The details of an entry that batches several transactions describe
+	// only one of them, so they are left for the entry information.
+	var details camtTransaction
+	if len(entry.Details) == 1 {
+		details = entry.Details[0]
+	}
+
+	line.ExternalID = firstNonEmpty(entry.ServicerReference, details.ServicerReference, entry.Reference)
+
+	// The counterparty of money that came in is the debtor who paid it,
+	// and of money that left, the creditor who was paid.
+	if entry.Indicator == "CRDT" {
+		line.Payee = collapseSpaces(details.Debtor.name())
+	} else {
+		line.Payee = collapseSpaces(details.Creditor.name())
+	}
+	line.Description = firstNonEmpty(
+		collapseSpaces(strings.Join(details.Unstructured, " ")),
+		collapseSpaces(strings.Join(details.CreditorReferences, " ")),
+		collapseSpaces(details.AdditionalInfo),
+		collapseSpaces(entry.AdditionalInfo),
+		line.Payee,
+	)
+
+	date, ok := parseCAMTDate(entry.BookingDate)
+	if !ok {
+		line.Error = "booking date is missing or invalid"
+		return line
+	}
+	line.Date = date
+	if valueDate, ok := parseCAMTDate(entry.ValueDate); ok {
+		line.ValueDate = &valueDate
+	}
+
+	if amountCurrency := strings.ToUpper(strings.TrimSpace(entry.Amount.Currency)); amountCurrency != "" && currency != "" && amountCurrency != currency {
+		line.Error = fmt.Sprintf("amount is in %s, not in %s", amountCurrency, currency)
+		return line
+	}
+	if entry.Indicator != "CRDT" && entry.Indicator != "DBIT" {
+		line.Error = fmt.Sprintf("credit or debit indicator %q is not CRDT or DBIT", entry.Indicator)
+		return line
+	}
+	amount, ok := camtSignedAmount(entry.Amount.Value, entry.Indicator)
+	if !ok {
+		line.Error = fmt.Sprintf("amount %q is not a number", strings.TrimSpace(entry.Amount.Value))
+		return line
+	}
+	if amount.IsZero() {
+		line.Error = "amount is zero"
+		return line
+	}
+	line.Amount = amount
+
+	return line
+}
+
+// camtSignedAmount reads an amount, which camt.053 writes unsigned, and
+// makes it negative when it is a debit.
+func camtSignedAmount(value, indicator string) (shareddomain.Amount, bool) {
+	amount, err := shareddomain.ParseAmount(strings.TrimSpace(value))
+	if err != nil || amount.IsNegative() {
+		return shareddomain.Amount{}, false
+	}
+	if indicator == "DBIT" {
+		amount = amount.Neg()
+	}
+	return amount, true
+}
+
+// parseCAMTDate reads the day of a camt.053 date, given either as a date
+// or as a date and time.
+func parseCAMTDate(value camtDate) (time.Time, bool) {
+	text := strings.TrimSpace(value.Date)
+	if text == "" {
+		text = strings.TrimSpace(value.DateTime)
+	}
+	if len(text) < 10 {
+		return time.Time{}, false
+	}
+	date, err := time.Parse("2006-01-02", text[:10])
+	return date, err == nil
+}
+
+// collapseSpaces trims a value and turns every run of white space in it,
+// line breaks included, into a single space.
+func collapseSpaces(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
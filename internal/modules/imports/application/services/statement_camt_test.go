@@ -0,0 +1,151 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+const camtFile = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.08">
+  <BkToCstmrStmt>
+    <GrpHdr><MsgId>MSG-1</MsgId><CreDtTm>2026-04-01T06:00:00</CreDtTm></GrpHdr>
+    <Stmt>
+      <Id>STMT-1</Id>
+      <Acct><Id><IBAN>DE89370400440532013000</IBAN></Id><Ccy>EUR</Ccy></Acct>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>PRCD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="EUR">100.00</Amt><CdtDbtInd>CRDT</CdtDbtInd><Dt><Dt>2026-03-31</Dt></Dt>
+      </Bal>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>OPBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="EUR">100.00</Amt><CdtDbtInd>CRDT</CdtDbtInd><Dt><Dt>2026-04-01</Dt></Dt>
+      </Bal>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="EUR">2045.50</Amt><CdtDbtInd>CRDT</CdtDbtInd><Dt><Dt>2026-04-01</Dt></Dt>
+      </Bal>
+      <Ntry>
+        <NtryRef>1</NtryRef>
+        <Amt Ccy="EUR">54.50</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><Dt>2026-04-01</Dt></BookgDt>
+        <ValDt><Dt>2026-03-31</Dt></ValDt>
+        <AcctSvcrRef>BANK-REF-1</AcctSvcrRef>
+        <NtryDtls><TxDtls>
+          <RltdPties><Cdtr><Pty><Nm>Stadtwerke   München</Nm></Pty></Cdtr></RltdPties>
+          <RmtInf><Ustrd>Abschlag April</Ustrd><Ustrd>Kunde 4711</Ustrd></RmtInf>
+        </TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">2000.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><DtTm>2026-04-01T09:30:00+02:00</DtTm></BookgDt>
+        <NtryDtls><TxDtls>
+          <Refs><AcctSvcrRef>BANK-REF-2</AcctSvcrRef></Refs>
+          <RltdPties><Dbtr><Pty><Nm>Example GmbH</Nm></Pty></Dbtr><Cdtr><Pty><Nm>Me</Nm></Pty></Cdtr></RltdPties>
+        </TxDtls></NtryDtls>
+        <AddtlNtryInf>SALARY</AddtlNtryInf>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">10.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts><Cd>PDNG</Cd></Sts>
+        <BookgDt><Dt>2026-04-02</Dt></BookgDt>
+      </Ntry>
+    </Stmt>
+    <Stmt>
+      <Id>STMT-2</Id>
+      <Acct><Id><Othr><Id>GB-123</Id></Othr></Id></Acct>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="GBP">5.00</Amt><CdtDbtInd>DBIT</CdtDbtInd><Dt><Dt>2026-04-01</Dt></Dt>
+      </Bal>
+      <Ntry>
+        <Amt Ccy="USD">5.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt><Dt>2026-04-01</Dt></BookgDt>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>
+`
+
+func TestParseStatementCAMT053(t *testing.T) {
+	statements, err := ParseStatementCAMT053([]byte(camtFile))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(statements) != 2 {
+		t.Fatalf("expected 2 statements, got %d", len(statements))
+	}
+
+	statement := statements[0]
+	if statement.Account != "DE89370400440532013000" || statement.Currency != "EUR" || len(statement.Lines) != 2 {
+		t.Fatalf("unexpected statement %+v", statement)
+	}
+	if statement.OpeningBalance == nil || !statement.OpeningBalance.Equal(shareddomain.MustParseAmount("100")) {
+		t.Errorf("expected an opening balance of 100, got %v", statement.OpeningBalance)
+	}
+	if statement.ClosingBalance == nil || !statement.ClosingBalance.Equal(shareddomain.MustParseAmount("2045.50")) {
+		t.Errorf("expected a closing balance of 2045.50, got %v", statement.ClosingBalance)
+	}
+
+	bill := statement.Lines[0]
+	if bill.Line != 1 || bill.Payee != "Stadtwerke München" || bill.Description != "Abschlag April Kunde 4711" || bill.ExternalID != "BANK-REF-1" {
+		t.Errorf("unexpected first line %+v", bill)
+	}
+	if bill.Date.Format("2006-01-02") != "2026-04-01" || bill.ValueDate == nil || bill.ValueDate.Format("2006-01-02") != "2026-03-31" {
+		t.Errorf("unexpected dates %s %v", bill.Date, bill.ValueDate)
+	}
+	if !bill.Amount.Equal(shareddomain.MustParseAmount("-54.50")) {
+		t.Errorf("expected -54.50, got %s", bill.Amount)
+	}
+
+	salary := statement.Lines[1]
+	if salary.Payee != "Example GmbH" || salary.Description != "SALARY" || salary.ExternalID != "BANK-REF-2" || salary.ValueDate != nil {
+		t.Errorf("unexpected second line %+v", salary)
+	}
+	if salary.Date.Format("2006-01-02") != "2026-04-01" || !salary.Amount.Equal(shareddomain.MustParseAmount("2000")) {
+		t.Errorf("unexpected date or amount %s %s", salary.Date, salary.Amount)
+	}
+
+	other := statements[1]
+	if other.Account != "GB-123" || other.Currency != "GBP" || other.OpeningBalance != nil {
+		t.Fatalf("unexpected second statement %+v", other)
+	}
+	if !other.ClosingBalance.Equal(shareddomain.MustParseAmount("-5")) {
+		t.Errorf("expected a debit closing balance, got %s", other.ClosingBalance)
+	}
+	if other.Lines[0].Line != 3 || other.Lines[0].Error != "amount is in USD, not in GBP" {
+		t.Errorf("unexpected line %+v", other.Lines[0])
+	}
+}
+
+func TestParseStatementCAMT053_NotCAMT(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"csv", "Date,Description,Amount\n15/03/2026,Coffee,-4.50\n"},
+		{"other xml", "<?xml version=\"1.0\"?><Document><CstmrCdtTrfInitn/></Document>"},
+		{"broken xml", "<Document><BkToCstmrStmt><Stmt></BkToCstmrStmt>"},
+		{"no entries", "<Document><BkToCstmrStmt><Stmt><Acct><Id><IBAN>X</IBAN></Id></Acct></Stmt></BkToCstmrStmt></Document>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseStatementCAMT053([]byte(tt.content))
+
+			var importErr *ImportError
+			if !errors.As(err, &importErr) {
+				t.Errorf("expected an ImportError, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"fin-flow-api/internal/modules/imports/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+// MaxImportRows bounds the lines of a single statement.
+const MaxImportRows = 5000
+
+// ImportError reports a statement that could not be read, or the first
+// line that keeps it from being committed.
+type ImportError struct {
+	Line    int
+	Message string
+}
+
+func (e *ImportError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// ParseStatementCSV reads the lines of a CSV statement laid out as the
+// profile describes. Lines whose fields cannot be read are returned with
+// the reason in Error, so they can be shown and left out, while a file
+// that is not CSV at all fails as a whole. Blank lines are skipped.
+func ParseStatementCSV(content []byte, profile *domain.Profile) ([]*domain.StatementLine, error) {
+	layout, err := profile.DateLayout()
+	if err != nil {
+		return nil, err
+	}
+
+	text, err := decodeText(content, profile.Encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < profile.SkipRows && text != ""; i++ {
+		end := strings.IndexByte(text, '\n')
+		if end < 0 {
+			text = ""
+			break
+		}
+		text = text[end+1:]
+	}
+
+	delimiter, _ := utf8.DecodeRuneInString(profile.Delimiter)
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header := profile.HasHeader
+	var lines []*domain.StatementLine
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return nil, &ImportError{Line: parseErr.Line + profile.SkipRows, Message: parseErr.Err.Error()}
+			}
+			return nil, err
+		}
+
+		if header {
+			header = false
+			continue
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+
+		number, _ := reader.FieldPos(0)
+		number += profile.SkipRows
+		if len(lines) == MaxImportRows {
+			return nil, &ImportError{Line: number, Message: fmt.Sprintf("more than %d lines", MaxImportRows)}
+		}
+
+		lines = append(lines, parseStatementRecord(record, number, layout, profile))
+	}
+
+	if len(lines) == 0 {
+		return nil, &ImportError{Line: profile.SkipRows + 1, Message: "no statement lines found"}
+	}
+
+	return lines, nil
+}
+
+func parseStatementRecord(record []string, number int, layout string, profile *domain.Profile) *domain.StatementLine {
+	line := &domain.StatementLine{Line: number}
+
+	field := func(column int) (string, bool) {
+		if column == 0 {
+			return "", true
+		}
+		if column > len(record) {
+			line.Error = fmt.Sprintf("column %d is missing", column)
+			return "", false
+		}
+		return strings.TrimSpace(record[column-1]), true
+	}
+
+	dateValue, ok := field(profile.DateColumn)
+	if !ok {
+		return line
+	}
+	date, err := time.Parse(layout, dateValue)
+	if err != nil {
+		line.Error = fmt.Sprintf("date %q does not match the format %s", dateValue, strings.ToUpper(profile.DateFormat))
+		return line
+	}
+	line.Date = date
+
+	if line.Description, ok = field(profile.DescriptionColumn); !ok {
+		return line
+	}
+	if line.Payee, ok = field(profile.PayeeColumn); !ok {
+		return line
+	}
+
+	amount, ok := parseStatementAmount(line, field, profile)
+	if !ok {
+		return line
+	}
+	if profile.NegateAmounts {
+		amount = amount.Neg()
+	}
+	if amount.IsZero() {
+		line.Error = "amount is zero"
+		return line
+	}
+	line.Amount = amount
+
+	return line
+}
+
+// parseStatementAmount reads the signed amount of a line, either from the
+// amount column or as credit minus debit.
+func parseStatementAmount(line *domain.StatementLine, field func(int) (string, bool), profile *domain.Profile) (shareddomain.Amount, bool) {
+	parse := func(column int) (shareddomain.Amount, bool) {
+		value, ok := field(column)
+		if !ok {
+			return shareddomain.Amount{}, false
+		}
+		if value == "" && profile.AmountColumn == 0 {
+			return shareddomain.Amount{}, true
+		}
+		amount, err := profile.ParseAmount(value)
+		if err != nil {
+			line.Error = fmt.Sprintf("amount %q is not a number", value)
+			return shareddomain.Amount{}, false
+		}
+		return amount, true
+	}
+
+	if profile.AmountColumn > 0 {
+		return parse(profile.AmountColumn)
+	}
+
+	debit, ok := parse(profile.DebitColumn)
+	if !ok {
+		return shareddomain.Amount{}, false
+	}
+	credit, ok := parse(profile.CreditColumn)
+	if !ok {
+		return shareddomain.Amount{}, false
+	}
+	return credit.Abs().Sub(debit.Abs()), true
+}
+
+func isBlankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// windows1252 holds the characters Windows-1252 puts in 0x80-0x9F, where
+// ISO-8859-1 has control codes. The five unassigned bytes are kept as
+// control codes.
+var windows1252 = [32]rune{
+	'€', '\u0081', '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', '\u008d', 'Ž', '\u008f',
+	'\u0090', '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', '\u009d', 'ž', 'Ÿ',
+}
+
+// decodeText turns the content of a statement into text. UTF-8 files may
+// start with a byte order mark.
+func decodeText(content []byte, encoding string) (string, error) {
+	switch encoding {
+	case domain.EncodingLatin1, domain.EncodingWindows1252:
+		var text strings.Builder
+		text.Grow(len(content))
+		for _, b := range content {
+			if encoding == domain.EncodingWindows1252 && b >= 0x80 && b <= 0x9f {
+				text.WriteRune(windows1252[b-0x80])
+				continue
+			}
+			text.WriteRune(rune(b))
+		}
+		return text.String(), nil
+	default:
+		content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+		if !utf8.Valid(content) {
+			return "", &ImportError{Line: 1, Message: "file is not valid UTF-8, check the encoding of the import profile"}
+		}
+		return string(content), nil
+	}
+}
+
+// decodeLegacyText turns the content of a file whose encoding is at most
+// hinted at, as with OFX and QIF files, into text. Without a Latin-1 or
+// Windows-1252 hint, content that is valid UTF-8 is taken as such and any
+// other is read as Windows-1252, the usual encoding of files that are not.
+func decodeLegacyText(content []byte, charset string) string {
+	charset = strings.ToUpper(charset)
+	encoding := domain.EncodingUTF8
+	switch {
+	case strings.Contains(charset, "8859-1"), strings.Contains(charset, "LATIN"):
+		encoding = domain.EncodingLatin1
+	case strings.Contains(charset, "1252"):
+		encoding = domain.EncodingWindows1252
+	}
+
+	text, err := decodeText(content, encoding)
+	if err != nil {
+		text, _ = decodeText(content, domain.EncodingWindows1252)
+	}
+	return text
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"fin-flow-api/internal/modules/imports/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+func newTestProfile() *domain.Profile {
+	profile := domain.NewProfile("profile1", "user1", "Bank", "system")
+	profile.DateColumn = 1
+	profile.DateFormat = "DD/MM/YYYY"
+	profile.DescriptionColumn = 2
+	profile.AmountColumn = 3
+	return profile
+}
+
+func TestParseStatementCSV(t *testing.T) {
+	content := []byte("\xef\xbb\xbfDate,Description,Amount\n" +
+		"15/03/2026,Coffee shop,-4.50\n" +
+		"\n" +
+		"16/03/2026,\"Salary, March\",2500.00\n")
+
+	lines, err := ParseStatementCSV(content, newTestProfile())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+
+	first := lines[0]
+	if first.Line != 2 || first.Description != "Coffee shop" || !first.Amount.Equal(shareddomain.MustParseAmount("-4.5")) {
+		t.Errorf("unexpected first line %+v", first)
+	}
+	if !first.Date.Equal(time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected 2026-03-15, got %s", first.Date)
+	}
+
+	if lines[1].Line != 4 || lines[1].Description != "Salary, March" || lines[1].Error != "" {
+		t.Errorf("unexpected second line %+v", lines[1])
+	}
+}
+
+func TestParseStatementCSV_Layout(t *testing.T) {
+	profile := newTestProfile()
+	profile.Delimiter = ";"
+	profile.Encoding = domain.EncodingWindows1252
+	profile.SkipRows = 2
+	profile.DecimalSeparator = ","
+	profile.PayeeColumn = 3
+	profile.AmountColumn = 0
+	profile.DebitColumn = 4
+	profile.CreditColumn = 5
+
+	content := []byte("Cuenta 0042\n" +
+		"Extracto de marzo\n" +
+		"Fecha;Concepto;Beneficiario;Debe;Haber\n" +
+		"01/03/2026;Caf\xe9 \x80;Bar;1.234,50;\n" +
+		"02/03/2026;Transferencia;Empresa;;900,00\n")
+
+	lines, err := ParseStatementCSV(content, profile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+
+	if lines[0].Line != 4 || lines[0].Description != "Café €" || lines[0].Payee != "Bar" {
+		t.Errorf("unexpected first line %+v", lines[0])
+	}
+	if !lines[0].Amount.Equal(shareddomain.MustParseAmount("-1234.5")) {
+		t.Errorf("expected -1234.5, got %s", lines[0].Amount)
+	}
+	if !lines[1].Amount.Equal(shareddomain.MustParseAmount("900")) {
+		t.Errorf("expected 900, got %s", lines[1].Amount)
+	}
+}
+
+func TestParseStatementCSV_NegateAmounts(t *testing.T) {
+	profile := newTestProfile()
+	profile.HasHeader = false
+	profile.NegateAmounts = true
+
+	lines, err := ParseStatementCSV([]byte("15/03/2026,Card purchase,30.00\n"), profile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !lines[0].Amount.Equal(shareddomain.MustParseAmount("-30")) {
+		t.Errorf("expected -30, got %s", lines[0].Amount)
+	}
+}
+
+func TestParseStatementCSV_LineErrors(t *testing.T) {
+	content := []byte("Date,Description,Amount\n" +
+		"2026-03-15,Coffee,-4.50\n" +
+		"15/03/2026,Refund,abc\n" +
+		"15/03/2026,Nothing,0\n" +
+		"15/03/2026,Short\n")
+
+	lines, err := ParseStatementCSV(content, newTestProfile())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		`date "2026-03-15" does not match the format DD/MM/YYYY`,
+		`amount "abc" is not a number`,
+		"amount is zero",
+		"column 3 is missing",
+	}
+	for i, message := range expected {
+		if lines[i].Error != message {
+			t.Errorf("line %d: expected %q, got %q", lines[i].Line, message, lines[i].Error)
+		}
+	}
+}
+
+func TestParseStatementCSV_FileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		line    int
+	}{
+		{"only header", "Date,Description,Amount\n", 1},
+		{"invalid utf-8", "Date,Description,Amount\n15/03/2026,Caf\xe9,-4.50\n", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseStatementCSV([]byte(tt.content), newTestProfile())
+
+			var importErr *ImportError
+			if !errors.As(err, &importErr) {
+				t.Fatalf("expected an ImportError, got %v", err)
+			}
+			if importErr.Line != tt.line {
+				t.Errorf("expected line %d, got %d", tt.line, importErr.Line)
+			}
+		})
+	}
+}
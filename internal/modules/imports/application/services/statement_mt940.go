@@ -0,0 +1,409 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fin-flow-api/internal/modules/imports/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+// mt940Field is a field of an MT940 message: its tag, such as 61 or 60F,
+// its value, which may span several lines, and the line it starts at.
+type mt940Field struct {
+	tag   string
+	value string
+	line  int
+}
+
+// mt940InformationKeys are the keys of the /KEY/value layout several
+// banks structure the information of an entry (field 86) with.
+var mt940InformationKeys = map[string]bool{
+	"ADDR": true,
+	"BENM": true,
+	"BIC":  true,
+	"CNTP": true,
+	"CSID": true,
+	"EREF": true,
+	"IBAN": true,
+	"MARF": true,
+	"NAME": true,
+	"ORDP": true,
+	"PURP": true,
+	"REMI": true,
+	"STRD": true,
+	"TRTP": true,
+	"USTD": true,
+}
+
+// ParseStatementMT940 reads the statements of a SWIFT MT940 file, with or
+// without the SWIFT envelope. Messages for the same account, such as the
+// pages of a long statement, make a single statement whose opening balance
+// is that of the first and whose closing balance is that of the last.
+// Each statement line (field 61) is a line of the statement, with the
+// information of field 86 as its description and payee.
+func ParseStatementMT940(content []byte) ([]*domain.Statement, error) {
+	fields := splitMT940Fields(decodeLegacyText(content, ""))
+
+	var statements []*domain.Statement
+	byAccount := make(map[string]*domain.Statement)
+	statementFor := func(account string) *domain.Statement {
+		statement := byAccount[account]
+		if statement == nil {
+			statement = &domain.Statement{Account: account}
+			byAccount[account] = statement
+			statements = append(statements, statement)
+		}
+		return statement
+	}
+
+	var current *domain.Statement
+	var last *domain.StatementLine
+	count := 0
+	for _, field := range fields {
+		switch field.tag {
+		case "25":
+			account, _, _ := strings.Cut(field.value, "\n")
+			current = statementFor(strings.TrimSpace(account))
+			last = nil
+		case "60F", "60M", "62F", "62M":
+			if current == nil {
+				current = statementFor("")
+			}
+			balance, currency, ok := parseMT940Balance(field.value)
+			if !ok {
+				return nil, &ImportError{Line: field.line, Message: fmt.Sprintf("balance %q is not an MT940 balance", strings.TrimSpace(field.value))}
+			}
+			if current.Currency == "" {
+				current.Currency = currency
+			}
+			if strings.HasPrefix(field.tag, "60") {
+				if current.OpeningBalance == nil {
+					current.OpeningBalance = &balance
+				}
+			} else {
+				current.ClosingBalance = &balance
+			}
+			last = nil
+		case "61":
+			if current == nil {
+				current = statementFor("")
+			}
+			count++
+			if count > MaxImportRows {
+				return nil, &ImportError{Line: field.line, Message: fmt.Sprintf("more than %d lines", MaxImportRows)}
+			}
+			last = parseMT940Line(field)
+			current.Lines = append(current.Lines, last)
+		case "86":
+			// Information that follows the closing balance is about the
+			// whole statement rather than one of its lines.
+			if last != nil {
+				applyMT940Information(last, field.value)
+			}
+			last = nil
+		}
+	}
+
+	if len(statements) == 0 {
+		return nil, &ImportError{Line: 1, Message: "file is not an MT940 statement"}
+	}
+	if count == 0 {
+		return nil, &ImportError{Line: 1, Message: "no statement lines found"}
+	}
+
+	return statements, nil
+}
+
+// splitMT940Fields splits the text of an MT940 file into its fields. The
+// header blocks of the SWIFT envelope and the end of each message are
+// skipped, and lines that start no field continue the previous one.
+func splitMT940Fields(text string) []*mt940Field {
+	var fields []*mt940Field
+	for i, raw := range strings.Split(text, "\n") {
+		line := strings.TrimRight(raw, "\r")
+		if strings.HasPrefix(line, "{") {
+			start := strings.Index(line, "{4:")
+			if start < 0 {
+				continue
+			}
+			line = line[start+len("{4:"):]
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || trimmed == "-" || strings.HasPrefix(trimmed, "-}") {
+			continue
+		}
+
+		if tag, value, ok := mt940Tag(line); ok {
+			fields = append(fields, &mt940Field{tag: tag, value: value, line: i + 1})
+			continue
+		}
+		if len(fields) > 0 {
+			fields[len(fields)-1].value += "\n" + line
+		}
+	}
+	return fields
+}
+
+// mt940Tag splits a line that starts a field, such as ":60F:C260301EUR1,00",
+// into its tag and the start of its value.
+func mt940Tag(line string) (string, string, bool) {
+	if len(line) < 4 || line[0] != ':' {
+		return "", "", false
+	}
+	end := strings.IndexByte(line[1:], ':') + 1
+	if end < 3 || end > 4 {
+		return "", "", false
+	}
+	tag := line[1:end]
+	if !isDigit(tag[0]) || !isDigit(tag[1]) || (len(tag) == 3 && !isUpperLetter(tag[2])) {
+		return "", "", false
+	}
+	return tag, line[end+1:], true
+}
+
+// parseMT940Balance reads a balance field: the credit or debit mark, the
+// date, the currency and the amount, as in C260301EUR1234,56.
+func parseMT940Balance(value string) (shareddomain.Amount, string, bool) {
+	value = strings.TrimSpace(value)
+	if len(value) < 11 || (value[0] != 'C' && value[0] != 'D') {
+		return shareddomain.Amount{}, "", false
+	}
+	if _, err := time.Parse("060102", value[1:7]); err != nil {
+		return shareddomain.Amount{}, "", false
+	}
+
+	amount, ok := parseMT940Amount(value[10:])
+	if !ok {
+		return shareddomain.Amount{}, "", false
+	}
+	if value[0] == 'D' {
+		amount = amount.Neg()
+	}
+	return amount, value[7:10], true
+}
+
+// parseMT940Line reads a statement line: the value date, the booking date
+// if it differs, the credit or debit mark, the amount, the transaction
+// type, the reference of the account owner and, after //, the reference of
+// the bank, which becomes the external id. The second line, if any, holds
+// supplementary details.
+func parseMT940Line(field *mt940Field) *domain.StatementLine {
+	line := &domain.StatementLine{Line: field.line}
+	rest, supplementary, _ := strings.Cut(field.value, "\n")
+	rest = strings.TrimSpace(rest)
+
+	if len(rest) < 6 {
+		line.Error = fmt.Sprintf("statement line %q is too short", rest)
+		return line
+	}
+	valueDate, err := time.Parse("060102", rest[:6])
+	if err != nil {
+		line.Error = fmt.Sprintf("date %q is not an MT940 date", rest[:6])
+		return line
+	}
+	line.Date = valueDate
+	line.ValueDate = &valueDate
+	rest = rest[6:]
+
+	if len(rest) >= 4 && isDigit(rest[0]) && isDigit(rest[1]) && isDigit(rest[2]) && isDigit(rest[3]) {
+		booked, ok := mt940BookingDate(valueDate, rest[:4])
+		if !ok {
+			line.Error = fmt.Sprintf("booking date %q is not an MT940 date", rest[:4])
+			return line
+		}
+		line.Date = booked
+		rest = rest[4:]
+	}
+
+	// Reversals carry an R before the mark of the entry they reverse, so
+	// a reversed credit takes money out of the account.
+	negative := false
+	switch {
+	case strings.HasPrefix(rest, "RC"):
+		negative, rest = true, rest[2:]
+	case strings.HasPrefix(rest, "RD"):
+		rest = rest[2:]
+	case strings.HasPrefix(rest, "C"):
+		rest = rest[1:]
+	case strings.HasPrefix(rest, "D"):
+		negative, rest = true, rest[1:]
+	default:
+		line.Error = "credit or debit mark is missing"
+		return line
+	}
+
+	// Some banks give the last letter of the currency code before the
+	// amount.
+	if rest != "" && isUpperLetter(rest[0]) {
+		rest = rest[1:]
+	}
+	end := strings.IndexFunc(rest, func(r rune) bool {
+		return (r < '0' || r > '9') && r != ','
+	})
+	if end < 0 {
+		end = len(rest)
+	}
+	amount, ok := parseMT940Amount(rest[:end])
+	if !ok {
+		line.Error = fmt.Sprintf("amount %q is not a number", rest[:end])
+		return line
+	}
+	if amount.IsZero() {
+		line.Error = "amount is zero"
+		return line
+	}
+	if negative {
+		amount = amount.Neg()
+	}
+	line.Amount = amount
+	rest = rest[end:]
+
+	if len(rest) >= 4 {
+		rest = rest[4:]
+	}
+	reference, bankReference, _ := strings.Cut(rest, "//")
+	if bankReference = strings.TrimSpace(bankReference); bankReference != "" && bankReference != "NONREF" {
+		line.ExternalID = bankReference
+	}
+	if reference = strings.TrimSpace(reference); reference == "NONREF" {
+		reference = ""
+	}
+	line.Description = firstNonEmpty(collapseSpaces(supplementary), reference)
+
+	return line
+}
+
+// mt940BookingDate reads the month and day a line was booked on, which
+// may fall in the year before or after its value date.
+func mt940BookingDate(valueDate time.Time, monthDay string) (time.Time, bool) {
+	date, err := time.Parse("0102", monthDay)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	booked := time.Date(valueDate.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	if booked.Day() != date.Day() {
+		// February 29 of a year that is not a leap year.
+		return time.Time{}, false
+	}
+	switch {
+	case booked.Sub(valueDate) > 183*24*time.Hour:
+		booked = booked.AddDate(-1, 0, 0)
+	case valueDate.Sub(booked) > 183*24*time.Hour:
+		booked = booked.AddDate(1, 0, 0)
+	}
+	return booked, true
+}
+
+// parseMT940Amount reads an MT940 amount, which is unsigned and uses a
+// comma as decimal separator that may end the amount, as in 1234,.
+func parseMT940Amount(value string) (shareddomain.Amount, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.Count(value, ",") != 1 || strings.ContainsAny(value, ".-+") {
+		return shareddomain.Amount{}, false
+	}
+	amount, err := shareddomain.ParseAmount(strings.TrimSuffix(strings.Replace(value, ",", ".", 1), "."))
+	return amount, err == nil
+}
+
+// applyMT940Information fills the description and payee of a line from
+// the information field that follows it. German banks structure it in
+// ?nn subfields after a three-digit transaction code, Dutch banks and
+// others in /KEY/value pairs, and the rest write free text. Structured
+// values are wrapped anywhere, so their lines are joined as they are.
+func applyMT940Information(line *domain.StatementLine, value string) {
+	joined := strings.ReplaceAll(strings.ReplaceAll(value, "\r", ""), "\n", "")
+
+	description, payee := "", ""
+	switch {
+	case len(joined) > 3 && isDigit(joined[0]) && isDigit(joined[1]) && isDigit(joined[2]) && joined[3] == '?':
+		description, payee = parseMT940Subfields(joined[3:])
+	case strings.HasPrefix(joined, "/"):
+		description, payee = parseMT940KeyValues(joined)
+	default:
+		description = collapseSpaces(value)
+	}
+
+	if payee != "" {
+		line.Payee = payee
+	}
+	if description = firstNonEmpty(description, line.Description, line.Payee); description != "" {
+		line.Description = description
+	}
+}
+
+// parseMT940Subfields reads the ?nn subfields of German banks: 20 to 29
+// and 60 to 63 hold the remittance information, 32 and 33 the name of the
+// counterparty and 00 the kind of posting. In SEPA remittances the text
+// itself follows SVWZ+.
+func parseMT940Subfields(value string) (string, string) {
+	var remittance, name strings.Builder
+	posting := ""
+	for _, subfield := range strings.Split(value, "?")[1:] {
+		if len(subfield) < 2 {
+			continue
+		}
+		code, text := subfield[:2], subfield[2:]
+		switch {
+		case code >= "20" && code <= "29", code >= "60" && code <= "63":
+			remittance.WriteString(text)
+		case code == "32" || code == "33":
+			name.WriteString(text)
+		case code == "00":
+			posting = text
+		}
+	}
+
+	description := remittance.String()
+	if _, text, found := strings.Cut(description, "SVWZ+"); found {
+		description = text
+	}
+	return firstNonEmpty(collapseSpaces(description), collapseSpaces(posting)), collapseSpaces(name.String())
+}
+
+// parseMT940KeyValues reads the /KEY/value pairs some banks use. The
+// description is the remittance information and the payee the NAME, or
+// the name within the counterparty (CNTP), which lists the account, the
+// bank, the name and the city.
+func parseMT940KeyValues(value string) (string, string) {
+	values := make(map[string]string)
+	key := ""
+	var parts []string
+	flush := func() {
+		if key != "" && values[key] == "" {
+			values[key] = strings.Join(parts, "/")
+		}
+	}
+	for _, token := range strings.Split(value, "/") {
+		if mt940InformationKeys[token] {
+			flush()
+			key, parts = token, nil
+			continue
+		}
+		parts = append(parts, token)
+	}
+	flush()
+
+	field := func(key string) string {
+		return collapseSpaces(strings.Trim(values[key], "/"))
+	}
+
+	payee := field("NAME")
+	if payee == "" {
+		if counterparty := strings.Split(values["CNTP"], "/"); len(counterparty) >= 3 {
+			payee = collapseSpaces(counterparty[2])
+		}
+	}
+	return firstNonEmpty(field("REMI"), field("USTD"), field("STRD")), payee
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+func isUpperLetter(b byte) bool {
+	return b >= 'A' && b <= 'Z'
+}
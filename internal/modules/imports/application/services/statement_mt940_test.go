@@ -0,0 +1,133 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+const mt940File = "{1:F01BANKDEFFAXXX0000000000}{2:O9400000000000BANKDEFFAXXX00000000000000000000N}{4:\r\n" +
+	":20:STARTUMSE\r\n" +
+	":25:37040044/0532013000\r\n" +
+	":28C:00001/001\r\n" +
+	":60F:C260330EUR100,00\r\n" +
+	":61:2603310401DR54,50NDDTNONREF//BANK-REF-1\r\n" +
+	"Abschlag\r\n" +
+	":86:105?00SEPA-BASISLASTSCHRIFT?20EREF+4711?21SVWZ+Abschlag Apr\r\n" +
+	"?22il Kunde 4711?32Stadtwerke Mue?33nchen\r\n" +
+	":61:260401C2000,NTRFPAYROLL\r\n" +
+	":86:/TRTP/SEPA OVERBOEKING/NAME/Example BV/REMI/USTD//Salary April/\r\n" +
+	":61:260402RC10,NMSCNONREF//BANK-REF-3\r\n" +
+	":86:Reversed card payment\r\n" +
+	"refunded in error\r\n" +
+	":62M:C260402EUR2035,50\r\n" +
+	"-}\r\n" +
+	"{1:F01BANKDEFFAXXX0000000000}{2:O9400000000000BANKDEFFAXXX00000000000000000000N}{4:\r\n" +
+	":20:STARTUMSE\r\n" +
+	":25:37040044/0532013000\r\n" +
+	":28C:00001/002\r\n" +
+	":60M:C260402EUR2035,50\r\n" +
+	":61:260403D35,50NTRFNONREF\r\n" +
+	":86:/CNTP/NL91ABNA0417164300/ABNANL2A/J. Jansen/Amsterdam/\r\n" +
+	":61:2604XXC1,00NTRFNONREF\r\n" +
+	":62F:C260403EUR2000,00\r\n" +
+	":86:Statement information\r\n" +
+	"-}\r\n"
+
+func TestParseStatementMT940(t *testing.T) {
+	statements, err := ParseStatementMT940([]byte(mt940File))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(statements) != 1 {
+		t.Fatalf("expected the pages to make 1 statement, got %d", len(statements))
+	}
+	statement := statements[0]
+	if statement.Account != "37040044/0532013000" || statement.Currency != "EUR" || len(statement.Lines) != 5 {
+		t.Fatalf("unexpected statement %+v", statement)
+	}
+	if !statement.OpeningBalance.Equal(shareddomain.MustParseAmount("100")) || !statement.ClosingBalance.Equal(shareddomain.MustParseAmount("2000")) {
+		t.Errorf("expected balances of 100 and 2000, got %s and %s", statement.OpeningBalance, statement.ClosingBalance)
+	}
+
+	bill := statement.Lines[0]
+	if bill.Line != 6 || bill.Payee != "Stadtwerke Muenchen" || bill.Description != "Abschlag April Kunde 4711" || bill.ExternalID != "BANK-REF-1" {
+		t.Errorf("unexpected first line %+v", bill)
+	}
+	if bill.Date.Format("2006-01-02") != "2026-04-01" || bill.ValueDate.Format("2006-01-02") != "2026-03-31" {
+		t.Errorf("unexpected dates %s %s", bill.Date, bill.ValueDate)
+	}
+	if !bill.Amount.Equal(shareddomain.MustParseAmount("-54.5")) {
+		t.Errorf("expected -54.50, got %s", bill.Amount)
+	}
+
+	salary := statement.Lines[1]
+	if salary.Payee != "Example BV" || salary.Description != "Salary April" || salary.ExternalID != "" {
+		t.Errorf("unexpected second line %+v", salary)
+	}
+	if !salary.Amount.Equal(shareddomain.MustParseAmount("2000")) || salary.Date.Format("2006-01-02") != "2026-04-01" {
+		t.Errorf("unexpected amount or date %s %s", salary.Amount, salary.Date)
+	}
+
+	reversal := statement.Lines[2]
+	if reversal.Description != "Reversed card payment refunded in error" || !reversal.Amount.Equal(shareddomain.MustParseAmount("-10")) {
+		t.Errorf("expected a reversed credit to take money out, got %+v", reversal)
+	}
+
+	transfer := statement.Lines[3]
+	if transfer.Payee != "J. Jansen" || transfer.Description != "J. Jansen" {
+		t.Errorf("unexpected counterparty line %+v", transfer)
+	}
+
+	if statement.Lines[4].Error != `date "2604XX" is not an MT940 date` {
+		t.Errorf("expected the broken line to fail, got %q", statement.Lines[4].Error)
+	}
+}
+
+func TestParseStatementMT940_NotMT940(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"csv", "Date,Description,Amount\n15/03/2026,Coffee,-4.50\n"},
+		{"no lines", ":20:STMT\n:25:ACCOUNT\n:60F:C260301EUR1,00\n:62F:C260301EUR1,00\n"},
+		{"bad balance", ":20:STMT\n:25:ACCOUNT\n:60F:C2603EUR\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseStatementMT940([]byte(tt.content))
+
+			var importErr *ImportError
+			if !errors.As(err, &importErr) {
+				t.Errorf("expected an ImportError, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMT940BookingDate(t *testing.T) {
+	tests := []struct {
+		valueDate string
+		monthDay  string
+		expected  string
+	}{
+		{"2026-03-31", "0401", "2026-04-01"},
+		{"2025-12-31", "0102", "2026-01-02"},
+		{"2026-01-02", "1231", "2025-12-31"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.valueDate+" "+tt.monthDay, func(t *testing.T) {
+			valueDate, _ := time.Parse("2006-01-02", tt.valueDate)
+
+			booked, ok := mt940BookingDate(valueDate, tt.monthDay)
+			if !ok || booked.Format("2006-01-02") != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, booked.Format("2006-01-02"))
+			}
+		})
+	}
+}
@@ -0,0 +1,295 @@
+package services
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"fin-flow-api/internal/modules/imports/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+// ofxElement is an element of an OFX file. Aggregates have children and
+// leaves a value.
+type ofxElement struct {
+	name     string
+	value    string
+	children []*ofxElement
+}
+
+// find returns the first element with the given name below e, without
+// looking inside the elements named in skip.
+func (e *ofxElement) find(name string, skip ...string) *ofxElement {
+	for _, child := range e.children {
+		if child.name == name {
+			return child
+		}
+		if containsName(skip, child.name) {
+			continue
+		}
+		if found := child.find(name, skip...); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// findAll returns every element with the given name below e. Elements
+// found are not looked inside.
+func (e *ofxElement) findAll(name string) []*ofxElement {
+	var found []*ofxElement
+	for _, child := range e.children {
+		if child.name == name {
+			found = append(found, child)
+			continue
+		}
+		found = append(found, child.findAll(name)...)
+	}
+	return found
+}
+
+// text returns the value of the first leaf with the given name below e,
+// or an empty string.
+func (e *ofxElement) text(name string, skip ...string) string {
+	if found := e.find(name, skip...); found != nil {
+		return found.value
+	}
+	return ""
+}
+
+func containsName(names []string, name string) bool {
+	for _, candidate := range names {
+		if candidate == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseStatementOFX reads the bank and credit card statements of an OFX or
+// QFX file, both in the SGML layout of OFX 1.x, where leaf elements are not
+// closed, and in the XML layout of OFX 2.x. Each statement keeps the
+// account it belongs to. The lines of an OFX file mean little, so
+// transactions are numbered in the order they appear instead.
+func ParseStatementOFX(content []byte) ([]*domain.Statement, error) {
+	text := decodeLegacyText(content, ofxCharset(content))
+
+	start := indexOFXBody(text)
+	if start < 0 {
+		return nil, &ImportError{Line: 1, Message: "file is not an OFX statement"}
+	}
+
+	root, err := parseOFXElements(text, start)
+	if err != nil {
+		return nil, err
+	}
+
+	var statements []*domain.Statement
+	number := 0
+	for _, response := range append(root.findAll("STMTRS"), root.findAll("CCSTMTRS")...) {
+		statement := &domain.Statement{
+			Account:  response.text("ACCTID", "BANKTRANLIST"),
+			Currency: strings.ToUpper(response.text("CURDEF", "BANKTRANLIST")),
+		}
+
+		for _, entry := range response.findAll("STMTTRN") {
+			number++
+			if number > MaxImportRows {
+				return nil, &ImportError{Line: number, Message: fmt.Sprintf("more than %d lines", MaxImportRows)}
+			}
+			statement.Lines = append(statement.Lines, parseOFXTransaction(entry, number))
+		}
+
+		statements = append(statements, statement)
+	}
+
+	if number == 0 {
+		return nil, &ImportError{Line: 1, Message: "no statement lines found"}
+	}
+
+	return statements, nil
+}
+
+func parseOFXTransaction(entry *ofxElement, number int) *domain.StatementLine {
+	line := &domain.StatementLine{
+		Line:        number,
+		Description: entry.text("NAME", "PAYEE", "BANKACCTTO", "CCACCTTO"),
+		ExternalID:  entry.text("FITID"),
+	}
+	if payee := entry.find("PAYEE"); payee != nil {
+		line.Payee = payee.text("NAME")
+		if line.Description == "" {
+			line.Description = line.Payee
+		}
+	}
+	if memo := entry.text("MEMO"); memo != "" && memo != line.Description {
+		if line.Description == "" {
+			line.Description = memo
+		} else {
+			line.Description += " - " + memo
+		}
+	}
+
+	posted := entry.text("DTPOSTED")
+	date, ok := parseOFXDate(posted)
+	if !ok {
+		line.Error = fmt.Sprintf("date %q is not an OFX date", posted)
+		return line
+	}
+	line.Date = date
+
+	value := entry.text("TRNAMT")
+	amount, err := shareddomain.ParseAmount(ofxAmount(value))
+	if err != nil {
+		line.Error = fmt.Sprintf("amount %q is not a number", value)
+		return line
+	}
+	if amount.IsZero() {
+		line.Error = "amount is zero"
+		return line
+	}
+	line.Amount = amount
+
+	return line
+}
+
+// parseOFXDate reads the day of an OFX date, which is written as
+// YYYYMMDD optionally followed by the time and the time zone.
+func parseOFXDate(value string) (time.Time, bool) {
+	if len(value) < 8 {
+		return time.Time{}, false
+	}
+	date, err := time.Parse("20060102", value[:8])
+	return date, err == nil
+}
+
+// ofxAmount normalizes an OFX amount, which may use a comma as decimal
+// separator and an explicit plus sign.
+func ofxAmount(value string) string {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "+")
+	if !strings.Contains(value, ".") {
+		value = strings.Replace(value, ",", ".", 1)
+	}
+	return value
+}
+
+// parseOFXElements builds the element tree of the body of an OFX file. An
+// element followed by text is a leaf, whose closing tag is optional, and
+// any other is an aggregate that lasts until its closing tag. Closing tags
+// that match no open aggregate are ignored, which is what SGML files with
+// closed leaves need.
+func parseOFXElements(text string, start int) (*ofxElement, error) {
+	root := &ofxElement{}
+	stack := []*ofxElement{root}
+
+	for pos := start; pos < len(text); {
+		open := strings.IndexByte(text[pos:], '<')
+		if open < 0 {
+			break
+		}
+		open += pos
+
+		if strings.HasPrefix(text[open:], "<!--") {
+			end := strings.Index(text[open:], "-->")
+			if end < 0 {
+				break
+			}
+			pos = open + end + 3
+			continue
+		}
+
+		end := strings.IndexByte(text[open:], '>')
+		if end < 0 {
+			return nil, &ImportError{Line: lineAt(text, open), Message: "unterminated OFX tag"}
+		}
+		end += open
+		tag := strings.TrimSpace(text[open+1 : end])
+		pos = end + 1
+
+		switch {
+		case tag == "" || strings.HasPrefix(tag, "?") || strings.HasPrefix(tag, "!"):
+			continue
+		case strings.HasPrefix(tag, "/"):
+			name := strings.ToUpper(strings.TrimSpace(tag[1:]))
+			for i := len(stack) - 1; i > 0; i-- {
+				if stack[i].name == name {
+					stack = stack[:i]
+					break
+				}
+			}
+			continue
+		}
+
+		parent := stack[len(stack)-1]
+		element := &ofxElement{name: strings.ToUpper(strings.TrimSuffix(tag, "/"))}
+		parent.children = append(parent.children, element)
+		if strings.HasSuffix(tag, "/") {
+			continue
+		}
+
+		next := strings.IndexByte(text[pos:], '<')
+		if next < 0 {
+			next = len(text) - pos
+		}
+		value := strings.TrimSpace(text[pos : pos+next])
+		if value == "" {
+			stack = append(stack, element)
+			continue
+		}
+
+		element.value = html.UnescapeString(value)
+		pos += next
+		closing := "</" + element.name + ">"
+		if strings.HasPrefix(strings.ToUpper(text[pos:min(pos+len(closing), len(text))]), closing) {
+			pos += len(closing)
+		}
+	}
+
+	return root, nil
+}
+
+// ofxCharset returns the character set the header of an OFX file declares:
+// the CHARSET of SGML files or the encoding of the XML declaration.
+func ofxCharset(content []byte) string {
+	header := string(content)
+	if start := indexOFXBody(header); start >= 0 {
+		header = header[:start]
+	}
+	header = strings.ToUpper(header)
+
+	if start := strings.Index(header, "ENCODING=\""); start >= 0 {
+		value := header[start+len("ENCODING=\""):]
+		if end := strings.IndexByte(value, '"'); end >= 0 {
+			return value[:end]
+		}
+	}
+	if start := strings.Index(header, "CHARSET:"); start >= 0 {
+		value := header[start+len("CHARSET:"):]
+		if end := strings.IndexAny(value, "\r\n"); end >= 0 {
+			value = value[:end]
+		}
+		return strings.TrimSpace(value)
+	}
+	return ""
+}
+
+// indexOFXBody returns where the <OFX> element starts, whatever the case
+// of its tag, or -1.
+func indexOFXBody(text string) int {
+	for i := strings.IndexByte(text, '<'); i >= 0 && i+5 <= len(text); {
+		if strings.EqualFold(text[i:i+5], "<OFX>") {
+			return i
+		}
+		next := strings.IndexByte(text[i+1:], '<')
+		if next < 0 {
+			break
+		}
+		i += next + 1
+	}
+	return -1
+}
+
+func lineAt(text string, offset int) int {
+	return strings.Count(text[:offset], "\n") + 1
+}
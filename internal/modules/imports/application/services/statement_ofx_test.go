@@ -0,0 +1,152 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+const sgmlStatement = "OFXHEADER:100\r\n" +
+	"DATA:OFXSGML\r\n" +
+	"VERSION:102\r\n" +
+	"ENCODING:USASCII\r\n" +
+	"CHARSET:1252\r\n" +
+	"\r\n" +
+	"<OFX>\r\n" +
+	"<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0<SEVERITY>INFO</STATUS><DTSERVER>20260401</SONRS></SIGNONMSGSRSV1>\r\n" +
+	"<BANKMSGSRSV1><STMTTRNRS><TRNUID>1<STMTRS>\r\n" +
+	"<CURDEF>USD\r\n" +
+	"<BANKACCTFROM><BANKID>123<ACCTID>0001-234<ACCTTYPE>CHECKING</BANKACCTFROM>\r\n" +
+	"<BANKTRANLIST><DTSTART>20260301<DTEND>20260331\r\n" +
+	"<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20260315120000.000[-3:ART]<TRNAMT>-4.50<FITID>A1<NAME>Caf\xe9 Bar<MEMO>Card 1234</STMTTRN>\r\n" +
+	"<STMTTRN><TRNTYPE>CREDIT<DTPOSTED>20260316<TRNAMT>2500,00<FITID>A2<NAME>Salary &amp; bonus</STMTTRN>\r\n" +
+	"<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>yesterday<TRNAMT>-1<FITID>A3<NAME>Broken</STMTTRN>\r\n" +
+	"</BANKTRANLIST><LEDGERBAL><BALAMT>100<DTASOF>20260331</LEDGERBAL>\r\n" +
+	"</STMTRS></STMTTRNRS></BANKMSGSRSV1>\r\n" +
+	"</OFX>\r\n"
+
+const xmlStatement = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+  <BANKMSGSRSV1>
+    <STMTTRNRS>
+      <STMTRS>
+        <CURDEF>EUR</CURDEF>
+        <BANKACCTFROM><BANKID>1</BANKID><ACCTID>ES-1</ACCTID><ACCTTYPE>SAVINGS</ACCTTYPE></BANKACCTFROM>
+        <BANKTRANLIST>
+          <STMTTRN>
+            <TRNTYPE>XFER</TRNTYPE>
+            <DTPOSTED>20260310</DTPOSTED>
+            <TRNAMT>+150.25</TRNAMT>
+            <FITID>X1</FITID>
+            <PAYEE><NAME>Ana López</NAME><ADDR1>Main St</ADDR1></PAYEE>
+            <MEMO></MEMO>
+            <BANKACCTTO><BANKID>2</BANKID><ACCTID>OTHER</ACCTID><ACCTTYPE>CHECKING</ACCTTYPE></BANKACCTTO>
+          </STMTTRN>
+        </BANKTRANLIST>
+      </STMTRS>
+    </STMTTRNRS>
+  </BANKMSGSRSV1>
+  <CREDITCARDMSGSRSV1>
+    <CCSTMTTRNRS>
+      <CCSTMTRS>
+        <CURDEF>EUR</CURDEF>
+        <CCACCTFROM><ACCTID>4111</ACCTID></CCACCTFROM>
+        <BANKTRANLIST>
+          <STMTTRN><TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20260311</DTPOSTED><TRNAMT>-20.00</TRNAMT><FITID>C1</FITID><NAME>Books</NAME></STMTTRN>
+          <STMTTRN><TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20260312</DTPOSTED><TRNAMT>0.00</TRNAMT><FITID>C2</FITID><NAME>Nothing</NAME></STMTTRN>
+        </BANKTRANLIST>
+      </CCSTMTRS>
+    </CCSTMTTRNRS>
+  </CREDITCARDMSGSRSV1>
+</OFX>
+`
+
+func TestParseStatementOFX_SGML(t *testing.T) {
+	statements, err := ParseStatementOFX([]byte(sgmlStatement))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(statements) != 1 {
+		t.Fatalf("expected 1 statement, got %d", len(statements))
+	}
+	statement := statements[0]
+	if statement.Account != "0001-234" || statement.Currency != "USD" || len(statement.Lines) != 3 {
+		t.Fatalf("unexpected statement %+v", statement)
+	}
+
+	coffee := statement.Lines[0]
+	if coffee.Line != 1 || coffee.Description != "Café Bar - Card 1234" || coffee.ExternalID != "A1" {
+		t.Errorf("unexpected first line %+v", coffee)
+	}
+	if coffee.Date.Format("2006-01-02") != "2026-03-15" || !coffee.Amount.Equal(shareddomain.MustParseAmount("-4.5")) {
+		t.Errorf("unexpected date or amount %s %s", coffee.Date, coffee.Amount)
+	}
+
+	salary := statement.Lines[1]
+	if salary.Description != "Salary & bonus" || !salary.Amount.Equal(shareddomain.MustParseAmount("2500")) {
+		t.Errorf("unexpected second line %+v", salary)
+	}
+
+	if statement.Lines[2].Error != `date "yesterday" is not an OFX date` {
+		t.Errorf("expected a date error, got %q", statement.Lines[2].Error)
+	}
+}
+
+func TestParseStatementOFX_XML(t *testing.T) {
+	statements, err := ParseStatementOFX([]byte(xmlStatement))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(statements) != 2 {
+		t.Fatalf("expected 2 statements, got %d", len(statements))
+	}
+
+	bank := statements[0]
+	if bank.Account != "ES-1" || bank.Currency != "EUR" || len(bank.Lines) != 1 {
+		t.Fatalf("unexpected bank statement %+v", bank)
+	}
+	transfer := bank.Lines[0]
+	if transfer.Payee != "Ana López" || transfer.Description != "Ana López" || transfer.ExternalID != "X1" {
+		t.Errorf("unexpected transfer %+v", transfer)
+	}
+	if !transfer.Amount.Equal(shareddomain.MustParseAmount("150.25")) {
+		t.Errorf("expected 150.25, got %s", transfer.Amount)
+	}
+
+	card := statements[1]
+	if card.Account != "4111" || len(card.Lines) != 2 {
+		t.Fatalf("unexpected card statement %+v", card)
+	}
+	if card.Lines[0].Line != 2 || card.Lines[1].Line != 3 {
+		t.Errorf("expected transactions to be numbered across statements, got %d and %d", card.Lines[0].Line, card.Lines[1].Line)
+	}
+	if card.Lines[1].Error != "amount is zero" {
+		t.Errorf("expected a zero amount error, got %q", card.Lines[1].Error)
+	}
+}
+
+func TestParseStatementOFX_NotOFX(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"csv", "Date,Description,Amount\n15/03/2026,Coffee,-4.50\n"},
+		{"no transactions", "OFXHEADER:100\n\n<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><CURDEF>USD</STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>"},
+		{"unterminated tag", "<OFX><BANKMSGSRSV1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseStatementOFX([]byte(tt.content))
+
+			var importErr *ImportError
+			if !errors.As(err, &importErr) {
+				t.Errorf("expected an ImportError, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fin-flow-api/internal/modules/imports/domain"
+)
+
+// qifAccountTypes are the QIF sections whose records are transactions of a
+// bank, cash or credit card account. Investment, category, class and
+// memorized transaction lists are skipped.
+var qifAccountTypes = map[string]bool{
+	"bank":  true,
+	"cash":  true,
+	"ccard": true,
+	"oth a": true,
+	"oth l": true,
+}
+
+// ParseStatementQIF reads the bank, cash and credit card transactions of a
+// QIF file. Files exported with several accounts name each of them in an
+// !Account record before its transactions, which become the Account of
+// their statement. QIF has no fixed date or number format: dates are read
+// month first and amounts with a dot as decimal separator unless a
+// profile is given, whose date order, decimal separator, encoding and sign
+// are used instead.
+func ParseStatementQIF(content []byte, profile *domain.Profile) ([]*domain.Statement, error) {
+	dayFirst := false
+	text := ""
+	if profile != nil {
+		var err error
+		if text, err = decodeText(content, profile.Encoding); err != nil {
+			return nil, err
+		}
+		dayFirst = profile.DayFirst()
+	} else {
+		text = decodeLegacyText(content, "")
+		profile = domain.NewProfile("", "", "", "")
+	}
+
+	var statements []*domain.Statement
+	byAccount := make(map[string]*domain.Statement)
+	var current *domain.Statement
+
+	section := ""
+	account, pendingAccount := "", ""
+	record := make(map[byte]string)
+	recordLine := 0
+	count := 0
+
+	for i, raw := range strings.Split(text, "\n") {
+		number := i + 1
+		value := strings.TrimSpace(raw)
+		if value == "" {
+			continue
+		}
+
+		if value[0] == '!' {
+			header := strings.ToLower(value)
+			switch {
+			case header == "!account":
+				section = "account"
+			case strings.HasPrefix(header, "!type:"):
+				section = strings.TrimSpace(header[len("!type:"):])
+				current = nil
+				if qifAccountTypes[section] {
+					current = byAccount[account]
+					if current == nil {
+						current = &domain.Statement{Account: account}
+						byAccount[account] = current
+						statements = append(statements, current)
+					}
+				}
+			default:
+				section = ""
+			}
+			record = make(map[byte]string)
+			continue
+		}
+
+		code, field := value[0], strings.TrimSpace(value[1:])
+		if section == "account" {
+			switch code {
+			case 'N':
+				pendingAccount = field
+			case '^':
+				account = pendingAccount
+			}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		if code != '^' {
+			if len(record) == 0 {
+				recordLine = number
+			}
+			// Split lines repeat S, E and $; only the first of each field
+			// of the transaction itself matters.
+			if _, exists := record[code]; !exists {
+				record[code] = field
+			}
+			continue
+		}
+
+		if len(record) > 0 {
+			count++
+			if count > MaxImportRows {
+				return nil, &ImportError{Line: recordLine, Message: fmt.Sprintf("more than %d lines", MaxImportRows)}
+			}
+			current.Lines = append(current.Lines, parseQIFRecord(record, recordLine, dayFirst, profile))
+		}
+		record = make(map[byte]string)
+	}
+
+	if count == 0 {
+		return nil, &ImportError{Line: 1, Message: "no statement lines found"}
+	}
+
+	return statements, nil
+}
+
+func parseQIFRecord(record map[byte]string, number int, dayFirst bool, profile *domain.Profile) *domain.StatementLine {
+	line := &domain.StatementLine{
+		Line:        number,
+		Description: record['M'],
+		Payee:       record['P'],
+	}
+	if line.Description == "" {
+		line.Description = line.Payee
+	}
+
+	date, ok := parseQIFDate(record['D'], dayFirst)
+	if !ok {
+		line.Error = fmt.Sprintf("date %q is not a QIF date", record['D'])
+		return line
+	}
+	line.Date = date
+
+	value, ok := record['T']
+	if !ok {
+		value, ok = record['U']
+	}
+	if !ok {
+		line.Error = "amount is missing"
+		return line
+	}
+	amount, err := profile.ParseAmount(value)
+	if err != nil {
+		line.Error = fmt.Sprintf("amount %q is not a number", value)
+		return line
+	}
+	if profile.NegateAmounts {
+		amount = amount.Neg()
+	}
+	if amount.IsZero() {
+		line.Error = "amount is zero"
+		return line
+	}
+	line.Amount = amount
+
+	return line
+}
+
+// parseQIFDate reads the many ways QIF files write dates: 3/15/26,
+// 03/15/2026, 3/15'26 or 2026-03-15, with the day first when dayFirst is
+// set. Two-digit years after an apostrophe are in the 2000s; after any
+// other separator, years below 70 are too.
+func parseQIFDate(value string, dayFirst bool) (time.Time, bool) {
+	value = strings.ReplaceAll(value, " ", "")
+	apostrophe := strings.Contains(value, "'")
+	parts := strings.FieldsFunc(value, func(r rune) bool {
+		return r == '/' || r == '\'' || r == '-' || r == '.'
+	})
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+
+	var numbers [3]int
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return time.Time{}, false
+		}
+		numbers[i] = number
+	}
+
+	var year, month, day int
+	switch {
+	case len(parts[0]) == 4:
+		year, month, day = numbers[0], numbers[1], numbers[2]
+	case dayFirst:
+		day, month, year = numbers[0], numbers[1], numbers[2]
+	default:
+		month, day, year = numbers[0], numbers[1], numbers[2]
+	}
+
+	if len(parts[0]) != 4 && len(parts[2]) <= 2 {
+		if apostrophe || year < 70 {
+			year += 2000
+		} else {
+			year += 1900
+		}
+	}
+
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if date.Year() != year || int(date.Month()) != month || date.Day() != day {
+		return time.Time{}, false
+	}
+	return date, true
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"testing"
+
+	"fin-flow-api/internal/modules/imports/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+func TestParseStatementQIF(t *testing.T) {
+	content := "!Type:Bank\n" +
+		"D3/15'26\n" +
+		"T-1,234.50\n" +
+		"PSupermarket\n" +
+		"MWeekly groceries\n" +
+		"LFood\n" +
+		"^\n" +
+		"D03/16/2026\n" +
+		"U2,500.00\n" +
+		"T2,500.00\n" +
+		"PEmployer\n" +
+		"SSalary\n" +
+		"$2000.00\n" +
+		"SBonus\n" +
+		"$500.00\n" +
+		"^\n" +
+		"D13/13/2026\n" +
+		"T-1\n" +
+		"^\n"
+
+	statements, err := ParseStatementQIF([]byte(content), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(statements) != 1 || statements[0].Account != "" || len(statements[0].Lines) != 3 {
+		t.Fatalf("unexpected statements %+v", statements)
+	}
+
+	groceries := statements[0].Lines[0]
+	if groceries.Line != 2 || groceries.Payee != "Supermarket" || groceries.Description != "Weekly groceries" {
+		t.Errorf("unexpected first line %+v", groceries)
+	}
+	if groceries.Date.Format("2006-01-02") != "2026-03-15" || !groceries.Amount.Equal(shareddomain.MustParseAmount("-1234.5")) {
+		t.Errorf("unexpected date or amount %s %s", groceries.Date, groceries.Amount)
+	}
+
+	salary := statements[0].Lines[1]
+	if salary.Line != 8 || salary.Description != "Employer" || !salary.Amount.Equal(shareddomain.MustParseAmount("2500")) {
+		t.Errorf("unexpected second line %+v", salary)
+	}
+
+	if statements[0].Lines[2].Error != `date "13/13/2026" is not a QIF date` {
+		t.Errorf("expected a date error, got %q", statements[0].Lines[2].Error)
+	}
+}
+
+func TestParseStatementQIF_Accounts(t *testing.T) {
+	content := "!Option:AutoSwitch\n" +
+		"!Account\n" +
+		"NChecking\n" +
+		"TBank\n" +
+		"^\n" +
+		"NVisa\n" +
+		"TCCard\n" +
+		"^\n" +
+		"!Clear:AutoSwitch\n" +
+		"!Type:Cat\n" +
+		"NFood\n" +
+		"E\n" +
+		"^\n" +
+		"!Account\n" +
+		"NChecking\n" +
+		"TBank\n" +
+		"^\n" +
+		"!Type:Bank\n" +
+		"D15/03/2026\n" +
+		"T-10,50\n" +
+		"PBakery\n" +
+		"^\n" +
+		"!Account\n" +
+		"NVisa\n" +
+		"TCCard\n" +
+		"^\n" +
+		"!Type:CCard\n" +
+		"D16/03/2026\n" +
+		"T-99,99\n" +
+		"PAirline\n" +
+		"^\n"
+
+	profile := domain.NewProfile("profile1", "user1", "QIF", "system")
+	profile.DateFormat = "DD/MM/YYYY"
+	profile.DecimalSeparator = ","
+
+	statements, err := ParseStatementQIF([]byte(content), profile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(statements) != 2 {
+		t.Fatalf("expected 2 statements, got %d", len(statements))
+	}
+	if statements[0].Account != "Checking" || statements[1].Account != "Visa" {
+		t.Errorf("unexpected accounts %q and %q", statements[0].Account, statements[1].Account)
+	}
+
+	bakery := statements[0].Lines[0]
+	if bakery.Date.Format("2006-01-02") != "2026-03-15" || !bakery.Amount.Equal(shareddomain.MustParseAmount("-10.5")) {
+		t.Errorf("unexpected bakery line %+v", bakery)
+	}
+	if len(statements[1].Lines) != 1 || statements[1].Lines[0].Payee != "Airline" {
+		t.Errorf("unexpected card lines %+v", statements[1].Lines)
+	}
+}
+
+func TestParseQIFDate(t *testing.T) {
+	tests := []struct {
+		value    string
+		dayFirst bool
+		expected string
+	}{
+		{"3/15/26", false, "2026-03-15"},
+		{"3/15'26", false, "2026-03-15"},
+		{" 3/ 5'05", false, "2005-03-05"},
+		{"12/31/99", false, "1999-12-31"},
+		{"03/15/2026", false, "2026-03-15"},
+		{"2026-03-15", false, "2026-03-15"},
+		{"15/03/2026", true, "2026-03-15"},
+		{"15.03.26", true, "2026-03-15"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			date, ok := parseQIFDate(tt.value, tt.dayFirst)
+			if !ok {
+				t.Fatalf("expected %q to be read", tt.value)
+			}
+			if date.Format("2006-01-02") != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, date.Format("2006-01-02"))
+			}
+		})
+	}
+
+	for _, value := range []string{"", "15/03/2026", "3/15", "a/b/c"} {
+		if _, ok := parseQIFDate(value, false); ok {
+			t.Errorf("expected %q to be rejected", value)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"errors"
+	"path"
+	"strings"
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+var (
+	ErrNothingToImport    = errors.New("the statement has no lines to import")
+	ErrBatchAlreadyUndone = errors.New("import has already been undone")
+	ErrInvalidFormat      = errors.New("format must be csv, ofx, qif, camt053 or mt940")
+	ErrProfileRequired    = errors.New("an import profile is required for CSV statements")
+	ErrWalletRequired     = errors.New("a wallet is required for statements that do not name their account")
+)
+
+type BatchStatus string
+
+const (
+	BatchStatusCommitted BatchStatus = "committed"
+	BatchStatusUndone    BatchStatus = "undone"
+)
+
+// Statement file formats. CSV statements are read with an import profile,
+// OFX covers QFX files as well, and QIF is the format of older desktop
+// finance tools. European banks export ISO 20022 camt.053 XML and SWIFT
+// MT940 statements.
+const (
+	FormatCSV     = "csv"
+	FormatOFX     = "ofx"
+	FormatQIF     = "qif"
+	FormatCAMT053 = "camt053"
+	FormatMT940   = "mt940"
+)
+
+func IsValidFormat(format string) bool {
+	switch format {
+	case FormatCSV, FormatOFX, FormatQIF, FormatCAMT053, FormatMT940:
+		return true
+	}
+	return false
+}
+
+// DetectFormat guesses the format of a statement from the extension of its
+// file name or else from how its content starts, falling back to CSV.
+func DetectFormat(fileName string, content []byte) string {
+	switch strings.ToLower(path.Ext(fileName)) {
+	case ".ofx", ".qfx":
+		return FormatOFX
+	case ".qif":
+		return FormatQIF
+	case ".sta", ".mt940", ".940":
+		return FormatMT940
+	case ".csv":
+		return FormatCSV
+	}
+
+	head := content
+	if len(head) > 1024 {
+		head = head[:1024]
+	}
+	start := strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(string(head), "\xef\xbb\xbf")))
+	switch {
+	case strings.HasPrefix(start, "OFXHEADER"), strings.Contains(start, "<OFX>"):
+		return FormatOFX
+	case strings.HasPrefix(start, "!TYPE:"), strings.HasPrefix(start, "!ACCOUNT"), strings.HasPrefix(start, "!OPTION:"):
+		return FormatQIF
+	case strings.Contains(start, "CAMT.053"), strings.Contains(start, "<BKTOCSTMRSTMT"):
+		return FormatCAMT053
+	case strings.HasPrefix(start, ":20:"), strings.HasPrefix(start, "{1:"):
+		return FormatMT940
+	}
+	return FormatCSV
+}
+
+// Batch is a statement committed into a wallet. Its transactions remember
+// the batch, so undoing it deletes all of them. The batch itself is kept
+// as a record of the import. WalletID is empty when the file held several
+// accounts that went to different wallets.
+type Batch struct {
+	domain.Entity
+
+	ID               string
+	UserID           string
+	WalletID         string
+	ProfileID        string
+	Format           string
+	FileName         string
+	TransactionCount int
+	Status           BatchStatus
+	UndoneAt         *time.Time
+}
+
+func NewBatch(id, userID, walletID, profileID, format, fileName string, transactionCount int, createdBy string) *Batch {
+	return &Batch{
+		Entity:           domain.NewEntity(id, createdBy),
+		ID:               id,
+		UserID:           userID,
+		WalletID:         walletID,
+		ProfileID:        profileID,
+		Format:           format,
+		FileName:         fileName,
+		TransactionCount: transactionCount,
+		Status:           BatchStatusCommitted,
+	}
+}
+
+// Undo marks the batch as undone. Deleting its transactions is up to the
+// caller.
+func (b *Batch) Undo(undoneBy string) error {
+	if b.Status == BatchStatusUndone {
+		return ErrBatchAlreadyUndone
+	}
+
+	b.Entity.UpdateModified(undoneBy)
+	undoneAt := b.ModifiedAt
+	b.Status = BatchStatusUndone
+	b.UndoneAt = &undoneAt
+	return nil
+}
@@ -0,0 +1,199 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+var (
+	ErrNameRequired            = errors.New("import profile name is required")
+	ErrInvalidDelimiter        = errors.New("delimiter must be a single character other than a quote or a line break")
+	ErrInvalidEncoding         = errors.New("encoding must be utf-8, iso-8859-1 or windows-1252")
+	ErrInvalidDecimalSeparator = errors.New("decimal separator must be a dot or a comma")
+	ErrInvalidDateFormat       = errors.New("date format must combine DD, MM and YY or YYYY, such as DD/MM/YYYY")
+	ErrInvalidColumn           = errors.New("columns are numbered from 1")
+	ErrDateColumnRequired      = errors.New("date column is required")
+	ErrDescriptionRequired     = errors.New("description column is required")
+	ErrAmountColumnsRequired   = errors.New("an amount column or both debit and credit columns are required")
+	ErrInvalidSkipRows         = errors.New("skip rows must not be negative")
+)
+
+const (
+	EncodingUTF8        = "utf-8"
+	EncodingLatin1      = "iso-8859-1"
+	EncodingWindows1252 = "windows-1252"
+)
+
+// Profile describes how the CSV statements of a bank are laid out. Columns
+// are numbered from 1 and 0 leaves a column out. Amounts are read either
+// signed from AmountColumn, with money coming in positive, or as credit
+// minus debit from two columns. NegateAmounts flips the sign of every
+// amount, for exports that show money going out as positive.
+type Profile struct {
+	domain.Entity
+
+	ID                string
+	UserID            string
+	Name              string
+	Delimiter         string
+	Encoding          string
+	SkipRows          int
+	HasHeader         bool
+	DateColumn        int
+	DateFormat        string
+	DescriptionColumn int
+	PayeeColumn       int
+	AmountColumn      int
+	DebitColumn       int
+	CreditColumn      int
+	DecimalSeparator  string
+	NegateAmounts     bool
+}
+
+func NewProfile(id, userID, name string, createdBy string) *Profile {
+	return &Profile{
+		Entity:           domain.NewEntity(id, createdBy),
+		ID:               id,
+		UserID:           userID,
+		Name:             name,
+		Delimiter:        ",",
+		Encoding:         EncodingUTF8,
+		HasHeader:        true,
+		DecimalSeparator: ".",
+	}
+}
+
+func IsValidEncoding(encoding string) bool {
+	switch encoding {
+	case EncodingUTF8, EncodingLatin1, EncodingWindows1252:
+		return true
+	}
+	return false
+}
+
+func (p *Profile) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return ErrNameRequired
+	}
+	if utf8.RuneCountInString(p.Delimiter) != 1 || strings.ContainsAny(p.Delimiter, "\"\r\n") {
+		return ErrInvalidDelimiter
+	}
+	if !IsValidEncoding(p.Encoding) {
+		return ErrInvalidEncoding
+	}
+	if p.DecimalSeparator != "." && p.DecimalSeparator != "," {
+		return ErrInvalidDecimalSeparator
+	}
+	if p.SkipRows < 0 {
+		return ErrInvalidSkipRows
+	}
+	if _, err := p.DateLayout(); err != nil {
+		return err
+	}
+
+	for _, column := range []int{p.DateColumn, p.DescriptionColumn, p.PayeeColumn, p.AmountColumn, p.DebitColumn, p.CreditColumn} {
+		if column < 0 {
+			return ErrInvalidColumn
+		}
+	}
+	if p.DateColumn == 0 {
+		return ErrDateColumnRequired
+	}
+	if p.DescriptionColumn == 0 {
+		return ErrDescriptionRequired
+	}
+	if p.AmountColumn == 0 && (p.DebitColumn == 0 || p.CreditColumn == 0) {
+		return ErrAmountColumnsRequired
+	}
+
+	return nil
+}
+
+// DateLayout turns DateFormat into the layout of the time package. DD, MM,
+// YY and YYYY stand for the day, month and year, and dots, dashes,
+// slashes and spaces are kept as they are.
+func (p *Profile) DateLayout() (string, error) {
+	format := strings.ToUpper(strings.TrimSpace(p.DateFormat))
+
+	var layout strings.Builder
+	var day, month, year int
+	for format != "" {
+		switch {
+		case strings.HasPrefix(format, "YYYY"):
+			layout.WriteString("2006")
+			format = format[4:]
+			year++
+		case strings.HasPrefix(format, "YY"):
+			layout.WriteString("06")
+			format = format[2:]
+			year++
+		case strings.HasPrefix(format, "MM"):
+			layout.WriteString("01")
+			format = format[2:]
+			month++
+		case strings.HasPrefix(format, "DD"):
+			layout.WriteString("02")
+			format = format[2:]
+			day++
+		case strings.ContainsAny(format[:1], "./- "):
+			layout.WriteString(format[:1])
+			format = format[1:]
+		default:
+			return "", ErrInvalidDateFormat
+		}
+	}
+
+	if day != 1 || month != 1 || year != 1 {
+		return "", ErrInvalidDateFormat
+	}
+
+	return layout.String(), nil
+}
+
+// DayFirst reports whether the date format puts the day before the month.
+// QIF dates are read in that order when the profile is used with them.
+func (p *Profile) DayFirst() bool {
+	format := strings.ToUpper(p.DateFormat)
+	return strings.Index(format, "DD") < strings.Index(format, "MM")
+}
+
+// ParseAmount reads an amount written with the decimal separator of the
+// profile. Thousands separators, spaces and currency symbols are ignored,
+// and a leading or trailing minus or parentheses make it negative.
+func (p *Profile) ParseAmount(value string) (domain.Amount, error) {
+	value = strings.TrimSpace(value)
+
+	negative := false
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		negative = true
+		value = value[1 : len(value)-1]
+	}
+
+	var digits strings.Builder
+	for i, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case string(r) == p.DecimalSeparator:
+			digits.WriteByte('.')
+		case r == '-' && (i == 0 || i == len(value)-1 || digits.Len() == 0):
+			negative = true
+		}
+	}
+
+	if digits.Len() == 0 {
+		return domain.Amount{}, domain.ErrInvalidAmountFormat
+	}
+
+	amount, err := domain.ParseAmount(digits.String())
+	if err != nil {
+		return domain.Amount{}, err
+	}
+	if negative {
+		amount = amount.Neg()
+	}
+	return amount, nil
+}
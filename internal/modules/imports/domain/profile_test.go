@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"testing"
+
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+func newTestProfile() *Profile {
+	profile := NewProfile("profile-1", "user-1", "Bank", "system")
+	profile.DateColumn = 1
+	profile.DateFormat = "DD/MM/YYYY"
+	profile.DescriptionColumn = 2
+	profile.AmountColumn = 3
+	return profile
+}
+
+func TestProfile_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		profile  func(profile *Profile)
+		expected error
+	}{
+		{"valid", func(profile *Profile) {}, nil},
+		{"debit and credit", func(profile *Profile) { profile.AmountColumn = 0; profile.DebitColumn = 3; profile.CreditColumn = 4 }, nil},
+		{"tab delimiter", func(profile *Profile) { profile.Delimiter = "\t" }, nil},
+		{"no name", func(profile *Profile) { profile.Name = " " }, ErrNameRequired},
+		{"long delimiter", func(profile *Profile) { profile.Delimiter = ";;" }, ErrInvalidDelimiter},
+		{"quote delimiter", func(profile *Profile) { profile.Delimiter = "\"" }, ErrInvalidDelimiter},
+		{"unknown encoding", func(profile *Profile) { profile.Encoding = "utf-16" }, ErrInvalidEncoding},
+		{"bad decimal separator", func(profile *Profile) { profile.DecimalSeparator = "'" }, ErrInvalidDecimalSeparator},
+		{"negative skip rows", func(profile *Profile) { profile.SkipRows = -1 }, ErrInvalidSkipRows},
+		{"bad date format", func(profile *Profile) { profile.DateFormat = "MM/YYYY" }, ErrInvalidDateFormat},
+		{"negative column", func(profile *Profile) { profile.PayeeColumn = -1 }, ErrInvalidColumn},
+		{"no date column", func(profile *Profile) { profile.DateColumn = 0 }, ErrDateColumnRequired},
+		{"no description column", func(profile *Profile) { profile.DescriptionColumn = 0 }, ErrDescriptionRequired},
+		{"debit only", func(profile *Profile) { profile.AmountColumn = 0; profile.DebitColumn = 3 }, ErrAmountColumnsRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := newTestProfile()
+			tt.profile(profile)
+
+			if err := profile.Validate(); err != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestProfile_DateLayout(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected string
+		valid    bool
+	}{
+		{"DD/MM/YYYY", "02/01/2006", true},
+		{"mm-dd-yy", "01-02-06", true},
+		{"YYYYMMDD", "20060102", true},
+		{"DD.MM.YYYY", "02.01.2006", true},
+		{"DD/MM", "", false},
+		{"DD/MM/YYYY/YY", "", false},
+		{"D/M/YYYY", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			profile := newTestProfile()
+			profile.DateFormat = tt.format
+
+			layout, err := profile.DateLayout()
+			if tt.valid && (err != nil || layout != tt.expected) {
+				t.Errorf("expected %q, got %q (%v)", tt.expected, layout, err)
+			}
+			if !tt.valid && err != ErrInvalidDateFormat {
+				t.Errorf("expected ErrInvalidDateFormat, got %v", err)
+			}
+		})
+	}
+}
+
+func TestProfile_ParseAmount(t *testing.T) {
+	tests := []struct {
+		value     string
+		separator string
+		expected  string
+	}{
+		{"1234.56", ".", "1234.56"},
+		{"-1,234.56", ".", "-1234.56"},
+		{"1.234,56", ",", "1234.56"},
+		{"€ -12,50", ",", "-12.5"},
+		{"12.50-", ".", "-12.5"},
+		{"(45.00)", ".", "-45"},
+		{"$ 1 000", ".", "1000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			profile := newTestProfile()
+			profile.DecimalSeparator = tt.separator
+
+			amount, err := profile.ParseAmount(tt.value)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !amount.Equal(shareddomain.MustParseAmount(tt.expected)) {
+				t.Errorf("expected %s, got %s", tt.expected, amount)
+			}
+		})
+	}
+
+	for _, value := range []string{"", "n/a", "1.2.3"} {
+		if _, err := newTestProfile().ParseAmount(value); err == nil {
+			t.Errorf("expected an error for %q", value)
+		}
+	}
+}
+
+func TestBatch_Undo(t *testing.T) {
+	batch := NewBatch("batch-1", "user-1", "wallet-1", "profile-1", FormatCSV, "march.csv", 3, "system")
+
+	if err := batch.Undo("system"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if batch.Status != BatchStatusUndone || batch.UndoneAt == nil {
+		t.Errorf("expected the batch to be undone, got %+v", batch)
+	}
+
+	if err := batch.Undo("system"); err != ErrBatchAlreadyUndone {
+		t.Errorf("expected ErrBatchAlreadyUndone, got %v", err)
+	}
+}
+
+func TestProfile_DayFirst(t *testing.T) {
+	tests := map[string]bool{
+		"DD/MM/YYYY": true,
+		"MM/DD/YY":   false,
+		"YYYY-MM-DD": false,
+		"dd.mm.yyyy": true,
+	}
+
+	for format, expected := range tests {
+		profile := &Profile{DateFormat: format}
+		if profile.DayFirst() != expected {
+			t.Errorf("%s: expected %v", format, expected)
+		}
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		content  string
+		expected string
+	}{
+		{"qfx extension", "march.QFX", "anything", FormatOFX},
+		{"qif extension", "march.qif", "anything", FormatQIF},
+		{"csv extension", "march.csv", "OFXHEADER:100", FormatCSV},
+		{"sgml header", "", "\xef\xbb\xbfOFXHEADER:100\nDATA:OFXSGML\n", FormatOFX},
+		{"xml body", "", "<?xml version=\"1.0\"?>\n<OFX>\n", FormatOFX},
+		{"qif header", "", "!Type:Bank\nD3/15/26\n", FormatQIF},
+		{"qif accounts", "", "!Option:AutoSwitch\n!Account\n", FormatQIF},
+		{"sta extension", "march.sta", "anything", FormatMT940},
+		{"camt namespace", "march.xml", "<?xml version=\"1.0\"?>\n<Document xmlns=\"urn:iso:std:iso:20022:tech:xsd:camt.053.001.02\">\n", FormatCAMT053},
+		{"mt940 blocks", "march.txt", "{1:F01BANKDEFFXXXX0000000000}{2:I940}{4:\n:20:STMT\n", FormatMT940},
+		{"mt940 fields", "", ":20:STMT\n:25:DE89370400440532013000\n", FormatMT940},
+		{"txt content", "march.txt", "Date,Description,Amount\n", FormatCSV},
+		{"csv content", "", "Date,Description,Amount\n", FormatCSV},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectFormat(tt.fileName, []byte(tt.content)); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
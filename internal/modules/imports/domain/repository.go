@@ -0,0 +1,22 @@
+package domain
+
+import transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+
+type ProfileRepository interface {
+	Create(profile *Profile) error
+	GetByID(id string, userID string) (*Profile, error)
+	List(userID string) ([]*Profile, error)
+	Update(profile *Profile) error
+	Delete(id string, userID string) error
+}
+
+type BatchRepository interface {
+	// Create stores the batch and its transactions, all or none of them.
+	Create(batch *Batch, transactions []*transactiondomain.Transaction) error
+	GetByID(id string, userID string) (*Batch, error)
+	// List returns the batches of a user, newest first.
+	List(userID string) ([]*Batch, error)
+	// Undo deletes the transactions of the batch and saves it as undone,
+	// both or neither.
+	Undo(batch *Batch) error
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"time"
+
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	"fin-flow-api/internal/shared/domain"
+)
+
+// Statement is the part of a statement file that belongs to one account.
+// Account is the number the file gives the account and Currency the code
+// of its currency; either is empty when the file does not say. Files that
+// report the balance of the account before and after their lines keep it
+// in OpeningBalance and ClosingBalance, which are nil otherwise.
+type Statement struct {
+	Account        string
+	Currency       string
+	OpeningBalance *domain.Amount
+	ClosingBalance *domain.Amount
+	Lines          []*StatementLine
+}
+
+// StatementLine is an entry read from a bank statement. Line is where it
+// was found in the file. Amount is signed: money that came into the
+// account is positive and money that left it negative. ExternalID is the
+// id the bank gave the entry and ValueDate the day it took effect for
+// interest, if the file gives them. A line that could not be read keeps
+// the reason in Error.
+type StatementLine struct {
+	Line        int
+	Date        time.Time
+	ValueDate   *time.Time
+	Description string
+	Payee       string
+	Amount      domain.Amount
+	ExternalID  string
+	Error       string
+}
+
+// TransactionType returns the type of the transaction the line becomes.
+func (l *StatementLine) TransactionType() transactiondomain.TransactionType {
+	if l.Amount.IsNegative() {
+		return transactiondomain.TransactionTypeExpense
+	}
+	return transactiondomain.TransactionTypeIncome
+}
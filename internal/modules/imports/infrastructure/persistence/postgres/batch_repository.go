@@ -0,0 +1,226 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"fin-flow-api/internal/modules/imports/domain"
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	transactionpostgres "fin-flow-api/internal/modules/transactions/infrastructure/persistence/postgres"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const batchColumns = `id, user_id, wallet_id, profile_id, format, file_name, transaction_count, status, undone_at, created_at, modified_at, created_by, modified_by`
+
+type BatchRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewBatchRepository(pool *pgxpool.Pool) *BatchRepository {
+	return &BatchRepository{pool: pool}
+}
+
+// Create stores the batch together with its transactions in a single
+// database transaction, so a failed import leaves neither behind.
+func (r *BatchRepository) Create(batch *domain.Batch, transactions []*transactiondomain.Transaction) error {
+	ctx := context.Background()
+
+	dbTx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create import batch: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	query := `
+		INSERT INTO import_batches (` + batchColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+
+	_, err = dbTx.Exec(
+		ctx,
+		query,
+		batch.ID,
+		batch.UserID,
+		nullableString(batch.WalletID),
+		nullableString(batch.ProfileID),
+		batch.Format,
+		batch.FileName,
+		batch.TransactionCount,
+		string(batch.Status),
+		batch.UndoneAt,
+		batch.CreatedAt,
+		batch.ModifiedAt,
+		batch.CreatedBy,
+		batch.ModifiedBy,
+	)
+	if err != nil {
+		return mapBatchWriteError("failed to create import batch", err)
+	}
+
+	if err := transactionpostgres.InsertAll(ctx, dbTx, transactions); err != nil {
+		return err
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit import batch: %w", err)
+	}
+
+	return nil
+}
+
+func (r *BatchRepository) GetByID(id string, userID string) (*domain.Batch, error) {
+	checkQuery := `SELECT user_id FROM import_batches WHERE id = $1`
+	var batchUserID string
+	err := r.pool.QueryRow(context.Background(), checkQuery, id).Scan(&batchUserID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("import batch not found")
+		}
+		return nil, fmt.Errorf("failed to get import batch: %w", err)
+	}
+
+	if batchUserID != userID {
+		return nil, fmt.Errorf("unauthorized access to import batch")
+	}
+
+	query := `SELECT ` + batchColumns + ` FROM import_batches WHERE id = $1 AND user_id = $2`
+
+	batch, err := scanBatch(r.pool.QueryRow(context.Background(), query, id, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("import batch not found")
+		}
+		return nil, fmt.Errorf("failed to get import batch: %w", err)
+	}
+
+	return batch, nil
+}
+
+func (r *BatchRepository) List(userID string) ([]*domain.Batch, error) {
+	query := `SELECT ` + batchColumns + ` FROM import_batches WHERE user_id = $1 ORDER BY created_at DESC`
+
+	rows, err := r.pool.Query(context.Background(), query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list import batches: %w", err)
+	}
+	defer rows.Close()
+
+	var batches []*domain.Batch
+	for rows.Next() {
+		batch, err := scanBatch(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan import batch: %w", err)
+		}
+		batches = append(batches, batch)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate import batches: %w", err)
+	}
+
+	return batches, nil
+}
+
+// Undo deletes the transactions of the batch and saves it as undone in a
+// single database transaction.
+func (r *BatchRepository) Undo(batch *domain.Batch) error {
+	ctx := context.Background()
+
+	dbTx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to undo import batch: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	if err := transactionpostgres.DeleteImported(ctx, dbTx, batch.ID, batch.UserID); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE import_batches
+		SET status = $2, undone_at = $3, modified_at = $4, modified_by = $5
+		WHERE id = $1 AND user_id = $6
+	`
+
+	result, err := dbTx.Exec(
+		ctx,
+		query,
+		batch.ID,
+		string(batch.Status),
+		batch.UndoneAt,
+		batch.ModifiedAt,
+		batch.ModifiedBy,
+		batch.UserID,
+	)
+	if err != nil {
+		return mapBatchWriteError("failed to update import batch", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("import batch not found")
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit import batch: %w", err)
+	}
+
+	return nil
+}
+
+func scanBatch(row pgx.Row) (*domain.Batch, error) {
+	var batch domain.Batch
+	var walletID, profileID *string
+	var status string
+
+	err := row.Scan(
+		&batch.ID,
+		&batch.UserID,
+		&walletID,
+		&profileID,
+		&batch.Format,
+		&batch.FileName,
+		&batch.TransactionCount,
+		&status,
+		&batch.UndoneAt,
+		&batch.CreatedAt,
+		&batch.ModifiedAt,
+		&batch.CreatedBy,
+		&batch.ModifiedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	batch.Status = domain.BatchStatus(status)
+	if walletID != nil {
+		batch.WalletID = *walletID
+	}
+	if profileID != nil {
+		batch.ProfileID = *profileID
+	}
+
+	return &batch, nil
+}
+
+func mapBatchWriteError(message string, err error) error {
+	if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" { // foreign_key_violation
+		if strings.Contains(pgErr.ConstraintName, "profile") {
+			return fmt.Errorf("invalid import profile reference")
+		}
+		return fmt.Errorf("invalid wallet reference")
+	}
+	return fmt.Errorf("%s: %w", message, err)
+}
+
+func nullableString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,229 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"fin-flow-api/internal/modules/imports/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const profileColumns = `id, user_id, name, delimiter, encoding, skip_rows, has_header, date_column, date_format, description_column, payee_column, amount_column, debit_column, credit_column, decimal_separator, negate_amounts, created_at, modified_at, created_by, modified_by`
+
+type ProfileRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewProfileRepository(pool *pgxpool.Pool) *ProfileRepository {
+	return &ProfileRepository{pool: pool}
+}
+
+func (r *ProfileRepository) Create(profile *domain.Profile) error {
+	query := `
+		INSERT INTO import_profiles (` + profileColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+	`
+
+	_, err := r.pool.Exec(
+		context.Background(),
+		query,
+		profile.ID,
+		profile.UserID,
+		profile.Name,
+		profile.Delimiter,
+		profile.Encoding,
+		profile.SkipRows,
+		profile.HasHeader,
+		profile.DateColumn,
+		profile.DateFormat,
+		profile.DescriptionColumn,
+		profile.PayeeColumn,
+		profile.AmountColumn,
+		profile.DebitColumn,
+		profile.CreditColumn,
+		profile.DecimalSeparator,
+		profile.NegateAmounts,
+		profile.CreatedAt,
+		profile.ModifiedAt,
+		profile.CreatedBy,
+		profile.ModifiedBy,
+	)
+	if err != nil {
+		return mapProfileWriteError("failed to create import profile", err)
+	}
+
+	return nil
+}
+
+func (r *ProfileRepository) GetByID(id string, userID string) (*domain.Profile, error) {
+	checkQuery := `SELECT user_id FROM import_profiles WHERE id = $1`
+	var profileUserID string
+	err := r.pool.QueryRow(context.Background(), checkQuery, id).Scan(&profileUserID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("import profile not found")
+		}
+		return nil, fmt.Errorf("failed to get import profile: %w", err)
+	}
+
+	if profileUserID != userID {
+		return nil, fmt.Errorf("unauthorized access to import profile")
+	}
+
+	query := `SELECT ` + profileColumns + ` FROM import_profiles WHERE id = $1 AND user_id = $2`
+
+	profile, err := scanProfile(r.pool.QueryRow(context.Background(), query, id, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("import profile not found")
+		}
+		return nil, fmt.Errorf("failed to get import profile: %w", err)
+	}
+
+	return profile, nil
+}
+
+func (r *ProfileRepository) List(userID string) ([]*domain.Profile, error) {
+	query := `SELECT ` + profileColumns + ` FROM import_profiles WHERE user_id = $1 ORDER BY name ASC`
+
+	rows, err := r.pool.Query(context.Background(), query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list import profiles: %w", err)
+	}
+	defer rows.Close()
+
+	var profiles []*domain.Profile
+	for rows.Next() {
+		profile, err := scanProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan import profile: %w", err)
+		}
+		profiles = append(profiles, profile)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate import profiles: %w", err)
+	}
+
+	return profiles, nil
+}
+
+func (r *ProfileRepository) Update(profile *domain.Profile) error {
+	query := `
+		UPDATE import_profiles
+		SET name = $2, delimiter = $3, encoding = $4, skip_rows = $5, has_header = $6,
+			date_column = $7, date_format = $8, description_column = $9, payee_column = $10,
+			amount_column = $11, debit_column = $12, credit_column = $13, decimal_separator = $14,
+			negate_amounts = $15, modified_at = $16, modified_by = $17
+		WHERE id = $1 AND user_id = $18
+	`
+
+	result, err := r.pool.Exec(
+		context.Background(),
+		query,
+		profile.ID,
+		profile.Name,
+		profile.Delimiter,
+		profile.Encoding,
+		profile.SkipRows,
+		profile.HasHeader,
+		profile.DateColumn,
+		profile.DateFormat,
+		profile.DescriptionColumn,
+		profile.PayeeColumn,
+		profile.AmountColumn,
+		profile.DebitColumn,
+		profile.CreditColumn,
+		profile.DecimalSeparator,
+		profile.NegateAmounts,
+		profile.ModifiedAt,
+		profile.ModifiedBy,
+		profile.UserID,
+	)
+	if err != nil {
+		return mapProfileWriteError("failed to update import profile", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("import profile not found")
+	}
+
+	return nil
+}
+
+func (r *ProfileRepository) Delete(id string, userID string) error {
+	checkQuery := `SELECT user_id FROM import_profiles WHERE id = $1`
+	var profileUserID string
+	err := r.pool.QueryRow(context.Background(), checkQuery, id).Scan(&profileUserID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("import profile not found")
+		}
+		return fmt.Errorf("failed to delete import profile: %w", err)
+	}
+
+	if profileUserID != userID {
+		return fmt.Errorf("unauthorized access to import profile")
+	}
+
+	// Batches imported with the profile are kept and forget it.
+	query := `DELETE FROM import_profiles WHERE id = $1 AND user_id = $2`
+
+	if _, err := r.pool.Exec(context.Background(), query, id, userID); err != nil {
+		return fmt.Errorf("failed to delete import profile: %w", err)
+	}
+
+	return nil
+}
+
+func scanProfile(row pgx.Row) (*domain.Profile, error) {
+	var profile domain.Profile
+
+	err := row.Scan(
+		&profile.ID,
+		&profile.UserID,
+		&profile.Name,
+		&profile.Delimiter,
+		&profile.Encoding,
+		&profile.SkipRows,
+		&profile.HasHeader,
+		&profile.DateColumn,
+		&profile.DateFormat,
+		&profile.DescriptionColumn,
+		&profile.PayeeColumn,
+		&profile.AmountColumn,
+		&profile.DebitColumn,
+		&profile.CreditColumn,
+		&profile.DecimalSeparator,
+		&profile.NegateAmounts,
+		&profile.CreatedAt,
+		&profile.ModifiedAt,
+		&profile.CreatedBy,
+		&profile.ModifiedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &profile, nil
+}
+
+func mapProfileWriteError(message string, err error) error {
+	if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23514" { // check_violation
+		switch pgErr.ConstraintName {
+		case "chk_import_profiles_encoding":
+			return domain.ErrInvalidEncoding
+		case "chk_import_profiles_decimal_separator":
+			return domain.ErrInvalidDecimalSeparator
+		case "chk_import_profiles_amount_columns":
+			return domain.ErrAmountColumnsRequired
+		}
+	}
+	return fmt.Errorf("%s: %w", message, err)
+}
@@ -0,0 +1,593 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"fin-flow-api/internal/modules/imports/application/contracts/commands"
+	"fin-flow-api/internal/modules/imports/application/contracts/queries"
+	"fin-flow-api/internal/modules/imports/application/services"
+	"fin-flow-api/internal/modules/imports/domain"
+	basehandler "fin-flow-api/internal/shared/http"
+)
+
+const dateLayout = "2006-01-02"
+
+// maxImportBytes bounds the size of an uploaded statement.
+const maxImportBytes = 10 << 20
+
+type profileService interface {
+	Create(ctx context.Context, req commands.ProfileRequest) error
+	GetByID(ctx context.Context, id string) (*queries.ProfileResponse, error)
+	Update(ctx context.Context, id string, req commands.ProfileRequest) error
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context) ([]*queries.ProfileResponse, error)
+}
+
+type importService interface {
+	Preview(ctx context.Context, req commands.ImportRequest) (*queries.ImportPreviewResponse, error)
+	Commit(ctx context.Context, req commands.ImportRequest) (*queries.BatchResponse, error)
+	Undo(ctx context.Context, id string) (*queries.BatchResponse, error)
+	GetByID(ctx context.Context, id string) (*queries.BatchResponse, error)
+	List(ctx context.Context) ([]*queries.BatchResponse, error)
+}
+
+type Handler struct {
+	profileService profileService
+	importService  importService
+}
+
+func NewHandler(profileService profileService, importService importService) *Handler {
+	return &Handler{
+		profileService: profileService,
+		importService:  importService,
+	}
+}
+
+func (h *Handler) CreateProfile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var reqDTO ProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	cmd, err := toProfileCommand(reqDTO)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.profileService.Create(r.Context(), cmd); err != nil {
+		statusCode, errorMsg := importErrorResponse(err, "create")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Import profile created successfully")
+}
+
+func (h *Handler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id := idFromPath(r.URL.Path, "/import-profiles/")
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Import profile ID is required in the URL path")
+		return
+	}
+
+	profile, err := h.profileService.GetByID(r.Context(), id)
+	if err != nil {
+		statusCode, errorMsg := importErrorResponse(err, "access")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, toProfileResponse(profile))
+}
+
+func (h *Handler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id := idFromPath(r.URL.Path, "/import-profiles/")
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Import profile ID is required in the URL path")
+		return
+	}
+
+	var reqDTO ProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	cmd, err := toProfileCommand(reqDTO)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.profileService.Update(r.Context(), id, cmd); err != nil {
+		statusCode, errorMsg := importErrorResponse(err, "update")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Import profile updated successfully")
+}
+
+func (h *Handler) DeleteProfile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id := idFromPath(r.URL.Path, "/import-profiles/")
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Import profile ID is required in the URL path")
+		return
+	}
+
+	if err := h.profileService.Delete(r.Context(), id); err != nil {
+		statusCode, errorMsg := importErrorResponse(err, "delete")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Import profile deleted successfully")
+}
+
+func (h *Handler) ListProfiles(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	profiles, err := h.profileService.List(r.Context())
+	if err != nil {
+		statusCode, errorMsg := importErrorResponse(err, "access")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	responses := make([]ProfileResponse, len(profiles))
+	for i, profile := range profiles {
+		responses[i] = toProfileResponse(profile)
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, responses)
+}
+
+// PreviewImport handles POST /imports/preview and shows the transactions a
+// statement would create without creating them.
+func (h *Handler) PreviewImport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	cmd, err := readImportRequest(w, r)
+	if err != nil {
+		writeImportReadError(w, err)
+		return
+	}
+
+	preview, err := h.importService.Preview(r.Context(), cmd)
+	if err != nil {
+		statusCode, errorMsg := importErrorResponse(err, "use")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, toImportPreviewResponse(preview))
+}
+
+// CommitImport handles POST /imports and creates the transactions of a
+// statement, all of them or none.
+func (h *Handler) CommitImport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	cmd, err := readImportRequest(w, r)
+	if err != nil {
+		writeImportReadError(w, err)
+		return
+	}
+
+	batch, err := h.importService.Commit(r.Context(), cmd)
+	if err != nil {
+		statusCode, errorMsg := importErrorResponse(err, "use")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusCreated, toBatchResponse(batch))
+}
+
+// UndoImport handles POST /imports/{id}/undo and deletes the transactions
+// of an import.
+func (h *Handler) UndoImport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id := idFromPath(r.URL.Path, "/imports/")
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Import ID is required in the URL path")
+		return
+	}
+
+	batch, err := h.importService.Undo(r.Context(), id)
+	if err != nil {
+		statusCode, errorMsg := importErrorResponse(err, "undo")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, toBatchResponse(batch))
+}
+
+func (h *Handler) GetImport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id := idFromPath(r.URL.Path, "/imports/")
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Import ID is required in the URL path")
+		return
+	}
+
+	batch, err := h.importService.GetByID(r.Context(), id)
+	if err != nil {
+		statusCode, errorMsg := importErrorResponse(err, "access")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, toBatchResponse(batch))
+}
+
+func (h *Handler) ListImports(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	batches, err := h.importService.List(r.Context())
+	if err != nil {
+		statusCode, errorMsg := importErrorResponse(err, "access")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	responses := make([]BatchResponse, len(batches))
+	for i, batch := range batches {
+		responses[i] = toBatchResponse(batch)
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, responses)
+}
+
+func idFromPath(path, prefix string) string {
+	path = strings.TrimPrefix(path, prefix)
+	return strings.Split(path, "/")[0]
+}
+
+// readImportRequest reads a statement sent either as the request body or
+// as the "file" field of a multipart form. The other settings come as
+// query parameters or form fields: format, wallet_id, profile_id,
+// file_name, expense_category_id, income_category_id and exclude_lines, a
+// comma-separated list of line numbers.
+func readImportRequest(w http.ResponseWriter, r *http.Request) (commands.ImportRequest, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportBytes)
+
+	content, fileName, err := statementContent(r)
+	if err != nil {
+		return commands.ImportRequest{}, err
+	}
+
+	cmd := commands.ImportRequest{
+		Format:            strings.ToLower(strings.TrimSpace(r.FormValue("format"))),
+		WalletID:          strings.TrimSpace(r.FormValue("wallet_id")),
+		ProfileID:         strings.TrimSpace(r.FormValue("profile_id")),
+		FileName:          strings.TrimSpace(r.FormValue("file_name")),
+		Content:           content,
+		ExpenseCategoryID: strings.TrimSpace(r.FormValue("expense_category_id")),
+		IncomeCategoryID:  strings.TrimSpace(r.FormValue("income_category_id")),
+	}
+	if cmd.FileName == "" {
+		cmd.FileName = fileName
+	}
+
+	if cmd.Format != "" && !domain.IsValidFormat(cmd.Format) {
+		return cmd, &ValidationError{Field: "format", Message: "Format must be csv, ofx, qif, camt053 or mt940"}
+	}
+
+	if len(cmd.FileName) > 255 {
+		return cmd, &ValidationError{Field: "file_name", Message: "File name must not exceed 255 characters"}
+	}
+
+	if len(content) == 0 {
+		return cmd, &ValidationError{Field: "file", Message: "The statement is empty"}
+	}
+
+	for _, value := range strings.Split(r.FormValue("exclude_lines"), ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		line, err := strconv.Atoi(value)
+		if err != nil || line < 1 {
+			return cmd, &ValidationError{Field: "exclude_lines", Message: "Excluded lines must be a comma-separated list of line numbers"}
+		}
+		cmd.ExcludeLines = append(cmd.ExcludeLines, line)
+	}
+
+	return cmd, nil
+}
+
+func statementContent(r *http.Request) ([]byte, string, error) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		content, err := io.ReadAll(r.Body)
+		return content, "", err
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, "", err
+		}
+		return nil, "", &ValidationError{Field: "file", Message: "A statement file is required in the \"file\" form field"}
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	return content, header.Filename, err
+}
+
+func writeImportReadError(w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		basehandler.WriteError(w, http.StatusRequestEntityTooLarge, "Statement file is too large")
+		return
+	}
+	basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+}
+
+func toProfileCommand(req ProfileRequest) (commands.ProfileRequest, error) {
+	if err := validateProfileRequest(req); err != nil {
+		return commands.ProfileRequest{}, err
+	}
+
+	hasHeader := true
+	if req.HasHeader != nil {
+		hasHeader = *req.HasHeader
+	}
+
+	return commands.ProfileRequest{
+		Name:              strings.TrimSpace(req.Name),
+		Delimiter:         req.Delimiter,
+		Encoding:          strings.ToLower(strings.TrimSpace(req.Encoding)),
+		SkipRows:          req.SkipRows,
+		HasHeader:         hasHeader,
+		DateColumn:        req.DateColumn,
+		DateFormat:        strings.TrimSpace(req.DateFormat),
+		DescriptionColumn: req.DescriptionColumn,
+		PayeeColumn:       req.PayeeColumn,
+		AmountColumn:      req.AmountColumn,
+		DebitColumn:       req.DebitColumn,
+		CreditColumn:      req.CreditColumn,
+		DecimalSeparator:  req.DecimalSeparator,
+		NegateAmounts:     req.NegateAmounts,
+	}, nil
+}
+
+func validateProfileRequest(req ProfileRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return &ValidationError{Field: "name", Message: "Name is required"}
+	}
+
+	if len(name) > 255 {
+		return &ValidationError{Field: "name", Message: "Name must not exceed 255 characters"}
+	}
+
+	if req.DateColumn == 0 {
+		return &ValidationError{Field: "date_column", Message: "Date column is required"}
+	}
+
+	if strings.TrimSpace(req.DateFormat) == "" {
+		return &ValidationError{Field: "date_format", Message: "Date format is required"}
+	}
+
+	if req.DescriptionColumn == 0 {
+		return &ValidationError{Field: "description_column", Message: "Description column is required"}
+	}
+
+	return nil
+}
+
+func importErrorResponse(err error, action string) (int, string) {
+	errorMsg := err.Error()
+
+	var importErr *services.ImportError
+	if errors.As(err, &importErr) {
+		return http.StatusBadRequest, errorMsg
+	}
+
+	switch {
+	case strings.Contains(errorMsg, "user not authenticated"):
+		return http.StatusUnauthorized, "Authentication required"
+	case strings.Contains(errorMsg, "unauthorized access to import profile"):
+		return http.StatusForbidden, "You do not have permission to " + action + " this import profile"
+	case strings.Contains(errorMsg, "unauthorized access to import batch"):
+		return http.StatusForbidden, "You do not have permission to " + action + " this import"
+	case strings.Contains(errorMsg, "unauthorized access to wallet"):
+		return http.StatusForbidden, "You do not have permission to use this wallet"
+	case strings.Contains(errorMsg, "unauthorized access to category"):
+		return http.StatusForbidden, "You do not have permission to use this category"
+	case strings.Contains(errorMsg, "import profile not found"):
+		return http.StatusNotFound, "Import profile not found"
+	case strings.Contains(errorMsg, "import batch not found"):
+		return http.StatusNotFound, "Import not found"
+	case strings.Contains(errorMsg, "wallet not found"), strings.Contains(errorMsg, "invalid wallet reference"):
+		return http.StatusBadRequest, "Wallet not found"
+	case strings.Contains(errorMsg, "category not found"), strings.Contains(errorMsg, "invalid category reference"):
+		return http.StatusBadRequest, "Category not found"
+	case strings.Contains(errorMsg, "already been undone"):
+		return http.StatusConflict, "Import has already been undone"
+	case strings.Contains(errorMsg, "reconciled transactions cannot be deleted"):
+		return http.StatusConflict, "Import has reconciled transactions and cannot be undone"
+	case strings.Contains(errorMsg, "already been imported"):
+		return http.StatusConflict, "A transaction of the statement has already been imported"
+	case strings.Contains(errorMsg, "category type does not match"),
+		strings.Contains(errorMsg, "no lines to import"),
+		strings.Contains(errorMsg, "format must be"),
+		strings.Contains(errorMsg, "import profile is required"),
+		strings.Contains(errorMsg, "wallet is required"),
+		strings.Contains(errorMsg, "import profile name"),
+		strings.Contains(errorMsg, "delimiter must"),
+		strings.Contains(errorMsg, "encoding must"),
+		strings.Contains(errorMsg, "decimal separator"),
+		strings.Contains(errorMsg, "date format"),
+		strings.Contains(errorMsg, "columns are numbered"),
+		strings.Contains(errorMsg, "column is required"),
+		strings.Contains(errorMsg, "debit and credit columns"),
+		strings.Contains(errorMsg, "skip rows"):
+		return http.StatusBadRequest, errorMsg
+	}
+
+	return http.StatusInternalServerError, errorMsg
+}
+
+func toProfileResponse(profile *queries.ProfileResponse) ProfileResponse {
+	return ProfileResponse{
+		ID:                profile.ID,
+		Name:              profile.Name,
+		Delimiter:         profile.Delimiter,
+		Encoding:          profile.Encoding,
+		SkipRows:          profile.SkipRows,
+		HasHeader:         profile.HasHeader,
+		DateColumn:        profile.DateColumn,
+		DateFormat:        profile.DateFormat,
+		DescriptionColumn: profile.DescriptionColumn,
+		PayeeColumn:       profile.PayeeColumn,
+		AmountColumn:      profile.AmountColumn,
+		DebitColumn:       profile.DebitColumn,
+		CreditColumn:      profile.CreditColumn,
+		DecimalSeparator:  profile.DecimalSeparator,
+		NegateAmounts:     profile.NegateAmounts,
+		CreatedAt:         profile.CreatedAt,
+		UpdatedAt:         profile.UpdatedAt,
+		CreatedBy:         profile.CreatedBy,
+		UpdatedBy:         profile.UpdatedBy,
+	}
+}
+
+func toImportPreviewResponse(preview *queries.ImportPreviewResponse) ImportPreviewResponse {
+	lines := make([]ImportLineResponse, len(preview.Lines))
+	for i, line := range preview.Lines {
+		lines[i] = ImportLineResponse{
+			Line:                 line.Line,
+			WalletID:             line.WalletID,
+			Description:          line.Description,
+			Payee:                line.Payee,
+			Amount:               line.Amount,
+			Type:                 line.Type,
+			TypeName:             line.TypeName,
+			CategoryID:           line.CategoryID,
+			Tags:                 line.Tags,
+			ExternalID:           line.ExternalID,
+			Excluded:             line.Excluded,
+			AlreadyImported:      line.AlreadyImported,
+			PossibleDuplicateIDs: line.PossibleDuplicateIDs,
+			Error:                line.Error,
+		}
+		if !line.Date.IsZero() {
+			lines[i].Date = line.Date.Format(dateLayout)
+		}
+		if line.ValueDate != nil {
+			lines[i].ValueDate = line.ValueDate.Format(dateLayout)
+		}
+	}
+
+	statements := make([]StatementResponse, len(preview.Statements))
+	for i, statement := range preview.Statements {
+		statements[i] = StatementResponse{
+			Account:            statement.Account,
+			WalletID:           statement.WalletID,
+			Currency:           statement.Currency,
+			LineCount:          statement.LineCount,
+			OpeningBalance:     statement.OpeningBalance,
+			ClosingBalance:     statement.ClosingBalance,
+			Movement:           statement.Movement,
+			Balanced:           statement.Balanced,
+			WalletBalance:      statement.WalletBalance,
+			BalanceAfterImport: statement.BalanceAfterImport,
+			Difference:         statement.Difference,
+			Reconciled:         statement.Reconciled,
+		}
+	}
+
+	return ImportPreviewResponse{
+		Format:                 preview.Format,
+		WalletID:               preview.WalletID,
+		ProfileID:              preview.ProfileID,
+		Lines:                  lines,
+		ReadyCount:             preview.ReadyCount,
+		ExcludedCount:          preview.ExcludedCount,
+		AlreadyImportedCount:   preview.AlreadyImportedCount,
+		ErrorCount:             preview.ErrorCount,
+		PossibleDuplicateCount: preview.PossibleDuplicateCount,
+		TotalIncome:            preview.TotalIncome,
+		TotalExpenses:          preview.TotalExpenses,
+		Statements:             statements,
+	}
+}
+
+func toBatchResponse(batch *queries.BatchResponse) BatchResponse {
+	return BatchResponse{
+		ID:               batch.ID,
+		WalletID:         batch.WalletID,
+		ProfileID:        batch.ProfileID,
+		Format:           batch.Format,
+		FileName:         batch.FileName,
+		TransactionCount: batch.TransactionCount,
+		Status:           batch.Status,
+		UndoneAt:         batch.UndoneAt,
+		CreatedAt:        batch.CreatedAt,
+		UpdatedAt:        batch.UpdatedAt,
+		CreatedBy:        batch.CreatedBy,
+		UpdatedBy:        batch.UpdatedBy,
+	}
+}
+
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,423 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"fin-flow-api/internal/modules/imports/application/contracts/commands"
+	"fin-flow-api/internal/modules/imports/application/contracts/queries"
+	"fin-flow-api/internal/modules/imports/application/services"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+type mockProfileService struct {
+	createErr   error
+	getByIDErr  error
+	profile     *queries.ProfileResponse
+	lastCommand commands.ProfileRequest
+	lastID      string
+}
+
+func (m *mockProfileService) Create(ctx context.Context, req commands.ProfileRequest) error {
+	m.lastCommand = req
+	return m.createErr
+}
+
+func (m *mockProfileService) GetByID(ctx context.Context, id string) (*queries.ProfileResponse, error) {
+	m.lastID = id
+	if m.getByIDErr != nil {
+		return nil, m.getByIDErr
+	}
+	return m.profile, nil
+}
+
+func (m *mockProfileService) Update(ctx context.Context, id string, req commands.ProfileRequest) error {
+	m.lastID = id
+	m.lastCommand = req
+	return nil
+}
+
+func (m *mockProfileService) Delete(ctx context.Context, id string) error {
+	m.lastID = id
+	return nil
+}
+
+func (m *mockProfileService) List(ctx context.Context) ([]*queries.ProfileResponse, error) {
+	return nil, nil
+}
+
+type mockImportService struct {
+	previewErr  error
+	commitErr   error
+	undoErr     error
+	preview     *queries.ImportPreviewResponse
+	batch       *queries.BatchResponse
+	lastRequest commands.ImportRequest
+	lastID      string
+}
+
+func (m *mockImportService) Preview(ctx context.Context, req commands.ImportRequest) (*queries.ImportPreviewResponse, error) {
+	m.lastRequest = req
+	if m.previewErr != nil {
+		return nil, m.previewErr
+	}
+	return m.preview, nil
+}
+
+func (m *mockImportService) Commit(ctx context.Context, req commands.ImportRequest) (*queries.BatchResponse, error) {
+	m.lastRequest = req
+	if m.commitErr != nil {
+		return nil, m.commitErr
+	}
+	return m.batch, nil
+}
+
+func (m *mockImportService) Undo(ctx context.Context, id string) (*queries.BatchResponse, error) {
+	m.lastID = id
+	if m.undoErr != nil {
+		return nil, m.undoErr
+	}
+	return m.batch, nil
+}
+
+func (m *mockImportService) GetByID(ctx context.Context, id string) (*queries.BatchResponse, error) {
+	m.lastID = id
+	return m.batch, nil
+}
+
+func (m *mockImportService) List(ctx context.Context) ([]*queries.BatchResponse, error) {
+	return []*queries.BatchResponse{m.batch}, nil
+}
+
+func createContextWithUserID(userID string) context.Context {
+	return context.WithValue(context.Background(), middleware.UserIDKey, userID)
+}
+
+func sampleBatch() *queries.BatchResponse {
+	return &queries.BatchResponse{
+		ID:               "batch1",
+		WalletID:         "wallet1",
+		ProfileID:        "profile1",
+		Format:           "csv",
+		FileName:         "march.csv",
+		TransactionCount: 3,
+		Status:           "committed",
+		CreatedAt:        time.Date(2026, 3, 20, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func validProfileBody() ProfileRequest {
+	return ProfileRequest{
+		Name:              " Bank ",
+		Delimiter:         ";",
+		Encoding:          " ISO-8859-1 ",
+		DateColumn:        1,
+		DateFormat:        "DD/MM/YYYY",
+		DescriptionColumn: 2,
+		AmountColumn:      3,
+		DecimalSeparator:  ",",
+	}
+}
+
+func TestCreateProfile_Success(t *testing.T) {
+	service := &mockProfileService{}
+	handler := &Handler{profileService: service}
+
+	jsonBody, _ := json.Marshal(validProfileBody())
+	req := httptest.NewRequest("POST", "/import-profiles", bytes.NewBuffer(jsonBody))
+	req = req.WithContext(createContextWithUserID("user1"))
+	rr := httptest.NewRecorder()
+
+	handler.CreateProfile(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if service.lastCommand.Name != "Bank" || service.lastCommand.Encoding != "iso-8859-1" {
+		t.Errorf("expected normalized fields, got %+v", service.lastCommand)
+	}
+	if !service.lastCommand.HasHeader {
+		t.Error("expected the header to be on by default")
+	}
+}
+
+func TestCreateProfile_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*ProfileRequest)
+	}{
+		{"missing name", func(r *ProfileRequest) { r.Name = " " }},
+		{"long name", func(r *ProfileRequest) { r.Name = strings.Repeat("a", 256) }},
+		{"missing date column", func(r *ProfileRequest) { r.DateColumn = 0 }},
+		{"missing date format", func(r *ProfileRequest) { r.DateFormat = "" }},
+		{"missing description column", func(r *ProfileRequest) { r.DescriptionColumn = 0 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := validProfileBody()
+			tt.mutate(&body)
+
+			if _, err := toProfileCommand(body); err == nil {
+				t.Error("expected validation error, got nil")
+			}
+		})
+	}
+}
+
+func TestCreateProfile_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{"unauthenticated", errors.New("user not authenticated"), http.StatusUnauthorized},
+		{"encoding", errors.New("encoding must be utf-8, iso-8859-1 or windows-1252"), http.StatusBadRequest},
+		{"amount columns", errors.New("either an amount column or debit and credit columns are required"), http.StatusBadRequest},
+		{"database", errors.New("failed to create import profile: boom"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &Handler{profileService: &mockProfileService{createErr: tt.err}}
+
+			jsonBody, _ := json.Marshal(validProfileBody())
+			req := httptest.NewRequest("POST", "/import-profiles", bytes.NewBuffer(jsonBody))
+			rr := httptest.NewRecorder()
+
+			handler.CreateProfile(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestGetProfile_Forbidden(t *testing.T) {
+	service := &mockProfileService{getByIDErr: errors.New("unauthorized access to import profile")}
+	handler := &Handler{profileService: service}
+
+	req := httptest.NewRequest("GET", "/import-profiles/profile1", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetProfile(rr, req)
+
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d", rr.Code)
+	}
+	if service.lastID != "profile1" {
+		t.Errorf("expected profile1, got %q", service.lastID)
+	}
+}
+
+func TestPreviewImport_RawBody(t *testing.T) {
+	service := &mockImportService{preview: &queries.ImportPreviewResponse{
+		WalletID:  "wallet1",
+		ProfileID: "profile1",
+		Lines: []*queries.ImportLineResponse{{
+			Line:        2,
+			Date:        time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC),
+			Description: "Coffee",
+			Amount:      shareddomain.MustParseAmount("4.5"),
+			Type:        2,
+			TypeName:    "Expense",
+			CategoryID:  "cat-coffee",
+		}},
+		ReadyCount:    1,
+		TotalExpenses: shareddomain.MustParseAmount("4.5"),
+	}}
+	handler := &Handler{importService: service}
+
+	body := "Date,Description,Amount\n15/03/2026,Coffee,-4.50\n"
+	req := httptest.NewRequest("POST", "/imports/preview?wallet_id=wallet1&profile_id=profile1&expense_category_id=cat-food&exclude_lines=3,%204", strings.NewReader(body))
+	req.Header.Set("Content-Type", "text/csv")
+	rr := httptest.NewRecorder()
+
+	handler.PreviewImport(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	got := service.lastRequest
+	if got.WalletID != "wallet1" || got.ProfileID != "profile1" || got.ExpenseCategoryID != "cat-food" || string(got.Content) != body {
+		t.Errorf("unexpected request %+v", got)
+	}
+	if len(got.ExcludeLines) != 2 || got.ExcludeLines[0] != 3 || got.ExcludeLines[1] != 4 {
+		t.Errorf("expected lines 3 and 4 to be excluded, got %v", got.ExcludeLines)
+	}
+
+	var response ImportPreviewResponse
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Lines) != 1 || response.Lines[0].Date != "2026-03-15" || response.ReadyCount != 1 {
+		t.Errorf("unexpected response %+v", response)
+	}
+}
+
+func TestPreviewImport_Statements(t *testing.T) {
+	valueDate := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	closing := shareddomain.MustParseAmount("2045.5")
+	difference := shareddomain.MustParseAmount("0")
+	service := &mockImportService{preview: &queries.ImportPreviewResponse{
+		Format: "camt053",
+		Lines: []*queries.ImportLineResponse{{
+			Line:      1,
+			Date:      time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+			ValueDate: &valueDate,
+			Amount:    shareddomain.MustParseAmount("54.5"),
+		}},
+		Statements: []*queries.ImportStatementResponse{{
+			Account:        "DE89370400440532013000",
+			WalletID:       "wallet4",
+			ClosingBalance: &closing,
+			Difference:     &difference,
+			Reconciled:     true,
+		}},
+	}}
+	handler := &Handler{importService: service}
+
+	req := httptest.NewRequest("POST", "/imports/preview?format=camt053", strings.NewReader("<Document/>"))
+	rr := httptest.NewRecorder()
+
+	handler.PreviewImport(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if service.lastRequest.Format != "camt053" {
+		t.Errorf("expected the camt053 format, got %q", service.lastRequest.Format)
+	}
+
+	var response ImportPreviewResponse
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Lines[0].ValueDate != "2026-03-31" {
+		t.Errorf("expected the value date, got %q", response.Lines[0].ValueDate)
+	}
+	if len(response.Statements) != 1 || !response.Statements[0].Reconciled || response.Statements[0].OpeningBalance != nil {
+		t.Errorf("unexpected statements %+v", response.Statements)
+	}
+}
+
+func TestCommitImport_Multipart(t *testing.T) {
+	service := &mockImportService{batch: sampleBatch()}
+	handler := &Handler{importService: service}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	writer.WriteField("wallet_id", "wallet1")
+	writer.WriteField("profile_id", "profile1")
+	part, _ := writer.CreateFormFile("file", "march.csv")
+	part.Write([]byte("Date,Description,Amount\n15/03/2026,Coffee,-4.50\n"))
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/imports", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	rr := httptest.NewRecorder()
+
+	handler.CommitImport(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if service.lastRequest.FileName != "march.csv" || len(service.lastRequest.Content) == 0 {
+		t.Errorf("expected the uploaded file, got %+v", service.lastRequest)
+	}
+}
+
+func TestCommitImport_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		body           string
+		err            error
+		expectedStatus int
+	}{
+		{"unknown format", "wallet_id=wallet1&format=xls", "x", nil, http.StatusBadRequest},
+		{"missing wallet", "format=qif", "x", errors.New("a wallet is required for statements that do not name their account"), http.StatusBadRequest},
+		{"missing profile", "wallet_id=wallet1", "x", errors.New("an import profile is required for CSV statements"), http.StatusBadRequest},
+		{"imported twice", "format=ofx", "x", errors.New("transaction has already been imported into the wallet"), http.StatusConflict},
+		{"empty statement", "wallet_id=wallet1&profile_id=profile1", "", nil, http.StatusBadRequest},
+		{"bad excluded line", "wallet_id=wallet1&profile_id=profile1&exclude_lines=two", "x", nil, http.StatusBadRequest},
+		{"line error", "wallet_id=wallet1&profile_id=profile1", "x", &services.ImportError{Line: 3, Message: "amount is zero"}, http.StatusBadRequest},
+		{"nothing to import", "wallet_id=wallet1&profile_id=profile1", "x", errors.New("the statement has no lines to import"), http.StatusBadRequest},
+		{"foreign wallet", "wallet_id=wallet1&profile_id=profile1", "x", errors.New("unauthorized access to wallet"), http.StatusForbidden},
+		{"unknown profile", "wallet_id=wallet1&profile_id=profile1", "x", errors.New("import profile not found"), http.StatusNotFound},
+		{"database", "wallet_id=wallet1&profile_id=profile1", "x", errors.New("failed to create transactions: boom"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &Handler{importService: &mockImportService{commitErr: tt.err, batch: sampleBatch()}}
+
+			req := httptest.NewRequest("POST", "/imports?"+tt.query, strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+
+			handler.CommitImport(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestCommitImport_TooLarge(t *testing.T) {
+	handler := &Handler{importService: &mockImportService{batch: sampleBatch()}}
+
+	body := strings.Repeat("a", maxImportBytes+1)
+	req := httptest.NewRequest("POST", "/imports?wallet_id=wallet1&profile_id=profile1", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+
+	handler.CommitImport(rr, req)
+
+	if rr.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected status 413, got %d", rr.Code)
+	}
+}
+
+func TestUndoImport(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{"success", nil, http.StatusOK},
+		{"already undone", errors.New("import has already been undone"), http.StatusConflict},
+		{"reconciled", errors.New("reconciled transactions cannot be deleted"), http.StatusConflict},
+		{"not found", errors.New("import batch not found"), http.StatusNotFound},
+		{"forbidden", errors.New("unauthorized access to import batch"), http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &mockImportService{undoErr: tt.err, batch: sampleBatch()}
+			handler := &Handler{importService: service}
+
+			req := httptest.NewRequest("POST", "/imports/batch1/undo", nil)
+			rr := httptest.NewRecorder()
+
+			handler.UndoImport(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+			if service.lastID != "batch1" {
+				t.Errorf("expected batch1, got %q", service.lastID)
+			}
+		})
+	}
+}
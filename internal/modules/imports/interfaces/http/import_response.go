@@ -0,0 +1,71 @@
+package http
+
+import (
+	"time"
+
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+type ImportLineResponse struct {
+	Line                 int                 `json:"line"`
+	WalletID             string              `json:"wallet_id,omitempty"`
+	Date                 string              `json:"date,omitempty"`
+	ValueDate            string              `json:"value_date,omitempty"`
+	Description          string              `json:"description"`
+	Payee                string              `json:"payee,omitempty"`
+	Amount               shareddomain.Amount `json:"amount"`
+	Type                 int                 `json:"type"`
+	TypeName             string              `json:"type_name"`
+	CategoryID           string              `json:"category_id,omitempty"`
+	Tags                 []string            `json:"tags,omitempty"`
+	ExternalID           string              `json:"external_id,omitempty"`
+	Excluded             bool                `json:"excluded"`
+	AlreadyImported      bool                `json:"already_imported"`
+	PossibleDuplicateIDs []string            `json:"possible_duplicate_ids,omitempty"`
+	Error                string              `json:"error,omitempty"`
+}
+
+type ImportPreviewResponse struct {
+	Format                 string               `json:"format"`
+	WalletID               string               `json:"wallet_id,omitempty"`
+	ProfileID              string               `json:"profile_id,omitempty"`
+	Lines                  []ImportLineResponse `json:"lines"`
+	ReadyCount             int                  `json:"ready_count"`
+	ExcludedCount          int                  `json:"excluded_count"`
+	AlreadyImportedCount   int                  `json:"already_imported_count"`
+	ErrorCount             int                  `json:"error_count"`
+	PossibleDuplicateCount int                  `json:"possible_duplicate_count"`
+	TotalIncome            shareddomain.Amount  `json:"total_income"`
+	TotalExpenses          shareddomain.Amount  `json:"total_expenses"`
+	Statements             []StatementResponse  `json:"statements"`
+}
+
+type StatementResponse struct {
+	Account            string               `json:"account,omitempty"`
+	WalletID           string               `json:"wallet_id,omitempty"`
+	Currency           string               `json:"currency,omitempty"`
+	LineCount          int                  `json:"line_count"`
+	OpeningBalance     *shareddomain.Amount `json:"opening_balance,omitempty"`
+	ClosingBalance     *shareddomain.Amount `json:"closing_balance,omitempty"`
+	Movement           shareddomain.Amount  `json:"movement"`
+	Balanced           bool                 `json:"balanced"`
+	WalletBalance      *shareddomain.Amount `json:"wallet_balance,omitempty"`
+	BalanceAfterImport *shareddomain.Amount `json:"balance_after_import,omitempty"`
+	Difference         *shareddomain.Amount `json:"difference,omitempty"`
+	Reconciled         bool                 `json:"reconciled"`
+}
+
+type BatchResponse struct {
+	ID               string     `json:"id"`
+	WalletID         string     `json:"wallet_id,omitempty"`
+	ProfileID        string     `json:"profile_id,omitempty"`
+	Format           string     `json:"format"`
+	FileName         string     `json:"file_name,omitempty"`
+	TransactionCount int        `json:"transaction_count"`
+	Status           string     `json:"status"`
+	UndoneAt         *time.Time `json:"undone_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	CreatedBy        string     `json:"created_by"`
+	UpdatedBy        string     `json:"updated_by"`
+}
@@ -0,0 +1,21 @@
+package http
+
+// ProfileRequest describes the layout of the CSV statements of a bank.
+// Columns are numbered from 1. HasHeader defaults to true, Delimiter to a
+// comma, Encoding to utf-8 and DecimalSeparator to a dot.
+type ProfileRequest struct {
+	Name              string `json:"name"`
+	Delimiter         string `json:"delimiter,omitempty"`
+	Encoding          string `json:"encoding,omitempty"`
+	SkipRows          int    `json:"skip_rows,omitempty"`
+	HasHeader         *bool  `json:"has_header,omitempty"`
+	DateColumn        int    `json:"date_column"`
+	DateFormat        string `json:"date_format"`
+	DescriptionColumn int    `json:"description_column"`
+	PayeeColumn       int    `json:"payee_column,omitempty"`
+	AmountColumn      int    `json:"amount_column,omitempty"`
+	DebitColumn       int    `json:"debit_column,omitempty"`
+	CreditColumn      int    `json:"credit_column,omitempty"`
+	DecimalSeparator  string `json:"decimal_separator,omitempty"`
+	NegateAmounts     bool   `json:"negate_amounts,omitempty"`
+}
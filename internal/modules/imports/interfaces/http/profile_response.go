@@ -0,0 +1,25 @@
+package http
+
+import "time"
+
+type ProfileResponse struct {
+	ID                string    `json:"id"`
+	Name              string    `json:"name"`
+	Delimiter         string    `json:"delimiter"`
+	Encoding          string    `json:"encoding"`
+	SkipRows          int       `json:"skip_rows"`
+	HasHeader         bool      `json:"has_header"`
+	DateColumn        int       `json:"date_column"`
+	DateFormat        string    `json:"date_format"`
+	DescriptionColumn int       `json:"description_column"`
+	PayeeColumn       int       `json:"payee_column,omitempty"`
+	AmountColumn      int       `json:"amount_column,omitempty"`
+	DebitColumn       int       `json:"debit_column,omitempty"`
+	CreditColumn      int       `json:"credit_column,omitempty"`
+	DecimalSeparator  string    `json:"decimal_separator"`
+	NegateAmounts     bool      `json:"negate_amounts"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+	CreatedBy         string    `json:"created_by"`
+	UpdatedBy         string    `json:"updated_by"`
+}
@@ -0,0 +1,91 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"fin-flow-api/internal/shared/interface/jwt"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+var importHandler *Handler
+
+func SetupRoutes(mux *http.ServeMux, jwtService jwt.Service) {
+	mountProfiles(mux, jwtService)
+	mountImports(mux, jwtService)
+}
+
+func mountProfiles(mux *http.ServeMux, jwtService jwt.Service) {
+	mux.HandleFunc("/import-profiles", handleProfilesCollection(jwtService))
+
+	protectedHandler := middleware.RequireAuth(jwtService)(http.HandlerFunc(handleProfilesResource))
+	mux.Handle("/import-profiles/", protectedHandler)
+}
+
+func mountImports(mux *http.ServeMux, jwtService jwt.Service) {
+	mux.HandleFunc("/imports", handleImportsCollection(jwtService))
+
+	protectedHandler := middleware.RequireAuth(jwtService)(http.HandlerFunc(handleImportsResource))
+	mux.Handle("/imports/", protectedHandler)
+}
+
+func handleProfilesCollection(jwtService jwt.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.RequireAuth(jwtService)(http.HandlerFunc(importHandler.ListProfiles)).ServeHTTP(w, r)
+		case http.MethodPost:
+			middleware.RequireAuth(jwtService)(http.HandlerFunc(importHandler.CreateProfile)).ServeHTTP(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func handleProfilesResource(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		importHandler.GetProfile(w, r)
+	case http.MethodPut:
+		importHandler.UpdateProfile(w, r)
+	case http.MethodDelete:
+		importHandler.DeleteProfile(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func handleImportsCollection(jwtService jwt.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.RequireAuth(jwtService)(http.HandlerFunc(importHandler.ListImports)).ServeHTTP(w, r)
+		case http.MethodPost:
+			middleware.RequireAuth(jwtService)(http.HandlerFunc(importHandler.CommitImport)).ServeHTTP(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func handleImportsResource(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/imports/preview":
+		importHandler.PreviewImport(w, r)
+		return
+	case strings.HasSuffix(r.URL.Path, "/undo"):
+		importHandler.UndoImport(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		importHandler.GetImport(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func SetHandler(handler *Handler) {
+	importHandler = handler
+}
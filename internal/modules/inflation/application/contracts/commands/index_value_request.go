@@ -0,0 +1,13 @@
+package commands
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+type IndexValueRequest struct {
+	Currency string
+	Month    time.Time
+	Value    domain.Amount
+}
@@ -0,0 +1,19 @@
+package queries
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+type IndexValueResponse struct {
+	ID        string
+	Currency  string
+	Month     time.Time
+	Value     domain.Amount
+	Source    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	CreatedBy string
+	UpdatedBy string
+}
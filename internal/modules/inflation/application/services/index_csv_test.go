@@ -0,0 +1,59 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseIndexCSV(t *testing.T) {
+	input := "\ufeffValue, Currency,month,note\n8540.25,ars,2026-01,indec\n8745.2, ARS ,2026-02,\n"
+
+	reqs, err := ParseIndexCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseIndexCSV failed: %v", err)
+	}
+
+	if len(reqs) != 2 {
+		t.Fatalf("expected 2 index values, got %d", len(reqs))
+	}
+	if reqs[0].Currency != "ARS" || reqs[0].Value.String() != "8540.25" || reqs[0].Month.Format(monthLayout) != "2026-01" {
+		t.Errorf("unexpected first index value %+v", reqs[0])
+	}
+	if reqs[1].Currency != "ARS" || reqs[1].Value.String() != "8745.2" {
+		t.Errorf("unexpected second index value %+v", reqs[1])
+	}
+}
+
+func TestParseIndexCSV_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantLine int
+		wantMsg  string
+	}{
+		{"empty", "", 1, "file is empty"},
+		{"missing column", "month,value\n2026-01,100\n", 1, `missing column "currency"`},
+		{"no rows", "month,currency,value\n", 2, "no index values found"},
+		{"bad month", "month,currency,value\n2026-01,ARS,100\n2026-02-01,ARS,104\n", 3, "YYYY-MM"},
+		{"bad value", "month,currency,value\n2026-01,ARS,abc\n", 2, "value is not a number"},
+		{"wrong field count", "month,currency,value\n2026-01,ARS\n", 2, "wrong number of fields"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseIndexCSV(strings.NewReader(tt.input))
+
+			var importErr *ImportError
+			if !errors.As(err, &importErr) {
+				t.Fatalf("expected an ImportError, got %v", err)
+			}
+			if importErr.Line != tt.wantLine {
+				t.Errorf("expected line %d, got %d", tt.wantLine, importErr.Line)
+			}
+			if !strings.Contains(importErr.Message, tt.wantMsg) {
+				t.Errorf("expected message containing %q, got %q", tt.wantMsg, importErr.Message)
+			}
+		})
+	}
+}
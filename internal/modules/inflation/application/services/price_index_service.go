@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"fin-flow-api/internal/modules/inflation/application/contracts/commands"
+	"fin-flow-api/internal/modules/inflation/application/contracts/queries"
+	"fin-flow-api/internal/modules/inflation/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	"fin-flow-api/internal/shared/middleware"
+
+	"github.com/google/uuid"
+)
+
+type PriceIndexService struct {
+	repository domain.PriceIndexRepository
+	systemUser string
+}
+
+func NewPriceIndexService(repository domain.PriceIndexRepository, systemUser string) *PriceIndexService {
+	return &PriceIndexService{
+		repository: repository,
+		systemUser: systemUser,
+	}
+}
+
+func (s *PriceIndexService) getUserIDFromContext(ctx context.Context) (string, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return "", errors.New("user not authenticated")
+	}
+	return userID, nil
+}
+
+// Upsert stores the index value of a currency for a month, replacing any
+// value already stored for that month.
+func (s *PriceIndexService) Upsert(ctx context.Context, req commands.IndexValueRequest) error {
+	if _, err := s.getUserIDFromContext(ctx); err != nil {
+		return err
+	}
+
+	value := s.newIndexValue(req, domain.SourceManual)
+	if err := value.Validate(); err != nil {
+		return err
+	}
+
+	return s.repository.Upsert([]*domain.IndexValue{value})
+}
+
+// Import stores all the index values or none of them. Errors name the CSV
+// line of the offending value.
+func (s *PriceIndexService) Import(ctx context.Context, reqs []commands.IndexValueRequest) (int, error) {
+	if _, err := s.getUserIDFromContext(ctx); err != nil {
+		return 0, err
+	}
+
+	values := make([]*domain.IndexValue, len(reqs))
+	for i, req := range reqs {
+		value := s.newIndexValue(req, domain.SourceCSV)
+		if err := value.Validate(); err != nil {
+			return 0, &ImportError{Line: i + 2, Message: err.Error()}
+		}
+		values[i] = value
+	}
+
+	if err := s.repository.Upsert(values); err != nil {
+		return 0, err
+	}
+
+	return len(values), nil
+}
+
+func (s *PriceIndexService) List(ctx context.Context, currency string, from, to time.Time) ([]*queries.IndexValueResponse, error) {
+	if _, err := s.getUserIDFromContext(ctx); err != nil {
+		return nil, err
+	}
+
+	if !walletdomain.IsValidCurrency(currency) {
+		return nil, walletdomain.ErrInvalidCurrency
+	}
+
+	values, err := s.repository.List(walletdomain.Currency(currency), domain.MonthOf(from), domain.MonthOf(to))
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*queries.IndexValueResponse, len(values))
+	for i, value := range values {
+		responses[i] = toIndexValueResponse(value)
+	}
+
+	return responses, nil
+}
+
+// Restater loads the index values a report covering the months from from
+// to to needs to be restated to money of the reference month.
+func (s *PriceIndexService) Restater(currency walletdomain.Currency, reference, from, to time.Time) (*domain.Restater, error) {
+	first, last := domain.MonthOf(from), domain.MonthOf(to)
+	if reference = domain.MonthOf(reference); reference.Before(first) {
+		first = reference
+	} else if reference.After(last) {
+		last = reference
+	}
+
+	values, err := s.repository.List(currency, first, last)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewRestater(currency, reference, values)
+}
+
+func (s *PriceIndexService) newIndexValue(req commands.IndexValueRequest, source string) *domain.IndexValue {
+	return domain.NewIndexValue(
+		uuid.New().String(),
+		walletdomain.Currency(req.Currency),
+		req.Month,
+		req.Value,
+		source,
+		s.systemUser,
+	)
+}
+
+func toIndexValueResponse(value *domain.IndexValue) *queries.IndexValueResponse {
+	return &queries.IndexValueResponse{
+		ID:        value.ID,
+		Currency:  string(value.Currency),
+		Month:     value.Month,
+		Value:     value.Value,
+		Source:    value.Source,
+		CreatedAt: value.CreatedAt,
+		UpdatedAt: value.ModifiedAt,
+		CreatedBy: value.CreatedBy,
+		UpdatedBy: value.ModifiedBy,
+	}
+}
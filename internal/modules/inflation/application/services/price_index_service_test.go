@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"fin-flow-api/internal/modules/inflation/application/contracts/commands"
+	"fin-flow-api/internal/modules/inflation/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+type mockContext struct {
+	context.Context
+	userID string
+	hasID  bool
+}
+
+func (m *mockContext) Value(key interface{}) interface{} {
+	if key == middleware.UserIDKey {
+		if m.hasID {
+			return m.userID
+		}
+		return nil
+	}
+	return nil
+}
+
+func authenticated() context.Context {
+	return &mockContext{userID: "admin-1", hasID: true}
+}
+
+type mockPriceIndexRepository struct {
+	values   []*domain.IndexValue
+	upserted []*domain.IndexValue
+	listFrom time.Time
+	listTo   time.Time
+}
+
+func (m *mockPriceIndexRepository) Upsert(values []*domain.IndexValue) error {
+	m.upserted = append(m.upserted, values...)
+	return nil
+}
+
+func (m *mockPriceIndexRepository) List(currency walletdomain.Currency, from, to time.Time) ([]*domain.IndexValue, error) {
+	m.listFrom, m.listTo = from, to
+
+	var result []*domain.IndexValue
+	for _, value := range m.values {
+		if value.Currency != currency {
+			continue
+		}
+		if (!from.IsZero() && value.Month.Before(from)) || (!to.IsZero() && value.Month.After(to)) {
+			continue
+		}
+		result = append(result, value)
+	}
+	return result, nil
+}
+
+func month(year int, m time.Month) time.Time {
+	return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func storedValue(m time.Time, value string) *domain.IndexValue {
+	return domain.NewIndexValue("index-"+m.Format(monthLayout), walletdomain.CurrencyARS, m, shareddomain.MustParseAmount(value), domain.SourceManual, "system")
+}
+
+func indexRequest(currency string, m time.Time, value string) commands.IndexValueRequest {
+	return commands.IndexValueRequest{
+		Currency: currency,
+		Month:    m,
+		Value:    shareddomain.MustParseAmount(value),
+	}
+}
+
+func newTestService(values ...*domain.IndexValue) (*PriceIndexService, *mockPriceIndexRepository) {
+	repo := &mockPriceIndexRepository{values: values}
+	return NewPriceIndexService(repo, "system"), repo
+}
+
+func TestPriceIndexService_Upsert(t *testing.T) {
+	service, repo := newTestService()
+
+	if err := service.Upsert(authenticated(), indexRequest("ARS", time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC), "8540.25")); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+
+	if len(repo.upserted) != 1 {
+		t.Fatalf("expected 1 stored value, got %d", len(repo.upserted))
+	}
+	if value := repo.upserted[0]; value.Source != domain.SourceManual || !value.Month.Equal(month(2026, time.January)) || value.CreatedBy != "system" {
+		t.Errorf("unexpected index value %+v", value)
+	}
+}
+
+func TestPriceIndexService_Upsert_Errors(t *testing.T) {
+	service, repo := newTestService()
+
+	if err := service.Upsert(&mockContext{}, indexRequest("ARS", month(2026, time.January), "100")); err == nil || err.Error() != "user not authenticated" {
+		t.Errorf("expected user not authenticated, got %v", err)
+	}
+	if err := service.Upsert(authenticated(), indexRequest("XXX", month(2026, time.January), "100")); !errors.Is(err, walletdomain.ErrInvalidCurrency) {
+		t.Errorf("expected %v, got %v", walletdomain.ErrInvalidCurrency, err)
+	}
+	if err := service.Upsert(authenticated(), indexRequest("ARS", month(2026, time.January), "0")); !errors.Is(err, domain.ErrInvalidIndexValue) {
+		t.Errorf("expected %v, got %v", domain.ErrInvalidIndexValue, err)
+	}
+	if len(repo.upserted) != 0 {
+		t.Errorf("expected nothing stored, got %d values", len(repo.upserted))
+	}
+}
+
+func TestPriceIndexService_Import_ReportsLineAndStoresNothing(t *testing.T) {
+	service, repo := newTestService()
+
+	_, err := service.Import(authenticated(), []commands.IndexValueRequest{
+		indexRequest("ARS", month(2026, time.January), "100"),
+		indexRequest("ARS", month(2026, time.February), "-4"),
+	})
+
+	var importErr *ImportError
+	if !errors.As(err, &importErr) {
+		t.Fatalf("expected an ImportError, got %v", err)
+	}
+	if importErr.Line != 3 {
+		t.Errorf("expected line 3, got %d", importErr.Line)
+	}
+	if len(repo.upserted) != 0 {
+		t.Errorf("expected nothing stored, got %d values", len(repo.upserted))
+	}
+}
+
+func TestPriceIndexService_Import(t *testing.T) {
+	service, repo := newTestService()
+
+	imported, err := service.Import(authenticated(), []commands.IndexValueRequest{
+		indexRequest("ARS", month(2026, time.January), "100"),
+		indexRequest("ARS", month(2026, time.February), "104"),
+	})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	if imported != 2 || len(repo.upserted) != 2 {
+		t.Fatalf("expected 2 imported values, got %d (%d stored)", imported, len(repo.upserted))
+	}
+	for _, value := range repo.upserted {
+		if value.Source != domain.SourceCSV {
+			t.Errorf("expected source csv, got %s", value.Source)
+		}
+	}
+}
+
+func TestPriceIndexService_Restater_LoadsReferenceOutsidePeriod(t *testing.T) {
+	service, repo := newTestService(
+		storedValue(month(2026, time.January), "100"),
+		storedValue(month(2026, time.February), "104"),
+		storedValue(month(2026, time.June), "125"),
+	)
+
+	restater, err := service.Restater(walletdomain.CurrencyARS, time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC), month(2026, time.January), month(2026, time.February))
+	if err != nil {
+		t.Fatalf("Restater failed: %v", err)
+	}
+
+	if !repo.listFrom.Equal(month(2026, time.January)) || !repo.listTo.Equal(month(2026, time.June)) {
+		t.Errorf("expected values from 2026-01 to 2026-06 to be loaded, got %s to %s", repo.listFrom, repo.listTo)
+	}
+
+	restated, err := restater.Restate(shareddomain.MustParseAmount("1000"), month(2026, time.January))
+	if err != nil {
+		t.Fatalf("Restate failed: %v", err)
+	}
+	if restated.String() != "1250" {
+		t.Errorf("expected 1250, got %s", restated)
+	}
+}
+
+func TestPriceIndexService_Restater_MissingReference(t *testing.T) {
+	service, _ := newTestService(storedValue(month(2026, time.January), "100"))
+
+	if _, err := service.Restater(walletdomain.CurrencyARS, month(2026, time.March), month(2026, time.January), month(2026, time.January)); !errors.Is(err, domain.ErrIndexNotFound) {
+		t.Errorf("expected %v, got %v", domain.ErrIndexNotFound, err)
+	}
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	"fin-flow-api/internal/shared/domain"
+)
+
+var (
+	ErrIndexNotFound     = errors.New("price index not found")
+	ErrInvalidIndexValue = errors.New("index value must be greater than zero")
+	ErrIndexPrecision    = errors.New("index value has more than 8 decimal places")
+	ErrInvalidIndexMonth = errors.New("index month is required")
+)
+
+// IndexScale is the number of decimal places index values are stored with.
+const IndexScale int32 = 8
+
+// Sources an index value can be recorded from.
+const (
+	SourceManual = "manual"
+	SourceCSV    = "csv"
+)
+
+// IndexValue is the consumer price index of the country issuing Currency
+// for Month. There is at most one value per currency and month; only the
+// ratio between two values of the same currency is meaningful.
+type IndexValue struct {
+	domain.Entity
+
+	ID       string
+	Currency walletdomain.Currency
+	Month    time.Time
+	Value    domain.Amount
+	Source   string
+}
+
+func NewIndexValue(id string, currency walletdomain.Currency, month time.Time, value domain.Amount, source, createdBy string) *IndexValue {
+	return &IndexValue{
+		Entity:   domain.NewEntity(id, createdBy),
+		ID:       id,
+		Currency: currency,
+		Month:    MonthOf(month),
+		Value:    value,
+		Source:   source,
+	}
+}
+
+func (v *IndexValue) Validate() error {
+	if !walletdomain.IsValidCurrency(string(v.Currency)) {
+		return walletdomain.ErrInvalidCurrency
+	}
+	if v.Month.IsZero() {
+		return ErrInvalidIndexMonth
+	}
+	if !v.Value.IsPositive() {
+		return ErrInvalidIndexValue
+	}
+	if !v.Value.Round(IndexScale).Equal(v.Value) {
+		return ErrIndexPrecision
+	}
+	return nil
+}
+
+// MonthOf returns the first day of the month containing date, in UTC.
+func MonthOf(date time.Time) time.Time {
+	if date.IsZero() {
+		return date
+	}
+	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
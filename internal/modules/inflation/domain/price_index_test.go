@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+func month(year int, m time.Month) time.Time {
+	return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func indexValue(m time.Time, value string) *IndexValue {
+	return NewIndexValue("index-"+m.Format(monthLayout), walletdomain.CurrencyARS, m, shareddomain.MustParseAmount(value), SourceManual, "system")
+}
+
+func TestNewIndexValue_TruncatesMonth(t *testing.T) {
+	value := NewIndexValue("index-1", walletdomain.CurrencyARS, time.Date(2026, 3, 17, 23, 30, 0, 0, time.FixedZone("ART", -3*3600)), shareddomain.MustParseAmount("8540.25"), SourceCSV, "system")
+
+	if !value.Month.Equal(month(2026, time.March)) {
+		t.Errorf("expected month 2026-03, got %s", value.Month)
+	}
+	if value.CreatedBy != "system" {
+		t.Errorf("expected CreatedBy system, got %s", value.CreatedBy)
+	}
+}
+
+func TestIndexValue_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(v *IndexValue)
+		wantErr error
+	}{
+		{"valid", func(v *IndexValue) {}, nil},
+		{"invalid currency", func(v *IndexValue) { v.Currency = "XXX" }, walletdomain.ErrInvalidCurrency},
+		{"missing month", func(v *IndexValue) { v.Month = time.Time{} }, ErrInvalidIndexMonth},
+		{"zero value", func(v *IndexValue) { v.Value = shareddomain.Amount{} }, ErrInvalidIndexValue},
+		{"negative value", func(v *IndexValue) { v.Value = shareddomain.MustParseAmount("-1") }, ErrInvalidIndexValue},
+		{"precision", func(v *IndexValue) { v.Value = shareddomain.MustParseAmount("100.123456789") }, ErrIndexPrecision},
+		{"max precision", func(v *IndexValue) { v.Value = shareddomain.MustParseAmount("100.12345678") }, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value := indexValue(month(2026, time.January), "100")
+			tt.mutate(value)
+			if err := value.Validate(); err != tt.wantErr {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRestater_Restate(t *testing.T) {
+	restater, err := NewRestater(walletdomain.CurrencyARS, time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC), []*IndexValue{
+		indexValue(month(2026, time.January), "100"),
+		indexValue(month(2026, time.February), "104"),
+		indexValue(month(2026, time.March), "106.08"),
+	})
+	if err != nil {
+		t.Fatalf("NewRestater failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		month    time.Time
+		amount   string
+		expected string
+		factor   string
+	}{
+		{"january", month(2026, time.January), "1000", "1060.8", "1.0608"},
+		{"february", month(2026, time.February), "1000", "1020", "1.02"},
+		{"reference month", month(2026, time.March), "1000", "1000", "1"},
+		{"mid-month date", time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC), "333.33", "340", "1.02"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := restater.Restate(shareddomain.MustParseAmount(tt.amount), tt.month)
+			if err != nil {
+				t.Fatalf("Restate failed: %v", err)
+			}
+			if got.String() != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+			factor, err := restater.Factor(tt.month)
+			if err != nil {
+				t.Fatalf("Factor failed: %v", err)
+			}
+			if factor.String() != tt.factor {
+				t.Errorf("expected factor %s, got %s", tt.factor, factor)
+			}
+		})
+	}
+}
+
+func TestRestater_MissingMonths(t *testing.T) {
+	values := []*IndexValue{indexValue(month(2026, time.January), "100")}
+
+	if _, err := NewRestater(walletdomain.CurrencyARS, month(2026, time.March), values); !errors.Is(err, ErrIndexNotFound) {
+		t.Errorf("expected %v for a missing reference month, got %v", ErrIndexNotFound, err)
+	}
+
+	restater, err := NewRestater(walletdomain.CurrencyARS, month(2026, time.January), values)
+	if err != nil {
+		t.Fatalf("NewRestater failed: %v", err)
+	}
+	_, err = restater.Restate(shareddomain.MustParseAmount("10"), month(2026, time.February))
+	if !errors.Is(err, ErrIndexNotFound) {
+		t.Fatalf("expected %v, got %v", ErrIndexNotFound, err)
+	}
+	if err.Error() != "price index not found for ARS in 2026-02" {
+		t.Errorf("expected the currency and month in the error, got %q", err.Error())
+	}
+}
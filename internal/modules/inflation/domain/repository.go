@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"time"
+
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+)
+
+type PriceIndexRepository interface {
+	// Upsert stores the values in one transaction, replacing the value
+	// already stored for the same currency and month.
+	Upsert(values []*IndexValue) error
+	// List returns the values of a currency for the months from from to to
+	// inclusive, oldest first. Zero bounds are open.
+	List(currency walletdomain.Currency, from, to time.Time) ([]*IndexValue, error)
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	"fin-flow-api/internal/shared/domain"
+)
+
+// FactorScale is the number of decimal places restatement factors are
+// reported with. Restated amounts are computed from the index values, not
+// from the rounded factor.
+const FactorScale int32 = 6
+
+const monthLayout = "2006-01"
+
+// Restater restates nominal amounts of a currency to constant currency of
+// the Reference month: an amount from month m is worth
+// amount * index(Reference) / index(m) in Reference money.
+type Restater struct {
+	Currency  walletdomain.Currency
+	Reference time.Time
+
+	reference domain.Amount
+	values    map[string]domain.Amount
+}
+
+// NewRestater builds a restater from the index values of currency. values
+// must include the reference month.
+func NewRestater(currency walletdomain.Currency, reference time.Time, values []*IndexValue) (*Restater, error) {
+	restater := &Restater{
+		Currency:  currency,
+		Reference: MonthOf(reference),
+		values:    make(map[string]domain.Amount, len(values)),
+	}
+	for _, value := range values {
+		if value.Currency == currency {
+			restater.values[value.Month.Format(monthLayout)] = value.Value
+		}
+	}
+
+	value, err := restater.indexFor(restater.Reference)
+	if err != nil {
+		return nil, err
+	}
+	restater.reference = value
+
+	return restater, nil
+}
+
+// Factor returns index(Reference) / index(month).
+func (r *Restater) Factor(month time.Time) (domain.Amount, error) {
+	value, err := r.indexFor(month)
+	if err != nil {
+		return domain.Amount{}, err
+	}
+	return r.reference.DivRound(value, FactorScale), nil
+}
+
+// Restate converts an amount from month into money of the reference month,
+// rounded to the currency's minor unit.
+func (r *Restater) Restate(amount domain.Amount, month time.Time) (domain.Amount, error) {
+	value, err := r.indexFor(month)
+	if err != nil {
+		return domain.Amount{}, err
+	}
+	return amount.Mul(r.reference).DivRound(value, domain.CurrencyScale(string(r.Currency))), nil
+}
+
+func (r *Restater) indexFor(month time.Time) (domain.Amount, error) {
+	key := MonthOf(month).Format(monthLayout)
+	value, ok := r.values[key]
+	if !ok {
+		return domain.Amount{}, fmt.Errorf("%w for %s in %s", ErrIndexNotFound, r.Currency, key)
+	}
+	return value, nil
+}
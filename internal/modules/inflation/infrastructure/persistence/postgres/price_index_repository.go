@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"fin-flow-api/internal/modules/inflation/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const indexValueColumns = `id, currency, index_month, value, source, created_at, modified_at, created_by, modified_by`
+
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+func (r *Repository) Upsert(values []*domain.IndexValue) error {
+	ctx := context.Background()
+
+	dbTx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to store price index values: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	query := `
+		INSERT INTO price_indexes (` + indexValueColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (currency, index_month) DO UPDATE
+		SET value = EXCLUDED.value, source = EXCLUDED.source, modified_at = EXCLUDED.modified_at, modified_by = EXCLUDED.modified_by
+	`
+
+	batch := &pgx.Batch{}
+	for _, value := range values {
+		batch.Queue(
+			query,
+			value.ID,
+			value.Currency.String(),
+			value.Month,
+			value.Value,
+			value.Source,
+			value.CreatedAt,
+			value.ModifiedAt,
+			value.CreatedBy,
+			value.ModifiedBy,
+		)
+	}
+
+	if err := dbTx.SendBatch(ctx, batch).Close(); err != nil {
+		return mapWriteError("failed to store price index values", err)
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to store price index values: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) List(currency walletdomain.Currency, from, to time.Time) ([]*domain.IndexValue, error) {
+	query := `
+		SELECT ` + indexValueColumns + `
+		FROM price_indexes
+		WHERE currency = $1
+			AND ($2::date IS NULL OR index_month >= $2)
+			AND ($3::date IS NULL OR index_month <= $3)
+		ORDER BY index_month ASC
+	`
+
+	rows, err := r.pool.Query(context.Background(), query, currency.String(), nullableDate(from), nullableDate(to))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list price index values: %w", err)
+	}
+	defer rows.Close()
+
+	var values []*domain.IndexValue
+	for rows.Next() {
+		value, err := scanIndexValue(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan price index value: %w", err)
+		}
+		values = append(values, value)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate price index values: %w", err)
+	}
+
+	return values, nil
+}
+
+func nullableDate(date time.Time) *time.Time {
+	if date.IsZero() {
+		return nil
+	}
+	return &date
+}
+
+func scanIndexValue(row pgx.Row) (*domain.IndexValue, error) {
+	var value domain.IndexValue
+	var currency string
+
+	err := row.Scan(
+		&value.ID,
+		&currency,
+		&value.Month,
+		&value.Value,
+		&value.Source,
+		&value.CreatedAt,
+		&value.ModifiedAt,
+		&value.CreatedBy,
+		&value.ModifiedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	value.Currency = walletdomain.Currency(currency)
+
+	return &value, nil
+}
+
+func mapWriteError(message string, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23514": // check_violation
+			if pgErr.ConstraintName == "chk_price_indexes_value_positive" {
+				return domain.ErrInvalidIndexValue
+			}
+			return domain.ErrInvalidIndexMonth
+		}
+	}
+	return fmt.Errorf("%s: %w", message, err)
+}
@@ -0,0 +1,251 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"fin-flow-api/internal/modules/inflation/application/contracts/commands"
+	"fin-flow-api/internal/modules/inflation/application/contracts/queries"
+	"fin-flow-api/internal/modules/inflation/application/services"
+	basehandler "fin-flow-api/internal/shared/http"
+)
+
+const monthLayout = "2006-01"
+
+// maxImportBytes bounds the size of an uploaded CSV file.
+const maxImportBytes = 10 << 20
+
+type priceIndexService interface {
+	Upsert(ctx context.Context, req commands.IndexValueRequest) error
+	Import(ctx context.Context, reqs []commands.IndexValueRequest) (int, error)
+	List(ctx context.Context, currency string, from, to time.Time) ([]*queries.IndexValueResponse, error)
+}
+
+type Handler struct {
+	priceIndexService priceIndexService
+}
+
+func NewHandler(priceIndexService priceIndexService) *Handler {
+	return &Handler{
+		priceIndexService: priceIndexService,
+	}
+}
+
+// UpsertIndexValue handles PUT /price-indexes and stores the index value of
+// a currency for a month, replacing the one already stored.
+func (h *Handler) UpsertIndexValue(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var reqDTO IndexValueRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	cmd, err := toIndexValueCommand(reqDTO)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.priceIndexService.Upsert(r.Context(), cmd); err != nil {
+		statusCode, errorMsg := priceIndexErrorResponse(err)
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Price index value saved successfully")
+}
+
+// ImportIndexValues handles POST /price-indexes/import. The CSV is sent
+// either as the request body or as the "file" field of a multipart form.
+func (h *Handler) ImportIndexValues(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportBytes)
+
+	body, err := csvBody(r)
+	if err != nil {
+		writeImportReadError(w, err)
+		return
+	}
+	defer body.Close()
+
+	reqs, err := services.ParseIndexCSV(body)
+	if err != nil {
+		writeImportReadError(w, err)
+		return
+	}
+
+	imported, err := h.priceIndexService.Import(r.Context(), reqs)
+	if err != nil {
+		statusCode, errorMsg := priceIndexErrorResponse(err)
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, ImportResponse{Imported: imported})
+}
+
+// ListIndexValues handles GET /price-indexes?currency=ARS&from=YYYY-MM&to=YYYY-MM.
+func (h *Handler) ListIndexValues(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+
+	currency := strings.ToUpper(strings.TrimSpace(query.Get("currency")))
+	if currency == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Currency is required")
+		return
+	}
+
+	from, err := parseOptionalMonth(query.Get("from"), "from")
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	to, err := parseOptionalMonth(query.Get("to"), "to")
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	values, err := h.priceIndexService.List(r.Context(), currency, from, to)
+	if err != nil {
+		statusCode, errorMsg := priceIndexErrorResponse(err)
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	responses := make([]IndexValueResponse, len(values))
+	for i, value := range values {
+		responses[i] = toIndexValueResponse(value)
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, responses)
+}
+
+func csvBody(r *http.Request) (io.ReadCloser, error) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		return r.Body, nil
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, err
+		}
+		return nil, &ValidationError{Field: "file", Message: "A CSV file is required in the \"file\" form field"}
+	}
+
+	return file, nil
+}
+
+func writeImportReadError(w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		basehandler.WriteError(w, http.StatusRequestEntityTooLarge, "CSV file is too large")
+		return
+	}
+	basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+}
+
+func parseOptionalMonth(value, field string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	month, err := time.Parse(monthLayout, value)
+	if err != nil {
+		return time.Time{}, &ValidationError{Field: field, Message: "Month must use the YYYY-MM format"}
+	}
+
+	return month, nil
+}
+
+func toIndexValueCommand(req IndexValueRequest) (commands.IndexValueRequest, error) {
+	if strings.TrimSpace(req.Currency) == "" {
+		return commands.IndexValueRequest{}, &ValidationError{Field: "currency", Message: "Currency is required"}
+	}
+
+	if req.Value == nil {
+		return commands.IndexValueRequest{}, &ValidationError{Field: "value", Message: "Value is required"}
+	}
+
+	if req.Month == "" {
+		return commands.IndexValueRequest{}, &ValidationError{Field: "month", Message: "Month is required"}
+	}
+
+	month, err := time.Parse(monthLayout, req.Month)
+	if err != nil {
+		return commands.IndexValueRequest{}, &ValidationError{Field: "month", Message: "Month must use the YYYY-MM format"}
+	}
+
+	return commands.IndexValueRequest{
+		Currency: strings.ToUpper(strings.TrimSpace(req.Currency)),
+		Month:    month,
+		Value:    *req.Value,
+	}, nil
+}
+
+func priceIndexErrorResponse(err error) (int, string) {
+	errorMsg := err.Error()
+
+	var importErr *services.ImportError
+	if errors.As(err, &importErr) {
+		return http.StatusBadRequest, errorMsg
+	}
+
+	switch {
+	case strings.Contains(errorMsg, "user not authenticated"):
+		return http.StatusUnauthorized, "Authentication required"
+	case strings.Contains(errorMsg, "invalid currency"):
+		return http.StatusBadRequest, "Invalid currency"
+	case strings.Contains(errorMsg, "index value must be greater than zero"),
+		strings.Contains(errorMsg, "decimal places"),
+		strings.Contains(errorMsg, "index month is required"):
+		return http.StatusBadRequest, errorMsg
+	}
+
+	return http.StatusInternalServerError, errorMsg
+}
+
+func toIndexValueResponse(value *queries.IndexValueResponse) IndexValueResponse {
+	return IndexValueResponse{
+		ID:        value.ID,
+		Currency:  value.Currency,
+		Month:     value.Month.Format(monthLayout),
+		Value:     value.Value,
+		Source:    value.Source,
+		CreatedAt: value.CreatedAt,
+		UpdatedAt: value.UpdatedAt,
+		CreatedBy: value.CreatedBy,
+		UpdatedBy: value.UpdatedBy,
+	}
+}
+
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
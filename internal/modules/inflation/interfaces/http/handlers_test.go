@@ -0,0 +1,211 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"fin-flow-api/internal/modules/inflation/application/contracts/commands"
+	"fin-flow-api/internal/modules/inflation/application/contracts/queries"
+	"fin-flow-api/internal/modules/inflation/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+type mockPriceIndexService struct {
+	upsertErr   error
+	importErr   error
+	listErr     error
+	values      []*queries.IndexValueResponse
+	lastCommand commands.IndexValueRequest
+	lastImport  []commands.IndexValueRequest
+	lastFrom    time.Time
+	lastTo      time.Time
+}
+
+func (m *mockPriceIndexService) Upsert(ctx context.Context, req commands.IndexValueRequest) error {
+	m.lastCommand = req
+	return m.upsertErr
+}
+
+func (m *mockPriceIndexService) Import(ctx context.Context, reqs []commands.IndexValueRequest) (int, error) {
+	m.lastImport = reqs
+	if m.importErr != nil {
+		return 0, m.importErr
+	}
+	return len(reqs), nil
+}
+
+func (m *mockPriceIndexService) List(ctx context.Context, currency string, from, to time.Time) ([]*queries.IndexValueResponse, error) {
+	m.lastFrom, m.lastTo = from, to
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	return m.values, nil
+}
+
+func validIndexBody() IndexValueRequest {
+	value := shareddomain.MustParseAmount("8540.25")
+	return IndexValueRequest{
+		Currency: " ars",
+		Month:    "2026-01",
+		Value:    &value,
+	}
+}
+
+func TestUpsertIndexValue_Success(t *testing.T) {
+	service := &mockPriceIndexService{}
+	handler := &Handler{priceIndexService: service}
+
+	jsonBody, _ := json.Marshal(validIndexBody())
+	req := httptest.NewRequest("PUT", "/price-indexes", bytes.NewBuffer(jsonBody))
+	rr := httptest.NewRecorder()
+	handler.UpsertIndexValue(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rr.Code)
+	}
+	if service.lastCommand.Currency != "ARS" {
+		t.Errorf("expected currency ARS, got %s", service.lastCommand.Currency)
+	}
+	if !service.lastCommand.Month.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected month %v", service.lastCommand.Month)
+	}
+}
+
+func TestUpsertIndexValue_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*IndexValueRequest)
+	}{
+		{"missing currency", func(r *IndexValueRequest) { r.Currency = " " }},
+		{"missing value", func(r *IndexValueRequest) { r.Value = nil }},
+		{"missing month", func(r *IndexValueRequest) { r.Month = "" }},
+		{"invalid month", func(r *IndexValueRequest) { r.Month = "2026-01-01" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := validIndexBody()
+			tt.mutate(&body)
+
+			if _, err := toIndexValueCommand(body); err == nil {
+				t.Error("expected validation error, got nil")
+			}
+		})
+	}
+}
+
+func TestUpsertIndexValue_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"unauthenticated", errors.New("user not authenticated"), http.StatusUnauthorized},
+		{"currency", errors.New("invalid currency"), http.StatusBadRequest},
+		{"value", domain.ErrInvalidIndexValue, http.StatusBadRequest},
+		{"precision", domain.ErrIndexPrecision, http.StatusBadRequest},
+		{"unexpected", errors.New("boom"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &Handler{priceIndexService: &mockPriceIndexService{upsertErr: tt.err}}
+
+			jsonBody, _ := json.Marshal(validIndexBody())
+			req := httptest.NewRequest("PUT", "/price-indexes", bytes.NewBuffer(jsonBody))
+			rr := httptest.NewRecorder()
+			handler.UpsertIndexValue(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestImportIndexValues_RawBody(t *testing.T) {
+	service := &mockPriceIndexService{}
+	handler := &Handler{priceIndexService: service}
+
+	req := httptest.NewRequest("POST", "/price-indexes/import", strings.NewReader("month,currency,value\n2026-01,ARS,100\n2026-02,ARS,104\n"))
+	req.Header.Set("Content-Type", "text/csv")
+	rr := httptest.NewRecorder()
+	handler.ImportIndexValues(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var response ImportResponse
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Imported != 2 {
+		t.Errorf("expected 2 imported values, got %d", response.Imported)
+	}
+}
+
+func TestImportIndexValues_BadCSV(t *testing.T) {
+	handler := &Handler{priceIndexService: &mockPriceIndexService{}}
+
+	req := httptest.NewRequest("POST", "/price-indexes/import", strings.NewReader("month,value\n2026-01,100\n"))
+	rr := httptest.NewRecorder()
+	handler.ImportIndexValues(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rr.Code)
+	}
+}
+
+func TestListIndexValues(t *testing.T) {
+	service := &mockPriceIndexService{
+		values: []*queries.IndexValueResponse{{
+			ID:       "index-1",
+			Currency: "ARS",
+			Month:    time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			Value:    shareddomain.MustParseAmount("8540.25"),
+			Source:   domain.SourceCSV,
+		}},
+	}
+	handler := &Handler{priceIndexService: service}
+
+	req := httptest.NewRequest("GET", "/price-indexes?currency=ars&from=2026-01&to=2026-06", nil)
+	rr := httptest.NewRecorder()
+	handler.ListIndexValues(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var response []IndexValueResponse
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response) != 1 || response[0].Month != "2026-01" || response[0].Value.String() != "8540.25" {
+		t.Errorf("unexpected response %+v", response)
+	}
+	if !service.lastTo.Equal(time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected to %v", service.lastTo)
+	}
+}
+
+func TestListIndexValues_BadRequests(t *testing.T) {
+	handler := &Handler{priceIndexService: &mockPriceIndexService{}}
+
+	for _, target := range []string{"/price-indexes", "/price-indexes?currency=ARS&from=2026-01-01"} {
+		req := httptest.NewRequest("GET", target, nil)
+		rr := httptest.NewRecorder()
+		handler.ListIndexValues(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", target, rr.Code)
+		}
+	}
+}
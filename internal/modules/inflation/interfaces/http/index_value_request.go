@@ -0,0 +1,9 @@
+package http
+
+import shareddomain "fin-flow-api/internal/shared/domain"
+
+type IndexValueRequest struct {
+	Currency string               `json:"currency"`
+	Month    string               `json:"month"`
+	Value    *shareddomain.Amount `json:"value"`
+}
@@ -0,0 +1,23 @@
+package http
+
+import (
+	"time"
+
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+type IndexValueResponse struct {
+	ID        string              `json:"id"`
+	Currency  string              `json:"currency"`
+	Month     string              `json:"month"`
+	Value     shareddomain.Amount `json:"value"`
+	Source    string              `json:"source"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+	CreatedBy string              `json:"created_by"`
+	UpdatedBy string              `json:"updated_by"`
+}
+
+type ImportResponse struct {
+	Imported int `json:"imported"`
+}
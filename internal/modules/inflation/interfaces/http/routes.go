@@ -0,0 +1,43 @@
+package http
+
+import (
+	"net/http"
+
+	"fin-flow-api/internal/shared/interface/jwt"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+var priceIndexHandler *Handler
+
+func SetupRoutes(mux *http.ServeMux, jwtService jwt.Service) {
+	mountPriceIndexes(mux, jwtService)
+}
+
+func mountPriceIndexes(mux *http.ServeMux, jwtService jwt.Service) {
+	mux.HandleFunc("/price-indexes", handlePriceIndexesCollection(jwtService))
+
+	mux.Handle("/price-indexes/import", middleware.RequireAuth(jwtService)(requireManageIndexes(http.HandlerFunc(priceIndexHandler.ImportIndexValues))))
+}
+
+// Index values are shared by every user, so only callers allowed to manage
+// them may write.
+func handlePriceIndexesCollection(jwtService jwt.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.RequireAuth(jwtService)(http.HandlerFunc(priceIndexHandler.ListIndexValues)).ServeHTTP(w, r)
+		case http.MethodPut:
+			middleware.RequireAuth(jwtService)(requireManageIndexes(http.HandlerFunc(priceIndexHandler.UpsertIndexValue))).ServeHTTP(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func requireManageIndexes(next http.Handler) http.Handler {
+	return middleware.RequirePermission(middleware.PermissionManagePriceIndexes, nil)(next)
+}
+
+func SetHandler(handler *Handler) {
+	priceIndexHandler = handler
+}
@@ -0,0 +1,14 @@
+package commands
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+type AssetPriceRequest struct {
+	Symbol   string
+	Currency string
+	Date     time.Time
+	Price    domain.Amount
+}
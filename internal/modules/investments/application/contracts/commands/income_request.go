@@ -0,0 +1,16 @@
+package commands
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+type IncomeRequest struct {
+	WalletID string
+	Symbol   string
+	Type     int
+	Amount   domain.Amount
+	Date     time.Time
+	Notes    string
+}
@@ -0,0 +1,11 @@
+package commands
+
+import "time"
+
+// PortfolioRequest asks for the holdings of an investment wallet as of
+// Date, with sales matched to lots by Method. An empty Method uses FIFO.
+type PortfolioRequest struct {
+	WalletID string
+	Method   string
+	Date     time.Time
+}
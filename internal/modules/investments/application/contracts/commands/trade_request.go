@@ -0,0 +1,18 @@
+package commands
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+type TradeRequest struct {
+	WalletID string
+	Symbol   string
+	Side     int
+	Quantity domain.Amount
+	Price    domain.Amount
+	Fees     domain.Amount
+	Date     time.Time
+	Notes    string
+}
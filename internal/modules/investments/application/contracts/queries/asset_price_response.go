@@ -0,0 +1,20 @@
+package queries
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+type AssetPriceResponse struct {
+	ID        string
+	Symbol    string
+	Currency  string
+	Date      time.Time
+	Price     domain.Amount
+	Source    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	CreatedBy string
+	UpdatedBy string
+}
@@ -0,0 +1,50 @@
+package queries
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+// PortfolioResponse values the holdings of an investment wallet in its
+// currency. Market value and unrealized gain only add up positions with a
+// stored price; the others are listed in UnpricedSymbols.
+type PortfolioResponse struct {
+	WalletID        string
+	Currency        string
+	Date            time.Time
+	Method          string
+	Cash            domain.Amount
+	Cost            domain.Amount
+	MarketValue     domain.Amount
+	UnrealizedGain  domain.Amount
+	RealizedGain    domain.Amount
+	Income          domain.Amount
+	UnpricedSymbols []string
+	Positions       []PositionResponse
+}
+
+// PositionResponse is the holding of one symbol. Price, MarketValue and
+// UnrealizedGain are nil when no price is stored for the symbol on or
+// before the portfolio date.
+type PositionResponse struct {
+	Symbol         string
+	Quantity       domain.Amount
+	Cost           domain.Amount
+	AverageCost    domain.Amount
+	Price          *domain.Amount
+	PriceDate      *time.Time
+	MarketValue    *domain.Amount
+	UnrealizedGain *domain.Amount
+	RealizedGain   domain.Amount
+	Income         domain.Amount
+	Lots           []LotResponse
+}
+
+type LotResponse struct {
+	TradeID  string
+	Date     time.Time
+	Quantity domain.Amount
+	UnitCost domain.Amount
+	Cost     domain.Amount
+}
@@ -0,0 +1,40 @@
+package queries
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+type TradeResponse struct {
+	ID        string
+	WalletID  string
+	Symbol    string
+	Side      int
+	SideName  string
+	Quantity  domain.Amount
+	Price     domain.Amount
+	Amount    domain.Amount
+	Fees      domain.Amount
+	Date      time.Time
+	Notes     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	CreatedBy string
+	UpdatedBy string
+}
+
+type IncomeResponse struct {
+	ID        string
+	WalletID  string
+	Symbol    string
+	Type      int
+	TypeName  string
+	Amount    domain.Amount
+	Date      time.Time
+	Notes     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	CreatedBy string
+	UpdatedBy string
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"fin-flow-api/internal/modules/investments/application/contracts/commands"
+	"fin-flow-api/internal/modules/investments/application/contracts/queries"
+	"fin-flow-api/internal/modules/investments/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	"fin-flow-api/internal/shared/middleware"
+
+	"github.com/google/uuid"
+)
+
+type AssetPriceService struct {
+	repository domain.AssetPriceRepository
+	systemUser string
+}
+
+func NewAssetPriceService(repository domain.AssetPriceRepository, systemUser string) *AssetPriceService {
+	return &AssetPriceService{
+		repository: repository,
+		systemUser: systemUser,
+	}
+}
+
+func (s *AssetPriceService) getUserIDFromContext(ctx context.Context) (string, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return "", errors.New("user not authenticated")
+	}
+	return userID, nil
+}
+
+// Upsert stores the price of a symbol for a day, replacing any price
+// already stored for that day.
+func (s *AssetPriceService) Upsert(ctx context.Context, req commands.AssetPriceRequest) error {
+	if _, err := s.getUserIDFromContext(ctx); err != nil {
+		return err
+	}
+
+	price := s.newPrice(req, domain.SourceManual)
+	if err := price.Validate(); err != nil {
+		return err
+	}
+
+	return s.repository.Upsert([]*domain.AssetPrice{price})
+}
+
+// Import stores all the prices or none of them. Errors name the CSV line
+// of the offending price.
+func (s *AssetPriceService) Import(ctx context.Context, reqs []commands.AssetPriceRequest) (int, error) {
+	if _, err := s.getUserIDFromContext(ctx); err != nil {
+		return 0, err
+	}
+
+	prices := make([]*domain.AssetPrice, len(reqs))
+	for i, req := range reqs {
+		price := s.newPrice(req, domain.SourceCSV)
+		if err := price.Validate(); err != nil {
+			return 0, &ImportError{Line: i + 2, Message: err.Error()}
+		}
+		prices[i] = price
+	}
+
+	if err := s.repository.Upsert(prices); err != nil {
+		return 0, err
+	}
+
+	return len(prices), nil
+}
+
+func (s *AssetPriceService) List(ctx context.Context, symbol, currency string, from, to time.Time) ([]*queries.AssetPriceResponse, error) {
+	if _, err := s.getUserIDFromContext(ctx); err != nil {
+		return nil, err
+	}
+
+	symbol = domain.NormalizeSymbol(symbol)
+	if !domain.IsValidSymbol(symbol) {
+		return nil, domain.ErrInvalidSymbol
+	}
+	if !walletdomain.IsValidCurrency(currency) {
+		return nil, walletdomain.ErrInvalidCurrency
+	}
+
+	prices, err := s.repository.List(symbol, walletdomain.Currency(currency), domain.DayOf(from), domain.DayOf(to))
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*queries.AssetPriceResponse, len(prices))
+	for i, price := range prices {
+		responses[i] = toAssetPriceResponse(price)
+	}
+
+	return responses, nil
+}
+
+func (s *AssetPriceService) newPrice(req commands.AssetPriceRequest, source string) *domain.AssetPrice {
+	return domain.NewAssetPrice(
+		uuid.New().String(),
+		req.Symbol,
+		walletdomain.Currency(req.Currency),
+		req.Date,
+		req.Price,
+		source,
+		s.systemUser,
+	)
+}
+
+func toAssetPriceResponse(price *domain.AssetPrice) *queries.AssetPriceResponse {
+	return &queries.AssetPriceResponse{
+		ID:        price.ID,
+		Symbol:    price.Symbol,
+		Currency:  string(price.Currency),
+		Date:      price.Date,
+		Price:     price.Price,
+		Source:    price.Source,
+		CreatedAt: price.CreatedAt,
+		UpdatedAt: price.ModifiedAt,
+		CreatedBy: price.CreatedBy,
+		UpdatedBy: price.ModifiedBy,
+	}
+}
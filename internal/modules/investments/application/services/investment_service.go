@@ -0,0 +1,374 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"fin-flow-api/internal/modules/investments/application/contracts/commands"
+	"fin-flow-api/internal/modules/investments/application/contracts/queries"
+	"fin-flow-api/internal/modules/investments/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/middleware"
+
+	"github.com/google/uuid"
+)
+
+// InvestmentService records the trades and income of investment wallets
+// and values their holdings with the stored asset prices.
+type InvestmentService struct {
+	repository       domain.InvestmentRepository
+	priceRepository  domain.AssetPriceRepository
+	walletRepository walletdomain.WalletRepository
+}
+
+func NewInvestmentService(repository domain.InvestmentRepository, priceRepository domain.AssetPriceRepository, walletRepository walletdomain.WalletRepository) *InvestmentService {
+	return &InvestmentService{
+		repository:       repository,
+		priceRepository:  priceRepository,
+		walletRepository: walletRepository,
+	}
+}
+
+func (s *InvestmentService) getUserIDFromContext(ctx context.Context) (string, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return "", errors.New("user not authenticated")
+	}
+	return userID, nil
+}
+
+// CreateTrade records a buy or sell and moves the cash it costs or pays
+// in the wallet balance.
+func (s *InvestmentService) CreateTrade(ctx context.Context, req commands.TradeRequest) (*queries.TradeResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !domain.IsValidTradeSide(req.Side) {
+		return nil, domain.ErrInvalidTradeSide
+	}
+
+	wallet, err := s.investmentWallet(req.WalletID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	trade := domain.NewTrade(
+		uuid.New().String(),
+		userID,
+		wallet.ID,
+		req.Symbol,
+		domain.TradeSide(req.Side),
+		req.Quantity,
+		req.Price,
+		req.Fees,
+		wallet.Currency,
+		req.Date,
+		req.Notes,
+		userID,
+	)
+	if err := trade.Validate(wallet.Currency); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.CreateTrade(trade); err != nil {
+		return nil, err
+	}
+
+	return toTradeResponse(trade), nil
+}
+
+// ListTrades returns the trades of a wallet, optionally of one symbol,
+// oldest first.
+func (s *InvestmentService) ListTrades(ctx context.Context, walletID, symbol string) ([]*queries.TradeResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.investmentWallet(walletID, userID); err != nil {
+		return nil, err
+	}
+
+	trades, err := s.repository.ListTrades(userID, walletID, domain.NormalizeSymbol(symbol))
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*queries.TradeResponse, len(trades))
+	for i, trade := range trades {
+		responses[i] = toTradeResponse(trade)
+	}
+
+	return responses, nil
+}
+
+func (s *InvestmentService) DeleteTrade(ctx context.Context, id string) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.repository.DeleteTrade(id, userID)
+}
+
+// RecordIncome records a dividend or interest payment and adds it to the
+// wallet balance.
+func (s *InvestmentService) RecordIncome(ctx context.Context, req commands.IncomeRequest) (*queries.IncomeResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !domain.IsValidIncomeType(req.Type) {
+		return nil, domain.ErrInvalidIncomeType
+	}
+
+	wallet, err := s.investmentWallet(req.WalletID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	income := domain.NewIncome(
+		uuid.New().String(),
+		userID,
+		wallet.ID,
+		req.Symbol,
+		domain.IncomeType(req.Type),
+		req.Amount,
+		req.Date,
+		req.Notes,
+		userID,
+	)
+	if err := income.Validate(wallet.Currency); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.CreateIncome(income); err != nil {
+		return nil, err
+	}
+
+	return toIncomeResponse(income), nil
+}
+
+func (s *InvestmentService) ListIncome(ctx context.Context, walletID string) ([]*queries.IncomeResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.investmentWallet(walletID, userID); err != nil {
+		return nil, err
+	}
+
+	incomes, err := s.repository.ListIncome(userID, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*queries.IncomeResponse, len(incomes))
+	for i, income := range incomes {
+		responses[i] = toIncomeResponse(income)
+	}
+
+	return responses, nil
+}
+
+func (s *InvestmentService) DeleteIncome(ctx context.Context, id string) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.repository.DeleteIncome(id, userID)
+}
+
+// Portfolio replays the trades and income of a wallet up to the requested
+// date and values each position at the latest price stored for its symbol
+// in the wallet currency on or before that date.
+func (s *InvestmentService) Portfolio(ctx context.Context, req commands.PortfolioRequest) (*queries.PortfolioResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	method := domain.DefaultCostBasisMethod
+	if req.Method != "" {
+		if !domain.IsValidCostBasisMethod(req.Method) {
+			return nil, domain.ErrInvalidCostBasisMethod
+		}
+		method = domain.CostBasisMethod(req.Method)
+	}
+
+	date := domain.DayOf(req.Date)
+	if date.IsZero() {
+		date = domain.DayOf(time.Now())
+	}
+
+	wallet, err := s.investmentWallet(req.WalletID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	trades, err := s.repository.ListTrades(userID, wallet.ID, "")
+	if err != nil {
+		return nil, err
+	}
+	incomes, err := s.repository.ListIncome(userID, wallet.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	tradesBySymbol := make(map[string][]*domain.Trade)
+	for _, trade := range trades {
+		if trade.Date.After(date) {
+			continue
+		}
+		tradesBySymbol[trade.Symbol] = append(tradesBySymbol[trade.Symbol], trade)
+	}
+
+	response := &queries.PortfolioResponse{
+		WalletID:        wallet.ID,
+		Currency:        wallet.Currency.String(),
+		Date:            date,
+		Method:          string(method),
+		Cash:            wallet.Balance,
+		UnpricedSymbols: []string{},
+		Positions:       []queries.PositionResponse{},
+	}
+
+	incomeBySymbol := make(map[string]shareddomain.Amount)
+	for _, income := range incomes {
+		if income.Date.After(date) {
+			continue
+		}
+		incomeBySymbol[income.Symbol] = incomeBySymbol[income.Symbol].Add(income.Amount)
+		response.Income = response.Income.Add(income.Amount)
+	}
+
+	symbols := make([]string, 0, len(tradesBySymbol))
+	for symbol := range tradesBySymbol {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	for _, symbol := range symbols {
+		position, err := domain.NewPosition(symbol, method, tradesBySymbol[symbol])
+		if err != nil {
+			return nil, err
+		}
+
+		positionResponse := toPositionResponse(position, wallet.Currency)
+		positionResponse.Income = incomeBySymbol[symbol]
+
+		response.Cost = response.Cost.Add(positionResponse.Cost)
+		response.RealizedGain = response.RealizedGain.Add(positionResponse.RealizedGain)
+
+		if position.Quantity.IsPositive() {
+			price, err := s.priceRepository.Latest(symbol, wallet.Currency, date)
+			switch {
+			case errors.Is(err, domain.ErrPriceNotFound):
+				response.UnpricedSymbols = append(response.UnpricedSymbols, symbol)
+			case err != nil:
+				return nil, err
+			default:
+				value(&positionResponse, price, wallet.Currency)
+				response.MarketValue = response.MarketValue.Add(*positionResponse.MarketValue)
+				response.UnrealizedGain = response.UnrealizedGain.Add(*positionResponse.UnrealizedGain)
+			}
+		}
+
+		response.Positions = append(response.Positions, positionResponse)
+	}
+
+	return response, nil
+}
+
+func (s *InvestmentService) investmentWallet(walletID, userID string) (*walletdomain.Wallet, error) {
+	wallet, err := s.walletRepository.GetByID(walletID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if wallet.Type != walletdomain.WalletTypeInvestment {
+		return nil, domain.ErrNotInvestmentWallet
+	}
+
+	return wallet, nil
+}
+
+// value prices a position at price and fills in what it is worth and how
+// much it gained since it was bought.
+func value(position *queries.PositionResponse, price *domain.AssetPrice, currency walletdomain.Currency) {
+	marketValue := position.Quantity.Mul(price.Price).RoundToCurrency(currency.String())
+	unrealized := marketValue.Sub(position.Cost)
+
+	position.Price = &price.Price
+	position.PriceDate = &price.Date
+	position.MarketValue = &marketValue
+	position.UnrealizedGain = &unrealized
+}
+
+func toPositionResponse(position *domain.Position, currency walletdomain.Currency) queries.PositionResponse {
+	response := queries.PositionResponse{
+		Symbol:       position.Symbol,
+		Quantity:     position.Quantity,
+		Cost:         position.Cost.RoundToCurrency(currency.String()),
+		AverageCost:  position.AverageCost(),
+		RealizedGain: position.RealizedGain.RoundToCurrency(currency.String()),
+		Lots:         make([]queries.LotResponse, len(position.Lots)),
+	}
+
+	for i, lot := range position.Lots {
+		response.Lots[i] = queries.LotResponse{
+			TradeID:  lot.TradeID,
+			Date:     lot.Date,
+			Quantity: lot.Quantity,
+			UnitCost: lot.UnitCost,
+			Cost:     lot.Cost.RoundToCurrency(currency.String()),
+		}
+	}
+
+	return response
+}
+
+func toTradeResponse(trade *domain.Trade) *queries.TradeResponse {
+	return &queries.TradeResponse{
+		ID:        trade.ID,
+		WalletID:  trade.WalletID,
+		Symbol:    trade.Symbol,
+		Side:      trade.Side.Value(),
+		SideName:  trade.Side.String(),
+		Quantity:  trade.Quantity,
+		Price:     trade.Price,
+		Amount:    trade.Amount,
+		Fees:      trade.Fees,
+		Date:      trade.Date,
+		Notes:     trade.Notes,
+		CreatedAt: trade.CreatedAt,
+		UpdatedAt: trade.ModifiedAt,
+		CreatedBy: trade.CreatedBy,
+		UpdatedBy: trade.ModifiedBy,
+	}
+}
+
+func toIncomeResponse(income *domain.Income) *queries.IncomeResponse {
+	return &queries.IncomeResponse{
+		ID:        income.ID,
+		WalletID:  income.WalletID,
+		Symbol:    income.Symbol,
+		Type:      income.Type.Value(),
+		TypeName:  income.Type.String(),
+		Amount:    income.Amount,
+		Date:      income.Date,
+		Notes:     income.Notes,
+		CreatedAt: income.CreatedAt,
+		UpdatedAt: income.ModifiedAt,
+		CreatedBy: income.CreatedBy,
+		UpdatedBy: income.ModifiedBy,
+	}
+}
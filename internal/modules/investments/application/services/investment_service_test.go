@@ -0,0 +1,328 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"fin-flow-api/internal/modules/investments/application/contracts/commands"
+	"fin-flow-api/internal/modules/investments/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+type mockWalletRepository struct {
+	wallets map[string]*walletdomain.Wallet
+}
+
+func (m *mockWalletRepository) Create(wallet *walletdomain.Wallet) error {
+	m.wallets[wallet.ID] = wallet
+	return nil
+}
+
+func (m *mockWalletRepository) GetByID(id string, userID string) (*walletdomain.Wallet, error) {
+	wallet, exists := m.wallets[id]
+	if !exists {
+		return nil, errors.New("wallet not found")
+	}
+	if wallet.UserID != userID {
+		return nil, errors.New("unauthorized access to wallet")
+	}
+	return wallet, nil
+}
+
+func (m *mockWalletRepository) List(userID string) ([]*walletdomain.Wallet, error) {
+	return nil, nil
+}
+
+func (m *mockWalletRepository) Update(wallet *walletdomain.Wallet) error {
+	return nil
+}
+
+func (m *mockWalletRepository) Delete(id string, userID string) error {
+	return nil
+}
+
+// mockInvestmentRepository keeps trades and income in memory and moves the
+// wallet balance like the real repository does.
+type mockInvestmentRepository struct {
+	wallets *mockWalletRepository
+	trades  []*domain.Trade
+	incomes []*domain.Income
+}
+
+func (m *mockInvestmentRepository) CreateTrade(trade *domain.Trade) error {
+	trades, _ := m.ListTrades(trade.UserID, trade.WalletID, trade.Symbol)
+	if err := domain.CheckHoldings(append(trades, trade)); err != nil {
+		return err
+	}
+	m.trades = append(m.trades, trade)
+	wallet := m.wallets.wallets[trade.WalletID]
+	wallet.Balance = wallet.Balance.Add(trade.CashDelta())
+	return nil
+}
+
+func (m *mockInvestmentRepository) GetTrade(id string, userID string) (*domain.Trade, error) {
+	for _, trade := range m.trades {
+		if trade.ID == id {
+			if trade.UserID != userID {
+				return nil, domain.ErrUnauthorizedTrade
+			}
+			return trade, nil
+		}
+	}
+	return nil, domain.ErrTradeNotFound
+}
+
+func (m *mockInvestmentRepository) ListTrades(userID, walletID, symbol string) ([]*domain.Trade, error) {
+	var result []*domain.Trade
+	for _, trade := range m.trades {
+		if trade.UserID == userID && trade.WalletID == walletID && (symbol == "" || trade.Symbol == symbol) {
+			result = append(result, trade)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockInvestmentRepository) DeleteTrade(id string, userID string) error {
+	for i, trade := range m.trades {
+		if trade.ID == id {
+			if trade.UserID != userID {
+				return domain.ErrUnauthorizedTrade
+			}
+			m.trades = append(m.trades[:i], m.trades[i+1:]...)
+			return nil
+		}
+	}
+	return domain.ErrTradeNotFound
+}
+
+func (m *mockInvestmentRepository) CreateIncome(income *domain.Income) error {
+	m.incomes = append(m.incomes, income)
+	wallet := m.wallets.wallets[income.WalletID]
+	wallet.Balance = wallet.Balance.Add(income.Amount)
+	return nil
+}
+
+func (m *mockInvestmentRepository) GetIncome(id string, userID string) (*domain.Income, error) {
+	return nil, domain.ErrIncomeNotFound
+}
+
+func (m *mockInvestmentRepository) ListIncome(userID, walletID string) ([]*domain.Income, error) {
+	var result []*domain.Income
+	for _, income := range m.incomes {
+		if income.UserID == userID && income.WalletID == walletID {
+			result = append(result, income)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockInvestmentRepository) DeleteIncome(id string, userID string) error {
+	return domain.ErrIncomeNotFound
+}
+
+type mockAssetPriceRepository struct {
+	prices []*domain.AssetPrice
+}
+
+func (m *mockAssetPriceRepository) Upsert(prices []*domain.AssetPrice) error {
+	m.prices = append(m.prices, prices...)
+	return nil
+}
+
+func (m *mockAssetPriceRepository) Latest(symbol string, currency walletdomain.Currency, date time.Time) (*domain.AssetPrice, error) {
+	var latest *domain.AssetPrice
+	for _, price := range m.prices {
+		if price.Symbol != symbol || price.Currency != currency || price.Date.After(date) {
+			continue
+		}
+		if latest == nil || price.Date.After(latest.Date) {
+			latest = price
+		}
+	}
+	if latest == nil {
+		return nil, domain.ErrPriceNotFound
+	}
+	return latest, nil
+}
+
+func (m *mockAssetPriceRepository) List(symbol string, currency walletdomain.Currency, from, to time.Time) ([]*domain.AssetPrice, error) {
+	return m.prices, nil
+}
+
+type mockContext struct {
+	context.Context
+	userID string
+	hasID  bool
+}
+
+func (m *mockContext) Value(key interface{}) interface{} {
+	if key == middleware.UserIDKey {
+		if m.hasID {
+			return m.userID
+		}
+		return nil
+	}
+	return nil
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func amount(value string) shareddomain.Amount {
+	return shareddomain.MustParseAmount(value)
+}
+
+func newTestService() (*InvestmentService, *mockWalletRepository, *mockAssetPriceRepository) {
+	wallets := &mockWalletRepository{wallets: map[string]*walletdomain.Wallet{
+		"broker": walletdomain.NewWallet("broker", "user1", "Broker", walletdomain.WalletTypeInvestment, amount("10000"), walletdomain.CurrencyUSD, "system"),
+		"bank":   walletdomain.NewWallet("bank", "user1", "Bank", walletdomain.WalletTypeBank, amount("10000"), walletdomain.CurrencyUSD, "system"),
+	}}
+	prices := &mockAssetPriceRepository{}
+	repo := &mockInvestmentRepository{wallets: wallets}
+
+	return NewInvestmentService(repo, prices, wallets), wallets, prices
+}
+
+func TestInvestmentService_CreateTrade(t *testing.T) {
+	service, wallets, _ := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	trade, err := service.CreateTrade(ctx, commands.TradeRequest{WalletID: "broker", Symbol: "acme", Side: 0, Quantity: amount("10"), Price: amount("100.5"), Fees: amount("2"), Date: date(2026, 1, 5)})
+	if err != nil {
+		t.Fatalf("CreateTrade failed: %v", err)
+	}
+
+	if trade.Symbol != "ACME" || trade.SideName != "Buy" || !trade.Amount.Equal(amount("1005")) {
+		t.Errorf("unexpected trade %+v", trade)
+	}
+	if balance := wallets.wallets["broker"].Balance; !balance.Equal(amount("8993")) {
+		t.Errorf("expected balance 8993, got %s", balance)
+	}
+
+	_, err = service.CreateTrade(ctx, commands.TradeRequest{WalletID: "broker", Symbol: "ACME", Side: 1, Quantity: amount("11"), Price: amount("100"), Date: date(2026, 1, 6)})
+	if !errors.Is(err, domain.ErrInsufficientQuantity) {
+		t.Errorf("expected ErrInsufficientQuantity, got %v", err)
+	}
+}
+
+func TestInvestmentService_CreateTrade_Errors(t *testing.T) {
+	service, _, _ := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+	valid := commands.TradeRequest{WalletID: "broker", Symbol: "ACME", Quantity: amount("1"), Price: amount("10"), Date: date(2026, 1, 5)}
+
+	tests := []struct {
+		name    string
+		mutate  func(r *commands.TradeRequest)
+		wantErr error
+	}{
+		{"not an investment wallet", func(r *commands.TradeRequest) { r.WalletID = "bank" }, domain.ErrNotInvestmentWallet},
+		{"invalid side", func(r *commands.TradeRequest) { r.Side = 3 }, domain.ErrInvalidTradeSide},
+		{"invalid symbol", func(r *commands.TradeRequest) { r.Symbol = "" }, domain.ErrInvalidSymbol},
+		{"zero quantity", func(r *commands.TradeRequest) { r.Quantity = shareddomain.Amount{} }, domain.ErrInvalidQuantity},
+		{"amount rounds to zero", func(r *commands.TradeRequest) { r.Quantity = amount("0.0001") }, domain.ErrTradeAmountTooSmall},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid
+			tt.mutate(&req)
+			if _, err := service.CreateTrade(ctx, req); err != tt.wantErr {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	if _, err := service.CreateTrade(&mockContext{}, valid); err == nil || err.Error() != "user not authenticated" {
+		t.Errorf("expected user not authenticated, got %v", err)
+	}
+}
+
+func TestInvestmentService_RecordIncome(t *testing.T) {
+	service, wallets, _ := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	income, err := service.RecordIncome(ctx, commands.IncomeRequest{WalletID: "broker", Type: 1, Amount: amount("3.25"), Date: date(2026, 1, 31)})
+	if err != nil {
+		t.Fatalf("RecordIncome failed: %v", err)
+	}
+	if income.TypeName != "Interest" || income.Symbol != "" {
+		t.Errorf("unexpected income %+v", income)
+	}
+	if balance := wallets.wallets["broker"].Balance; !balance.Equal(amount("10003.25")) {
+		t.Errorf("expected balance 10003.25, got %s", balance)
+	}
+
+	if _, err := service.RecordIncome(ctx, commands.IncomeRequest{WalletID: "broker", Type: 0, Amount: amount("1"), Date: date(2026, 1, 31)}); err != domain.ErrDividendSymbol {
+		t.Errorf("expected ErrDividendSymbol, got %v", err)
+	}
+}
+
+func TestInvestmentService_Portfolio(t *testing.T) {
+	service, _, prices := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	trades := []commands.TradeRequest{
+		{WalletID: "broker", Symbol: "ACME", Side: 0, Quantity: amount("10"), Price: amount("100"), Date: date(2026, 1, 5)},
+		{WalletID: "broker", Symbol: "ACME", Side: 0, Quantity: amount("10"), Price: amount("200"), Date: date(2026, 2, 5)},
+		{WalletID: "broker", Symbol: "ACME", Side: 1, Quantity: amount("15"), Price: amount("300"), Date: date(2026, 3, 5)},
+		{WalletID: "broker", Symbol: "BTC", Side: 0, Quantity: amount("0.5"), Price: amount("60000"), Date: date(2026, 3, 6)},
+		{WalletID: "broker", Symbol: "ACME", Side: 0, Quantity: amount("1"), Price: amount("250"), Date: date(2026, 5, 1)},
+	}
+	for _, req := range trades {
+		if _, err := service.CreateTrade(ctx, req); err != nil {
+			t.Fatalf("CreateTrade failed: %v", err)
+		}
+	}
+	if _, err := service.RecordIncome(ctx, commands.IncomeRequest{WalletID: "broker", Symbol: "ACME", Type: 0, Amount: amount("12"), Date: date(2026, 3, 20)}); err != nil {
+		t.Fatalf("RecordIncome failed: %v", err)
+	}
+
+	prices.prices = []*domain.AssetPrice{
+		domain.NewAssetPrice("p1", "ACME", walletdomain.CurrencyUSD, date(2026, 3, 31), amount("320"), domain.SourceManual, "admin"),
+		domain.NewAssetPrice("p2", "ACME", walletdomain.CurrencyUSD, date(2026, 4, 30), amount("999"), domain.SourceManual, "admin"),
+		domain.NewAssetPrice("p3", "ACME", walletdomain.CurrencyEUR, date(2026, 3, 31), amount("1"), domain.SourceManual, "admin"),
+	}
+
+	portfolio, err := service.Portfolio(ctx, commands.PortfolioRequest{WalletID: "broker", Method: "lifo", Date: date(2026, 4, 1)})
+	if err != nil {
+		t.Fatalf("Portfolio failed: %v", err)
+	}
+
+	if portfolio.Method != "lifo" || len(portfolio.Positions) != 2 {
+		t.Fatalf("expected 2 lifo positions, got %d %s", len(portfolio.Positions), portfolio.Method)
+	}
+
+	acme := portfolio.Positions[0]
+	if acme.Symbol != "ACME" || !acme.Quantity.Equal(amount("5")) || !acme.Cost.Equal(amount("500")) {
+		t.Errorf("unexpected ACME position %+v", acme)
+	}
+	if acme.MarketValue == nil || !acme.MarketValue.Equal(amount("1600")) || !acme.UnrealizedGain.Equal(amount("1100")) {
+		t.Errorf("expected ACME worth 1600 with 1100 unrealized, got %v and %v", acme.MarketValue, acme.UnrealizedGain)
+	}
+	if !acme.RealizedGain.Equal(amount("2000")) || !acme.Income.Equal(amount("12")) {
+		t.Errorf("expected 2000 realized and 12 income, got %s and %s", acme.RealizedGain, acme.Income)
+	}
+
+	btc := portfolio.Positions[1]
+	if btc.MarketValue != nil || btc.UnrealizedGain != nil {
+		t.Errorf("expected BTC to be unpriced, got %v", btc.MarketValue)
+	}
+	if len(portfolio.UnpricedSymbols) != 1 || portfolio.UnpricedSymbols[0] != "BTC" {
+		t.Errorf("expected BTC unpriced, got %v", portfolio.UnpricedSymbols)
+	}
+	if !portfolio.Cost.Equal(amount("30500")) || !portfolio.MarketValue.Equal(amount("1600")) || !portfolio.RealizedGain.Equal(amount("2000")) || !portfolio.Income.Equal(amount("12")) {
+		t.Errorf("unexpected totals cost %s value %s realized %s income %s", portfolio.Cost, portfolio.MarketValue, portfolio.RealizedGain, portfolio.Income)
+	}
+
+	if _, err := service.Portfolio(ctx, commands.PortfolioRequest{WalletID: "broker", Method: "hifo"}); err != domain.ErrInvalidCostBasisMethod {
+		t.Errorf("expected ErrInvalidCostBasisMethod, got %v", err)
+	}
+	if _, err := service.Portfolio(ctx, commands.PortfolioRequest{WalletID: "bank"}); err != domain.ErrNotInvestmentWallet {
+		t.Errorf("expected ErrNotInvestmentWallet, got %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"fin-flow-api/internal/modules/investments/application/contracts/commands"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+const dateLayout = "2006-01-02"
+
+// MaxImportRows bounds a single CSV import.
+const MaxImportRows = 50000
+
+var priceCSVColumns = []string{"date", "symbol", "currency", "price"}
+
+// ImportError reports the first CSV line that could not be read. Line 1 is
+// the header.
+type ImportError struct {
+	Line    int
+	Message string
+}
+
+func (e *ImportError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// ParsePricesCSV reads prices from CSV with a header row naming the columns
+// date (YYYY-MM-DD), symbol, currency and price, in any order. Other
+// columns are ignored.
+func ParsePricesCSV(r io.Reader) ([]commands.AssetPriceRequest, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, &ImportError{Line: 1, Message: "file is empty"}
+	}
+	if err != nil {
+		return nil, importReadError(err)
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, column := range priceCSVColumns {
+		if _, ok := index[column]; !ok {
+			return nil, &ImportError{Line: 1, Message: fmt.Sprintf("missing column %q", column)}
+		}
+	}
+
+	var requests []commands.AssetPriceRequest
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, importReadError(err)
+		}
+		if len(requests) == MaxImportRows {
+			return nil, &ImportError{Line: line, Message: fmt.Sprintf("more than %d rows", MaxImportRows)}
+		}
+
+		field := func(name string) string {
+			return strings.TrimSpace(record[index[name]])
+		}
+
+		date, err := time.Parse(dateLayout, field("date"))
+		if err != nil {
+			return nil, &ImportError{Line: line, Message: "date must use the YYYY-MM-DD format"}
+		}
+
+		price, err := shareddomain.ParseAmount(field("price"))
+		if err != nil {
+			return nil, &ImportError{Line: line, Message: "price is not a number"}
+		}
+
+		requests = append(requests, commands.AssetPriceRequest{
+			Symbol:   field("symbol"),
+			Currency: strings.ToUpper(field("currency")),
+			Date:     date,
+			Price:    price,
+		})
+	}
+
+	if len(requests) == 0 {
+		return nil, &ImportError{Line: 2, Message: "no prices found"}
+	}
+
+	return requests, nil
+}
+
+// importReadError reports malformed CSV against its line and passes errors
+// of the underlying reader, such as a size limit, through unchanged.
+func importReadError(err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return &ImportError{Line: parseErr.Line, Message: parseErr.Err.Error()}
+	}
+	return err
+}
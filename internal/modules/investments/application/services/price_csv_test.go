@@ -0,0 +1,87 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParsePricesCSV(t *testing.T) {
+	input := "\ufeffPrice, Symbol,date,currency,note\n480.25,brk.b,2026-01-05,usd,close\n0.000012, SHIB ,2026-01-06,USD,\n"
+
+	reqs, err := ParsePricesCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParsePricesCSV failed: %v", err)
+	}
+
+	if len(reqs) != 2 {
+		t.Fatalf("expected 2 prices, got %d", len(reqs))
+	}
+	if reqs[0].Symbol != "brk.b" || reqs[0].Currency != "USD" || reqs[0].Price.String() != "480.25" || reqs[0].Date.Format(dateLayout) != "2026-01-05" {
+		t.Errorf("unexpected first price %+v", reqs[0])
+	}
+	if reqs[1].Symbol != "SHIB" || reqs[1].Price.String() != "0.000012" {
+		t.Errorf("unexpected second price %+v", reqs[1])
+	}
+}
+
+func TestParsePricesCSV_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantLine int
+		wantMsg  string
+	}{
+		{"empty", "", 1, "file is empty"},
+		{"missing column", "date,symbol,price\n2026-01-05,ACME,1\n", 1, `missing column "currency"`},
+		{"no rows", "date,symbol,currency,price\n", 2, "no prices found"},
+		{"bad date", "date,symbol,currency,price\n2026-01-05,ACME,USD,1\n05/01/2026,ACME,USD,1\n", 3, "YYYY-MM-DD"},
+		{"bad price", "date,symbol,currency,price\n2026-01-05,ACME,USD,abc\n", 2, "price is not a number"},
+		{"wrong field count", "date,symbol,currency,price\n2026-01-05,ACME,USD\n", 2, "wrong number of fields"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePricesCSV(strings.NewReader(tt.input))
+
+			var importErr *ImportError
+			if !errors.As(err, &importErr) {
+				t.Fatalf("expected an ImportError, got %v", err)
+			}
+			if importErr.Line != tt.wantLine {
+				t.Errorf("expected line %d, got %d", tt.wantLine, importErr.Line)
+			}
+			if !strings.Contains(importErr.Message, tt.wantMsg) {
+				t.Errorf("expected message containing %q, got %q", tt.wantMsg, importErr.Message)
+			}
+		})
+	}
+}
+
+func TestAssetPriceService_Import(t *testing.T) {
+	repo := &mockAssetPriceRepository{}
+	service := NewAssetPriceService(repo, "system")
+	ctx := &mockContext{userID: "admin", hasID: true}
+
+	reqs, err := ParsePricesCSV(strings.NewReader("date,symbol,currency,price\n2026-01-05,acme,USD,10\n2026-01-05,ACME,XXX,10\n"))
+	if err != nil {
+		t.Fatalf("ParsePricesCSV failed: %v", err)
+	}
+
+	_, err = service.Import(ctx, reqs)
+	var importErr *ImportError
+	if !errors.As(err, &importErr) || importErr.Line != 3 {
+		t.Fatalf("expected an ImportError on line 3, got %v", err)
+	}
+	if len(repo.prices) != 0 {
+		t.Errorf("expected nothing stored, got %d prices", len(repo.prices))
+	}
+
+	count, err := service.Import(ctx, reqs[:1])
+	if err != nil || count != 1 {
+		t.Fatalf("expected 1 price imported, got %d and %v", count, err)
+	}
+	if repo.prices[0].Symbol != "ACME" || repo.prices[0].Source != "csv" {
+		t.Errorf("unexpected stored price %+v", repo.prices[0])
+	}
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	"fin-flow-api/internal/shared/domain"
+)
+
+var (
+	ErrPriceNotFound   = errors.New("asset price not found")
+	ErrInvalidSymbol   = errors.New("symbol must be 1 to 20 letters, digits, dots or dashes")
+	ErrInvalidPrice    = errors.New("price must be greater than zero")
+	ErrPricePrecision  = errors.New("price has more than 18 decimal places")
+	ErrInvalidPriceDay = errors.New("price date is required")
+)
+
+// PriceScale is the number of decimal places prices and quantities are
+// stored with.
+const PriceScale int32 = 18
+
+// Sources a price can be recorded from.
+const (
+	SourceManual = "manual"
+	SourceCSV    = "csv"
+)
+
+var symbolPattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9.\-]{0,19}$`)
+
+// NormalizeSymbol returns symbol trimmed and in upper case, the form it is
+// stored in.
+func NormalizeSymbol(symbol string) string {
+	return strings.ToUpper(strings.TrimSpace(symbol))
+}
+
+func IsValidSymbol(symbol string) bool {
+	return symbolPattern.MatchString(symbol)
+}
+
+// AssetPrice is the closing price of one unit of Symbol in Currency on
+// Date. There is at most one price per symbol, currency and day.
+type AssetPrice struct {
+	domain.Entity
+
+	ID       string
+	Symbol   string
+	Currency walletdomain.Currency
+	Date     time.Time
+	Price    domain.Amount
+	Source   string
+}
+
+func NewAssetPrice(id, symbol string, currency walletdomain.Currency, date time.Time, price domain.Amount, source, createdBy string) *AssetPrice {
+	return &AssetPrice{
+		Entity:   domain.NewEntity(id, createdBy),
+		ID:       id,
+		Symbol:   NormalizeSymbol(symbol),
+		Currency: currency,
+		Date:     DayOf(date),
+		Price:    price,
+		Source:   source,
+	}
+}
+
+func (p *AssetPrice) Validate() error {
+	if !IsValidSymbol(p.Symbol) {
+		return ErrInvalidSymbol
+	}
+	if !walletdomain.IsValidCurrency(string(p.Currency)) {
+		return walletdomain.ErrInvalidCurrency
+	}
+	if p.Date.IsZero() {
+		return ErrInvalidPriceDay
+	}
+	if !p.Price.IsPositive() {
+		return ErrInvalidPrice
+	}
+	if !p.Price.Round(PriceScale).Equal(p.Price) {
+		return ErrPricePrecision
+	}
+	return nil
+}
+
+// DayOf returns the start of the day containing date, in UTC.
+func DayOf(date time.Time) time.Time {
+	if date.IsZero() {
+		return date
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,242 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+var ErrInvalidCostBasisMethod = errors.New("cost basis method must be fifo, lifo or average")
+
+// CostBasisMethod decides which units a sale takes out of a position and
+// therefore what they cost.
+type CostBasisMethod string
+
+const (
+	// CostBasisFIFO sells the oldest lots first.
+	CostBasisFIFO CostBasisMethod = "fifo"
+	// CostBasisLIFO sells the newest lots first.
+	CostBasisLIFO CostBasisMethod = "lifo"
+	// CostBasisAverage pools every unit held at their average cost.
+	CostBasisAverage CostBasisMethod = "average"
+)
+
+const DefaultCostBasisMethod = CostBasisFIFO
+
+func IsValidCostBasisMethod(method string) bool {
+	switch CostBasisMethod(method) {
+	case CostBasisFIFO, CostBasisLIFO, CostBasisAverage:
+		return true
+	default:
+		return false
+	}
+}
+
+// Lot is what is left of one purchase. Cost includes its share of the
+// purchase fees.
+type Lot struct {
+	TradeID  string
+	Date     time.Time
+	Quantity domain.Amount
+	UnitCost domain.Amount
+	Cost     domain.Amount
+}
+
+// Disposal is one sale and the gain it realized against the cost of the
+// units it took out of the position.
+type Disposal struct {
+	TradeID  string
+	Date     time.Time
+	Quantity domain.Amount
+	Proceeds domain.Amount
+	Cost     domain.Amount
+	Gain     domain.Amount
+}
+
+// Position is what is held of one symbol after replaying its trades.
+type Position struct {
+	Symbol       string
+	Method       CostBasisMethod
+	Quantity     domain.Amount
+	Cost         domain.Amount
+	Lots         []*Lot
+	Disposals    []Disposal
+	RealizedGain domain.Amount
+}
+
+// NewPosition replays the trades of one symbol in date order, buys before
+// sells on the same day, and matches every sale against the lots held with
+// the given method. A sale of more units than held fails with
+// ErrInsufficientQuantity.
+func NewPosition(symbol string, method CostBasisMethod, trades []*Trade) (*Position, error) {
+	if !IsValidCostBasisMethod(string(method)) {
+		return nil, ErrInvalidCostBasisMethod
+	}
+
+	position := &Position{Symbol: symbol, Method: method}
+
+	for _, trade := range sortTrades(trades) {
+		if trade.Side == TradeSideBuy {
+			position.buy(trade)
+			continue
+		}
+		if err := position.sell(trade); err != nil {
+			return nil, err
+		}
+	}
+
+	if method == CostBasisAverage {
+		position.spreadAverageCost()
+	}
+
+	return position, nil
+}
+
+// AverageCost is the cost of one unit held, or zero when nothing is held.
+func (p *Position) AverageCost() domain.Amount {
+	if !p.Quantity.IsPositive() {
+		return domain.Amount{}
+	}
+	return p.Cost.DivRound(p.Quantity, PriceScale)
+}
+
+// CheckHoldings replays the quantities of a symbol's trades and fails if
+// any sale would sell more units than were held at the time.
+func CheckHoldings(trades []*Trade) error {
+	var held domain.Amount
+	for _, trade := range sortTrades(trades) {
+		if trade.Side == TradeSideBuy {
+			held = held.Add(trade.Quantity)
+			continue
+		}
+		if trade.Quantity.Cmp(held) > 0 {
+			return insufficientQuantity(trade, held)
+		}
+		held = held.Sub(trade.Quantity)
+	}
+	return nil
+}
+
+func (p *Position) buy(trade *Trade) {
+	cost := trade.Amount.Add(trade.Fees)
+	p.Lots = append(p.Lots, &Lot{
+		TradeID:  trade.ID,
+		Date:     trade.Date,
+		Quantity: trade.Quantity,
+		UnitCost: cost.DivRound(trade.Quantity, PriceScale),
+		Cost:     cost,
+	})
+	p.Quantity = p.Quantity.Add(trade.Quantity)
+	p.Cost = p.Cost.Add(cost)
+}
+
+func (p *Position) sell(trade *Trade) error {
+	if trade.Quantity.Cmp(p.Quantity) > 0 {
+		return insufficientQuantity(trade, p.Quantity)
+	}
+
+	var cost domain.Amount
+	if p.Method == CostBasisAverage {
+		cost = proportion(p.Cost, trade.Quantity, p.Quantity)
+		p.takeFromLots(trade.Quantity, false)
+	} else {
+		cost = p.takeFromLots(trade.Quantity, p.Method == CostBasisLIFO)
+	}
+
+	p.Quantity = p.Quantity.Sub(trade.Quantity)
+	p.Cost = p.Cost.Sub(cost)
+	if p.Quantity.IsZero() {
+		p.Cost = domain.Amount{}
+	}
+
+	proceeds := trade.Amount.Sub(trade.Fees)
+	gain := proceeds.Sub(cost)
+	p.Disposals = append(p.Disposals, Disposal{
+		TradeID:  trade.ID,
+		Date:     trade.Date,
+		Quantity: trade.Quantity,
+		Proceeds: proceeds,
+		Cost:     cost,
+		Gain:     gain,
+	})
+	p.RealizedGain = p.RealizedGain.Add(gain)
+
+	return nil
+}
+
+// takeFromLots removes quantity units from the lots, oldest first or newest
+// first, and returns what those units cost.
+func (p *Position) takeFromLots(quantity domain.Amount, newestFirst bool) domain.Amount {
+	var cost domain.Amount
+	remaining := quantity
+
+	for remaining.IsPositive() && len(p.Lots) > 0 {
+		index := 0
+		if newestFirst {
+			index = len(p.Lots) - 1
+		}
+		lot := p.Lots[index]
+
+		if lot.Quantity.Cmp(remaining) <= 0 {
+			cost = cost.Add(lot.Cost)
+			remaining = remaining.Sub(lot.Quantity)
+			p.Lots = append(p.Lots[:index], p.Lots[index+1:]...)
+			continue
+		}
+
+		taken := proportion(lot.Cost, remaining, lot.Quantity)
+		cost = cost.Add(taken)
+		lot.Cost = lot.Cost.Sub(taken)
+		lot.Quantity = lot.Quantity.Sub(remaining)
+		remaining = domain.Amount{}
+	}
+
+	return cost
+}
+
+// spreadAverageCost restates every remaining lot at the average cost of
+// the position, which is what the average method charges sales with.
+func (p *Position) spreadAverageCost() {
+	average := p.AverageCost()
+	allocated := domain.Amount{}
+	for i, lot := range p.Lots {
+		lot.UnitCost = average
+		if i == len(p.Lots)-1 {
+			lot.Cost = p.Cost.Sub(allocated)
+			continue
+		}
+		lot.Cost = proportion(p.Cost, lot.Quantity, p.Quantity)
+		allocated = allocated.Add(lot.Cost)
+	}
+}
+
+// proportion returns the share of total that part of whole stands for.
+func proportion(total, part, whole domain.Amount) domain.Amount {
+	if part.Equal(whole) {
+		return total
+	}
+	return total.Mul(part).DivRound(whole, PriceScale)
+}
+
+func sortTrades(trades []*Trade) []*Trade {
+	sorted := make([]*Trade, len(trades))
+	copy(sorted, trades)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if !a.Date.Equal(b.Date) {
+			return a.Date.Before(b.Date)
+		}
+		if a.Side != b.Side {
+			return a.Side == TradeSideBuy
+		}
+		return a.CreatedAt.Before(b.CreatedAt)
+	})
+	return sorted
+}
+
+func insufficientQuantity(trade *Trade, held domain.Amount) error {
+	return fmt.Errorf("%w: selling %s %s on %s but only %s held", ErrInsufficientQuantity, trade.Quantity, trade.Symbol, trade.Date.Format("2006-01-02"), held)
+}
@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+func trade(id string, side TradeSide, quantity, price, fees string, day int) *Trade {
+	return NewTrade(id, "user-1", "wallet-1", "ACME", side, shareddomain.MustParseAmount(quantity), shareddomain.MustParseAmount(price), shareddomain.MustParseAmount(fees), walletdomain.CurrencyUSD, time.Date(2026, 1, day, 0, 0, 0, 0, time.UTC), "", "user-1")
+}
+
+func assertAmount(t *testing.T, name string, got shareddomain.Amount, want string) {
+	t.Helper()
+	if !got.Equal(shareddomain.MustParseAmount(want)) {
+		t.Errorf("expected %s %s, got %s", name, want, got)
+	}
+}
+
+func TestNewPosition_Methods(t *testing.T) {
+	trades := []*Trade{
+		trade("t1", TradeSideBuy, "10", "100", "0", 1),
+		trade("t2", TradeSideBuy, "10", "200", "0", 2),
+		trade("t3", TradeSideSell, "15", "300", "0", 3),
+	}
+
+	tests := []struct {
+		method   CostBasisMethod
+		cost     string
+		realized string
+		lotCost  string
+	}{
+		// FIFO sells the 10 at 100 and 5 at 200: cost 2000.
+		{CostBasisFIFO, "1000", "2500", "1000"},
+		// LIFO sells the 10 at 200 and 5 at 100: cost 2500.
+		{CostBasisLIFO, "500", "2000", "500"},
+		// Average sells 15 at 150: cost 2250.
+		{CostBasisAverage, "750", "2250", "750"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.method), func(t *testing.T) {
+			position, err := NewPosition("ACME", tt.method, trades)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assertAmount(t, "quantity", position.Quantity, "5")
+			assertAmount(t, "cost", position.Cost, tt.cost)
+			assertAmount(t, "realized gain", position.RealizedGain, tt.realized)
+			if len(position.Lots) != 1 {
+				t.Fatalf("expected 1 lot, got %d", len(position.Lots))
+			}
+			assertAmount(t, "lot quantity", position.Lots[0].Quantity, "5")
+			assertAmount(t, "lot cost", position.Lots[0].Cost, tt.lotCost)
+			if len(position.Disposals) != 1 {
+				t.Fatalf("expected 1 disposal, got %d", len(position.Disposals))
+			}
+			assertAmount(t, "proceeds", position.Disposals[0].Proceeds, "4500")
+		})
+	}
+}
+
+func TestNewPosition_FeesCountTowardsCost(t *testing.T) {
+	position, err := NewPosition("ACME", CostBasisFIFO, []*Trade{
+		trade("t1", TradeSideBuy, "4", "25", "2", 1),
+		trade("t2", TradeSideSell, "2", "30", "1", 2),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The lot cost 102; half of it is sold for 60 - 1.
+	assertAmount(t, "unit cost", position.Lots[0].UnitCost, "25.5")
+	assertAmount(t, "cost", position.Cost, "51")
+	assertAmount(t, "realized gain", position.RealizedGain, "8")
+	assertAmount(t, "average cost", position.AverageCost(), "25.5")
+}
+
+func TestNewPosition_SameDayBuyBeforeSell(t *testing.T) {
+	sell := trade("t1", TradeSideSell, "1", "10", "0", 5)
+	buy := trade("t2", TradeSideBuy, "1", "8", "0", 5)
+	buy.CreatedAt = sell.CreatedAt.Add(time.Minute)
+
+	position, err := NewPosition("ACME", CostBasisFIFO, []*Trade{sell, buy})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertAmount(t, "realized gain", position.RealizedGain, "2")
+	if !position.Quantity.IsZero() || !position.Cost.IsZero() {
+		t.Errorf("expected an empty position, got %s units costing %s", position.Quantity, position.Cost)
+	}
+}
+
+func TestNewPosition_Errors(t *testing.T) {
+	if _, err := NewPosition("ACME", "hifo", nil); err != ErrInvalidCostBasisMethod {
+		t.Errorf("expected ErrInvalidCostBasisMethod, got %v", err)
+	}
+
+	_, err := NewPosition("ACME", CostBasisFIFO, []*Trade{
+		trade("t1", TradeSideBuy, "1", "10", "0", 1),
+		trade("t2", TradeSideSell, "2", "10", "0", 2),
+	})
+	if !errors.Is(err, ErrInsufficientQuantity) {
+		t.Errorf("expected ErrInsufficientQuantity, got %v", err)
+	}
+}
+
+func TestCheckHoldings(t *testing.T) {
+	tests := []struct {
+		name    string
+		trades  []*Trade
+		wantErr error
+	}{
+		{"no trades", nil, nil},
+		{"covered", []*Trade{trade("t1", TradeSideBuy, "2", "10", "0", 1), trade("t2", TradeSideSell, "2", "10", "0", 2)}, nil},
+		{"sale before buy", []*Trade{trade("t1", TradeSideBuy, "2", "10", "0", 3), trade("t2", TradeSideSell, "1", "10", "0", 2)}, ErrInsufficientQuantity},
+		{"oversold", []*Trade{trade("t1", TradeSideBuy, "0.5", "10", "0", 1), trade("t2", TradeSideSell, "0.6", "10", "0", 2)}, ErrInsufficientQuantity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckHoldings(tt.trades); !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestIsValidCostBasisMethod(t *testing.T) {
+	for _, method := range []string{"fifo", "lifo", "average"} {
+		if !IsValidCostBasisMethod(method) {
+			t.Errorf("expected %s to be valid", method)
+		}
+	}
+	for _, method := range []string{"", "FIFO", "hifo"} {
+		if IsValidCostBasisMethod(method) {
+			t.Errorf("expected %q to be invalid", method)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	"fin-flow-api/internal/shared/domain"
+)
+
+var (
+	ErrInvalidIncomeType  = errors.New("invalid income type")
+	ErrInvalidIncome      = errors.New("income amount must be greater than zero")
+	ErrIncomeNotFound     = errors.New("investment income not found")
+	ErrUnauthorizedIncome = errors.New("unauthorized access to investment income")
+	ErrDividendSymbol     = errors.New("dividends must name the symbol that paid them")
+	ErrIncomeDateRequired = errors.New("income date is required")
+)
+
+type IncomeType int
+
+const (
+	IncomeTypeDividend IncomeType = iota
+	IncomeTypeInterest
+)
+
+func (t IncomeType) String() string {
+	switch t {
+	case IncomeTypeDividend:
+		return "Dividend"
+	case IncomeTypeInterest:
+		return "Interest"
+	default:
+		return "Unknown"
+	}
+}
+
+func (t IncomeType) Value() int {
+	return int(t)
+}
+
+func IsValidIncomeType(value int) bool {
+	incomeType := IncomeType(value)
+	return incomeType >= IncomeTypeDividend && incomeType <= IncomeTypeInterest
+}
+
+// Income is a dividend or interest payment into an investment wallet.
+// Interest may leave Symbol empty when it is paid on cash.
+type Income struct {
+	domain.Entity
+
+	ID       string
+	UserID   string
+	WalletID string
+	Symbol   string
+	Type     IncomeType
+	Amount   domain.Amount
+	Date     time.Time
+	Notes    string
+}
+
+func NewIncome(id, userID, walletID, symbol string, incomeType IncomeType, amount domain.Amount, date time.Time, notes, createdBy string) *Income {
+	return &Income{
+		Entity:   domain.NewEntity(id, createdBy),
+		ID:       id,
+		UserID:   userID,
+		WalletID: walletID,
+		Symbol:   NormalizeSymbol(symbol),
+		Type:     incomeType,
+		Amount:   amount,
+		Date:     DayOf(date),
+		Notes:    notes,
+	}
+}
+
+func (i *Income) Validate(currency walletdomain.Currency) error {
+	if !IsValidIncomeType(i.Type.Value()) {
+		return ErrInvalidIncomeType
+	}
+	if i.Symbol == "" && i.Type == IncomeTypeDividend {
+		return ErrDividendSymbol
+	}
+	if i.Symbol != "" && !IsValidSymbol(i.Symbol) {
+		return ErrInvalidSymbol
+	}
+	if !i.Amount.IsPositive() {
+		return ErrInvalidIncome
+	}
+	if !i.Amount.FitsCurrency(currency.String()) {
+		return domain.ErrAmountPrecision
+	}
+	if i.Date.IsZero() {
+		return ErrIncomeDateRequired
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+)
+
+type AssetPriceRepository interface {
+	// Upsert stores the prices in one transaction, replacing the price
+	// already stored for the same symbol, currency and day.
+	Upsert(prices []*AssetPrice) error
+	// Latest returns the most recent price of symbol in currency on or
+	// before date, or ErrPriceNotFound.
+	Latest(symbol string, currency walletdomain.Currency, date time.Time) (*AssetPrice, error)
+	// List returns the prices of a symbol in currency between from and to
+	// inclusive, oldest first. Zero bounds are open.
+	List(symbol string, currency walletdomain.Currency, from, to time.Time) ([]*AssetPrice, error)
+}
+
+type InvestmentRepository interface {
+	// CreateTrade stores the trade and moves the cash balance of its
+	// wallet. It fails with ErrInsufficientQuantity when the trade would
+	// leave a sale of the symbol uncovered.
+	CreateTrade(trade *Trade) error
+	GetTrade(id string, userID string) (*Trade, error)
+	// ListTrades returns the trades of a wallet, optionally of one symbol,
+	// oldest first.
+	ListTrades(userID, walletID, symbol string) ([]*Trade, error)
+	// DeleteTrade removes the trade and reverts its cash movement. Deleting
+	// a purchase that later sales depend on fails with
+	// ErrInsufficientQuantity.
+	DeleteTrade(id string, userID string) error
+
+	CreateIncome(income *Income) error
+	GetIncome(id string, userID string) (*Income, error)
+	// ListIncome returns the income of a wallet, oldest first.
+	ListIncome(userID, walletID string) ([]*Income, error)
+	DeleteIncome(id string, userID string) error
+}
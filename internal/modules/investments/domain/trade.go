@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	"fin-flow-api/internal/shared/domain"
+)
+
+var (
+	ErrInvalidTradeSide     = errors.New("invalid trade side")
+	ErrInvalidQuantity      = errors.New("quantity must be greater than zero")
+	ErrQuantityPrecision    = errors.New("quantity has more than 18 decimal places")
+	ErrInvalidFees          = errors.New("fees must not be negative")
+	ErrNotInvestmentWallet  = errors.New("holdings are only allowed in investment wallets")
+	ErrInsufficientQuantity = errors.New("not enough units held to sell")
+	ErrTradeNotFound        = errors.New("trade not found")
+	ErrUnauthorizedTrade    = errors.New("unauthorized access to trade")
+	ErrTradeDateRequired    = errors.New("trade date is required")
+	ErrTradeAmountTooSmall  = errors.New("trade amount rounds to zero in the wallet currency")
+)
+
+type TradeSide int
+
+const (
+	TradeSideBuy TradeSide = iota
+	TradeSideSell
+)
+
+func (s TradeSide) String() string {
+	switch s {
+	case TradeSideBuy:
+		return "Buy"
+	case TradeSideSell:
+		return "Sell"
+	default:
+		return "Unknown"
+	}
+}
+
+func (s TradeSide) Value() int {
+	return int(s)
+}
+
+func IsValidTradeSide(value int) bool {
+	side := TradeSide(value)
+	return side >= TradeSideBuy && side <= TradeSideSell
+}
+
+// Trade buys or sells Quantity units of Symbol at Price in an investment
+// wallet. Amount is Quantity × Price rounded to the wallet currency and
+// Fees are charged on top of buys and taken out of sales.
+type Trade struct {
+	domain.Entity
+
+	ID       string
+	UserID   string
+	WalletID string
+	Symbol   string
+	Side     TradeSide
+	Quantity domain.Amount
+	Price    domain.Amount
+	Amount   domain.Amount
+	Fees     domain.Amount
+	Date     time.Time
+	Notes    string
+}
+
+func NewTrade(id, userID, walletID, symbol string, side TradeSide, quantity, price, fees domain.Amount, currency walletdomain.Currency, date time.Time, notes, createdBy string) *Trade {
+	return &Trade{
+		Entity:   domain.NewEntity(id, createdBy),
+		ID:       id,
+		UserID:   userID,
+		WalletID: walletID,
+		Symbol:   NormalizeSymbol(symbol),
+		Side:     side,
+		Quantity: quantity,
+		Price:    price,
+		Amount:   quantity.Mul(price).RoundToCurrency(currency.String()),
+		Fees:     fees,
+		Date:     DayOf(date),
+		Notes:    notes,
+	}
+}
+
+// Validate checks the trade on its own. Whether a sale is covered by the
+// units held depends on the other trades; see CheckHoldings.
+func (t *Trade) Validate(currency walletdomain.Currency) error {
+	if !IsValidSymbol(t.Symbol) {
+		return ErrInvalidSymbol
+	}
+	if !IsValidTradeSide(t.Side.Value()) {
+		return ErrInvalidTradeSide
+	}
+	if !t.Quantity.IsPositive() {
+		return ErrInvalidQuantity
+	}
+	if !t.Quantity.Round(PriceScale).Equal(t.Quantity) {
+		return ErrQuantityPrecision
+	}
+	if !t.Price.IsPositive() {
+		return ErrInvalidPrice
+	}
+	if !t.Price.Round(PriceScale).Equal(t.Price) {
+		return ErrPricePrecision
+	}
+	if t.Fees.IsNegative() {
+		return ErrInvalidFees
+	}
+	if !t.Fees.FitsCurrency(currency.String()) {
+		return domain.ErrAmountPrecision
+	}
+	if !t.Amount.IsPositive() {
+		return ErrTradeAmountTooSmall
+	}
+	if t.Date.IsZero() {
+		return ErrTradeDateRequired
+	}
+	return nil
+}
+
+// CashDelta is what the trade adds to (or, when negative, takes from) the
+// cash balance of its wallet.
+func (t *Trade) CashDelta() domain.Amount {
+	if t.Side == TradeSideSell {
+		return t.Amount.Sub(t.Fees)
+	}
+	return t.Amount.Add(t.Fees).Neg()
+}
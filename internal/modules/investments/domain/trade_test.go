@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+func TestNewTrade_RoundsAmountToCurrency(t *testing.T) {
+	trade := NewTrade("t1", "user-1", "wallet-1", " btc ", TradeSideBuy, shareddomain.MustParseAmount("0.0123"), shareddomain.MustParseAmount("61234.56"), shareddomain.MustParseAmount("1.5"), walletdomain.CurrencyUSD, time.Date(2026, 2, 3, 15, 0, 0, 0, time.UTC), "", "user-1")
+
+	if trade.Symbol != "BTC" {
+		t.Errorf("expected symbol BTC, got %s", trade.Symbol)
+	}
+	assertAmount(t, "amount", trade.Amount, "753.19")
+	assertAmount(t, "cash delta", trade.CashDelta(), "-754.69")
+	if !trade.Date.Equal(time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected date 2026-02-03, got %s", trade.Date)
+	}
+
+	trade.Side = TradeSideSell
+	assertAmount(t, "cash delta", trade.CashDelta(), "751.69")
+}
+
+func TestTrade_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(t *Trade)
+		wantErr error
+	}{
+		{"valid", func(t *Trade) {}, nil},
+		{"invalid symbol", func(t *Trade) { t.Symbol = "" }, ErrInvalidSymbol},
+		{"invalid side", func(t *Trade) { t.Side = 2 }, ErrInvalidTradeSide},
+		{"zero quantity", func(t *Trade) { t.Quantity = shareddomain.Amount{} }, ErrInvalidQuantity},
+		{"quantity precision", func(t *Trade) { t.Quantity = shareddomain.MustParseAmount("0.1234567890123456789") }, ErrQuantityPrecision},
+		{"zero price", func(t *Trade) { t.Price = shareddomain.Amount{} }, ErrInvalidPrice},
+		{"negative fees", func(t *Trade) { t.Fees = shareddomain.MustParseAmount("-1") }, ErrInvalidFees},
+		{"fees precision", func(t *Trade) { t.Fees = shareddomain.MustParseAmount("0.001") }, shareddomain.ErrAmountPrecision},
+		{"amount rounds to zero", func(t *Trade) { t.Amount = shareddomain.Amount{} }, ErrTradeAmountTooSmall},
+		{"missing date", func(t *Trade) { t.Date = time.Time{} }, ErrTradeDateRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trade := NewTrade("t1", "user-1", "wallet-1", "ACME", TradeSideBuy, shareddomain.MustParseAmount("2"), shareddomain.MustParseAmount("10"), shareddomain.Amount{}, walletdomain.CurrencyUSD, time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), "", "user-1")
+			tt.mutate(trade)
+			if err := trade.Validate(walletdomain.CurrencyUSD); err != tt.wantErr {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestIncome_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(i *Income)
+		wantErr error
+	}{
+		{"valid", func(i *Income) {}, nil},
+		{"interest on cash", func(i *Income) { i.Type = IncomeTypeInterest; i.Symbol = "" }, nil},
+		{"dividend without symbol", func(i *Income) { i.Symbol = "" }, ErrDividendSymbol},
+		{"invalid type", func(i *Income) { i.Type = 5 }, ErrInvalidIncomeType},
+		{"invalid symbol", func(i *Income) { i.Symbol = "A B" }, ErrInvalidSymbol},
+		{"zero amount", func(i *Income) { i.Amount = shareddomain.Amount{} }, ErrInvalidIncome},
+		{"precision", func(i *Income) { i.Amount = shareddomain.MustParseAmount("1.001") }, shareddomain.ErrAmountPrecision},
+		{"missing date", func(i *Income) { i.Date = time.Time{} }, ErrIncomeDateRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			income := NewIncome("i1", "user-1", "wallet-1", "acme", IncomeTypeDividend, shareddomain.MustParseAmount("12.5"), time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), "", "user-1")
+			tt.mutate(income)
+			if err := income.Validate(walletdomain.CurrencyUSD); err != tt.wantErr {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAssetPrice_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(p *AssetPrice)
+		wantErr error
+	}{
+		{"valid", func(p *AssetPrice) {}, nil},
+		{"invalid symbol", func(p *AssetPrice) { p.Symbol = "-ACME" }, ErrInvalidSymbol},
+		{"invalid currency", func(p *AssetPrice) { p.Currency = "XXX" }, walletdomain.ErrInvalidCurrency},
+		{"missing date", func(p *AssetPrice) { p.Date = time.Time{} }, ErrInvalidPriceDay},
+		{"zero price", func(p *AssetPrice) { p.Price = shareddomain.Amount{} }, ErrInvalidPrice},
+		{"precision", func(p *AssetPrice) { p.Price = shareddomain.MustParseAmount("0.1234567890123456789") }, ErrPricePrecision},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price := NewAssetPrice("p1", "BRK.B", walletdomain.CurrencyUSD, time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), shareddomain.MustParseAmount("480.25"), SourceManual, "admin")
+			tt.mutate(price)
+			if err := price.Validate(); err != tt.wantErr {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"fin-flow-api/internal/modules/investments/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const assetPriceColumns = `id, symbol, currency, price_date, price, source, created_at, modified_at, created_by, modified_by`
+
+type AssetPriceRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAssetPriceRepository(pool *pgxpool.Pool) *AssetPriceRepository {
+	return &AssetPriceRepository{pool: pool}
+}
+
+func (r *AssetPriceRepository) Upsert(prices []*domain.AssetPrice) error {
+	ctx := context.Background()
+
+	dbTx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to store asset prices: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	query := `
+		INSERT INTO asset_prices (` + assetPriceColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (symbol, currency, price_date) DO UPDATE
+		SET price = EXCLUDED.price, source = EXCLUDED.source, modified_at = EXCLUDED.modified_at, modified_by = EXCLUDED.modified_by
+	`
+
+	batch := &pgx.Batch{}
+	for _, price := range prices {
+		batch.Queue(
+			query,
+			price.ID,
+			price.Symbol,
+			price.Currency.String(),
+			price.Date,
+			price.Price,
+			price.Source,
+			price.CreatedAt,
+			price.ModifiedAt,
+			price.CreatedBy,
+			price.ModifiedBy,
+		)
+	}
+
+	if err := dbTx.SendBatch(ctx, batch).Close(); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23514" { // check_violation
+			return domain.ErrInvalidPrice
+		}
+		return fmt.Errorf("failed to store asset prices: %w", err)
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to store asset prices: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AssetPriceRepository) Latest(symbol string, currency walletdomain.Currency, date time.Time) (*domain.AssetPrice, error) {
+	query := `
+		SELECT ` + assetPriceColumns + `
+		FROM asset_prices
+		WHERE symbol = $1 AND currency = $2 AND price_date <= $3
+		ORDER BY price_date DESC
+		LIMIT 1
+	`
+
+	price, err := scanAssetPrice(r.pool.QueryRow(context.Background(), query, symbol, currency.String(), date))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrPriceNotFound
+		}
+		return nil, fmt.Errorf("failed to get asset price: %w", err)
+	}
+
+	return price, nil
+}
+
+func (r *AssetPriceRepository) List(symbol string, currency walletdomain.Currency, from, to time.Time) ([]*domain.AssetPrice, error) {
+	query := `
+		SELECT ` + assetPriceColumns + `
+		FROM asset_prices
+		WHERE symbol = $1 AND currency = $2
+			AND ($3::date IS NULL OR price_date >= $3)
+			AND ($4::date IS NULL OR price_date <= $4)
+		ORDER BY price_date ASC
+	`
+
+	rows, err := r.pool.Query(context.Background(), query, symbol, currency.String(), nullableDate(from), nullableDate(to))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list asset prices: %w", err)
+	}
+	defer rows.Close()
+
+	var prices []*domain.AssetPrice
+	for rows.Next() {
+		price, err := scanAssetPrice(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan asset price: %w", err)
+		}
+		prices = append(prices, price)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate asset prices: %w", err)
+	}
+
+	return prices, nil
+}
+
+func nullableDate(date time.Time) *time.Time {
+	if date.IsZero() {
+		return nil
+	}
+	return &date
+}
+
+func scanAssetPrice(row pgx.Row) (*domain.AssetPrice, error) {
+	var price domain.AssetPrice
+	var currency string
+
+	err := row.Scan(
+		&price.ID,
+		&price.Symbol,
+		&currency,
+		&price.Date,
+		&price.Price,
+		&price.Source,
+		&price.CreatedAt,
+		&price.ModifiedAt,
+		&price.CreatedBy,
+		&price.ModifiedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	price.Currency = walletdomain.Currency(currency)
+
+	return &price, nil
+}
@@ -0,0 +1,436 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"fin-flow-api/internal/modules/investments/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const tradeColumns = `id, user_id, wallet_id, symbol, side, quantity, price, amount, fees, trade_date, notes, created_at, modified_at, created_by, modified_by`
+
+const incomeColumns = `id, user_id, wallet_id, symbol, type, amount, income_date, notes, created_at, modified_at, created_by, modified_by`
+
+type InvestmentRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewInvestmentRepository(pool *pgxpool.Pool) *InvestmentRepository {
+	return &InvestmentRepository{pool: pool}
+}
+
+func (r *InvestmentRepository) CreateTrade(trade *domain.Trade) error {
+	ctx := context.Background()
+
+	dbTx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create trade: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	// Locking the wallet serializes trades on it, so two sales cannot both
+	// be checked against the same units.
+	if err := lockWallet(ctx, dbTx, trade.WalletID, trade.UserID); err != nil {
+		return err
+	}
+
+	if trade.Side == domain.TradeSideSell {
+		trades, err := listTrades(ctx, dbTx, trade.UserID, trade.WalletID, trade.Symbol)
+		if err != nil {
+			return err
+		}
+		if err := domain.CheckHoldings(append(trades, trade)); err != nil {
+			return err
+		}
+	}
+
+	query := `
+		INSERT INTO investment_trades (` + tradeColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`
+
+	_, err = dbTx.Exec(ctx, query,
+		trade.ID,
+		trade.UserID,
+		trade.WalletID,
+		trade.Symbol,
+		trade.Side.Value(),
+		trade.Quantity,
+		trade.Price,
+		trade.Amount,
+		trade.Fees,
+		trade.Date,
+		trade.Notes,
+		trade.CreatedAt,
+		trade.ModifiedAt,
+		trade.CreatedBy,
+		trade.ModifiedBy,
+	)
+	if err != nil {
+		return mapWriteError("failed to create trade", err)
+	}
+
+	if err := adjustWalletBalance(ctx, dbTx, trade.WalletID, trade.UserID, trade.CashDelta()); err != nil {
+		return err
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to create trade: %w", err)
+	}
+
+	return nil
+}
+
+func (r *InvestmentRepository) GetTrade(id string, userID string) (*domain.Trade, error) {
+	query := `SELECT ` + tradeColumns + ` FROM investment_trades WHERE id = $1`
+
+	trade, err := scanTrade(r.pool.QueryRow(context.Background(), query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrTradeNotFound
+		}
+		return nil, fmt.Errorf("failed to get trade: %w", err)
+	}
+
+	if trade.UserID != userID {
+		return nil, domain.ErrUnauthorizedTrade
+	}
+
+	return trade, nil
+}
+
+func (r *InvestmentRepository) ListTrades(userID, walletID, symbol string) ([]*domain.Trade, error) {
+	return listTrades(context.Background(), r.pool, userID, walletID, symbol)
+}
+
+func (r *InvestmentRepository) DeleteTrade(id string, userID string) error {
+	ctx := context.Background()
+
+	dbTx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete trade: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	existing, err := scanTrade(dbTx.QueryRow(ctx, `SELECT `+tradeColumns+` FROM investment_trades WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrTradeNotFound
+		}
+		return fmt.Errorf("failed to get trade: %w", err)
+	}
+	if existing.UserID != userID {
+		return domain.ErrUnauthorizedTrade
+	}
+
+	if err := lockWallet(ctx, dbTx, existing.WalletID, userID); err != nil {
+		return err
+	}
+
+	// Removing a purchase must not leave a later sale uncovered.
+	if existing.Side == domain.TradeSideBuy {
+		trades, err := listTrades(ctx, dbTx, userID, existing.WalletID, existing.Symbol)
+		if err != nil {
+			return err
+		}
+		remaining := make([]*domain.Trade, 0, len(trades))
+		for _, trade := range trades {
+			if trade.ID != existing.ID {
+				remaining = append(remaining, trade)
+			}
+		}
+		if err := domain.CheckHoldings(remaining); err != nil {
+			return err
+		}
+	}
+
+	if err := adjustWalletBalance(ctx, dbTx, existing.WalletID, userID, existing.CashDelta().Neg()); err != nil {
+		return err
+	}
+
+	if _, err := dbTx.Exec(ctx, `DELETE FROM investment_trades WHERE id = $1 AND user_id = $2`, id, userID); err != nil {
+		return fmt.Errorf("failed to delete trade: %w", err)
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to delete trade: %w", err)
+	}
+
+	return nil
+}
+
+func (r *InvestmentRepository) CreateIncome(income *domain.Income) error {
+	ctx := context.Background()
+
+	dbTx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to record investment income: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	query := `
+		INSERT INTO investment_income (` + incomeColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	_, err = dbTx.Exec(ctx, query,
+		income.ID,
+		income.UserID,
+		income.WalletID,
+		income.Symbol,
+		income.Type.Value(),
+		income.Amount,
+		income.Date,
+		income.Notes,
+		income.CreatedAt,
+		income.ModifiedAt,
+		income.CreatedBy,
+		income.ModifiedBy,
+	)
+	if err != nil {
+		return mapWriteError("failed to record investment income", err)
+	}
+
+	if err := adjustWalletBalance(ctx, dbTx, income.WalletID, income.UserID, income.Amount); err != nil {
+		return err
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to record investment income: %w", err)
+	}
+
+	return nil
+}
+
+func (r *InvestmentRepository) GetIncome(id string, userID string) (*domain.Income, error) {
+	query := `SELECT ` + incomeColumns + ` FROM investment_income WHERE id = $1`
+
+	income, err := scanIncome(r.pool.QueryRow(context.Background(), query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrIncomeNotFound
+		}
+		return nil, fmt.Errorf("failed to get investment income: %w", err)
+	}
+
+	if income.UserID != userID {
+		return nil, domain.ErrUnauthorizedIncome
+	}
+
+	return income, nil
+}
+
+func (r *InvestmentRepository) ListIncome(userID, walletID string) ([]*domain.Income, error) {
+	query := `
+		SELECT ` + incomeColumns + `
+		FROM investment_income
+		WHERE user_id = $1 AND wallet_id = $2
+		ORDER BY income_date ASC, created_at ASC
+	`
+
+	rows, err := r.pool.Query(context.Background(), query, userID, walletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list investment income: %w", err)
+	}
+	defer rows.Close()
+
+	var incomes []*domain.Income
+	for rows.Next() {
+		income, err := scanIncome(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan investment income: %w", err)
+		}
+		incomes = append(incomes, income)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate investment income: %w", err)
+	}
+
+	return incomes, nil
+}
+
+func (r *InvestmentRepository) DeleteIncome(id string, userID string) error {
+	ctx := context.Background()
+
+	dbTx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete investment income: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	existing, err := scanIncome(dbTx.QueryRow(ctx, `SELECT `+incomeColumns+` FROM investment_income WHERE id = $1 FOR UPDATE`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrIncomeNotFound
+		}
+		return fmt.Errorf("failed to get investment income: %w", err)
+	}
+	if existing.UserID != userID {
+		return domain.ErrUnauthorizedIncome
+	}
+
+	if err := adjustWalletBalance(ctx, dbTx, existing.WalletID, userID, existing.Amount.Neg()); err != nil {
+		return err
+	}
+
+	if _, err := dbTx.Exec(ctx, `DELETE FROM investment_income WHERE id = $1 AND user_id = $2`, id, userID); err != nil {
+		return fmt.Errorf("failed to delete investment income: %w", err)
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to delete investment income: %w", err)
+	}
+
+	return nil
+}
+
+// querier is what listTrades needs from either the pool or a transaction.
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+func listTrades(ctx context.Context, db querier, userID, walletID, symbol string) ([]*domain.Trade, error) {
+	query := `
+		SELECT ` + tradeColumns + `
+		FROM investment_trades
+		WHERE user_id = $1 AND wallet_id = $2 AND ($3 = '' OR symbol = $3)
+		ORDER BY trade_date ASC, created_at ASC
+	`
+
+	rows, err := db.Query(ctx, query, userID, walletID, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trades: %w", err)
+	}
+	defer rows.Close()
+
+	var trades []*domain.Trade
+	for rows.Next() {
+		trade, err := scanTrade(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan trade: %w", err)
+		}
+		trades = append(trades, trade)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate trades: %w", err)
+	}
+
+	return trades, nil
+}
+
+func lockWallet(ctx context.Context, dbTx pgx.Tx, walletID string, userID string) error {
+	var id string
+	err := dbTx.QueryRow(ctx, `SELECT id FROM wallets WHERE id = $1 AND user_id = $2 FOR UPDATE`, walletID, userID).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("wallet not found")
+		}
+		return fmt.Errorf("failed to lock wallet: %w", err)
+	}
+	return nil
+}
+
+func adjustWalletBalance(ctx context.Context, dbTx pgx.Tx, walletID string, userID string, delta shareddomain.Amount) error {
+	query := `UPDATE wallets SET balance = balance + $1 WHERE id = $2 AND user_id = $3`
+
+	result, err := dbTx.Exec(ctx, query, delta, walletID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update wallet balance: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("wallet not found")
+	}
+
+	return nil
+}
+
+func scanTrade(row pgx.Row) (*domain.Trade, error) {
+	var trade domain.Trade
+	var side int
+
+	err := row.Scan(
+		&trade.ID,
+		&trade.UserID,
+		&trade.WalletID,
+		&trade.Symbol,
+		&side,
+		&trade.Quantity,
+		&trade.Price,
+		&trade.Amount,
+		&trade.Fees,
+		&trade.Date,
+		&trade.Notes,
+		&trade.CreatedAt,
+		&trade.ModifiedAt,
+		&trade.CreatedBy,
+		&trade.ModifiedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	trade.Side = domain.TradeSide(side)
+
+	return &trade, nil
+}
+
+func scanIncome(row pgx.Row) (*domain.Income, error) {
+	var income domain.Income
+	var incomeType int
+
+	err := row.Scan(
+		&income.ID,
+		&income.UserID,
+		&income.WalletID,
+		&income.Symbol,
+		&incomeType,
+		&income.Amount,
+		&income.Date,
+		&income.Notes,
+		&income.CreatedAt,
+		&income.ModifiedAt,
+		&income.CreatedBy,
+		&income.ModifiedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	income.Type = domain.IncomeType(incomeType)
+
+	return &income, nil
+}
+
+func mapWriteError(message string, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23503": // foreign_key_violation
+			return fmt.Errorf("invalid wallet reference")
+		case "23514": // check_violation
+			switch pgErr.ConstraintName {
+			case "chk_investment_trades_side":
+				return domain.ErrInvalidTradeSide
+			case "chk_investment_trades_quantity_positive":
+				return domain.ErrInvalidQuantity
+			case "chk_investment_trades_price_positive":
+				return domain.ErrInvalidPrice
+			case "chk_investment_trades_fees_not_negative":
+				return domain.ErrInvalidFees
+			case "chk_investment_income_type":
+				return domain.ErrInvalidIncomeType
+			}
+			return domain.ErrInvalidIncome
+		}
+	}
+	return fmt.Errorf("%s: %w", message, err)
+}
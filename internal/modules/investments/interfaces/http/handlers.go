@@ -0,0 +1,646 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"fin-flow-api/internal/modules/investments/application/contracts/commands"
+	"fin-flow-api/internal/modules/investments/application/contracts/queries"
+	"fin-flow-api/internal/modules/investments/application/services"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	basehandler "fin-flow-api/internal/shared/http"
+)
+
+const dateLayout = "2006-01-02"
+
+// maxImportBytes bounds the size of an uploaded CSV file.
+const maxImportBytes = 10 << 20
+
+type assetPriceService interface {
+	Upsert(ctx context.Context, req commands.AssetPriceRequest) error
+	Import(ctx context.Context, reqs []commands.AssetPriceRequest) (int, error)
+	List(ctx context.Context, symbol, currency string, from, to time.Time) ([]*queries.AssetPriceResponse, error)
+}
+
+type investmentService interface {
+	CreateTrade(ctx context.Context, req commands.TradeRequest) (*queries.TradeResponse, error)
+	ListTrades(ctx context.Context, walletID, symbol string) ([]*queries.TradeResponse, error)
+	DeleteTrade(ctx context.Context, id string) error
+	RecordIncome(ctx context.Context, req commands.IncomeRequest) (*queries.IncomeResponse, error)
+	ListIncome(ctx context.Context, walletID string) ([]*queries.IncomeResponse, error)
+	DeleteIncome(ctx context.Context, id string) error
+	Portfolio(ctx context.Context, req commands.PortfolioRequest) (*queries.PortfolioResponse, error)
+}
+
+type Handler struct {
+	assetPriceService assetPriceService
+	investmentService investmentService
+}
+
+func NewHandler(assetPriceService assetPriceService, investmentService investmentService) *Handler {
+	return &Handler{
+		assetPriceService: assetPriceService,
+		investmentService: investmentService,
+	}
+}
+
+// UpsertPrice handles PUT /asset-prices and stores the price of a symbol
+// for a day, replacing the one already stored.
+func (h *Handler) UpsertPrice(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var reqDTO AssetPriceRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	cmd, err := toAssetPriceCommand(reqDTO)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.assetPriceService.Upsert(r.Context(), cmd); err != nil {
+		statusCode, errorMsg := investmentErrorResponse(err)
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Asset price saved successfully")
+}
+
+// ImportPrices handles POST /asset-prices/import. The CSV is sent either
+// as the request body or as the "file" field of a multipart form.
+func (h *Handler) ImportPrices(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportBytes)
+
+	body, err := csvBody(r)
+	if err != nil {
+		writeImportReadError(w, err)
+		return
+	}
+	defer body.Close()
+
+	reqs, err := services.ParsePricesCSV(body)
+	if err != nil {
+		writeImportReadError(w, err)
+		return
+	}
+
+	imported, err := h.assetPriceService.Import(r.Context(), reqs)
+	if err != nil {
+		statusCode, errorMsg := investmentErrorResponse(err)
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, ImportResponse{Imported: imported})
+}
+
+// ListPrices handles GET /asset-prices?symbol=BTC&currency=USD&from=&to=.
+func (h *Handler) ListPrices(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	symbol := strings.TrimSpace(query.Get("symbol"))
+	currency := strings.ToUpper(strings.TrimSpace(query.Get("currency")))
+
+	if symbol == "" || currency == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Both symbol and currency are required")
+		return
+	}
+
+	from, err := parseOptionalDate(query.Get("from"), "from")
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	to, err := parseOptionalDate(query.Get("to"), "to")
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	prices, err := h.assetPriceService.List(r.Context(), symbol, currency, from, to)
+	if err != nil {
+		statusCode, errorMsg := investmentErrorResponse(err)
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	responses := make([]AssetPriceResponse, len(prices))
+	for i, price := range prices {
+		responses[i] = toAssetPriceResponse(price)
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, responses)
+}
+
+func (h *Handler) CreateTrade(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var reqDTO TradeRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	cmd, err := toTradeCommand(reqDTO)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	trade, err := h.investmentService.CreateTrade(r.Context(), cmd)
+	if err != nil {
+		statusCode, errorMsg := investmentErrorResponse(err)
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusCreated, toTradeResponse(trade))
+}
+
+// ListTrades handles GET /investments/trades?wallet_id=&symbol=.
+func (h *Handler) ListTrades(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	walletID := strings.TrimSpace(r.URL.Query().Get("wallet_id"))
+	if walletID == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Wallet ID is required")
+		return
+	}
+
+	trades, err := h.investmentService.ListTrades(r.Context(), walletID, r.URL.Query().Get("symbol"))
+	if err != nil {
+		statusCode, errorMsg := investmentErrorResponse(err)
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	responses := make([]TradeResponse, len(trades))
+	for i, trade := range trades {
+		responses[i] = toTradeResponse(trade)
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, responses)
+}
+
+func (h *Handler) DeleteTrade(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/investments/trades/")
+	id := strings.Split(path, "/")[0]
+
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Trade ID is required in the URL path")
+		return
+	}
+
+	if err := h.investmentService.DeleteTrade(r.Context(), id); err != nil {
+		statusCode, errorMsg := investmentErrorResponse(err)
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Trade deleted successfully")
+}
+
+func (h *Handler) RecordIncome(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var reqDTO IncomeRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	cmd, err := toIncomeCommand(reqDTO)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	income, err := h.investmentService.RecordIncome(r.Context(), cmd)
+	if err != nil {
+		statusCode, errorMsg := investmentErrorResponse(err)
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusCreated, toIncomeResponse(income))
+}
+
+// ListIncome handles GET /investments/income?wallet_id=.
+func (h *Handler) ListIncome(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	walletID := strings.TrimSpace(r.URL.Query().Get("wallet_id"))
+	if walletID == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Wallet ID is required")
+		return
+	}
+
+	incomes, err := h.investmentService.ListIncome(r.Context(), walletID)
+	if err != nil {
+		statusCode, errorMsg := investmentErrorResponse(err)
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	responses := make([]IncomeResponse, len(incomes))
+	for i, income := range incomes {
+		responses[i] = toIncomeResponse(income)
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, responses)
+}
+
+func (h *Handler) DeleteIncome(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/investments/income/")
+	id := strings.Split(path, "/")[0]
+
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Income ID is required in the URL path")
+		return
+	}
+
+	if err := h.investmentService.DeleteIncome(r.Context(), id); err != nil {
+		statusCode, errorMsg := investmentErrorResponse(err)
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Investment income deleted successfully")
+}
+
+// GetPortfolio handles GET /investments/portfolio?wallet_id=&method=&date=.
+// The method defaults to fifo and the date to today.
+func (h *Handler) GetPortfolio(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+
+	walletID := strings.TrimSpace(query.Get("wallet_id"))
+	if walletID == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Wallet ID is required")
+		return
+	}
+
+	date, err := parseOptionalDate(query.Get("date"), "date")
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	portfolio, err := h.investmentService.Portfolio(r.Context(), commands.PortfolioRequest{
+		WalletID: walletID,
+		Method:   strings.ToLower(strings.TrimSpace(query.Get("method"))),
+		Date:     date,
+	})
+	if err != nil {
+		statusCode, errorMsg := investmentErrorResponse(err)
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, toPortfolioResponse(portfolio))
+}
+
+func csvBody(r *http.Request) (io.ReadCloser, error) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		return r.Body, nil
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, err
+		}
+		return nil, &ValidationError{Field: "file", Message: "A CSV file is required in the \"file\" form field"}
+	}
+	return file, nil
+}
+
+func writeImportReadError(w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		basehandler.WriteError(w, http.StatusRequestEntityTooLarge, "CSV file is too large")
+		return
+	}
+	basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+}
+
+func parseOptionalDate(value, field string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, &ValidationError{Field: field, Message: "Date must use the YYYY-MM-DD format"}
+	}
+
+	return date, nil
+}
+
+func parseRequiredDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, &ValidationError{Field: "date", Message: "Date is required"}
+	}
+	return parseOptionalDate(value, "date")
+}
+
+func toAssetPriceCommand(req AssetPriceRequest) (commands.AssetPriceRequest, error) {
+	if strings.TrimSpace(req.Symbol) == "" {
+		return commands.AssetPriceRequest{}, &ValidationError{Field: "symbol", Message: "Symbol is required"}
+	}
+
+	if strings.TrimSpace(req.Currency) == "" {
+		return commands.AssetPriceRequest{}, &ValidationError{Field: "currency", Message: "Currency is required"}
+	}
+
+	if req.Price == nil {
+		return commands.AssetPriceRequest{}, &ValidationError{Field: "price", Message: "Price is required"}
+	}
+
+	date, err := parseRequiredDate(req.Date)
+	if err != nil {
+		return commands.AssetPriceRequest{}, err
+	}
+
+	return commands.AssetPriceRequest{
+		Symbol:   req.Symbol,
+		Currency: strings.ToUpper(strings.TrimSpace(req.Currency)),
+		Date:     date,
+		Price:    *req.Price,
+	}, nil
+}
+
+func toTradeCommand(req TradeRequest) (commands.TradeRequest, error) {
+	if strings.TrimSpace(req.WalletID) == "" {
+		return commands.TradeRequest{}, &ValidationError{Field: "wallet_id", Message: "Wallet ID is required"}
+	}
+
+	if strings.TrimSpace(req.Symbol) == "" {
+		return commands.TradeRequest{}, &ValidationError{Field: "symbol", Message: "Symbol is required"}
+	}
+
+	if req.Side == nil {
+		return commands.TradeRequest{}, &ValidationError{Field: "side", Message: "Side is required"}
+	}
+
+	if req.Quantity == nil {
+		return commands.TradeRequest{}, &ValidationError{Field: "quantity", Message: "Quantity is required"}
+	}
+
+	if req.Price == nil {
+		return commands.TradeRequest{}, &ValidationError{Field: "price", Message: "Price is required"}
+	}
+
+	date, err := parseRequiredDate(req.Date)
+	if err != nil {
+		return commands.TradeRequest{}, err
+	}
+
+	var fees shareddomain.Amount
+	if req.Fees != nil {
+		fees = *req.Fees
+	}
+
+	return commands.TradeRequest{
+		WalletID: strings.TrimSpace(req.WalletID),
+		Symbol:   req.Symbol,
+		Side:     *req.Side,
+		Quantity: *req.Quantity,
+		Price:    *req.Price,
+		Fees:     fees,
+		Date:     date,
+		Notes:    strings.TrimSpace(req.Notes),
+	}, nil
+}
+
+func toIncomeCommand(req IncomeRequest) (commands.IncomeRequest, error) {
+	if strings.TrimSpace(req.WalletID) == "" {
+		return commands.IncomeRequest{}, &ValidationError{Field: "wallet_id", Message: "Wallet ID is required"}
+	}
+
+	if req.Type == nil {
+		return commands.IncomeRequest{}, &ValidationError{Field: "type", Message: "Type is required"}
+	}
+
+	if req.Amount == nil {
+		return commands.IncomeRequest{}, &ValidationError{Field: "amount", Message: "Amount is required"}
+	}
+
+	date, err := parseRequiredDate(req.Date)
+	if err != nil {
+		return commands.IncomeRequest{}, err
+	}
+
+	return commands.IncomeRequest{
+		WalletID: strings.TrimSpace(req.WalletID),
+		Symbol:   req.Symbol,
+		Type:     *req.Type,
+		Amount:   *req.Amount,
+		Date:     date,
+		Notes:    strings.TrimSpace(req.Notes),
+	}, nil
+}
+
+func investmentErrorResponse(err error) (int, string) {
+	errorMsg := err.Error()
+
+	var importErr *services.ImportError
+	if errors.As(err, &importErr) {
+		return http.StatusBadRequest, errorMsg
+	}
+
+	switch {
+	case strings.Contains(errorMsg, "user not authenticated"):
+		return http.StatusUnauthorized, "Authentication required"
+	case strings.Contains(errorMsg, "unauthorized access to wallet"):
+		return http.StatusForbidden, "You do not have permission to use this wallet"
+	case strings.Contains(errorMsg, "unauthorized access to trade"):
+		return http.StatusForbidden, "You do not have permission to delete this trade"
+	case strings.Contains(errorMsg, "unauthorized access to investment income"):
+		return http.StatusForbidden, "You do not have permission to delete this income"
+	case strings.Contains(errorMsg, "trade not found"):
+		return http.StatusNotFound, "Trade not found"
+	case strings.Contains(errorMsg, "investment income not found"):
+		return http.StatusNotFound, "Investment income not found"
+	case strings.Contains(errorMsg, "wallet not found"), strings.Contains(errorMsg, "invalid wallet reference"):
+		return http.StatusBadRequest, "Wallet not found"
+	case strings.Contains(errorMsg, "not enough units held"):
+		return http.StatusConflict, errorMsg
+	case strings.Contains(errorMsg, "invalid currency"):
+		return http.StatusBadRequest, "Invalid currency"
+	case strings.Contains(errorMsg, "invalid trade side"):
+		return http.StatusBadRequest, "Invalid trade side. Must be 0 (Buy) or 1 (Sell)"
+	case strings.Contains(errorMsg, "invalid income type"):
+		return http.StatusBadRequest, "Invalid income type. Must be 0 (Dividend) or 1 (Interest)"
+	case strings.Contains(errorMsg, "holdings are only allowed"),
+		strings.Contains(errorMsg, "symbol must be"),
+		strings.Contains(errorMsg, "must be greater than zero"),
+		strings.Contains(errorMsg, "must not be negative"),
+		strings.Contains(errorMsg, "decimal places"),
+		strings.Contains(errorMsg, "rounds to zero"),
+		strings.Contains(errorMsg, "date is required"),
+		strings.Contains(errorMsg, "dividends must name"),
+		strings.Contains(errorMsg, "cost basis method"):
+		return http.StatusBadRequest, errorMsg
+	}
+
+	return http.StatusInternalServerError, errorMsg
+}
+
+func toAssetPriceResponse(price *queries.AssetPriceResponse) AssetPriceResponse {
+	return AssetPriceResponse{
+		ID:        price.ID,
+		Symbol:    price.Symbol,
+		Currency:  price.Currency,
+		Date:      price.Date.Format(dateLayout),
+		Price:     price.Price,
+		Source:    price.Source,
+		CreatedAt: price.CreatedAt,
+		UpdatedAt: price.UpdatedAt,
+		CreatedBy: price.CreatedBy,
+		UpdatedBy: price.UpdatedBy,
+	}
+}
+
+func toTradeResponse(trade *queries.TradeResponse) TradeResponse {
+	return TradeResponse{
+		ID:        trade.ID,
+		WalletID:  trade.WalletID,
+		Symbol:    trade.Symbol,
+		Side:      trade.Side,
+		SideName:  trade.SideName,
+		Quantity:  trade.Quantity,
+		Price:     trade.Price,
+		Amount:    trade.Amount,
+		Fees:      trade.Fees,
+		Date:      trade.Date.Format(dateLayout),
+		Notes:     trade.Notes,
+		CreatedAt: trade.CreatedAt,
+		UpdatedAt: trade.UpdatedAt,
+		CreatedBy: trade.CreatedBy,
+		UpdatedBy: trade.UpdatedBy,
+	}
+}
+
+func toIncomeResponse(income *queries.IncomeResponse) IncomeResponse {
+	return IncomeResponse{
+		ID:        income.ID,
+		WalletID:  income.WalletID,
+		Symbol:    income.Symbol,
+		Type:      income.Type,
+		TypeName:  income.TypeName,
+		Amount:    income.Amount,
+		Date:      income.Date.Format(dateLayout),
+		Notes:     income.Notes,
+		CreatedAt: income.CreatedAt,
+		UpdatedAt: income.UpdatedAt,
+		CreatedBy: income.CreatedBy,
+		UpdatedBy: income.UpdatedBy,
+	}
+}
+
+func toPortfolioResponse(portfolio *queries.PortfolioResponse) PortfolioResponse {
+	response := PortfolioResponse{
+		WalletID:        portfolio.WalletID,
+		Currency:        portfolio.Currency,
+		Date:            portfolio.Date.Format(dateLayout),
+		Method:          portfolio.Method,
+		Cash:            portfolio.Cash,
+		Cost:            portfolio.Cost,
+		MarketValue:     portfolio.MarketValue,
+		UnrealizedGain:  portfolio.UnrealizedGain,
+		RealizedGain:    portfolio.RealizedGain,
+		Income:          portfolio.Income,
+		UnpricedSymbols: portfolio.UnpricedSymbols,
+		Positions:       make([]PositionResponse, len(portfolio.Positions)),
+	}
+
+	for i, position := range portfolio.Positions {
+		positionResponse := PositionResponse{
+			Symbol:         position.Symbol,
+			Quantity:       position.Quantity,
+			Cost:           position.Cost,
+			AverageCost:    position.AverageCost,
+			Price:          position.Price,
+			MarketValue:    position.MarketValue,
+			UnrealizedGain: position.UnrealizedGain,
+			RealizedGain:   position.RealizedGain,
+			Income:         position.Income,
+			Lots:           make([]LotResponse, len(position.Lots)),
+		}
+		if position.PriceDate != nil {
+			priceDate := position.PriceDate.Format(dateLayout)
+			positionResponse.PriceDate = &priceDate
+		}
+		for j, lot := range position.Lots {
+			positionResponse.Lots[j] = LotResponse{
+				TradeID:  lot.TradeID,
+				Date:     lot.Date.Format(dateLayout),
+				Quantity: lot.Quantity,
+				UnitCost: lot.UnitCost,
+				Cost:     lot.Cost,
+			}
+		}
+		response.Positions[i] = positionResponse
+	}
+
+	return response
+}
+
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
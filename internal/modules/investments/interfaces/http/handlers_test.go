@@ -0,0 +1,331 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"fin-flow-api/internal/modules/investments/application/contracts/commands"
+	"fin-flow-api/internal/modules/investments/application/contracts/queries"
+	"fin-flow-api/internal/modules/investments/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+type mockAssetPriceService struct {
+	upsertErr   error
+	lastCommand commands.AssetPriceRequest
+	lastImport  []commands.AssetPriceRequest
+}
+
+func (m *mockAssetPriceService) Upsert(ctx context.Context, req commands.AssetPriceRequest) error {
+	m.lastCommand = req
+	return m.upsertErr
+}
+
+func (m *mockAssetPriceService) Import(ctx context.Context, reqs []commands.AssetPriceRequest) (int, error) {
+	m.lastImport = reqs
+	return len(reqs), nil
+}
+
+func (m *mockAssetPriceService) List(ctx context.Context, symbol, currency string, from, to time.Time) ([]*queries.AssetPriceResponse, error) {
+	return nil, nil
+}
+
+type mockInvestmentService struct {
+	err           error
+	portfolio     *queries.PortfolioResponse
+	lastTrade     commands.TradeRequest
+	lastIncome    commands.IncomeRequest
+	lastPortfolio commands.PortfolioRequest
+	lastDeleted   string
+}
+
+func (m *mockInvestmentService) CreateTrade(ctx context.Context, req commands.TradeRequest) (*queries.TradeResponse, error) {
+	m.lastTrade = req
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &queries.TradeResponse{ID: "trade-1", Symbol: req.Symbol, Side: req.Side, Date: req.Date}, nil
+}
+
+func (m *mockInvestmentService) ListTrades(ctx context.Context, walletID, symbol string) ([]*queries.TradeResponse, error) {
+	return nil, m.err
+}
+
+func (m *mockInvestmentService) DeleteTrade(ctx context.Context, id string) error {
+	m.lastDeleted = id
+	return m.err
+}
+
+func (m *mockInvestmentService) RecordIncome(ctx context.Context, req commands.IncomeRequest) (*queries.IncomeResponse, error) {
+	m.lastIncome = req
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &queries.IncomeResponse{ID: "income-1", Type: req.Type, Date: req.Date}, nil
+}
+
+func (m *mockInvestmentService) ListIncome(ctx context.Context, walletID string) ([]*queries.IncomeResponse, error) {
+	return nil, m.err
+}
+
+func (m *mockInvestmentService) DeleteIncome(ctx context.Context, id string) error {
+	m.lastDeleted = id
+	return m.err
+}
+
+func (m *mockInvestmentService) Portfolio(ctx context.Context, req commands.PortfolioRequest) (*queries.PortfolioResponse, error) {
+	m.lastPortfolio = req
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.portfolio, nil
+}
+
+func validTradeBody() TradeRequest {
+	side := 0
+	quantity := shareddomain.MustParseAmount("0.25")
+	price := shareddomain.MustParseAmount("60000")
+	return TradeRequest{
+		WalletID: "wallet-1",
+		Symbol:   "btc",
+		Side:     &side,
+		Quantity: &quantity,
+		Price:    &price,
+		Date:     "2026-01-05",
+	}
+}
+
+func TestCreateTrade_Success(t *testing.T) {
+	service := &mockInvestmentService{}
+	handler := &Handler{investmentService: service}
+
+	jsonBody, _ := json.Marshal(validTradeBody())
+	req := httptest.NewRequest("POST", "/investments/trades", bytes.NewBuffer(jsonBody))
+	rr := httptest.NewRecorder()
+	handler.CreateTrade(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if !service.lastTrade.Fees.IsZero() || !service.lastTrade.Quantity.Equal(shareddomain.MustParseAmount("0.25")) {
+		t.Errorf("unexpected command %+v", service.lastTrade)
+	}
+
+	var response TradeResponse
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.ID != "trade-1" || response.Date != "2026-01-05" {
+		t.Errorf("unexpected response %+v", response)
+	}
+}
+
+func TestCreateTrade_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*TradeRequest)
+	}{
+		{"missing wallet", func(r *TradeRequest) { r.WalletID = " " }},
+		{"missing symbol", func(r *TradeRequest) { r.Symbol = "" }},
+		{"missing side", func(r *TradeRequest) { r.Side = nil }},
+		{"missing quantity", func(r *TradeRequest) { r.Quantity = nil }},
+		{"missing price", func(r *TradeRequest) { r.Price = nil }},
+		{"missing date", func(r *TradeRequest) { r.Date = "" }},
+		{"invalid date", func(r *TradeRequest) { r.Date = "05/01/2026" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := validTradeBody()
+			tt.mutate(&body)
+
+			if _, err := toTradeCommand(body); err == nil {
+				t.Error("expected validation error, got nil")
+			}
+		})
+	}
+}
+
+func TestCreateTrade_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"unauthenticated", errors.New("user not authenticated"), http.StatusUnauthorized},
+		{"foreign wallet", errors.New("unauthorized access to wallet"), http.StatusForbidden},
+		{"missing wallet", errors.New("wallet not found"), http.StatusBadRequest},
+		{"not investment", domain.ErrNotInvestmentWallet, http.StatusBadRequest},
+		{"oversold", fmt.Errorf("%w: selling 2 BTC", domain.ErrInsufficientQuantity), http.StatusConflict},
+		{"side", domain.ErrInvalidTradeSide, http.StatusBadRequest},
+		{"symbol", domain.ErrInvalidSymbol, http.StatusBadRequest},
+		{"quantity", domain.ErrInvalidQuantity, http.StatusBadRequest},
+		{"precision", domain.ErrQuantityPrecision, http.StatusBadRequest},
+		{"too small", domain.ErrTradeAmountTooSmall, http.StatusBadRequest},
+		{"unexpected", errors.New("boom"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &Handler{investmentService: &mockInvestmentService{err: tt.err}}
+
+			jsonBody, _ := json.Marshal(validTradeBody())
+			req := httptest.NewRequest("POST", "/investments/trades", bytes.NewBuffer(jsonBody))
+			rr := httptest.NewRecorder()
+			handler.CreateTrade(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestDeleteTrade(t *testing.T) {
+	service := &mockInvestmentService{}
+	handler := &Handler{investmentService: service}
+
+	req := httptest.NewRequest("DELETE", "/investments/trades/trade-1", nil)
+	rr := httptest.NewRecorder()
+	handler.DeleteTrade(rr, req)
+
+	if rr.Code != http.StatusOK || service.lastDeleted != "trade-1" {
+		t.Errorf("expected trade-1 deleted, got %d and %q", rr.Code, service.lastDeleted)
+	}
+
+	service.err = domain.ErrTradeNotFound
+	rr = httptest.NewRecorder()
+	handler.DeleteTrade(rr, req)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", rr.Code)
+	}
+}
+
+func TestRecordIncome(t *testing.T) {
+	service := &mockInvestmentService{}
+	handler := &Handler{investmentService: service}
+
+	req := httptest.NewRequest("POST", "/investments/income", strings.NewReader(`{"wallet_id":"wallet-1","symbol":"ACME","type":0,"amount":"12.50","date":"2026-03-20"}`))
+	rr := httptest.NewRecorder()
+	handler.RecordIncome(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if !service.lastIncome.Amount.Equal(shareddomain.MustParseAmount("12.5")) || service.lastIncome.Symbol != "ACME" {
+		t.Errorf("unexpected command %+v", service.lastIncome)
+	}
+
+	req = httptest.NewRequest("POST", "/investments/income", strings.NewReader(`{"wallet_id":"wallet-1","amount":"12.50","date":"2026-03-20"}`))
+	rr = httptest.NewRecorder()
+	handler.RecordIncome(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 without a type, got %d", rr.Code)
+	}
+}
+
+func TestGetPortfolio(t *testing.T) {
+	price := shareddomain.MustParseAmount("320")
+	priceDate := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	service := &mockInvestmentService{portfolio: &queries.PortfolioResponse{
+		WalletID:        "wallet-1",
+		Date:            time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+		Method:          "lifo",
+		UnpricedSymbols: []string{"BTC"},
+		Positions: []queries.PositionResponse{
+			{Symbol: "ACME", Price: &price, PriceDate: &priceDate, Lots: []queries.LotResponse{{TradeID: "t1", Date: priceDate}}},
+			{Symbol: "BTC"},
+		},
+	}}
+	handler := &Handler{investmentService: service}
+
+	req := httptest.NewRequest("GET", "/investments/portfolio?wallet_id=wallet-1&method=LIFO&date=2026-04-01", nil)
+	rr := httptest.NewRecorder()
+	handler.GetPortfolio(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if service.lastPortfolio.Method != "lifo" || !service.lastPortfolio.Date.Equal(time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected request %+v", service.lastPortfolio)
+	}
+
+	var response PortfolioResponse
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Positions) != 2 || *response.Positions[0].PriceDate != "2026-03-31" || response.Positions[1].Price != nil {
+		t.Errorf("unexpected positions %+v", response.Positions)
+	}
+
+	req = httptest.NewRequest("GET", "/investments/portfolio", nil)
+	rr = httptest.NewRecorder()
+	handler.GetPortfolio(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 without a wallet, got %d", rr.Code)
+	}
+
+	service.err = domain.ErrInvalidCostBasisMethod
+	req = httptest.NewRequest("GET", "/investments/portfolio?wallet_id=wallet-1&method=hifo", nil)
+	rr = httptest.NewRecorder()
+	handler.GetPortfolio(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for an unknown method, got %d", rr.Code)
+	}
+}
+
+func TestUpsertPrice(t *testing.T) {
+	service := &mockAssetPriceService{}
+	handler := &Handler{assetPriceService: service}
+
+	req := httptest.NewRequest("PUT", "/asset-prices", strings.NewReader(`{"symbol":"btc","currency":"usd","date":"2026-01-05","price":"61234.5"}`))
+	rr := httptest.NewRecorder()
+	handler.UpsertPrice(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if service.lastCommand.Currency != "USD" || !service.lastCommand.Price.Equal(shareddomain.MustParseAmount("61234.5")) {
+		t.Errorf("unexpected command %+v", service.lastCommand)
+	}
+
+	service.upsertErr = domain.ErrPricePrecision
+	req = httptest.NewRequest("PUT", "/asset-prices", strings.NewReader(`{"symbol":"btc","currency":"usd","date":"2026-01-05","price":"1"}`))
+	rr = httptest.NewRecorder()
+	handler.UpsertPrice(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rr.Code)
+	}
+}
+
+func TestImportPrices_RawBody(t *testing.T) {
+	service := &mockAssetPriceService{}
+	handler := &Handler{assetPriceService: service}
+
+	req := httptest.NewRequest("POST", "/asset-prices/import", strings.NewReader("date,symbol,currency,price\n2026-01-05,BTC,USD,61234.5\n"))
+	req.Header.Set("Content-Type", "text/csv")
+	rr := httptest.NewRecorder()
+	handler.ImportPrices(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if len(service.lastImport) != 1 {
+		t.Errorf("expected 1 price passed to the service, got %d", len(service.lastImport))
+	}
+
+	req = httptest.NewRequest("POST", "/asset-prices/import", strings.NewReader("date,symbol,price\n"))
+	rr = httptest.NewRecorder()
+	handler.ImportPrices(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for a missing column, got %d", rr.Code)
+	}
+}
@@ -0,0 +1,30 @@
+package http
+
+import shareddomain "fin-flow-api/internal/shared/domain"
+
+type AssetPriceRequest struct {
+	Symbol   string               `json:"symbol"`
+	Currency string               `json:"currency"`
+	Date     string               `json:"date"`
+	Price    *shareddomain.Amount `json:"price"`
+}
+
+type TradeRequest struct {
+	WalletID string               `json:"wallet_id"`
+	Symbol   string               `json:"symbol"`
+	Side     *int                 `json:"side"`
+	Quantity *shareddomain.Amount `json:"quantity"`
+	Price    *shareddomain.Amount `json:"price"`
+	Fees     *shareddomain.Amount `json:"fees"`
+	Date     string               `json:"date"`
+	Notes    string               `json:"notes"`
+}
+
+type IncomeRequest struct {
+	WalletID string               `json:"wallet_id"`
+	Symbol   string               `json:"symbol"`
+	Type     *int                 `json:"type"`
+	Amount   *shareddomain.Amount `json:"amount"`
+	Date     string               `json:"date"`
+	Notes    string               `json:"notes"`
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"time"
+
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+type AssetPriceResponse struct {
+	ID        string              `json:"id"`
+	Symbol    string              `json:"symbol"`
+	Currency  string              `json:"currency"`
+	Date      string              `json:"date"`
+	Price     shareddomain.Amount `json:"price"`
+	Source    string              `json:"source"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+	CreatedBy string              `json:"created_by"`
+	UpdatedBy string              `json:"updated_by"`
+}
+
+type ImportResponse struct {
+	Imported int `json:"imported"`
+}
+
+type TradeResponse struct {
+	ID        string              `json:"id"`
+	WalletID  string              `json:"wallet_id"`
+	Symbol    string              `json:"symbol"`
+	Side      int                 `json:"side"`
+	SideName  string              `json:"side_name"`
+	Quantity  shareddomain.Amount `json:"quantity"`
+	Price     shareddomain.Amount `json:"price"`
+	Amount    shareddomain.Amount `json:"amount"`
+	Fees      shareddomain.Amount `json:"fees"`
+	Date      string              `json:"date"`
+	Notes     string              `json:"notes"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+	CreatedBy string              `json:"created_by"`
+	UpdatedBy string              `json:"updated_by"`
+}
+
+type IncomeResponse struct {
+	ID        string              `json:"id"`
+	WalletID  string              `json:"wallet_id"`
+	Symbol    string              `json:"symbol,omitempty"`
+	Type      int                 `json:"type"`
+	TypeName  string              `json:"type_name"`
+	Amount    shareddomain.Amount `json:"amount"`
+	Date      string              `json:"date"`
+	Notes     string              `json:"notes"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+	CreatedBy string              `json:"created_by"`
+	UpdatedBy string              `json:"updated_by"`
+}
+
+type PortfolioResponse struct {
+	WalletID        string              `json:"wallet_id"`
+	Currency        string              `json:"currency"`
+	Date            string              `json:"date"`
+	Method          string              `json:"method"`
+	Cash            shareddomain.Amount `json:"cash"`
+	Cost            shareddomain.Amount `json:"cost"`
+	MarketValue     shareddomain.Amount `json:"market_value"`
+	UnrealizedGain  shareddomain.Amount `json:"unrealized_gain"`
+	RealizedGain    shareddomain.Amount `json:"realized_gain"`
+	Income          shareddomain.Amount `json:"income"`
+	UnpricedSymbols []string            `json:"unpriced_symbols"`
+	Positions       []PositionResponse  `json:"positions"`
+}
+
+type PositionResponse struct {
+	Symbol         string               `json:"symbol"`
+	Quantity       shareddomain.Amount  `json:"quantity"`
+	Cost           shareddomain.Amount  `json:"cost"`
+	AverageCost    shareddomain.Amount  `json:"average_cost"`
+	Price          *shareddomain.Amount `json:"price"`
+	PriceDate      *string              `json:"price_date"`
+	MarketValue    *shareddomain.Amount `json:"market_value"`
+	UnrealizedGain *shareddomain.Amount `json:"unrealized_gain"`
+	RealizedGain   shareddomain.Amount  `json:"realized_gain"`
+	Income         shareddomain.Amount  `json:"income"`
+	Lots           []LotResponse        `json:"lots"`
+}
+
+type LotResponse struct {
+	TradeID  string              `json:"trade_id"`
+	Date     string              `json:"date"`
+	Quantity shareddomain.Amount `json:"quantity"`
+	UnitCost shareddomain.Amount `json:"unit_cost"`
+	Cost     shareddomain.Amount `json:"cost"`
+}
@@ -0,0 +1,80 @@
+package http
+
+import (
+	"net/http"
+
+	"fin-flow-api/internal/shared/interface/jwt"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+var investmentHandler *Handler
+
+func SetupRoutes(mux *http.ServeMux, jwtService jwt.Service) {
+	mountAssetPrices(mux, jwtService)
+	mountInvestments(mux, jwtService)
+}
+
+func mountAssetPrices(mux *http.ServeMux, jwtService jwt.Service) {
+	mux.HandleFunc("/asset-prices", handleAssetPricesCollection(jwtService))
+
+	mux.Handle("/asset-prices/import", middleware.RequireAuth(jwtService)(requireManagePrices(http.HandlerFunc(investmentHandler.ImportPrices))))
+}
+
+func mountInvestments(mux *http.ServeMux, jwtService jwt.Service) {
+	mux.HandleFunc("/investments/trades", handleTradesCollection(jwtService))
+	mux.Handle("/investments/trades/", middleware.RequireAuth(jwtService)(http.HandlerFunc(investmentHandler.DeleteTrade)))
+
+	mux.HandleFunc("/investments/income", handleIncomeCollection(jwtService))
+	mux.Handle("/investments/income/", middleware.RequireAuth(jwtService)(http.HandlerFunc(investmentHandler.DeleteIncome)))
+
+	mux.Handle("/investments/portfolio", middleware.RequireAuth(jwtService)(http.HandlerFunc(investmentHandler.GetPortfolio)))
+}
+
+// Prices are shared by every user, so only callers allowed to manage them
+// may write.
+func handleAssetPricesCollection(jwtService jwt.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.RequireAuth(jwtService)(http.HandlerFunc(investmentHandler.ListPrices)).ServeHTTP(w, r)
+		case http.MethodPut:
+			middleware.RequireAuth(jwtService)(requireManagePrices(http.HandlerFunc(investmentHandler.UpsertPrice))).ServeHTTP(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func handleTradesCollection(jwtService jwt.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.RequireAuth(jwtService)(http.HandlerFunc(investmentHandler.ListTrades)).ServeHTTP(w, r)
+		case http.MethodPost:
+			middleware.RequireAuth(jwtService)(http.HandlerFunc(investmentHandler.CreateTrade)).ServeHTTP(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func handleIncomeCollection(jwtService jwt.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.RequireAuth(jwtService)(http.HandlerFunc(investmentHandler.ListIncome)).ServeHTTP(w, r)
+		case http.MethodPost:
+			middleware.RequireAuth(jwtService)(http.HandlerFunc(investmentHandler.RecordIncome)).ServeHTTP(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func requireManagePrices(next http.Handler) http.Handler {
+	return middleware.RequirePermission(middleware.PermissionManageAssetPrices, nil)(next)
+}
+
+func SetHandler(handler *Handler) {
+	investmentHandler = handler
+}
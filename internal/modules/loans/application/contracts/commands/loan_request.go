@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+type LoanRequest struct {
+	WalletID     string
+	CategoryID   string
+	Name         string
+	Counterparty string
+	Direction    int
+	Principal    domain.Amount
+	AnnualRate   domain.Amount
+	TermMonths   int
+	Method       string
+	StartDate    time.Time
+}
+
+// UpdateLoanRequest changes how a loan is labelled. Its terms cannot be
+// changed once it exists.
+type UpdateLoanRequest struct {
+	CategoryID   string
+	Name         string
+	Counterparty string
+}
+
+// LoanPaymentRequest pays one installment. Installment 0 pays the first
+// one that has not been paid yet.
+type LoanPaymentRequest struct {
+	Installment int
+	Date        time.Time
+}
@@ -0,0 +1,66 @@
+package queries
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+type LoanResponse struct {
+	ID            string
+	WalletID      string
+	CategoryID    string
+	Name          string
+	Counterparty  string
+	Direction     int
+	DirectionName string
+	Principal     domain.Amount
+	AnnualRate    domain.Amount
+	TermMonths    int
+	Method        string
+	StartDate     time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	CreatedBy     string
+	UpdatedBy     string
+}
+
+// LoanInstallmentResponse is one row of the schedule. TransactionID and
+// PaidDate are set once the installment has been paid.
+type LoanInstallmentResponse struct {
+	Number        int
+	DueDate       time.Time
+	Payment       domain.Amount
+	Principal     domain.Amount
+	Interest      domain.Amount
+	Balance       domain.Amount
+	Paid          bool
+	TransactionID string
+	PaidDate      *time.Time
+}
+
+type LoanScheduleResponse struct {
+	LoanID        string
+	Currency      string
+	Method        string
+	TotalPayment  domain.Amount
+	TotalInterest domain.Amount
+	Installments  []*LoanInstallmentResponse
+}
+
+// LoanBalanceResponse is the state of a loan on Date. ScheduledBalance is
+// what should be owed if every installment had been paid on time, and
+// OutstandingBalance is what is owed given the payments recorded so far.
+type LoanBalanceResponse struct {
+	LoanID              string
+	Date                time.Time
+	Currency            string
+	Principal           domain.Amount
+	ScheduledBalance    domain.Amount
+	OutstandingBalance  domain.Amount
+	PrincipalPaid       domain.Amount
+	InterestPaid        domain.Amount
+	InstallmentsPaid    int
+	InstallmentsDue     int
+	InstallmentsOverdue int
+}
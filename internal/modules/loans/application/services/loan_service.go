@@ -0,0 +1,407 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	categorydomain "fin-flow-api/internal/modules/categories/domain"
+	"fin-flow-api/internal/modules/loans/application/contracts/commands"
+	"fin-flow-api/internal/modules/loans/application/contracts/queries"
+	"fin-flow-api/internal/modules/loans/domain"
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/middleware"
+
+	"github.com/google/uuid"
+)
+
+type LoanService struct {
+	repository            domain.LoanRepository
+	walletRepository      walletdomain.WalletRepository
+	categoryRepository    categorydomain.CategoryRepository
+	transactionRepository transactiondomain.TransactionRepository
+	systemUser            string
+	now                   func() time.Time
+}
+
+func NewLoanService(repository domain.LoanRepository, walletRepository walletdomain.WalletRepository, categoryRepository categorydomain.CategoryRepository, transactionRepository transactiondomain.TransactionRepository, systemUser string) *LoanService {
+	return &LoanService{
+		repository:            repository,
+		walletRepository:      walletRepository,
+		categoryRepository:    categoryRepository,
+		transactionRepository: transactionRepository,
+		systemUser:            systemUser,
+		now:                   time.Now,
+	}
+}
+
+func (s *LoanService) getUserIDFromContext(ctx context.Context) (string, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return "", errors.New("user not authenticated")
+	}
+	return userID, nil
+}
+
+// Create stores a new loan. The principal itself is not posted to the
+// wallet; only installments are, as they are paid.
+func (s *LoanService) Create(ctx context.Context, req commands.LoanRequest) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	loan := domain.NewLoan(
+		uuid.New().String(),
+		userID,
+		req.WalletID,
+		req.CategoryID,
+		req.Name,
+		req.Counterparty,
+		domain.Direction(req.Direction),
+		req.Principal,
+		req.AnnualRate,
+		req.TermMonths,
+		domain.Method(req.Method),
+		req.StartDate,
+		s.systemUser,
+	)
+
+	if err := loan.Validate(); err != nil {
+		return err
+	}
+
+	wallet, err := s.walletRepository.GetByID(loan.WalletID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !loan.Principal.FitsCurrency(wallet.Currency.String()) {
+		return shareddomain.ErrAmountPrecision
+	}
+
+	if err := s.validateCategory(userID, loan); err != nil {
+		return err
+	}
+
+	return s.repository.Create(loan)
+}
+
+func (s *LoanService) Update(ctx context.Context, id string, req commands.UpdateLoanRequest) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	loan, err := s.repository.GetByID(id, userID)
+	if err != nil {
+		return err
+	}
+
+	loan.CategoryID = req.CategoryID
+	loan.Name = req.Name
+	loan.Counterparty = req.Counterparty
+
+	if err := loan.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.validateCategory(userID, loan); err != nil {
+		return err
+	}
+
+	loan.Entity.UpdateModified(s.systemUser)
+
+	return s.repository.Update(loan)
+}
+
+func (s *LoanService) Delete(ctx context.Context, id string) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return s.repository.Delete(id, userID)
+}
+
+func (s *LoanService) GetByID(ctx context.Context, id string) (*queries.LoanResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	loan, err := s.repository.GetByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toLoanResponse(loan), nil
+}
+
+func (s *LoanService) List(ctx context.Context) ([]*queries.LoanResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	loans, err := s.repository.List(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*queries.LoanResponse, len(loans))
+	for i, loan := range loans {
+		responses[i] = toLoanResponse(loan)
+	}
+
+	return responses, nil
+}
+
+// Schedule returns every installment of the loan split into principal and
+// interest, marking the ones that have been paid.
+func (s *LoanService) Schedule(ctx context.Context, id string) (*queries.LoanScheduleResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	loan, currency, err := s.loadLoan(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := s.payments(loan)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &queries.LoanScheduleResponse{
+		LoanID:       loan.ID,
+		Currency:     currency,
+		Method:       string(loan.Method),
+		Installments: []*queries.LoanInstallmentResponse{},
+	}
+
+	for _, installment := range loan.Schedule(currency) {
+		row := &queries.LoanInstallmentResponse{
+			Number:    installment.Number,
+			DueDate:   installment.DueDate,
+			Payment:   installment.Payment,
+			Principal: installment.Principal,
+			Interest:  installment.Interest,
+			Balance:   installment.Balance,
+		}
+		if payment, ok := payments[installment.Number]; ok {
+			paidDate := payment.Date
+			row.Paid = true
+			row.TransactionID = payment.ID
+			row.PaidDate = &paidDate
+		}
+
+		response.TotalPayment = response.TotalPayment.Add(installment.Payment)
+		response.TotalInterest = response.TotalInterest.Add(installment.Interest)
+		response.Installments = append(response.Installments, row)
+	}
+
+	return response, nil
+}
+
+// RecordPayment pays an installment by posting a transaction of its
+// scheduled amount to the loan's wallet. Deleting that transaction makes
+// the installment unpaid again.
+func (s *LoanService) RecordPayment(ctx context.Context, id string, req commands.LoanPaymentRequest) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	loan, currency, err := s.loadLoan(id, userID)
+	if err != nil {
+		return err
+	}
+
+	payments, err := s.payments(loan)
+	if err != nil {
+		return err
+	}
+
+	schedule := loan.Schedule(currency)
+
+	number := req.Installment
+	if number == 0 {
+		for _, installment := range schedule {
+			if _, ok := payments[installment.Number]; !ok {
+				number = installment.Number
+				break
+			}
+		}
+		if number == 0 {
+			return domain.ErrLoanPaidOff
+		}
+	}
+
+	if number < 1 || number > len(schedule) {
+		return domain.ErrInvalidInstallment
+	}
+
+	if _, ok := payments[number]; ok {
+		return transactiondomain.ErrLoanInstallmentPaid
+	}
+
+	date := req.Date
+	if date.IsZero() {
+		date = s.now()
+	}
+
+	installment := schedule[number-1]
+	transaction := transactiondomain.NewTransaction(
+		uuid.New().String(),
+		userID,
+		loan.WalletID,
+		loan.CategoryID,
+		loan.PaymentType(),
+		installment.Payment,
+		fmt.Sprintf("%s %d/%d", loan.Name, installment.Number, loan.TermMonths),
+		date,
+		s.systemUser,
+	)
+	transaction.LoanID = loan.ID
+	transaction.LoanInstallment = installment.Number
+
+	return s.transactionRepository.Create(transaction)
+}
+
+// Balance reports what is owed on a loan at the end of date, counting only
+// the payments made on or before it. A zero date means today.
+func (s *LoanService) Balance(ctx context.Context, id string, date time.Time) (*queries.LoanBalanceResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if date.IsZero() {
+		date = s.now()
+	}
+	date = truncateToDate(date)
+
+	loan, currency, err := s.loadLoan(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := s.payments(loan)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := loan.Schedule(currency)
+
+	response := &queries.LoanBalanceResponse{
+		LoanID:             loan.ID,
+		Date:               date,
+		Currency:           currency,
+		Principal:          loan.Principal,
+		ScheduledBalance:   domain.ScheduledBalance(schedule, loan.Principal, date),
+		OutstandingBalance: loan.Principal,
+	}
+
+	for _, installment := range schedule {
+		due := !installment.DueDate.After(date)
+		if due {
+			response.InstallmentsDue++
+		}
+
+		payment, ok := payments[installment.Number]
+		if !ok || truncateToDate(payment.Date).After(date) {
+			if due {
+				response.InstallmentsOverdue++
+			}
+			continue
+		}
+
+		response.InstallmentsPaid++
+		response.PrincipalPaid = response.PrincipalPaid.Add(installment.Principal)
+		response.InterestPaid = response.InterestPaid.Add(installment.Interest)
+	}
+
+	response.OutstandingBalance = loan.Principal.Sub(response.PrincipalPaid)
+
+	return response, nil
+}
+
+// loadLoan returns the loan and the currency of its wallet, which the
+// schedule is rounded to.
+func (s *LoanService) loadLoan(id, userID string) (*domain.Loan, string, error) {
+	loan, err := s.repository.GetByID(id, userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	wallet, err := s.walletRepository.GetByID(loan.WalletID, userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return loan, wallet.Currency.String(), nil
+}
+
+// payments returns the transactions that paid the loan, by installment.
+func (s *LoanService) payments(loan *domain.Loan) (map[int]*transactiondomain.Transaction, error) {
+	transactions, err := s.transactionRepository.List(loan.UserID, transactiondomain.TransactionFilter{LoanID: loan.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	payments := make(map[int]*transactiondomain.Transaction, len(transactions))
+	for _, transaction := range transactions {
+		if transaction.LoanID == loan.ID && transaction.LoanInstallment > 0 {
+			payments[transaction.LoanInstallment] = transaction
+		}
+	}
+
+	return payments, nil
+}
+
+func (s *LoanService) validateCategory(userID string, loan *domain.Loan) error {
+	if loan.CategoryID == "" {
+		return transactiondomain.ErrCategoryRequired
+	}
+
+	category, err := s.categoryRepository.GetByID(loan.CategoryID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !loan.PaymentType().AcceptsCategory(category.Type) {
+		return transactiondomain.ErrCategoryTypeMismatch
+	}
+
+	return nil
+}
+
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func toLoanResponse(loan *domain.Loan) *queries.LoanResponse {
+	return &queries.LoanResponse{
+		ID:            loan.ID,
+		WalletID:      loan.WalletID,
+		CategoryID:    loan.CategoryID,
+		Name:          loan.Name,
+		Counterparty:  loan.Counterparty,
+		Direction:     loan.Direction.Value(),
+		DirectionName: loan.Direction.String(),
+		Principal:     loan.Principal,
+		AnnualRate:    loan.AnnualRate,
+		TermMonths:    loan.TermMonths,
+		Method:        string(loan.Method),
+		StartDate:     loan.StartDate,
+		CreatedAt:     loan.CreatedAt,
+		UpdatedAt:     loan.ModifiedAt,
+		CreatedBy:     loan.CreatedBy,
+		UpdatedBy:     loan.ModifiedBy,
+	}
+}
@@ -0,0 +1,462 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	categorydomain "fin-flow-api/internal/modules/categories/domain"
+	"fin-flow-api/internal/modules/loans/application/contracts/commands"
+	"fin-flow-api/internal/modules/loans/domain"
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	"fin-flow-api/internal/modules/transactions/domain/transactiontest"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+type mockLoanRepository struct {
+	loans map[string]*domain.Loan
+}
+
+func (m *mockLoanRepository) Create(loan *domain.Loan) error {
+	m.loans[loan.ID] = loan
+	return nil
+}
+
+func (m *mockLoanRepository) GetByID(id string, userID string) (*domain.Loan, error) {
+	loan, exists := m.loans[id]
+	if !exists {
+		return nil, errors.New("loan not found")
+	}
+	if loan.UserID != userID {
+		return nil, errors.New("unauthorized access to loan")
+	}
+	copied := *loan
+	return &copied, nil
+}
+
+func (m *mockLoanRepository) List(userID string) ([]*domain.Loan, error) {
+	var result []*domain.Loan
+	for _, loan := range m.loans {
+		if loan.UserID == userID {
+			result = append(result, loan)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockLoanRepository) Update(loan *domain.Loan) error {
+	if _, exists := m.loans[loan.ID]; !exists {
+		return errors.New("loan not found")
+	}
+	m.loans[loan.ID] = loan
+	return nil
+}
+
+func (m *mockLoanRepository) Delete(id string, userID string) error {
+	loan, exists := m.loans[id]
+	if !exists {
+		return errors.New("loan not found")
+	}
+	if loan.UserID != userID {
+		return errors.New("unauthorized access to loan")
+	}
+	delete(m.loans, id)
+	return nil
+}
+
+type mockWalletRepository struct {
+	wallets map[string]*walletdomain.Wallet
+}
+
+func (m *mockWalletRepository) Create(wallet *walletdomain.Wallet) error {
+	return nil
+}
+
+func (m *mockWalletRepository) GetByID(id string, userID string) (*walletdomain.Wallet, error) {
+	wallet, exists := m.wallets[id]
+	if !exists {
+		return nil, errors.New("wallet not found")
+	}
+	if wallet.UserID != userID {
+		return nil, errors.New("unauthorized access to wallet")
+	}
+	return wallet, nil
+}
+
+func (m *mockWalletRepository) List(userID string) ([]*walletdomain.Wallet, error) {
+	return nil, nil
+}
+
+func (m *mockWalletRepository) Update(wallet *walletdomain.Wallet) error {
+	return nil
+}
+
+func (m *mockWalletRepository) Delete(id string, userID string) error {
+	return nil
+}
+
+type mockCategoryRepository struct {
+	categories map[string]*categorydomain.Category
+}
+
+func (m *mockCategoryRepository) Create(category *categorydomain.Category) error {
+	return nil
+}
+
+func (m *mockCategoryRepository) CreateAll(categories []*categorydomain.Category) error {
+	return nil
+}
+
+func (m *mockCategoryRepository) GetByID(id string, userID string) (*categorydomain.Category, error) {
+	category, exists := m.categories[id]
+	if !exists {
+		return nil, errors.New("category not found")
+	}
+	if category.UserID != userID {
+		return nil, errors.New("unauthorized access to category")
+	}
+	return category, nil
+}
+
+func (m *mockCategoryRepository) List(userID string) ([]*categorydomain.Category, error) {
+	return nil, nil
+}
+
+func (m *mockCategoryRepository) Update(category *categorydomain.Category) error {
+	return nil
+}
+
+func (m *mockCategoryRepository) Delete(id string, userID string, strategy categorydomain.DeleteStrategy) error {
+	return nil
+}
+
+func (m *mockCategoryRepository) Merge(targetID string, sourceIDs []string, userID string) error {
+	return nil
+}
+
+type mockContext struct {
+	context.Context
+	userID string
+	hasID  bool
+}
+
+func (m *mockContext) Value(key interface{}) interface{} {
+	if key == middleware.UserIDKey {
+		if m.hasID {
+			return m.userID
+		}
+		return nil
+	}
+	return nil
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func newTestService() (*LoanService, *mockLoanRepository, *transactiontest.Repository) {
+	repo := &mockLoanRepository{loans: make(map[string]*domain.Loan)}
+	wallets := &mockWalletRepository{wallets: map[string]*walletdomain.Wallet{
+		"wallet-usd": walletdomain.NewWallet("wallet-usd", "user1", "Checking", walletdomain.WalletTypeBank, shareddomain.Amount{}, walletdomain.CurrencyUSD, "system"),
+		"wallet-jpy": walletdomain.NewWallet("wallet-jpy", "user1", "Yen", walletdomain.WalletTypeBank, shareddomain.Amount{}, walletdomain.CurrencyJPY, "system"),
+	}}
+	categories := &mockCategoryRepository{categories: map[string]*categorydomain.Category{
+		"cat-debt":     categorydomain.NewCategory("cat-debt", "user1", "Debt", categorydomain.CategoryTypeExpense, "system"),
+		"cat-interest": categorydomain.NewCategory("cat-interest", "user1", "Interest", categorydomain.CategoryTypeIncome, "system"),
+	}}
+	transactions := transactiontest.NewRepository()
+	service := NewLoanService(repo, wallets, categories, transactions, "system")
+	service.now = func() time.Time { return time.Date(2026, 5, 10, 9, 0, 0, 0, time.UTC) }
+	return service, repo, transactions
+}
+
+func carLoanRequest() commands.LoanRequest {
+	return commands.LoanRequest{
+		WalletID:     "wallet-usd",
+		CategoryID:   "cat-debt",
+		Name:         "Car loan",
+		Counterparty: "Bank",
+		Direction:    int(domain.DirectionBorrowed),
+		Principal:    shareddomain.MustParseAmount("12000"),
+		AnnualRate:   shareddomain.MustParseAmount("12"),
+		TermMonths:   12,
+		Method:       string(domain.MethodGerman),
+		StartDate:    date(2026, 1, 15),
+	}
+}
+
+func storeCarLoan(repo *mockLoanRepository) *domain.Loan {
+	loan := domain.NewLoan("loan-1", "user1", "wallet-usd", "cat-debt", "Car loan", "Bank", domain.DirectionBorrowed, shareddomain.MustParseAmount("12000"), shareddomain.MustParseAmount("12"), 12, domain.MethodGerman, date(2026, 1, 15), "system")
+	repo.loans[loan.ID] = loan
+	return loan
+}
+
+func TestLoanService_Create(t *testing.T) {
+	service, repo, transactions := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	if err := service.Create(ctx, carLoanRequest()); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if len(repo.loans) != 1 {
+		t.Fatalf("expected 1 loan, got %d", len(repo.loans))
+	}
+	for _, loan := range repo.loans {
+		if loan.UserID != "user1" || loan.Method != domain.MethodGerman || loan.TermMonths != 12 {
+			t.Errorf("unexpected loan %+v", loan)
+		}
+	}
+	if len(transactions.Created) != 0 {
+		t.Error("creating a loan should not post any transaction")
+	}
+}
+
+func TestLoanService_Create_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(req *commands.LoanRequest)
+		wantErr string
+	}{
+		{"missing name", func(req *commands.LoanRequest) { req.Name = "" }, "loan name is required"},
+		{"invalid direction", func(req *commands.LoanRequest) { req.Direction = 3 }, "invalid loan direction"},
+		{"invalid method", func(req *commands.LoanRequest) { req.Method = "balloon" }, "amortization method"},
+		{"zero principal", func(req *commands.LoanRequest) { req.Principal = shareddomain.Amount{} }, "principal must be greater than zero"},
+		{"negative rate", func(req *commands.LoanRequest) { req.AnnualRate = shareddomain.MustParseAmount("-2") }, "annual rate"},
+		{"term", func(req *commands.LoanRequest) { req.TermMonths = 0 }, "term must be between"},
+		{"unknown wallet", func(req *commands.LoanRequest) { req.WalletID = "missing" }, "wallet not found"},
+		{"precision", func(req *commands.LoanRequest) {
+			req.WalletID = "wallet-jpy"
+			req.Principal = shareddomain.MustParseAmount("1000.5")
+		}, "decimal places"},
+		{"missing category", func(req *commands.LoanRequest) { req.CategoryID = "" }, "category is required"},
+		{"borrowed with income category", func(req *commands.LoanRequest) { req.CategoryID = "cat-interest" }, "category type does not match"},
+		{"lent with expense category", func(req *commands.LoanRequest) { req.Direction = int(domain.DirectionLent) }, "category type does not match"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo, _ := newTestService()
+			ctx := &mockContext{userID: "user1", hasID: true}
+
+			req := carLoanRequest()
+			tt.mutate(&req)
+
+			err := service.Create(ctx, req)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %q", tt.wantErr, err.Error())
+			}
+			if len(repo.loans) != 0 {
+				t.Errorf("expected no loan to be stored, got %d", len(repo.loans))
+			}
+		})
+	}
+}
+
+func TestLoanService_Create_Unauthenticated(t *testing.T) {
+	service, _, _ := newTestService()
+	ctx := &mockContext{hasID: false}
+
+	err := service.Create(ctx, carLoanRequest())
+	if err == nil || !strings.Contains(err.Error(), "user not authenticated") {
+		t.Errorf("expected authentication error, got %v", err)
+	}
+}
+
+func TestLoanService_Update_KeepsTerms(t *testing.T) {
+	service, repo, _ := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+	loan := storeCarLoan(repo)
+
+	req := commands.UpdateLoanRequest{CategoryID: "cat-debt", Name: "Car", Counterparty: "Credit union"}
+	if err := service.Update(ctx, loan.ID, req); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	updated := repo.loans[loan.ID]
+	if updated.Name != "Car" || updated.Counterparty != "Credit union" {
+		t.Errorf("expected labels to be updated, got %+v", updated)
+	}
+	if updated.Principal.String() != "12000" || updated.TermMonths != 12 {
+		t.Error("expected the loan terms to be kept")
+	}
+}
+
+func TestLoanService_Update_Forbidden(t *testing.T) {
+	service, repo, _ := newTestService()
+	ctx := &mockContext{userID: "user2", hasID: true}
+	loan := storeCarLoan(repo)
+
+	err := service.Update(ctx, loan.ID, commands.UpdateLoanRequest{CategoryID: "cat-debt", Name: "Car"})
+	if err == nil || !strings.Contains(err.Error(), "unauthorized access to loan") {
+		t.Errorf("expected unauthorized error, got %v", err)
+	}
+}
+
+func TestLoanService_RecordPayment(t *testing.T) {
+	service, repo, transactions := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+	loan := storeCarLoan(repo)
+
+	if err := service.RecordPayment(ctx, loan.ID, commands.LoanPaymentRequest{Date: date(2026, 2, 15)}); err != nil {
+		t.Fatalf("RecordPayment failed: %v", err)
+	}
+	if err := service.RecordPayment(ctx, loan.ID, commands.LoanPaymentRequest{}); err != nil {
+		t.Fatalf("RecordPayment failed: %v", err)
+	}
+
+	if len(transactions.Created) != 2 {
+		t.Fatalf("expected 2 payments, got %d", len(transactions.Created))
+	}
+
+	first := transactions.Created[0]
+	if first.LoanID != loan.ID || first.LoanInstallment != 1 {
+		t.Errorf("expected first payment to pay installment 1, got %+v", first)
+	}
+	if first.Amount.String() != "1120" || first.Type != transactiondomain.TransactionTypeExpense || first.WalletID != "wallet-usd" || first.CategoryID != "cat-debt" {
+		t.Errorf("unexpected first payment %+v", first)
+	}
+	if first.Description != "Car loan 1/12" {
+		t.Errorf("unexpected description %q", first.Description)
+	}
+
+	second := transactions.Created[1]
+	if second.LoanInstallment != 2 || second.Amount.String() != "1110" {
+		t.Errorf("expected second payment to pay installment 2 for 1110, got %d for %s", second.LoanInstallment, second.Amount)
+	}
+	if !second.Date.Equal(service.now()) {
+		t.Errorf("expected payment without a date to be dated today, got %s", second.Date)
+	}
+}
+
+func TestLoanService_RecordPayment_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		installment int
+		wantErr     error
+	}{
+		{"already paid", 1, transactiondomain.ErrLoanInstallmentPaid},
+		{"out of schedule", 13, domain.ErrInvalidInstallment},
+		{"negative", -1, domain.ErrInvalidInstallment},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo, _ := newTestService()
+			ctx := &mockContext{userID: "user1", hasID: true}
+			loan := storeCarLoan(repo)
+
+			if err := service.RecordPayment(ctx, loan.ID, commands.LoanPaymentRequest{Installment: 1}); err != nil {
+				t.Fatalf("RecordPayment failed: %v", err)
+			}
+
+			err := service.RecordPayment(ctx, loan.ID, commands.LoanPaymentRequest{Installment: tt.installment})
+			if err != tt.wantErr {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLoanService_RecordPayment_PaidOff(t *testing.T) {
+	service, repo, _ := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+	loan := domain.NewLoan("loan-1", "user1", "wallet-usd", "cat-debt", "Advance", "", domain.DirectionBorrowed, shareddomain.MustParseAmount("500"), shareddomain.Amount{}, 1, domain.MethodBullet, date(2026, 1, 1), "system")
+	repo.loans[loan.ID] = loan
+
+	if err := service.RecordPayment(ctx, loan.ID, commands.LoanPaymentRequest{}); err != nil {
+		t.Fatalf("RecordPayment failed: %v", err)
+	}
+	if err := service.RecordPayment(ctx, loan.ID, commands.LoanPaymentRequest{}); err != domain.ErrLoanPaidOff {
+		t.Errorf("expected ErrLoanPaidOff, got %v", err)
+	}
+}
+
+func TestLoanService_Schedule(t *testing.T) {
+	service, repo, _ := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+	loan := storeCarLoan(repo)
+
+	if err := service.RecordPayment(ctx, loan.ID, commands.LoanPaymentRequest{Date: date(2026, 2, 14)}); err != nil {
+		t.Fatalf("RecordPayment failed: %v", err)
+	}
+
+	schedule, err := service.Schedule(ctx, loan.ID)
+	if err != nil {
+		t.Fatalf("Schedule failed: %v", err)
+	}
+
+	if schedule.Currency != "USD" || len(schedule.Installments) != 12 {
+		t.Fatalf("unexpected schedule: currency %s with %d installments", schedule.Currency, len(schedule.Installments))
+	}
+	if schedule.TotalInterest.String() != "780" || schedule.TotalPayment.String() != "12780" {
+		t.Errorf("expected totals 12780 with 780 interest, got %s and %s", schedule.TotalPayment, schedule.TotalInterest)
+	}
+
+	first := schedule.Installments[0]
+	if !first.Paid || first.TransactionID == "" || first.PaidDate == nil || !first.PaidDate.Equal(date(2026, 2, 14)) {
+		t.Errorf("expected first installment to be paid on 2026-02-14, got %+v", first)
+	}
+	if schedule.Installments[1].Paid {
+		t.Error("expected second installment to be unpaid")
+	}
+}
+
+func TestLoanService_Balance(t *testing.T) {
+	service, repo, _ := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+	loan := storeCarLoan(repo)
+
+	for _, payment := range []commands.LoanPaymentRequest{
+		{Installment: 1, Date: date(2026, 2, 15)},
+		{Installment: 2, Date: date(2026, 3, 20)},
+	} {
+		if err := service.RecordPayment(ctx, loan.ID, payment); err != nil {
+			t.Fatalf("RecordPayment failed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name            string
+		date            time.Time
+		wantOutstanding string
+		wantScheduled   string
+		wantInterest    string
+		wantPaid        int
+		wantDue         int
+		wantOverdue     int
+	}{
+		{"before the first installment", date(2026, 2, 1), "12000", "12000", "0", 0, 0, 0},
+		{"second installment late", date(2026, 3, 16), "11000", "10000", "120", 1, 2, 1},
+		{"today", time.Time{}, "10000", "9000", "230", 2, 3, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			balance, err := service.Balance(ctx, loan.ID, tt.date)
+			if err != nil {
+				t.Fatalf("Balance failed: %v", err)
+			}
+
+			if balance.OutstandingBalance.String() != tt.wantOutstanding {
+				t.Errorf("expected outstanding %s, got %s", tt.wantOutstanding, balance.OutstandingBalance)
+			}
+			if balance.ScheduledBalance.String() != tt.wantScheduled {
+				t.Errorf("expected scheduled %s, got %s", tt.wantScheduled, balance.ScheduledBalance)
+			}
+			if balance.InterestPaid.String() != tt.wantInterest {
+				t.Errorf("expected interest paid %s, got %s", tt.wantInterest, balance.InterestPaid)
+			}
+			if balance.InstallmentsPaid != tt.wantPaid || balance.InstallmentsDue != tt.wantDue || balance.InstallmentsOverdue != tt.wantOverdue {
+				t.Errorf("expected %d paid, %d due and %d overdue, got %d, %d and %d", tt.wantPaid, tt.wantDue, tt.wantOverdue, balance.InstallmentsPaid, balance.InstallmentsDue, balance.InstallmentsOverdue)
+			}
+		})
+	}
+}
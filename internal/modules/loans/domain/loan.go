@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	"fin-flow-api/internal/shared/domain"
+)
+
+const MaxTermMonths = 600
+
+var (
+	ErrInvalidDirection   = errors.New("invalid loan direction")
+	ErrInvalidMethod      = errors.New("amortization method must be french, german or bullet")
+	ErrInvalidPrincipal   = errors.New("principal must be greater than zero")
+	ErrInvalidRate        = errors.New("annual rate must not be negative")
+	ErrInvalidTerm        = errors.New("term must be between 1 and 600 months")
+	ErrNameRequired       = errors.New("loan name is required")
+	ErrInvalidInstallment = errors.New("installment does not exist in the loan schedule")
+	ErrLoanPaidOff        = errors.New("every installment of the loan has already been paid")
+)
+
+// Direction tells whether the money was borrowed or lent, and therefore
+// whether installments leave or enter the loan's wallet.
+type Direction int
+
+const (
+	// DirectionBorrowed is a loan the user owes; installments are expenses.
+	DirectionBorrowed Direction = iota
+	// DirectionLent is a loan owed to the user; installments are income.
+	DirectionLent
+)
+
+func (d Direction) String() string {
+	switch d {
+	case DirectionBorrowed:
+		return "Borrowed"
+	case DirectionLent:
+		return "Lent"
+	default:
+		return "Unknown"
+	}
+}
+
+func (d Direction) Value() int {
+	return int(d)
+}
+
+func IsValidDirection(value int) bool {
+	d := Direction(value)
+	return d >= DirectionBorrowed && d <= DirectionLent
+}
+
+// Method is the amortization system that splits the loan into
+// installments.
+type Method string
+
+const (
+	// MethodFrench pays the same amount every month; early installments
+	// are mostly interest.
+	MethodFrench Method = "french"
+	// MethodGerman repays the same principal every month, so installments
+	// shrink as the interest does.
+	MethodGerman Method = "german"
+	// MethodBullet pays only interest every month and the whole principal
+	// with the last installment.
+	MethodBullet Method = "bullet"
+)
+
+func IsValidMethod(method string) bool {
+	switch Method(method) {
+	case MethodFrench, MethodGerman, MethodBullet:
+		return true
+	default:
+		return false
+	}
+}
+
+// Loan is money borrowed from or lent to a counterparty and repaid in
+// monthly installments through WalletID, which also sets its currency.
+// AnnualRate is the nominal yearly rate in percent; the first installment
+// is due one month after StartDate.
+type Loan struct {
+	domain.Entity
+
+	ID           string
+	UserID       string
+	WalletID     string
+	CategoryID   string
+	Name         string
+	Counterparty string
+	Direction    Direction
+	Principal    domain.Amount
+	AnnualRate   domain.Amount
+	TermMonths   int
+	Method       Method
+	StartDate    time.Time
+}
+
+func NewLoan(id, userID, walletID, categoryID, name, counterparty string, direction Direction, principal, annualRate domain.Amount, termMonths int, method Method, startDate time.Time, createdBy string) *Loan {
+	return &Loan{
+		Entity:       domain.NewEntity(id, createdBy),
+		ID:           id,
+		UserID:       userID,
+		WalletID:     walletID,
+		CategoryID:   categoryID,
+		Name:         name,
+		Counterparty: counterparty,
+		Direction:    direction,
+		Principal:    principal,
+		AnnualRate:   annualRate,
+		TermMonths:   termMonths,
+		Method:       method,
+		StartDate:    truncateToDate(startDate),
+	}
+}
+
+// Validate checks the loan terms. Wallet and category are validated by the
+// service, which has access to them.
+func (l *Loan) Validate() error {
+	if strings.TrimSpace(l.Name) == "" {
+		return ErrNameRequired
+	}
+	if !IsValidDirection(l.Direction.Value()) {
+		return ErrInvalidDirection
+	}
+	if !IsValidMethod(string(l.Method)) {
+		return ErrInvalidMethod
+	}
+	if !l.Principal.IsPositive() {
+		return ErrInvalidPrincipal
+	}
+	if l.AnnualRate.IsNegative() {
+		return ErrInvalidRate
+	}
+	if l.TermMonths < 1 || l.TermMonths > MaxTermMonths {
+		return ErrInvalidTerm
+	}
+	return nil
+}
+
+// PaymentType is the type of the transactions that pay the installments.
+func (l *Loan) PaymentType() transactiondomain.TransactionType {
+	if l.Direction == DirectionLent {
+		return transactiondomain.TransactionTypeIncome
+	}
+	return transactiondomain.TransactionTypeExpense
+}
+
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
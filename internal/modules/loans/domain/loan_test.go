@@ -0,0 +1,224 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func newLoan(method Method, principal, rate string, term int) *Loan {
+	return NewLoan(
+		"loan-1",
+		"user-1",
+		"wallet-1",
+		"category-1",
+		"Car loan",
+		"Bank",
+		DirectionBorrowed,
+		shareddomain.MustParseAmount(principal),
+		shareddomain.MustParseAmount(rate),
+		term,
+		method,
+		date(2026, 1, 31),
+		"system",
+	)
+}
+
+func assertScheduleClears(t *testing.T, loan *Loan, schedule []Installment) {
+	t.Helper()
+
+	if len(schedule) != loan.TermMonths {
+		t.Fatalf("expected %d installments, got %d", loan.TermMonths, len(schedule))
+	}
+
+	var principal shareddomain.Amount
+	for _, installment := range schedule {
+		if !installment.Payment.Equal(installment.Principal.Add(installment.Interest)) {
+			t.Errorf("installment %d: payment %s is not principal %s plus interest %s", installment.Number, installment.Payment, installment.Principal, installment.Interest)
+		}
+		if installment.Principal.IsNegative() {
+			t.Errorf("installment %d: negative principal %s", installment.Number, installment.Principal)
+		}
+		principal = principal.Add(installment.Principal)
+	}
+
+	if !principal.Equal(loan.Principal) {
+		t.Errorf("expected installments to repay %s, got %s", loan.Principal, principal)
+	}
+	if !schedule[len(schedule)-1].Balance.IsZero() {
+		t.Errorf("expected last installment to clear the balance, got %s", schedule[len(schedule)-1].Balance)
+	}
+}
+
+func TestLoan_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*Loan)
+		wantErr error
+	}{
+		{"valid", func(l *Loan) {}, nil},
+		{"missing name", func(l *Loan) { l.Name = " " }, ErrNameRequired},
+		{"invalid direction", func(l *Loan) { l.Direction = Direction(5) }, ErrInvalidDirection},
+		{"invalid method", func(l *Loan) { l.Method = "balloon" }, ErrInvalidMethod},
+		{"zero principal", func(l *Loan) { l.Principal = shareddomain.MustParseAmount("0") }, ErrInvalidPrincipal},
+		{"negative rate", func(l *Loan) { l.AnnualRate = shareddomain.MustParseAmount("-1") }, ErrInvalidRate},
+		{"zero term", func(l *Loan) { l.TermMonths = 0 }, ErrInvalidTerm},
+		{"term too long", func(l *Loan) { l.TermMonths = MaxTermMonths + 1 }, ErrInvalidTerm},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loan := newLoan(MethodFrench, "10000", "12", 12)
+			tt.mutate(loan)
+
+			if err := loan.Validate(); err != tt.wantErr {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLoan_PaymentType(t *testing.T) {
+	loan := newLoan(MethodFrench, "10000", "12", 12)
+	if loan.PaymentType() != transactiondomain.TransactionTypeExpense {
+		t.Errorf("expected borrowed loans to be paid with expenses, got %v", loan.PaymentType())
+	}
+
+	loan.Direction = DirectionLent
+	if loan.PaymentType() != transactiondomain.TransactionTypeIncome {
+		t.Errorf("expected lent loans to be paid with income, got %v", loan.PaymentType())
+	}
+}
+
+func TestLoan_Schedule_French(t *testing.T) {
+	loan := newLoan(MethodFrench, "10000", "12", 12)
+	schedule := loan.Schedule("USD")
+
+	assertScheduleClears(t, loan, schedule)
+
+	first := schedule[0]
+	if first.Payment.String() != "888.49" || first.Interest.String() != "100" || first.Principal.String() != "788.49" {
+		t.Errorf("unexpected first installment: payment %s, interest %s, principal %s", first.Payment, first.Interest, first.Principal)
+	}
+	if first.Balance.String() != "9211.51" {
+		t.Errorf("expected balance 9211.51 after the first installment, got %s", first.Balance)
+	}
+
+	for _, installment := range schedule[:len(schedule)-1] {
+		if !installment.Payment.Equal(first.Payment) {
+			t.Errorf("installment %d: expected constant payment %s, got %s", installment.Number, first.Payment, installment.Payment)
+		}
+	}
+	if schedule[1].Interest.Cmp(first.Interest) >= 0 {
+		t.Error("expected interest to decrease as the principal is repaid")
+	}
+}
+
+func TestLoan_Schedule_FrenchWithoutInterest(t *testing.T) {
+	loan := newLoan(MethodFrench, "1000", "0", 3)
+	schedule := loan.Schedule("USD")
+
+	assertScheduleClears(t, loan, schedule)
+
+	want := []string{"333.33", "333.33", "333.34"}
+	for i, installment := range schedule {
+		if installment.Payment.String() != want[i] || !installment.Interest.IsZero() {
+			t.Errorf("installment %d: expected payment %s without interest, got %s and %s", installment.Number, want[i], installment.Payment, installment.Interest)
+		}
+	}
+}
+
+func TestLoan_Schedule_German(t *testing.T) {
+	loan := newLoan(MethodGerman, "12000", "12", 12)
+	schedule := loan.Schedule("USD")
+
+	assertScheduleClears(t, loan, schedule)
+
+	for _, installment := range schedule {
+		if installment.Principal.String() != "1000" {
+			t.Errorf("installment %d: expected principal 1000, got %s", installment.Number, installment.Principal)
+		}
+	}
+	if schedule[0].Payment.String() != "1120" || schedule[11].Payment.String() != "1010" {
+		t.Errorf("expected payments to go from 1120 down to 1010, got %s and %s", schedule[0].Payment, schedule[11].Payment)
+	}
+}
+
+func TestLoan_Schedule_Bullet(t *testing.T) {
+	loan := newLoan(MethodBullet, "5000", "6", 4)
+	schedule := loan.Schedule("USD")
+
+	assertScheduleClears(t, loan, schedule)
+
+	for _, installment := range schedule[:3] {
+		if installment.Payment.String() != "25" || !installment.Principal.IsZero() {
+			t.Errorf("installment %d: expected interest-only payment of 25, got %s", installment.Number, installment.Payment)
+		}
+	}
+	if schedule[3].Payment.String() != "5025" {
+		t.Errorf("expected last installment to repay the principal, got %s", schedule[3].Payment)
+	}
+}
+
+func TestLoan_Schedule_RoundsToCurrency(t *testing.T) {
+	loan := newLoan(MethodFrench, "100000", "7.5", 6)
+	schedule := loan.Schedule("JPY")
+
+	assertScheduleClears(t, loan, schedule)
+
+	for _, installment := range schedule {
+		if !installment.Payment.FitsCurrency("JPY") {
+			t.Errorf("installment %d: payment %s has decimals in JPY", installment.Number, installment.Payment)
+		}
+	}
+}
+
+func TestLoan_DueDate(t *testing.T) {
+	loan := newLoan(MethodFrench, "1000", "12", 12)
+
+	tests := []struct {
+		number int
+		want   time.Time
+	}{
+		{1, date(2026, 2, 28)},
+		{2, date(2026, 3, 31)},
+		{3, date(2026, 4, 30)},
+		{12, date(2027, 1, 31)},
+	}
+
+	for _, tt := range tests {
+		if got := loan.DueDate(tt.number); !got.Equal(tt.want) {
+			t.Errorf("installment %d: expected due date %s, got %s", tt.number, tt.want.Format("2006-01-02"), got.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestScheduledBalance(t *testing.T) {
+	loan := newLoan(MethodGerman, "12000", "12", 12)
+	schedule := loan.Schedule("USD")
+
+	tests := []struct {
+		name string
+		date time.Time
+		want string
+	}{
+		{"before the first installment", date(2026, 2, 27), "12000"},
+		{"on the first due date", date(2026, 2, 28), "11000"},
+		{"between installments", date(2026, 4, 15), "10000"},
+		{"after the last installment", date(2030, 1, 1), "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ScheduledBalance(schedule, loan.Principal, tt.date); got.String() != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package domain
+
+type LoanRepository interface {
+	Create(loan *Loan) error
+	GetByID(id string, userID string) (*Loan, error)
+	List(userID string) ([]*Loan, error)
+	Update(loan *Loan) error
+	Delete(id string, userID string) error
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+// rateScale is the precision kept for the monthly rate and the compound
+// factor before amounts are rounded to the currency.
+const rateScale = 18
+
+// Installment is one monthly payment of a loan. Payment is Principal plus
+// Interest, and Balance is the principal still owed once it is paid.
+type Installment struct {
+	Number    int
+	DueDate   time.Time
+	Payment   domain.Amount
+	Principal domain.Amount
+	Interest  domain.Amount
+	Balance   domain.Amount
+}
+
+// Schedule splits the loan into its monthly installments. Interest is
+// charged every month on the outstanding principal at a twelfth of the
+// annual rate and every amount is rounded to the currency, so the last
+// installment absorbs the rounding and always clears the balance.
+func (l *Loan) Schedule(currency string) []Installment {
+	rate := l.monthlyRate()
+	principalPart := l.principalPart(rate, currency)
+
+	schedule := make([]Installment, 0, l.TermMonths)
+	balance := l.Principal
+	for number := 1; number <= l.TermMonths; number++ {
+		interest := balance.Mul(rate).RoundToCurrency(currency)
+
+		var principal domain.Amount
+		switch {
+		case number == l.TermMonths:
+			principal = balance
+		case l.Method == MethodFrench:
+			principal = principalPart.Sub(interest)
+		case l.Method == MethodGerman:
+			principal = principalPart
+		}
+		if principal.Cmp(balance) > 0 {
+			principal = balance
+		}
+
+		balance = balance.Sub(principal)
+		schedule = append(schedule, Installment{
+			Number:    number,
+			DueDate:   l.DueDate(number),
+			Payment:   principal.Add(interest),
+			Principal: principal,
+			Interest:  interest,
+			Balance:   balance,
+		})
+	}
+
+	return schedule
+}
+
+// DueDate is the date installment number is due: that many months after
+// the start date, on the same day or the last day of shorter months.
+func (l *Loan) DueDate(number int) time.Time {
+	start := l.StartDate
+	firstOfMonth := time.Date(start.Year(), start.Month()+time.Month(number), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	day := start.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
+
+// ScheduledBalance is the principal the schedule says is still owed on
+// date, after every installment due on or before it.
+func ScheduledBalance(schedule []Installment, principal domain.Amount, date time.Time) domain.Amount {
+	balance := principal
+	for _, installment := range schedule {
+		if installment.DueDate.After(date) {
+			break
+		}
+		balance = installment.Balance
+	}
+	return balance
+}
+
+func (l *Loan) monthlyRate() domain.Amount {
+	return l.AnnualRate.DivRound(domain.NewAmountFromInt(1200), rateScale)
+}
+
+// principalPart is what the method pays every month before the last
+// installment: the whole payment for French loans, which the interest
+// then eats into, and the principal share for German loans. Bullet loans
+// repay no principal until the end.
+func (l *Loan) principalPart(rate domain.Amount, currency string) domain.Amount {
+	term := domain.NewAmountFromInt(int64(l.TermMonths))
+
+	switch l.Method {
+	case MethodFrench:
+		if rate.IsZero() {
+			return l.Principal.DivRound(term, rateScale).RoundToCurrency(currency)
+		}
+		one := domain.NewAmountFromInt(1)
+		factor := one
+		growth := one.Add(rate)
+		for i := 0; i < l.TermMonths; i++ {
+			factor = factor.Mul(growth).Round(rateScale)
+		}
+		return l.Principal.Mul(rate).Mul(factor).DivRound(factor.Sub(one), rateScale).RoundToCurrency(currency)
+	case MethodGerman:
+		return l.Principal.DivRound(term, rateScale).RoundToCurrency(currency)
+	default:
+		return domain.Amount{}
+	}
+}
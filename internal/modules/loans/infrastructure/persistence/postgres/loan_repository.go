@@ -0,0 +1,213 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"fin-flow-api/internal/modules/loans/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const loanColumns = `id, user_id, wallet_id, category_id, name, counterparty, direction, principal, annual_rate, term_months, method, start_date, created_at, modified_at, created_by, modified_by`
+
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+func (r *Repository) Create(loan *domain.Loan) error {
+	query := `
+		INSERT INTO loans (` + loanColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+	`
+
+	_, err := r.pool.Exec(
+		context.Background(),
+		query,
+		loan.ID,
+		loan.UserID,
+		loan.WalletID,
+		loan.CategoryID,
+		loan.Name,
+		loan.Counterparty,
+		loan.Direction.Value(),
+		loan.Principal,
+		loan.AnnualRate,
+		loan.TermMonths,
+		string(loan.Method),
+		loan.StartDate,
+		loan.CreatedAt,
+		loan.ModifiedAt,
+		loan.CreatedBy,
+		loan.ModifiedBy,
+	)
+	if err != nil {
+		return mapWriteError("failed to create loan", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetByID(id string, userID string) (*domain.Loan, error) {
+	checkQuery := `SELECT user_id FROM loans WHERE id = $1`
+	var loanUserID string
+	err := r.pool.QueryRow(context.Background(), checkQuery, id).Scan(&loanUserID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("loan not found")
+		}
+		return nil, fmt.Errorf("failed to get loan: %w", err)
+	}
+
+	if loanUserID != userID {
+		return nil, fmt.Errorf("unauthorized access to loan")
+	}
+
+	query := `SELECT ` + loanColumns + ` FROM loans WHERE id = $1 AND user_id = $2`
+
+	loan, err := scanLoan(r.pool.QueryRow(context.Background(), query, id, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("loan not found")
+		}
+		return nil, fmt.Errorf("failed to get loan: %w", err)
+	}
+
+	return loan, nil
+}
+
+func (r *Repository) List(userID string) ([]*domain.Loan, error) {
+	query := `SELECT ` + loanColumns + ` FROM loans WHERE user_id = $1 ORDER BY start_date DESC, created_at DESC`
+
+	rows, err := r.pool.Query(context.Background(), query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list loans: %w", err)
+	}
+	defer rows.Close()
+
+	var loans []*domain.Loan
+	for rows.Next() {
+		loan, err := scanLoan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan loan: %w", err)
+		}
+		loans = append(loans, loan)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate loans: %w", err)
+	}
+
+	return loans, nil
+}
+
+// Update stores the descriptive fields of a loan. The terms are fixed once
+// the loan is created because installments may already have been paid
+// against its schedule.
+func (r *Repository) Update(loan *domain.Loan) error {
+	query := `
+		UPDATE loans
+		SET category_id = $2, name = $3, counterparty = $4, modified_at = $5, modified_by = $6
+		WHERE id = $1 AND user_id = $7
+	`
+
+	result, err := r.pool.Exec(
+		context.Background(),
+		query,
+		loan.ID,
+		loan.CategoryID,
+		loan.Name,
+		loan.Counterparty,
+		loan.ModifiedAt,
+		loan.ModifiedBy,
+		loan.UserID,
+	)
+	if err != nil {
+		return mapWriteError("failed to update loan", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("loan not found")
+	}
+
+	return nil
+}
+
+func (r *Repository) Delete(id string, userID string) error {
+	checkQuery := `SELECT user_id FROM loans WHERE id = $1`
+	var loanUserID string
+	err := r.pool.QueryRow(context.Background(), checkQuery, id).Scan(&loanUserID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("loan not found")
+		}
+		return fmt.Errorf("failed to delete loan: %w", err)
+	}
+
+	if loanUserID != userID {
+		return fmt.Errorf("unauthorized access to loan")
+	}
+
+	// Installment payments stay in place as plain transactions; their
+	// loan_id is cleared by the foreign key.
+	query := `DELETE FROM loans WHERE id = $1 AND user_id = $2`
+
+	if _, err := r.pool.Exec(context.Background(), query, id, userID); err != nil {
+		return fmt.Errorf("failed to delete loan: %w", err)
+	}
+
+	return nil
+}
+
+func scanLoan(row pgx.Row) (*domain.Loan, error) {
+	var loan domain.Loan
+	var directionValue int
+	var method string
+
+	err := row.Scan(
+		&loan.ID,
+		&loan.UserID,
+		&loan.WalletID,
+		&loan.CategoryID,
+		&loan.Name,
+		&loan.Counterparty,
+		&directionValue,
+		&loan.Principal,
+		&loan.AnnualRate,
+		&loan.TermMonths,
+		&method,
+		&loan.StartDate,
+		&loan.CreatedAt,
+		&loan.ModifiedAt,
+		&loan.CreatedBy,
+		&loan.ModifiedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	loan.Direction = domain.Direction(directionValue)
+	loan.Method = domain.Method(method)
+
+	return &loan, nil
+}
+
+func mapWriteError(message string, err error) error {
+	if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" { // foreign_key_violation
+		if strings.Contains(pgErr.ConstraintName, "category") {
+			return fmt.Errorf("invalid category reference")
+		}
+		return fmt.Errorf("invalid wallet reference")
+	}
+	return fmt.Errorf("%s: %w", message, err)
+}
@@ -0,0 +1,494 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"fin-flow-api/internal/modules/loans/application/contracts/commands"
+	"fin-flow-api/internal/modules/loans/application/contracts/queries"
+	"fin-flow-api/internal/modules/loans/domain"
+	basehandler "fin-flow-api/internal/shared/http"
+)
+
+const dateLayout = "2006-01-02"
+
+type loanService interface {
+	Create(ctx context.Context, req commands.LoanRequest) error
+	GetByID(ctx context.Context, id string) (*queries.LoanResponse, error)
+	Update(ctx context.Context, id string, req commands.UpdateLoanRequest) error
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context) ([]*queries.LoanResponse, error)
+	Schedule(ctx context.Context, id string) (*queries.LoanScheduleResponse, error)
+	RecordPayment(ctx context.Context, id string, req commands.LoanPaymentRequest) error
+	Balance(ctx context.Context, id string, date time.Time) (*queries.LoanBalanceResponse, error)
+}
+
+type Handler struct {
+	loanService loanService
+}
+
+func NewHandler(loanService loanService) *Handler {
+	return &Handler{
+		loanService: loanService,
+	}
+}
+
+func (h *Handler) CreateLoan(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var reqDTO LoanRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	cmd, err := toLoanCommand(reqDTO)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.loanService.Create(r.Context(), cmd); err != nil {
+		statusCode, errorMsg := loanErrorResponse(err, "create")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Loan created successfully")
+}
+
+func (h *Handler) GetLoan(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id := loanIDFromPath(r.URL.Path)
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Loan ID is required in the URL path")
+		return
+	}
+
+	loan, err := h.loanService.GetByID(r.Context(), id)
+	if err != nil {
+		statusCode, errorMsg := loanErrorResponse(err, "access")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, toLoanResponse(loan))
+}
+
+func (h *Handler) UpdateLoan(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id := loanIDFromPath(r.URL.Path)
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Loan ID is required in the URL path")
+		return
+	}
+
+	var reqDTO UpdateLoanRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	if err := validateUpdateLoanRequest(reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	cmd := commands.UpdateLoanRequest{
+		CategoryID:   strings.TrimSpace(reqDTO.CategoryID),
+		Name:         strings.TrimSpace(reqDTO.Name),
+		Counterparty: strings.TrimSpace(reqDTO.Counterparty),
+	}
+
+	if err := h.loanService.Update(r.Context(), id, cmd); err != nil {
+		statusCode, errorMsg := loanErrorResponse(err, "update")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Loan updated successfully")
+}
+
+func (h *Handler) DeleteLoan(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id := loanIDFromPath(r.URL.Path)
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Loan ID is required in the URL path")
+		return
+	}
+
+	if err := h.loanService.Delete(r.Context(), id); err != nil {
+		statusCode, errorMsg := loanErrorResponse(err, "delete")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Loan deleted successfully")
+}
+
+func (h *Handler) ListLoans(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	loans, err := h.loanService.List(r.Context())
+	if err != nil {
+		statusCode, errorMsg := loanErrorResponse(err, "access")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	responses := make([]LoanResponse, len(loans))
+	for i, loan := range loans {
+		responses[i] = toLoanResponse(loan)
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, responses)
+}
+
+func (h *Handler) GetSchedule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id := loanIDFromPath(r.URL.Path)
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Loan ID is required in the URL path")
+		return
+	}
+
+	schedule, err := h.loanService.Schedule(r.Context(), id)
+	if err != nil {
+		statusCode, errorMsg := loanErrorResponse(err, "access")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, toLoanScheduleResponse(schedule))
+}
+
+func (h *Handler) RecordPayment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id := loanIDFromPath(r.URL.Path)
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Loan ID is required in the URL path")
+		return
+	}
+
+	var reqDTO LoanPaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	if reqDTO.Installment < 0 {
+		basehandler.WriteError(w, http.StatusBadRequest, "Installment must be a positive number")
+		return
+	}
+
+	date, err := parseOptionalDate(reqDTO.Date, "date")
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	cmd := commands.LoanPaymentRequest{Installment: reqDTO.Installment, Date: date}
+	if err := h.loanService.RecordPayment(r.Context(), id, cmd); err != nil {
+		statusCode, errorMsg := loanErrorResponse(err, "pay")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Loan payment recorded successfully")
+}
+
+func (h *Handler) GetBalance(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id := loanIDFromPath(r.URL.Path)
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Loan ID is required in the URL path")
+		return
+	}
+
+	date, err := parseOptionalDate(r.URL.Query().Get("date"), "date")
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	balance, err := h.loanService.Balance(r.Context(), id, date)
+	if err != nil {
+		statusCode, errorMsg := loanErrorResponse(err, "access")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, toLoanBalanceResponse(balance))
+}
+
+func loanIDFromPath(path string) string {
+	path = strings.TrimPrefix(path, "/loans/")
+	return strings.Split(path, "/")[0]
+}
+
+func parseOptionalDate(value, field string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, &ValidationError{Field: field, Message: "Date must use the YYYY-MM-DD format"}
+	}
+	return date, nil
+}
+
+func toLoanCommand(req LoanRequest) (commands.LoanRequest, error) {
+	if err := validateLoanRequest(req); err != nil {
+		return commands.LoanRequest{}, err
+	}
+
+	startDate, err := time.Parse(dateLayout, req.StartDate)
+	if err != nil {
+		return commands.LoanRequest{}, &ValidationError{Field: "start_date", Message: "Start date must use the YYYY-MM-DD format"}
+	}
+
+	return commands.LoanRequest{
+		WalletID:     strings.TrimSpace(req.WalletID),
+		CategoryID:   strings.TrimSpace(req.CategoryID),
+		Name:         strings.TrimSpace(req.Name),
+		Counterparty: strings.TrimSpace(req.Counterparty),
+		Direction:    *req.Direction,
+		Principal:    *req.Principal,
+		AnnualRate:   *req.AnnualRate,
+		TermMonths:   *req.TermMonths,
+		Method:       strings.ToLower(strings.TrimSpace(req.Method)),
+		StartDate:    startDate,
+	}, nil
+}
+
+func validateLoanRequest(req LoanRequest) error {
+	if strings.TrimSpace(req.WalletID) == "" {
+		return &ValidationError{Field: "wallet_id", Message: "Wallet ID is required"}
+	}
+
+	if strings.TrimSpace(req.CategoryID) == "" {
+		return &ValidationError{Field: "category_id", Message: "Category ID is required"}
+	}
+
+	if err := validateLoanLabels(req.Name, req.Counterparty); err != nil {
+		return err
+	}
+
+	if req.Direction == nil {
+		return &ValidationError{Field: "direction", Message: "Direction is required"}
+	}
+
+	if !domain.IsValidDirection(*req.Direction) {
+		return &ValidationError{Field: "direction", Message: directionMessage}
+	}
+
+	if req.Principal == nil {
+		return &ValidationError{Field: "principal", Message: "Principal is required"}
+	}
+
+	if !req.Principal.IsPositive() {
+		return &ValidationError{Field: "principal", Message: "Principal must be greater than zero"}
+	}
+
+	if req.AnnualRate == nil {
+		return &ValidationError{Field: "annual_rate", Message: "Annual rate is required"}
+	}
+
+	if req.AnnualRate.IsNegative() {
+		return &ValidationError{Field: "annual_rate", Message: "Annual rate must not be negative"}
+	}
+
+	if req.TermMonths == nil {
+		return &ValidationError{Field: "term_months", Message: "Term is required"}
+	}
+
+	if *req.TermMonths < 1 || *req.TermMonths > domain.MaxTermMonths {
+		return &ValidationError{Field: "term_months", Message: "Term must be between 1 and 600 months"}
+	}
+
+	if !domain.IsValidMethod(strings.ToLower(strings.TrimSpace(req.Method))) {
+		return &ValidationError{Field: "method", Message: methodMessage}
+	}
+
+	if req.StartDate == "" {
+		return &ValidationError{Field: "start_date", Message: "Start date is required"}
+	}
+
+	return nil
+}
+
+func validateUpdateLoanRequest(req UpdateLoanRequest) error {
+	if strings.TrimSpace(req.CategoryID) == "" {
+		return &ValidationError{Field: "category_id", Message: "Category ID is required"}
+	}
+
+	return validateLoanLabels(req.Name, req.Counterparty)
+}
+
+func validateLoanLabels(name, counterparty string) error {
+	if strings.TrimSpace(name) == "" {
+		return &ValidationError{Field: "name", Message: "Name is required"}
+	}
+
+	if len(name) > 255 {
+		return &ValidationError{Field: "name", Message: "Name must not exceed 255 characters"}
+	}
+
+	if len(counterparty) > 255 {
+		return &ValidationError{Field: "counterparty", Message: "Counterparty must not exceed 255 characters"}
+	}
+
+	return nil
+}
+
+const (
+	directionMessage = "Direction must be 0 (Borrowed) or 1 (Lent)"
+	methodMessage    = "Method must be french, german or bullet"
+)
+
+func loanErrorResponse(err error, action string) (int, string) {
+	errorMsg := err.Error()
+
+	switch {
+	case strings.Contains(errorMsg, "user not authenticated"):
+		return http.StatusUnauthorized, "Authentication required"
+	case strings.Contains(errorMsg, "unauthorized access to loan"):
+		return http.StatusForbidden, "You do not have permission to " + action + " this loan"
+	case strings.Contains(errorMsg, "unauthorized access to wallet"):
+		return http.StatusForbidden, "You do not have permission to use this wallet"
+	case strings.Contains(errorMsg, "unauthorized access to category"):
+		return http.StatusForbidden, "You do not have permission to use this category"
+	case strings.Contains(errorMsg, "loan not found"):
+		return http.StatusNotFound, "Loan not found"
+	case strings.Contains(errorMsg, "wallet not found"), strings.Contains(errorMsg, "invalid wallet reference"):
+		return http.StatusBadRequest, "Wallet not found"
+	case strings.Contains(errorMsg, "category not found"), strings.Contains(errorMsg, "invalid category reference"):
+		return http.StatusBadRequest, "Category not found"
+	case strings.Contains(errorMsg, "invalid loan direction"):
+		return http.StatusBadRequest, directionMessage
+	case strings.Contains(errorMsg, "decimal places"):
+		return http.StatusBadRequest, "Principal has more decimal places than the wallet currency allows"
+	case strings.Contains(errorMsg, "has already been paid"):
+		return http.StatusConflict, errorMsg
+	case strings.Contains(errorMsg, "amortization method"),
+		strings.Contains(errorMsg, "principal must be"),
+		strings.Contains(errorMsg, "annual rate"),
+		strings.Contains(errorMsg, "term must be"),
+		strings.Contains(errorMsg, "loan name is required"),
+		strings.Contains(errorMsg, "installment does not exist"),
+		strings.Contains(errorMsg, "category is required"),
+		strings.Contains(errorMsg, "category type does not match"):
+		return http.StatusBadRequest, errorMsg
+	}
+
+	return http.StatusInternalServerError, errorMsg
+}
+
+func toLoanResponse(loan *queries.LoanResponse) LoanResponse {
+	return LoanResponse{
+		ID:            loan.ID,
+		WalletID:      loan.WalletID,
+		CategoryID:    loan.CategoryID,
+		Name:          loan.Name,
+		Counterparty:  loan.Counterparty,
+		Direction:     loan.Direction,
+		DirectionName: loan.DirectionName,
+		Principal:     loan.Principal,
+		AnnualRate:    loan.AnnualRate,
+		TermMonths:    loan.TermMonths,
+		Method:        loan.Method,
+		StartDate:     loan.StartDate.Format(dateLayout),
+		CreatedAt:     loan.CreatedAt,
+		UpdatedAt:     loan.UpdatedAt,
+		CreatedBy:     loan.CreatedBy,
+		UpdatedBy:     loan.UpdatedBy,
+	}
+}
+
+func toLoanScheduleResponse(schedule *queries.LoanScheduleResponse) LoanScheduleResponse {
+	installments := make([]LoanInstallmentResponse, len(schedule.Installments))
+	for i, installment := range schedule.Installments {
+		installments[i] = LoanInstallmentResponse{
+			Number:        installment.Number,
+			DueDate:       installment.DueDate.Format(dateLayout),
+			Payment:       installment.Payment,
+			Principal:     installment.Principal,
+			Interest:      installment.Interest,
+			Balance:       installment.Balance,
+			Paid:          installment.Paid,
+			TransactionID: installment.TransactionID,
+		}
+		if installment.PaidDate != nil {
+			installments[i].PaidDate = installment.PaidDate.Format(dateLayout)
+		}
+	}
+
+	return LoanScheduleResponse{
+		LoanID:        schedule.LoanID,
+		Currency:      schedule.Currency,
+		Method:        schedule.Method,
+		TotalPayment:  schedule.TotalPayment,
+		TotalInterest: schedule.TotalInterest,
+		Installments:  installments,
+	}
+}
+
+func toLoanBalanceResponse(balance *queries.LoanBalanceResponse) LoanBalanceResponse {
+	return LoanBalanceResponse{
+		LoanID:              balance.LoanID,
+		Date:                balance.Date.Format(dateLayout),
+		Currency:            balance.Currency,
+		Principal:           balance.Principal,
+		ScheduledBalance:    balance.ScheduledBalance,
+		OutstandingBalance:  balance.OutstandingBalance,
+		PrincipalPaid:       balance.PrincipalPaid,
+		InterestPaid:        balance.InterestPaid,
+		InstallmentsPaid:    balance.InstallmentsPaid,
+		InstallmentsDue:     balance.InstallmentsDue,
+		InstallmentsOverdue: balance.InstallmentsOverdue,
+	}
+}
+
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
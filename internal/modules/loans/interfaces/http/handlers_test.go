@@ -0,0 +1,442 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"fin-flow-api/internal/modules/loans/application/contracts/commands"
+	"fin-flow-api/internal/modules/loans/application/contracts/queries"
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+type mockLoanService struct {
+	createErr   error
+	getByIDErr  error
+	updateErr   error
+	deleteErr   error
+	listErr     error
+	scheduleErr error
+	paymentErr  error
+	balanceErr  error
+	loan        *queries.LoanResponse
+	loans       []*queries.LoanResponse
+	schedule    *queries.LoanScheduleResponse
+	balance     *queries.LoanBalanceResponse
+	lastCommand commands.LoanRequest
+	lastUpdate  commands.UpdateLoanRequest
+	lastPayment commands.LoanPaymentRequest
+	lastID      string
+	lastDate    time.Time
+}
+
+func (m *mockLoanService) Create(ctx context.Context, req commands.LoanRequest) error {
+	m.lastCommand = req
+	return m.createErr
+}
+
+func (m *mockLoanService) GetByID(ctx context.Context, id string) (*queries.LoanResponse, error) {
+	m.lastID = id
+	if m.getByIDErr != nil {
+		return nil, m.getByIDErr
+	}
+	return m.loan, nil
+}
+
+func (m *mockLoanService) Update(ctx context.Context, id string, req commands.UpdateLoanRequest) error {
+	m.lastID = id
+	m.lastUpdate = req
+	return m.updateErr
+}
+
+func (m *mockLoanService) Delete(ctx context.Context, id string) error {
+	m.lastID = id
+	return m.deleteErr
+}
+
+func (m *mockLoanService) List(ctx context.Context) ([]*queries.LoanResponse, error) {
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	return m.loans, nil
+}
+
+func (m *mockLoanService) Schedule(ctx context.Context, id string) (*queries.LoanScheduleResponse, error) {
+	m.lastID = id
+	if m.scheduleErr != nil {
+		return nil, m.scheduleErr
+	}
+	return m.schedule, nil
+}
+
+func (m *mockLoanService) RecordPayment(ctx context.Context, id string, req commands.LoanPaymentRequest) error {
+	m.lastID = id
+	m.lastPayment = req
+	return m.paymentErr
+}
+
+func (m *mockLoanService) Balance(ctx context.Context, id string, date time.Time) (*queries.LoanBalanceResponse, error) {
+	m.lastID = id
+	m.lastDate = date
+	if m.balanceErr != nil {
+		return nil, m.balanceErr
+	}
+	return m.balance, nil
+}
+
+func validLoanBody() LoanRequest {
+	return LoanRequest{
+		WalletID:     "wallet1",
+		CategoryID:   "category1",
+		Name:         "Mortgage",
+		Counterparty: "Bank",
+		Direction:    intPtr(0),
+		Principal:    amountPtr("150000"),
+		AnnualRate:   amountPtr("4.5"),
+		TermMonths:   intPtr(240),
+		Method:       "French",
+		StartDate:    "2026-01-15",
+	}
+}
+
+func sampleLoan() *queries.LoanResponse {
+	return &queries.LoanResponse{
+		ID:            "loan1",
+		WalletID:      "wallet1",
+		CategoryID:    "category1",
+		Name:          "Mortgage",
+		DirectionName: "Borrowed",
+		Principal:     shareddomain.MustParseAmount("150000"),
+		AnnualRate:    shareddomain.MustParseAmount("4.5"),
+		TermMonths:    240,
+		Method:        "french",
+		StartDate:     time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestCreateLoan_Success(t *testing.T) {
+	service := &mockLoanService{}
+	handler := &Handler{loanService: service}
+
+	jsonBody, _ := json.Marshal(validLoanBody())
+
+	req := httptest.NewRequest("POST", "/loans", bytes.NewBuffer(jsonBody))
+	rr := httptest.NewRecorder()
+	handler.CreateLoan(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if service.lastCommand.Method != "french" {
+		t.Errorf("expected method to be normalized to french, got %s", service.lastCommand.Method)
+	}
+	if service.lastCommand.AnnualRate.String() != "4.5" || service.lastCommand.TermMonths != 240 {
+		t.Errorf("unexpected command %+v", service.lastCommand)
+	}
+	if !service.lastCommand.StartDate.Equal(time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected start date %v", service.lastCommand.StartDate)
+	}
+}
+
+func TestCreateLoan_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*LoanRequest)
+	}{
+		{"missing wallet", func(r *LoanRequest) { r.WalletID = "" }},
+		{"missing category", func(r *LoanRequest) { r.CategoryID = "" }},
+		{"missing name", func(r *LoanRequest) { r.Name = " " }},
+		{"missing direction", func(r *LoanRequest) { r.Direction = nil }},
+		{"invalid direction", func(r *LoanRequest) { r.Direction = intPtr(2) }},
+		{"missing principal", func(r *LoanRequest) { r.Principal = nil }},
+		{"zero principal", func(r *LoanRequest) { r.Principal = amountPtr("0") }},
+		{"missing rate", func(r *LoanRequest) { r.AnnualRate = nil }},
+		{"negative rate", func(r *LoanRequest) { r.AnnualRate = amountPtr("-1") }},
+		{"missing term", func(r *LoanRequest) { r.TermMonths = nil }},
+		{"term too long", func(r *LoanRequest) { r.TermMonths = intPtr(601) }},
+		{"invalid method", func(r *LoanRequest) { r.Method = "balloon" }},
+		{"missing start date", func(r *LoanRequest) { r.StartDate = "" }},
+		{"invalid start date", func(r *LoanRequest) { r.StartDate = "15/01/2026" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := validLoanBody()
+			tt.mutate(&body)
+
+			if _, err := toLoanCommand(body); err == nil {
+				t.Error("expected validation error, got nil")
+			}
+		})
+	}
+}
+
+func TestCreateLoan_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"unauthenticated", errors.New("user not authenticated"), http.StatusUnauthorized},
+		{"foreign wallet", errors.New("unauthorized access to wallet"), http.StatusForbidden},
+		{"missing wallet", errors.New("wallet not found"), http.StatusBadRequest},
+		{"category mismatch", errors.New("category type does not match transaction type"), http.StatusBadRequest},
+		{"precision", shareddomain.ErrAmountPrecision, http.StatusBadRequest},
+		{"unexpected", errors.New("boom"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &Handler{loanService: &mockLoanService{createErr: tt.err}}
+
+			jsonBody, _ := json.Marshal(validLoanBody())
+			req := httptest.NewRequest("POST", "/loans", bytes.NewBuffer(jsonBody))
+			rr := httptest.NewRecorder()
+			handler.CreateLoan(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestGetLoan_Success(t *testing.T) {
+	service := &mockLoanService{loan: sampleLoan()}
+	handler := &Handler{loanService: service}
+
+	req := httptest.NewRequest("GET", "/loans/loan1", nil)
+	rr := httptest.NewRecorder()
+	handler.GetLoan(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if service.lastID != "loan1" {
+		t.Errorf("expected id loan1, got %s", service.lastID)
+	}
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response["start_date"] != "2026-01-15" || response["annual_rate"] != "4.5" {
+		t.Errorf("unexpected response: %v", response)
+	}
+}
+
+func TestGetLoan_NotFound(t *testing.T) {
+	handler := &Handler{loanService: &mockLoanService{getByIDErr: errors.New("loan not found")}}
+
+	req := httptest.NewRequest("GET", "/loans/missing", nil)
+	rr := httptest.NewRecorder()
+	handler.GetLoan(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", rr.Code)
+	}
+}
+
+func TestUpdateLoan_Success(t *testing.T) {
+	service := &mockLoanService{}
+	handler := &Handler{loanService: service}
+
+	jsonBody, _ := json.Marshal(UpdateLoanRequest{CategoryID: "category2", Name: " Home ", Counterparty: "Bank"})
+	req := httptest.NewRequest("PUT", "/loans/loan1", bytes.NewBuffer(jsonBody))
+	rr := httptest.NewRecorder()
+	handler.UpdateLoan(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if service.lastID != "loan1" || service.lastUpdate.Name != "Home" || service.lastUpdate.CategoryID != "category2" {
+		t.Errorf("unexpected update %s %+v", service.lastID, service.lastUpdate)
+	}
+}
+
+func TestUpdateLoan_MissingName(t *testing.T) {
+	handler := &Handler{loanService: &mockLoanService{}}
+
+	jsonBody, _ := json.Marshal(UpdateLoanRequest{CategoryID: "category2"})
+	req := httptest.NewRequest("PUT", "/loans/loan1", bytes.NewBuffer(jsonBody))
+	rr := httptest.NewRecorder()
+	handler.UpdateLoan(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rr.Code)
+	}
+}
+
+func TestDeleteLoan_Forbidden(t *testing.T) {
+	handler := &Handler{loanService: &mockLoanService{deleteErr: errors.New("unauthorized access to loan")}}
+
+	req := httptest.NewRequest("DELETE", "/loans/loan1", nil)
+	rr := httptest.NewRecorder()
+	handler.DeleteLoan(rr, req)
+
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d", rr.Code)
+	}
+}
+
+func TestListLoans_Success(t *testing.T) {
+	handler := &Handler{loanService: &mockLoanService{loans: []*queries.LoanResponse{sampleLoan()}}}
+
+	req := httptest.NewRequest("GET", "/loans", nil)
+	rr := httptest.NewRecorder()
+	handler.ListLoans(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+
+	var response []LoanResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response) != 1 {
+		t.Errorf("expected 1 loan, got %d", len(response))
+	}
+}
+
+func TestGetSchedule_Success(t *testing.T) {
+	paid := time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC)
+	service := &mockLoanService{schedule: &queries.LoanScheduleResponse{
+		LoanID:   "loan1",
+		Currency: "USD",
+		Method:   "german",
+		Installments: []*queries.LoanInstallmentResponse{
+			{Number: 1, DueDate: time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC), Payment: shareddomain.MustParseAmount("1120"), Paid: true, TransactionID: "tx1", PaidDate: &paid},
+			{Number: 2, DueDate: time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), Payment: shareddomain.MustParseAmount("1110")},
+		},
+	}}
+	handler := &Handler{loanService: service}
+
+	req := httptest.NewRequest("GET", "/loans/loan1/schedule", nil)
+	rr := httptest.NewRecorder()
+	handler.GetSchedule(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if service.lastID != "loan1" {
+		t.Errorf("expected id loan1, got %s", service.lastID)
+	}
+
+	var response LoanScheduleResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Installments) != 2 || response.Installments[0].PaidDate != "2026-02-14" || response.Installments[1].PaidDate != "" {
+		t.Errorf("unexpected installments %+v", response.Installments)
+	}
+	if response.Installments[0].DueDate != "2026-02-15" {
+		t.Errorf("expected due date 2026-02-15, got %s", response.Installments[0].DueDate)
+	}
+}
+
+func TestRecordPayment_Success(t *testing.T) {
+	service := &mockLoanService{}
+	handler := &Handler{loanService: service}
+
+	jsonBody, _ := json.Marshal(LoanPaymentRequest{Installment: 3, Date: "2026-04-15"})
+	req := httptest.NewRequest("POST", "/loans/loan1/payments", bytes.NewBuffer(jsonBody))
+	rr := httptest.NewRecorder()
+	handler.RecordPayment(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if service.lastID != "loan1" || service.lastPayment.Installment != 3 {
+		t.Errorf("unexpected payment %s %+v", service.lastID, service.lastPayment)
+	}
+	if !service.lastPayment.Date.Equal(time.Date(2026, 4, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected payment date %v", service.lastPayment.Date)
+	}
+}
+
+func TestRecordPayment_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       LoanPaymentRequest
+		err        error
+		wantStatus int
+	}{
+		{"invalid date", LoanPaymentRequest{Date: "soon"}, nil, http.StatusBadRequest},
+		{"negative installment", LoanPaymentRequest{Installment: -1}, nil, http.StatusBadRequest},
+		{"already paid", LoanPaymentRequest{Installment: 1}, transactiondomain.ErrLoanInstallmentPaid, http.StatusConflict},
+		{"paid off", LoanPaymentRequest{}, errors.New("every installment of the loan has already been paid"), http.StatusConflict},
+		{"out of schedule", LoanPaymentRequest{Installment: 99}, errors.New("installment does not exist in the loan schedule"), http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &Handler{loanService: &mockLoanService{paymentErr: tt.err}}
+
+			jsonBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("POST", "/loans/loan1/payments", bytes.NewBuffer(jsonBody))
+			rr := httptest.NewRecorder()
+			handler.RecordPayment(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestGetBalance_Success(t *testing.T) {
+	service := &mockLoanService{balance: &queries.LoanBalanceResponse{
+		LoanID:             "loan1",
+		Date:               time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC),
+		Currency:           "USD",
+		OutstandingBalance: shareddomain.MustParseAmount("145000"),
+	}}
+	handler := &Handler{loanService: service}
+
+	req := httptest.NewRequest("GET", "/loans/loan1/balance?date=2026-06-30", nil)
+	rr := httptest.NewRecorder()
+	handler.GetBalance(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if !service.lastDate.Equal(time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected date %v", service.lastDate)
+	}
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response["outstanding_balance"] != "145000" || response["date"] != "2026-06-30" {
+		t.Errorf("unexpected response: %v", response)
+	}
+}
+
+func TestGetBalance_InvalidDate(t *testing.T) {
+	handler := &Handler{loanService: &mockLoanService{}}
+
+	req := httptest.NewRequest("GET", "/loans/loan1/balance?date=june", nil)
+	rr := httptest.NewRecorder()
+	handler.GetBalance(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rr.Code)
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func amountPtr(value string) *shareddomain.Amount {
+	amount := shareddomain.MustParseAmount(value)
+	return &amount
+}
@@ -0,0 +1,27 @@
+package http
+
+import shareddomain "fin-flow-api/internal/shared/domain"
+
+type LoanRequest struct {
+	WalletID     string               `json:"wallet_id"`
+	CategoryID   string               `json:"category_id"`
+	Name         string               `json:"name"`
+	Counterparty string               `json:"counterparty"`
+	Direction    *int                 `json:"direction"`
+	Principal    *shareddomain.Amount `json:"principal"`
+	AnnualRate   *shareddomain.Amount `json:"annual_rate"`
+	TermMonths   *int                 `json:"term_months"`
+	Method       string               `json:"method"`
+	StartDate    string               `json:"start_date"`
+}
+
+type UpdateLoanRequest struct {
+	CategoryID   string `json:"category_id"`
+	Name         string `json:"name"`
+	Counterparty string `json:"counterparty"`
+}
+
+type LoanPaymentRequest struct {
+	Installment int    `json:"installment"`
+	Date        string `json:"date"`
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"time"
+
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+type LoanResponse struct {
+	ID            string              `json:"id"`
+	WalletID      string              `json:"wallet_id"`
+	CategoryID    string              `json:"category_id"`
+	Name          string              `json:"name"`
+	Counterparty  string              `json:"counterparty"`
+	Direction     int                 `json:"direction"`
+	DirectionName string              `json:"direction_name"`
+	Principal     shareddomain.Amount `json:"principal"`
+	AnnualRate    shareddomain.Amount `json:"annual_rate"`
+	TermMonths    int                 `json:"term_months"`
+	Method        string              `json:"method"`
+	StartDate     string              `json:"start_date"`
+	CreatedAt     time.Time           `json:"created_at"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+	CreatedBy     string              `json:"created_by"`
+	UpdatedBy     string              `json:"updated_by"`
+}
+
+type LoanInstallmentResponse struct {
+	Number        int                 `json:"number"`
+	DueDate       string              `json:"due_date"`
+	Payment       shareddomain.Amount `json:"payment"`
+	Principal     shareddomain.Amount `json:"principal"`
+	Interest      shareddomain.Amount `json:"interest"`
+	Balance       shareddomain.Amount `json:"balance"`
+	Paid          bool                `json:"paid"`
+	TransactionID string              `json:"transaction_id,omitempty"`
+	PaidDate      string              `json:"paid_date,omitempty"`
+}
+
+type LoanScheduleResponse struct {
+	LoanID        string                    `json:"loan_id"`
+	Currency      string                    `json:"currency"`
+	Method        string                    `json:"method"`
+	TotalPayment  shareddomain.Amount       `json:"total_payment"`
+	TotalInterest shareddomain.Amount       `json:"total_interest"`
+	Installments  []LoanInstallmentResponse `json:"installments"`
+}
+
+type LoanBalanceResponse struct {
+	LoanID              string              `json:"loan_id"`
+	Date                string              `json:"date"`
+	Currency            string              `json:"currency"`
+	Principal           shareddomain.Amount `json:"principal"`
+	ScheduledBalance    shareddomain.Amount `json:"scheduled_balance"`
+	OutstandingBalance  shareddomain.Amount `json:"outstanding_balance"`
+	PrincipalPaid       shareddomain.Amount `json:"principal_paid"`
+	InterestPaid        shareddomain.Amount `json:"interest_paid"`
+	InstallmentsPaid    int                 `json:"installments_paid"`
+	InstallmentsDue     int                 `json:"installments_due"`
+	InstallmentsOverdue int                 `json:"installments_overdue"`
+}
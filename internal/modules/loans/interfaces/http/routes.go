@@ -0,0 +1,64 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"fin-flow-api/internal/shared/interface/jwt"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+var loanHandler *Handler
+
+func SetupRoutes(mux *http.ServeMux, jwtService jwt.Service) {
+	mountLoans(mux, jwtService)
+}
+
+func mountLoans(mux *http.ServeMux, jwtService jwt.Service) {
+	mux.HandleFunc("/loans", handleLoansCollection(jwtService))
+
+	protectedHandler := middleware.RequireAuth(jwtService)(http.HandlerFunc(handleLoansResource))
+	mux.Handle("/loans/", protectedHandler)
+}
+
+func handleLoansCollection(jwtService jwt.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.RequireAuth(jwtService)(http.HandlerFunc(loanHandler.ListLoans)).ServeHTTP(w, r)
+		case http.MethodPost:
+			middleware.RequireAuth(jwtService)(http.HandlerFunc(loanHandler.CreateLoan)).ServeHTTP(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func handleLoansResource(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/schedule"):
+		loanHandler.GetSchedule(w, r)
+		return
+	case strings.HasSuffix(r.URL.Path, "/payments"):
+		loanHandler.RecordPayment(w, r)
+		return
+	case strings.HasSuffix(r.URL.Path, "/balance"):
+		loanHandler.GetBalance(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		loanHandler.GetLoan(w, r)
+	case http.MethodPut:
+		loanHandler.UpdateLoan(w, r)
+	case http.MethodDelete:
+		loanHandler.DeleteLoan(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func SetHandler(handler *Handler) {
+	loanHandler = handler
+}
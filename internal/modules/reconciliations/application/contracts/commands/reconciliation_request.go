@@ -0,0 +1,23 @@
+package commands
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+// ReconciliationRequest is the statement a wallet is reconciled against:
+// its date and the balance the bank reports on that day. WalletID is only
+// used when the reconciliation is started.
+type ReconciliationRequest struct {
+	WalletID      string
+	StatementDate time.Time
+	EndingBalance domain.Amount
+}
+
+// ClearRequest marks transactions as cleared in a reconciliation, or as not
+// cleared when Cleared is false.
+type ClearRequest struct {
+	TransactionIDs []string
+	Cleared        bool
+}
@@ -0,0 +1,44 @@
+package queries
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+// ReconciliationResponse is a reconciliation and where it stands.
+// ClearedBalance is the balance the bank should report for the wallet given
+// the transactions cleared so far, and Difference what is left to reach
+// EndingBalance; the reconciliation can be locked once it is zero.
+// Transactions are only listed for a single reconciliation.
+type ReconciliationResponse struct {
+	ID              string
+	WalletID        string
+	StatementDate   time.Time
+	EndingBalance   domain.Amount
+	StartingBalance domain.Amount
+	ClearedBalance  domain.Amount
+	Difference      domain.Amount
+	ClearedDeposits domain.Amount
+	ClearedPayments domain.Amount
+	ClearedCount    int
+	Status          string
+	LockedAt        *time.Time
+	Transactions    []*ReconciliationTransactionResponse
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// ReconciliationTransactionResponse is a transaction of the reconciled
+// wallet. Amount is what it adds to the balance, negative for payments.
+type ReconciliationTransactionResponse struct {
+	ID          string
+	CategoryID  string
+	Type        int
+	TypeName    string
+	Amount      domain.Amount
+	Description string
+	Payee       string
+	Date        time.Time
+	Cleared     bool
+}
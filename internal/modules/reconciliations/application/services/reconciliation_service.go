@@ -0,0 +1,353 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"fin-flow-api/internal/modules/reconciliations/application/contracts/commands"
+	"fin-flow-api/internal/modules/reconciliations/application/contracts/queries"
+	"fin-flow-api/internal/modules/reconciliations/domain"
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/middleware"
+
+	"github.com/google/uuid"
+)
+
+var ErrWalletRequired = errors.New("wallet is required")
+
+type ReconciliationService struct {
+	repository            domain.ReconciliationRepository
+	walletRepository      walletdomain.WalletRepository
+	transactionRepository transactiondomain.TransactionRepository
+	systemUser            string
+	now                   func() time.Time
+}
+
+func NewReconciliationService(repository domain.ReconciliationRepository, walletRepository walletdomain.WalletRepository, transactionRepository transactiondomain.TransactionRepository, systemUser string) *ReconciliationService {
+	return &ReconciliationService{
+		repository:            repository,
+		walletRepository:      walletRepository,
+		transactionRepository: transactionRepository,
+		systemUser:            systemUser,
+		now:                   time.Now,
+	}
+}
+
+func (s *ReconciliationService) getUserIDFromContext(ctx context.Context) (string, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return "", errors.New("user not authenticated")
+	}
+	return userID, nil
+}
+
+// Create starts reconciling a wallet against a statement and returns
+// where the reconciliation stands, with the transactions that can be
+// cleared.
+func (s *ReconciliationService) Create(ctx context.Context, req commands.ReconciliationRequest) (*queries.ReconciliationResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.WalletID == "" {
+		return nil, ErrWalletRequired
+	}
+
+	wallet, err := s.walletRepository.GetByID(req.WalletID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkStatement(wallet, req, ""); err != nil {
+		return nil, err
+	}
+
+	reconciliation := domain.NewReconciliation(
+		uuid.New().String(),
+		userID,
+		wallet.ID,
+		req.StatementDate,
+		req.EndingBalance,
+		s.systemUser,
+	)
+
+	if err := s.repository.Create(reconciliation); err != nil {
+		return nil, err
+	}
+
+	return s.detail(wallet, reconciliation)
+}
+
+func (s *ReconciliationService) GetByID(ctx context.Context, id string) (*queries.ReconciliationResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reconciliation, wallet, err := s.load(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.detail(wallet, reconciliation)
+}
+
+// List returns the reconciliations of a wallet, the latest statement
+// first, without their transactions.
+func (s *ReconciliationService) List(ctx context.Context, walletID string) ([]*queries.ReconciliationResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if walletID == "" {
+		return nil, ErrWalletRequired
+	}
+
+	wallet, err := s.walletRepository.GetByID(walletID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	reconciliations, err := s.repository.List(wallet.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := s.walletTransactions(wallet)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*queries.ReconciliationResponse, len(reconciliations))
+	for i, reconciliation := range reconciliations {
+		responses[i] = toReconciliationResponse(reconciliation, reconciliation.Summarize(wallet.Balance, transactions))
+	}
+
+	return responses, nil
+}
+
+// Update changes the statement date and ending balance of an open
+// reconciliation. Transactions cleared in it that fall after the new
+// statement date are no longer cleared.
+func (s *ReconciliationService) Update(ctx context.Context, id string, req commands.ReconciliationRequest) (*queries.ReconciliationResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reconciliation, wallet, err := s.load(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := reconciliation.CheckOpen(); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkStatement(wallet, req, reconciliation.ID); err != nil {
+		return nil, err
+	}
+
+	if err := reconciliation.Update(req.StatementDate, req.EndingBalance, s.systemUser); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Update(reconciliation); err != nil {
+		return nil, err
+	}
+
+	return s.detail(wallet, reconciliation)
+}
+
+// Clear marks transactions of the wallet as cleared in an open
+// reconciliation, or as not cleared, and returns the new difference.
+func (s *ReconciliationService) Clear(ctx context.Context, id string, req commands.ClearRequest) (*queries.ReconciliationResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reconciliation, wallet, err := s.load(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := reconciliation.CheckOpen(); err != nil {
+		return nil, err
+	}
+
+	for _, transactionID := range req.TransactionIDs {
+		transaction, err := s.transactionRepository.GetByID(transactionID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if err := reconciliation.CheckClear(transaction); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(req.TransactionIDs) > 0 {
+		if err := s.repository.SetCleared(reconciliation, req.TransactionIDs, req.Cleared); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.detail(wallet, reconciliation)
+}
+
+// Lock locks a reconciliation whose difference is zero. From then on the
+// transactions cleared in it are reconciled and keep their wallet, type,
+// amount and date.
+func (s *ReconciliationService) Lock(ctx context.Context, id string) (*queries.ReconciliationResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reconciliation, wallet, err := s.load(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := s.walletTransactions(wallet)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := reconciliation.Lock(reconciliation.Summarize(wallet.Balance, transactions), s.systemUser); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Lock(reconciliation); err != nil {
+		return nil, err
+	}
+
+	for _, transaction := range transactions {
+		if transaction.ReconciliationID == reconciliation.ID {
+			transaction.Reconciled = true
+		}
+	}
+
+	return toDetailResponse(reconciliation, wallet, transactions), nil
+}
+
+// Delete discards an open reconciliation. Locked reconciliations are kept.
+func (s *ReconciliationService) Delete(ctx context.Context, id string) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	reconciliation, err := s.repository.GetByID(id, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := reconciliation.CheckOpen(); err != nil {
+		return err
+	}
+
+	return s.repository.Delete(reconciliation.ID, userID)
+}
+
+func (s *ReconciliationService) load(id string, userID string) (*domain.Reconciliation, *walletdomain.Wallet, error) {
+	reconciliation, err := s.repository.GetByID(id, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	wallet, err := s.walletRepository.GetByID(reconciliation.WalletID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return reconciliation, wallet, nil
+}
+
+// checkStatement validates a statement of the wallet against the latest
+// locked reconciliation of the wallet. A new reconciliation cannot be
+// started while another one is open.
+func (s *ReconciliationService) checkStatement(wallet *walletdomain.Wallet, req commands.ReconciliationRequest, reconciliationID string) error {
+	if !req.EndingBalance.FitsCurrency(wallet.Currency.String()) {
+		return shareddomain.ErrAmountPrecision
+	}
+
+	reconciliations, err := s.repository.List(wallet.ID, wallet.UserID)
+	if err != nil {
+		return err
+	}
+
+	var lastLocked *domain.Reconciliation
+	for _, reconciliation := range reconciliations {
+		if !reconciliation.IsLocked() {
+			if reconciliation.ID != reconciliationID {
+				return domain.ErrOpenReconciliationExists
+			}
+			continue
+		}
+		if lastLocked == nil || reconciliation.StatementDate.After(lastLocked.StatementDate) {
+			lastLocked = reconciliation
+		}
+	}
+
+	return domain.CheckStatementDate(req.StatementDate, s.now(), lastLocked)
+}
+
+func (s *ReconciliationService) walletTransactions(wallet *walletdomain.Wallet) ([]*transactiondomain.Transaction, error) {
+	return s.transactionRepository.List(wallet.UserID, transactiondomain.TransactionFilter{WalletID: wallet.ID})
+}
+
+func (s *ReconciliationService) detail(wallet *walletdomain.Wallet, reconciliation *domain.Reconciliation) (*queries.ReconciliationResponse, error) {
+	transactions, err := s.walletTransactions(wallet)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDetailResponse(reconciliation, wallet, transactions), nil
+}
+
+func toDetailResponse(reconciliation *domain.Reconciliation, wallet *walletdomain.Wallet, transactions []*transactiondomain.Transaction) *queries.ReconciliationResponse {
+	response := toReconciliationResponse(reconciliation, reconciliation.Summarize(wallet.Balance, transactions))
+
+	candidates := reconciliation.Candidates(transactions)
+	response.Transactions = make([]*queries.ReconciliationTransactionResponse, len(candidates))
+	for i, transaction := range candidates {
+		response.Transactions[i] = &queries.ReconciliationTransactionResponse{
+			ID:          transaction.ID,
+			CategoryID:  transaction.CategoryID,
+			Type:        transaction.Type.Value(),
+			TypeName:    transaction.Type.String(),
+			Amount:      transaction.WalletDelta(),
+			Description: transaction.Description,
+			Payee:       transaction.Payee,
+			Date:        transaction.Date,
+			Cleared:     transaction.ReconciliationID == reconciliation.ID,
+		}
+	}
+
+	return response
+}
+
+func toReconciliationResponse(reconciliation *domain.Reconciliation, summary domain.Summary) *queries.ReconciliationResponse {
+	return &queries.ReconciliationResponse{
+		ID:              reconciliation.ID,
+		WalletID:        reconciliation.WalletID,
+		StatementDate:   reconciliation.StatementDate,
+		EndingBalance:   reconciliation.EndingBalance,
+		StartingBalance: summary.StartingBalance,
+		ClearedBalance:  summary.ClearedBalance,
+		Difference:      summary.Difference,
+		ClearedDeposits: summary.ClearedDeposits,
+		ClearedPayments: summary.ClearedPayments,
+		ClearedCount:    summary.ClearedCount,
+		Status:          string(reconciliation.Status),
+		LockedAt:        reconciliation.LockedAt,
+		CreatedAt:       reconciliation.CreatedAt,
+		UpdatedAt:       reconciliation.ModifiedAt,
+	}
+}
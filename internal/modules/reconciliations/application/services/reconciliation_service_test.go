@@ -0,0 +1,357 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"fin-flow-api/internal/modules/reconciliations/application/contracts/commands"
+	"fin-flow-api/internal/modules/reconciliations/domain"
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	"fin-flow-api/internal/modules/transactions/domain/transactiontest"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+type mockReconciliationRepository struct {
+	reconciliations map[string]*domain.Reconciliation
+	transactions    *transactiontest.Repository
+	lockErr         error
+}
+
+func (m *mockReconciliationRepository) Create(reconciliation *domain.Reconciliation) error {
+	m.reconciliations[reconciliation.ID] = reconciliation
+	return nil
+}
+
+func (m *mockReconciliationRepository) GetByID(id string, userID string) (*domain.Reconciliation, error) {
+	reconciliation, exists := m.reconciliations[id]
+	if !exists {
+		return nil, errors.New("reconciliation not found")
+	}
+	if reconciliation.UserID != userID {
+		return nil, errors.New("unauthorized access to reconciliation")
+	}
+	return reconciliation, nil
+}
+
+func (m *mockReconciliationRepository) List(walletID string, userID string) ([]*domain.Reconciliation, error) {
+	var result []*domain.Reconciliation
+	for _, reconciliation := range m.reconciliations {
+		if reconciliation.WalletID == walletID && reconciliation.UserID == userID {
+			result = append(result, reconciliation)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockReconciliationRepository) Update(reconciliation *domain.Reconciliation) error {
+	for _, transaction := range m.transactions.Transactions {
+		if transaction.ReconciliationID == reconciliation.ID && transaction.Date.After(reconciliation.StatementDate) {
+			transaction.ReconciliationID = ""
+		}
+	}
+	return nil
+}
+
+func (m *mockReconciliationRepository) SetCleared(reconciliation *domain.Reconciliation, transactionIDs []string, cleared bool) error {
+	for _, id := range transactionIDs {
+		if cleared {
+			m.transactions.Transactions[id].ReconciliationID = reconciliation.ID
+		} else {
+			m.transactions.Transactions[id].ReconciliationID = ""
+		}
+	}
+	return nil
+}
+
+func (m *mockReconciliationRepository) Lock(reconciliation *domain.Reconciliation) error {
+	if m.lockErr != nil {
+		return m.lockErr
+	}
+	for _, transaction := range m.transactions.Transactions {
+		if transaction.ReconciliationID == reconciliation.ID {
+			transaction.Reconciled = true
+		}
+	}
+	return nil
+}
+
+func (m *mockReconciliationRepository) Delete(id string, userID string) error {
+	delete(m.reconciliations, id)
+	return nil
+}
+
+type mockWalletRepository struct {
+	wallets map[string]*walletdomain.Wallet
+}
+
+func (m *mockWalletRepository) Create(wallet *walletdomain.Wallet) error {
+	return nil
+}
+
+func (m *mockWalletRepository) GetByID(id string, userID string) (*walletdomain.Wallet, error) {
+	wallet, exists := m.wallets[id]
+	if !exists {
+		return nil, errors.New("wallet not found")
+	}
+	if wallet.UserID != userID {
+		return nil, errors.New("unauthorized access to wallet")
+	}
+	return wallet, nil
+}
+
+func (m *mockWalletRepository) List(userID string) ([]*walletdomain.Wallet, error) {
+	return nil, nil
+}
+
+func (m *mockWalletRepository) Update(wallet *walletdomain.Wallet) error {
+	return nil
+}
+
+func (m *mockWalletRepository) Delete(id string, userID string) error {
+	return nil
+}
+
+func contextWithUser(userID string) context.Context {
+	return context.WithValue(context.Background(), middleware.UserIDKey, userID)
+}
+
+func date(value string) time.Time {
+	day, _ := time.Parse("2006-01-02", value)
+	return day
+}
+
+func transaction(id string, transactionType transactiondomain.TransactionType, amount, day string) *transactiondomain.Transaction {
+	return transactiondomain.NewTransaction(id, "user1", "wallet1", "cat1", transactionType, shareddomain.MustParseAmount(amount), id, date(day), "system")
+}
+
+// newTestReconciliationService returns a service over a checking account
+// that opened with 1000 and, by the 12th of May, has the April salary and
+// rent, a May purchase and 1100 left.
+func newTestReconciliationService() (*ReconciliationService, *mockReconciliationRepository, *transactiontest.Repository) {
+	wallets := &mockWalletRepository{wallets: map[string]*walletdomain.Wallet{
+		"wallet1": walletdomain.NewWallet("wallet1", "user1", "Checking", walletdomain.WalletTypeBank, shareddomain.MustParseAmount("1100"), walletdomain.CurrencyUSD, "system"),
+		"wallet2": walletdomain.NewWallet("wallet2", "user2", "Other", walletdomain.WalletTypeBank, shareddomain.Amount{}, walletdomain.CurrencyUSD, "system"),
+	}}
+	transactions := transactiontest.NewRepository(
+		transaction("salary", transactiondomain.TransactionTypeIncome, "500", "2026-04-01"),
+		transaction("rent", transactiondomain.TransactionTypeExpense, "380", "2026-04-05"),
+		transaction("groceries", transactiondomain.TransactionTypeExpense, "20", "2026-05-03"),
+	)
+	reconciliations := &mockReconciliationRepository{reconciliations: map[string]*domain.Reconciliation{}, transactions: transactions}
+
+	service := NewReconciliationService(reconciliations, wallets, transactions, "system")
+	service.now = func() time.Time { return date("2026-05-12") }
+	return service, reconciliations, transactions
+}
+
+func TestReconciliationService_Workflow(t *testing.T) {
+	service, reconciliations, transactions := newTestReconciliationService()
+	ctx := contextWithUser("user1")
+
+	created, err := service.Create(ctx, commands.ReconciliationRequest{
+		WalletID:      "wallet1",
+		StatementDate: date("2026-04-30"),
+		EndingBalance: shareddomain.MustParseAmount("1120"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created.Status != "open" || created.ClearedBalance.String() != "1000" || created.Difference.String() != "120" {
+		t.Errorf("expected a cleared balance of 1000 and a difference of 120, got %s and %s", created.ClearedBalance, created.Difference)
+	}
+	if len(created.Transactions) != 2 || created.Transactions[0].ID != "salary" || created.Transactions[1].Amount.String() != "-380" {
+		t.Errorf("expected the April transactions, oldest first, got %+v", created.Transactions)
+	}
+
+	if _, err := service.Lock(ctx, created.ID); err != domain.ErrNotBalanced {
+		t.Errorf("expected ErrNotBalanced, got %v", err)
+	}
+
+	cleared, err := service.Clear(ctx, created.ID, commands.ClearRequest{TransactionIDs: []string{"salary", "rent"}, Cleared: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cleared.Difference.IsZero() || cleared.ClearedCount != 2 || cleared.ClearedDeposits.String() != "500" || cleared.ClearedPayments.String() != "380" {
+		t.Errorf("expected the statement to balance, got %+v", cleared)
+	}
+
+	locked, err := service.Lock(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if locked.Status != "locked" || locked.LockedAt == nil || len(locked.Transactions) != 2 {
+		t.Errorf("expected a locked reconciliation with its two transactions, got %+v", locked)
+	}
+	if !transactions.Transactions["rent"].Reconciled || transactions.Transactions["groceries"].Reconciled {
+		t.Error("expected only the cleared transactions to be reconciled")
+	}
+
+	if _, err := service.Clear(ctx, created.ID, commands.ClearRequest{TransactionIDs: []string{"rent"}}); err != domain.ErrReconciliationLocked {
+		t.Errorf("expected ErrReconciliationLocked, got %v", err)
+	}
+	if err := service.Delete(ctx, created.ID); err != domain.ErrReconciliationLocked {
+		t.Errorf("expected ErrReconciliationLocked, got %v", err)
+	}
+	if _, exists := reconciliations.reconciliations[created.ID]; !exists {
+		t.Error("expected the locked reconciliation to be kept")
+	}
+
+	if _, err := service.Create(ctx, commands.ReconciliationRequest{WalletID: "wallet1", StatementDate: date("2026-04-15"), EndingBalance: shareddomain.MustParseAmount("1")}); err != domain.ErrStatementBeforeLastLocked {
+		t.Errorf("expected ErrStatementBeforeLastLocked, got %v", err)
+	}
+
+	next, err := service.Create(ctx, commands.ReconciliationRequest{WalletID: "wallet1", StatementDate: date("2026-05-10"), EndingBalance: shareddomain.MustParseAmount("1100")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if next.StartingBalance.String() != "1120" || next.Difference.String() != "-20" || len(next.Transactions) != 1 {
+		t.Errorf("expected the next statement to start from 1120 with the May purchase to clear, got %+v", next)
+	}
+
+	list, err := service.List(ctx, "wallet1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list) != 2 {
+		t.Errorf("expected 2 reconciliations, got %d", len(list))
+	}
+}
+
+func TestReconciliationService_Create_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     commands.ReconciliationRequest
+		wantErr string
+	}{
+		{"missing wallet", commands.ReconciliationRequest{StatementDate: date("2026-04-30")}, "wallet is required"},
+		{"other user's wallet", commands.ReconciliationRequest{WalletID: "wallet2", StatementDate: date("2026-04-30")}, "unauthorized access to wallet"},
+		{"too many decimals", commands.ReconciliationRequest{WalletID: "wallet1", StatementDate: date("2026-04-30"), EndingBalance: shareddomain.MustParseAmount("1.005")}, shareddomain.ErrAmountPrecision.Error()},
+		{"missing date", commands.ReconciliationRequest{WalletID: "wallet1"}, domain.ErrStatementDateRequired.Error()},
+		{"future date", commands.ReconciliationRequest{WalletID: "wallet1", StatementDate: date("2026-05-13")}, domain.ErrStatementDateInFuture.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _, _ := newTestReconciliationService()
+
+			if _, err := service.Create(contextWithUser("user1"), tt.req); err == nil || err.Error() != tt.wantErr {
+				t.Errorf("expected %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestReconciliationService_Create_OpenExists(t *testing.T) {
+	service, _, _ := newTestReconciliationService()
+	ctx := contextWithUser("user1")
+	req := commands.ReconciliationRequest{WalletID: "wallet1", StatementDate: date("2026-04-30"), EndingBalance: shareddomain.MustParseAmount("1120")}
+
+	if _, err := service.Create(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.Create(ctx, req); err != domain.ErrOpenReconciliationExists {
+		t.Errorf("expected ErrOpenReconciliationExists, got %v", err)
+	}
+}
+
+func TestReconciliationService_Clear_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		wantErr string
+	}{
+		{"unknown", "missing", "transaction not found"},
+		{"after the statement", "groceries", domain.ErrTransactionAfterStatement.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _, transactions := newTestReconciliationService()
+			ctx := contextWithUser("user1")
+
+			created, err := service.Create(ctx, commands.ReconciliationRequest{WalletID: "wallet1", StatementDate: date("2026-04-30"), EndingBalance: shareddomain.MustParseAmount("1120")})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			_, err = service.Clear(ctx, created.ID, commands.ClearRequest{TransactionIDs: []string{"salary", tt.id}, Cleared: true})
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("expected %q, got %v", tt.wantErr, err)
+			}
+			if transactions.Transactions["salary"].ReconciliationID != "" {
+				t.Error("expected no transaction to be cleared")
+			}
+		})
+	}
+}
+
+func TestReconciliationService_Update(t *testing.T) {
+	service, _, transactions := newTestReconciliationService()
+	ctx := contextWithUser("user1")
+
+	created, err := service.Create(ctx, commands.ReconciliationRequest{WalletID: "wallet1", StatementDate: date("2026-04-30"), EndingBalance: shareddomain.MustParseAmount("1120")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.Clear(ctx, created.ID, commands.ClearRequest{TransactionIDs: []string{"salary", "rent"}, Cleared: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	updated, err := service.Update(ctx, created.ID, commands.ReconciliationRequest{StatementDate: date("2026-04-02"), EndingBalance: shareddomain.MustParseAmount("1500")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if transactions.Transactions["rent"].ReconciliationID != "" || transactions.Transactions["salary"].ReconciliationID != created.ID {
+		t.Error("expected the rent to be no longer cleared")
+	}
+	if !updated.Difference.IsZero() || updated.ClearedCount != 1 {
+		t.Errorf("expected the salary alone to balance, got %+v", updated)
+	}
+}
+
+func TestReconciliationService_Lock_Concurrent(t *testing.T) {
+	service, reconciliations, _ := newTestReconciliationService()
+	ctx := contextWithUser("user1")
+
+	created, err := service.Create(ctx, commands.ReconciliationRequest{WalletID: "wallet1", StatementDate: date("2026-04-30"), EndingBalance: shareddomain.MustParseAmount("1000")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reconciliations.lockErr = domain.ErrNotBalanced
+	if _, err := service.Lock(ctx, created.ID); err != domain.ErrNotBalanced {
+		t.Errorf("expected ErrNotBalanced, got %v", err)
+	}
+}
+
+func TestReconciliationService_Delete(t *testing.T) {
+	service, reconciliations, _ := newTestReconciliationService()
+	ctx := contextWithUser("user1")
+
+	created, err := service.Create(ctx, commands.ReconciliationRequest{WalletID: "wallet1", StatementDate: date("2026-04-30"), EndingBalance: shareddomain.MustParseAmount("1120")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := service.Delete(contextWithUser("user2"), created.ID); err == nil || err.Error() != "unauthorized access to reconciliation" {
+		t.Errorf("expected unauthorized access, got %v", err)
+	}
+	if err := service.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reconciliations.reconciliations) != 0 {
+		t.Error("expected the reconciliation to be deleted")
+	}
+}
+
+func TestReconciliationService_Unauthenticated(t *testing.T) {
+	service, _, _ := newTestReconciliationService()
+
+	if _, err := service.List(context.Background(), "wallet1"); err == nil || err.Error() != "user not authenticated" {
+		t.Errorf("expected user not authenticated, got %v", err)
+	}
+}
@@ -0,0 +1,204 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	"fin-flow-api/internal/shared/domain"
+)
+
+var (
+	ErrStatementDateRequired     = errors.New("statement date is required")
+	ErrStatementDateInFuture     = errors.New("statement date cannot be in the future")
+	ErrStatementBeforeLastLocked = errors.New("statement date cannot be before that of the last locked reconciliation of the wallet")
+	ErrOpenReconciliationExists  = errors.New("the wallet already has an open reconciliation")
+	ErrReconciliationLocked      = errors.New("reconciliation is locked and can no longer be changed")
+	ErrNotBalanced               = errors.New("reconciliation can only be locked once the difference is zero")
+	ErrTransactionOtherWallet    = errors.New("only transactions of the wallet being reconciled can be cleared")
+	ErrTransactionAfterStatement = errors.New("only transactions dated on or before the statement date can be cleared")
+	ErrTransactionReconciled     = errors.New("transaction has already been reconciled")
+)
+
+type Status string
+
+const (
+	StatusOpen   Status = "open"
+	StatusLocked Status = "locked"
+)
+
+// Reconciliation checks a wallet against the statement the bank sent for
+// it. The user marks the transactions the statement lists as cleared, and
+// once the cleared balance matches EndingBalance the reconciliation is
+// locked: its transactions become reconciled and keep what the statement
+// confirmed. A wallet has at most one open reconciliation at a time.
+type Reconciliation struct {
+	domain.Entity
+
+	ID            string
+	UserID        string
+	WalletID      string
+	StatementDate time.Time
+	EndingBalance domain.Amount
+	Status        Status
+	LockedAt      *time.Time
+}
+
+func NewReconciliation(id, userID, walletID string, statementDate time.Time, endingBalance domain.Amount, createdBy string) *Reconciliation {
+	return &Reconciliation{
+		Entity:        domain.NewEntity(id, createdBy),
+		ID:            id,
+		UserID:        userID,
+		WalletID:      walletID,
+		StatementDate: statementDate,
+		EndingBalance: endingBalance,
+		Status:        StatusOpen,
+	}
+}
+
+func (r *Reconciliation) IsLocked() bool {
+	return r.Status == StatusLocked
+}
+
+// CheckOpen reports whether the reconciliation can still be changed.
+func (r *Reconciliation) CheckOpen() error {
+	if r.IsLocked() {
+		return ErrReconciliationLocked
+	}
+	return nil
+}
+
+// CheckStatementDate reports whether a wallet can be reconciled up to
+// statementDate, given the latest locked reconciliation of the wallet, if
+// there is one.
+func CheckStatementDate(statementDate time.Time, today time.Time, lastLocked *Reconciliation) error {
+	if statementDate.IsZero() {
+		return ErrStatementDateRequired
+	}
+	if day(statementDate).After(day(today)) {
+		return ErrStatementDateInFuture
+	}
+	if lastLocked != nil && day(statementDate).Before(day(lastLocked.StatementDate)) {
+		return ErrStatementBeforeLastLocked
+	}
+	return nil
+}
+
+// Update changes the statement an open reconciliation is checked against.
+func (r *Reconciliation) Update(statementDate time.Time, endingBalance domain.Amount, modifiedBy string) error {
+	if err := r.CheckOpen(); err != nil {
+		return err
+	}
+
+	r.StatementDate = statementDate
+	r.EndingBalance = endingBalance
+	r.Entity.UpdateModified(modifiedBy)
+	return nil
+}
+
+// CheckClear reports whether a transaction can be marked as cleared, or as
+// not cleared, in the reconciliation.
+func (r *Reconciliation) CheckClear(transaction *transactiondomain.Transaction) error {
+	if err := r.CheckOpen(); err != nil {
+		return err
+	}
+	if transaction.WalletID != r.WalletID {
+		return ErrTransactionOtherWallet
+	}
+	if transaction.Reconciled || (transaction.ReconciliationID != "" && transaction.ReconciliationID != r.ID) {
+		return ErrTransactionReconciled
+	}
+	if day(transaction.Date).After(day(r.StatementDate)) {
+		return ErrTransactionAfterStatement
+	}
+	return nil
+}
+
+// Lock locks a balanced reconciliation.
+func (r *Reconciliation) Lock(summary Summary, lockedBy string) error {
+	if err := r.CheckOpen(); err != nil {
+		return err
+	}
+	if !summary.Difference.IsZero() {
+		return ErrNotBalanced
+	}
+
+	r.Entity.UpdateModified(lockedBy)
+	lockedAt := r.ModifiedAt
+	r.Status = StatusLocked
+	r.LockedAt = &lockedAt
+	return nil
+}
+
+// Summary is where a reconciliation stands. ClearedBalance is the balance
+// the bank should report for the wallet given the transactions cleared so
+// far, StartingBalance what it was before the transactions cleared in this
+// reconciliation, and Difference what is left to reach EndingBalance.
+type Summary struct {
+	StartingBalance domain.Amount
+	ClearedBalance  domain.Amount
+	Difference      domain.Amount
+	ClearedDeposits domain.Amount
+	ClearedPayments domain.Amount
+	ClearedCount    int
+}
+
+// Summarize works out the summary of the reconciliation from the balance
+// of the wallet and all of its transactions. The cleared balance is the
+// wallet balance without the transactions that have not cleared yet,
+// whatever their date. A locked reconciliation balanced when it was
+// locked, so it reports its ending balance.
+func (r *Reconciliation) Summarize(walletBalance domain.Amount, transactions []*transactiondomain.Transaction) Summary {
+	summary := Summary{ClearedBalance: walletBalance}
+
+	for _, transaction := range transactions {
+		if transaction.WalletID != r.WalletID {
+			continue
+		}
+
+		delta := transaction.WalletDelta()
+		switch transaction.ReconciliationID {
+		case "":
+			summary.ClearedBalance = summary.ClearedBalance.Sub(delta)
+		case r.ID:
+			summary.ClearedCount++
+			if delta.IsNegative() {
+				summary.ClearedPayments = summary.ClearedPayments.Add(delta.Neg())
+			} else {
+				summary.ClearedDeposits = summary.ClearedDeposits.Add(delta)
+			}
+		}
+	}
+
+	if r.IsLocked() {
+		summary.ClearedBalance = r.EndingBalance
+	}
+	summary.StartingBalance = summary.ClearedBalance.Sub(summary.ClearedDeposits).Add(summary.ClearedPayments)
+	summary.Difference = r.EndingBalance.Sub(summary.ClearedBalance)
+	return summary
+}
+
+// Candidates returns the transactions that belong in the reconciliation,
+// oldest first: those cleared in it and, while it is open, those dated up
+// to the statement date that have not cleared in any other.
+func (r *Reconciliation) Candidates(transactions []*transactiondomain.Transaction) []*transactiondomain.Transaction {
+	var candidates []*transactiondomain.Transaction
+	for _, transaction := range transactions {
+		if transaction.WalletID != r.WalletID {
+			continue
+		}
+		if transaction.ReconciliationID == r.ID || (!r.IsLocked() && r.CheckClear(transaction) == nil) {
+			candidates = append(candidates, transaction)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Date.Before(candidates[j].Date)
+	})
+	return candidates
+}
+
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+func date(value string) time.Time {
+	day, _ := time.Parse("2006-01-02", value)
+	return day
+}
+
+func transaction(id string, transactionType transactiondomain.TransactionType, amount, day string) *transactiondomain.Transaction {
+	return transactiondomain.NewTransaction(id, "user-1", "wallet-1", "cat-1", transactionType, shareddomain.MustParseAmount(amount), id, date(day), "system")
+}
+
+func TestCheckStatementDate(t *testing.T) {
+	today := date("2026-05-10")
+	lastLocked := NewReconciliation("rec-0", "user-1", "wallet-1", date("2026-03-31"), shareddomain.MustParseAmount("100"), "system")
+	lastLocked.Status = StatusLocked
+
+	tests := []struct {
+		name       string
+		statement  time.Time
+		lastLocked *Reconciliation
+		wantErr    error
+	}{
+		{"first reconciliation", date("2026-04-30"), nil, nil},
+		{"today", today.Add(15 * time.Hour), lastLocked, nil},
+		{"same day as the last one", date("2026-03-31"), lastLocked, nil},
+		{"missing", time.Time{}, nil, ErrStatementDateRequired},
+		{"future", date("2026-05-11"), nil, ErrStatementDateInFuture},
+		{"before the last one", date("2026-03-30"), lastLocked, ErrStatementBeforeLastLocked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckStatementDate(tt.statement, today, tt.lastLocked); err != tt.wantErr {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestReconciliation_CheckClear(t *testing.T) {
+	reconciliation := NewReconciliation("rec-1", "user-1", "wallet-1", date("2026-04-30"), shareddomain.MustParseAmount("100"), "system")
+
+	otherWallet := transaction("other-wallet", transactiondomain.TransactionTypeExpense, "10", "2026-04-10")
+	otherWallet.WalletID = "wallet-2"
+	reconciled := transaction("reconciled", transactiondomain.TransactionTypeExpense, "10", "2026-03-10")
+	reconciled.ReconciliationID, reconciled.Reconciled = "rec-0", true
+	cleared := transaction("cleared", transactiondomain.TransactionTypeExpense, "10", "2026-04-10")
+	cleared.ReconciliationID = reconciliation.ID
+
+	tests := []struct {
+		name        string
+		transaction *transactiondomain.Transaction
+		wantErr     error
+	}{
+		{"not cleared", transaction("new", transactiondomain.TransactionTypeExpense, "10", "2026-04-30"), nil},
+		{"cleared in it", cleared, nil},
+		{"other wallet", otherWallet, ErrTransactionOtherWallet},
+		{"reconciled", reconciled, ErrTransactionReconciled},
+		{"after the statement", transaction("late", transactiondomain.TransactionTypeExpense, "10", "2026-05-01"), ErrTransactionAfterStatement},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := reconciliation.CheckClear(tt.transaction); err != tt.wantErr {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestReconciliation_Summarize(t *testing.T) {
+	reconciliation := NewReconciliation("rec-1", "user-1", "wallet-1", date("2026-04-30"), shareddomain.MustParseAmount("1150"), "system")
+
+	// The wallet opened with 1000, March was reconciled with the salary and
+	// April has the rent and a refund cleared, an uncleared purchase and a
+	// purchase after the statement.
+	salary := transaction("salary", transactiondomain.TransactionTypeIncome, "500", "2026-03-31")
+	salary.ReconciliationID, salary.Reconciled = "rec-0", true
+	rent := transaction("rent", transactiondomain.TransactionTypeExpense, "400", "2026-04-01")
+	rent.ReconciliationID = reconciliation.ID
+	refund := transaction("refund", transactiondomain.TransactionTypeIncome, "50", "2026-04-20")
+	refund.ReconciliationID = reconciliation.ID
+	groceries := transaction("groceries", transactiondomain.TransactionTypeExpense, "30", "2026-04-29")
+	later := transaction("later", transactiondomain.TransactionTypeExpense, "20", "2026-05-02")
+
+	transactions := []*transactiondomain.Transaction{later, groceries, refund, rent, salary}
+	balance := shareddomain.MustParseAmount("1100")
+
+	summary := reconciliation.Summarize(balance, transactions)
+
+	if summary.ClearedBalance.String() != "1150" || !summary.Difference.IsZero() {
+		t.Errorf("expected a cleared balance of 1150 and no difference, got %s and %s", summary.ClearedBalance, summary.Difference)
+	}
+	if summary.StartingBalance.String() != "1500" {
+		t.Errorf("expected a starting balance of 1500, got %s", summary.StartingBalance)
+	}
+	if summary.ClearedCount != 2 || summary.ClearedDeposits.String() != "50" || summary.ClearedPayments.String() != "400" {
+		t.Errorf("unexpected cleared transactions %+v", summary)
+	}
+
+	groceries.ReconciliationID = reconciliation.ID
+	summary = reconciliation.Summarize(balance, transactions)
+	if summary.Difference.String() != "30" {
+		t.Errorf("expected a difference of 30, got %s", summary.Difference)
+	}
+
+	if err := reconciliation.Lock(summary, "system"); err != ErrNotBalanced {
+		t.Errorf("expected ErrNotBalanced, got %v", err)
+	}
+}
+
+func TestReconciliation_Lock(t *testing.T) {
+	reconciliation := NewReconciliation("rec-1", "user-1", "wallet-1", date("2026-04-30"), shareddomain.MustParseAmount("100"), "system")
+
+	if err := reconciliation.Lock(Summary{}, "system"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reconciliation.IsLocked() || reconciliation.LockedAt == nil {
+		t.Error("expected the reconciliation to be locked")
+	}
+
+	if err := reconciliation.Lock(Summary{}, "system"); err != ErrReconciliationLocked {
+		t.Errorf("expected ErrReconciliationLocked, got %v", err)
+	}
+	if err := reconciliation.Update(date("2026-05-01"), shareddomain.MustParseAmount("1"), "system"); err != ErrReconciliationLocked {
+		t.Errorf("expected ErrReconciliationLocked, got %v", err)
+	}
+
+	summary := reconciliation.Summarize(shareddomain.MustParseAmount("250"), nil)
+	if summary.ClearedBalance.String() != "100" || !summary.Difference.IsZero() {
+		t.Errorf("expected a locked reconciliation to report its ending balance, got %+v", summary)
+	}
+}
+
+func TestReconciliation_Candidates(t *testing.T) {
+	reconciliation := NewReconciliation("rec-1", "user-1", "wallet-1", date("2026-04-30"), shareddomain.MustParseAmount("100"), "system")
+
+	reconciled := transaction("reconciled", transactiondomain.TransactionTypeExpense, "10", "2026-03-10")
+	reconciled.ReconciliationID, reconciled.Reconciled = "rec-0", true
+	cleared := transaction("cleared", transactiondomain.TransactionTypeExpense, "10", "2026-04-20")
+	cleared.ReconciliationID = reconciliation.ID
+	pending := transaction("pending", transactiondomain.TransactionTypeExpense, "10", "2026-04-02")
+	later := transaction("later", transactiondomain.TransactionTypeExpense, "10", "2026-05-02")
+
+	candidates := reconciliation.Candidates([]*transactiondomain.Transaction{later, cleared, reconciled, pending})
+	if len(candidates) != 2 || candidates[0].ID != "pending" || candidates[1].ID != "cleared" {
+		t.Errorf("expected pending and cleared, oldest first, got %v", candidates)
+	}
+
+	reconciliation.Status = StatusLocked
+	candidates = reconciliation.Candidates([]*transactiondomain.Transaction{later, cleared, reconciled, pending})
+	if len(candidates) != 1 || candidates[0].ID != "cleared" {
+		t.Errorf("expected only the cleared transaction once locked, got %v", candidates)
+	}
+}
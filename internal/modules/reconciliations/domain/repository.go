@@ -0,0 +1,23 @@
+package domain
+
+type ReconciliationRepository interface {
+	Create(reconciliation *Reconciliation) error
+	GetByID(id string, userID string) (*Reconciliation, error)
+	// List returns the reconciliations of a wallet, the latest statement
+	// first.
+	List(walletID string, userID string) ([]*Reconciliation, error)
+	// Update saves the statement of an open reconciliation. The
+	// transactions cleared in it that are dated after the new statement
+	// date are no longer cleared.
+	Update(reconciliation *Reconciliation) error
+	// SetCleared marks the transactions with the given ids as cleared in an
+	// open reconciliation, or as not cleared when cleared is false.
+	SetCleared(reconciliation *Reconciliation, transactionIDs []string, cleared bool) error
+	// Lock saves a locked reconciliation and makes the transactions cleared
+	// in it reconciled. It fails with ErrNotBalanced if the wallet no longer
+	// balances with the statement.
+	Lock(reconciliation *Reconciliation) error
+	// Delete deletes an open reconciliation, which leaves its transactions
+	// not cleared.
+	Delete(id string, userID string) error
+}
@@ -0,0 +1,367 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"fin-flow-api/internal/modules/reconciliations/domain"
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const reconciliationColumns = `id, user_id, wallet_id, statement_date, ending_balance, status, locked_at, created_at, modified_at, created_by, modified_by`
+
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+func (r *Repository) Create(reconciliation *domain.Reconciliation) error {
+	query := `
+		INSERT INTO reconciliations (` + reconciliationColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err := r.pool.Exec(
+		context.Background(),
+		query,
+		reconciliation.ID,
+		reconciliation.UserID,
+		reconciliation.WalletID,
+		reconciliation.StatementDate,
+		reconciliation.EndingBalance,
+		string(reconciliation.Status),
+		reconciliation.LockedAt,
+		reconciliation.CreatedAt,
+		reconciliation.ModifiedAt,
+		reconciliation.CreatedBy,
+		reconciliation.ModifiedBy,
+	)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			switch pgErr.Code {
+			case "23505": // unique_violation
+				return domain.ErrOpenReconciliationExists
+			case "23503": // foreign_key_violation
+				return fmt.Errorf("invalid wallet reference")
+			}
+		}
+		return fmt.Errorf("failed to create reconciliation: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetByID(id string, userID string) (*domain.Reconciliation, error) {
+	checkQuery := `SELECT user_id FROM reconciliations WHERE id = $1`
+	var reconciliationUserID string
+	err := r.pool.QueryRow(context.Background(), checkQuery, id).Scan(&reconciliationUserID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("reconciliation not found")
+		}
+		return nil, fmt.Errorf("failed to get reconciliation: %w", err)
+	}
+
+	if reconciliationUserID != userID {
+		return nil, fmt.Errorf("unauthorized access to reconciliation")
+	}
+
+	query := `SELECT ` + reconciliationColumns + ` FROM reconciliations WHERE id = $1 AND user_id = $2`
+
+	reconciliation, err := scanReconciliation(r.pool.QueryRow(context.Background(), query, id, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("reconciliation not found")
+		}
+		return nil, fmt.Errorf("failed to get reconciliation: %w", err)
+	}
+
+	return reconciliation, nil
+}
+
+func (r *Repository) List(walletID string, userID string) ([]*domain.Reconciliation, error) {
+	query := `
+		SELECT ` + reconciliationColumns + `
+		FROM reconciliations
+		WHERE wallet_id = $1 AND user_id = $2
+		ORDER BY statement_date DESC, created_at DESC
+	`
+
+	rows, err := r.pool.Query(context.Background(), query, walletID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reconciliations: %w", err)
+	}
+	defer rows.Close()
+
+	var reconciliations []*domain.Reconciliation
+	for rows.Next() {
+		reconciliation, err := scanReconciliation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reconciliation: %w", err)
+		}
+		reconciliations = append(reconciliations, reconciliation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate reconciliations: %w", err)
+	}
+
+	return reconciliations, nil
+}
+
+func (r *Repository) Update(reconciliation *domain.Reconciliation) error {
+	ctx := context.Background()
+
+	dbTx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update reconciliation: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	query := `
+		UPDATE reconciliations
+		SET statement_date = $2, ending_balance = $3, modified_at = $4, modified_by = $5
+		WHERE id = $1 AND user_id = $6 AND status = $7
+	`
+
+	result, err := dbTx.Exec(
+		ctx,
+		query,
+		reconciliation.ID,
+		reconciliation.StatementDate,
+		reconciliation.EndingBalance,
+		reconciliation.ModifiedAt,
+		reconciliation.ModifiedBy,
+		reconciliation.UserID,
+		string(domain.StatusOpen),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update reconciliation: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrReconciliationLocked
+	}
+
+	unclearQuery := `UPDATE transactions SET reconciliation_id = NULL WHERE reconciliation_id = $1 AND date > $2`
+	if _, err := dbTx.Exec(ctx, unclearQuery, reconciliation.ID, reconciliation.StatementDate); err != nil {
+		return fmt.Errorf("failed to update reconciliation: %w", err)
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit reconciliation update: %w", err)
+	}
+
+	return nil
+}
+
+// SetCleared only touches transactions that can still be cleared, so a
+// transaction reconciled or moved to another wallet after the service
+// checked it is left alone.
+func (r *Repository) SetCleared(reconciliation *domain.Reconciliation, transactionIDs []string, cleared bool) error {
+	ctx := context.Background()
+
+	dbTx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to clear transactions: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	locked, err := lockReconciliation(ctx, dbTx, reconciliation)
+	if err != nil {
+		return err
+	}
+
+	var query string
+	args := []any{reconciliation.ID, transactionIDs, reconciliation.UserID}
+	if cleared {
+		query = `
+			UPDATE transactions
+			SET reconciliation_id = $1
+			WHERE id = ANY($2) AND user_id = $3 AND wallet_id = $4 AND date <= $5
+				AND NOT reconciled AND (reconciliation_id IS NULL OR reconciliation_id = $1)
+		`
+		args = append(args, locked.WalletID, locked.StatementDate)
+	} else {
+		query = `
+			UPDATE transactions
+			SET reconciliation_id = NULL
+			WHERE id = ANY($2) AND user_id = $3 AND reconciliation_id = $1 AND NOT reconciled
+		`
+	}
+
+	if _, err := dbTx.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to clear transactions: %w", err)
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit cleared transactions: %w", err)
+	}
+
+	return nil
+}
+
+// Lock works out the cleared balance again with the wallet row locked, so
+// a transaction posted after the service summarized the reconciliation
+// cannot slip in unnoticed.
+func (r *Repository) Lock(reconciliation *domain.Reconciliation) error {
+	ctx := context.Background()
+
+	dbTx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to lock reconciliation: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	var balance shareddomain.Amount
+	balanceQuery := `SELECT balance FROM wallets WHERE id = $1 AND user_id = $2 FOR UPDATE`
+	if err := dbTx.QueryRow(ctx, balanceQuery, reconciliation.WalletID, reconciliation.UserID).Scan(&balance); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("wallet not found")
+		}
+		return fmt.Errorf("failed to lock reconciliation: %w", err)
+	}
+
+	if _, err := lockReconciliation(ctx, dbTx, reconciliation); err != nil {
+		return err
+	}
+
+	var uncleared shareddomain.Amount
+	unclearedQuery := `
+		SELECT COALESCE(SUM(CASE WHEN type = $3 OR (type = $4 AND is_inbound) THEN amount ELSE -amount END), 0)
+		FROM transactions
+		WHERE wallet_id = $1 AND user_id = $2 AND reconciliation_id IS NULL
+	`
+	err = dbTx.QueryRow(
+		ctx,
+		unclearedQuery,
+		reconciliation.WalletID,
+		reconciliation.UserID,
+		transactiondomain.TransactionTypeIncome.Value(),
+		transactiondomain.TransactionTypeTransfer.Value(),
+	).Scan(&uncleared)
+	if err != nil {
+		return fmt.Errorf("failed to lock reconciliation: %w", err)
+	}
+
+	if !balance.Sub(uncleared).Equal(reconciliation.EndingBalance) {
+		return domain.ErrNotBalanced
+	}
+
+	query := `
+		UPDATE reconciliations
+		SET status = $2, locked_at = $3, modified_at = $4, modified_by = $5
+		WHERE id = $1
+	`
+	_, err = dbTx.Exec(
+		ctx,
+		query,
+		reconciliation.ID,
+		string(reconciliation.Status),
+		reconciliation.LockedAt,
+		reconciliation.ModifiedAt,
+		reconciliation.ModifiedBy,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to lock reconciliation: %w", err)
+	}
+
+	reconcileQuery := `UPDATE transactions SET reconciled = TRUE WHERE reconciliation_id = $1`
+	if _, err := dbTx.Exec(ctx, reconcileQuery, reconciliation.ID); err != nil {
+		return fmt.Errorf("failed to lock reconciliation: %w", err)
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit reconciliation lock: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) Delete(id string, userID string) error {
+	checkQuery := `SELECT user_id FROM reconciliations WHERE id = $1`
+	var reconciliationUserID string
+	err := r.pool.QueryRow(context.Background(), checkQuery, id).Scan(&reconciliationUserID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("reconciliation not found")
+		}
+		return fmt.Errorf("failed to delete reconciliation: %w", err)
+	}
+
+	if reconciliationUserID != userID {
+		return fmt.Errorf("unauthorized access to reconciliation")
+	}
+
+	// The cleared transactions stay in place; their reconciliation_id is
+	// cleared by the foreign key.
+	query := `DELETE FROM reconciliations WHERE id = $1 AND user_id = $2 AND status = $3`
+
+	result, err := r.pool.Exec(context.Background(), query, id, userID, string(domain.StatusOpen))
+	if err != nil {
+		return fmt.Errorf("failed to delete reconciliation: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrReconciliationLocked
+	}
+
+	return nil
+}
+
+// lockReconciliation locks the row of an open reconciliation for the rest
+// of the database transaction and returns it as stored.
+func lockReconciliation(ctx context.Context, dbTx pgx.Tx, reconciliation *domain.Reconciliation) (*domain.Reconciliation, error) {
+	query := `SELECT ` + reconciliationColumns + ` FROM reconciliations WHERE id = $1 AND user_id = $2 FOR UPDATE`
+
+	locked, err := scanReconciliation(dbTx.QueryRow(ctx, query, reconciliation.ID, reconciliation.UserID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("reconciliation not found")
+		}
+		return nil, fmt.Errorf("failed to get reconciliation: %w", err)
+	}
+
+	if err := locked.CheckOpen(); err != nil {
+		return nil, err
+	}
+
+	return locked, nil
+}
+
+func scanReconciliation(row pgx.Row) (*domain.Reconciliation, error) {
+	var reconciliation domain.Reconciliation
+	var status string
+
+	err := row.Scan(
+		&reconciliation.ID,
+		&reconciliation.UserID,
+		&reconciliation.WalletID,
+		&reconciliation.StatementDate,
+		&reconciliation.EndingBalance,
+		&status,
+		&reconciliation.LockedAt,
+		&reconciliation.CreatedAt,
+		&reconciliation.ModifiedAt,
+		&reconciliation.CreatedBy,
+		&reconciliation.ModifiedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	reconciliation.Status = domain.Status(status)
+
+	return &reconciliation, nil
+}
@@ -0,0 +1,356 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"fin-flow-api/internal/modules/reconciliations/application/contracts/commands"
+	"fin-flow-api/internal/modules/reconciliations/application/contracts/queries"
+	basehandler "fin-flow-api/internal/shared/http"
+)
+
+const dateLayout = "2006-01-02"
+
+type reconciliationService interface {
+	Create(ctx context.Context, req commands.ReconciliationRequest) (*queries.ReconciliationResponse, error)
+	GetByID(ctx context.Context, id string) (*queries.ReconciliationResponse, error)
+	List(ctx context.Context, walletID string) ([]*queries.ReconciliationResponse, error)
+	Update(ctx context.Context, id string, req commands.ReconciliationRequest) (*queries.ReconciliationResponse, error)
+	Clear(ctx context.Context, id string, req commands.ClearRequest) (*queries.ReconciliationResponse, error)
+	Lock(ctx context.Context, id string) (*queries.ReconciliationResponse, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type Handler struct {
+	reconciliationService reconciliationService
+}
+
+func NewHandler(reconciliationService reconciliationService) *Handler {
+	return &Handler{
+		reconciliationService: reconciliationService,
+	}
+}
+
+func (h *Handler) CreateReconciliation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var reqDTO ReconciliationRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	if strings.TrimSpace(reqDTO.WalletID) == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Wallet ID is required")
+		return
+	}
+
+	cmd, err := toReconciliationCommand(reqDTO)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	reconciliation, err := h.reconciliationService.Create(r.Context(), cmd)
+	if err != nil {
+		statusCode, errorMsg := reconciliationErrorResponse(err, "create")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusCreated, toReconciliationResponse(reconciliation))
+}
+
+func (h *Handler) GetReconciliation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id := reconciliationIDFromPath(r.URL.Path)
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Reconciliation ID is required in the URL path")
+		return
+	}
+
+	reconciliation, err := h.reconciliationService.GetByID(r.Context(), id)
+	if err != nil {
+		statusCode, errorMsg := reconciliationErrorResponse(err, "access")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, toReconciliationResponse(reconciliation))
+}
+
+func (h *Handler) ListReconciliations(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	walletID := strings.TrimSpace(r.URL.Query().Get("wallet_id"))
+	if walletID == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Query parameter wallet_id is required")
+		return
+	}
+
+	reconciliations, err := h.reconciliationService.List(r.Context(), walletID)
+	if err != nil {
+		statusCode, errorMsg := reconciliationErrorResponse(err, "access")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	responses := make([]ReconciliationResponse, len(reconciliations))
+	for i, reconciliation := range reconciliations {
+		responses[i] = toReconciliationResponse(reconciliation)
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, responses)
+}
+
+func (h *Handler) UpdateReconciliation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id := reconciliationIDFromPath(r.URL.Path)
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Reconciliation ID is required in the URL path")
+		return
+	}
+
+	var reqDTO ReconciliationRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	cmd, err := toReconciliationCommand(reqDTO)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	reconciliation, err := h.reconciliationService.Update(r.Context(), id, cmd)
+	if err != nil {
+		statusCode, errorMsg := reconciliationErrorResponse(err, "update")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, toReconciliationResponse(reconciliation))
+}
+
+func (h *Handler) ClearTransactions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id := reconciliationIDFromPath(r.URL.Path)
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Reconciliation ID is required in the URL path")
+		return
+	}
+
+	var reqDTO ClearRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	cmd, err := toClearCommand(reqDTO)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	reconciliation, err := h.reconciliationService.Clear(r.Context(), id, cmd)
+	if err != nil {
+		statusCode, errorMsg := reconciliationErrorResponse(err, "update")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, toReconciliationResponse(reconciliation))
+}
+
+func (h *Handler) LockReconciliation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id := reconciliationIDFromPath(r.URL.Path)
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Reconciliation ID is required in the URL path")
+		return
+	}
+
+	reconciliation, err := h.reconciliationService.Lock(r.Context(), id)
+	if err != nil {
+		statusCode, errorMsg := reconciliationErrorResponse(err, "lock")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, toReconciliationResponse(reconciliation))
+}
+
+func (h *Handler) DeleteReconciliation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	id := reconciliationIDFromPath(r.URL.Path)
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Reconciliation ID is required in the URL path")
+		return
+	}
+
+	if err := h.reconciliationService.Delete(r.Context(), id); err != nil {
+		statusCode, errorMsg := reconciliationErrorResponse(err, "delete")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Reconciliation deleted successfully")
+}
+
+func reconciliationIDFromPath(path string) string {
+	path = strings.TrimPrefix(path, "/reconciliations/")
+	return strings.Split(path, "/")[0]
+}
+
+func toReconciliationCommand(req ReconciliationRequest) (commands.ReconciliationRequest, error) {
+	if strings.TrimSpace(req.StatementDate) == "" {
+		return commands.ReconciliationRequest{}, &ValidationError{Field: "statement_date", Message: "Statement date is required"}
+	}
+
+	statementDate, err := time.Parse(dateLayout, strings.TrimSpace(req.StatementDate))
+	if err != nil {
+		return commands.ReconciliationRequest{}, &ValidationError{Field: "statement_date", Message: "Statement date must use the YYYY-MM-DD format"}
+	}
+
+	if req.EndingBalance == nil {
+		return commands.ReconciliationRequest{}, &ValidationError{Field: "ending_balance", Message: "Ending balance is required"}
+	}
+
+	return commands.ReconciliationRequest{
+		WalletID:      strings.TrimSpace(req.WalletID),
+		StatementDate: statementDate,
+		EndingBalance: *req.EndingBalance,
+	}, nil
+}
+
+func toClearCommand(req ClearRequest) (commands.ClearRequest, error) {
+	if len(req.TransactionIDs) == 0 {
+		return commands.ClearRequest{}, &ValidationError{Field: "transaction_ids", Message: "At least one transaction ID is required"}
+	}
+
+	ids := make([]string, len(req.TransactionIDs))
+	for i, id := range req.TransactionIDs {
+		ids[i] = strings.TrimSpace(id)
+		if ids[i] == "" {
+			return commands.ClearRequest{}, &ValidationError{Field: "transaction_ids", Message: "Transaction IDs must not be empty"}
+		}
+	}
+
+	cleared := true
+	if req.Cleared != nil {
+		cleared = *req.Cleared
+	}
+
+	return commands.ClearRequest{TransactionIDs: ids, Cleared: cleared}, nil
+}
+
+func reconciliationErrorResponse(err error, action string) (int, string) {
+	errorMsg := err.Error()
+
+	switch {
+	case strings.Contains(errorMsg, "user not authenticated"):
+		return http.StatusUnauthorized, "Authentication required"
+	case strings.Contains(errorMsg, "unauthorized access to reconciliation"):
+		return http.StatusForbidden, "You do not have permission to " + action + " this reconciliation"
+	case strings.Contains(errorMsg, "unauthorized access to wallet"):
+		return http.StatusForbidden, "You do not have permission to use this wallet"
+	case strings.Contains(errorMsg, "unauthorized access to transaction"):
+		return http.StatusForbidden, "You do not have permission to use this transaction"
+	case strings.Contains(errorMsg, "reconciliation not found"):
+		return http.StatusNotFound, "Reconciliation not found"
+	case strings.Contains(errorMsg, "wallet not found"), strings.Contains(errorMsg, "invalid wallet reference"):
+		return http.StatusBadRequest, "Wallet not found"
+	case strings.Contains(errorMsg, "transaction not found"):
+		return http.StatusBadRequest, "Transaction not found"
+	case strings.Contains(errorMsg, "decimal places"):
+		return http.StatusBadRequest, "Ending balance has more decimal places than the wallet currency allows"
+	case strings.Contains(errorMsg, "already has an open reconciliation"),
+		strings.Contains(errorMsg, "reconciliation is locked"),
+		strings.Contains(errorMsg, "once the difference is zero"),
+		strings.Contains(errorMsg, "has already been reconciled"):
+		return http.StatusConflict, errorMsg
+	case strings.Contains(errorMsg, "wallet is required"),
+		strings.Contains(errorMsg, "statement date"),
+		strings.Contains(errorMsg, "only transactions"):
+		return http.StatusBadRequest, errorMsg
+	}
+
+	return http.StatusInternalServerError, errorMsg
+}
+
+func toReconciliationResponse(reconciliation *queries.ReconciliationResponse) ReconciliationResponse {
+	response := ReconciliationResponse{
+		ID:              reconciliation.ID,
+		WalletID:        reconciliation.WalletID,
+		StatementDate:   reconciliation.StatementDate.Format(dateLayout),
+		EndingBalance:   reconciliation.EndingBalance,
+		StartingBalance: reconciliation.StartingBalance,
+		ClearedBalance:  reconciliation.ClearedBalance,
+		Difference:      reconciliation.Difference,
+		ClearedDeposits: reconciliation.ClearedDeposits,
+		ClearedPayments: reconciliation.ClearedPayments,
+		ClearedCount:    reconciliation.ClearedCount,
+		Status:          reconciliation.Status,
+		LockedAt:        reconciliation.LockedAt,
+		CreatedAt:       reconciliation.CreatedAt,
+		UpdatedAt:       reconciliation.UpdatedAt,
+	}
+
+	if reconciliation.Transactions != nil {
+		response.Transactions = make([]ReconciliationTransactionResponse, len(reconciliation.Transactions))
+		for i, transaction := range reconciliation.Transactions {
+			response.Transactions[i] = ReconciliationTransactionResponse{
+				ID:          transaction.ID,
+				CategoryID:  transaction.CategoryID,
+				Type:        transaction.Type,
+				TypeName:    transaction.TypeName,
+				Amount:      transaction.Amount,
+				Description: transaction.Description,
+				Payee:       transaction.Payee,
+				Date:        transaction.Date.Format(dateLayout),
+				Cleared:     transaction.Cleared,
+			}
+		}
+	}
+
+	return response
+}
+
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
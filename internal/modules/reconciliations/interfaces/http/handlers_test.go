@@ -0,0 +1,313 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"fin-flow-api/internal/modules/reconciliations/application/contracts/commands"
+	"fin-flow-api/internal/modules/reconciliations/application/contracts/queries"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+type mockReconciliationService struct {
+	err         error
+	lastID      string
+	lastWallet  string
+	lastCommand commands.ReconciliationRequest
+	lastClear   commands.ClearRequest
+}
+
+func (m *mockReconciliationService) result() (*queries.ReconciliationResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return sampleReconciliation(), nil
+}
+
+func (m *mockReconciliationService) Create(ctx context.Context, req commands.ReconciliationRequest) (*queries.ReconciliationResponse, error) {
+	m.lastCommand = req
+	return m.result()
+}
+
+func (m *mockReconciliationService) GetByID(ctx context.Context, id string) (*queries.ReconciliationResponse, error) {
+	m.lastID = id
+	return m.result()
+}
+
+func (m *mockReconciliationService) List(ctx context.Context, walletID string) ([]*queries.ReconciliationResponse, error) {
+	m.lastWallet = walletID
+	if m.err != nil {
+		return nil, m.err
+	}
+	reconciliation := sampleReconciliation()
+	reconciliation.Transactions = nil
+	return []*queries.ReconciliationResponse{reconciliation}, nil
+}
+
+func (m *mockReconciliationService) Update(ctx context.Context, id string, req commands.ReconciliationRequest) (*queries.ReconciliationResponse, error) {
+	m.lastID = id
+	m.lastCommand = req
+	return m.result()
+}
+
+func (m *mockReconciliationService) Clear(ctx context.Context, id string, req commands.ClearRequest) (*queries.ReconciliationResponse, error) {
+	m.lastID = id
+	m.lastClear = req
+	return m.result()
+}
+
+func (m *mockReconciliationService) Lock(ctx context.Context, id string) (*queries.ReconciliationResponse, error) {
+	m.lastID = id
+	return m.result()
+}
+
+func (m *mockReconciliationService) Delete(ctx context.Context, id string) error {
+	m.lastID = id
+	return m.err
+}
+
+func createContextWithUserID(userID string) context.Context {
+	return context.WithValue(context.Background(), middleware.UserIDKey, userID)
+}
+
+func sampleReconciliation() *queries.ReconciliationResponse {
+	return &queries.ReconciliationResponse{
+		ID:              "rec1",
+		WalletID:        "wallet1",
+		StatementDate:   time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC),
+		EndingBalance:   shareddomain.MustParseAmount("1120"),
+		StartingBalance: shareddomain.MustParseAmount("1000"),
+		ClearedBalance:  shareddomain.MustParseAmount("1500"),
+		Difference:      shareddomain.MustParseAmount("-380"),
+		ClearedDeposits: shareddomain.MustParseAmount("500"),
+		ClearedCount:    1,
+		Status:          "open",
+		Transactions: []*queries.ReconciliationTransactionResponse{
+			{ID: "salary", Type: 1, TypeName: "Income", Amount: shareddomain.MustParseAmount("500"), Date: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), Cleared: true},
+			{ID: "rent", Type: 0, TypeName: "Expense", Amount: shareddomain.MustParseAmount("-380"), Date: time.Date(2026, 4, 5, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+}
+
+func TestCreateReconciliation_Success(t *testing.T) {
+	service := &mockReconciliationService{}
+	handler := &Handler{reconciliationService: service}
+
+	body := []byte(`{"wallet_id": " wallet1 ", "statement_date": "2026-04-30", "ending_balance": "1120"}`)
+	req := httptest.NewRequest("POST", "/reconciliations", bytes.NewBuffer(body))
+	req = req.WithContext(createContextWithUserID("user1"))
+	rr := httptest.NewRecorder()
+
+	handler.CreateReconciliation(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	got := service.lastCommand
+	if got.WalletID != "wallet1" || got.StatementDate.Day() != 30 || got.EndingBalance.String() != "1120" {
+		t.Errorf("unexpected command %+v", got)
+	}
+
+	var response ReconciliationResponse
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.StatementDate != "2026-04-30" || response.Difference.String() != "-380" || len(response.Transactions) != 2 {
+		t.Errorf("unexpected response %+v", response)
+	}
+	if response.Transactions[1].Date != "2026-04-05" || response.Transactions[1].Cleared {
+		t.Errorf("unexpected transaction %+v", response.Transactions[1])
+	}
+}
+
+func TestCreateReconciliation_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"missing wallet", `{"statement_date": "2026-04-30", "ending_balance": "1120"}`},
+		{"missing date", `{"wallet_id": "wallet1", "ending_balance": "1120"}`},
+		{"bad date", `{"wallet_id": "wallet1", "statement_date": "30/04/2026", "ending_balance": "1120"}`},
+		{"missing balance", `{"wallet_id": "wallet1", "statement_date": "2026-04-30"}`},
+		{"invalid JSON", `{`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &Handler{reconciliationService: &mockReconciliationService{}}
+
+			req := httptest.NewRequest("POST", "/reconciliations", bytes.NewBufferString(tt.body))
+			rr := httptest.NewRecorder()
+
+			handler.CreateReconciliation(rr, req)
+
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rr.Code)
+			}
+		})
+	}
+}
+
+func TestListReconciliations(t *testing.T) {
+	service := &mockReconciliationService{}
+	handler := &Handler{reconciliationService: service}
+
+	req := httptest.NewRequest("GET", "/reconciliations?wallet_id=wallet1", nil)
+	req = req.WithContext(createContextWithUserID("user1"))
+	rr := httptest.NewRecorder()
+
+	handler.ListReconciliations(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if service.lastWallet != "wallet1" {
+		t.Errorf("expected wallet1, got %q", service.lastWallet)
+	}
+	if bytes.Contains(rr.Body.Bytes(), []byte(`"transactions"`)) {
+		t.Errorf("expected the list to leave transactions out, got %s", rr.Body.String())
+	}
+
+	req = httptest.NewRequest("GET", "/reconciliations", nil)
+	rr = httptest.NewRecorder()
+
+	handler.ListReconciliations(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 without wallet_id, got %d", rr.Code)
+	}
+}
+
+func TestClearTransactions(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantCleared bool
+	}{
+		{"default", `{"transaction_ids": ["rent", " salary "]}`, true},
+		{"unclear", `{"transaction_ids": ["rent", "salary"], "cleared": false}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &mockReconciliationService{}
+			handler := &Handler{reconciliationService: service}
+
+			req := httptest.NewRequest("POST", "/reconciliations/rec1/clear", bytes.NewBufferString(tt.body))
+			req = req.WithContext(createContextWithUserID("user1"))
+			rr := httptest.NewRecorder()
+
+			handler.ClearTransactions(rr, req)
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+			}
+			got := service.lastClear
+			if service.lastID != "rec1" || len(got.TransactionIDs) != 2 || got.TransactionIDs[1] != "salary" || got.Cleared != tt.wantCleared {
+				t.Errorf("unexpected command %+v for %s", got, service.lastID)
+			}
+		})
+	}
+}
+
+func TestClearTransactions_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		body ClearRequest
+	}{
+		{"no transactions", ClearRequest{}},
+		{"empty id", ClearRequest{TransactionIDs: []string{"rent", " "}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := toClearCommand(tt.body); err == nil {
+				t.Error("expected validation error, got nil")
+			}
+		})
+	}
+}
+
+func TestLockReconciliation(t *testing.T) {
+	service := &mockReconciliationService{}
+	handler := &Handler{reconciliationService: service}
+
+	req := httptest.NewRequest("POST", "/reconciliations/rec1/lock", nil)
+	req = req.WithContext(createContextWithUserID("user1"))
+	rr := httptest.NewRecorder()
+
+	handler.LockReconciliation(rr, req)
+
+	if rr.Code != http.StatusOK || service.lastID != "rec1" {
+		t.Errorf("expected status 200 for rec1, got %d for %q", rr.Code, service.lastID)
+	}
+
+	req = httptest.NewRequest("GET", "/reconciliations/rec1/lock", nil)
+	rr = httptest.NewRecorder()
+
+	handler.LockReconciliation(rr, req)
+
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status 405, got %d", rr.Code)
+	}
+}
+
+func TestReconciliation_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{"unauthenticated", errors.New("user not authenticated"), http.StatusUnauthorized},
+		{"forbidden", errors.New("unauthorized access to reconciliation"), http.StatusForbidden},
+		{"forbidden transaction", errors.New("unauthorized access to transaction"), http.StatusForbidden},
+		{"not found", errors.New("reconciliation not found"), http.StatusNotFound},
+		{"transaction not found", errors.New("transaction not found"), http.StatusBadRequest},
+		{"not balanced", errors.New("reconciliation can only be locked once the difference is zero"), http.StatusConflict},
+		{"locked", errors.New("reconciliation is locked and can no longer be changed"), http.StatusConflict},
+		{"open exists", errors.New("the wallet already has an open reconciliation"), http.StatusConflict},
+		{"reconciled", errors.New("transaction has already been reconciled"), http.StatusConflict},
+		{"after statement", errors.New("only transactions dated on or before the statement date can be cleared"), http.StatusBadRequest},
+		{"future", errors.New("statement date cannot be in the future"), http.StatusBadRequest},
+		{"precision", errors.New("amount has more decimal places than the currency allows"), http.StatusBadRequest},
+		{"database", errors.New("failed to lock reconciliation: boom"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &Handler{reconciliationService: &mockReconciliationService{err: tt.err}}
+
+			req := httptest.NewRequest("POST", "/reconciliations/rec1/lock", nil)
+			rr := httptest.NewRecorder()
+
+			handler.LockReconciliation(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestDeleteReconciliation(t *testing.T) {
+	service := &mockReconciliationService{}
+	handler := &Handler{reconciliationService: service}
+
+	req := httptest.NewRequest("DELETE", "/reconciliations/rec1", nil)
+	req = req.WithContext(createContextWithUserID("user1"))
+	rr := httptest.NewRecorder()
+
+	handler.DeleteReconciliation(rr, req)
+
+	if rr.Code != http.StatusOK || service.lastID != "rec1" {
+		t.Errorf("expected status 200 for rec1, got %d for %q", rr.Code, service.lastID)
+	}
+}
@@ -0,0 +1,18 @@
+package http
+
+import shareddomain "fin-flow-api/internal/shared/domain"
+
+// ReconciliationRequest starts a reconciliation or changes its statement.
+// wallet_id is ignored when updating.
+type ReconciliationRequest struct {
+	WalletID      string               `json:"wallet_id"`
+	StatementDate string               `json:"statement_date"`
+	EndingBalance *shareddomain.Amount `json:"ending_balance"`
+}
+
+// ClearRequest marks transactions as cleared, or as not cleared when
+// cleared is false. cleared defaults to true.
+type ClearRequest struct {
+	TransactionIDs []string `json:"transaction_ids"`
+	Cleared        *bool    `json:"cleared"`
+}
@@ -0,0 +1,37 @@
+package http
+
+import (
+	"time"
+
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+type ReconciliationResponse struct {
+	ID              string                              `json:"id"`
+	WalletID        string                              `json:"wallet_id"`
+	StatementDate   string                              `json:"statement_date"`
+	EndingBalance   shareddomain.Amount                 `json:"ending_balance"`
+	StartingBalance shareddomain.Amount                 `json:"starting_balance"`
+	ClearedBalance  shareddomain.Amount                 `json:"cleared_balance"`
+	Difference      shareddomain.Amount                 `json:"difference"`
+	ClearedDeposits shareddomain.Amount                 `json:"cleared_deposits"`
+	ClearedPayments shareddomain.Amount                 `json:"cleared_payments"`
+	ClearedCount    int                                 `json:"cleared_count"`
+	Status          string                              `json:"status"`
+	LockedAt        *time.Time                          `json:"locked_at,omitempty"`
+	Transactions    []ReconciliationTransactionResponse `json:"transactions,omitempty"`
+	CreatedAt       time.Time                           `json:"created_at"`
+	UpdatedAt       time.Time                           `json:"updated_at"`
+}
+
+type ReconciliationTransactionResponse struct {
+	ID          string              `json:"id"`
+	CategoryID  string              `json:"category_id,omitempty"`
+	Type        int                 `json:"type"`
+	TypeName    string              `json:"type_name"`
+	Amount      shareddomain.Amount `json:"amount"`
+	Description string              `json:"description"`
+	Payee       string              `json:"payee,omitempty"`
+	Date        string              `json:"date"`
+	Cleared     bool                `json:"cleared"`
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"fin-flow-api/internal/shared/interface/jwt"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+var reconciliationHandler *Handler
+
+func SetupRoutes(mux *http.ServeMux, jwtService jwt.Service) {
+	mountReconciliations(mux, jwtService)
+}
+
+func mountReconciliations(mux *http.ServeMux, jwtService jwt.Service) {
+	mux.HandleFunc("/reconciliations", handleReconciliationsCollection(jwtService))
+
+	protectedHandler := middleware.RequireAuth(jwtService)(http.HandlerFunc(handleReconciliationsResource))
+	mux.Handle("/reconciliations/", protectedHandler)
+}
+
+func handleReconciliationsCollection(jwtService jwt.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.RequireAuth(jwtService)(http.HandlerFunc(reconciliationHandler.ListReconciliations)).ServeHTTP(w, r)
+		case http.MethodPost:
+			middleware.RequireAuth(jwtService)(http.HandlerFunc(reconciliationHandler.CreateReconciliation)).ServeHTTP(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func handleReconciliationsResource(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/clear"):
+		reconciliationHandler.ClearTransactions(w, r)
+		return
+	case strings.HasSuffix(r.URL.Path, "/lock"):
+		reconciliationHandler.LockReconciliation(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		reconciliationHandler.GetReconciliation(w, r)
+	case http.MethodPut:
+		reconciliationHandler.UpdateReconciliation(w, r)
+	case http.MethodDelete:
+		reconciliationHandler.DeleteReconciliation(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func SetHandler(handler *Handler) {
+	reconciliationHandler = handler
+}
@@ -0,0 +1,21 @@
+package commands
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+type RecurringRuleRequest struct {
+	WalletID    string
+	CategoryID  string
+	Type        int
+	Amount      domain.Amount
+	Description string
+	Frequency   int
+	Interval    int
+	DayOfMonth  int
+	StartDate   time.Time
+	EndDate     *time.Time
+	Active      *bool
+}
@@ -0,0 +1,29 @@
+package queries
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+type RecurringRuleResponse struct {
+	ID             string
+	WalletID       string
+	CategoryID     string
+	Type           int
+	TypeName       string
+	Amount         domain.Amount
+	Description    string
+	Frequency      int
+	FrequencyName  string
+	Interval       int
+	DayOfMonth     int
+	StartDate      time.Time
+	EndDate        *time.Time
+	NextOccurrence time.Time
+	Active         bool
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	CreatedBy      string
+	UpdatedBy      string
+}
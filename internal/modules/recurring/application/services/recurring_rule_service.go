@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	categorydomain "fin-flow-api/internal/modules/categories/domain"
+	"fin-flow-api/internal/modules/recurring/application/contracts/commands"
+	"fin-flow-api/internal/modules/recurring/application/contracts/queries"
+	"fin-flow-api/internal/modules/recurring/domain"
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/middleware"
+
+	"github.com/google/uuid"
+)
+
+type RecurringRuleService struct {
+	repository         domain.RecurringRuleRepository
+	walletRepository   walletdomain.WalletRepository
+	categoryRepository categorydomain.CategoryRepository
+	systemUser         string
+	now                func() time.Time
+}
+
+func NewRecurringRuleService(repository domain.RecurringRuleRepository, walletRepository walletdomain.WalletRepository, categoryRepository categorydomain.CategoryRepository, systemUser string) *RecurringRuleService {
+	return &RecurringRuleService{
+		repository:         repository,
+		walletRepository:   walletRepository,
+		categoryRepository: categoryRepository,
+		systemUser:         systemUser,
+		now:                time.Now,
+	}
+}
+
+func (s *RecurringRuleService) getUserIDFromContext(ctx context.Context) (string, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return "", errors.New("user not authenticated")
+	}
+	return userID, nil
+}
+
+// Create stores a new rule. Occurrences between the start date and today
+// are posted by the scheduler on its next run.
+func (s *RecurringRuleService) Create(ctx context.Context, req commands.RecurringRuleRequest) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	rule := domain.NewRecurringRule(
+		uuid.New().String(),
+		userID,
+		req.WalletID,
+		req.CategoryID,
+		transactiondomain.TransactionType(req.Type),
+		req.Amount,
+		req.Description,
+		domain.Frequency(req.Frequency),
+		intervalOrDefault(req.Interval),
+		req.DayOfMonth,
+		req.StartDate,
+		req.EndDate,
+		s.systemUser,
+	)
+	if req.Active != nil {
+		rule.Active = *req.Active
+	}
+
+	if err := s.validate(userID, rule); err != nil {
+		return err
+	}
+
+	return s.repository.Create(rule)
+}
+
+// Update replaces the rule definition and recomputes its schedule from
+// today, so occurrences that were already due are not posted again under
+// the new dates.
+func (s *RecurringRuleService) Update(ctx context.Context, id string, req commands.RecurringRuleRequest) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	existing, err := s.repository.GetByID(id, userID)
+	if err != nil {
+		return err
+	}
+
+	rule := domain.NewRecurringRule(
+		existing.ID,
+		userID,
+		req.WalletID,
+		req.CategoryID,
+		transactiondomain.TransactionType(req.Type),
+		req.Amount,
+		req.Description,
+		domain.Frequency(req.Frequency),
+		intervalOrDefault(req.Interval),
+		req.DayOfMonth,
+		req.StartDate,
+		req.EndDate,
+		existing.CreatedBy,
+	)
+	rule.Entity = existing.Entity
+	rule.Active = existing.Active
+	if req.Active != nil {
+		rule.Active = *req.Active
+	}
+
+	if err := s.validate(userID, rule); err != nil {
+		return err
+	}
+
+	rule.Reschedule(s.now())
+	rule.Entity.UpdateModified(s.systemUser)
+
+	return s.repository.Update(rule)
+}
+
+func (s *RecurringRuleService) Delete(ctx context.Context, id string) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return s.repository.Delete(id, userID)
+}
+
+func (s *RecurringRuleService) GetByID(ctx context.Context, id string) (*queries.RecurringRuleResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := s.repository.GetByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toRecurringRuleResponse(rule), nil
+}
+
+func (s *RecurringRuleService) List(ctx context.Context) ([]*queries.RecurringRuleResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := s.repository.List(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*queries.RecurringRuleResponse, len(rules))
+	for i, rule := range rules {
+		responses[i] = toRecurringRuleResponse(rule)
+	}
+
+	return responses, nil
+}
+
+func (s *RecurringRuleService) validate(userID string, rule *domain.RecurringRule) error {
+	if !transactiondomain.IsValidTransactionType(rule.Type.Value()) {
+		return transactiondomain.ErrInvalidTransactionType
+	}
+
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+
+	if !rule.Amount.IsPositive() {
+		return transactiondomain.ErrInvalidAmount
+	}
+
+	wallet, err := s.walletRepository.GetByID(rule.WalletID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !rule.Amount.FitsCurrency(wallet.Currency.String()) {
+		return shareddomain.ErrAmountPrecision
+	}
+
+	if rule.CategoryID == "" {
+		return transactiondomain.ErrCategoryRequired
+	}
+
+	category, err := s.categoryRepository.GetByID(rule.CategoryID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !rule.Type.AcceptsCategory(category.Type) {
+		return transactiondomain.ErrCategoryTypeMismatch
+	}
+
+	return nil
+}
+
+func intervalOrDefault(interval int) int {
+	if interval == 0 {
+		return 1
+	}
+	return interval
+}
+
+func toRecurringRuleResponse(rule *domain.RecurringRule) *queries.RecurringRuleResponse {
+	return &queries.RecurringRuleResponse{
+		ID:             rule.ID,
+		WalletID:       rule.WalletID,
+		CategoryID:     rule.CategoryID,
+		Type:           rule.Type.Value(),
+		TypeName:       rule.Type.String(),
+		Amount:         rule.Amount,
+		Description:    rule.Description,
+		Frequency:      rule.Frequency.Value(),
+		FrequencyName:  rule.Frequency.String(),
+		Interval:       rule.Interval,
+		DayOfMonth:     rule.DayOfMonth,
+		StartDate:      rule.StartDate,
+		EndDate:        rule.EndDate,
+		NextOccurrence: rule.NextOccurrence,
+		Active:         rule.Active,
+		CreatedAt:      rule.CreatedAt,
+		UpdatedAt:      rule.ModifiedAt,
+		CreatedBy:      rule.CreatedBy,
+		UpdatedBy:      rule.ModifiedBy,
+	}
+}
@@ -0,0 +1,398 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	categorydomain "fin-flow-api/internal/modules/categories/domain"
+	"fin-flow-api/internal/modules/recurring/application/contracts/commands"
+	"fin-flow-api/internal/modules/recurring/domain"
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+type mockRecurringRuleRepository struct {
+	rules   map[string]*domain.RecurringRule
+	listErr error
+}
+
+func newMockRecurringRuleRepository(rules ...*domain.RecurringRule) *mockRecurringRuleRepository {
+	repo := &mockRecurringRuleRepository{rules: make(map[string]*domain.RecurringRule)}
+	for _, rule := range rules {
+		repo.rules[rule.ID] = rule
+	}
+	return repo
+}
+
+func (m *mockRecurringRuleRepository) Create(rule *domain.RecurringRule) error {
+	m.rules[rule.ID] = rule
+	return nil
+}
+
+func (m *mockRecurringRuleRepository) GetByID(id string, userID string) (*domain.RecurringRule, error) {
+	rule, exists := m.rules[id]
+	if !exists {
+		return nil, errors.New("recurring rule not found")
+	}
+	if rule.UserID != userID {
+		return nil, errors.New("unauthorized access to recurring rule")
+	}
+	copied := *rule
+	return &copied, nil
+}
+
+func (m *mockRecurringRuleRepository) List(userID string) ([]*domain.RecurringRule, error) {
+	var result []*domain.RecurringRule
+	for _, rule := range m.rules {
+		if rule.UserID == userID {
+			result = append(result, rule)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockRecurringRuleRepository) Update(rule *domain.RecurringRule) error {
+	if _, exists := m.rules[rule.ID]; !exists {
+		return errors.New("recurring rule not found")
+	}
+	m.rules[rule.ID] = rule
+	return nil
+}
+
+func (m *mockRecurringRuleRepository) Delete(id string, userID string) error {
+	rule, exists := m.rules[id]
+	if !exists {
+		return errors.New("recurring rule not found")
+	}
+	if rule.UserID != userID {
+		return errors.New("unauthorized access to recurring rule")
+	}
+	delete(m.rules, id)
+	return nil
+}
+
+func (m *mockRecurringRuleRepository) ListDue(date time.Time) ([]*domain.RecurringRule, error) {
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	var result []*domain.RecurringRule
+	for _, rule := range m.rules {
+		if rule.Active && !rule.NextOccurrence.After(date) {
+			copied := *rule
+			result = append(result, &copied)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockRecurringRuleRepository) AdvanceSchedule(rule *domain.RecurringRule) error {
+	stored, exists := m.rules[rule.ID]
+	if !exists {
+		return errors.New("recurring rule not found")
+	}
+	if stored.NextOccurrence.Before(rule.NextOccurrence) {
+		stored.NextOccurrence = rule.NextOccurrence
+		stored.Active = rule.Active
+	}
+	return nil
+}
+
+type mockWalletRepository struct {
+	wallets map[string]*walletdomain.Wallet
+}
+
+func (m *mockWalletRepository) Create(wallet *walletdomain.Wallet) error {
+	return nil
+}
+
+func (m *mockWalletRepository) GetByID(id string, userID string) (*walletdomain.Wallet, error) {
+	wallet, exists := m.wallets[id]
+	if !exists {
+		return nil, errors.New("wallet not found")
+	}
+	if wallet.UserID != userID {
+		return nil, errors.New("unauthorized access to wallet")
+	}
+	return wallet, nil
+}
+
+func (m *mockWalletRepository) List(userID string) ([]*walletdomain.Wallet, error) {
+	return nil, nil
+}
+
+func (m *mockWalletRepository) Update(wallet *walletdomain.Wallet) error {
+	return nil
+}
+
+func (m *mockWalletRepository) Delete(id string, userID string) error {
+	return nil
+}
+
+type mockCategoryRepository struct {
+	categories map[string]*categorydomain.Category
+}
+
+func (m *mockCategoryRepository) Create(category *categorydomain.Category) error {
+	return nil
+}
+
+func (m *mockCategoryRepository) CreateAll(categories []*categorydomain.Category) error {
+	return nil
+}
+
+func (m *mockCategoryRepository) GetByID(id string, userID string) (*categorydomain.Category, error) {
+	category, exists := m.categories[id]
+	if !exists {
+		return nil, errors.New("category not found")
+	}
+	if category.UserID != userID {
+		return nil, errors.New("unauthorized access to category")
+	}
+	return category, nil
+}
+
+func (m *mockCategoryRepository) List(userID string) ([]*categorydomain.Category, error) {
+	return nil, nil
+}
+
+func (m *mockCategoryRepository) Update(category *categorydomain.Category) error {
+	return nil
+}
+
+func (m *mockCategoryRepository) Delete(id string, userID string, strategy categorydomain.DeleteStrategy) error {
+	return nil
+}
+
+func (m *mockCategoryRepository) Merge(targetID string, sourceIDs []string, userID string) error {
+	return nil
+}
+
+type mockContext struct {
+	context.Context
+	userID string
+	hasID  bool
+}
+
+func (m *mockContext) Value(key interface{}) interface{} {
+	if key == middleware.UserIDKey {
+		if m.hasID {
+			return m.userID
+		}
+		return nil
+	}
+	return nil
+}
+
+func newTestService() (*RecurringRuleService, *mockRecurringRuleRepository) {
+	repo := newMockRecurringRuleRepository()
+	wallets := &mockWalletRepository{wallets: map[string]*walletdomain.Wallet{
+		"wallet-usd": walletdomain.NewWallet("wallet-usd", "user1", "Checking", walletdomain.WalletTypeBank, shareddomain.Amount{}, walletdomain.CurrencyUSD, "system"),
+		"wallet-jpy": walletdomain.NewWallet("wallet-jpy", "user1", "Yen", walletdomain.WalletTypeBank, shareddomain.Amount{}, walletdomain.CurrencyJPY, "system"),
+	}}
+	categories := &mockCategoryRepository{categories: map[string]*categorydomain.Category{
+		"cat-rent":   categorydomain.NewCategory("cat-rent", "user1", "Rent", categorydomain.CategoryTypeExpense, "system"),
+		"cat-salary": categorydomain.NewCategory("cat-salary", "user1", "Salary", categorydomain.CategoryTypeIncome, "system"),
+	}}
+	service := NewRecurringRuleService(repo, wallets, categories, "system")
+	service.now = func() time.Time { return time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC) }
+	return service, repo
+}
+
+func rentRequest() commands.RecurringRuleRequest {
+	return commands.RecurringRuleRequest{
+		WalletID:    "wallet-usd",
+		CategoryID:  "cat-rent",
+		Type:        int(transactiondomain.TransactionTypeExpense),
+		Amount:      shareddomain.MustParseAmount("1200"),
+		Description: "Rent",
+		Frequency:   int(domain.FrequencyMonthly),
+		DayOfMonth:  1,
+		StartDate:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestRecurringRuleService_Create(t *testing.T) {
+	service, repo := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	if err := service.Create(ctx, rentRequest()); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if len(repo.rules) != 1 {
+		t.Fatalf("expected 1 rule, got %d", len(repo.rules))
+	}
+	for _, rule := range repo.rules {
+		if rule.Interval != 1 {
+			t.Errorf("expected interval to default to 1, got %d", rule.Interval)
+		}
+		if !rule.NextOccurrence.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("expected past start date to be kept for backfill, got %s", rule.NextOccurrence)
+		}
+		if !rule.Active {
+			t.Error("expected rule to be active")
+		}
+	}
+}
+
+func TestRecurringRuleService_Create_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(req *commands.RecurringRuleRequest)
+		wantErr string
+	}{
+		{"invalid type", func(req *commands.RecurringRuleRequest) { req.Type = 9 }, "invalid transaction type"},
+		{"transfer", func(req *commands.RecurringRuleRequest) { req.Type = int(transactiondomain.TransactionTypeTransfer) }, "only support expenses and income"},
+		{"invalid frequency", func(req *commands.RecurringRuleRequest) { req.Frequency = 9 }, "invalid frequency"},
+		{"negative interval", func(req *commands.RecurringRuleRequest) { req.Interval = -1 }, "interval must be at least 1"},
+		{"day of month", func(req *commands.RecurringRuleRequest) { req.DayOfMonth = 40 }, "day of month"},
+		{"zero amount", func(req *commands.RecurringRuleRequest) { req.Amount = shareddomain.Amount{} }, "invalid amount"},
+		{"precision", func(req *commands.RecurringRuleRequest) {
+			req.WalletID = "wallet-jpy"
+			req.Amount = shareddomain.MustParseAmount("10.5")
+		}, "decimal places"},
+		{"unknown wallet", func(req *commands.RecurringRuleRequest) { req.WalletID = "missing" }, "wallet not found"},
+		{"missing category", func(req *commands.RecurringRuleRequest) { req.CategoryID = "" }, "category is required"},
+		{"category mismatch", func(req *commands.RecurringRuleRequest) { req.CategoryID = "cat-salary" }, "category type does not match"},
+		{"end before start", func(req *commands.RecurringRuleRequest) {
+			end := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+			req.EndDate = &end
+		}, "end date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo := newTestService()
+			ctx := &mockContext{userID: "user1", hasID: true}
+
+			req := rentRequest()
+			tt.mutate(&req)
+
+			err := service.Create(ctx, req)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %q", tt.wantErr, err.Error())
+			}
+			if len(repo.rules) != 0 {
+				t.Errorf("expected no rule to be stored, got %d", len(repo.rules))
+			}
+		})
+	}
+}
+
+func TestRecurringRuleService_Create_Unauthenticated(t *testing.T) {
+	service, _ := newTestService()
+	ctx := &mockContext{hasID: false}
+
+	err := service.Create(ctx, rentRequest())
+	if err == nil || !strings.Contains(err.Error(), "user not authenticated") {
+		t.Errorf("expected authentication error, got %v", err)
+	}
+}
+
+func TestRecurringRuleService_Update_ReschedulesFromToday(t *testing.T) {
+	service, repo := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	rule := domain.NewRecurringRule("rule-1", "user1", "wallet-usd", "cat-rent", transactiondomain.TransactionTypeExpense, shareddomain.MustParseAmount("1200"), "Rent", domain.FrequencyMonthly, 1, 1, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), nil, "system")
+	repo.rules[rule.ID] = rule
+
+	req := rentRequest()
+	req.Amount = shareddomain.MustParseAmount("1300")
+	req.DayOfMonth = 5
+
+	if err := service.Update(ctx, "rule-1", req); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	updated := repo.rules["rule-1"]
+	if updated.Amount.String() != "1300" {
+		t.Errorf("expected amount 1300, got %s", updated.Amount)
+	}
+	if !updated.NextOccurrence.Equal(time.Date(2026, 4, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected next occurrence 2026-04-05, got %s", updated.NextOccurrence.Format("2006-01-02"))
+	}
+	if updated.CreatedBy != "system" || !updated.CreatedAt.Equal(rule.CreatedAt) {
+		t.Error("expected creation audit fields to be preserved")
+	}
+}
+
+func TestRecurringRuleService_Update_Pause(t *testing.T) {
+	service, repo := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	rule := domain.NewRecurringRule("rule-1", "user1", "wallet-usd", "cat-rent", transactiondomain.TransactionTypeExpense, shareddomain.MustParseAmount("1200"), "Rent", domain.FrequencyMonthly, 1, 1, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), nil, "system")
+	repo.rules[rule.ID] = rule
+
+	inactive := false
+	req := rentRequest()
+	req.Active = &inactive
+
+	if err := service.Update(ctx, "rule-1", req); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if repo.rules["rule-1"].Active {
+		t.Error("expected rule to be paused")
+	}
+}
+
+func TestRecurringRuleService_Update_NotFound(t *testing.T) {
+	service, _ := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	err := service.Update(ctx, "missing", rentRequest())
+	if err == nil || !strings.Contains(err.Error(), "recurring rule not found") {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestRecurringRuleService_GetByIDAndList(t *testing.T) {
+	service, repo := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	rule := domain.NewRecurringRule("rule-1", "user1", "wallet-usd", "cat-rent", transactiondomain.TransactionTypeExpense, shareddomain.MustParseAmount("1200"), "Rent", domain.FrequencyLastBusinessDay, 1, 0, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), nil, "system")
+	repo.rules[rule.ID] = rule
+	repo.rules["rule-2"] = domain.NewRecurringRule("rule-2", "user2", "wallet-x", "cat-x", transactiondomain.TransactionTypeExpense, shareddomain.MustParseAmount("1"), "", domain.FrequencyDaily, 1, 0, time.Now(), nil, "system")
+
+	response, err := service.GetByID(ctx, "rule-1")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if response.FrequencyName != "LastBusinessDay" || response.TypeName != "Expense" {
+		t.Errorf("unexpected names: %s %s", response.FrequencyName, response.TypeName)
+	}
+
+	if _, err := service.GetByID(ctx, "rule-2"); err == nil {
+		t.Error("expected error reading another user's rule")
+	}
+
+	responses, err := service.List(ctx)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(responses) != 1 {
+		t.Errorf("expected 1 rule, got %d", len(responses))
+	}
+}
+
+func TestRecurringRuleService_Delete(t *testing.T) {
+	service, repo := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	rule := domain.NewRecurringRule("rule-1", "user1", "wallet-usd", "cat-rent", transactiondomain.TransactionTypeExpense, shareddomain.MustParseAmount("1200"), "Rent", domain.FrequencyMonthly, 1, 1, time.Now(), nil, "system")
+	repo.rules[rule.ID] = rule
+
+	if err := service.Delete(ctx, "rule-1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if len(repo.rules) != 0 {
+		t.Errorf("expected rule to be deleted, got %d rules", len(repo.rules))
+	}
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"fin-flow-api/internal/modules/recurring/domain"
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+
+	"github.com/google/uuid"
+)
+
+// RecurringScheduler posts the due occurrences of recurring rules as
+// transactions. It is safe to run on several instances at once: every
+// occurrence is stored with its rule ID and date, which the database keeps
+// unique, and schedules only ever move forward.
+type RecurringScheduler struct {
+	repository            domain.RecurringRuleRepository
+	transactionRepository transactiondomain.TransactionRepository
+	interval              time.Duration
+	systemUser            string
+	now                   func() time.Time
+}
+
+func NewRecurringScheduler(repository domain.RecurringRuleRepository, transactionRepository transactiondomain.TransactionRepository, interval time.Duration, systemUser string) *RecurringScheduler {
+	return &RecurringScheduler{
+		repository:            repository,
+		transactionRepository: transactionRepository,
+		interval:              interval,
+		systemUser:            systemUser,
+		now:                   time.Now,
+	}
+}
+
+func (s *RecurringScheduler) Name() string {
+	return "recurring-transactions"
+}
+
+// Run posts due occurrences immediately and then once per interval until
+// ctx is cancelled.
+func (s *RecurringScheduler) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.RunOnce(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("recurring scheduler run failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce posts every occurrence that is due today or earlier. A failing
+// rule is logged and retried on the next run without blocking the others.
+func (s *RecurringScheduler) RunOnce(ctx context.Context) error {
+	today := s.now()
+
+	rules, err := s.repository.ListDue(today)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := s.post(ctx, rule, today); err != nil {
+			log.Printf("recurring rule %s: %v", rule.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *RecurringScheduler) post(ctx context.Context, rule *domain.RecurringRule, today time.Time) error {
+	for rule.IsDue(today) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		transaction := transactiondomain.NewTransaction(
+			uuid.New().String(),
+			rule.UserID,
+			rule.WalletID,
+			rule.CategoryID,
+			rule.Type,
+			rule.Amount,
+			rule.Description,
+			rule.NextOccurrence,
+			s.systemUser,
+		)
+		transaction.RecurringRuleID = rule.ID
+
+		err := s.transactionRepository.Create(transaction)
+		if err != nil && !errors.Is(err, transactiondomain.ErrOccurrenceAlreadyPosted) {
+			return err
+		}
+
+		rule.Advance()
+		rule.Entity.UpdateModified(s.systemUser)
+		if err := s.repository.AdvanceSchedule(rule); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"fin-flow-api/internal/modules/recurring/domain"
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	"fin-flow-api/internal/modules/transactions/domain/transactiontest"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+func newTestScheduler(today time.Time, rules ...*domain.RecurringRule) (*RecurringScheduler, *mockRecurringRuleRepository, *transactiontest.Repository) {
+	ruleRepository := newMockRecurringRuleRepository(rules...)
+	transactions := transactiontest.NewRepository()
+	scheduler := NewRecurringScheduler(ruleRepository, transactions, time.Hour, "system")
+	scheduler.now = func() time.Time { return today }
+	return scheduler, ruleRepository, transactions
+}
+
+func monthlyRent(start time.Time, end *time.Time) *domain.RecurringRule {
+	return domain.NewRecurringRule("rule-1", "user1", "wallet-usd", "cat-rent", transactiondomain.TransactionTypeExpense, shareddomain.MustParseAmount("1200"), "Rent", domain.FrequencyMonthly, 1, 1, start, end, "system")
+}
+
+func TestRecurringScheduler_PostsDueOccurrences(t *testing.T) {
+	today := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	scheduler, rules, transactions := newTestScheduler(today, monthlyRent(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), nil))
+
+	if err := scheduler.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce failed: %v", err)
+	}
+
+	if len(transactions.Created) != 3 {
+		t.Fatalf("expected 3 postings for January to March, got %d", len(transactions.Created))
+	}
+	for i, transaction := range transactions.Created {
+		want := time.Date(2026, time.Month(i+1), 1, 0, 0, 0, 0, time.UTC)
+		if !transaction.Date.Equal(want) {
+			t.Errorf("posting %d: expected date %s, got %s", i, want.Format("2006-01-02"), transaction.Date.Format("2006-01-02"))
+		}
+		if transaction.RecurringRuleID != "rule-1" || transaction.UserID != "user1" || transaction.Amount.String() != "1200" {
+			t.Errorf("posting %d: unexpected transaction %+v", i, transaction)
+		}
+	}
+
+	next := rules.rules["rule-1"].NextOccurrence
+	if !next.Equal(time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected next occurrence 2026-04-01, got %s", next.Format("2006-01-02"))
+	}
+}
+
+func TestRecurringScheduler_RerunDoesNotDoublePost(t *testing.T) {
+	today := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	scheduler, _, transactions := newTestScheduler(today, monthlyRent(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), nil))
+
+	for i := 0; i < 3; i++ {
+		if err := scheduler.RunOnce(context.Background()); err != nil {
+			t.Fatalf("RunOnce failed: %v", err)
+		}
+	}
+
+	if len(transactions.Created) != 3 {
+		t.Errorf("expected 3 postings after repeated runs, got %d", len(transactions.Created))
+	}
+}
+
+func TestRecurringScheduler_SkipsOccurrencesPostedByAnotherInstance(t *testing.T) {
+	today := time.Date(2026, 2, 10, 9, 0, 0, 0, time.UTC)
+	scheduler, rules, transactions := newTestScheduler(today, monthlyRent(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), nil))
+
+	// Another instance posted January but crashed before advancing the rule.
+	transactions.Transactions["january"] = &transactiondomain.Transaction{ID: "january", UserID: "user1", RecurringRuleID: "rule-1", Date: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	if err := scheduler.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce failed: %v", err)
+	}
+
+	if len(transactions.Created) != 1 {
+		t.Fatalf("expected only February to be posted, got %d postings", len(transactions.Created))
+	}
+	if !transactions.Created[0].Date.Equal(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected February posting, got %s", transactions.Created[0].Date.Format("2006-01-02"))
+	}
+	if !rules.rules["rule-1"].NextOccurrence.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected schedule to advance to March, got %s", rules.rules["rule-1"].NextOccurrence.Format("2006-01-02"))
+	}
+}
+
+func TestRecurringScheduler_DeactivatesAfterEndDate(t *testing.T) {
+	end := time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC)
+	today := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	scheduler, rules, transactions := newTestScheduler(today, monthlyRent(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), &end))
+
+	if err := scheduler.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce failed: %v", err)
+	}
+
+	if len(transactions.Created) != 2 {
+		t.Errorf("expected 2 postings before the end date, got %d", len(transactions.Created))
+	}
+	if rules.rules["rule-1"].Active {
+		t.Error("expected rule to be deactivated after its end date")
+	}
+}
+
+func TestRecurringScheduler_KeepsScheduleOnFailure(t *testing.T) {
+	today := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	scheduler, rules, transactions := newTestScheduler(today, monthlyRent(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), nil))
+	transactions.CreateErr = errors.New("connection refused")
+
+	if err := scheduler.RunOnce(context.Background()); err != nil {
+		t.Fatalf("RunOnce should log rule failures, got %v", err)
+	}
+
+	if !rules.rules["rule-1"].NextOccurrence.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected schedule to stay on the failed occurrence, got %s", rules.rules["rule-1"].NextOccurrence.Format("2006-01-02"))
+	}
+}
+
+func TestRecurringScheduler_ListError(t *testing.T) {
+	scheduler, rules, _ := newTestScheduler(time.Now())
+	rules.listErr = errors.New("database unavailable")
+
+	if err := scheduler.RunOnce(context.Background()); err == nil {
+		t.Error("expected error when due rules cannot be listed")
+	}
+}
+
+func TestRecurringScheduler_RunStopsOnCancel(t *testing.T) {
+	scheduler, _, _ := newTestScheduler(time.Now())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- scheduler.Run(ctx) }()
+
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not stop after cancellation")
+	}
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "errors"
+
+type Frequency int
+
+const (
+	FrequencyDaily Frequency = iota
+	FrequencyWeekly
+	FrequencyMonthly
+	FrequencyLastBusinessDay
+	FrequencyYearly
+)
+
+var ErrInvalidFrequency = errors.New("invalid frequency")
+
+func (f Frequency) String() string {
+	switch f {
+	case FrequencyDaily:
+		return "Daily"
+	case FrequencyWeekly:
+		return "Weekly"
+	case FrequencyMonthly:
+		return "Monthly"
+	case FrequencyLastBusinessDay:
+		return "LastBusinessDay"
+	case FrequencyYearly:
+		return "Yearly"
+	default:
+		return "Unknown"
+	}
+}
+
+func (f Frequency) Value() int {
+	return int(f)
+}
+
+func IsValidFrequency(value int) bool {
+	f := Frequency(value)
+	return f >= FrequencyDaily && f <= FrequencyYearly
+}
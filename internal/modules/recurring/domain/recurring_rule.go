@@ -0,0 +1,191 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	"fin-flow-api/internal/shared/domain"
+)
+
+var (
+	ErrInvalidInterval   = errors.New("interval must be at least 1")
+	ErrInvalidDayOfMonth = errors.New("day of month must be between 1 and 31")
+	ErrInvalidDateRange  = errors.New("end date must not be before start date")
+	ErrUnsupportedType   = errors.New("recurring rules only support expenses and income")
+)
+
+// RecurringRule describes an expense or income that repeats on a schedule.
+// NextOccurrence is the date of the first occurrence that has not been
+// posted yet; the scheduler moves it forward as it posts transactions.
+type RecurringRule struct {
+	domain.Entity
+
+	ID             string
+	UserID         string
+	WalletID       string
+	CategoryID     string
+	Type           transactiondomain.TransactionType
+	Amount         domain.Amount
+	Description    string
+	Frequency      Frequency
+	Interval       int
+	DayOfMonth     int
+	StartDate      time.Time
+	EndDate        *time.Time
+	NextOccurrence time.Time
+	Active         bool
+}
+
+// NewRecurringRule builds an active rule whose first occurrence is the first
+// scheduled date on or after startDate. Occurrences in the past are posted
+// by the scheduler on its next run. Monthly rules without a day of month
+// repeat on the day of the start date.
+func NewRecurringRule(id, userID, walletID, categoryID string, transactionType transactiondomain.TransactionType, amount domain.Amount, description string, frequency Frequency, interval, dayOfMonth int, startDate time.Time, endDate *time.Time, createdBy string) *RecurringRule {
+	if frequency == FrequencyMonthly && dayOfMonth == 0 {
+		dayOfMonth = startDate.Day()
+	}
+
+	rule := &RecurringRule{
+		Entity:      domain.NewEntity(id, createdBy),
+		ID:          id,
+		UserID:      userID,
+		WalletID:    walletID,
+		CategoryID:  categoryID,
+		Type:        transactionType,
+		Amount:      amount,
+		Description: description,
+		Frequency:   frequency,
+		Interval:    interval,
+		DayOfMonth:  dayOfMonth,
+		StartDate:   truncateToDate(startDate),
+		EndDate:     truncateDatePointer(endDate),
+		Active:      true,
+	}
+	rule.NextOccurrence = rule.firstOccurrence()
+	return rule
+}
+
+// Validate checks the schedule fields. Amount, wallet and category are
+// validated by the service, which has access to them.
+func (r *RecurringRule) Validate() error {
+	if r.Type != transactiondomain.TransactionTypeExpense && r.Type != transactiondomain.TransactionTypeIncome {
+		return ErrUnsupportedType
+	}
+	if !IsValidFrequency(r.Frequency.Value()) {
+		return ErrInvalidFrequency
+	}
+	if r.Interval < 1 {
+		return ErrInvalidInterval
+	}
+	if r.Frequency == FrequencyMonthly && (r.DayOfMonth < 1 || r.DayOfMonth > 31) {
+		return ErrInvalidDayOfMonth
+	}
+	if r.EndDate != nil && r.EndDate.Before(r.StartDate) {
+		return ErrInvalidDateRange
+	}
+	return nil
+}
+
+// Reschedule points NextOccurrence at the first scheduled date on or after
+// from, without going back before the start date. It is used after the
+// schedule of an existing rule changes so past occurrences are not posted
+// again under new dates.
+func (r *RecurringRule) Reschedule(from time.Time) {
+	from = truncateToDate(from)
+	occurrence := r.firstOccurrence()
+	for occurrence.Before(from) {
+		occurrence = r.nextAfter(occurrence)
+	}
+	r.NextOccurrence = occurrence
+	r.Active = r.Active && !r.isPastEnd(occurrence)
+}
+
+// IsDue reports whether the next occurrence should be posted on date.
+func (r *RecurringRule) IsDue(date time.Time) bool {
+	return r.Active && !r.isPastEnd(r.NextOccurrence) && !r.NextOccurrence.After(truncateToDate(date))
+}
+
+// Advance moves NextOccurrence to the following scheduled date and
+// deactivates the rule once that date is past its end date.
+func (r *RecurringRule) Advance() {
+	r.NextOccurrence = r.nextAfter(r.NextOccurrence)
+	if r.isPastEnd(r.NextOccurrence) {
+		r.Active = false
+	}
+}
+
+func (r *RecurringRule) isPastEnd(date time.Time) bool {
+	return r.EndDate != nil && date.After(*r.EndDate)
+}
+
+func (r *RecurringRule) firstOccurrence() time.Time {
+	start := r.StartDate
+	switch r.Frequency {
+	case FrequencyMonthly:
+		occurrence := dayInMonth(start.Year(), start.Month(), r.DayOfMonth)
+		if occurrence.Before(start) {
+			occurrence = dayInMonth(start.Year(), start.Month()+1, r.DayOfMonth)
+		}
+		return occurrence
+	case FrequencyLastBusinessDay:
+		occurrence := lastBusinessDay(start.Year(), start.Month())
+		if occurrence.Before(start) {
+			occurrence = lastBusinessDay(start.Year(), start.Month()+1)
+		}
+		return occurrence
+	default:
+		return start
+	}
+}
+
+func (r *RecurringRule) nextAfter(occurrence time.Time) time.Time {
+	switch r.Frequency {
+	case FrequencyDaily:
+		return occurrence.AddDate(0, 0, r.Interval)
+	case FrequencyWeekly:
+		return occurrence.AddDate(0, 0, 7*r.Interval)
+	case FrequencyMonthly:
+		return dayInMonth(occurrence.Year(), occurrence.Month()+time.Month(r.Interval), r.DayOfMonth)
+	case FrequencyLastBusinessDay:
+		return lastBusinessDay(occurrence.Year(), occurrence.Month()+time.Month(r.Interval))
+	case FrequencyYearly:
+		return dayInMonth(occurrence.Year()+r.Interval, r.StartDate.Month(), r.StartDate.Day())
+	default:
+		return occurrence
+	}
+}
+
+// dayInMonth returns the given day of a month, clamped to the last day of
+// shorter months (31 becomes 30 in April and 28 or 29 in February). Months
+// outside 1-12 roll over into the neighbouring years.
+func dayInMonth(year int, month time.Month, day int) time.Time {
+	firstOfMonth := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
+
+// lastBusinessDay returns the last Monday to Friday of a month. Public
+// holidays are not taken into account.
+func lastBusinessDay(year int, month time.Month) time.Time {
+	day := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+	for day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day
+}
+
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func truncateDatePointer(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	date := truncateToDate(*t)
+	return &date
+}
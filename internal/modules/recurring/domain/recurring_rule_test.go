@@ -0,0 +1,217 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func newRule(frequency Frequency, interval, dayOfMonth int, start time.Time, end *time.Time) *RecurringRule {
+	return NewRecurringRule(
+		"rule-1",
+		"user-1",
+		"wallet-1",
+		"category-1",
+		transactiondomain.TransactionTypeExpense,
+		shareddomain.MustParseAmount("1200"),
+		"Rent",
+		frequency,
+		interval,
+		dayOfMonth,
+		start,
+		end,
+		"system",
+	)
+}
+
+func occurrences(rule *RecurringRule, count int) []time.Time {
+	var result []time.Time
+	for i := 0; i < count && rule.Active; i++ {
+		result = append(result, rule.NextOccurrence)
+		rule.Advance()
+	}
+	return result
+}
+
+func TestNewRecurringRule(t *testing.T) {
+	rule := newRule(FrequencyMonthly, 1, 5, date(2026, 1, 1), nil)
+
+	if !rule.Active {
+		t.Error("expected new rule to be active")
+	}
+	if !rule.NextOccurrence.Equal(date(2026, 1, 5)) {
+		t.Errorf("expected first occurrence 2026-01-05, got %s", rule.NextOccurrence.Format("2006-01-02"))
+	}
+	if rule.CreatedBy != "system" {
+		t.Errorf("expected CreatedBy system, got %s", rule.CreatedBy)
+	}
+}
+
+func TestRecurringRule_Schedules(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  *RecurringRule
+		count int
+		want  []time.Time
+	}{
+		{
+			name:  "daily every 3 days",
+			rule:  newRule(FrequencyDaily, 3, 0, date(2026, 1, 30), nil),
+			count: 3,
+			want:  []time.Time{date(2026, 1, 30), date(2026, 2, 2), date(2026, 2, 5)},
+		},
+		{
+			name:  "biweekly",
+			rule:  newRule(FrequencyWeekly, 2, 0, date(2026, 3, 6), nil),
+			count: 3,
+			want:  []time.Time{date(2026, 3, 6), date(2026, 3, 20), date(2026, 4, 3)},
+		},
+		{
+			name:  "monthly on the 31st clamps to short months",
+			rule:  newRule(FrequencyMonthly, 1, 31, date(2026, 1, 1), nil),
+			count: 4,
+			want:  []time.Time{date(2026, 1, 31), date(2026, 2, 28), date(2026, 3, 31), date(2026, 4, 30)},
+		},
+		{
+			name:  "monthly day already passed in start month",
+			rule:  newRule(FrequencyMonthly, 1, 15, date(2026, 1, 20), nil),
+			count: 2,
+			want:  []time.Time{date(2026, 2, 15), date(2026, 3, 15)},
+		},
+		{
+			name:  "monthly defaults to start day",
+			rule:  newRule(FrequencyMonthly, 1, 0, date(2026, 11, 10), nil),
+			count: 3,
+			want:  []time.Time{date(2026, 11, 10), date(2026, 12, 10), date(2027, 1, 10)},
+		},
+		{
+			name:  "quarterly",
+			rule:  newRule(FrequencyMonthly, 3, 1, date(2026, 1, 1), nil),
+			count: 3,
+			want:  []time.Time{date(2026, 1, 1), date(2026, 4, 1), date(2026, 7, 1)},
+		},
+		{
+			name:  "last business day skips weekends",
+			rule:  newRule(FrequencyLastBusinessDay, 1, 0, date(2026, 1, 1), nil),
+			count: 3,
+			want:  []time.Time{date(2026, 1, 30), date(2026, 2, 27), date(2026, 3, 31)},
+		},
+		{
+			name:  "yearly from leap day",
+			rule:  newRule(FrequencyYearly, 1, 0, date(2024, 2, 29), nil),
+			count: 5,
+			want:  []time.Time{date(2024, 2, 29), date(2025, 2, 28), date(2026, 2, 28), date(2027, 2, 28), date(2028, 2, 29)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := occurrences(tt.rule, tt.count)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %d occurrences, got %d", len(tt.want), len(got))
+			}
+			for i := range tt.want {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("occurrence %d: expected %s, got %s", i, tt.want[i].Format("2006-01-02"), got[i].Format("2006-01-02"))
+				}
+			}
+		})
+	}
+}
+
+func TestRecurringRule_EndDate(t *testing.T) {
+	end := date(2026, 3, 15)
+	rule := newRule(FrequencyMonthly, 1, 10, date(2026, 1, 1), &end)
+
+	got := occurrences(rule, 10)
+	if len(got) != 3 {
+		t.Fatalf("expected 3 occurrences before the end date, got %d", len(got))
+	}
+	if rule.Active {
+		t.Error("expected rule to be inactive after its end date")
+	}
+	if rule.IsDue(date(2026, 12, 31)) {
+		t.Error("inactive rule should never be due")
+	}
+}
+
+func TestRecurringRule_IsDue(t *testing.T) {
+	rule := newRule(FrequencyMonthly, 1, 10, date(2026, 1, 1), nil)
+
+	if rule.IsDue(date(2026, 1, 9)) {
+		t.Error("rule should not be due before its next occurrence")
+	}
+	if !rule.IsDue(date(2026, 1, 10)) {
+		t.Error("rule should be due on its next occurrence")
+	}
+	if !rule.IsDue(time.Date(2026, 1, 10, 23, 59, 0, 0, time.UTC)) {
+		t.Error("rule should be due at any time of the occurrence day")
+	}
+}
+
+func TestRecurringRule_Reschedule(t *testing.T) {
+	rule := newRule(FrequencyWeekly, 1, 0, date(2026, 1, 5), nil)
+
+	rule.Reschedule(date(2026, 2, 4))
+	if !rule.NextOccurrence.Equal(date(2026, 2, 9)) {
+		t.Errorf("expected next occurrence 2026-02-09, got %s", rule.NextOccurrence.Format("2006-01-02"))
+	}
+
+	rule.Reschedule(date(2025, 6, 1))
+	if !rule.NextOccurrence.Equal(date(2026, 1, 5)) {
+		t.Errorf("expected rescheduling before the start to use the start date, got %s", rule.NextOccurrence.Format("2006-01-02"))
+	}
+}
+
+func TestRecurringRule_Validate(t *testing.T) {
+	before := date(2025, 12, 31)
+
+	tests := []struct {
+		name    string
+		mutate  func(r *RecurringRule)
+		wantErr error
+	}{
+		{"valid", func(r *RecurringRule) {}, nil},
+		{"transfer", func(r *RecurringRule) { r.Type = transactiondomain.TransactionTypeTransfer }, ErrUnsupportedType},
+		{"invalid frequency", func(r *RecurringRule) { r.Frequency = Frequency(99) }, ErrInvalidFrequency},
+		{"zero interval", func(r *RecurringRule) { r.Interval = 0 }, ErrInvalidInterval},
+		{"day of month too large", func(r *RecurringRule) { r.DayOfMonth = 32 }, ErrInvalidDayOfMonth},
+		{"end before start", func(r *RecurringRule) { r.EndDate = &before }, ErrInvalidDateRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := newRule(FrequencyMonthly, 1, 1, date(2026, 1, 1), nil)
+			tt.mutate(rule)
+			if err := rule.Validate(); err != tt.wantErr {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestFrequency_String(t *testing.T) {
+	tests := []struct {
+		frequency Frequency
+		want      string
+	}{
+		{FrequencyDaily, "Daily"},
+		{FrequencyWeekly, "Weekly"},
+		{FrequencyMonthly, "Monthly"},
+		{FrequencyLastBusinessDay, "LastBusinessDay"},
+		{FrequencyYearly, "Yearly"},
+		{Frequency(99), "Unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.frequency.String(); got != tt.want {
+			t.Errorf("Frequency(%d).String() = %s, want %s", tt.frequency, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+type RecurringRuleRepository interface {
+	Create(rule *RecurringRule) error
+	GetByID(id string, userID string) (*RecurringRule, error)
+	List(userID string) ([]*RecurringRule, error)
+	Update(rule *RecurringRule) error
+	Delete(id string, userID string) error
+	// ListDue returns the active rules of every user whose next occurrence
+	// is on or before date.
+	ListDue(date time.Time) ([]*RecurringRule, error)
+	// AdvanceSchedule stores the next occurrence and active flag of a rule
+	// after the scheduler posted an occurrence. It never moves the schedule
+	// backwards, so concurrent schedulers cannot undo each other's progress.
+	AdvanceSchedule(rule *RecurringRule) error
+}
@@ -0,0 +1,277 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"fin-flow-api/internal/modules/recurring/domain"
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const recurringRuleColumns = `id, user_id, wallet_id, category_id, type, amount, description, frequency, interval_count, day_of_month, start_date, end_date, next_occurrence, active, created_at, modified_at, created_by, modified_by`
+
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+func (r *Repository) Create(rule *domain.RecurringRule) error {
+	query := `
+		INSERT INTO recurring_rules (` + recurringRuleColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+	`
+
+	_, err := r.pool.Exec(
+		context.Background(),
+		query,
+		rule.ID,
+		rule.UserID,
+		rule.WalletID,
+		rule.CategoryID,
+		rule.Type.Value(),
+		rule.Amount,
+		rule.Description,
+		rule.Frequency.Value(),
+		rule.Interval,
+		nullableDay(rule.DayOfMonth),
+		rule.StartDate,
+		rule.EndDate,
+		rule.NextOccurrence,
+		rule.Active,
+		rule.CreatedAt,
+		rule.ModifiedAt,
+		rule.CreatedBy,
+		rule.ModifiedBy,
+	)
+	if err != nil {
+		return mapWriteError("failed to create recurring rule", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetByID(id string, userID string) (*domain.RecurringRule, error) {
+	checkQuery := `SELECT user_id FROM recurring_rules WHERE id = $1`
+	var ruleUserID string
+	err := r.pool.QueryRow(context.Background(), checkQuery, id).Scan(&ruleUserID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("recurring rule not found")
+		}
+		return nil, fmt.Errorf("failed to get recurring rule: %w", err)
+	}
+
+	if ruleUserID != userID {
+		return nil, fmt.Errorf("unauthorized access to recurring rule")
+	}
+
+	query := `SELECT ` + recurringRuleColumns + ` FROM recurring_rules WHERE id = $1 AND user_id = $2`
+
+	rule, err := scanRecurringRule(r.pool.QueryRow(context.Background(), query, id, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("recurring rule not found")
+		}
+		return nil, fmt.Errorf("failed to get recurring rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+func (r *Repository) List(userID string) ([]*domain.RecurringRule, error) {
+	query := `SELECT ` + recurringRuleColumns + ` FROM recurring_rules WHERE user_id = $1 ORDER BY next_occurrence ASC, created_at ASC`
+
+	return r.query("failed to list recurring rules", query, userID)
+}
+
+func (r *Repository) Update(rule *domain.RecurringRule) error {
+	query := `
+		UPDATE recurring_rules
+		SET wallet_id = $2, category_id = $3, type = $4, amount = $5, description = $6,
+			frequency = $7, interval_count = $8, day_of_month = $9, start_date = $10,
+			end_date = $11, next_occurrence = $12, active = $13, modified_at = $14, modified_by = $15
+		WHERE id = $1 AND user_id = $16
+	`
+
+	result, err := r.pool.Exec(
+		context.Background(),
+		query,
+		rule.ID,
+		rule.WalletID,
+		rule.CategoryID,
+		rule.Type.Value(),
+		rule.Amount,
+		rule.Description,
+		rule.Frequency.Value(),
+		rule.Interval,
+		nullableDay(rule.DayOfMonth),
+		rule.StartDate,
+		rule.EndDate,
+		rule.NextOccurrence,
+		rule.Active,
+		rule.ModifiedAt,
+		rule.ModifiedBy,
+		rule.UserID,
+	)
+	if err != nil {
+		return mapWriteError("failed to update recurring rule", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("recurring rule not found")
+	}
+
+	return nil
+}
+
+func (r *Repository) Delete(id string, userID string) error {
+	checkQuery := `SELECT user_id FROM recurring_rules WHERE id = $1`
+	var ruleUserID string
+	err := r.pool.QueryRow(context.Background(), checkQuery, id).Scan(&ruleUserID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("recurring rule not found")
+		}
+		return fmt.Errorf("failed to delete recurring rule: %w", err)
+	}
+
+	if ruleUserID != userID {
+		return fmt.Errorf("unauthorized access to recurring rule")
+	}
+
+	// Posted transactions stay in place; their recurring_rule_id is cleared
+	// by the foreign key.
+	query := `DELETE FROM recurring_rules WHERE id = $1 AND user_id = $2`
+
+	if _, err := r.pool.Exec(context.Background(), query, id, userID); err != nil {
+		return fmt.Errorf("failed to delete recurring rule: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) ListDue(date time.Time) ([]*domain.RecurringRule, error) {
+	query := `SELECT ` + recurringRuleColumns + ` FROM recurring_rules WHERE active AND next_occurrence <= $1 ORDER BY next_occurrence ASC`
+
+	return r.query("failed to list due recurring rules", query, date)
+}
+
+func (r *Repository) AdvanceSchedule(rule *domain.RecurringRule) error {
+	query := `
+		UPDATE recurring_rules
+		SET next_occurrence = $2, active = $3, modified_at = $4, modified_by = $5
+		WHERE id = $1 AND next_occurrence < $2
+	`
+
+	_, err := r.pool.Exec(
+		context.Background(),
+		query,
+		rule.ID,
+		rule.NextOccurrence,
+		rule.Active,
+		rule.ModifiedAt,
+		rule.ModifiedBy,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to advance recurring rule: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) query(message string, query string, args ...interface{}) ([]*domain.RecurringRule, error) {
+	rows, err := r.pool.Query(context.Background(), query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", message, err)
+	}
+	defer rows.Close()
+
+	var rules []*domain.RecurringRule
+	for rows.Next() {
+		rule, err := scanRecurringRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan recurring rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate recurring rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+func scanRecurringRule(row pgx.Row) (*domain.RecurringRule, error) {
+	var rule domain.RecurringRule
+	var typeValue int
+	var frequencyValue int
+	var dayOfMonth *int
+
+	err := row.Scan(
+		&rule.ID,
+		&rule.UserID,
+		&rule.WalletID,
+		&rule.CategoryID,
+		&typeValue,
+		&rule.Amount,
+		&rule.Description,
+		&frequencyValue,
+		&rule.Interval,
+		&dayOfMonth,
+		&rule.StartDate,
+		&rule.EndDate,
+		&rule.NextOccurrence,
+		&rule.Active,
+		&rule.CreatedAt,
+		&rule.ModifiedAt,
+		&rule.CreatedBy,
+		&rule.ModifiedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rule.Type = transactiondomain.TransactionType(typeValue)
+	rule.Frequency = domain.Frequency(frequencyValue)
+	if dayOfMonth != nil {
+		rule.DayOfMonth = *dayOfMonth
+	}
+
+	return &rule, nil
+}
+
+func mapWriteError(message string, err error) error {
+	if pgErr, ok := err.(*pgconn.PgError); ok {
+		switch pgErr.Code {
+		case "23503": // foreign_key_violation
+			if strings.Contains(pgErr.ConstraintName, "category") {
+				return fmt.Errorf("invalid category reference")
+			}
+			return fmt.Errorf("invalid wallet reference")
+		case "23514": // check_violation
+			return transactiondomain.ErrInvalidAmount
+		}
+	}
+	return fmt.Errorf("%s: %w", message, err)
+}
+
+// nullableDay stores the day of month only for rules that use it.
+func nullableDay(day int) *int {
+	if day == 0 {
+		return nil
+	}
+	return &day
+}
@@ -0,0 +1,325 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"fin-flow-api/internal/modules/recurring/application/contracts/commands"
+	"fin-flow-api/internal/modules/recurring/application/contracts/queries"
+	"fin-flow-api/internal/modules/recurring/domain"
+	basehandler "fin-flow-api/internal/shared/http"
+)
+
+const dateLayout = "2006-01-02"
+
+type recurringRuleService interface {
+	Create(ctx context.Context, req commands.RecurringRuleRequest) error
+	GetByID(ctx context.Context, id string) (*queries.RecurringRuleResponse, error)
+	Update(ctx context.Context, id string, req commands.RecurringRuleRequest) error
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context) ([]*queries.RecurringRuleResponse, error)
+}
+
+type Handler struct {
+	recurringRuleService recurringRuleService
+}
+
+func NewHandler(recurringRuleService recurringRuleService) *Handler {
+	return &Handler{
+		recurringRuleService: recurringRuleService,
+	}
+}
+
+func (h *Handler) CreateRecurringRule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var reqDTO RecurringRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	cmd, err := toRecurringRuleCommand(reqDTO)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.recurringRuleService.Create(r.Context(), cmd); err != nil {
+		statusCode, errorMsg := recurringRuleErrorResponse(err, "create")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Recurring rule created successfully")
+}
+
+func (h *Handler) GetRecurringRule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/recurring-rules/")
+	id := strings.Split(path, "/")[0]
+
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Recurring rule ID is required in the URL path")
+		return
+	}
+
+	rule, err := h.recurringRuleService.GetByID(r.Context(), id)
+	if err != nil {
+		statusCode, errorMsg := recurringRuleErrorResponse(err, "access")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, toRecurringRuleResponse(rule))
+}
+
+func (h *Handler) UpdateRecurringRule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/recurring-rules/")
+	id := strings.Split(path, "/")[0]
+
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Recurring rule ID is required in the URL path")
+		return
+	}
+
+	var reqDTO RecurringRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	cmd, err := toRecurringRuleCommand(reqDTO)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.recurringRuleService.Update(r.Context(), id, cmd); err != nil {
+		statusCode, errorMsg := recurringRuleErrorResponse(err, "update")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Recurring rule updated successfully")
+}
+
+func (h *Handler) DeleteRecurringRule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/recurring-rules/")
+	id := strings.Split(path, "/")[0]
+
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Recurring rule ID is required in the URL path")
+		return
+	}
+
+	if err := h.recurringRuleService.Delete(r.Context(), id); err != nil {
+		statusCode, errorMsg := recurringRuleErrorResponse(err, "delete")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Recurring rule deleted successfully")
+}
+
+func (h *Handler) ListRecurringRules(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	rules, err := h.recurringRuleService.List(r.Context())
+	if err != nil {
+		statusCode, errorMsg := recurringRuleErrorResponse(err, "access")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	responses := make([]RecurringRuleResponse, len(rules))
+	for i, rule := range rules {
+		responses[i] = toRecurringRuleResponse(rule)
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, responses)
+}
+
+func toRecurringRuleCommand(req RecurringRuleRequest) (commands.RecurringRuleRequest, error) {
+	if err := validateRecurringRuleRequest(req); err != nil {
+		return commands.RecurringRuleRequest{}, err
+	}
+
+	startDate, err := time.Parse(dateLayout, req.StartDate)
+	if err != nil {
+		return commands.RecurringRuleRequest{}, &ValidationError{Field: "start_date", Message: "Start date must use the YYYY-MM-DD format"}
+	}
+
+	var endDate *time.Time
+	if req.EndDate != "" {
+		date, err := time.Parse(dateLayout, req.EndDate)
+		if err != nil {
+			return commands.RecurringRuleRequest{}, &ValidationError{Field: "end_date", Message: "End date must use the YYYY-MM-DD format"}
+		}
+		endDate = &date
+	}
+
+	return commands.RecurringRuleRequest{
+		WalletID:    strings.TrimSpace(req.WalletID),
+		CategoryID:  strings.TrimSpace(req.CategoryID),
+		Type:        *req.Type,
+		Amount:      *req.Amount,
+		Description: strings.TrimSpace(req.Description),
+		Frequency:   *req.Frequency,
+		Interval:    req.Interval,
+		DayOfMonth:  req.DayOfMonth,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Active:      req.Active,
+	}, nil
+}
+
+func validateRecurringRuleRequest(req RecurringRuleRequest) error {
+	if strings.TrimSpace(req.WalletID) == "" {
+		return &ValidationError{Field: "wallet_id", Message: "Wallet ID is required"}
+	}
+
+	if strings.TrimSpace(req.CategoryID) == "" {
+		return &ValidationError{Field: "category_id", Message: "Category ID is required"}
+	}
+
+	if req.Type == nil {
+		return &ValidationError{Field: "type", Message: "Transaction type is required"}
+	}
+
+	if *req.Type != 0 && *req.Type != 1 {
+		return &ValidationError{Field: "type", Message: "Transaction type must be 0 (Expense) or 1 (Income)"}
+	}
+
+	if req.Amount == nil {
+		return &ValidationError{Field: "amount", Message: "Amount is required"}
+	}
+
+	if !req.Amount.IsPositive() {
+		return &ValidationError{Field: "amount", Message: "Amount must be greater than zero"}
+	}
+
+	if len(req.Description) > 500 {
+		return &ValidationError{Field: "description", Message: "Description must not exceed 500 characters"}
+	}
+
+	if req.Frequency == nil {
+		return &ValidationError{Field: "frequency", Message: "Frequency is required"}
+	}
+
+	if !domain.IsValidFrequency(*req.Frequency) {
+		return &ValidationError{Field: "frequency", Message: frequencyMessage}
+	}
+
+	if req.Interval < 0 {
+		return &ValidationError{Field: "interval", Message: "Interval must be at least 1"}
+	}
+
+	if req.DayOfMonth < 0 || req.DayOfMonth > 31 {
+		return &ValidationError{Field: "day_of_month", Message: "Day of month must be between 1 and 31"}
+	}
+
+	if req.StartDate == "" {
+		return &ValidationError{Field: "start_date", Message: "Start date is required"}
+	}
+
+	return nil
+}
+
+const frequencyMessage = "Frequency must be 0 (Daily), 1 (Weekly), 2 (Monthly), 3 (LastBusinessDay), or 4 (Yearly)"
+
+func recurringRuleErrorResponse(err error, action string) (int, string) {
+	errorMsg := err.Error()
+
+	switch {
+	case strings.Contains(errorMsg, "user not authenticated"):
+		return http.StatusUnauthorized, "Authentication required"
+	case strings.Contains(errorMsg, "unauthorized access to recurring rule"):
+		return http.StatusForbidden, "You do not have permission to " + action + " this recurring rule"
+	case strings.Contains(errorMsg, "unauthorized access to wallet"):
+		return http.StatusForbidden, "You do not have permission to use this wallet"
+	case strings.Contains(errorMsg, "unauthorized access to category"):
+		return http.StatusForbidden, "You do not have permission to use this category"
+	case strings.Contains(errorMsg, "recurring rule not found"):
+		return http.StatusNotFound, "Recurring rule not found"
+	case strings.Contains(errorMsg, "wallet not found"), strings.Contains(errorMsg, "invalid wallet reference"):
+		return http.StatusBadRequest, "Wallet not found"
+	case strings.Contains(errorMsg, "category not found"), strings.Contains(errorMsg, "invalid category reference"):
+		return http.StatusBadRequest, "Category not found"
+	case strings.Contains(errorMsg, "invalid frequency"):
+		return http.StatusBadRequest, frequencyMessage
+	case strings.Contains(errorMsg, "invalid amount"):
+		return http.StatusBadRequest, "Amount must be greater than zero"
+	case strings.Contains(errorMsg, "decimal places"):
+		return http.StatusBadRequest, "Amount has more decimal places than the wallet currency allows"
+	case strings.Contains(errorMsg, "invalid transaction type"),
+		strings.Contains(errorMsg, "only support expenses and income"),
+		strings.Contains(errorMsg, "interval must be"),
+		strings.Contains(errorMsg, "day of month"),
+		strings.Contains(errorMsg, "end date"),
+		strings.Contains(errorMsg, "category is required"),
+		strings.Contains(errorMsg, "category type does not match"):
+		return http.StatusBadRequest, errorMsg
+	}
+
+	return http.StatusInternalServerError, errorMsg
+}
+
+func toRecurringRuleResponse(rule *queries.RecurringRuleResponse) RecurringRuleResponse {
+	response := RecurringRuleResponse{
+		ID:             rule.ID,
+		WalletID:       rule.WalletID,
+		CategoryID:     rule.CategoryID,
+		Type:           rule.Type,
+		TypeName:       rule.TypeName,
+		Amount:         rule.Amount,
+		Description:    rule.Description,
+		Frequency:      rule.Frequency,
+		FrequencyName:  rule.FrequencyName,
+		Interval:       rule.Interval,
+		DayOfMonth:     rule.DayOfMonth,
+		StartDate:      rule.StartDate.Format(dateLayout),
+		NextOccurrence: rule.NextOccurrence.Format(dateLayout),
+		Active:         rule.Active,
+		CreatedAt:      rule.CreatedAt,
+		UpdatedAt:      rule.UpdatedAt,
+		CreatedBy:      rule.CreatedBy,
+		UpdatedBy:      rule.UpdatedBy,
+	}
+	if rule.EndDate != nil {
+		response.EndDate = rule.EndDate.Format(dateLayout)
+	}
+	return response
+}
+
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,311 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"fin-flow-api/internal/modules/recurring/application/contracts/commands"
+	"fin-flow-api/internal/modules/recurring/application/contracts/queries"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+type mockRecurringRuleService struct {
+	createErr   error
+	getByIDErr  error
+	updateErr   error
+	deleteErr   error
+	listErr     error
+	rule        *queries.RecurringRuleResponse
+	rules       []*queries.RecurringRuleResponse
+	lastCommand commands.RecurringRuleRequest
+	lastID      string
+}
+
+func (m *mockRecurringRuleService) Create(ctx context.Context, req commands.RecurringRuleRequest) error {
+	m.lastCommand = req
+	return m.createErr
+}
+
+func (m *mockRecurringRuleService) GetByID(ctx context.Context, id string) (*queries.RecurringRuleResponse, error) {
+	m.lastID = id
+	if m.getByIDErr != nil {
+		return nil, m.getByIDErr
+	}
+	return m.rule, nil
+}
+
+func (m *mockRecurringRuleService) Update(ctx context.Context, id string, req commands.RecurringRuleRequest) error {
+	m.lastID = id
+	m.lastCommand = req
+	return m.updateErr
+}
+
+func (m *mockRecurringRuleService) Delete(ctx context.Context, id string) error {
+	m.lastID = id
+	return m.deleteErr
+}
+
+func (m *mockRecurringRuleService) List(ctx context.Context) ([]*queries.RecurringRuleResponse, error) {
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	return m.rules, nil
+}
+
+func createContextWithUserID(userID string) context.Context {
+	return context.WithValue(context.Background(), middleware.UserIDKey, userID)
+}
+
+func validRecurringRuleBody() RecurringRuleRequest {
+	return RecurringRuleRequest{
+		WalletID:    "wallet1",
+		CategoryID:  "category1",
+		Type:        intPtr(0),
+		Amount:      amountPtr("1200.00"),
+		Description: "Rent",
+		Frequency:   intPtr(2),
+		DayOfMonth:  1,
+		StartDate:   "2026-01-01",
+		EndDate:     "2026-12-31",
+	}
+}
+
+func sampleRule() *queries.RecurringRuleResponse {
+	end := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+	return &queries.RecurringRuleResponse{
+		ID:             "rule1",
+		WalletID:       "wallet1",
+		CategoryID:     "category1",
+		TypeName:       "Expense",
+		Amount:         shareddomain.MustParseAmount("1200"),
+		Frequency:      2,
+		FrequencyName:  "Monthly",
+		Interval:       1,
+		DayOfMonth:     1,
+		StartDate:      time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:        &end,
+		NextOccurrence: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+		Active:         true,
+	}
+}
+
+func TestCreateRecurringRule_Success(t *testing.T) {
+	service := &mockRecurringRuleService{}
+	handler := &Handler{recurringRuleService: service}
+
+	jsonBody, _ := json.Marshal(validRecurringRuleBody())
+
+	req := httptest.NewRequest("POST", "/recurring-rules", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(createContextWithUserID("user1"))
+
+	rr := httptest.NewRecorder()
+	handler.CreateRecurringRule(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rr.Code)
+	}
+
+	if !service.lastCommand.StartDate.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected start date %v", service.lastCommand.StartDate)
+	}
+	if service.lastCommand.EndDate == nil || !service.lastCommand.EndDate.Equal(time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected end date %v", service.lastCommand.EndDate)
+	}
+	if service.lastCommand.Amount.String() != "1200" {
+		t.Errorf("expected amount 1200, got %s", service.lastCommand.Amount)
+	}
+}
+
+func TestCreateRecurringRule_InvalidMethod(t *testing.T) {
+	handler := &Handler{recurringRuleService: &mockRecurringRuleService{}}
+
+	req := httptest.NewRequest("GET", "/recurring-rules", nil)
+	rr := httptest.NewRecorder()
+	handler.CreateRecurringRule(rr, req)
+
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status 405, got %d", rr.Code)
+	}
+}
+
+func TestCreateRecurringRule_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*RecurringRuleRequest)
+	}{
+		{"missing wallet", func(r *RecurringRuleRequest) { r.WalletID = "" }},
+		{"missing category", func(r *RecurringRuleRequest) { r.CategoryID = "" }},
+		{"missing type", func(r *RecurringRuleRequest) { r.Type = nil }},
+		{"transfer type", func(r *RecurringRuleRequest) { r.Type = intPtr(2) }},
+		{"missing amount", func(r *RecurringRuleRequest) { r.Amount = nil }},
+		{"zero amount", func(r *RecurringRuleRequest) { r.Amount = amountPtr("0") }},
+		{"missing frequency", func(r *RecurringRuleRequest) { r.Frequency = nil }},
+		{"invalid frequency", func(r *RecurringRuleRequest) { r.Frequency = intPtr(7) }},
+		{"negative interval", func(r *RecurringRuleRequest) { r.Interval = -2 }},
+		{"invalid day of month", func(r *RecurringRuleRequest) { r.DayOfMonth = 32 }},
+		{"missing start date", func(r *RecurringRuleRequest) { r.StartDate = "" }},
+		{"invalid start date", func(r *RecurringRuleRequest) { r.StartDate = "01/01/2026" }},
+		{"invalid end date", func(r *RecurringRuleRequest) { r.EndDate = "soon" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := validRecurringRuleBody()
+			tt.mutate(&body)
+
+			if _, err := toRecurringRuleCommand(body); err == nil {
+				t.Error("expected validation error, got nil")
+			}
+		})
+	}
+}
+
+func TestCreateRecurringRule_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"unauthenticated", errors.New("user not authenticated"), http.StatusUnauthorized},
+		{"foreign wallet", errors.New("unauthorized access to wallet"), http.StatusForbidden},
+		{"missing category", errors.New("category not found"), http.StatusBadRequest},
+		{"category mismatch", errors.New("category type does not match transaction type"), http.StatusBadRequest},
+		{"precision", shareddomain.ErrAmountPrecision, http.StatusBadRequest},
+		{"date range", errors.New("end date must not be before start date"), http.StatusBadRequest},
+		{"unexpected", errors.New("boom"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &mockRecurringRuleService{createErr: tt.err}
+			handler := &Handler{recurringRuleService: service}
+
+			jsonBody, _ := json.Marshal(validRecurringRuleBody())
+			req := httptest.NewRequest("POST", "/recurring-rules", bytes.NewBuffer(jsonBody))
+			rr := httptest.NewRecorder()
+			handler.CreateRecurringRule(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestGetRecurringRule_Success(t *testing.T) {
+	service := &mockRecurringRuleService{rule: sampleRule()}
+	handler := &Handler{recurringRuleService: service}
+
+	req := httptest.NewRequest("GET", "/recurring-rules/rule1", nil)
+	rr := httptest.NewRecorder()
+	handler.GetRecurringRule(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if service.lastID != "rule1" {
+		t.Errorf("expected id rule1, got %s", service.lastID)
+	}
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response["next_occurrence"] != "2026-04-01" || response["end_date"] != "2026-12-31" {
+		t.Errorf("unexpected dates in response: %v", response)
+	}
+	if response["amount"] != "1200" {
+		t.Errorf("expected amount encoded as string, got %v", response["amount"])
+	}
+	if response["frequency_name"] != "Monthly" {
+		t.Errorf("expected frequency name Monthly, got %v", response["frequency_name"])
+	}
+}
+
+func TestGetRecurringRule_NotFound(t *testing.T) {
+	service := &mockRecurringRuleService{getByIDErr: errors.New("recurring rule not found")}
+	handler := &Handler{recurringRuleService: service}
+
+	req := httptest.NewRequest("GET", "/recurring-rules/missing", nil)
+	rr := httptest.NewRecorder()
+	handler.GetRecurringRule(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", rr.Code)
+	}
+}
+
+func TestUpdateRecurringRule_Success(t *testing.T) {
+	service := &mockRecurringRuleService{}
+	handler := &Handler{recurringRuleService: service}
+
+	body := validRecurringRuleBody()
+	body.Active = boolPtr(false)
+	jsonBody, _ := json.Marshal(body)
+
+	req := httptest.NewRequest("PUT", "/recurring-rules/rule1", bytes.NewBuffer(jsonBody))
+	rr := httptest.NewRecorder()
+	handler.UpdateRecurringRule(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rr.Code)
+	}
+	if service.lastCommand.Active == nil || *service.lastCommand.Active {
+		t.Error("expected rule to be paused")
+	}
+}
+
+func TestDeleteRecurringRule_Forbidden(t *testing.T) {
+	service := &mockRecurringRuleService{deleteErr: errors.New("unauthorized access to recurring rule")}
+	handler := &Handler{recurringRuleService: service}
+
+	req := httptest.NewRequest("DELETE", "/recurring-rules/rule1", nil)
+	rr := httptest.NewRecorder()
+	handler.DeleteRecurringRule(rr, req)
+
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d", rr.Code)
+	}
+}
+
+func TestListRecurringRules_Success(t *testing.T) {
+	service := &mockRecurringRuleService{rules: []*queries.RecurringRuleResponse{sampleRule()}}
+	handler := &Handler{recurringRuleService: service}
+
+	req := httptest.NewRequest("GET", "/recurring-rules", nil)
+	rr := httptest.NewRecorder()
+	handler.ListRecurringRules(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+
+	var response []RecurringRuleResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response) != 1 {
+		t.Errorf("expected 1 rule, got %d", len(response))
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func amountPtr(value string) *shareddomain.Amount {
+	amount := shareddomain.MustParseAmount(value)
+	return &amount
+}
@@ -0,0 +1,17 @@
+package http
+
+import shareddomain "fin-flow-api/internal/shared/domain"
+
+type RecurringRuleRequest struct {
+	WalletID    string               `json:"wallet_id"`
+	CategoryID  string               `json:"category_id"`
+	Type        *int                 `json:"type"`
+	Amount      *shareddomain.Amount `json:"amount"`
+	Description string               `json:"description"`
+	Frequency   *int                 `json:"frequency"`
+	Interval    int                  `json:"interval"`
+	DayOfMonth  int                  `json:"day_of_month"`
+	StartDate   string               `json:"start_date"`
+	EndDate     string               `json:"end_date"`
+	Active      *bool                `json:"active"`
+}
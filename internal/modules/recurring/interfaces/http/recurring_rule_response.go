@@ -0,0 +1,29 @@
+package http
+
+import (
+	"time"
+
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+type RecurringRuleResponse struct {
+	ID             string              `json:"id"`
+	WalletID       string              `json:"wallet_id"`
+	CategoryID     string              `json:"category_id"`
+	Type           int                 `json:"type"`
+	TypeName       string              `json:"type_name"`
+	Amount         shareddomain.Amount `json:"amount"`
+	Description    string              `json:"description"`
+	Frequency      int                 `json:"frequency"`
+	FrequencyName  string              `json:"frequency_name"`
+	Interval       int                 `json:"interval"`
+	DayOfMonth     int                 `json:"day_of_month,omitempty"`
+	StartDate      string              `json:"start_date"`
+	EndDate        string              `json:"end_date,omitempty"`
+	NextOccurrence string              `json:"next_occurrence"`
+	Active         bool                `json:"active"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+	CreatedBy      string              `json:"created_by"`
+	UpdatedBy      string              `json:"updated_by"`
+}
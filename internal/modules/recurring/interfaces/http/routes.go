@@ -0,0 +1,51 @@
+package http
+
+import (
+	"net/http"
+
+	"fin-flow-api/internal/shared/interface/jwt"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+var recurringRuleHandler *Handler
+
+func SetupRoutes(mux *http.ServeMux, jwtService jwt.Service) {
+	mountRecurringRules(mux, jwtService)
+}
+
+func mountRecurringRules(mux *http.ServeMux, jwtService jwt.Service) {
+	mux.HandleFunc("/recurring-rules", handleRecurringRulesCollection(jwtService))
+
+	protectedHandler := middleware.RequireAuth(jwtService)(http.HandlerFunc(handleRecurringRulesResource))
+	mux.Handle("/recurring-rules/", protectedHandler)
+}
+
+func handleRecurringRulesCollection(jwtService jwt.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.RequireAuth(jwtService)(http.HandlerFunc(recurringRuleHandler.ListRecurringRules)).ServeHTTP(w, r)
+		case http.MethodPost:
+			middleware.RequireAuth(jwtService)(http.HandlerFunc(recurringRuleHandler.CreateRecurringRule)).ServeHTTP(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func handleRecurringRulesResource(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		recurringRuleHandler.GetRecurringRule(w, r)
+	case http.MethodPut:
+		recurringRuleHandler.UpdateRecurringRule(w, r)
+	case http.MethodDelete:
+		recurringRuleHandler.DeleteRecurringRule(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func SetHandler(handler *Handler) {
+	recurringRuleHandler = handler
+}
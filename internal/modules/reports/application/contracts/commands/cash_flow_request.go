@@ -0,0 +1,12 @@
+package commands
+
+import "time"
+
+// CashFlowRequest asks for the cash flow of the months from From to To. A
+// zero Reference asks for nominal figures.
+type CashFlowRequest struct {
+	Currency  string
+	From      time.Time
+	To        time.Time
+	Reference time.Time
+}
@@ -0,0 +1,37 @@
+package queries
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+type CategoryTypeTotalResponse struct {
+	CategoryType     int
+	CategoryTypeName string
+	Income           domain.Amount
+	Expenses         domain.Amount
+}
+
+type CashFlowTotalsResponse struct {
+	Income        domain.Amount
+	Expenses      domain.Amount
+	Net           domain.Amount
+	CategoryTypes []CategoryTypeTotalResponse
+}
+
+type CashFlowMonthResponse struct {
+	CashFlowTotalsResponse
+
+	Month  time.Time
+	Factor *domain.Amount
+}
+
+type CashFlowResponse struct {
+	Currency  string
+	From      time.Time
+	To        time.Time
+	Reference *time.Time
+	Months    []CashFlowMonthResponse
+	Total     CashFlowTotalsResponse
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	inflationdomain "fin-flow-api/internal/modules/inflation/domain"
+	"fin-flow-api/internal/modules/reports/application/contracts/commands"
+	"fin-flow-api/internal/modules/reports/application/contracts/queries"
+	"fin-flow-api/internal/modules/reports/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+type priceIndex interface {
+	Restater(currency walletdomain.Currency, reference, from, to time.Time) (*inflationdomain.Restater, error)
+}
+
+// ReportService reports what the caller earned and spent. Figures can be
+// restated to constant currency with the price index of the report's
+// currency.
+type ReportService struct {
+	repository domain.ReportRepository
+	priceIndex priceIndex
+}
+
+func NewReportService(repository domain.ReportRepository, priceIndex priceIndex) *ReportService {
+	return &ReportService{
+		repository: repository,
+		priceIndex: priceIndex,
+	}
+}
+
+func (s *ReportService) getUserIDFromContext(ctx context.Context) (string, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return "", errors.New("user not authenticated")
+	}
+	return userID, nil
+}
+
+// CashFlow returns income and expenses per month and category type, booked
+// in wallets of the requested currency.
+func (s *ReportService) CashFlow(ctx context.Context, req commands.CashFlowRequest) (*queries.CashFlowResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !walletdomain.IsValidCurrency(req.Currency) {
+		return nil, walletdomain.ErrInvalidCurrency
+	}
+	currency := walletdomain.Currency(req.Currency)
+
+	if domain.MonthOf(req.To).Before(domain.MonthOf(req.From)) {
+		return nil, domain.ErrInvalidPeriod
+	}
+
+	var restater domain.Restater
+	if !req.Reference.IsZero() {
+		indexes, err := s.priceIndex.Restater(currency, req.Reference, req.From, req.To)
+		if err != nil {
+			return nil, err
+		}
+		restater = indexes
+	}
+
+	totals, err := s.repository.MonthlyTotals(userID, currency, req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := domain.NewCashFlowReport(currency.String(), req.From, req.To, req.Reference, totals, restater)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCashFlowResponse(report), nil
+}
+
+func toCashFlowResponse(report *domain.CashFlowReport) *queries.CashFlowResponse {
+	response := &queries.CashFlowResponse{
+		Currency: report.Currency,
+		From:     report.From,
+		To:       report.To,
+		Months:   make([]queries.CashFlowMonthResponse, len(report.Months)),
+		Total:    toCashFlowTotalsResponse(report.Total),
+	}
+	if !report.Reference.IsZero() {
+		reference := report.Reference
+		response.Reference = &reference
+	}
+
+	for i, month := range report.Months {
+		response.Months[i] = queries.CashFlowMonthResponse{
+			CashFlowTotalsResponse: toCashFlowTotalsResponse(month.CashFlowTotals),
+			Month:                  month.Month,
+			Factor:                 month.Factor,
+		}
+	}
+
+	return response
+}
+
+func toCashFlowTotalsResponse(totals domain.CashFlowTotals) queries.CashFlowTotalsResponse {
+	response := queries.CashFlowTotalsResponse{
+		Income:        totals.Income,
+		Expenses:      totals.Expenses,
+		Net:           totals.Net,
+		CategoryTypes: make([]queries.CategoryTypeTotalResponse, len(totals.CategoryTypes)),
+	}
+
+	for i, total := range totals.CategoryTypes {
+		response.CategoryTypes[i] = queries.CategoryTypeTotalResponse{
+			CategoryType:     total.CategoryType.Value(),
+			CategoryTypeName: total.CategoryType.String(),
+			Income:           total.Income,
+			Expenses:         total.Expenses,
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	categorydomain "fin-flow-api/internal/modules/categories/domain"
+	inflationdomain "fin-flow-api/internal/modules/inflation/domain"
+	"fin-flow-api/internal/modules/reports/application/contracts/commands"
+	"fin-flow-api/internal/modules/reports/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+type mockContext struct {
+	context.Context
+	userID string
+	hasID  bool
+}
+
+func (m *mockContext) Value(key interface{}) interface{} {
+	if key == middleware.UserIDKey {
+		if m.hasID {
+			return m.userID
+		}
+		return nil
+	}
+	return nil
+}
+
+func authenticated() context.Context {
+	return &mockContext{userID: "user-1", hasID: true}
+}
+
+type mockReportRepository struct {
+	totals       []domain.MonthlyTotal
+	lastUserID   string
+	lastCurrency walletdomain.Currency
+}
+
+func (m *mockReportRepository) MonthlyTotals(userID string, currency walletdomain.Currency, from, to time.Time) ([]domain.MonthlyTotal, error) {
+	m.lastUserID, m.lastCurrency = userID, currency
+	return m.totals, nil
+}
+
+type mockPriceIndex struct {
+	values []*inflationdomain.IndexValue
+	calls  int
+}
+
+func (m *mockPriceIndex) Restater(currency walletdomain.Currency, reference, from, to time.Time) (*inflationdomain.Restater, error) {
+	m.calls++
+	return inflationdomain.NewRestater(currency, reference, m.values)
+}
+
+func month(year int, m time.Month) time.Time {
+	return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func indexValue(m time.Time, value string) *inflationdomain.IndexValue {
+	return inflationdomain.NewIndexValue("index-"+m.Format("2006-01"), walletdomain.CurrencyARS, m, shareddomain.MustParseAmount(value), inflationdomain.SourceManual, "system")
+}
+
+func newTestService() (*ReportService, *mockReportRepository, *mockPriceIndex) {
+	repo := &mockReportRepository{totals: []domain.MonthlyTotal{
+		{Month: month(2026, time.January), CategoryType: categorydomain.CategoryTypeIncome, Income: shareddomain.MustParseAmount("1000")},
+		{Month: month(2026, time.February), CategoryType: categorydomain.CategoryTypeExpense, Expenses: shareddomain.MustParseAmount("520")},
+	}}
+	priceIndex := &mockPriceIndex{values: []*inflationdomain.IndexValue{
+		indexValue(month(2026, time.January), "100"),
+		indexValue(month(2026, time.February), "104"),
+	}}
+	return NewReportService(repo, priceIndex), repo, priceIndex
+}
+
+func TestReportService_CashFlow_Nominal(t *testing.T) {
+	service, repo, priceIndex := newTestService()
+
+	report, err := service.CashFlow(authenticated(), commands.CashFlowRequest{Currency: "ARS", From: month(2026, time.January), To: month(2026, time.February)})
+	if err != nil {
+		t.Fatalf("CashFlow failed: %v", err)
+	}
+
+	if repo.lastUserID != "user-1" || repo.lastCurrency != walletdomain.CurrencyARS {
+		t.Errorf("unexpected repository call for %s in %s", repo.lastUserID, repo.lastCurrency)
+	}
+	if priceIndex.calls != 0 {
+		t.Errorf("expected no price index lookup for a nominal report, got %d", priceIndex.calls)
+	}
+	if report.Reference != nil || report.Total.Net.String() != "480" {
+		t.Errorf("unexpected report %+v", report)
+	}
+	if len(report.Months) != 2 || report.Months[0].CategoryTypes[0].CategoryTypeName != "Income" {
+		t.Errorf("unexpected months %+v", report.Months)
+	}
+}
+
+func TestReportService_CashFlow_Restated(t *testing.T) {
+	service, _, _ := newTestService()
+
+	report, err := service.CashFlow(authenticated(), commands.CashFlowRequest{
+		Currency:  "ARS",
+		From:      month(2026, time.January),
+		To:        month(2026, time.February),
+		Reference: month(2026, time.February),
+	})
+	if err != nil {
+		t.Fatalf("CashFlow failed: %v", err)
+	}
+
+	if report.Reference == nil || !report.Reference.Equal(month(2026, time.February)) {
+		t.Errorf("expected reference 2026-02, got %v", report.Reference)
+	}
+	if report.Months[0].Income.String() != "1040" || report.Months[0].Factor.String() != "1.04" {
+		t.Errorf("unexpected restated january %+v", report.Months[0])
+	}
+	if report.Total.Net.String() != "520" {
+		t.Errorf("expected restated net 520, got %s", report.Total.Net)
+	}
+}
+
+func TestReportService_CashFlow_Errors(t *testing.T) {
+	service, _, _ := newTestService()
+
+	if _, err := service.CashFlow(&mockContext{}, commands.CashFlowRequest{Currency: "ARS"}); err == nil || err.Error() != "user not authenticated" {
+		t.Errorf("expected user not authenticated, got %v", err)
+	}
+	if _, err := service.CashFlow(authenticated(), commands.CashFlowRequest{Currency: "XXX"}); !errors.Is(err, walletdomain.ErrInvalidCurrency) {
+		t.Errorf("expected %v, got %v", walletdomain.ErrInvalidCurrency, err)
+	}
+	if _, err := service.CashFlow(authenticated(), commands.CashFlowRequest{Currency: "ARS", From: month(2026, time.March), To: month(2026, time.January)}); !errors.Is(err, domain.ErrInvalidPeriod) {
+		t.Errorf("expected %v, got %v", domain.ErrInvalidPeriod, err)
+	}
+	_, err := service.CashFlow(authenticated(), commands.CashFlowRequest{
+		Currency:  "ARS",
+		From:      month(2026, time.January),
+		To:        month(2026, time.February),
+		Reference: month(2026, time.June),
+	})
+	if !errors.Is(err, inflationdomain.ErrIndexNotFound) {
+		t.Errorf("expected %v, got %v", inflationdomain.ErrIndexNotFound, err)
+	}
+}
@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	categorydomain "fin-flow-api/internal/modules/categories/domain"
+	"fin-flow-api/internal/shared/domain"
+)
+
+var ErrInvalidPeriod = errors.New("report period must not end before it starts")
+
+const monthKeyLayout = "2006-01"
+
+// MonthlyTotal is what a user booked in one month to categories of one
+// type. Investment categories can hold both income and expenses.
+type MonthlyTotal struct {
+	Month        time.Time
+	CategoryType categorydomain.CategoryType
+	Income       domain.Amount
+	Expenses     domain.Amount
+}
+
+// Restater restates nominal amounts to money of a reference month.
+type Restater interface {
+	Factor(month time.Time) (domain.Amount, error)
+	Restate(amount domain.Amount, month time.Time) (domain.Amount, error)
+}
+
+// CategoryTypeTotal is the income and expenses booked to categories of one
+// type.
+type CategoryTypeTotal struct {
+	CategoryType categorydomain.CategoryType
+	Income       domain.Amount
+	Expenses     domain.Amount
+}
+
+// CashFlowTotals sums income and expenses; Net is income minus expenses.
+type CashFlowTotals struct {
+	Income        domain.Amount
+	Expenses      domain.Amount
+	Net           domain.Amount
+	CategoryTypes []CategoryTypeTotal
+}
+
+// CashFlowMonth holds the totals of one month. Factor is the restatement
+// factor applied to the month, nil for nominal reports and months without
+// activity.
+type CashFlowMonth struct {
+	CashFlowTotals
+
+	Month  time.Time
+	Factor *domain.Amount
+}
+
+// CashFlowReport is the income and expenses of a user in one currency for
+// every month from From to To. When Reference is set every figure is
+// restated to constant currency of that month.
+type CashFlowReport struct {
+	Currency  string
+	From      time.Time
+	To        time.Time
+	Reference time.Time
+	Months    []CashFlowMonth
+	Total     CashFlowTotals
+}
+
+// NewCashFlowReport builds the report from the monthly totals. restater may
+// be nil for a nominal report; otherwise it must know the index of every
+// month with activity.
+func NewCashFlowReport(currency string, from, to, reference time.Time, totals []MonthlyTotal, restater Restater) (*CashFlowReport, error) {
+	from, to = MonthOf(from), MonthOf(to)
+	if to.Before(from) {
+		return nil, ErrInvalidPeriod
+	}
+
+	byMonth := make(map[string][]MonthlyTotal, len(totals))
+	for _, total := range totals {
+		key := MonthOf(total.Month).Format(monthKeyLayout)
+		byMonth[key] = append(byMonth[key], total)
+	}
+
+	report := &CashFlowReport{
+		Currency: currency,
+		From:     from,
+		To:       to,
+	}
+	if restater != nil {
+		report.Reference = MonthOf(reference)
+	}
+
+	var all []CategoryTypeTotal
+	for month := from; !month.After(to); month = month.AddDate(0, 1, 0) {
+		entry := CashFlowMonth{Month: month}
+
+		lines := byMonth[month.Format(monthKeyLayout)]
+		if restater != nil && len(lines) > 0 {
+			factor, err := restater.Factor(month)
+			if err != nil {
+				return nil, err
+			}
+			entry.Factor = &factor
+		}
+
+		var categoryTypes []CategoryTypeTotal
+		for _, line := range lines {
+			total := CategoryTypeTotal{
+				CategoryType: line.CategoryType,
+				Income:       line.Income,
+				Expenses:     line.Expenses,
+			}
+			if restater != nil {
+				var err error
+				if total.Income, err = restater.Restate(line.Income, month); err != nil {
+					return nil, err
+				}
+				if total.Expenses, err = restater.Restate(line.Expenses, month); err != nil {
+					return nil, err
+				}
+			}
+			categoryTypes = append(categoryTypes, total)
+		}
+
+		entry.CashFlowTotals = sumTotals(categoryTypes)
+		report.Months = append(report.Months, entry)
+		all = append(all, categoryTypes...)
+	}
+
+	report.Total = sumTotals(all)
+	return report, nil
+}
+
+// sumTotals adds up the totals, merging entries of the same category type.
+func sumTotals(totals []CategoryTypeTotal) CashFlowTotals {
+	merged := make(map[categorydomain.CategoryType]*CategoryTypeTotal)
+	result := CashFlowTotals{CategoryTypes: []CategoryTypeTotal{}}
+
+	for _, total := range totals {
+		result.Income = result.Income.Add(total.Income)
+		result.Expenses = result.Expenses.Add(total.Expenses)
+
+		if existing, ok := merged[total.CategoryType]; ok {
+			existing.Income = existing.Income.Add(total.Income)
+			existing.Expenses = existing.Expenses.Add(total.Expenses)
+			continue
+		}
+		copied := total
+		merged[total.CategoryType] = &copied
+	}
+
+	for _, total := range merged {
+		result.CategoryTypes = append(result.CategoryTypes, *total)
+	}
+	sort.Slice(result.CategoryTypes, func(i, j int) bool {
+		return result.CategoryTypes[i].CategoryType < result.CategoryTypes[j].CategoryType
+	})
+
+	result.Net = result.Income.Sub(result.Expenses)
+	return result
+}
+
+// MonthOf returns the first day of the month containing date, in UTC.
+func MonthOf(date time.Time) time.Time {
+	if date.IsZero() {
+		return date
+	}
+	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	categorydomain "fin-flow-api/internal/modules/categories/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+func month(year int, m time.Month) time.Time {
+	return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func amount(value string) shareddomain.Amount {
+	return shareddomain.MustParseAmount(value)
+}
+
+// doublingRestater doubles every amount, and knows no index for skipped.
+type doublingRestater struct {
+	skipped time.Time
+}
+
+func (r doublingRestater) Factor(m time.Time) (shareddomain.Amount, error) {
+	if m.Equal(r.skipped) {
+		return shareddomain.Amount{}, fmt.Errorf("price index not found in %s", m.Format(monthKeyLayout))
+	}
+	return amount("2"), nil
+}
+
+func (r doublingRestater) Restate(value shareddomain.Amount, m time.Time) (shareddomain.Amount, error) {
+	factor, err := r.Factor(m)
+	if err != nil {
+		return shareddomain.Amount{}, err
+	}
+	return value.Mul(factor), nil
+}
+
+func sampleTotals() []MonthlyTotal {
+	return []MonthlyTotal{
+		{Month: month(2026, time.January), CategoryType: categorydomain.CategoryTypeIncome, Income: amount("1000")},
+		{Month: month(2026, time.January), CategoryType: categorydomain.CategoryTypeExpense, Expenses: amount("600")},
+		{Month: month(2026, time.March), CategoryType: categorydomain.CategoryTypeInvestment, Income: amount("50"), Expenses: amount("200")},
+		{Month: month(2026, time.March), CategoryType: categorydomain.CategoryTypeExpense, Expenses: amount("300.5")},
+	}
+}
+
+func TestNewCashFlowReport_Nominal(t *testing.T) {
+	report, err := NewCashFlowReport("ARS", time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC), month(2026, time.March), month(2026, time.March), sampleTotals(), nil)
+	if err != nil {
+		t.Fatalf("NewCashFlowReport failed: %v", err)
+	}
+
+	if !report.Reference.IsZero() {
+		t.Errorf("expected no reference month, got %s", report.Reference)
+	}
+	if len(report.Months) != 3 {
+		t.Fatalf("expected 3 months, got %d", len(report.Months))
+	}
+
+	january, february, march := report.Months[0], report.Months[1], report.Months[2]
+	if january.Income.String() != "1000" || january.Expenses.String() != "600" || january.Net.String() != "400" || january.Factor != nil {
+		t.Errorf("unexpected january %+v", january)
+	}
+	if !february.Net.IsZero() || len(february.CategoryTypes) != 0 {
+		t.Errorf("expected an empty february, got %+v", february)
+	}
+	if march.Net.String() != "-450.5" || len(march.CategoryTypes) != 2 || march.CategoryTypes[0].CategoryType != categorydomain.CategoryTypeExpense {
+		t.Errorf("unexpected march %+v", march)
+	}
+
+	if report.Total.Income.String() != "1050" || report.Total.Expenses.String() != "1100.5" || report.Total.Net.String() != "-50.5" {
+		t.Errorf("unexpected total %+v", report.Total)
+	}
+	if len(report.Total.CategoryTypes) != 3 || report.Total.CategoryTypes[0].Expenses.String() != "900.5" {
+		t.Errorf("unexpected category type totals %+v", report.Total.CategoryTypes)
+	}
+}
+
+func TestNewCashFlowReport_Restated(t *testing.T) {
+	report, err := NewCashFlowReport("ARS", month(2026, time.January), month(2026, time.March), month(2026, time.March), sampleTotals(), doublingRestater{skipped: month(2026, time.February)})
+	if err != nil {
+		t.Fatalf("NewCashFlowReport failed: %v", err)
+	}
+
+	if !report.Reference.Equal(month(2026, time.March)) {
+		t.Errorf("expected reference 2026-03, got %s", report.Reference)
+	}
+	if factor := report.Months[0].Factor; factor == nil || factor.String() != "2" {
+		t.Errorf("expected factor 2 for january, got %v", factor)
+	}
+	if report.Months[1].Factor != nil {
+		t.Errorf("expected no factor for a month without activity, got %v", report.Months[1].Factor)
+	}
+	if report.Total.Net.String() != "-101" {
+		t.Errorf("expected restated net -101, got %s", report.Total.Net)
+	}
+}
+
+func TestNewCashFlowReport_Errors(t *testing.T) {
+	if _, err := NewCashFlowReport("ARS", month(2026, time.March), month(2026, time.January), time.Time{}, nil, nil); !errors.Is(err, ErrInvalidPeriod) {
+		t.Errorf("expected %v, got %v", ErrInvalidPeriod, err)
+	}
+
+	_, err := NewCashFlowReport("ARS", month(2026, time.January), month(2026, time.March), month(2026, time.March), sampleTotals(), doublingRestater{skipped: month(2026, time.March)})
+	if err == nil || err.Error() != "price index not found in 2026-03" {
+		t.Errorf("expected the missing index to fail the report, got %v", err)
+	}
+}
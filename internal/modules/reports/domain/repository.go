@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"time"
+
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+)
+
+type ReportRepository interface {
+	// MonthlyTotals returns the income and expenses of a user booked in
+	// wallets of the given currency, grouped by month and category type, for
+	// the months from from to to inclusive. Transfers are left out.
+	MonthlyTotals(userID string, currency walletdomain.Currency, from, to time.Time) ([]MonthlyTotal, error)
+}
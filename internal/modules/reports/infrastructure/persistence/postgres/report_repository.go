@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	categorydomain "fin-flow-api/internal/modules/categories/domain"
+	"fin-flow-api/internal/modules/reports/domain"
+	transactiondomain "fin-flow-api/internal/modules/transactions/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+func (r *Repository) MonthlyTotals(userID string, currency walletdomain.Currency, from, to time.Time) ([]domain.MonthlyTotal, error) {
+	query := `
+		SELECT date_trunc('month', t.date)::date AS month, c.type,
+			COALESCE(SUM(t.amount) FILTER (WHERE t.type = $3), 0),
+			COALESCE(SUM(t.amount) FILTER (WHERE t.type = $4), 0)
+		FROM transactions t
+		JOIN wallets w ON w.id = t.wallet_id
+		JOIN categories c ON c.id = t.category_id
+		WHERE t.user_id = $1 AND w.currency = $2 AND t.type IN ($3, $4)
+			AND t.date >= $5 AND t.date < $6
+		GROUP BY month, c.type
+		ORDER BY month, c.type
+	`
+
+	rows, err := r.pool.Query(
+		context.Background(),
+		query,
+		userID,
+		currency.String(),
+		transactiondomain.TransactionTypeIncome.Value(),
+		transactiondomain.TransactionTypeExpense.Value(),
+		domain.MonthOf(from),
+		domain.MonthOf(to).AddDate(0, 1, 0),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get monthly totals: %w", err)
+	}
+	defer rows.Close()
+
+	var totals []domain.MonthlyTotal
+	for rows.Next() {
+		var total domain.MonthlyTotal
+		var categoryType int
+		if err := rows.Scan(&total.Month, &categoryType, &total.Income, &total.Expenses); err != nil {
+			return nil, fmt.Errorf("failed to scan monthly totals: %w", err)
+		}
+		total.CategoryType = categorydomain.CategoryType(categoryType)
+		totals = append(totals, total)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate monthly totals: %w", err)
+	}
+
+	return totals, nil
+}
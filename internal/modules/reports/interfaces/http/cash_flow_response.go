@@ -0,0 +1,32 @@
+package http
+
+import shareddomain "fin-flow-api/internal/shared/domain"
+
+type CategoryTypeTotalResponse struct {
+	CategoryType     int                 `json:"category_type"`
+	CategoryTypeName string              `json:"category_type_name"`
+	Income           shareddomain.Amount `json:"income"`
+	Expenses         shareddomain.Amount `json:"expenses"`
+}
+
+type CashFlowTotalsResponse struct {
+	Income        shareddomain.Amount         `json:"income"`
+	Expenses      shareddomain.Amount         `json:"expenses"`
+	Net           shareddomain.Amount         `json:"net"`
+	CategoryTypes []CategoryTypeTotalResponse `json:"category_types"`
+}
+
+type CashFlowMonthResponse struct {
+	Month  string               `json:"month"`
+	Factor *shareddomain.Amount `json:"factor,omitempty"`
+	CashFlowTotalsResponse
+}
+
+type CashFlowResponse struct {
+	Currency  string                  `json:"currency"`
+	From      string                  `json:"from"`
+	To        string                  `json:"to"`
+	Reference *string                 `json:"reference_month"`
+	Months    []CashFlowMonthResponse `json:"months"`
+	Total     CashFlowTotalsResponse  `json:"total"`
+}
@@ -0,0 +1,174 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"fin-flow-api/internal/modules/reports/application/contracts/commands"
+	"fin-flow-api/internal/modules/reports/application/contracts/queries"
+	basehandler "fin-flow-api/internal/shared/http"
+)
+
+const monthLayout = "2006-01"
+
+// defaultReportMonths is how many months a report covers when no start
+// month is given.
+const defaultReportMonths = 12
+
+type reportService interface {
+	CashFlow(ctx context.Context, req commands.CashFlowRequest) (*queries.CashFlowResponse, error)
+}
+
+type Handler struct {
+	reportService reportService
+}
+
+func NewHandler(reportService reportService) *Handler {
+	return &Handler{
+		reportService: reportService,
+	}
+}
+
+// GetCashFlow handles GET /reports/cash-flow?currency=ARS&from=YYYY-MM&to=YYYY-MM&reference=YYYY-MM.
+// The report ends in the current month and covers the twelve months up to
+// its end unless told otherwise. With a reference month every figure is
+// restated to constant currency of that month.
+func (h *Handler) GetCashFlow(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+
+	currency := strings.ToUpper(strings.TrimSpace(query.Get("currency")))
+	if currency == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Currency is required")
+		return
+	}
+
+	to, err := parseOptionalMonth(query.Get("to"), "to")
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if to.IsZero() {
+		now := time.Now().UTC()
+		to = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	from, err := parseOptionalMonth(query.Get("from"), "from")
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 1-defaultReportMonths, 0)
+	}
+
+	reference, err := parseOptionalMonth(query.Get("reference"), "reference")
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.reportService.CashFlow(r.Context(), commands.CashFlowRequest{
+		Currency:  currency,
+		From:      from,
+		To:        to,
+		Reference: reference,
+	})
+	if err != nil {
+		statusCode, errorMsg := reportErrorResponse(err)
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, toCashFlowResponse(report))
+}
+
+func parseOptionalMonth(value, field string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	month, err := time.Parse(monthLayout, value)
+	if err != nil {
+		return time.Time{}, &ValidationError{Field: field, Message: "Month must use the YYYY-MM format"}
+	}
+
+	return month, nil
+}
+
+func reportErrorResponse(err error) (int, string) {
+	errorMsg := err.Error()
+
+	switch {
+	case strings.Contains(errorMsg, "user not authenticated"):
+		return http.StatusUnauthorized, "Authentication required"
+	case strings.Contains(errorMsg, "invalid currency"):
+		return http.StatusBadRequest, "Invalid currency"
+	case strings.Contains(errorMsg, "price index not found"):
+		return http.StatusNotFound, errorMsg
+	case strings.Contains(errorMsg, "report period"):
+		return http.StatusBadRequest, errorMsg
+	}
+
+	return http.StatusInternalServerError, errorMsg
+}
+
+func toCashFlowResponse(report *queries.CashFlowResponse) CashFlowResponse {
+	response := CashFlowResponse{
+		Currency: report.Currency,
+		From:     report.From.Format(monthLayout),
+		To:       report.To.Format(monthLayout),
+		Months:   make([]CashFlowMonthResponse, len(report.Months)),
+		Total:    toCashFlowTotalsResponse(report.Total),
+	}
+	if report.Reference != nil {
+		reference := report.Reference.Format(monthLayout)
+		response.Reference = &reference
+	}
+
+	for i, month := range report.Months {
+		response.Months[i] = CashFlowMonthResponse{
+			Month:                  month.Month.Format(monthLayout),
+			Factor:                 month.Factor,
+			CashFlowTotalsResponse: toCashFlowTotalsResponse(month.CashFlowTotalsResponse),
+		}
+	}
+
+	return response
+}
+
+func toCashFlowTotalsResponse(totals queries.CashFlowTotalsResponse) CashFlowTotalsResponse {
+	response := CashFlowTotalsResponse{
+		Income:        totals.Income,
+		Expenses:      totals.Expenses,
+		Net:           totals.Net,
+		CategoryTypes: make([]CategoryTypeTotalResponse, len(totals.CategoryTypes)),
+	}
+
+	for i, total := range totals.CategoryTypes {
+		response.CategoryTypes[i] = CategoryTypeTotalResponse{
+			CategoryType:     total.CategoryType,
+			CategoryTypeName: total.CategoryTypeName,
+			Income:           total.Income,
+			Expenses:         total.Expenses,
+		}
+	}
+
+	return response
+}
+
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
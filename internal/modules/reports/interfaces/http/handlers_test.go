@@ -0,0 +1,146 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	inflationdomain "fin-flow-api/internal/modules/inflation/domain"
+	"fin-flow-api/internal/modules/reports/application/contracts/commands"
+	"fin-flow-api/internal/modules/reports/application/contracts/queries"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+type mockReportService struct {
+	err         error
+	report      *queries.CashFlowResponse
+	lastRequest commands.CashFlowRequest
+}
+
+func (m *mockReportService) CashFlow(ctx context.Context, req commands.CashFlowRequest) (*queries.CashFlowResponse, error) {
+	m.lastRequest = req
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.report, nil
+}
+
+func month(year int, m time.Month) time.Time {
+	return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func TestGetCashFlow_Success(t *testing.T) {
+	reference := month(2026, time.February)
+	factor := shareddomain.MustParseAmount("1.04")
+	service := &mockReportService{report: &queries.CashFlowResponse{
+		Currency:  "ARS",
+		From:      month(2026, time.January),
+		To:        month(2026, time.February),
+		Reference: &reference,
+		Months: []queries.CashFlowMonthResponse{{
+			CashFlowTotalsResponse: queries.CashFlowTotalsResponse{
+				Income: shareddomain.MustParseAmount("1040"),
+				Net:    shareddomain.MustParseAmount("1040"),
+				CategoryTypes: []queries.CategoryTypeTotalResponse{{
+					CategoryType:     1,
+					CategoryTypeName: "Income",
+					Income:           shareddomain.MustParseAmount("1040"),
+				}},
+			},
+			Month:  month(2026, time.January),
+			Factor: &factor,
+		}},
+	}}
+	handler := &Handler{reportService: service}
+
+	req := httptest.NewRequest("GET", "/reports/cash-flow?currency=ars&from=2026-01&to=2026-02&reference=2026-02", nil)
+	rr := httptest.NewRecorder()
+	handler.GetCashFlow(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if service.lastRequest.Currency != "ARS" || !service.lastRequest.Reference.Equal(reference) {
+		t.Errorf("unexpected request %+v", service.lastRequest)
+	}
+
+	var response CashFlowResponse
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Reference == nil || *response.Reference != "2026-02" {
+		t.Errorf("expected reference month 2026-02, got %v", response.Reference)
+	}
+	if len(response.Months) != 1 || response.Months[0].Month != "2026-01" || response.Months[0].Income.String() != "1040" || response.Months[0].Factor.String() != "1.04" {
+		t.Errorf("unexpected months %+v", response.Months)
+	}
+}
+
+func TestGetCashFlow_DefaultsToTwelveMonths(t *testing.T) {
+	service := &mockReportService{report: &queries.CashFlowResponse{}}
+	handler := &Handler{reportService: service}
+
+	req := httptest.NewRequest("GET", "/reports/cash-flow?currency=ARS&to=2026-06", nil)
+	rr := httptest.NewRecorder()
+	handler.GetCashFlow(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if !service.lastRequest.From.Equal(month(2025, time.July)) {
+		t.Errorf("expected the report to start in 2025-07, got %s", service.lastRequest.From)
+	}
+	if !service.lastRequest.Reference.IsZero() {
+		t.Errorf("expected a nominal report, got reference %s", service.lastRequest.Reference)
+	}
+}
+
+func TestGetCashFlow_BadRequests(t *testing.T) {
+	handler := &Handler{reportService: &mockReportService{}}
+
+	for _, target := range []string{
+		"/reports/cash-flow",
+		"/reports/cash-flow?currency=ARS&from=2026",
+		"/reports/cash-flow?currency=ARS&reference=2026-02-01",
+	} {
+		req := httptest.NewRequest("GET", target, nil)
+		rr := httptest.NewRecorder()
+		handler.GetCashFlow(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", target, rr.Code)
+		}
+	}
+}
+
+func TestGetCashFlow_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"unauthenticated", errors.New("user not authenticated"), http.StatusUnauthorized},
+		{"currency", errors.New("invalid currency"), http.StatusBadRequest},
+		{"missing index", inflationdomain.ErrIndexNotFound, http.StatusNotFound},
+		{"period", errors.New("report period must not end before it starts"), http.StatusBadRequest},
+		{"unexpected", errors.New("boom"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &Handler{reportService: &mockReportService{err: tt.err}}
+
+			req := httptest.NewRequest("GET", "/reports/cash-flow?currency=ARS", nil)
+			rr := httptest.NewRecorder()
+			handler.GetCashFlow(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rr.Code)
+			}
+		})
+	}
+}
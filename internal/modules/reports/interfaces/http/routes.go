@@ -0,0 +1,22 @@
+package http
+
+import (
+	"net/http"
+
+	"fin-flow-api/internal/shared/interface/jwt"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+var reportHandler *Handler
+
+func SetupRoutes(mux *http.ServeMux, jwtService jwt.Service) {
+	mountReports(mux, jwtService)
+}
+
+func mountReports(mux *http.ServeMux, jwtService jwt.Service) {
+	mux.Handle("/reports/cash-flow", middleware.RequireAuth(jwtService)(http.HandlerFunc(reportHandler.GetCashFlow)))
+}
+
+func SetHandler(handler *Handler) {
+	reportHandler = handler
+}
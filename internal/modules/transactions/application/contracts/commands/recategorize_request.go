@@ -0,0 +1,11 @@
+package commands
+
+import "fin-flow-api/internal/modules/transactions/application/contracts/queries"
+
+// RecategorizeRequest moves the transactions that match Filter to
+// CategoryID. When TransactionIDs is set, only those among them move.
+type RecategorizeRequest struct {
+	CategoryID     string
+	Filter         queries.ListTransactionsRequest
+	TransactionIDs []string
+}
@@ -0,0 +1,24 @@
+package commands
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+type TransactionRequest struct {
+	WalletID            string
+	DestinationWalletID string
+	CategoryID          string
+	Type                int
+	Amount              domain.Amount
+	DestinationAmount   domain.Amount
+	ExchangeRate        domain.Amount
+	Description         string
+	Payee               string
+	Tags                []string
+	Date                time.Time
+	// Installments splits a credit card expense into that many monthly
+	// installments. Zero and one mean a single charge.
+	Installments int
+}
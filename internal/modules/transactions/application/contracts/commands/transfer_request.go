@@ -0,0 +1,18 @@
+package commands
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+type TransferRequest struct {
+	FromWalletID      string
+	ToWalletID        string
+	CategoryID        string
+	Amount            domain.Amount
+	DestinationAmount domain.Amount
+	ExchangeRate      domain.Amount
+	Description       string
+	Date              time.Time
+}
@@ -0,0 +1,11 @@
+package queries
+
+import "time"
+
+type ListTransactionsRequest struct {
+	WalletID   string
+	CategoryID string
+	Type       *int
+	From       *time.Time
+	To         *time.Time
+}
@@ -0,0 +1,50 @@
+package queries
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+type TransactionResponse struct {
+	ID                  string
+	WalletID            string
+	CategoryID          string
+	Type                int
+	TypeName            string
+	Amount              domain.Amount
+	Description         string
+	Payee               string
+	Tags                []string
+	Date                time.Time
+	TransferID          string
+	CounterpartWalletID string
+	ExchangeRate        domain.Amount
+	Inbound             bool
+	RecurringRuleID     string
+	InstallmentPlanID   string
+	InstallmentNumber   int
+	InstallmentCount    int
+	LoanID              string
+	LoanInstallment     int
+	ImportBatchID       string
+	ExternalID          string
+	ValueDate           *time.Time
+	ReconciliationID    string
+	Reconciled          bool
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	CreatedBy           string
+	UpdatedBy           string
+}
+
+// CreateTransactionResponse identifies a new expense or income and the
+// existing transactions it is likely a duplicate of, best match first.
+// Transfers and purchases in installments create several transactions and
+// are identified by their TransferID or InstallmentPlanID instead.
+type CreateTransactionResponse struct {
+	ID                   string
+	TransferID           string
+	InstallmentPlanID    string
+	PossibleDuplicateIDs []string
+}
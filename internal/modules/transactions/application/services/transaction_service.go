@@ -0,0 +1,540 @@
+package services
+
+import (
+	"context"
+	"errors"
+	categorydomain "fin-flow-api/internal/modules/categories/domain"
+	"fin-flow-api/internal/modules/transactions/application/contracts/commands"
+	"fin-flow-api/internal/modules/transactions/application/contracts/queries"
+	"fin-flow-api/internal/modules/transactions/domain"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/middleware"
+
+	"github.com/google/uuid"
+)
+
+// categorizer applies the categorization rules of a user to a new expense
+// or income in a wallet of currency.
+type categorizer interface {
+	Categorize(transaction *domain.Transaction, currency string) error
+}
+
+// duplicateFinder looks for the existing transactions a new expense or
+// income is likely a duplicate of.
+type duplicateFinder interface {
+	FindDuplicates(transaction *domain.Transaction) ([]string, error)
+}
+
+type TransactionService struct {
+	repository         domain.TransactionRepository
+	walletRepository   walletdomain.WalletRepository
+	categoryRepository categorydomain.CategoryRepository
+	categorizer        categorizer
+	duplicateFinder    duplicateFinder
+	systemUser         string
+}
+
+// NewTransactionService returns the transaction service. categorizer may be
+// nil, in which case new transactions keep what they are given, and so may
+// duplicateFinder, in which case they are not checked for duplicates.
+func NewTransactionService(repository domain.TransactionRepository, walletRepository walletdomain.WalletRepository, categoryRepository categorydomain.CategoryRepository, categorizer categorizer, duplicateFinder duplicateFinder, systemUser string) *TransactionService {
+	return &TransactionService{
+		repository:         repository,
+		walletRepository:   walletRepository,
+		categoryRepository: categoryRepository,
+		categorizer:        categorizer,
+		duplicateFinder:    duplicateFinder,
+		systemUser:         systemUser,
+	}
+}
+
+func (s *TransactionService) getUserIDFromContext(ctx context.Context) (string, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return "", errors.New("user not authenticated")
+	}
+	return userID, nil
+}
+
+// Create records an expense, income or transfer. A new expense or income is
+// checked against the existing transactions of its wallet, and those it is
+// likely a duplicate of are reported; it is created all the same.
+func (s *TransactionService) Create(ctx context.Context, req commands.TransactionRequest) (*queries.CreateTransactionResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Installments < 0 || req.Installments > domain.MaxInstallments {
+		return nil, domain.ErrInvalidInstallments
+	}
+
+	if req.Type == domain.TransactionTypeTransfer.Value() {
+		if req.Installments > 1 {
+			return nil, domain.ErrInstallmentsRequireCreditCard
+		}
+		transferID, err := s.transfer(userID, commands.TransferRequest{
+			FromWalletID:      req.WalletID,
+			ToWalletID:        req.DestinationWalletID,
+			CategoryID:        req.CategoryID,
+			Amount:            req.Amount,
+			DestinationAmount: req.DestinationAmount,
+			ExchangeRate:      req.ExchangeRate,
+			Description:       req.Description,
+			Date:              req.Date,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &queries.CreateTransactionResponse{TransferID: transferID}, nil
+	}
+
+	wallet, err := s.validateWallet(userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.categorize(wallet, &req); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateCategory(userID, req); err != nil {
+		return nil, err
+	}
+
+	if req.Installments >= domain.MinInstallments {
+		planID, err := s.createInstallments(userID, wallet, req)
+		if err != nil {
+			return nil, err
+		}
+		return &queries.CreateTransactionResponse{InstallmentPlanID: planID}, nil
+	}
+
+	transaction := domain.NewTransaction(
+		uuid.New().String(),
+		userID,
+		req.WalletID,
+		req.CategoryID,
+		domain.TransactionType(req.Type),
+		req.Amount,
+		req.Description,
+		req.Date,
+		s.systemUser,
+	)
+	transaction.Payee = req.Payee
+	transaction.Tags = req.Tags
+
+	response := &queries.CreateTransactionResponse{ID: transaction.ID}
+	if s.duplicateFinder != nil {
+		if response.PossibleDuplicateIDs, err = s.duplicateFinder.FindDuplicates(transaction); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repository.Create(transaction); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// categorize lets the categorization rules of the owner of wallet fill in
+// the category, payee and tags of a new expense or income in it.
+func (s *TransactionService) categorize(wallet *walletdomain.Wallet, req *commands.TransactionRequest) error {
+	if s.categorizer == nil {
+		return nil
+	}
+
+	transaction := domain.NewTransaction("", wallet.UserID, wallet.ID, req.CategoryID, domain.TransactionType(req.Type), req.Amount, req.Description, req.Date, s.systemUser)
+	transaction.Payee = req.Payee
+	transaction.Tags = req.Tags
+
+	if err := s.categorizer.Categorize(transaction, wallet.Currency.String()); err != nil {
+		return err
+	}
+
+	req.CategoryID = transaction.CategoryID
+	req.Payee = transaction.Payee
+	req.Tags = transaction.Tags
+	return nil
+}
+
+// createInstallments spreads a credit card purchase over monthly
+// installments so each one is billed on a later statement, and returns the
+// id of the installment plan.
+func (s *TransactionService) createInstallments(userID string, wallet *walletdomain.Wallet, req commands.TransactionRequest) (string, error) {
+	if wallet.Type != walletdomain.WalletTypeCreditCard || req.Type != domain.TransactionTypeExpense.Value() {
+		return "", domain.ErrInstallmentsRequireCreditCard
+	}
+
+	ids := make([]string, req.Installments)
+	for i := range ids {
+		ids[i] = uuid.New().String()
+	}
+
+	planID := uuid.New().String()
+	installments, err := domain.NewInstallments(
+		ids,
+		planID,
+		userID,
+		wallet.ID,
+		req.CategoryID,
+		req.Amount,
+		wallet.Currency.String(),
+		req.Installments,
+		req.Description,
+		req.Date,
+		s.systemUser,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	for _, installment := range installments {
+		installment.Payee = req.Payee
+		installment.Tags = req.Tags
+	}
+
+	if err := s.repository.CreateInstallments(installments); err != nil {
+		return "", err
+	}
+	return planID, nil
+}
+
+// Transfer moves money between two wallets of the authenticated user. Wallets
+// in different currencies need either the exchange rate or the amount that
+// reaches the destination wallet.
+func (s *TransactionService) Transfer(ctx context.Context, req commands.TransferRequest) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.transfer(userID, req)
+	return err
+}
+
+// transfer records the two legs of a transfer and returns the id that links
+// them.
+func (s *TransactionService) transfer(userID string, req commands.TransferRequest) (string, error) {
+	if !req.Amount.IsPositive() {
+		return "", domain.ErrInvalidAmount
+	}
+	if req.ToWalletID == "" {
+		return "", domain.ErrDestinationRequired
+	}
+	if req.ToWalletID == req.FromWalletID {
+		return "", domain.ErrSameWallet
+	}
+
+	source, err := s.walletRepository.GetByID(req.FromWalletID, userID)
+	if err != nil {
+		return "", err
+	}
+
+	destination, err := s.walletRepository.GetByID(req.ToWalletID, userID)
+	if err != nil {
+		return "", err
+	}
+
+	destinationAmount, exchangeRate, err := domain.ResolveTransferAmounts(
+		req.Amount,
+		req.DestinationAmount,
+		req.ExchangeRate,
+		source.Currency.String(),
+		destination.Currency.String(),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	if req.CategoryID != "" {
+		if _, err := s.categoryRepository.GetByID(req.CategoryID, userID); err != nil {
+			return "", err
+		}
+	}
+
+	transferID := uuid.New().String()
+	out, in := domain.NewTransfer(
+		uuid.New().String(),
+		uuid.New().String(),
+		transferID,
+		userID,
+		source.ID,
+		destination.ID,
+		req.CategoryID,
+		req.Amount,
+		destinationAmount,
+		exchangeRate,
+		req.Description,
+		req.Date,
+		s.systemUser,
+	)
+
+	if err := s.repository.CreateTransfer(out, in); err != nil {
+		return "", err
+	}
+	return transferID, nil
+}
+
+func (s *TransactionService) Update(ctx context.Context, transactionID string, req commands.TransactionRequest) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	transaction, err := s.repository.GetByID(transactionID, userID)
+	if err != nil {
+		return err
+	}
+
+	if transaction.IsTransfer() || req.Type == domain.TransactionTypeTransfer.Value() {
+		return domain.ErrTransferNotEditable
+	}
+
+	if transaction.IsInstallment() || req.Installments > 1 {
+		return domain.ErrInstallmentNotEditable
+	}
+
+	if transaction.IsLoanPayment() {
+		return domain.ErrLoanPaymentNotEditable
+	}
+
+	if err := transaction.CheckEdit(req.WalletID, domain.TransactionType(req.Type), req.Amount, req.Date); err != nil {
+		return err
+	}
+
+	if _, err := s.validate(userID, req); err != nil {
+		return err
+	}
+
+	transaction.WalletID = req.WalletID
+	transaction.CategoryID = req.CategoryID
+	transaction.Type = domain.TransactionType(req.Type)
+	transaction.Amount = req.Amount
+	transaction.Description = req.Description
+	transaction.Payee = req.Payee
+	transaction.Tags = req.Tags
+	transaction.Date = req.Date
+	transaction.Entity.UpdateModified(s.systemUser)
+
+	return s.repository.Update(transaction)
+}
+
+func (s *TransactionService) Delete(ctx context.Context, transactionID string) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return s.repository.Delete(transactionID, userID)
+}
+
+func (s *TransactionService) GetByID(ctx context.Context, transactionID string) (*queries.TransactionResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.repository.GetByID(transactionID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toTransactionResponse(transaction), nil
+}
+
+func (s *TransactionService) List(ctx context.Context, req queries.ListTransactionsRequest) ([]*queries.TransactionResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := toTransactionFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := s.repository.List(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*queries.TransactionResponse, len(transactions))
+	for i, transaction := range transactions {
+		responses[i] = toTransactionResponse(transaction)
+	}
+
+	return responses, nil
+}
+
+// Recategorize moves a filtered set of transactions to another category
+// and returns how many were updated. Every transaction must accept the new
+// category, otherwise none is moved.
+func (s *TransactionService) Recategorize(ctx context.Context, req commands.RecategorizeRequest) (int, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if req.CategoryID == "" {
+		return 0, domain.ErrCategoryRequired
+	}
+
+	filter, err := toTransactionFilter(req.Filter)
+	if err != nil {
+		return 0, err
+	}
+	if filter.WalletID == "" && filter.CategoryID == "" && filter.Type == nil && filter.From == nil && filter.To == nil && len(req.TransactionIDs) == 0 {
+		return 0, domain.ErrRecategorizeUnfiltered
+	}
+
+	category, err := s.categoryRepository.GetByID(req.CategoryID, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	transactions, err := s.repository.List(userID, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	var selected map[string]bool
+	if len(req.TransactionIDs) > 0 {
+		selected = make(map[string]bool, len(req.TransactionIDs))
+		for _, id := range req.TransactionIDs {
+			selected[id] = true
+		}
+	}
+
+	var ids []string
+	for _, transaction := range transactions {
+		if selected != nil && !selected[transaction.ID] {
+			continue
+		}
+		if transaction.CategoryID == category.ID {
+			continue
+		}
+		if !transaction.Type.AcceptsCategory(category.Type) {
+			return 0, domain.ErrCategoryTypeMismatch
+		}
+		ids = append(ids, transaction.ID)
+	}
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	return s.repository.Recategorize(ids, userID, category.ID, s.systemUser)
+}
+
+func toTransactionFilter(req queries.ListTransactionsRequest) (domain.TransactionFilter, error) {
+	filter := domain.TransactionFilter{
+		WalletID:   req.WalletID,
+		CategoryID: req.CategoryID,
+		From:       req.From,
+		To:         req.To,
+	}
+	if req.Type != nil {
+		if !domain.IsValidTransactionType(*req.Type) {
+			return filter, domain.ErrInvalidTransactionType
+		}
+		transactionType := domain.TransactionType(*req.Type)
+		filter.Type = &transactionType
+	}
+	return filter, nil
+}
+
+// validate checks a non-transfer request and returns the wallet it is
+// posted to.
+func (s *TransactionService) validate(userID string, req commands.TransactionRequest) (*walletdomain.Wallet, error) {
+	wallet, err := s.validateWallet(userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateCategory(userID, req); err != nil {
+		return nil, err
+	}
+
+	return wallet, nil
+}
+
+// validateWallet checks the type and amount of an expense or income and
+// returns the wallet it goes to.
+func (s *TransactionService) validateWallet(userID string, req commands.TransactionRequest) (*walletdomain.Wallet, error) {
+	if !domain.IsValidTransactionType(req.Type) {
+		return nil, domain.ErrInvalidTransactionType
+	}
+
+	if !req.Amount.IsPositive() {
+		return nil, domain.ErrInvalidAmount
+	}
+
+	if req.DestinationWalletID != "" {
+		return nil, domain.ErrUnexpectedDestination
+	}
+
+	wallet, err := s.walletRepository.GetByID(req.WalletID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.Amount.FitsCurrency(wallet.Currency.String()) {
+		return nil, shareddomain.ErrAmountPrecision
+	}
+
+	return wallet, nil
+}
+
+// validateCategory checks the category of an expense or income, which may
+// have been filled in by the categorization rules.
+func (s *TransactionService) validateCategory(userID string, req commands.TransactionRequest) error {
+	if req.CategoryID == "" {
+		return domain.ErrCategoryRequired
+	}
+
+	category, err := s.categoryRepository.GetByID(req.CategoryID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !domain.TransactionType(req.Type).AcceptsCategory(category.Type) {
+		return domain.ErrCategoryTypeMismatch
+	}
+
+	return nil
+}
+
+func toTransactionResponse(transaction *domain.Transaction) *queries.TransactionResponse {
+	return &queries.TransactionResponse{
+		ID:                  transaction.ID,
+		WalletID:            transaction.WalletID,
+		CategoryID:          transaction.CategoryID,
+		Type:                transaction.Type.Value(),
+		TypeName:            transaction.Type.String(),
+		Amount:              transaction.Amount,
+		Description:         transaction.Description,
+		Payee:               transaction.Payee,
+		Tags:                transaction.Tags,
+		Date:                transaction.Date,
+		TransferID:          transaction.TransferID,
+		CounterpartWalletID: transaction.CounterpartWalletID,
+		ExchangeRate:        transaction.ExchangeRate,
+		Inbound:             transaction.Inbound,
+		RecurringRuleID:     transaction.RecurringRuleID,
+		InstallmentPlanID:   transaction.InstallmentPlanID,
+		InstallmentNumber:   transaction.InstallmentNumber,
+		InstallmentCount:    transaction.InstallmentCount,
+		LoanID:              transaction.LoanID,
+		LoanInstallment:     transaction.LoanInstallment,
+		ImportBatchID:       transaction.ImportBatchID,
+		ExternalID:          transaction.ExternalID,
+		ValueDate:           transaction.ValueDate,
+		ReconciliationID:    transaction.ReconciliationID,
+		Reconciled:          transaction.Reconciled,
+		CreatedAt:           transaction.CreatedAt,
+		UpdatedAt:           transaction.ModifiedAt,
+		CreatedBy:           transaction.CreatedBy,
+		UpdatedBy:           transaction.ModifiedBy,
+	}
+}
@@ -0,0 +1,756 @@
+package services
+
+import (
+	"context"
+	"errors"
+	categorydomain "fin-flow-api/internal/modules/categories/domain"
+	"fin-flow-api/internal/modules/transactions/application/contracts/commands"
+	"fin-flow-api/internal/modules/transactions/application/contracts/queries"
+	"fin-flow-api/internal/modules/transactions/domain"
+	"fin-flow-api/internal/modules/transactions/domain/transactiontest"
+	walletdomain "fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/middleware"
+	"strings"
+	"testing"
+	"time"
+)
+
+type mockWalletRepository struct {
+	wallets map[string]*walletdomain.Wallet
+}
+
+func newMockWalletRepository(wallets ...*walletdomain.Wallet) *mockWalletRepository {
+	repo := &mockWalletRepository{wallets: make(map[string]*walletdomain.Wallet)}
+	for _, wallet := range wallets {
+		repo.wallets[wallet.ID] = wallet
+	}
+	return repo
+}
+
+func (m *mockWalletRepository) Create(wallet *walletdomain.Wallet) error {
+	m.wallets[wallet.ID] = wallet
+	return nil
+}
+
+func (m *mockWalletRepository) GetByID(id string, userID string) (*walletdomain.Wallet, error) {
+	wallet, exists := m.wallets[id]
+	if !exists {
+		return nil, errors.New("wallet not found")
+	}
+	if wallet.UserID != userID {
+		return nil, errors.New("unauthorized access to wallet")
+	}
+	return wallet, nil
+}
+
+func (m *mockWalletRepository) List(userID string) ([]*walletdomain.Wallet, error) {
+	return nil, nil
+}
+
+func (m *mockWalletRepository) Update(wallet *walletdomain.Wallet) error {
+	return nil
+}
+
+func (m *mockWalletRepository) Delete(id string, userID string) error {
+	return nil
+}
+
+type mockCategoryRepository struct {
+	categories map[string]*categorydomain.Category
+}
+
+func newMockCategoryRepository(categories ...*categorydomain.Category) *mockCategoryRepository {
+	repo := &mockCategoryRepository{categories: make(map[string]*categorydomain.Category)}
+	for _, category := range categories {
+		repo.categories[category.ID] = category
+	}
+	return repo
+}
+
+func (m *mockCategoryRepository) Create(category *categorydomain.Category) error {
+	m.categories[category.ID] = category
+	return nil
+}
+
+func (m *mockCategoryRepository) CreateAll(categories []*categorydomain.Category) error {
+	for _, category := range categories {
+		m.categories[category.ID] = category
+	}
+	return nil
+}
+
+func (m *mockCategoryRepository) GetByID(id string, userID string) (*categorydomain.Category, error) {
+	category, exists := m.categories[id]
+	if !exists {
+		return nil, errors.New("category not found")
+	}
+	if category.UserID != userID {
+		return nil, errors.New("unauthorized access to category")
+	}
+	return category, nil
+}
+
+func (m *mockCategoryRepository) List(userID string) ([]*categorydomain.Category, error) {
+	return nil, nil
+}
+
+func (m *mockCategoryRepository) Update(category *categorydomain.Category) error {
+	return nil
+}
+
+func (m *mockCategoryRepository) Delete(id string, userID string, strategy categorydomain.DeleteStrategy) error {
+	return nil
+}
+
+func (m *mockCategoryRepository) Merge(targetID string, sourceIDs []string, userID string) error {
+	return nil
+}
+
+type mockContext struct {
+	context.Context
+	userID string
+	hasID  bool
+}
+
+func (m *mockContext) Value(key interface{}) interface{} {
+	if key == middleware.UserIDKey {
+		if m.hasID {
+			return m.userID
+		}
+		return nil
+	}
+	return nil
+}
+
+func newTestService() (*TransactionService, *transactiontest.Repository) {
+	repo := transactiontest.NewRepository()
+	wallets := newMockWalletRepository(
+		walletdomain.NewWallet("wallet-usd", "user1", "Checking", walletdomain.WalletTypeBank, shareddomain.Amount{}, walletdomain.CurrencyUSD, "system"),
+		walletdomain.NewWallet("wallet-usd-2", "user1", "Cash", walletdomain.WalletTypeCash, shareddomain.Amount{}, walletdomain.CurrencyUSD, "system"),
+		walletdomain.NewWallet("wallet-ars", "user1", "Pesos", walletdomain.WalletTypeBank, shareddomain.Amount{}, walletdomain.CurrencyARS, "system"),
+		walletdomain.NewWallet("wallet-other", "user2", "Other", walletdomain.WalletTypeBank, shareddomain.Amount{}, walletdomain.CurrencyUSD, "system"),
+		walletdomain.NewWallet("wallet-card", "user1", "Visa", walletdomain.WalletTypeCreditCard, shareddomain.Amount{}, walletdomain.CurrencyUSD, "system"),
+	)
+	categories := newMockCategoryRepository(
+		categorydomain.NewCategory("cat-food", "user1", "Food", categorydomain.CategoryTypeExpense, "system"),
+		categorydomain.NewCategory("cat-salary", "user1", "Salary", categorydomain.CategoryTypeIncome, "system"),
+	)
+	return NewTransactionService(repo, wallets, categories, nil, nil, "system"), repo
+}
+
+type mockCategorizer struct {
+	categoryID string
+	payee      string
+	tags       []string
+	err        error
+
+	calls        int
+	lastCurrency string
+}
+
+func (m *mockCategorizer) Categorize(transaction *domain.Transaction, currency string) error {
+	m.calls++
+	m.lastCurrency = currency
+	if transaction.CategoryID == "" {
+		transaction.CategoryID = m.categoryID
+	}
+	transaction.Payee = m.payee
+	transaction.AddTags(m.tags...)
+	return m.err
+}
+
+type mockDuplicateFinder struct {
+	ids []string
+	err error
+}
+
+func (m *mockDuplicateFinder) FindDuplicates(transaction *domain.Transaction) ([]string, error) {
+	return m.ids, m.err
+}
+
+func TestNewTransactionService(t *testing.T) {
+	service, repo := newTestService()
+
+	if service == nil {
+		t.Fatal("NewTransactionService returned nil")
+	}
+	if service.repository != repo {
+		t.Error("repository not set correctly")
+	}
+	if service.systemUser != "system" {
+		t.Error("systemUser not set correctly")
+	}
+}
+
+func TestTransactionService_Create(t *testing.T) {
+	service, repo := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	req := commands.TransactionRequest{
+		WalletID:    "wallet-usd",
+		CategoryID:  "cat-food",
+		Type:        int(domain.TransactionTypeExpense),
+		Amount:      shareddomain.MustParseAmount("42.50"),
+		Description: "Groceries",
+		Date:        time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC),
+	}
+
+	if _, err := service.Create(ctx, req); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if len(repo.Transactions) != 1 {
+		t.Errorf("expected 1 transaction, got %d", len(repo.Transactions))
+	}
+}
+
+func TestTransactionService_Create_Categorized(t *testing.T) {
+	service, repo := newTestService()
+	service.categorizer = &mockCategorizer{categoryID: "cat-food", payee: "Corner Market", tags: []string{"groceries"}}
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	req := commands.TransactionRequest{
+		WalletID:    "wallet-usd",
+		Type:        int(domain.TransactionTypeExpense),
+		Amount:      shareddomain.MustParseAmount("42.50"),
+		Description: "CORNER MKT 0042",
+		Tags:        []string{"weekly"},
+		Date:        time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC),
+	}
+
+	if _, err := service.Create(ctx, req); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	for _, transaction := range repo.Transactions {
+		if transaction.CategoryID != "cat-food" || transaction.Payee != "Corner Market" {
+			t.Errorf("expected the rules to set the category and payee, got %q and %q", transaction.CategoryID, transaction.Payee)
+		}
+		if strings.Join(transaction.Tags, ",") != "weekly,groceries" {
+			t.Errorf("expected the rule tags to be added, got %v", transaction.Tags)
+		}
+	}
+
+	service.categorizer = &mockCategorizer{err: errors.New("failed to list categorization rules")}
+	if _, err := service.Create(ctx, req); err == nil {
+		t.Error("expected the categorizer error")
+	}
+}
+
+func TestTransactionService_Create_CategorizesValidRequests(t *testing.T) {
+	service, _ := newTestService()
+	categorizer := &mockCategorizer{categoryID: "cat-food"}
+	service.categorizer = categorizer
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	req := commands.TransactionRequest{
+		WalletID: "wallet-ars",
+		Type:     int(domain.TransactionTypeExpense),
+		Amount:   shareddomain.MustParseAmount("1500"),
+		Date:     time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC),
+	}
+	if _, err := service.Create(ctx, req); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if categorizer.lastCurrency != "ARS" {
+		t.Errorf("expected the rules to see the wallet currency, got %q", categorizer.lastCurrency)
+	}
+
+	invalid := []commands.TransactionRequest{
+		{WalletID: "wallet-usd", Type: int(domain.TransactionTypeExpense), Amount: shareddomain.MustParseAmount("-1")},
+		{WalletID: "wallet-missing", Type: int(domain.TransactionTypeExpense), Amount: shareddomain.MustParseAmount("1")},
+		{WalletID: "wallet-other", Type: int(domain.TransactionTypeExpense), Amount: shareddomain.MustParseAmount("1")},
+	}
+	for _, req := range invalid {
+		categorizer.calls = 0
+		if _, err := service.Create(ctx, req); err == nil {
+			t.Fatalf("expected %+v to be rejected", req)
+		}
+		if categorizer.calls != 0 {
+			t.Errorf("expected %+v to be rejected before the rules run", req)
+		}
+	}
+}
+
+func TestTransactionService_Create_PossibleDuplicates(t *testing.T) {
+	service, repo := newTestService()
+	service.duplicateFinder = &mockDuplicateFinder{ids: []string{"imported-1"}}
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	req := commands.TransactionRequest{
+		WalletID:    "wallet-usd",
+		CategoryID:  "cat-food",
+		Type:        int(domain.TransactionTypeExpense),
+		Amount:      shareddomain.MustParseAmount("42.50"),
+		Description: "Groceries",
+		Date:        time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC),
+	}
+
+	created, err := service.Create(ctx, req)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if repo.Transactions[created.ID] == nil {
+		t.Error("expected the transaction to be created even though it may be a duplicate")
+	}
+	if len(created.PossibleDuplicateIDs) != 1 || created.PossibleDuplicateIDs[0] != "imported-1" {
+		t.Errorf("expected the possible duplicate, got %v", created.PossibleDuplicateIDs)
+	}
+
+	service.duplicateFinder = &mockDuplicateFinder{err: errors.New("failed to list transactions")}
+	if _, err := service.Create(ctx, req); err == nil {
+		t.Error("expected the duplicate finder error")
+	}
+	if len(repo.Transactions) != 1 {
+		t.Errorf("expected nothing else to be created, got %d transactions", len(repo.Transactions))
+	}
+}
+
+func TestTransactionService_Create_Transfer(t *testing.T) {
+	service, repo := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	req := commands.TransactionRequest{
+		WalletID:            "wallet-usd",
+		DestinationWalletID: "wallet-usd-2",
+		Type:                int(domain.TransactionTypeTransfer),
+		Amount:              shareddomain.MustParseAmount("100"),
+		Date:                time.Now(),
+	}
+
+	created, err := service.Create(ctx, req)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if len(repo.Transactions) != 2 {
+		t.Fatalf("expected 2 transfer legs, got %d", len(repo.Transactions))
+	}
+
+	if created.ID != "" || created.TransferID == "" {
+		t.Errorf("expected only the transfer id, got %+v", created)
+	}
+	for _, leg := range repo.Transactions {
+		if leg.TransferID != created.TransferID {
+			t.Errorf("expected leg of transfer %s, got %s", created.TransferID, leg.TransferID)
+		}
+		if leg.Amount.String() != "100" || leg.ExchangeRate.String() != "1" {
+			t.Errorf("expected amount 100 at rate 1, got %s at %s", leg.Amount, leg.ExchangeRate)
+		}
+		if leg.Inbound && leg.WalletID != "wallet-usd-2" {
+			t.Errorf("expected incoming leg on wallet-usd-2, got %s", leg.WalletID)
+		}
+	}
+}
+
+func TestTransactionService_Transfer_CrossCurrency(t *testing.T) {
+	service, repo := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	req := commands.TransferRequest{
+		FromWalletID: "wallet-usd",
+		ToWalletID:   "wallet-ars",
+		Amount:       shareddomain.MustParseAmount("100"),
+		ExchangeRate: shareddomain.MustParseAmount("1200"),
+		Description:  "Dollars to pesos",
+		Date:         time.Now(),
+	}
+
+	if err := service.Transfer(ctx, req); err != nil {
+		t.Fatalf("Transfer failed: %v", err)
+	}
+
+	if len(repo.Transactions) != 2 {
+		t.Fatalf("expected 2 transfer legs, got %d", len(repo.Transactions))
+	}
+
+	for _, leg := range repo.Transactions {
+		if leg.ExchangeRate.String() != "1200" {
+			t.Errorf("expected exchange rate 1200, got %s", leg.ExchangeRate)
+		}
+		if leg.Inbound {
+			if leg.WalletID != "wallet-ars" || leg.Amount.String() != "120000" {
+				t.Errorf("unexpected incoming leg: wallet %s amount %s", leg.WalletID, leg.Amount)
+			}
+		} else if leg.WalletID != "wallet-usd" || leg.Amount.String() != "100" {
+			t.Errorf("unexpected outgoing leg: wallet %s amount %s", leg.WalletID, leg.Amount)
+		}
+	}
+}
+
+func TestTransactionService_Transfer_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     commands.TransferRequest
+		wantErr string
+	}{
+		{"missing destination", commands.TransferRequest{FromWalletID: "wallet-usd", Amount: shareddomain.MustParseAmount("10")}, "destination wallet is required"},
+		{"same wallet", commands.TransferRequest{FromWalletID: "wallet-usd", ToWalletID: "wallet-usd", Amount: shareddomain.MustParseAmount("10")}, "must be different"},
+		{"foreign destination", commands.TransferRequest{FromWalletID: "wallet-usd", ToWalletID: "wallet-other", Amount: shareddomain.MustParseAmount("10")}, "unauthorized access to wallet"},
+		{"cross currency without rate", commands.TransferRequest{FromWalletID: "wallet-usd", ToWalletID: "wallet-ars", Amount: shareddomain.MustParseAmount("10")}, "exchange rate or destination amount is required"},
+		{"same currency with rate", commands.TransferRequest{FromWalletID: "wallet-usd", ToWalletID: "wallet-usd-2", Amount: shareddomain.MustParseAmount("10"), ExchangeRate: shareddomain.MustParseAmount("2")}, "exchange rate must be 1"},
+		{"zero amount", commands.TransferRequest{FromWalletID: "wallet-usd", ToWalletID: "wallet-usd-2"}, "invalid amount"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo := newTestService()
+			ctx := &mockContext{userID: "user1", hasID: true}
+
+			err := service.Transfer(ctx, tt.req)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %q", tt.wantErr, err.Error())
+			}
+			if len(repo.Transactions) != 0 {
+				t.Error("no transaction should have been created")
+			}
+		})
+	}
+}
+
+func TestTransactionService_Update_TransferNotEditable(t *testing.T) {
+	service, repo := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	out, in := domain.NewTransfer("tx-out", "tx-in", "transfer-1", "user1", "wallet-usd", "wallet-usd-2", "", shareddomain.MustParseAmount("10"), shareddomain.MustParseAmount("10"), shareddomain.MustParseAmount("1"), "", time.Now(), "system")
+	repo.Transactions[out.ID] = out
+	repo.Transactions[in.ID] = in
+
+	req := commands.TransactionRequest{WalletID: "wallet-usd", CategoryID: "cat-food", Type: 0, Amount: shareddomain.MustParseAmount("10")}
+	if err := service.Update(ctx, "tx-out", req); err != domain.ErrTransferNotEditable {
+		t.Errorf("expected ErrTransferNotEditable, got %v", err)
+	}
+}
+
+func TestTransactionService_Update_LoanPaymentNotEditable(t *testing.T) {
+	service, repo := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	payment := domain.NewTransaction("tx-loan", "user1", "wallet-usd", "cat-food", domain.TransactionTypeExpense, shareddomain.MustParseAmount("10"), "Car loan 1/12", time.Now(), "system")
+	payment.LoanID = "loan-1"
+	payment.LoanInstallment = 1
+	repo.Transactions[payment.ID] = payment
+
+	req := commands.TransactionRequest{WalletID: "wallet-usd", CategoryID: "cat-food", Type: 0, Amount: shareddomain.MustParseAmount("20")}
+	if err := service.Update(ctx, "tx-loan", req); err != domain.ErrLoanPaymentNotEditable {
+		t.Errorf("expected ErrLoanPaymentNotEditable, got %v", err)
+	}
+}
+
+func TestTransactionService_Create_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     commands.TransactionRequest
+		wantErr string
+	}{
+		{"invalid type", commands.TransactionRequest{WalletID: "wallet-usd", CategoryID: "cat-food", Type: 99, Amount: shareddomain.MustParseAmount("10")}, "invalid transaction type"},
+		{"zero amount", commands.TransactionRequest{WalletID: "wallet-usd", CategoryID: "cat-food", Type: 0}, "invalid amount"},
+		{"amount too precise", commands.TransactionRequest{WalletID: "wallet-usd", CategoryID: "cat-food", Type: 0, Amount: shareddomain.MustParseAmount("10.001")}, "decimal places"},
+		{"missing wallet", commands.TransactionRequest{WalletID: "missing", CategoryID: "cat-food", Type: 0, Amount: shareddomain.MustParseAmount("10")}, "wallet not found"},
+		{"foreign wallet", commands.TransactionRequest{WalletID: "wallet-other", CategoryID: "cat-food", Type: 0, Amount: shareddomain.MustParseAmount("10")}, "unauthorized access to wallet"},
+		{"missing category", commands.TransactionRequest{WalletID: "wallet-usd", Type: 0, Amount: shareddomain.MustParseAmount("10")}, "category is required"},
+		{"category type mismatch", commands.TransactionRequest{WalletID: "wallet-usd", CategoryID: "cat-salary", Type: 0, Amount: shareddomain.MustParseAmount("10")}, "category type does not match"},
+		{"destination on expense", commands.TransactionRequest{WalletID: "wallet-usd", DestinationWalletID: "wallet-usd-2", CategoryID: "cat-food", Type: 0, Amount: shareddomain.MustParseAmount("10")}, "only allowed for transfers"},
+		{"transfer without destination", commands.TransactionRequest{WalletID: "wallet-usd", Type: 2, Amount: shareddomain.MustParseAmount("10")}, "destination wallet is required"},
+		{"transfer to same wallet", commands.TransactionRequest{WalletID: "wallet-usd", DestinationWalletID: "wallet-usd", Type: 2, Amount: shareddomain.MustParseAmount("10")}, "must be different"},
+		{"transfer across currencies without rate", commands.TransactionRequest{WalletID: "wallet-usd", DestinationWalletID: "wallet-ars", Type: 2, Amount: shareddomain.MustParseAmount("10")}, "exchange rate or destination amount is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo := newTestService()
+			ctx := &mockContext{userID: "user1", hasID: true}
+
+			_, err := service.Create(ctx, tt.req)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %q", tt.wantErr, err.Error())
+			}
+			if len(repo.Transactions) != 0 {
+				t.Error("no transaction should have been created")
+			}
+		})
+	}
+}
+
+func TestTransactionService_Create_Unauthenticated(t *testing.T) {
+	service, _ := newTestService()
+	ctx := &mockContext{hasID: false}
+
+	_, err := service.Create(ctx, commands.TransactionRequest{})
+	if err == nil || err.Error() != "user not authenticated" {
+		t.Errorf("expected user not authenticated error, got %v", err)
+	}
+}
+
+func TestTransactionService_Update(t *testing.T) {
+	service, repo := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	repo.Transactions["tx-1"] = domain.NewTransaction("tx-1", "user1", "wallet-usd", "cat-food", domain.TransactionTypeExpense, shareddomain.MustParseAmount("10"), "Lunch", time.Now(), "system")
+
+	req := commands.TransactionRequest{
+		WalletID:    "wallet-usd",
+		CategoryID:  "cat-salary",
+		Type:        int(domain.TransactionTypeIncome),
+		Amount:      shareddomain.MustParseAmount("2500"),
+		Description: "Salary",
+		Date:        time.Now(),
+	}
+
+	if err := service.Update(ctx, "tx-1", req); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	updated := repo.Transactions["tx-1"]
+	if updated.Type != domain.TransactionTypeIncome {
+		t.Errorf("expected type Income, got %v", updated.Type)
+	}
+	if updated.Amount.String() != "2500" {
+		t.Errorf("expected amount 2500, got %s", updated.Amount)
+	}
+}
+
+func TestTransactionService_Update_Reconciled(t *testing.T) {
+	service, repo := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	date := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+	reconciled := domain.NewTransaction("tx-1", "user1", "wallet-usd", "cat-food", domain.TransactionTypeExpense, shareddomain.MustParseAmount("10"), "Lunch", date, "system")
+	reconciled.ReconciliationID, reconciled.Reconciled = "rec-1", true
+	repo.Transactions[reconciled.ID] = reconciled
+
+	req := commands.TransactionRequest{WalletID: "wallet-usd", CategoryID: "cat-food", Type: 0, Amount: shareddomain.MustParseAmount("12"), Description: "Lunch", Date: date}
+	if err := service.Update(ctx, "tx-1", req); err != domain.ErrReconciledNotEditable {
+		t.Fatalf("expected ErrReconciledNotEditable, got %v", err)
+	}
+
+	req.Amount = shareddomain.MustParseAmount("10")
+	req.Description = "Lunch with the team"
+	if err := service.Update(ctx, "tx-1", req); err != nil {
+		t.Fatalf("expected the description of a reconciled transaction to be editable, got %v", err)
+	}
+	if repo.Transactions["tx-1"].Description != "Lunch with the team" {
+		t.Errorf("expected the new description, got %q", repo.Transactions["tx-1"].Description)
+	}
+}
+
+func TestTransactionService_Update_NotFound(t *testing.T) {
+	service, _ := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	err := service.Update(ctx, "missing", commands.TransactionRequest{})
+	if err == nil || err.Error() != "transaction not found" {
+		t.Errorf("expected transaction not found error, got %v", err)
+	}
+}
+
+func TestTransactionService_Delete(t *testing.T) {
+	service, repo := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	repo.Transactions["tx-1"] = domain.NewTransaction("tx-1", "user1", "wallet-usd", "cat-food", domain.TransactionTypeExpense, shareddomain.MustParseAmount("10"), "", time.Now(), "system")
+
+	if err := service.Delete(ctx, "tx-1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if len(repo.Transactions) != 0 {
+		t.Error("transaction was not deleted")
+	}
+}
+
+func TestTransactionService_GetByID(t *testing.T) {
+	service, repo := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	repo.Transactions["tx-1"] = domain.NewTransaction("tx-1", "user1", "wallet-usd", "cat-food", domain.TransactionTypeExpense, shareddomain.MustParseAmount("10"), "Lunch", time.Now(), "system")
+
+	response, err := service.GetByID(ctx, "tx-1")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if response.TypeName != "Expense" {
+		t.Errorf("expected type name Expense, got %s", response.TypeName)
+	}
+	if response.Description != "Lunch" {
+		t.Errorf("expected description Lunch, got %s", response.Description)
+	}
+}
+
+func TestTransactionService_List(t *testing.T) {
+	service, repo := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	repo.Transactions["tx-1"] = domain.NewTransaction("tx-1", "user1", "wallet-usd", "cat-food", domain.TransactionTypeExpense, shareddomain.MustParseAmount("10"), "", time.Now(), "system")
+	repo.Transactions["tx-2"] = domain.NewTransaction("tx-2", "user2", "wallet-other", "", domain.TransactionTypeExpense, shareddomain.MustParseAmount("10"), "", time.Now(), "system")
+
+	typeValue := int(domain.TransactionTypeExpense)
+	responses, err := service.List(ctx, queries.ListTransactionsRequest{WalletID: "wallet-usd", Type: &typeValue})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(responses) != 1 {
+		t.Errorf("expected 1 transaction, got %d", len(responses))
+	}
+	if repo.LastFilter().WalletID != "wallet-usd" {
+		t.Errorf("expected wallet filter to be forwarded, got %q", repo.LastFilter().WalletID)
+	}
+	if repo.LastFilter().Type == nil || *repo.LastFilter().Type != domain.TransactionTypeExpense {
+		t.Error("expected type filter to be forwarded")
+	}
+}
+
+func TestTransactionService_List_InvalidType(t *testing.T) {
+	service, _ := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	typeValue := 42
+	_, err := service.List(ctx, queries.ListTransactionsRequest{Type: &typeValue})
+	if !errors.Is(err, domain.ErrInvalidTransactionType) {
+		t.Errorf("expected ErrInvalidTransactionType, got %v", err)
+	}
+}
+
+func TestTransactionService_Recategorize(t *testing.T) {
+	service, repo := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	repo.Transactions["tx-1"] = domain.NewTransaction("tx-1", "user1", "wallet-usd", "cat-super", domain.TransactionTypeExpense, shareddomain.MustParseAmount("10"), "", time.Now(), "system")
+	repo.Transactions["tx-2"] = domain.NewTransaction("tx-2", "user1", "wallet-usd", "cat-food", domain.TransactionTypeExpense, shareddomain.MustParseAmount("10"), "", time.Now(), "system")
+	repo.Transactions["tx-3"] = domain.NewTransaction("tx-3", "user1", "wallet-usd", "cat-super", domain.TransactionTypeExpense, shareddomain.MustParseAmount("10"), "", time.Now(), "system")
+
+	updated, err := service.Recategorize(ctx, commands.RecategorizeRequest{
+		CategoryID:     "cat-food",
+		Filter:         queries.ListTransactionsRequest{CategoryID: "cat-super"},
+		TransactionIDs: []string{"tx-1", "tx-2"},
+	})
+	if err != nil {
+		t.Fatalf("Recategorize failed: %v", err)
+	}
+
+	if updated != 1 || len(repo.Recategorized) != 1 || repo.Recategorized[0] != "tx-1" {
+		t.Errorf("expected only tx-1 to move, got %d: %v", updated, repo.Recategorized)
+	}
+	if repo.LastFilter().CategoryID != "cat-super" {
+		t.Errorf("expected category filter to be forwarded, got %q", repo.LastFilter().CategoryID)
+	}
+	if repo.Transactions["tx-3"].CategoryID != "cat-super" {
+		t.Error("expected transactions outside transaction_ids to keep their category")
+	}
+}
+
+func TestTransactionService_Recategorize_Errors(t *testing.T) {
+	invalidType := 42
+
+	tests := []struct {
+		name    string
+		req     commands.RecategorizeRequest
+		wantErr string
+	}{
+		{"no category", commands.RecategorizeRequest{Filter: queries.ListTransactionsRequest{WalletID: "wallet-usd"}}, domain.ErrCategoryRequired.Error()},
+		{"no filter", commands.RecategorizeRequest{CategoryID: "cat-food"}, domain.ErrRecategorizeUnfiltered.Error()},
+		{"invalid type", commands.RecategorizeRequest{CategoryID: "cat-food", Filter: queries.ListTransactionsRequest{Type: &invalidType}}, domain.ErrInvalidTransactionType.Error()},
+		{"category not found", commands.RecategorizeRequest{CategoryID: "cat-missing", Filter: queries.ListTransactionsRequest{WalletID: "wallet-usd"}}, "category not found"},
+		{"type mismatch", commands.RecategorizeRequest{CategoryID: "cat-salary", Filter: queries.ListTransactionsRequest{WalletID: "wallet-usd"}}, domain.ErrCategoryTypeMismatch.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo := newTestService()
+			ctx := &mockContext{userID: "user1", hasID: true}
+			repo.Transactions["tx-1"] = domain.NewTransaction("tx-1", "user1", "wallet-usd", "cat-food", domain.TransactionTypeExpense, shareddomain.MustParseAmount("10"), "", time.Now(), "system")
+
+			_, err := service.Recategorize(ctx, tt.req)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("expected %q, got %v", tt.wantErr, err)
+			}
+			if repo.Recategorized != nil {
+				t.Error("expected no transaction to move")
+			}
+		})
+	}
+}
+
+func TestTransactionService_Create_Installments(t *testing.T) {
+	service, repo := newTestService()
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	req := commands.TransactionRequest{
+		WalletID:     "wallet-card",
+		CategoryID:   "cat-food",
+		Type:         int(domain.TransactionTypeExpense),
+		Amount:       shareddomain.MustParseAmount("100"),
+		Description:  "Fridge",
+		Date:         time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC),
+		Installments: 3,
+	}
+
+	created, err := service.Create(ctx, req)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if len(repo.Transactions) != 3 {
+		t.Fatalf("expected 3 installments, got %d", len(repo.Transactions))
+	}
+
+	planID := created.InstallmentPlanID
+	if planID == "" || created.ID != "" {
+		t.Errorf("expected only the installment plan id, got %+v", created)
+	}
+	total := shareddomain.Amount{}
+	for _, installment := range repo.Transactions {
+		if installment.InstallmentPlanID != planID || installment.InstallmentCount != 3 {
+			t.Errorf("installment %d does not belong to the plan", installment.InstallmentNumber)
+		}
+		total = total.Add(installment.Amount)
+	}
+	if !total.Equal(req.Amount) {
+		t.Errorf("expected installments to add up to 100, got %s", total)
+	}
+
+	var firstID string
+	for id, installment := range repo.Transactions {
+		if installment.InstallmentNumber == 1 {
+			firstID = id
+		}
+	}
+	err = service.Update(ctx, firstID, commands.TransactionRequest{WalletID: "wallet-card", CategoryID: "cat-food", Type: 0, Amount: shareddomain.MustParseAmount("10")})
+	if err != domain.ErrInstallmentNotEditable {
+		t.Errorf("expected ErrInstallmentNotEditable, got %v", err)
+	}
+}
+
+func TestTransactionService_Create_InstallmentErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     commands.TransactionRequest
+		wantErr error
+	}{
+		{"bank wallet", commands.TransactionRequest{WalletID: "wallet-usd", CategoryID: "cat-food", Type: 0, Amount: shareddomain.MustParseAmount("100"), Installments: 3}, domain.ErrInstallmentsRequireCreditCard},
+		{"income", commands.TransactionRequest{WalletID: "wallet-card", CategoryID: "cat-salary", Type: 1, Amount: shareddomain.MustParseAmount("100"), Installments: 3}, domain.ErrInstallmentsRequireCreditCard},
+		{"transfer", commands.TransactionRequest{WalletID: "wallet-card", DestinationWalletID: "wallet-usd", Type: 2, Amount: shareddomain.MustParseAmount("100"), Installments: 3}, domain.ErrInstallmentsRequireCreditCard},
+		{"too many", commands.TransactionRequest{WalletID: "wallet-card", CategoryID: "cat-food", Type: 0, Amount: shareddomain.MustParseAmount("100"), Installments: 61}, domain.ErrInvalidInstallments},
+		{"negative", commands.TransactionRequest{WalletID: "wallet-card", CategoryID: "cat-food", Type: 0, Amount: shareddomain.MustParseAmount("100"), Installments: -2}, domain.ErrInvalidInstallments},
+		{"smaller than a cent each", commands.TransactionRequest{WalletID: "wallet-card", CategoryID: "cat-food", Type: 0, Amount: shareddomain.MustParseAmount("0.05"), Installments: 12}, domain.ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo := newTestService()
+			ctx := &mockContext{userID: "user1", hasID: true}
+
+			if _, err := service.Create(ctx, tt.req); err != tt.wantErr {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if len(repo.Transactions) != 0 {
+				t.Errorf("expected nothing to be stored, got %d transactions", len(repo.Transactions))
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+const (
+	// MinInstallments and MaxInstallments bound how many installments a
+	// purchase can be split into.
+	MinInstallments = 2
+	MaxInstallments = 60
+)
+
+var (
+	ErrInvalidInstallments           = errors.New("installments must be between 2 and 60")
+	ErrInstallmentsRequireCreditCard = errors.New("installments are only allowed for expenses on credit card wallets")
+	ErrInstallmentNotEditable        = errors.New("installments cannot be edited, delete the purchase and recreate it instead")
+)
+
+// NewInstallments splits a credit card purchase into count expenses that share
+// planID. Installment k is dated k-1 months after the purchase, clamped to the
+// last day of shorter months, so each one lands on a later statement. The
+// amount is divided at the scale of the wallet currency and any remainder is
+// charged on the first installment.
+func NewInstallments(ids []string, planID, userID, walletID, categoryID string, amount domain.Amount, currency string, count int, description string, date time.Time, createdBy string) ([]*Transaction, error) {
+	if count < MinInstallments || count > MaxInstallments || len(ids) != count {
+		return nil, ErrInvalidInstallments
+	}
+	if !amount.IsPositive() {
+		return nil, ErrInvalidAmount
+	}
+
+	scale := domain.CurrencyScale(currency)
+	share := amount.DivRound(domain.NewAmountFromInt(int64(count)), scale)
+	if share.Mul(domain.NewAmountFromInt(int64(count))).Cmp(amount) > 0 {
+		share = share.Sub(smallestUnit(scale))
+	}
+	if !share.IsPositive() {
+		return nil, ErrInvalidAmount
+	}
+	first := amount.Sub(share.Mul(domain.NewAmountFromInt(int64(count - 1))))
+
+	installments := make([]*Transaction, count)
+	for i := 0; i < count; i++ {
+		installmentAmount := share
+		if i == 0 {
+			installmentAmount = first
+		}
+
+		installment := NewTransaction(ids[i], userID, walletID, categoryID, TransactionTypeExpense, installmentAmount, description, addMonthsClamped(date, i), createdBy)
+		installment.InstallmentPlanID = planID
+		installment.InstallmentNumber = i + 1
+		installment.InstallmentCount = count
+		installments[i] = installment
+	}
+
+	return installments, nil
+}
+
+// IsInstallment reports whether the transaction is one installment of a
+// purchase split with NewInstallments.
+func (t *Transaction) IsInstallment() bool {
+	return t.InstallmentPlanID != ""
+}
+
+func addMonthsClamped(date time.Time, months int) time.Time {
+	firstOfMonth := time.Date(date.Year(), date.Month()+time.Month(months), 1, date.Hour(), date.Minute(), date.Second(), date.Nanosecond(), date.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	day := date.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
+
+// smallestUnit returns 10^-scale, the smallest amount a currency with the
+// given scale can hold.
+func smallestUnit(scale int32) domain.Amount {
+	unit := domain.NewAmountFromInt(1)
+	ten := domain.NewAmountFromInt(10)
+	for i := int32(0); i < scale; i++ {
+		unit = unit.DivRound(ten, scale)
+	}
+	return unit
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+func TestNewInstallments(t *testing.T) {
+	ids := []string{"i1", "i2", "i3"}
+	purchase := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	installments, err := NewInstallments(ids, "plan", "user", "card", "cat", shareddomain.MustParseAmount("100"), "USD", 3, "Fridge", purchase, "system")
+	if err != nil {
+		t.Fatalf("NewInstallments failed: %v", err)
+	}
+
+	expected := []struct {
+		amount string
+		date   time.Time
+	}{
+		{"33.34", time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{"33.33", time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{"33.33", time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for i, installment := range installments {
+		if installment.ID != ids[i] || installment.InstallmentPlanID != "plan" {
+			t.Errorf("installment %d has wrong identifiers", i+1)
+		}
+		if installment.InstallmentNumber != i+1 || installment.InstallmentCount != 3 {
+			t.Errorf("installment %d numbered %d of %d", i+1, installment.InstallmentNumber, installment.InstallmentCount)
+		}
+		if installment.Type != TransactionTypeExpense {
+			t.Errorf("installment %d should be an expense", i+1)
+		}
+		if !installment.Amount.Equal(shareddomain.MustParseAmount(expected[i].amount)) {
+			t.Errorf("installment %d: expected %s, got %s", i+1, expected[i].amount, installment.Amount)
+		}
+		if !installment.Date.Equal(expected[i].date) {
+			t.Errorf("installment %d: expected %s, got %s", i+1, expected[i].date.Format("2006-01-02"), installment.Date.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestNewInstallments_WholeCurrency(t *testing.T) {
+	installments, err := NewInstallments([]string{"a", "b", "c", "d"}, "plan", "user", "card", "cat", shareddomain.MustParseAmount("1001"), "JPY", 4, "", time.Now(), "system")
+	if err != nil {
+		t.Fatalf("NewInstallments failed: %v", err)
+	}
+	if !installments[0].Amount.Equal(shareddomain.MustParseAmount("251")) {
+		t.Errorf("expected first installment 251, got %s", installments[0].Amount)
+	}
+	for _, installment := range installments[1:] {
+		if !installment.Amount.Equal(shareddomain.MustParseAmount("250")) {
+			t.Errorf("expected 250, got %s", installment.Amount)
+		}
+	}
+}
+
+func TestNewInstallments_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		ids     []string
+		amount  string
+		count   int
+		wantErr error
+	}{
+		{"single installment", []string{"a"}, "100", 1, ErrInvalidInstallments},
+		{"too many", make([]string, 61), "100", 61, ErrInvalidInstallments},
+		{"ids mismatch", []string{"a", "b"}, "100", 3, ErrInvalidInstallments},
+		{"zero amount", []string{"a", "b"}, "0", 2, ErrInvalidAmount},
+		{"below one cent each", []string{"a", "b", "c"}, "0.02", 3, ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewInstallments(tt.ids, "plan", "user", "card", "cat", shareddomain.MustParseAmount(tt.amount), "USD", tt.count, "", time.Now(), "system")
+			if err != tt.wantErr {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
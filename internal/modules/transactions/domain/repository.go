@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+type TransactionFilter struct {
+	WalletID   string
+	CategoryID string
+	Type       *TransactionType
+	From       *time.Time
+	To         *time.Time
+	LoanID     string
+}
+
+type TransactionRepository interface {
+	Create(transaction *Transaction) error
+	CreateTransfer(out *Transaction, in *Transaction) error
+	CreateInstallments(installments []*Transaction) error
+	// CreateAll stores every transaction or, if one of them fails, none.
+	CreateAll(transactions []*Transaction) error
+	GetByID(id string, userID string) (*Transaction, error)
+	List(userID string, filter TransactionFilter) ([]*Transaction, error)
+	// Update refuses to change the wallet, type, amount or date of a
+	// reconciled transaction, and Delete and MergeDuplicate to delete
+	// one.
+	Update(transaction *Transaction) error
+	Delete(id string, userID string) error
+	// FindExternalIDs returns which of externalIDs the wallet already has
+	// transactions for.
+	FindExternalIDs(walletID string, externalIDs []string, userID string) (map[string]bool, error)
+	// Recategorize moves the transactions with the given ids to categoryID
+	// and returns how many were updated. The other leg of a transfer moves
+	// along with it.
+	Recategorize(ids []string, userID string, categoryID string, modifiedBy string) (int, error)
+	// MergeDuplicate saves the description, payee, tags, bank id and value
+	// date of kept and deletes the transaction duplicateID of the same user,
+	// both or neither.
+	MergeDuplicate(kept *Transaction, duplicateID string) error
+}
@@ -0,0 +1,184 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+var (
+	ErrInvalidAmount           = errors.New("invalid amount")
+	ErrInvalidExchangeRate     = errors.New("invalid exchange rate")
+	ErrCategoryRequired        = errors.New("category is required")
+	ErrCategoryTypeMismatch    = errors.New("category type does not match transaction type")
+	ErrDestinationRequired     = errors.New("destination wallet is required")
+	ErrSameWallet              = errors.New("source and destination wallets must be different")
+	ErrUnexpectedDestination   = errors.New("destination wallet is only allowed for transfers")
+	ErrExchangeRateRequired    = errors.New("exchange rate or destination amount is required for transfers between currencies")
+	ErrTransferNotEditable     = errors.New("transfers cannot be edited, delete and recreate them instead")
+	ErrSameCurrencyRateNotOne  = errors.New("exchange rate must be 1 for transfers in the same currency")
+	ErrOccurrenceAlreadyPosted = errors.New("recurring occurrence has already been posted")
+	ErrLoanInstallmentPaid     = errors.New("loan installment has already been paid")
+	ErrLoanPaymentNotEditable  = errors.New("loan payments cannot be edited, delete and record them again instead")
+	ErrRecategorizeUnfiltered  = errors.New("a filter or transaction ids are required to recategorize transactions")
+	ErrAlreadyImported         = errors.New("transaction has already been imported into the wallet")
+	ErrReconciledNotEditable   = errors.New("the wallet, type, amount and date of a reconciled transaction cannot be edited")
+	ErrReconciledNotDeletable  = errors.New("reconciled transactions cannot be deleted")
+)
+
+// Transaction is a single entry in a wallet. Transfers are stored as two
+// legs, one per wallet, that share the same TransferID. Entries posted by a
+// recurring rule keep its ID in RecurringRuleID, credit card purchases
+// paid in installments are stored as one expense per installment, and loan
+// payments remember the loan and the installment they pay. Entries read from
+// a bank statement keep the import batch they came in with, so the whole
+// import can be undone, the id the bank gave them, if any, so they are not
+// imported twice, and their value date when the bank reports one. Payee is
+// the cleaned-up counterpart of an expense or income, which the raw
+// Description of imported entries rarely is. Entries the bank statement of
+// their wallet confirmed remember the reconciliation they cleared in.
+type Transaction struct {
+	domain.Entity
+
+	ID                  string
+	UserID              string
+	WalletID            string
+	CategoryID          string
+	Type                TransactionType
+	Amount              domain.Amount
+	Description         string
+	Payee               string
+	Tags                []string
+	Date                time.Time
+	TransferID          string
+	CounterpartWalletID string
+	ExchangeRate        domain.Amount
+	Inbound             bool
+	RecurringRuleID     string
+	// Installments of a credit card purchase share InstallmentPlanID and
+	// are numbered from 1 to InstallmentCount.
+	InstallmentPlanID string
+	InstallmentNumber int
+	InstallmentCount  int
+	LoanID            string
+	LoanInstallment   int
+	ImportBatchID     string
+	// ExternalID is the id the bank gave an imported entry, such as the
+	// FITID of an OFX file. It is unique within a wallet.
+	ExternalID string
+	// ValueDate is the day an imported entry started or stopped earning
+	// interest, when the bank reports it. Date is the day it was booked.
+	ValueDate *time.Time
+	// ReconciliationID is the reconciliation the entry cleared in, and
+	// Reconciled is set once that reconciliation is locked.
+	ReconciliationID string
+	Reconciled       bool
+}
+
+func NewTransaction(id, userID, walletID, categoryID string, transactionType TransactionType, amount domain.Amount, description string, date time.Time, createdBy string) *Transaction {
+	return &Transaction{
+		Entity:      domain.NewEntity(id, createdBy),
+		ID:          id,
+		UserID:      userID,
+		WalletID:    walletID,
+		CategoryID:  categoryID,
+		Type:        transactionType,
+		Amount:      amount,
+		Description: description,
+		Date:        date,
+	}
+}
+
+// NewTransfer builds both legs of a transfer. The outgoing leg debits amount
+// from the source wallet and the incoming leg credits destinationAmount to
+// the destination wallet; exchangeRate is the rate applied between them.
+func NewTransfer(outID, inID, transferID, userID, walletID, destinationWalletID, categoryID string, amount, destinationAmount, exchangeRate domain.Amount, description string, date time.Time, createdBy string) (*Transaction, *Transaction) {
+	out := NewTransaction(outID, userID, walletID, categoryID, TransactionTypeTransfer, amount, description, date, createdBy)
+	out.TransferID = transferID
+	out.CounterpartWalletID = destinationWalletID
+	out.ExchangeRate = exchangeRate
+
+	in := NewTransaction(inID, userID, destinationWalletID, categoryID, TransactionTypeTransfer, destinationAmount, description, date, createdBy)
+	in.TransferID = transferID
+	in.CounterpartWalletID = walletID
+	in.ExchangeRate = exchangeRate
+	in.Inbound = true
+
+	return out, in
+}
+
+// AddTags adds the tags the transaction does not have yet.
+func (t *Transaction) AddTags(tags ...string) {
+	t.Tags = NormalizeTags(append(t.Tags, tags...))
+}
+
+func (t *Transaction) IsTransfer() bool {
+	return t.Type == TransactionTypeTransfer
+}
+
+func (t *Transaction) IsLoanPayment() bool {
+	return t.LoanID != ""
+}
+
+// CheckEdit reports whether an edit can give the transaction the wallet,
+// type, amount and date given. Reconciled transactions keep what the bank
+// statement confirmed; their description, payee, tags and category can
+// still be corrected.
+func (t *Transaction) CheckEdit(walletID string, transactionType TransactionType, amount domain.Amount, date time.Time) error {
+	if !t.Reconciled {
+		return nil
+	}
+	if walletID != t.WalletID || transactionType != t.Type || !amount.Equal(t.Amount) || !sameDay(date, t.Date) {
+		return ErrReconciledNotEditable
+	}
+	return nil
+}
+
+// CheckDelete reports whether the transaction can be deleted, which
+// reconciled transactions cannot.
+func (t *Transaction) CheckDelete() error {
+	if t.Reconciled {
+		return ErrReconciledNotDeletable
+	}
+	return nil
+}
+
+// WalletDelta returns the amount the transaction adds to (or, when negative,
+// subtracts from) the balance of its wallet.
+func (t *Transaction) WalletDelta() domain.Amount {
+	if t.Type == TransactionTypeIncome || (t.IsTransfer() && t.Inbound) {
+		return t.Amount
+	}
+	return t.Amount.Neg()
+}
+
+// MaxTags is how many tags a transaction can have and MaxTagLength how long
+// each of them can be.
+const (
+	MaxTags      = 20
+	MaxTagLength = 50
+)
+
+// NormalizeTags trims tags and drops the empty ones and those repeated
+// regardless of case, keeping the first spelling of each.
+func NormalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[strings.ToLower(tag)] {
+			continue
+		}
+		seen[strings.ToLower(tag)] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+func sameDay(a, b time.Time) bool {
+	yearA, monthA, dayA := a.Date()
+	yearB, monthB, dayB := b.Date()
+	return yearA == yearB && monthA == monthB && dayA == dayB
+}
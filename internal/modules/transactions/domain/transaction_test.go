@@ -0,0 +1,227 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+func TestNewTransaction(t *testing.T) {
+	date := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	transaction := NewTransaction("tx-1", "user-1", "wallet-1", "category-1", TransactionTypeExpense, shareddomain.MustParseAmount("42.50"), "Groceries", date, "system")
+
+	if transaction == nil {
+		t.Fatal("NewTransaction returned nil")
+	}
+	if transaction.ID != "tx-1" {
+		t.Errorf("expected ID tx-1, got %s", transaction.ID)
+	}
+	if transaction.UserID != "user-1" {
+		t.Errorf("expected UserID user-1, got %s", transaction.UserID)
+	}
+	if transaction.WalletID != "wallet-1" {
+		t.Errorf("expected WalletID wallet-1, got %s", transaction.WalletID)
+	}
+	if transaction.CategoryID != "category-1" {
+		t.Errorf("expected CategoryID category-1, got %s", transaction.CategoryID)
+	}
+	if transaction.Type != TransactionTypeExpense {
+		t.Errorf("expected Type Expense, got %v", transaction.Type)
+	}
+	if !transaction.Amount.Equal(shareddomain.MustParseAmount("42.50")) {
+		t.Errorf("expected Amount 42.50, got %s", transaction.Amount)
+	}
+	if !transaction.Date.Equal(date) {
+		t.Errorf("expected Date %v, got %v", date, transaction.Date)
+	}
+	if transaction.CreatedBy != "system" {
+		t.Errorf("expected CreatedBy system, got %s", transaction.CreatedBy)
+	}
+}
+
+func TestNewTransfer(t *testing.T) {
+	out, in := NewTransfer("tx-out", "tx-in", "transfer-1", "user-1", "wallet-usd", "wallet-ars", "", shareddomain.MustParseAmount("100"), shareddomain.MustParseAmount("120000"), shareddomain.MustParseAmount("1200"), "Dollars to pesos", time.Now(), "system")
+
+	if !out.IsTransfer() || !in.IsTransfer() {
+		t.Error("expected both legs to be transfers")
+	}
+	if out.TransferID != "transfer-1" || in.TransferID != "transfer-1" {
+		t.Error("expected both legs to share the transfer ID")
+	}
+	if out.WalletID != "wallet-usd" || out.CounterpartWalletID != "wallet-ars" {
+		t.Errorf("unexpected outgoing leg wallets: %s -> %s", out.WalletID, out.CounterpartWalletID)
+	}
+	if in.WalletID != "wallet-ars" || in.CounterpartWalletID != "wallet-usd" {
+		t.Errorf("unexpected incoming leg wallets: %s <- %s", in.WalletID, in.CounterpartWalletID)
+	}
+	if out.WalletDelta().String() != "-100" {
+		t.Errorf("expected outgoing delta -100, got %s", out.WalletDelta())
+	}
+	if in.WalletDelta().String() != "120000" {
+		t.Errorf("expected incoming delta 120000, got %s", in.WalletDelta())
+	}
+	if out.ExchangeRate.String() != "1200" || in.ExchangeRate.String() != "1200" {
+		t.Error("expected both legs to store the exchange rate")
+	}
+}
+
+func TestTransaction_WalletDelta(t *testing.T) {
+	tests := []struct {
+		name            string
+		transactionType TransactionType
+		walletDelta     string
+	}{
+		{"expense", TransactionTypeExpense, "-25.1"},
+		{"income", TransactionTypeIncome, "25.1"},
+		{"transfer", TransactionTypeTransfer, "-25.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction := NewTransaction("tx-1", "user-1", "wallet-1", "", tt.transactionType, shareddomain.MustParseAmount("25.10"), "", time.Now(), "system")
+
+			if got := transaction.WalletDelta().String(); got != tt.walletDelta {
+				t.Errorf("WalletDelta() = %s, want %s", got, tt.walletDelta)
+			}
+		})
+	}
+}
+
+func TestResolveTransferAmounts(t *testing.T) {
+	tests := []struct {
+		name                string
+		amount              string
+		destinationAmount   string
+		exchangeRate        string
+		sourceCurrency      string
+		destinationCurrency string
+		wantDestination     string
+		wantRate            string
+		wantErr             error
+	}{
+		{"same currency", "100", "0", "0", "USD", "USD", "100", "1", nil},
+		{"same currency with rate one", "100", "0", "1", "USD", "USD", "100", "1", nil},
+		{"same currency with other rate", "100", "0", "2", "USD", "USD", "0", "0", ErrSameCurrencyRateNotOne},
+		{"cross currency with rate", "100", "0", "1185.5", "USD", "ARS", "118550", "1185.5", nil},
+		{"rate result rounded to destination scale", "10.01", "0", "0.333", "USD", "EUR", "3.33", "0.333", nil},
+		{"rate result rounded to whole yen", "10.55", "0", "149.7", "USD", "JPY", "1579", "149.7", nil},
+		{"crypto keeps satoshis", "25", "0", "0.0000155", "USD", "BTC", "0.0003875", "0.0000155", nil},
+		{"cross currency with destination amount", "200", "180", "0", "USD", "EUR", "180", "0.9", nil},
+		{"destination amount wins over rate", "200", "180", "5", "USD", "EUR", "180", "0.9", nil},
+		{"source amount too precise", "10.005", "0", "2", "USD", "EUR", "0", "0", shareddomain.ErrAmountPrecision},
+		{"destination amount too precise", "10", "1500.5", "0", "USD", "JPY", "0", "0", shareddomain.ErrAmountPrecision},
+		{"cross currency without rate", "100", "0", "0", "USD", "ARS", "0", "0", ErrExchangeRateRequired},
+		{"negative rate", "100", "0", "-1", "USD", "ARS", "0", "0", ErrInvalidExchangeRate},
+		{"zero amount", "0", "0", "0", "USD", "USD", "0", "0", ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			destination, rate, err := ResolveTransferAmounts(
+				shareddomain.MustParseAmount(tt.amount),
+				shareddomain.MustParseAmount(tt.destinationAmount),
+				shareddomain.MustParseAmount(tt.exchangeRate),
+				tt.sourceCurrency,
+				tt.destinationCurrency,
+			)
+			if err != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if !destination.Equal(shareddomain.MustParseAmount(tt.wantDestination)) {
+				t.Errorf("expected destination %s, got %s", tt.wantDestination, destination)
+			}
+			if !rate.Equal(shareddomain.MustParseAmount(tt.wantRate)) {
+				t.Errorf("expected rate %s, got %s", tt.wantRate, rate)
+			}
+		})
+	}
+}
+
+func TestTransactionType_String(t *testing.T) {
+	tests := []struct {
+		transactionType TransactionType
+		expected        string
+	}{
+		{TransactionTypeExpense, "Expense"},
+		{TransactionTypeIncome, "Income"},
+		{TransactionTypeTransfer, "Transfer"},
+		{TransactionType(99), "Unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.transactionType.String(); got != tt.expected {
+			t.Errorf("String() = %s, want %s", got, tt.expected)
+		}
+	}
+}
+
+func TestIsValidTransactionType(t *testing.T) {
+	if !IsValidTransactionType(0) || !IsValidTransactionType(1) || !IsValidTransactionType(2) {
+		t.Error("expected 0, 1 and 2 to be valid transaction types")
+	}
+	if IsValidTransactionType(-1) || IsValidTransactionType(3) {
+		t.Error("expected -1 and 3 to be invalid transaction types")
+	}
+}
+
+func TestTransaction_AddTags(t *testing.T) {
+	transaction := NewTransaction("tx-1", "user-1", "wallet-1", "category-1", TransactionTypeExpense, shareddomain.MustParseAmount("10"), "Coffee", time.Now(), "system")
+	transaction.Tags = []string{"Work"}
+
+	transaction.AddTags(" travel ", "work", "", "Travel", "client")
+
+	if strings.Join(transaction.Tags, ",") != "Work,travel,client" {
+		t.Errorf("unexpected tags %v", transaction.Tags)
+	}
+}
+
+func TestTransaction_CheckEdit(t *testing.T) {
+	date := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+	amount := shareddomain.MustParseAmount("42.50")
+
+	tests := []struct {
+		name            string
+		walletID        string
+		transactionType TransactionType
+		amount          string
+		date            time.Time
+		wantErr         error
+	}{
+		{"same values", "wallet-1", TransactionTypeExpense, "42.5", date.Add(3 * time.Hour), nil},
+		{"other wallet", "wallet-2", TransactionTypeExpense, "42.50", date, ErrReconciledNotEditable},
+		{"other type", "wallet-1", TransactionTypeIncome, "42.50", date, ErrReconciledNotEditable},
+		{"other amount", "wallet-1", TransactionTypeExpense, "42.51", date, ErrReconciledNotEditable},
+		{"other date", "wallet-1", TransactionTypeExpense, "42.50", date.AddDate(0, 0, 1), ErrReconciledNotEditable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction := NewTransaction("tx-1", "user-1", "wallet-1", "category-1", TransactionTypeExpense, amount, "Groceries", date, "system")
+
+			if err := transaction.CheckEdit(tt.walletID, tt.transactionType, shareddomain.MustParseAmount(tt.amount), tt.date); err != nil {
+				t.Fatalf("expected unreconciled transactions to be editable, got %v", err)
+			}
+
+			transaction.Reconciled = true
+			if err := transaction.CheckEdit(tt.walletID, tt.transactionType, shareddomain.MustParseAmount(tt.amount), tt.date); err != tt.wantErr {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestTransaction_CheckDelete(t *testing.T) {
+	transaction := NewTransaction("tx-1", "user-1", "wallet-1", "category-1", TransactionTypeExpense, shareddomain.MustParseAmount("42.50"), "Groceries", time.Now(), "system")
+
+	if err := transaction.CheckDelete(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	transaction.ReconciliationID, transaction.Reconciled = "rec-1", true
+	if err := transaction.CheckDelete(); err != ErrReconciledNotDeletable {
+		t.Errorf("expected ErrReconciledNotDeletable, got %v", err)
+	}
+}
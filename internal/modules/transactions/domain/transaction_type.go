@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"errors"
+
+	categorydomain "fin-flow-api/internal/modules/categories/domain"
+)
+
+type TransactionType int
+
+const (
+	TransactionTypeExpense TransactionType = iota
+	TransactionTypeIncome
+	TransactionTypeTransfer
+)
+
+var ErrInvalidTransactionType = errors.New("invalid transaction type")
+
+func (tt TransactionType) String() string {
+	switch tt {
+	case TransactionTypeExpense:
+		return "Expense"
+	case TransactionTypeIncome:
+		return "Income"
+	case TransactionTypeTransfer:
+		return "Transfer"
+	default:
+		return "Unknown"
+	}
+}
+
+func (tt TransactionType) Value() int {
+	return int(tt)
+}
+
+// AcceptsCategory reports whether a category of the given type can classify
+// transactions of type tt. Investment categories can hold both contributions
+// and returns.
+func (tt TransactionType) AcceptsCategory(categoryType categorydomain.CategoryType) bool {
+	switch tt {
+	case TransactionTypeExpense:
+		return categoryType == categorydomain.CategoryTypeExpense || categoryType == categorydomain.CategoryTypeInvestment
+	case TransactionTypeIncome:
+		return categoryType == categorydomain.CategoryTypeIncome || categoryType == categorydomain.CategoryTypeInvestment
+	default:
+		return true
+	}
+}
+
+func IsValidTransactionType(value int) bool {
+	tt := TransactionType(value)
+	return tt >= TransactionTypeExpense && tt <= TransactionTypeTransfer
+}
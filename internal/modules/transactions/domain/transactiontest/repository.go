@@ -0,0 +1,183 @@
+// Package transactiontest provides an in-memory transaction repository
+// for the tests of the services that depend on one.
+package transactiontest
+
+import (
+	"errors"
+
+	"fin-flow-api/internal/modules/transactions/domain"
+)
+
+// Repository is an in-memory domain.TransactionRepository. Like the
+// unique indexes of the transactions table, it rejects a second payment of
+// the same loan installment and a second posting of the same recurring
+// rule occurrence.
+type Repository struct {
+	Transactions map[string]*domain.Transaction
+	// Created holds the transactions stored through the repository, in the
+	// order they were stored.
+	Created []*domain.Transaction
+	Updated []*domain.Transaction
+	// Filters holds every filter List was called with.
+	Filters []domain.TransactionFilter
+	// Recategorized holds the ids of the last Recategorize call.
+	Recategorized []string
+
+	CreateErr error
+	ListErr   error
+}
+
+func NewRepository(transactions ...*domain.Transaction) *Repository {
+	repo := &Repository{Transactions: make(map[string]*domain.Transaction)}
+	for _, transaction := range transactions {
+		repo.Transactions[transaction.ID] = transaction
+	}
+	return repo
+}
+
+// LastFilter returns the filter of the last List call.
+func (r *Repository) LastFilter() domain.TransactionFilter {
+	if len(r.Filters) == 0 {
+		return domain.TransactionFilter{}
+	}
+	return r.Filters[len(r.Filters)-1]
+}
+
+func (r *Repository) Create(transaction *domain.Transaction) error {
+	return r.store(transaction)
+}
+
+func (r *Repository) CreateTransfer(out *domain.Transaction, in *domain.Transaction) error {
+	return r.store(out, in)
+}
+
+func (r *Repository) CreateInstallments(installments []*domain.Transaction) error {
+	return r.store(installments...)
+}
+
+func (r *Repository) CreateAll(transactions []*domain.Transaction) error {
+	return r.store(transactions...)
+}
+
+func (r *Repository) store(transactions ...*domain.Transaction) error {
+	if r.CreateErr != nil {
+		return r.CreateErr
+	}
+	for _, transaction := range transactions {
+		if err := r.checkUnique(transaction); err != nil {
+			return err
+		}
+	}
+	for _, transaction := range transactions {
+		r.Transactions[transaction.ID] = transaction
+		r.Created = append(r.Created, transaction)
+	}
+	return nil
+}
+
+func (r *Repository) checkUnique(transaction *domain.Transaction) error {
+	for _, existing := range r.Transactions {
+		if transaction.LoanID != "" && existing.LoanID == transaction.LoanID && existing.LoanInstallment == transaction.LoanInstallment {
+			return domain.ErrLoanInstallmentPaid
+		}
+		if transaction.RecurringRuleID != "" && existing.RecurringRuleID == transaction.RecurringRuleID && existing.Date.Equal(transaction.Date) {
+			return domain.ErrOccurrenceAlreadyPosted
+		}
+	}
+	return nil
+}
+
+func (r *Repository) GetByID(id string, userID string) (*domain.Transaction, error) {
+	transaction, exists := r.Transactions[id]
+	if !exists {
+		return nil, errors.New("transaction not found")
+	}
+	if transaction.UserID != userID {
+		return nil, errors.New("unauthorized access to transaction")
+	}
+	return transaction, nil
+}
+
+func (r *Repository) List(userID string, filter domain.TransactionFilter) ([]*domain.Transaction, error) {
+	if r.ListErr != nil {
+		return nil, r.ListErr
+	}
+	r.Filters = append(r.Filters, filter)
+
+	var result []*domain.Transaction
+	for _, transaction := range r.Transactions {
+		if transaction.UserID == userID && matches(transaction, filter) {
+			result = append(result, transaction)
+		}
+	}
+	return result, nil
+}
+
+func matches(transaction *domain.Transaction, filter domain.TransactionFilter) bool {
+	switch {
+	case filter.WalletID != "" && transaction.WalletID != filter.WalletID:
+		return false
+	case filter.CategoryID != "" && transaction.CategoryID != filter.CategoryID:
+		return false
+	case filter.LoanID != "" && transaction.LoanID != filter.LoanID:
+		return false
+	case filter.Type != nil && transaction.Type != *filter.Type:
+		return false
+	case filter.From != nil && transaction.Date.Before(*filter.From):
+		return false
+	case filter.To != nil && transaction.Date.After(*filter.To):
+		return false
+	}
+	return true
+}
+
+func (r *Repository) Update(transaction *domain.Transaction) error {
+	if _, exists := r.Transactions[transaction.ID]; !exists {
+		return errors.New("transaction not found")
+	}
+	r.Transactions[transaction.ID] = transaction
+	r.Updated = append(r.Updated, transaction)
+	return nil
+}
+
+func (r *Repository) Delete(id string, userID string) error {
+	if _, err := r.GetByID(id, userID); err != nil {
+		return err
+	}
+	delete(r.Transactions, id)
+	return nil
+}
+
+// DeleteImportBatch deletes the transactions of an import batch, for
+// fakes of repositories that undo imports.
+func (r *Repository) DeleteImportBatch(batchID string, userID string) {
+	for id, transaction := range r.Transactions {
+		if transaction.ImportBatchID == batchID && transaction.UserID == userID {
+			delete(r.Transactions, id)
+		}
+	}
+}
+
+func (r *Repository) FindExternalIDs(walletID string, externalIDs []string, userID string) (map[string]bool, error) {
+	found := make(map[string]bool)
+	for _, transaction := range r.Transactions {
+		if transaction.WalletID == walletID && transaction.UserID == userID && transaction.ExternalID != "" {
+			found[transaction.ExternalID] = true
+		}
+	}
+	return found, nil
+}
+
+func (r *Repository) Recategorize(ids []string, userID string, categoryID string, modifiedBy string) (int, error) {
+	r.Recategorized = ids
+	for _, id := range ids {
+		r.Transactions[id].CategoryID = categoryID
+	}
+	return len(ids), nil
+}
+
+func (r *Repository) MergeDuplicate(kept *domain.Transaction, duplicateID string) error {
+	r.Transactions[kept.ID] = kept
+	delete(r.Transactions, duplicateID)
+	return nil
+}
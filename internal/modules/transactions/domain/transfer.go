@@ -0,0 +1,50 @@
+package domain
+
+import "fin-flow-api/internal/shared/domain"
+
+// ExchangeRateScale is the number of decimal places exchange rates are
+// stored with.
+const ExchangeRateScale int32 = 18
+
+// ResolveTransferAmounts returns the amount credited to the destination wallet
+// and the exchange rate applied. Callers supply either the destination amount
+// or the exchange rate; when both are given the destination amount wins.
+// Converted amounts are rounded to the scale of the destination currency.
+func ResolveTransferAmounts(amount, destinationAmount, exchangeRate domain.Amount, sourceCurrency, destinationCurrency string) (domain.Amount, domain.Amount, error) {
+	one := domain.NewAmountFromInt(1)
+
+	if !amount.IsPositive() || destinationAmount.IsNegative() {
+		return domain.Amount{}, domain.Amount{}, ErrInvalidAmount
+	}
+	if exchangeRate.IsNegative() {
+		return domain.Amount{}, domain.Amount{}, ErrInvalidExchangeRate
+	}
+	if !amount.FitsCurrency(sourceCurrency) || !destinationAmount.FitsCurrency(destinationCurrency) {
+		return domain.Amount{}, domain.Amount{}, domain.ErrAmountPrecision
+	}
+
+	if sourceCurrency == destinationCurrency {
+		if !exchangeRate.IsZero() && !exchangeRate.Equal(one) {
+			return domain.Amount{}, domain.Amount{}, ErrSameCurrencyRateNotOne
+		}
+		if !destinationAmount.IsZero() && !destinationAmount.Equal(amount) {
+			return domain.Amount{}, domain.Amount{}, ErrSameCurrencyRateNotOne
+		}
+		return amount, one, nil
+	}
+
+	if destinationAmount.IsPositive() {
+		return destinationAmount, destinationAmount.DivRound(amount, ExchangeRateScale), nil
+	}
+
+	if exchangeRate.IsPositive() {
+		rate := exchangeRate.Round(ExchangeRateScale)
+		converted := amount.Mul(rate).RoundToCurrency(destinationCurrency)
+		if !converted.IsPositive() {
+			return domain.Amount{}, domain.Amount{}, ErrInvalidAmount
+		}
+		return converted, rate, nil
+	}
+
+	return domain.Amount{}, domain.Amount{}, ErrExchangeRateRequired
+}
@@ -0,0 +1,788 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"fin-flow-api/internal/modules/transactions/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const transactionColumns = `id, user_id, wallet_id, counterpart_wallet_id, category_id, type, amount, description, date, transfer_id, exchange_rate, is_inbound, recurring_rule_id, installment_plan_id, installment_number, installment_count, loan_id, loan_installment, payee, tags, import_batch_id, external_id, value_date, reconciliation_id, reconciled, created_at, modified_at, created_by, modified_by`
+
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+func (r *Repository) Create(transaction *domain.Transaction) error {
+	ctx := context.Background()
+
+	dbTx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	if err := insertTransaction(ctx, dbTx, transaction); err != nil {
+		return err
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	return nil
+}
+
+// CreateTransfer writes both legs of a transfer and moves the balances of the
+// two wallets in a single database transaction.
+func (r *Repository) CreateTransfer(out *domain.Transaction, in *domain.Transaction) error {
+	ctx := context.Background()
+
+	dbTx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create transfer: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	if err := insertTransaction(ctx, dbTx, out); err != nil {
+		return err
+	}
+
+	if err := insertTransaction(ctx, dbTx, in); err != nil {
+		return err
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to create transfer: %w", err)
+	}
+
+	return nil
+}
+
+// CreateInstallments writes every installment of a purchase in a single
+// database transaction, so a plan is never stored half way.
+func (r *Repository) CreateInstallments(installments []*domain.Transaction) error {
+	ctx := context.Background()
+
+	dbTx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create installments: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	for _, installment := range installments {
+		if err := insertTransaction(ctx, dbTx, installment); err != nil {
+			return err
+		}
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to create installments: %w", err)
+	}
+
+	return nil
+}
+
+// CreateAll writes the transactions in a single database transaction, so
+// either all of them are stored or none is.
+func (r *Repository) CreateAll(transactions []*domain.Transaction) error {
+	ctx := context.Background()
+
+	dbTx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create transactions: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	if err := InsertAll(ctx, dbTx, transactions); err != nil {
+		return err
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to create transactions: %w", err)
+	}
+
+	return nil
+}
+
+// InsertAll writes the transactions and moves the balances of their
+// wallets within dbTx, for repositories that store transactions together
+// with rows of their own.
+func InsertAll(ctx context.Context, dbTx pgx.Tx, transactions []*domain.Transaction) error {
+	for _, transaction := range transactions {
+		if err := insertTransaction(ctx, dbTx, transaction); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteImported deletes the transactions of an import batch within dbTx
+// and moves the balances of their wallets back. Reconciled transactions
+// keep the whole batch from being deleted.
+func DeleteImported(ctx context.Context, dbTx pgx.Tx, batchID string, userID string) error {
+	imported, err := lockImportedTransactions(ctx, dbTx, batchID, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, transaction := range imported {
+		if err := transaction.CheckDelete(); err != nil {
+			return err
+		}
+	}
+
+	for _, transaction := range imported {
+		if err := adjustWalletBalance(ctx, dbTx, transaction.WalletID, transaction.UserID, transaction.WalletDelta().Neg()); err != nil {
+			return err
+		}
+	}
+
+	if _, err := dbTx.Exec(ctx, `DELETE FROM transactions WHERE import_batch_id = $1 AND user_id = $2`, batchID, userID); err != nil {
+		return fmt.Errorf("failed to delete imported transactions: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetByID(id string, userID string) (*domain.Transaction, error) {
+	checkQuery := `SELECT user_id FROM transactions WHERE id = $1`
+	var transactionUserID string
+	err := r.pool.QueryRow(context.Background(), checkQuery, id).Scan(&transactionUserID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("transaction not found")
+		}
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+
+	if transactionUserID != userID {
+		return nil, fmt.Errorf("unauthorized access to transaction")
+	}
+
+	query := `SELECT ` + transactionColumns + ` FROM transactions WHERE id = $1 AND user_id = $2`
+
+	transaction, err := scanTransaction(r.pool.QueryRow(context.Background(), query, id, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("transaction not found")
+		}
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+
+	return transaction, nil
+}
+
+func (r *Repository) List(userID string, filter domain.TransactionFilter) ([]*domain.Transaction, error) {
+	conditions := []string{"user_id = $1"}
+	args := []interface{}{userID}
+
+	if filter.WalletID != "" {
+		args = append(args, filter.WalletID)
+		conditions = append(conditions, fmt.Sprintf("wallet_id = $%d", len(args)))
+	}
+	if filter.CategoryID != "" {
+		args = append(args, filter.CategoryID)
+		conditions = append(conditions, fmt.Sprintf("category_id = $%d", len(args)))
+	}
+	if filter.Type != nil {
+		args = append(args, filter.Type.Value())
+		conditions = append(conditions, fmt.Sprintf("type = $%d", len(args)))
+	}
+	if filter.LoanID != "" {
+		args = append(args, filter.LoanID)
+		conditions = append(conditions, fmt.Sprintf("loan_id = $%d", len(args)))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("date >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("date <= $%d", len(args)))
+	}
+
+	query := `SELECT ` + transactionColumns + ` FROM transactions WHERE ` + strings.Join(conditions, " AND ") + ` ORDER BY date DESC, created_at DESC`
+
+	rows, err := r.pool.Query(context.Background(), query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var transactions []*domain.Transaction
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transaction: %w", err)
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate transactions: %w", err)
+	}
+
+	return transactions, nil
+}
+
+func (r *Repository) Update(transaction *domain.Transaction) error {
+	ctx := context.Background()
+
+	dbTx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update transaction: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	existing, err := lockTransaction(ctx, dbTx, transaction.ID, transaction.UserID)
+	if err != nil {
+		return err
+	}
+
+	if err := existing.CheckEdit(transaction.WalletID, transaction.Type, transaction.Amount, transaction.Date); err != nil {
+		return err
+	}
+
+	if err := adjustWalletBalance(ctx, dbTx, existing.WalletID, existing.UserID, existing.WalletDelta().Neg()); err != nil {
+		return err
+	}
+
+	// A transaction moved to another wallet or day no longer belongs to the
+	// reconciliation it cleared in.
+	query := `
+		UPDATE transactions
+		SET wallet_id = $2, category_id = $3, type = $4, amount = $5,
+			description = $6, payee = $7, tags = $8, date = $9, modified_at = $10, modified_by = $11,
+			reconciliation_id = CASE WHEN wallet_id = $2 AND date = $9 THEN reconciliation_id END
+		WHERE id = $1 AND user_id = $12
+	`
+
+	_, err = dbTx.Exec(
+		ctx,
+		query,
+		transaction.ID,
+		transaction.WalletID,
+		nullableString(transaction.CategoryID),
+		transaction.Type.Value(),
+		transaction.Amount,
+		transaction.Description,
+		nullableString(transaction.Payee),
+		tagsOrEmpty(transaction.Tags),
+		transaction.Date,
+		transaction.ModifiedAt,
+		transaction.ModifiedBy,
+		transaction.UserID,
+	)
+	if err != nil {
+		return mapWriteError("failed to update transaction", err)
+	}
+
+	if err := adjustWalletBalance(ctx, dbTx, transaction.WalletID, transaction.UserID, transaction.WalletDelta()); err != nil {
+		return err
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to update transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) Delete(id string, userID string) error {
+	ctx := context.Background()
+
+	dbTx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete transaction: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	existing, err := lockTransaction(ctx, dbTx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	// Transfers are deleted with both legs and installments with the whole
+	// purchase they belong to.
+	legs := []*domain.Transaction{existing}
+	if existing.TransferID != "" {
+		legs, err = lockTransferLegs(ctx, dbTx, existing.TransferID, userID)
+		if err != nil {
+			return err
+		}
+	} else if existing.InstallmentPlanID != "" {
+		legs, err = lockInstallments(ctx, dbTx, existing.InstallmentPlanID, userID)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, leg := range legs {
+		if err := leg.CheckDelete(); err != nil {
+			return err
+		}
+	}
+
+	for _, leg := range legs {
+		if err := adjustWalletBalance(ctx, dbTx, leg.WalletID, leg.UserID, leg.WalletDelta().Neg()); err != nil {
+			return err
+		}
+
+		if _, err := dbTx.Exec(ctx, `DELETE FROM transactions WHERE id = $1 AND user_id = $2`, leg.ID, userID); err != nil {
+			return fmt.Errorf("failed to delete transaction: %w", err)
+		}
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to delete transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) FindExternalIDs(walletID string, externalIDs []string, userID string) (map[string]bool, error) {
+	query := `SELECT external_id FROM transactions WHERE wallet_id = $1 AND user_id = $2 AND external_id = ANY($3)`
+
+	rows, err := r.pool.Query(context.Background(), query, walletID, userID, externalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find imported transactions: %w", err)
+	}
+	defer rows.Close()
+
+	found := make(map[string]bool)
+	for rows.Next() {
+		var externalID string
+		if err := rows.Scan(&externalID); err != nil {
+			return nil, fmt.Errorf("failed to scan imported transaction: %w", err)
+		}
+		found[externalID] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate imported transactions: %w", err)
+	}
+
+	return found, nil
+}
+
+func (r *Repository) Recategorize(ids []string, userID string, categoryID string, modifiedBy string) (int, error) {
+	query := `
+		UPDATE transactions
+		SET category_id = $1, modified_at = $2, modified_by = $3
+		WHERE user_id = $4 AND (
+			id = ANY($5)
+			OR transfer_id IN (
+				SELECT transfer_id FROM transactions
+				WHERE id = ANY($5) AND user_id = $4 AND transfer_id IS NOT NULL
+			)
+		)
+	`
+
+	result, err := r.pool.Exec(context.Background(), query, categoryID, time.Now(), modifiedBy, userID, ids)
+	if err != nil {
+		return 0, mapWriteError("failed to recategorize transactions", err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
+
+func (r *Repository) MergeDuplicate(kept *domain.Transaction, duplicateID string) error {
+	ctx := context.Background()
+
+	dbTx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to merge transactions: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	if _, err := lockTransaction(ctx, dbTx, kept.ID, kept.UserID); err != nil {
+		return err
+	}
+
+	duplicate, err := lockTransaction(ctx, dbTx, duplicateID, kept.UserID)
+	if err != nil {
+		return err
+	}
+
+	if err := duplicate.CheckDelete(); err != nil {
+		return err
+	}
+
+	if err := adjustWalletBalance(ctx, dbTx, duplicate.WalletID, duplicate.UserID, duplicate.WalletDelta().Neg()); err != nil {
+		return err
+	}
+
+	// The duplicate goes first, since kept may take over its external id,
+	// which is unique within the wallet.
+	if _, err := dbTx.Exec(ctx, `DELETE FROM transactions WHERE id = $1 AND user_id = $2`, duplicate.ID, kept.UserID); err != nil {
+		return fmt.Errorf("failed to merge transactions: %w", err)
+	}
+
+	query := `
+		UPDATE transactions
+		SET description = $2, payee = $3, tags = $4, external_id = $5, value_date = $6, modified_at = $7, modified_by = $8
+		WHERE id = $1 AND user_id = $9
+	`
+
+	_, err = dbTx.Exec(
+		ctx,
+		query,
+		kept.ID,
+		kept.Description,
+		nullableString(kept.Payee),
+		tagsOrEmpty(kept.Tags),
+		nullableString(kept.ExternalID),
+		kept.ValueDate,
+		kept.ModifiedAt,
+		kept.ModifiedBy,
+		kept.UserID,
+	)
+	if err != nil {
+		return mapWriteError("failed to merge transactions", err)
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to merge transactions: %w", err)
+	}
+
+	return nil
+}
+
+func lockTransaction(ctx context.Context, dbTx pgx.Tx, id string, userID string) (*domain.Transaction, error) {
+	query := `SELECT ` + transactionColumns + ` FROM transactions WHERE id = $1 FOR UPDATE`
+
+	existing, err := scanTransaction(dbTx.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("transaction not found")
+		}
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+
+	if existing.UserID != userID {
+		return nil, fmt.Errorf("unauthorized access to transaction")
+	}
+
+	return existing, nil
+}
+
+func lockTransferLegs(ctx context.Context, dbTx pgx.Tx, transferID string, userID string) ([]*domain.Transaction, error) {
+	query := `SELECT ` + transactionColumns + ` FROM transactions WHERE transfer_id = $1 AND user_id = $2 FOR UPDATE`
+
+	rows, err := dbTx.Query(ctx, query, transferID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transfer: %w", err)
+	}
+	defer rows.Close()
+
+	var legs []*domain.Transaction
+	for rows.Next() {
+		leg, err := scanTransaction(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transaction: %w", err)
+		}
+		legs = append(legs, leg)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate transfer legs: %w", err)
+	}
+
+	return legs, nil
+}
+
+func lockInstallments(ctx context.Context, dbTx pgx.Tx, planID string, userID string) ([]*domain.Transaction, error) {
+	query := `SELECT ` + transactionColumns + ` FROM transactions WHERE installment_plan_id = $1 AND user_id = $2 FOR UPDATE`
+
+	rows, err := dbTx.Query(ctx, query, planID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get installments: %w", err)
+	}
+	defer rows.Close()
+
+	var installments []*domain.Transaction
+	for rows.Next() {
+		installment, err := scanTransaction(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transaction: %w", err)
+		}
+		installments = append(installments, installment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate installments: %w", err)
+	}
+
+	return installments, nil
+}
+
+func lockImportedTransactions(ctx context.Context, dbTx pgx.Tx, batchID string, userID string) ([]*domain.Transaction, error) {
+	query := `SELECT ` + transactionColumns + ` FROM transactions WHERE import_batch_id = $1 AND user_id = $2 FOR UPDATE`
+
+	rows, err := dbTx.Query(ctx, query, batchID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get imported transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var imported []*domain.Transaction
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transaction: %w", err)
+		}
+		imported = append(imported, transaction)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate imported transactions: %w", err)
+	}
+
+	return imported, nil
+}
+
+// insertTransaction stores a transaction and applies it to the cached balance
+// of its wallet so both stay in sync.
+func insertTransaction(ctx context.Context, dbTx pgx.Tx, transaction *domain.Transaction) error {
+	query := `
+		INSERT INTO transactions (` + transactionColumns + `, occurrence_date)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30)
+	`
+
+	// occurrence_date records which scheduled occurrence of a recurring rule
+	// the entry belongs to. It is unique per rule, so an occurrence can only
+	// be posted once even if the date is edited afterwards.
+	var occurrenceDate interface{}
+	if transaction.RecurringRuleID != "" {
+		occurrenceDate = transaction.Date
+	}
+
+	var exchangeRate *shareddomain.Amount
+	if transaction.IsTransfer() {
+		exchangeRate = &transaction.ExchangeRate
+	}
+
+	_, err := dbTx.Exec(
+		ctx,
+		query,
+		transaction.ID,
+		transaction.UserID,
+		transaction.WalletID,
+		nullableString(transaction.CounterpartWalletID),
+		nullableString(transaction.CategoryID),
+		transaction.Type.Value(),
+		transaction.Amount,
+		transaction.Description,
+		transaction.Date,
+		nullableString(transaction.TransferID),
+		exchangeRate,
+		transaction.Inbound,
+		nullableString(transaction.RecurringRuleID),
+		nullableString(transaction.InstallmentPlanID),
+		nullableInt(transaction.InstallmentNumber),
+		nullableInt(transaction.InstallmentCount),
+		nullableString(transaction.LoanID),
+		nullableInt(transaction.LoanInstallment),
+		nullableString(transaction.Payee),
+		tagsOrEmpty(transaction.Tags),
+		nullableString(transaction.ImportBatchID),
+		nullableString(transaction.ExternalID),
+		transaction.ValueDate,
+		nullableString(transaction.ReconciliationID),
+		transaction.Reconciled,
+		transaction.CreatedAt,
+		transaction.ModifiedAt,
+		transaction.CreatedBy,
+		transaction.ModifiedBy,
+		occurrenceDate,
+	)
+	if err != nil {
+		return mapWriteError("failed to create transaction", err)
+	}
+
+	return adjustWalletBalance(ctx, dbTx, transaction.WalletID, transaction.UserID, transaction.WalletDelta())
+}
+
+func adjustWalletBalance(ctx context.Context, dbTx pgx.Tx, walletID string, userID string, delta shareddomain.Amount) error {
+	query := `UPDATE wallets SET balance = balance + $1 WHERE id = $2 AND user_id = $3`
+
+	result, err := dbTx.Exec(ctx, query, delta, walletID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update wallet balance: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("wallet not found")
+	}
+
+	return nil
+}
+
+func scanTransaction(row pgx.Row) (*domain.Transaction, error) {
+	var transaction domain.Transaction
+	var counterpartWalletID *string
+	var categoryID *string
+	var transferID *string
+	var recurringRuleID *string
+	var installmentPlanID *string
+	var installmentNumber, installmentCount *int
+	var loanID *string
+	var loanInstallment *int
+	var payee *string
+	var importBatchID *string
+	var externalID *string
+	var reconciliationID *string
+	var typeValue int
+
+	err := row.Scan(
+		&transaction.ID,
+		&transaction.UserID,
+		&transaction.WalletID,
+		&counterpartWalletID,
+		&categoryID,
+		&typeValue,
+		&transaction.Amount,
+		&transaction.Description,
+		&transaction.Date,
+		&transferID,
+		&transaction.ExchangeRate,
+		&transaction.Inbound,
+		&recurringRuleID,
+		&installmentPlanID,
+		&installmentNumber,
+		&installmentCount,
+		&loanID,
+		&loanInstallment,
+		&payee,
+		&transaction.Tags,
+		&importBatchID,
+		&externalID,
+		&transaction.ValueDate,
+		&reconciliationID,
+		&transaction.Reconciled,
+		&transaction.CreatedAt,
+		&transaction.ModifiedAt,
+		&transaction.CreatedBy,
+		&transaction.ModifiedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction.Type = domain.TransactionType(typeValue)
+	if counterpartWalletID != nil {
+		transaction.CounterpartWalletID = *counterpartWalletID
+	}
+	if categoryID != nil {
+		transaction.CategoryID = *categoryID
+	}
+	if transferID != nil {
+		transaction.TransferID = *transferID
+	}
+	if recurringRuleID != nil {
+		transaction.RecurringRuleID = *recurringRuleID
+	}
+	if installmentPlanID != nil {
+		transaction.InstallmentPlanID = *installmentPlanID
+	}
+	if installmentNumber != nil {
+		transaction.InstallmentNumber = *installmentNumber
+	}
+	if installmentCount != nil {
+		transaction.InstallmentCount = *installmentCount
+	}
+	if loanID != nil {
+		transaction.LoanID = *loanID
+	}
+	if loanInstallment != nil {
+		transaction.LoanInstallment = *loanInstallment
+	}
+	if payee != nil {
+		transaction.Payee = *payee
+	}
+	if importBatchID != nil {
+		transaction.ImportBatchID = *importBatchID
+	}
+	if externalID != nil {
+		transaction.ExternalID = *externalID
+	}
+	if reconciliationID != nil {
+		transaction.ReconciliationID = *reconciliationID
+	}
+
+	return &transaction, nil
+}
+
+func mapWriteError(message string, err error) error {
+	if pgErr, ok := err.(*pgconn.PgError); ok {
+		switch pgErr.Code {
+		case "23503": // foreign_key_violation
+			if strings.Contains(pgErr.ConstraintName, "category") {
+				return fmt.Errorf("invalid category reference")
+			}
+			if strings.Contains(pgErr.ConstraintName, "recurring_rule") {
+				return fmt.Errorf("invalid recurring rule reference")
+			}
+			if strings.Contains(pgErr.ConstraintName, "loan") {
+				return fmt.Errorf("invalid loan reference")
+			}
+			if strings.Contains(pgErr.ConstraintName, "import_batch") {
+				return fmt.Errorf("invalid import batch reference")
+			}
+			return fmt.Errorf("invalid wallet reference")
+		case "23505": // unique_violation
+			if strings.Contains(pgErr.ConstraintName, "recurring_occurrence") {
+				return domain.ErrOccurrenceAlreadyPosted
+			}
+			if strings.Contains(pgErr.ConstraintName, "loan_installment") {
+				return domain.ErrLoanInstallmentPaid
+			}
+			if strings.Contains(pgErr.ConstraintName, "external_id") {
+				return domain.ErrAlreadyImported
+			}
+		case "23514": // check_violation
+			if pgErr.ConstraintName == "chk_transactions_installments" {
+				return domain.ErrInvalidInstallments
+			}
+			return domain.ErrInvalidAmount
+		}
+	}
+	return fmt.Errorf("%s: %w", message, err)
+}
+
+func nullableString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// tagsOrEmpty stores transactions without tags as an empty array, since the
+// column is not nullable.
+func tagsOrEmpty(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
+func nullableInt(value int) *int {
+	if value == 0 {
+		return nil
+	}
+	return &value
+}
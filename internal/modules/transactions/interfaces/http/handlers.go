@@ -0,0 +1,588 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"fin-flow-api/internal/modules/transactions/application/contracts/commands"
+	"fin-flow-api/internal/modules/transactions/application/contracts/queries"
+	"fin-flow-api/internal/modules/transactions/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	basehandler "fin-flow-api/internal/shared/http"
+)
+
+const dateLayout = "2006-01-02"
+
+type transactionService interface {
+	Create(ctx context.Context, req commands.TransactionRequest) (*queries.CreateTransactionResponse, error)
+	Transfer(ctx context.Context, req commands.TransferRequest) error
+	GetByID(ctx context.Context, id string) (*queries.TransactionResponse, error)
+	Update(ctx context.Context, id string, req commands.TransactionRequest) error
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, req queries.ListTransactionsRequest) ([]*queries.TransactionResponse, error)
+	Recategorize(ctx context.Context, req commands.RecategorizeRequest) (int, error)
+}
+
+type Handler struct {
+	transactionService transactionService
+}
+
+func NewHandler(transactionService transactionService) *Handler {
+	return &Handler{
+		transactionService: transactionService,
+	}
+}
+
+func (h *Handler) CreateTransaction(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var reqDTO TransactionRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	cmd, err := toTransactionCommand(reqDTO)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	created, err := h.transactionService.Create(r.Context(), cmd)
+	if err != nil {
+		statusCode, errorMsg := transactionErrorResponse(err, "create")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, CreateTransactionResponse{
+		Message:              "Transaction created successfully",
+		ID:                   created.ID,
+		TransferID:           created.TransferID,
+		InstallmentPlanID:    created.InstallmentPlanID,
+		PossibleDuplicateIDs: created.PossibleDuplicateIDs,
+	})
+}
+
+func (h *Handler) CreateTransfer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var reqDTO TransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	cmd, err := toTransferCommand(reqDTO)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.transactionService.Transfer(r.Context(), cmd); err != nil {
+		statusCode, errorMsg := transactionErrorResponse(err, "create")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Transfer created successfully")
+}
+
+func (h *Handler) GetTransaction(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/transactions/")
+	id := strings.Split(path, "/")[0]
+
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Transaction ID is required in the URL path")
+		return
+	}
+
+	transaction, err := h.transactionService.GetByID(r.Context(), id)
+	if err != nil {
+		statusCode, errorMsg := transactionErrorResponse(err, "access")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, toTransactionResponse(transaction))
+}
+
+func (h *Handler) UpdateTransaction(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/transactions/")
+	id := strings.Split(path, "/")[0]
+
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Transaction ID is required in the URL path")
+		return
+	}
+
+	var reqDTO TransactionRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	cmd, err := toTransactionCommand(reqDTO)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.transactionService.Update(r.Context(), id, cmd); err != nil {
+		statusCode, errorMsg := transactionErrorResponse(err, "update")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Transaction updated successfully")
+}
+
+func (h *Handler) DeleteTransaction(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/transactions/")
+	id := strings.Split(path, "/")[0]
+
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Transaction ID is required in the URL path")
+		return
+	}
+
+	if err := h.transactionService.Delete(r.Context(), id); err != nil {
+		statusCode, errorMsg := transactionErrorResponse(err, "delete")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Transaction deleted successfully")
+}
+
+func (h *Handler) ListTransactions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	req, err := parseListTransactionsRequest(r)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	transactions, err := h.transactionService.List(r.Context(), req)
+	if err != nil {
+		statusCode, errorMsg := transactionErrorResponse(err, "access")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	responses := make([]TransactionResponse, len(transactions))
+	for i, transaction := range transactions {
+		responses[i] = toTransactionResponse(transaction)
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, responses)
+}
+
+// RecategorizeTransactions handles POST /transactions/recategorize and moves
+// the transactions that match a filter to another category.
+func (h *Handler) RecategorizeTransactions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var reqDTO RecategorizeRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	cmd, err := toRecategorizeCommand(reqDTO)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updated, err := h.transactionService.Recategorize(r.Context(), cmd)
+	if err != nil {
+		statusCode, errorMsg := transactionErrorResponse(err, "update")
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, RecategorizeResponse{Updated: updated})
+}
+
+func parseListTransactionsRequest(r *http.Request) (queries.ListTransactionsRequest, error) {
+	values := r.URL.Query()
+	req := queries.ListTransactionsRequest{
+		WalletID:   values.Get("wallet_id"),
+		CategoryID: values.Get("category_id"),
+	}
+
+	if typeParam := values.Get("type"); typeParam != "" {
+		typeValue, err := strconv.Atoi(typeParam)
+		if err != nil {
+			return req, &ValidationError{Field: "type", Message: "Transaction type must be a number"}
+		}
+		req.Type = &typeValue
+	}
+
+	if from := values.Get("from"); from != "" {
+		date, err := time.Parse(dateLayout, from)
+		if err != nil {
+			return req, &ValidationError{Field: "from", Message: "From date must use the YYYY-MM-DD format"}
+		}
+		req.From = &date
+	}
+
+	if to := values.Get("to"); to != "" {
+		date, err := time.Parse(dateLayout, to)
+		if err != nil {
+			return req, &ValidationError{Field: "to", Message: "To date must use the YYYY-MM-DD format"}
+		}
+		req.To = &date
+	}
+
+	return req, nil
+}
+
+func toRecategorizeCommand(req RecategorizeRequest) (commands.RecategorizeRequest, error) {
+	cmd := commands.RecategorizeRequest{
+		CategoryID: strings.TrimSpace(req.CategoryID),
+		Filter: queries.ListTransactionsRequest{
+			WalletID:   req.Filter.WalletID,
+			CategoryID: req.Filter.CategoryID,
+			Type:       req.Filter.Type,
+		},
+		TransactionIDs: req.TransactionIDs,
+	}
+
+	if cmd.CategoryID == "" {
+		return cmd, &ValidationError{Field: "category_id", Message: "Category ID is required"}
+	}
+
+	if req.Filter.From != "" {
+		date, err := time.Parse(dateLayout, req.Filter.From)
+		if err != nil {
+			return cmd, &ValidationError{Field: "filter.from", Message: "From date must use the YYYY-MM-DD format"}
+		}
+		cmd.Filter.From = &date
+	}
+
+	if req.Filter.To != "" {
+		date, err := time.Parse(dateLayout, req.Filter.To)
+		if err != nil {
+			return cmd, &ValidationError{Field: "filter.to", Message: "To date must use the YYYY-MM-DD format"}
+		}
+		cmd.Filter.To = &date
+	}
+
+	return cmd, nil
+}
+
+func toTransactionCommand(req TransactionRequest) (commands.TransactionRequest, error) {
+	if err := validateTransactionRequest(req); err != nil {
+		return commands.TransactionRequest{}, err
+	}
+
+	date, err := time.Parse(dateLayout, req.Date)
+	if err != nil {
+		return commands.TransactionRequest{}, &ValidationError{Field: "date", Message: "Date must use the YYYY-MM-DD format"}
+	}
+
+	return commands.TransactionRequest{
+		WalletID:            strings.TrimSpace(req.WalletID),
+		DestinationWalletID: strings.TrimSpace(req.DestinationWalletID),
+		CategoryID:          strings.TrimSpace(req.CategoryID),
+		Type:                *req.Type,
+		Amount:              *req.Amount,
+		DestinationAmount:   valueOrZero(req.DestinationAmount),
+		ExchangeRate:        valueOrZero(req.ExchangeRate),
+		Description:         strings.TrimSpace(req.Description),
+		Payee:               strings.TrimSpace(req.Payee),
+		Tags:                domain.NormalizeTags(req.Tags),
+		Date:                date,
+		Installments:        installments(req),
+	}, nil
+}
+
+func toTransferCommand(req TransferRequest) (commands.TransferRequest, error) {
+	if err := validateTransferRequest(req); err != nil {
+		return commands.TransferRequest{}, err
+	}
+
+	date, err := time.Parse(dateLayout, req.Date)
+	if err != nil {
+		return commands.TransferRequest{}, &ValidationError{Field: "date", Message: "Date must use the YYYY-MM-DD format"}
+	}
+
+	return commands.TransferRequest{
+		FromWalletID:      strings.TrimSpace(req.FromWalletID),
+		ToWalletID:        strings.TrimSpace(req.ToWalletID),
+		CategoryID:        strings.TrimSpace(req.CategoryID),
+		Amount:            *req.Amount,
+		DestinationAmount: valueOrZero(req.DestinationAmount),
+		ExchangeRate:      valueOrZero(req.ExchangeRate),
+		Description:       strings.TrimSpace(req.Description),
+		Date:              date,
+	}, nil
+}
+
+func validateTransferRequest(req TransferRequest) error {
+	if strings.TrimSpace(req.FromWalletID) == "" {
+		return &ValidationError{Field: "from_wallet_id", Message: "Source wallet ID is required"}
+	}
+
+	if strings.TrimSpace(req.ToWalletID) == "" {
+		return &ValidationError{Field: "to_wallet_id", Message: "Destination wallet ID is required"}
+	}
+
+	if req.Amount == nil {
+		return &ValidationError{Field: "amount", Message: "Amount is required"}
+	}
+
+	if !req.Amount.IsPositive() {
+		return &ValidationError{Field: "amount", Message: "Amount must be greater than zero"}
+	}
+
+	if req.DestinationAmount != nil && !req.DestinationAmount.IsPositive() {
+		return &ValidationError{Field: "destination_amount", Message: "Destination amount must be greater than zero"}
+	}
+
+	if req.ExchangeRate != nil && !req.ExchangeRate.IsPositive() {
+		return &ValidationError{Field: "exchange_rate", Message: "Exchange rate must be greater than zero"}
+	}
+
+	if len(req.Description) > 500 {
+		return &ValidationError{Field: "description", Message: "Description must not exceed 500 characters"}
+	}
+
+	if req.Date == "" {
+		return &ValidationError{Field: "date", Message: "Date is required"}
+	}
+
+	return nil
+}
+
+func valueOrZero(value *shareddomain.Amount) shareddomain.Amount {
+	if value == nil {
+		return shareddomain.Amount{}
+	}
+	return *value
+}
+
+func validateTransactionRequest(req TransactionRequest) error {
+	if strings.TrimSpace(req.WalletID) == "" {
+		return &ValidationError{Field: "wallet_id", Message: "Wallet ID is required"}
+	}
+
+	if req.Type == nil {
+		return &ValidationError{Field: "type", Message: "Transaction type is required"}
+	}
+
+	if !isValidTransactionType(*req.Type) {
+		return &ValidationError{Field: "type", Message: "Transaction type must be 0 (Expense), 1 (Income), or 2 (Transfer)"}
+	}
+
+	if req.Amount == nil {
+		return &ValidationError{Field: "amount", Message: "Amount is required"}
+	}
+
+	if !req.Amount.IsPositive() {
+		return &ValidationError{Field: "amount", Message: "Amount must be greater than zero"}
+	}
+
+	if len(req.Description) > 500 {
+		return &ValidationError{Field: "description", Message: "Description must not exceed 500 characters"}
+	}
+
+	if len(req.Payee) > 255 {
+		return &ValidationError{Field: "payee", Message: "Payee must not exceed 255 characters"}
+	}
+
+	if err := validateTags(req.Tags); err != nil {
+		return err
+	}
+
+	if req.Date == "" {
+		return &ValidationError{Field: "date", Message: "Date is required"}
+	}
+
+	if req.Installments != nil && (*req.Installments < 1 || *req.Installments > domain.MaxInstallments) {
+		return &ValidationError{Field: "installments", Message: "Installments must be between 1 and " + strconv.Itoa(domain.MaxInstallments)}
+	}
+
+	return nil
+}
+
+func validateTags(tags []string) error {
+	if len(tags) > domain.MaxTags {
+		return &ValidationError{Field: "tags", Message: "A transaction can have at most " + strconv.Itoa(domain.MaxTags) + " tags"}
+	}
+
+	for _, tag := range tags {
+		if len(strings.TrimSpace(tag)) > domain.MaxTagLength {
+			return &ValidationError{Field: "tags", Message: "Tags must not exceed " + strconv.Itoa(domain.MaxTagLength) + " characters"}
+		}
+	}
+
+	return nil
+}
+
+func installments(req TransactionRequest) int {
+	if req.Installments == nil {
+		return 0
+	}
+	return *req.Installments
+}
+
+func isValidTransactionType(typeValue int) bool {
+	return typeValue >= 0 && typeValue <= 2
+}
+
+func transactionErrorResponse(err error, action string) (int, string) {
+	errorMsg := err.Error()
+
+	switch {
+	case strings.Contains(errorMsg, "user not authenticated"):
+		return http.StatusUnauthorized, "Authentication required"
+	case strings.Contains(errorMsg, "unauthorized access to transaction"):
+		return http.StatusForbidden, "You do not have permission to " + action + " this transaction"
+	case strings.Contains(errorMsg, "unauthorized access to wallet"):
+		return http.StatusForbidden, "You do not have permission to use this wallet"
+	case strings.Contains(errorMsg, "unauthorized access to category"):
+		return http.StatusForbidden, "You do not have permission to use this category"
+	case strings.Contains(errorMsg, "transaction not found"):
+		return http.StatusNotFound, "Transaction not found"
+	case strings.Contains(errorMsg, "wallet not found"), strings.Contains(errorMsg, "invalid wallet reference"):
+		return http.StatusBadRequest, "Wallet not found"
+	case strings.Contains(errorMsg, "category not found"), strings.Contains(errorMsg, "invalid category reference"):
+		return http.StatusBadRequest, "Category not found"
+	case strings.Contains(errorMsg, "invalid transaction type"):
+		return http.StatusBadRequest, "Invalid transaction type. Must be 0 (Expense), 1 (Income), or 2 (Transfer)"
+	case strings.Contains(errorMsg, "invalid amount"):
+		return http.StatusBadRequest, "Amount must be greater than zero"
+	case strings.Contains(errorMsg, "decimal places"):
+		return http.StatusBadRequest, "Amount has more decimal places than the wallet currency allows"
+	case strings.Contains(errorMsg, "transfers cannot be edited"):
+		return http.StatusConflict, "Transfers cannot be edited, delete and recreate them instead"
+	case strings.Contains(errorMsg, "installments cannot be edited"):
+		return http.StatusConflict, "Installments cannot be edited, delete the purchase and recreate it instead"
+	case strings.Contains(errorMsg, "loan payments cannot be edited"):
+		return http.StatusConflict, "Loan payments cannot be edited, delete the payment and record it again instead"
+	case strings.Contains(errorMsg, "of a reconciled transaction cannot be edited"):
+		return http.StatusConflict, "The wallet, type, amount and date of a reconciled transaction cannot be edited"
+	case strings.Contains(errorMsg, "reconciled transactions cannot be deleted"):
+		return http.StatusConflict, "Reconciled transactions cannot be deleted"
+	case strings.Contains(errorMsg, "installments must be between"):
+		return http.StatusBadRequest, "Installments must be between 2 and " + strconv.Itoa(domain.MaxInstallments)
+	case strings.Contains(errorMsg, "installments are only allowed"):
+		return http.StatusBadRequest, "Installments are only allowed for expenses on credit card wallets"
+	case strings.Contains(errorMsg, "category is required"),
+		strings.Contains(errorMsg, "category type does not match"),
+		strings.Contains(errorMsg, "filter or transaction ids are required"),
+		strings.Contains(errorMsg, "destination wallet"),
+		strings.Contains(errorMsg, "wallets must be different"),
+		strings.Contains(errorMsg, "exchange rate"):
+		return http.StatusBadRequest, errorMsg
+	}
+
+	return http.StatusInternalServerError, errorMsg
+}
+
+func toTransactionResponse(transaction *queries.TransactionResponse) TransactionResponse {
+	return TransactionResponse{
+		ID:                  transaction.ID,
+		WalletID:            transaction.WalletID,
+		CategoryID:          transaction.CategoryID,
+		Type:                transaction.Type,
+		TypeName:            transaction.TypeName,
+		Amount:              transaction.Amount,
+		Description:         transaction.Description,
+		Payee:               transaction.Payee,
+		Tags:                transaction.Tags,
+		Date:                transaction.Date.Format(dateLayout),
+		TransferID:          transaction.TransferID,
+		CounterpartWalletID: transaction.CounterpartWalletID,
+		ExchangeRate:        exchangeRate(transaction),
+		Direction:           transferDirection(transaction),
+		RecurringRuleID:     transaction.RecurringRuleID,
+		InstallmentPlanID:   transaction.InstallmentPlanID,
+		InstallmentNumber:   transaction.InstallmentNumber,
+		InstallmentCount:    transaction.InstallmentCount,
+		LoanID:              transaction.LoanID,
+		LoanInstallment:     transaction.LoanInstallment,
+		ImportBatchID:       transaction.ImportBatchID,
+		ExternalID:          transaction.ExternalID,
+		ValueDate:           valueDate(transaction),
+		ReconciliationID:    transaction.ReconciliationID,
+		Reconciled:          transaction.Reconciled,
+		CreatedAt:           transaction.CreatedAt,
+		UpdatedAt:           transaction.UpdatedAt,
+		CreatedBy:           transaction.CreatedBy,
+		UpdatedBy:           transaction.UpdatedBy,
+	}
+}
+
+// valueDate formats the value date of an imported transaction, which
+// most transactions do not have.
+func valueDate(transaction *queries.TransactionResponse) string {
+	if transaction.ValueDate == nil {
+		return ""
+	}
+	return transaction.ValueDate.Format(dateLayout)
+}
+
+// exchangeRate returns the rate applied to a transfer leg. Other
+// transactions have no rate, so it is omitted from the response.
+func exchangeRate(transaction *queries.TransactionResponse) *shareddomain.Amount {
+	if transaction.TransferID == "" {
+		return nil
+	}
+	rate := transaction.ExchangeRate
+	return &rate
+}
+
+func transferDirection(transaction *queries.TransactionResponse) string {
+	if transaction.TransferID == "" {
+		return ""
+	}
+	if transaction.Inbound {
+		return "in"
+	}
+	return "out"
+}
+
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,639 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"fin-flow-api/internal/modules/transactions/application/contracts/commands"
+	"fin-flow-api/internal/modules/transactions/application/contracts/queries"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+type mockTransactionService struct {
+	createErr        error
+	created          *queries.CreateTransactionResponse
+	getByIDErr       error
+	updateErr        error
+	deleteErr        error
+	listErr          error
+	recategorizeErr  error
+	transaction      *queries.TransactionResponse
+	transactions     []*queries.TransactionResponse
+	lastCommand      commands.TransactionRequest
+	lastTransfer     commands.TransferRequest
+	lastList         queries.ListTransactionsRequest
+	lastRecategorize commands.RecategorizeRequest
+}
+
+func newMockTransactionService() *mockTransactionService {
+	return &mockTransactionService{
+		transactions: []*queries.TransactionResponse{},
+	}
+}
+
+func (m *mockTransactionService) Create(ctx context.Context, req commands.TransactionRequest) (*queries.CreateTransactionResponse, error) {
+	m.lastCommand = req
+	if m.createErr != nil {
+		return nil, m.createErr
+	}
+	if m.created != nil {
+		return m.created, nil
+	}
+	return &queries.CreateTransactionResponse{ID: "new-transaction"}, nil
+}
+
+func (m *mockTransactionService) Transfer(ctx context.Context, req commands.TransferRequest) error {
+	m.lastTransfer = req
+	return m.createErr
+}
+
+func (m *mockTransactionService) GetByID(ctx context.Context, id string) (*queries.TransactionResponse, error) {
+	if m.getByIDErr != nil {
+		return nil, m.getByIDErr
+	}
+	return m.transaction, nil
+}
+
+func (m *mockTransactionService) Update(ctx context.Context, id string, req commands.TransactionRequest) error {
+	m.lastCommand = req
+	return m.updateErr
+}
+
+func (m *mockTransactionService) Delete(ctx context.Context, id string) error {
+	return m.deleteErr
+}
+
+func (m *mockTransactionService) List(ctx context.Context, req queries.ListTransactionsRequest) ([]*queries.TransactionResponse, error) {
+	m.lastList = req
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	return m.transactions, nil
+}
+
+func (m *mockTransactionService) Recategorize(ctx context.Context, req commands.RecategorizeRequest) (int, error) {
+	m.lastRecategorize = req
+	if m.recategorizeErr != nil {
+		return 0, m.recategorizeErr
+	}
+	return len(req.TransactionIDs), nil
+}
+
+func createContextWithUserID(userID string) context.Context {
+	ctx := context.Background()
+	return context.WithValue(ctx, middleware.UserIDKey, userID)
+}
+
+func validTransactionBody() TransactionRequest {
+	return TransactionRequest{
+		WalletID:    "wallet1",
+		CategoryID:  "category1",
+		Type:        intPtr(0),
+		Amount:      amountPtr("42.50"),
+		Description: "Groceries",
+		Date:        "2026-03-15",
+	}
+}
+
+func TestCreateTransaction_Success(t *testing.T) {
+	service := newMockTransactionService()
+	handler := &Handler{transactionService: service}
+
+	jsonBody, _ := json.Marshal(validTransactionBody())
+
+	req := httptest.NewRequest("POST", "/transactions", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(createContextWithUserID("user1"))
+
+	rr := httptest.NewRecorder()
+	handler.CreateTransaction(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rr.Code)
+	}
+
+	expectedDate := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+	if !service.lastCommand.Date.Equal(expectedDate) {
+		t.Errorf("expected date %v, got %v", expectedDate, service.lastCommand.Date)
+	}
+	if service.lastCommand.Amount.String() != "42.5" {
+		t.Errorf("expected amount 42.50, got %s", service.lastCommand.Amount)
+	}
+}
+
+func TestCreateTransaction_PossibleDuplicates(t *testing.T) {
+	service := newMockTransactionService()
+	service.created = &queries.CreateTransactionResponse{ID: "new-transaction", PossibleDuplicateIDs: []string{"imported-1"}}
+	handler := &Handler{transactionService: service}
+
+	jsonBody, _ := json.Marshal(validTransactionBody())
+
+	req := httptest.NewRequest("POST", "/transactions", bytes.NewBuffer(jsonBody))
+	req = req.WithContext(createContextWithUserID("user1"))
+
+	rr := httptest.NewRecorder()
+	handler.CreateTransaction(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+
+	var response CreateTransactionResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Message != "Transaction created successfully" || response.ID != "new-transaction" {
+		t.Errorf("unexpected response %+v", response)
+	}
+	if len(response.PossibleDuplicateIDs) != 1 || response.PossibleDuplicateIDs[0] != "imported-1" {
+		t.Errorf("expected the possible duplicate, got %v", response.PossibleDuplicateIDs)
+	}
+}
+
+func TestCreateTransaction_TransferAndInstallmentIDs(t *testing.T) {
+	service := newMockTransactionService()
+	service.created = &queries.CreateTransactionResponse{TransferID: "transfer-1"}
+	handler := &Handler{transactionService: service}
+
+	jsonBody, _ := json.Marshal(validTransactionBody())
+
+	req := httptest.NewRequest("POST", "/transactions", bytes.NewBuffer(jsonBody))
+	req = req.WithContext(createContextWithUserID("user1"))
+
+	rr := httptest.NewRecorder()
+	handler.CreateTransaction(rr, req)
+
+	var response map[string]any
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response["transfer_id"] != "transfer-1" {
+		t.Errorf("expected transfer_id transfer-1, got %v", response)
+	}
+	if _, ok := response["id"]; ok {
+		t.Errorf("expected no id for a transfer, got %v", response)
+	}
+	if _, ok := response["installment_plan_id"]; ok {
+		t.Errorf("expected no installment_plan_id for a transfer, got %v", response)
+	}
+}
+
+func TestCreateTransaction_PayeeAndTags(t *testing.T) {
+	body := validTransactionBody()
+	body.Payee = "  Corner Market "
+	body.Tags = []string{"weekly", " Weekly", "", "home"}
+
+	cmd, err := toTransactionCommand(body)
+	if err != nil {
+		t.Fatalf("toTransactionCommand failed: %v", err)
+	}
+
+	if cmd.Payee != "Corner Market" {
+		t.Errorf("expected a trimmed payee, got %q", cmd.Payee)
+	}
+	if strings.Join(cmd.Tags, ",") != "weekly,home" {
+		t.Errorf("expected normalized tags, got %v", cmd.Tags)
+	}
+}
+
+func TestCreateTransaction_InvalidMethod(t *testing.T) {
+	handler := &Handler{transactionService: newMockTransactionService()}
+
+	req := httptest.NewRequest("GET", "/transactions", nil)
+	rr := httptest.NewRecorder()
+	handler.CreateTransaction(rr, req)
+
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status 405, got %d", rr.Code)
+	}
+}
+
+func TestCreateTransaction_InvalidBody(t *testing.T) {
+	handler := &Handler{transactionService: newMockTransactionService()}
+
+	req := httptest.NewRequest("POST", "/transactions", bytes.NewBufferString("invalid json"))
+	rr := httptest.NewRecorder()
+	handler.CreateTransaction(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rr.Code)
+	}
+}
+
+func TestCreateTransaction_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*TransactionRequest)
+	}{
+		{"missing wallet", func(r *TransactionRequest) { r.WalletID = "" }},
+		{"missing type", func(r *TransactionRequest) { r.Type = nil }},
+		{"invalid type", func(r *TransactionRequest) { r.Type = intPtr(9) }},
+		{"missing amount", func(r *TransactionRequest) { r.Amount = nil }},
+		{"negative amount", func(r *TransactionRequest) { r.Amount = amountPtr("-1") }},
+		{"missing date", func(r *TransactionRequest) { r.Date = "" }},
+		{"invalid date", func(r *TransactionRequest) { r.Date = "15/03/2026" }},
+		{"long payee", func(r *TransactionRequest) { r.Payee = strings.Repeat("a", 256) }},
+		{"long tag", func(r *TransactionRequest) { r.Tags = []string{strings.Repeat("a", 51)} }},
+		{"too many tags", func(r *TransactionRequest) { r.Tags = make([]string, 21) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := validTransactionBody()
+			tt.mutate(&body)
+
+			if _, err := toTransactionCommand(body); err == nil {
+				t.Error("expected validation error, got nil")
+			}
+		})
+	}
+}
+
+func TestCreateTransaction_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"unauthenticated", errors.New("user not authenticated"), http.StatusUnauthorized},
+		{"foreign wallet", errors.New("unauthorized access to wallet"), http.StatusForbidden},
+		{"missing category", errors.New("category not found"), http.StatusBadRequest},
+		{"missing exchange rate", errors.New("exchange rate or destination amount is required for transfers between currencies"), http.StatusBadRequest},
+		{"transfer edit", errors.New("transfers cannot be edited, delete and recreate them instead"), http.StatusConflict},
+		{"unexpected", errors.New("boom"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newMockTransactionService()
+			service.createErr = tt.err
+			handler := &Handler{transactionService: service}
+
+			jsonBody, _ := json.Marshal(validTransactionBody())
+			req := httptest.NewRequest("POST", "/transactions", bytes.NewBuffer(jsonBody))
+			rr := httptest.NewRecorder()
+			handler.CreateTransaction(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestCreateTransfer_Success(t *testing.T) {
+	service := newMockTransactionService()
+	handler := &Handler{transactionService: service}
+
+	body := TransferRequest{
+		FromWalletID: "wallet-usd",
+		ToWalletID:   "wallet-ars",
+		Amount:       amountPtr("100"),
+		ExchangeRate: amountPtr("1200"),
+		Date:         "2026-03-15",
+	}
+	jsonBody, _ := json.Marshal(body)
+
+	req := httptest.NewRequest("POST", "/transfers", bytes.NewBuffer(jsonBody))
+	rr := httptest.NewRecorder()
+	handler.CreateTransfer(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if service.lastTransfer.ExchangeRate.String() != "1200" {
+		t.Errorf("expected exchange rate 1200, got %s", service.lastTransfer.ExchangeRate)
+	}
+	if service.lastTransfer.ToWalletID != "wallet-ars" {
+		t.Errorf("expected destination wallet-ars, got %s", service.lastTransfer.ToWalletID)
+	}
+}
+
+func TestCreateTransfer_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		body TransferRequest
+	}{
+		{"missing source", TransferRequest{ToWalletID: "w2", Amount: amountPtr("1"), Date: "2026-03-15"}},
+		{"missing destination", TransferRequest{FromWalletID: "w1", Amount: amountPtr("1"), Date: "2026-03-15"}},
+		{"missing amount", TransferRequest{FromWalletID: "w1", ToWalletID: "w2", Date: "2026-03-15"}},
+		{"negative rate", TransferRequest{FromWalletID: "w1", ToWalletID: "w2", Amount: amountPtr("1"), ExchangeRate: amountPtr("-1"), Date: "2026-03-15"}},
+		{"missing date", TransferRequest{FromWalletID: "w1", ToWalletID: "w2", Amount: amountPtr("1")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := toTransferCommand(tt.body); err == nil {
+				t.Error("expected validation error, got nil")
+			}
+		})
+	}
+}
+
+func TestCreateTransfer_MissingRate(t *testing.T) {
+	service := newMockTransactionService()
+	service.createErr = errors.New("exchange rate or destination amount is required for transfers between currencies")
+	handler := &Handler{transactionService: service}
+
+	body := TransferRequest{FromWalletID: "wallet-usd", ToWalletID: "wallet-ars", Amount: amountPtr("100"), Date: "2026-03-15"}
+	jsonBody, _ := json.Marshal(body)
+
+	req := httptest.NewRequest("POST", "/transfers", bytes.NewBuffer(jsonBody))
+	rr := httptest.NewRecorder()
+	handler.CreateTransfer(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rr.Code)
+	}
+}
+
+func TestGetTransaction_Success(t *testing.T) {
+	service := newMockTransactionService()
+	service.transaction = &queries.TransactionResponse{
+		ID:        "tx1",
+		WalletID:  "wallet1",
+		Type:      0,
+		TypeName:  "Expense",
+		Amount:    shareddomain.MustParseAmount("42.50"),
+		Date:      time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	handler := &Handler{transactionService: service}
+
+	req := httptest.NewRequest("GET", "/transactions/tx1", nil)
+	req = req.WithContext(createContextWithUserID("user1"))
+	rr := httptest.NewRecorder()
+	handler.GetTransaction(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rr.Code)
+	}
+
+	var response TransactionResponse
+	json.NewDecoder(rr.Body).Decode(&response)
+	if response.ID != "tx1" {
+		t.Errorf("expected ID 'tx1', got %s", response.ID)
+	}
+	if response.Date != "2026-03-15" {
+		t.Errorf("expected date 2026-03-15, got %s", response.Date)
+	}
+}
+
+func TestGetTransaction_EncodesAmountsAsStrings(t *testing.T) {
+	service := newMockTransactionService()
+	service.transaction = &queries.TransactionResponse{
+		ID:           "tx1",
+		WalletID:     "wallet-btc",
+		Type:         2,
+		TypeName:     "Transfer",
+		Amount:       shareddomain.MustParseAmount("0.00012345"),
+		Date:         time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC),
+		TransferID:   "transfer1",
+		ExchangeRate: shareddomain.MustParseAmount("0.0000155"),
+	}
+	handler := &Handler{transactionService: service}
+
+	req := httptest.NewRequest("GET", "/transactions/tx1", nil)
+	rr := httptest.NewRecorder()
+	handler.GetTransaction(rr, req)
+
+	var response map[string]interface{}
+	json.NewDecoder(rr.Body).Decode(&response)
+	if response["amount"] != "0.00012345" {
+		t.Errorf("expected amount \"0.00012345\", got %v", response["amount"])
+	}
+	if response["exchange_rate"] != "0.0000155" {
+		t.Errorf("expected exchange_rate \"0.0000155\", got %v", response["exchange_rate"])
+	}
+}
+
+func TestGetTransaction_OmitsExchangeRateOutsideTransfers(t *testing.T) {
+	service := newMockTransactionService()
+	service.transaction = &queries.TransactionResponse{
+		ID:       "tx1",
+		WalletID: "wallet1",
+		Type:     0,
+		TypeName: "Expense",
+		Amount:   shareddomain.MustParseAmount("42.50"),
+		Date:     time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC),
+	}
+	handler := &Handler{transactionService: service}
+
+	req := httptest.NewRequest("GET", "/transactions/tx1", nil)
+	rr := httptest.NewRecorder()
+	handler.GetTransaction(rr, req)
+
+	var response map[string]interface{}
+	json.NewDecoder(rr.Body).Decode(&response)
+	if _, ok := response["exchange_rate"]; ok {
+		t.Errorf("expected exchange_rate to be omitted, got %v", response["exchange_rate"])
+	}
+}
+
+func TestGetTransaction_NotFound(t *testing.T) {
+	service := newMockTransactionService()
+	service.getByIDErr = errors.New("transaction not found")
+	handler := &Handler{transactionService: service}
+
+	req := httptest.NewRequest("GET", "/transactions/missing", nil)
+	rr := httptest.NewRecorder()
+	handler.GetTransaction(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", rr.Code)
+	}
+}
+
+func TestGetTransaction_Forbidden(t *testing.T) {
+	service := newMockTransactionService()
+	service.getByIDErr = errors.New("unauthorized access to transaction")
+	handler := &Handler{transactionService: service}
+
+	req := httptest.NewRequest("GET", "/transactions/tx1", nil)
+	rr := httptest.NewRecorder()
+	handler.GetTransaction(rr, req)
+
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d", rr.Code)
+	}
+
+	var response map[string]string
+	json.NewDecoder(rr.Body).Decode(&response)
+	if response["error"] != "You do not have permission to access this transaction" {
+		t.Errorf("expected forbidden message, got %s", response["error"])
+	}
+}
+
+func TestUpdateTransaction_Success(t *testing.T) {
+	handler := &Handler{transactionService: newMockTransactionService()}
+
+	jsonBody, _ := json.Marshal(validTransactionBody())
+	req := httptest.NewRequest("PUT", "/transactions/tx1", bytes.NewBuffer(jsonBody))
+	rr := httptest.NewRecorder()
+	handler.UpdateTransaction(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rr.Code)
+	}
+}
+
+func TestDeleteTransaction_NotFound(t *testing.T) {
+	service := newMockTransactionService()
+	service.deleteErr = errors.New("transaction not found")
+	handler := &Handler{transactionService: service}
+
+	req := httptest.NewRequest("DELETE", "/transactions/tx1", nil)
+	rr := httptest.NewRecorder()
+	handler.DeleteTransaction(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", rr.Code)
+	}
+}
+
+func TestReconciledTransaction_Conflict(t *testing.T) {
+	service := newMockTransactionService()
+	service.updateErr = errors.New("the wallet, type, amount and date of a reconciled transaction cannot be edited")
+	service.deleteErr = errors.New("reconciled transactions cannot be deleted")
+	handler := &Handler{transactionService: service}
+
+	jsonBody, _ := json.Marshal(validTransactionBody())
+	req := httptest.NewRequest("PUT", "/transactions/tx1", bytes.NewBuffer(jsonBody))
+	rr := httptest.NewRecorder()
+	handler.UpdateTransaction(rr, req)
+
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409 on update, got %d", rr.Code)
+	}
+
+	req = httptest.NewRequest("DELETE", "/transactions/tx1", nil)
+	rr = httptest.NewRecorder()
+	handler.DeleteTransaction(rr, req)
+
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409 on delete, got %d", rr.Code)
+	}
+}
+
+func TestListTransactions_Filters(t *testing.T) {
+	service := newMockTransactionService()
+	service.transactions = []*queries.TransactionResponse{
+		{ID: "tx1", Date: time.Now()},
+	}
+	handler := &Handler{transactionService: service}
+
+	req := httptest.NewRequest("GET", "/transactions?wallet_id=wallet1&type=1&from=2026-01-01&to=2026-01-31", nil)
+	rr := httptest.NewRecorder()
+	handler.ListTransactions(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+
+	if service.lastList.WalletID != "wallet1" {
+		t.Errorf("expected wallet filter wallet1, got %s", service.lastList.WalletID)
+	}
+	if service.lastList.Type == nil || *service.lastList.Type != 1 {
+		t.Error("expected type filter 1")
+	}
+	if service.lastList.From == nil || service.lastList.To == nil {
+		t.Error("expected date range filter")
+	}
+
+	var response []TransactionResponse
+	json.NewDecoder(rr.Body).Decode(&response)
+	if len(response) != 1 {
+		t.Errorf("expected 1 transaction, got %d", len(response))
+	}
+}
+
+func TestListTransactions_InvalidDate(t *testing.T) {
+	handler := &Handler{transactionService: newMockTransactionService()}
+
+	req := httptest.NewRequest("GET", "/transactions?from=yesterday", nil)
+	rr := httptest.NewRecorder()
+	handler.ListTransactions(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rr.Code)
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func amountPtr(value string) *shareddomain.Amount {
+	amount := shareddomain.MustParseAmount(value)
+	return &amount
+}
+
+func TestRecategorizeTransactions_Success(t *testing.T) {
+	service := newMockTransactionService()
+	handler := &Handler{transactionService: service}
+
+	body := `{"category_id":"market","filter":{"category_id":"super","type":0,"from":"2026-01-01","to":"2026-03-31"},"transaction_ids":["tx-1","tx-2"]}`
+	req := httptest.NewRequest("POST", "/transactions/recategorize", bytes.NewBufferString(body))
+	req = req.WithContext(createContextWithUserID("user1"))
+	rr := httptest.NewRecorder()
+	handler.RecategorizeTransactions(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	cmd := service.lastRecategorize
+	if cmd.CategoryID != "market" || cmd.Filter.CategoryID != "super" || cmd.Filter.Type == nil || *cmd.Filter.Type != 0 {
+		t.Errorf("unexpected command %+v", cmd)
+	}
+	if cmd.Filter.From == nil || !cmd.Filter.From.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) || cmd.Filter.To == nil {
+		t.Errorf("expected the date range to be parsed, got %v and %v", cmd.Filter.From, cmd.Filter.To)
+	}
+
+	var response RecategorizeResponse
+	json.NewDecoder(rr.Body).Decode(&response)
+	if response.Updated != 2 {
+		t.Errorf("expected 2 updated transactions, got %d", response.Updated)
+	}
+}
+
+func TestRecategorizeTransactions_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{name: "missing category", body: `{"filter":{"wallet_id":"wallet1"}}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid date", body: `{"category_id":"market","filter":{"from":"01/01/2026"}}`, wantStatus: http.StatusBadRequest},
+		{name: "no filter", body: `{"category_id":"market"}`, err: errors.New("a filter or transaction ids are required to recategorize transactions"), wantStatus: http.StatusBadRequest},
+		{name: "type mismatch", body: `{"category_id":"salary","filter":{"wallet_id":"wallet1"}}`, err: errors.New("category type does not match transaction type"), wantStatus: http.StatusBadRequest},
+		{name: "forbidden category", body: `{"category_id":"other","filter":{"wallet_id":"wallet1"}}`, err: errors.New("unauthorized access to category"), wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newMockTransactionService()
+			service.recategorizeErr = tt.err
+			handler := &Handler{transactionService: service}
+
+			req := httptest.NewRequest("POST", "/transactions/recategorize", bytes.NewBufferString(tt.body))
+			req = req.WithContext(createContextWithUserID("user1"))
+			rr := httptest.NewRecorder()
+			handler.RecategorizeTransactions(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rr.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package http
+
+// RecategorizeRequest moves the transactions that match Filter, and are in
+// TransactionIDs when it is set, to CategoryID.
+type RecategorizeRequest struct {
+	CategoryID     string             `json:"category_id"`
+	Filter         RecategorizeFilter `json:"filter"`
+	TransactionIDs []string           `json:"transaction_ids"`
+}
+
+// RecategorizeFilter takes the same criteria as the transaction list.
+type RecategorizeFilter struct {
+	WalletID   string `json:"wallet_id"`
+	CategoryID string `json:"category_id"`
+	Type       *int   `json:"type"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+}
@@ -0,0 +1,57 @@
+package http
+
+import (
+	"net/http"
+
+	"fin-flow-api/internal/shared/interface/jwt"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+var transactionHandler *Handler
+
+func SetupRoutes(mux *http.ServeMux, jwtService jwt.Service) {
+	mountTransactions(mux, jwtService)
+}
+
+func mountTransactions(mux *http.ServeMux, jwtService jwt.Service) {
+	mux.HandleFunc("/transactions", handleTransactionsCollection(jwtService))
+	mux.Handle("/transfers", middleware.RequireAuth(jwtService)(http.HandlerFunc(transactionHandler.CreateTransfer)))
+
+	protectedHandler := middleware.RequireAuth(jwtService)(http.HandlerFunc(handleTransactionsResource))
+	mux.Handle("/transactions/", protectedHandler)
+}
+
+func handleTransactionsCollection(jwtService jwt.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			middleware.RequireAuth(jwtService)(http.HandlerFunc(transactionHandler.ListTransactions)).ServeHTTP(w, r)
+		case http.MethodPost:
+			middleware.RequireAuth(jwtService)(http.HandlerFunc(transactionHandler.CreateTransaction)).ServeHTTP(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func handleTransactionsResource(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/transactions/recategorize" {
+		transactionHandler.RecategorizeTransactions(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		transactionHandler.GetTransaction(w, r)
+	case http.MethodPut:
+		transactionHandler.UpdateTransaction(w, r)
+	case http.MethodDelete:
+		transactionHandler.DeleteTransaction(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func SetHandler(handler *Handler) {
+	transactionHandler = handler
+}
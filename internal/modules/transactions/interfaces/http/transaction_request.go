@@ -0,0 +1,19 @@
+package http
+
+import shareddomain "fin-flow-api/internal/shared/domain"
+
+type TransactionRequest struct {
+	WalletID            string               `json:"wallet_id"`
+	DestinationWalletID string               `json:"destination_wallet_id"`
+	CategoryID          string               `json:"category_id"`
+	Type                *int                 `json:"type"`
+	Amount              *shareddomain.Amount `json:"amount"`
+	DestinationAmount   *shareddomain.Amount `json:"destination_amount"`
+	ExchangeRate        *shareddomain.Amount `json:"exchange_rate"`
+	Description         string               `json:"description"`
+	Payee               string               `json:"payee"`
+	Tags                []string             `json:"tags"`
+	Date                string               `json:"date"`
+	// Installments splits a credit card expense into monthly installments.
+	Installments *int `json:"installments"`
+}
@@ -0,0 +1,54 @@
+package http
+
+import (
+	"time"
+
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+type TransactionResponse struct {
+	ID                  string               `json:"id"`
+	WalletID            string               `json:"wallet_id"`
+	CategoryID          string               `json:"category_id,omitempty"`
+	Type                int                  `json:"type"`
+	TypeName            string               `json:"type_name"`
+	Amount              shareddomain.Amount  `json:"amount"`
+	Description         string               `json:"description"`
+	Payee               string               `json:"payee,omitempty"`
+	Tags                []string             `json:"tags,omitempty"`
+	Date                string               `json:"date"`
+	TransferID          string               `json:"transfer_id,omitempty"`
+	CounterpartWalletID string               `json:"counterpart_wallet_id,omitempty"`
+	ExchangeRate        *shareddomain.Amount `json:"exchange_rate,omitempty"`
+	Direction           string               `json:"direction,omitempty"`
+	RecurringRuleID     string               `json:"recurring_rule_id,omitempty"`
+	InstallmentPlanID   string               `json:"installment_plan_id,omitempty"`
+	InstallmentNumber   int                  `json:"installment_number,omitempty"`
+	InstallmentCount    int                  `json:"installment_count,omitempty"`
+	LoanID              string               `json:"loan_id,omitempty"`
+	LoanInstallment     int                  `json:"loan_installment,omitempty"`
+	ImportBatchID       string               `json:"import_batch_id,omitempty"`
+	ExternalID          string               `json:"external_id,omitempty"`
+	ValueDate           string               `json:"value_date,omitempty"`
+	ReconciliationID    string               `json:"reconciliation_id,omitempty"`
+	Reconciled          bool                 `json:"reconciled"`
+	CreatedAt           time.Time            `json:"created_at"`
+	UpdatedAt           time.Time            `json:"updated_at"`
+	CreatedBy           string               `json:"created_by"`
+	UpdatedBy           string               `json:"updated_by"`
+}
+
+// CreateTransactionResponse carries the id of a new expense or income and
+// of the existing transactions it is likely a duplicate of, or the id of a
+// new transfer or installment plan.
+type CreateTransactionResponse struct {
+	Message              string   `json:"message"`
+	ID                   string   `json:"id,omitempty"`
+	TransferID           string   `json:"transfer_id,omitempty"`
+	InstallmentPlanID    string   `json:"installment_plan_id,omitempty"`
+	PossibleDuplicateIDs []string `json:"possible_duplicate_ids,omitempty"`
+}
+
+type RecategorizeResponse struct {
+	Updated int `json:"updated"`
+}
@@ -0,0 +1,14 @@
+package http
+
+import shareddomain "fin-flow-api/internal/shared/domain"
+
+type TransferRequest struct {
+	FromWalletID      string               `json:"from_wallet_id"`
+	ToWalletID        string               `json:"to_wallet_id"`
+	CategoryID        string               `json:"category_id"`
+	Amount            *shareddomain.Amount `json:"amount"`
+	DestinationAmount *shareddomain.Amount `json:"destination_amount"`
+	ExchangeRate      *shareddomain.Amount `json:"exchange_rate"`
+	Description       string               `json:"description"`
+	Date              string               `json:"date"`
+}
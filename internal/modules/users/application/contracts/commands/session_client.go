@@ -0,0 +1,8 @@
+package commands
+
+// SessionClient describes the device a session is started or refreshed
+// from.
+type SessionClient struct {
+	UserAgent string
+	IPAddress string
+}
@@ -0,0 +1,20 @@
+package queries
+
+import "time"
+
+type SessionResponse struct {
+	ID         string
+	UserAgent  string
+	IPAddress  string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+}
+
+// RefreshTokenResponse carries a newly issued refresh token. Token is the
+// only copy of the opaque value; just its hash is stored.
+type RefreshTokenResponse struct {
+	UserID    string
+	SessionID string
+	Token     string
+	ExpiresAt time.Time
+}
@@ -7,6 +7,7 @@ type UserResponse struct {
 	FirstName string
 	LastName  string
 	Email     string
+	Role      string
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	CreatedBy string
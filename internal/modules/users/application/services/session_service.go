@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"fin-flow-api/internal/modules/users/application/contracts/commands"
+	"fin-flow-api/internal/modules/users/application/contracts/queries"
+	"fin-flow-api/internal/modules/users/domain"
+	"fin-flow-api/internal/shared/middleware"
+
+	"github.com/google/uuid"
+)
+
+const refreshTokenBytes = 32
+
+type SessionService struct {
+	repository domain.SessionRepository
+	refreshTTL time.Duration
+	now        func() time.Time
+}
+
+func NewSessionService(repository domain.SessionRepository, refreshTTL time.Duration) *SessionService {
+	return &SessionService{
+		repository: repository,
+		refreshTTL: refreshTTL,
+		now:        time.Now,
+	}
+}
+
+// Start opens a session for a user who just signed in and returns its
+// first refresh token.
+func (s *SessionService) Start(userID string, client commands.SessionClient) (*queries.RefreshTokenResponse, error) {
+	now := s.now().UTC()
+	session := domain.NewSession(uuid.New().String(), userID, client.UserAgent, client.IPAddress, now)
+
+	raw, token, err := s.issue(session.ID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Create(session, token); err != nil {
+		return nil, err
+	}
+
+	return toRefreshTokenResponse(session, raw, token), nil
+}
+
+// Refresh exchanges a refresh token for the next one in its family. A
+// token that was already exchanged means it has been copied, so the whole
+// session is revoked and both holders have to sign in again.
+func (s *SessionService) Refresh(refreshToken string, client commands.SessionClient) (*queries.RefreshTokenResponse, error) {
+	session, current, err := s.lookup(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	if session.IsRevoked() || current.IsExpired(now) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	if current.IsUsed() {
+		return nil, s.revokeForReuse(session, now)
+	}
+
+	raw, next, err := s.issue(session.ID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	session.LastUsedAt = now
+	session.UserAgent = client.UserAgent
+	session.IPAddress = client.IPAddress
+
+	if err := s.repository.Rotate(current, next, session); err != nil {
+		if errors.Is(err, domain.ErrRefreshTokenReused) {
+			return nil, s.revokeForReuse(session, now)
+		}
+		return nil, err
+	}
+
+	return toRefreshTokenResponse(session, raw, next), nil
+}
+
+// Logout revokes the session the refresh token belongs to.
+func (s *SessionService) Logout(refreshToken string) error {
+	session, _, err := s.lookup(refreshToken)
+	if err != nil {
+		return err
+	}
+	if session.IsRevoked() {
+		return nil
+	}
+	return s.repository.Revoke(session.ID, domain.RevokedByLogout, s.now().UTC())
+}
+
+// List returns the signed-in user's sessions that have not been revoked.
+func (s *SessionService) List(ctx context.Context) ([]*queries.SessionResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.repository.ListActive(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*queries.SessionResponse, len(sessions))
+	for i, session := range sessions {
+		responses[i] = toSessionResponse(session)
+	}
+
+	return responses, nil
+}
+
+// Revoke signs one of the user's sessions out.
+func (s *SessionService) Revoke(ctx context.Context, id string) error {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	session, err := s.repository.GetByID(id, userID)
+	if err != nil {
+		return err
+	}
+	if session.IsRevoked() {
+		return nil
+	}
+
+	return s.repository.Revoke(session.ID, domain.RevokedByUser, s.now().UTC())
+}
+
+func (s *SessionService) getUserIDFromContext(ctx context.Context) (string, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return "", errors.New("user not authenticated")
+	}
+	return userID, nil
+}
+
+func (s *SessionService) lookup(refreshToken string) (*domain.Session, *domain.RefreshToken, error) {
+	if refreshToken == "" {
+		return nil, nil, domain.ErrInvalidRefreshToken
+	}
+
+	session, token, err := s.repository.GetByTokenHash(hashRefreshToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return nil, nil, domain.ErrInvalidRefreshToken
+		}
+		return nil, nil, err
+	}
+
+	return session, token, nil
+}
+
+func (s *SessionService) issue(sessionID string, now time.Time) (string, *domain.RefreshToken, error) {
+	buf := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", nil, err
+	}
+	raw := base64.RawURLEncoding.EncodeToString(buf)
+
+	token := domain.NewRefreshToken(uuid.New().String(), sessionID, hashRefreshToken(raw), now, s.refreshTTL)
+	return raw, token, nil
+}
+
+func (s *SessionService) revokeForReuse(session *domain.Session, now time.Time) error {
+	if !session.IsRevoked() {
+		if err := s.repository.Revoke(session.ID, domain.RevokedByReuse, now); err != nil {
+			return err
+		}
+	}
+	return domain.ErrRefreshTokenReused
+}
+
+// hashRefreshToken uses a plain SHA-256: refresh tokens are 256 random
+// bits, so unlike passwords they need no slow hash.
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func toRefreshTokenResponse(session *domain.Session, raw string, token *domain.RefreshToken) *queries.RefreshTokenResponse {
+	return &queries.RefreshTokenResponse{
+		UserID:    session.UserID,
+		SessionID: session.ID,
+		Token:     raw,
+		ExpiresAt: token.ExpiresAt,
+	}
+}
+
+func toSessionResponse(session *domain.Session) *queries.SessionResponse {
+	return &queries.SessionResponse{
+		ID:         session.ID,
+		UserAgent:  session.UserAgent,
+		IPAddress:  session.IPAddress,
+		CreatedAt:  session.CreatedAt,
+		LastUsedAt: session.LastUsedAt,
+	}
+}
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"fin-flow-api/internal/modules/users/application/contracts/commands"
+	"fin-flow-api/internal/modules/users/domain"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+type mockSessionRepository struct {
+	sessions  map[string]*domain.Session
+	tokens    map[string]*domain.RefreshToken
+	rotateErr error
+}
+
+func newMockSessionRepository() *mockSessionRepository {
+	return &mockSessionRepository{
+		sessions: make(map[string]*domain.Session),
+		tokens:   make(map[string]*domain.RefreshToken),
+	}
+}
+
+func (m *mockSessionRepository) Create(session *domain.Session, token *domain.RefreshToken) error {
+	m.sessions[session.ID] = session
+	m.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (m *mockSessionRepository) GetByTokenHash(tokenHash string) (*domain.Session, *domain.RefreshToken, error) {
+	token, exists := m.tokens[tokenHash]
+	if !exists {
+		return nil, nil, domain.ErrSessionNotFound
+	}
+	return m.sessions[token.SessionID], token, nil
+}
+
+func (m *mockSessionRepository) Rotate(current *domain.RefreshToken, next *domain.RefreshToken, session *domain.Session) error {
+	if m.rotateErr != nil {
+		return m.rotateErr
+	}
+	usedAt := next.CreatedAt
+	current.UsedAt = &usedAt
+	m.tokens[next.TokenHash] = next
+	m.sessions[session.ID] = session
+	return nil
+}
+
+func (m *mockSessionRepository) GetByID(id string, userID string) (*domain.Session, error) {
+	session, exists := m.sessions[id]
+	if !exists {
+		return nil, domain.ErrSessionNotFound
+	}
+	if session.UserID != userID {
+		return nil, fmt.Errorf("unauthorized access to session")
+	}
+	return session, nil
+}
+
+func (m *mockSessionRepository) ListActive(userID string) ([]*domain.Session, error) {
+	var sessions []*domain.Session
+	for _, session := range m.sessions {
+		if session.UserID == userID && !session.IsRevoked() {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions, nil
+}
+
+func (m *mockSessionRepository) Revoke(id string, reason string, at time.Time) error {
+	session, exists := m.sessions[id]
+	if !exists {
+		return domain.ErrSessionNotFound
+	}
+	session.RevokedAt = &at
+	session.RevokedReason = reason
+	return nil
+}
+
+func newTestSessionService(repo *mockSessionRepository, now *time.Time) *SessionService {
+	service := NewSessionService(repo, time.Hour)
+	service.now = func() time.Time { return *now }
+	return service
+}
+
+func withUserID(userID string) context.Context {
+	return context.WithValue(context.Background(), middleware.UserIDKey, userID)
+}
+
+func TestSessionService_StartStoresOnlyTheHash(t *testing.T) {
+	repo := newMockSessionRepository()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	service := newTestSessionService(repo, &now)
+
+	response, err := service.Start("user-1", commands.SessionClient{UserAgent: "Firefox", IPAddress: "203.0.113.7"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if response.Token == "" {
+		t.Fatal("expected a refresh token")
+	}
+	if _, exists := repo.tokens[response.Token]; exists {
+		t.Error("expected the raw refresh token not to be stored")
+	}
+	if _, exists := repo.tokens[hashRefreshToken(response.Token)]; !exists {
+		t.Error("expected the refresh token hash to be stored")
+	}
+	if !response.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("expected expiry %v, got %v", now.Add(time.Hour), response.ExpiresAt)
+	}
+
+	session := repo.sessions[response.SessionID]
+	if session == nil || session.UserAgent != "Firefox" || session.IPAddress != "203.0.113.7" {
+		t.Errorf("expected session with client details, got %+v", session)
+	}
+}
+
+func TestSessionService_RefreshRotatesToken(t *testing.T) {
+	repo := newMockSessionRepository()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	service := newTestSessionService(repo, &now)
+
+	first, _ := service.Start("user-1", commands.SessionClient{UserAgent: "Firefox"})
+
+	now = now.Add(10 * time.Minute)
+	second, err := service.Refresh(first.Token, commands.SessionClient{UserAgent: "Chrome", IPAddress: "198.51.100.1"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if second.Token == first.Token {
+		t.Error("expected a new refresh token")
+	}
+	if second.SessionID != first.SessionID {
+		t.Errorf("expected session %s, got %s", first.SessionID, second.SessionID)
+	}
+	if second.UserID != "user-1" {
+		t.Errorf("expected user-1, got %s", second.UserID)
+	}
+
+	session := repo.sessions[first.SessionID]
+	if !session.LastUsedAt.Equal(now) {
+		t.Errorf("expected last used %v, got %v", now, session.LastUsedAt)
+	}
+	if session.UserAgent != "Chrome" {
+		t.Errorf("expected user agent Chrome, got %s", session.UserAgent)
+	}
+}
+
+func TestSessionService_ReuseRevokesSession(t *testing.T) {
+	repo := newMockSessionRepository()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	service := newTestSessionService(repo, &now)
+
+	first, _ := service.Start("user-1", commands.SessionClient{})
+	second, _ := service.Refresh(first.Token, commands.SessionClient{})
+
+	_, err := service.Refresh(first.Token, commands.SessionClient{})
+	if !errors.Is(err, domain.ErrRefreshTokenReused) {
+		t.Fatalf("expected ErrRefreshTokenReused, got %v", err)
+	}
+
+	session := repo.sessions[first.SessionID]
+	if !session.IsRevoked() || session.RevokedReason != domain.RevokedByReuse {
+		t.Errorf("expected session revoked for reuse, got %+v", session)
+	}
+
+	if _, err := service.Refresh(second.Token, commands.SessionClient{}); !errors.Is(err, domain.ErrInvalidRefreshToken) {
+		t.Errorf("expected the rest of the family to be invalid, got %v", err)
+	}
+}
+
+func TestSessionService_ConcurrentRotationIsReuse(t *testing.T) {
+	repo := newMockSessionRepository()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	service := newTestSessionService(repo, &now)
+
+	first, _ := service.Start("user-1", commands.SessionClient{})
+	repo.rotateErr = domain.ErrRefreshTokenReused
+
+	_, err := service.Refresh(first.Token, commands.SessionClient{})
+	if !errors.Is(err, domain.ErrRefreshTokenReused) {
+		t.Fatalf("expected ErrRefreshTokenReused, got %v", err)
+	}
+	if !repo.sessions[first.SessionID].IsRevoked() {
+		t.Error("expected session to be revoked")
+	}
+}
+
+func TestSessionService_RefreshRejectsInvalidTokens(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(service *SessionService, now *time.Time) string
+	}{
+		{
+			name:  "empty",
+			setup: func(service *SessionService, now *time.Time) string { return "" },
+		},
+		{
+			name:  "unknown",
+			setup: func(service *SessionService, now *time.Time) string { return "not-a-token" },
+		},
+		{
+			name: "expired",
+			setup: func(service *SessionService, now *time.Time) string {
+				response, _ := service.Start("user-1", commands.SessionClient{})
+				*now = now.Add(time.Hour)
+				return response.Token
+			},
+		},
+		{
+			name: "logged out",
+			setup: func(service *SessionService, now *time.Time) string {
+				response, _ := service.Start("user-1", commands.SessionClient{})
+				service.Logout(response.Token)
+				return response.Token
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockSessionRepository()
+			now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+			service := newTestSessionService(repo, &now)
+
+			token := tt.setup(service, &now)
+
+			_, err := service.Refresh(token, commands.SessionClient{})
+			if !errors.Is(err, domain.ErrInvalidRefreshToken) {
+				t.Errorf("expected ErrInvalidRefreshToken, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSessionService_Logout(t *testing.T) {
+	repo := newMockSessionRepository()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	service := newTestSessionService(repo, &now)
+
+	response, _ := service.Start("user-1", commands.SessionClient{})
+
+	if err := service.Logout(response.Token); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	session := repo.sessions[response.SessionID]
+	if !session.IsRevoked() || session.RevokedReason != domain.RevokedByLogout {
+		t.Errorf("expected session revoked by logout, got %+v", session)
+	}
+
+	if err := service.Logout(response.Token); err != nil {
+		t.Errorf("expected logging out twice to succeed, got %v", err)
+	}
+}
+
+func TestSessionService_ListAndRevoke(t *testing.T) {
+	repo := newMockSessionRepository()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	service := newTestSessionService(repo, &now)
+
+	mine, _ := service.Start("user-1", commands.SessionClient{UserAgent: "Firefox"})
+	theirs, _ := service.Start("user-2", commands.SessionClient{UserAgent: "Chrome"})
+
+	if _, err := service.List(context.Background()); err == nil {
+		t.Error("expected error without an authenticated user")
+	}
+
+	sessions, err := service.List(withUserID("user-1"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].ID != mine.SessionID {
+		t.Fatalf("expected only the user's session, got %+v", sessions)
+	}
+
+	err = service.Revoke(withUserID("user-1"), theirs.SessionID)
+	if err == nil || err.Error() != "unauthorized access to session" {
+		t.Errorf("expected unauthorized access error, got %v", err)
+	}
+	if repo.sessions[theirs.SessionID].IsRevoked() {
+		t.Error("expected the other user's session to stay active")
+	}
+
+	if err := service.Revoke(withUserID("user-1"), mine.SessionID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if repo.sessions[mine.SessionID].RevokedReason != domain.RevokedByUser {
+		t.Errorf("expected reason %s, got %s", domain.RevokedByUser, repo.sessions[mine.SessionID].RevokedReason)
+	}
+
+	sessions, _ = service.List(withUserID("user-1"))
+	if len(sessions) != 0 {
+		t.Errorf("expected no active sessions, got %d", len(sessions))
+	}
+}
@@ -1,24 +1,37 @@
 package services
 
 import (
+	"errors"
+	"log"
+
 	"fin-flow-api/internal/modules/users/application/contracts/commands"
 	"fin-flow-api/internal/modules/users/application/contracts/queries"
 	"fin-flow-api/internal/modules/users/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
 	"fin-flow-api/internal/shared/interface/hash"
 
 	"github.com/google/uuid"
 )
 
+// categorySeeder gives a new user its starting categories.
+type categorySeeder interface {
+	SeedCategories(userID string) error
+}
+
 type UserService struct {
 	repository domain.UserRepository
 	hashService hash.Service
+	categorySeeder categorySeeder
 	systemUser  string
 }
 
-func NewUserService(repository domain.UserRepository, hashService hash.Service, systemUser string) *UserService {
+// NewUserService returns the user service. categorySeeder may be nil, in
+// which case new users start without categories.
+func NewUserService(repository domain.UserRepository, hashService hash.Service, categorySeeder categorySeeder, systemUser string) *UserService {
 	return &UserService{
 		repository: repository,
 		hashService: hashService,
+		categorySeeder: categorySeeder,
 		systemUser: systemUser,
 	}
 }
@@ -40,7 +53,13 @@ func (s *UserService) Create(req commands.CreateUserRequest) error {
 		s.systemUser,
 	)
 
-	return s.repository.Create(user)
+	if err := s.repository.Create(user); err != nil {
+		return err
+	}
+
+	s.seedCategories(user.ID)
+
+	return nil
 }
 
 func (s *UserService) Update(userID string, req commands.UpdateUserRequest) error {
@@ -66,6 +85,28 @@ func (s *UserService) Update(userID string, req commands.UpdateUserRequest) erro
 	return s.repository.Update(user)
 }
 
+// AssignRole changes another user's role. Admins cannot change their own
+// role, so the last admin cannot lock everyone out by accident.
+func (s *UserService) AssignRole(actorID string, userID string, role string) error {
+	newRole := shareddomain.Role(role)
+	if !newRole.IsValid() {
+		return errors.New("invalid role")
+	}
+	if actorID == userID {
+		return errors.New("cannot change your own role")
+	}
+
+	user, err := s.repository.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	user.Role = newRole
+	user.Entity.UpdateModified(actorID)
+
+	return s.repository.Update(user)
+}
+
 func (s *UserService) Delete(userID string) error {
 	return s.repository.Delete(userID)
 }
@@ -81,6 +122,7 @@ func (s *UserService) GetByID(userID string) (*queries.UserResponse, error) {
 		FirstName: user.FirstName,
 		LastName:  user.LastName,
 		Email:     user.Email,
+		Role:      string(user.Role),
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.ModifiedAt,
 		CreatedBy: user.CreatedBy,
@@ -101,6 +143,7 @@ func (s *UserService) List() ([]*queries.UserResponse, error) {
 			FirstName: user.FirstName,
 			LastName:  user.LastName,
 			Email:     user.Email,
+			Role:      string(user.Role),
 			CreatedAt: user.CreatedAt,
 			UpdatedAt: user.ModifiedAt,
 			CreatedBy: user.CreatedBy,
@@ -129,12 +172,15 @@ func (s *UserService) SyncByAuthID(authID, firstName, lastName, email string) (*
 			if createErr := s.repository.Create(newUser); createErr != nil {
 				return nil, createErr
 			}
+
+			s.seedCategories(newUser.ID)
 			
 			return &queries.UserResponse{
 				ID:        newUser.ID,
 				FirstName: newUser.FirstName,
 				LastName:  newUser.LastName,
 				Email:     newUser.Email,
+				Role:      string(newUser.Role),
 				CreatedAt: newUser.CreatedAt,
 				UpdatedAt: newUser.ModifiedAt,
 				CreatedBy: newUser.CreatedBy,
@@ -149,9 +195,22 @@ func (s *UserService) SyncByAuthID(authID, firstName, lastName, email string) (*
 		FirstName: user.FirstName,
 		LastName:  user.LastName,
 		Email:     user.Email,
+		Role:      string(user.Role),
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.ModifiedAt,
 		CreatedBy: user.CreatedBy,
 		UpdatedBy: user.ModifiedBy,
 	}, nil
-}
\ No newline at end of file
+}
+
+// seedCategories gives a new user the default categories. The user exists by
+// then, so a failure is logged rather than failing the sign-up; the
+// categories can still be added later from a template.
+func (s *UserService) seedCategories(userID string) {
+	if s.categorySeeder == nil {
+		return
+	}
+	if err := s.categorySeeder.SeedCategories(userID); err != nil {
+		log.Printf("failed to seed categories for user %s: %v", userID, err)
+	}
+}
@@ -122,12 +122,22 @@ func (m *mockHashService) Verify(password, hash string) bool {
 	return true
 }
 
+type mockCategorySeeder struct {
+	seeded []string
+	err    error
+}
+
+func (m *mockCategorySeeder) SeedCategories(userID string) error {
+	m.seeded = append(m.seeded, userID)
+	return m.err
+}
+
 func TestNewUserService(t *testing.T) {
 	repo := newMockRepository()
 	hashService := newMockHashService()
 	systemUser := "system"
 
-	service := NewUserService(repo, hashService, systemUser)
+	service := NewUserService(repo, hashService, nil, systemUser)
 	if service == nil {
 		t.Fatal("NewUserService returned nil")
 	}
@@ -136,7 +146,7 @@ func TestNewUserService(t *testing.T) {
 func TestUserService_Create(t *testing.T) {
 	repo := newMockRepository()
 	hashService := newMockHashService()
-	service := NewUserService(repo, hashService, "system")
+	service := NewUserService(repo, hashService, nil, "system")
 
 	req := commands.CreateUserRequest{
 		FirstName: "John",
@@ -180,7 +190,7 @@ func TestUserService_Create_HashError(t *testing.T) {
 			return "", errors.New("hash error")
 		},
 	}
-	service := NewUserService(repo, hashService, "system")
+	service := NewUserService(repo, hashService, nil, "system")
 
 	req := commands.CreateUserRequest{
 		FirstName: "John",
@@ -195,10 +205,69 @@ func TestUserService_Create_HashError(t *testing.T) {
 	}
 }
 
+func TestUserService_Create_SeedsCategories(t *testing.T) {
+	repo := newMockRepository()
+	seeder := &mockCategorySeeder{}
+	service := NewUserService(repo, newMockHashService(), seeder, "system")
+
+	req := commands.CreateUserRequest{
+		FirstName: "John",
+		LastName:  "Doe",
+		Email:     "john@example.com",
+		Password:  "password123",
+	}
+
+	if err := service.Create(req); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if len(seeder.seeded) != 1 || repo.users[seeder.seeded[0]] == nil {
+		t.Errorf("expected the new user to be seeded, got %v", seeder.seeded)
+	}
+}
+
+func TestUserService_Create_SeedError(t *testing.T) {
+	repo := newMockRepository()
+	seeder := &mockCategorySeeder{err: errors.New("failed to create categories")}
+	service := NewUserService(repo, newMockHashService(), seeder, "system")
+
+	req := commands.CreateUserRequest{
+		FirstName: "John",
+		LastName:  "Doe",
+		Email:     "john@example.com",
+		Password:  "password123",
+	}
+
+	if err := service.Create(req); err != nil {
+		t.Fatalf("expected seeding errors not to fail Create, got %v", err)
+	}
+	if len(repo.users) != 1 {
+		t.Errorf("expected 1 user, got %d", len(repo.users))
+	}
+}
+
+func TestUserService_SyncByAuthID_SeedsNewUsersOnly(t *testing.T) {
+	repo := newMockRepository()
+	seeder := &mockCategorySeeder{}
+	service := NewUserService(repo, newMockHashService(), seeder, "system")
+
+	user, err := service.SyncByAuthID("auth-1", "John", "Doe", "john@example.com")
+	if err != nil {
+		t.Fatalf("SyncByAuthID failed: %v", err)
+	}
+	if _, err := service.SyncByAuthID("auth-1", "John", "Doe", "john@example.com"); err != nil {
+		t.Fatalf("SyncByAuthID failed: %v", err)
+	}
+
+	if len(seeder.seeded) != 1 || seeder.seeded[0] != user.ID {
+		t.Errorf("expected only the first sync to seed, got %v", seeder.seeded)
+	}
+}
+
 func TestUserService_GetByID(t *testing.T) {
 	repo := newMockRepository()
 	hashService := newMockHashService()
-	service := NewUserService(repo, hashService, "system")
+	service := NewUserService(repo, hashService, nil, "system")
 
 	user := domain.NewUser("user-1", "John", "Doe", "john@example.com", "hashed", "system")
 	repo.users["user-1"] = user
@@ -220,7 +289,7 @@ func TestUserService_GetByID(t *testing.T) {
 func TestUserService_GetByID_NotFound(t *testing.T) {
 	repo := newMockRepository()
 	hashService := newMockHashService()
-	service := NewUserService(repo, hashService, "system")
+	service := NewUserService(repo, hashService, nil, "system")
 
 	_, err := service.GetByID("nonexistent")
 	if err == nil {
@@ -231,7 +300,7 @@ func TestUserService_GetByID_NotFound(t *testing.T) {
 func TestUserService_Update(t *testing.T) {
 	repo := newMockRepository()
 	hashService := newMockHashService()
-	service := NewUserService(repo, hashService, "admin")
+	service := NewUserService(repo, hashService, nil, "admin")
 
 	user := domain.NewUser("user-1", "John", "Doe", "john@example.com", "hashed", "system")
 	repo.users["user-1"] = user
@@ -264,7 +333,7 @@ func TestUserService_Update(t *testing.T) {
 func TestUserService_Update_NotFound(t *testing.T) {
 	repo := newMockRepository()
 	hashService := newMockHashService()
-	service := NewUserService(repo, hashService, "system")
+	service := NewUserService(repo, hashService, nil, "system")
 
 	req := commands.UpdateUserRequest{
 		FirstName: "Jane",
@@ -281,7 +350,7 @@ func TestUserService_Update_NotFound(t *testing.T) {
 func TestUserService_Delete(t *testing.T) {
 	repo := newMockRepository()
 	hashService := newMockHashService()
-	service := NewUserService(repo, hashService, "system")
+	service := NewUserService(repo, hashService, nil, "system")
 
 	user := domain.NewUser("user-1", "John", "Doe", "john@example.com", "hashed", "system")
 	repo.users["user-1"] = user
@@ -299,7 +368,7 @@ func TestUserService_Delete(t *testing.T) {
 func TestUserService_Delete_NotFound(t *testing.T) {
 	repo := newMockRepository()
 	hashService := newMockHashService()
-	service := NewUserService(repo, hashService, "system")
+	service := NewUserService(repo, hashService, nil, "system")
 
 	err := service.Delete("nonexistent")
 	if err == nil {
@@ -310,7 +379,7 @@ func TestUserService_Delete_NotFound(t *testing.T) {
 func TestUserService_List(t *testing.T) {
 	repo := newMockRepository()
 	hashService := newMockHashService()
-	service := NewUserService(repo, hashService, "system")
+	service := NewUserService(repo, hashService, nil, "system")
 
 	user1 := domain.NewUser("user-1", "John", "Doe", "john@example.com", "hashed", "system")
 	user2 := domain.NewUser("user-2", "Jane", "Smith", "jane@example.com", "hashed", "system")
@@ -330,7 +399,7 @@ func TestUserService_List(t *testing.T) {
 func TestUserService_List_Empty(t *testing.T) {
 	repo := newMockRepository()
 	hashService := newMockHashService()
-	service := NewUserService(repo, hashService, "system")
+	service := NewUserService(repo, hashService, nil, "system")
 
 	responses, err := service.List()
 	if err != nil {
@@ -340,4 +409,46 @@ func TestUserService_List_Empty(t *testing.T) {
 	if len(responses) != 0 {
 		t.Errorf("expected 0 users, got %d", len(responses))
 	}
-}
\ No newline at end of file
+}
+
+func TestUserService_AssignRole(t *testing.T) {
+	tests := []struct {
+		name        string
+		actorID     string
+		userID      string
+		role        string
+		expectedErr string
+	}{
+		{name: "promote", actorID: "admin-1", userID: "user-1", role: "admin"},
+		{name: "invalid role", actorID: "admin-1", userID: "user-1", role: "owner", expectedErr: "invalid role"},
+		{name: "own role", actorID: "user-1", userID: "user-1", role: "user", expectedErr: "cannot change your own role"},
+		{name: "missing user", actorID: "admin-1", userID: "missing", role: "admin", expectedErr: "user not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockRepository()
+			repo.users["user-1"] = domain.NewUser("user-1", "John", "Doe", "john@example.com", "hashed", "system")
+			service := NewUserService(repo, newMockHashService(), nil, "system")
+
+			err := service.AssignRole(tt.actorID, tt.userID, tt.role)
+
+			if tt.expectedErr != "" {
+				if err == nil || err.Error() != tt.expectedErr {
+					t.Errorf("expected error %q, got %v", tt.expectedErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("AssignRole failed: %v", err)
+			}
+			if repo.users["user-1"].Role != "admin" {
+				t.Errorf("expected role admin, got %s", repo.users["user-1"].Role)
+			}
+			if repo.users["user-1"].ModifiedBy != tt.actorID {
+				t.Errorf("expected ModifiedBy %s, got %s", tt.actorID, repo.users["user-1"].ModifiedBy)
+			}
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type UserRepository interface {
 	Create(user *User) error
 	GetByID(id string) (*User, error)
@@ -9,3 +11,17 @@ type UserRepository interface {
 	Delete(id string) error
 	List() ([]*User, error)
 }
+
+type SessionRepository interface {
+	// Create stores a new session together with its first refresh token.
+	Create(session *Session, token *RefreshToken) error
+	// GetByTokenHash returns the refresh token with the given hash and the
+	// session it belongs to.
+	GetByTokenHash(tokenHash string) (*Session, *RefreshToken, error)
+	// Rotate marks current as used and stores next in the same session. It
+	// returns ErrRefreshTokenReused if current was used concurrently.
+	Rotate(current *RefreshToken, next *RefreshToken, session *Session) error
+	GetByID(id string, userID string) (*Session, error)
+	ListActive(userID string) ([]*Session, error)
+	Revoke(id string, reason string, at time.Time) error
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reuse detected")
+	ErrSessionNotFound     = errors.New("session not found")
+)
+
+// Reasons recorded when a session is revoked.
+const (
+	RevokedByLogout = "logout"
+	RevokedByUser   = "revoked"
+	RevokedByReuse  = "reuse_detected"
+)
+
+// Session is one signed-in device. Its refresh tokens form a family: each
+// refresh consumes the current token and issues the next one, and revoking
+// the session invalidates the whole family.
+type Session struct {
+	ID            string
+	UserID        string
+	UserAgent     string
+	IPAddress     string
+	CreatedAt     time.Time
+	LastUsedAt    time.Time
+	RevokedAt     *time.Time
+	RevokedReason string
+}
+
+func NewSession(id, userID, userAgent, ipAddress string, now time.Time) *Session {
+	return &Session{
+		ID:         id,
+		UserID:     userID,
+		UserAgent:  userAgent,
+		IPAddress:  ipAddress,
+		CreatedAt:  now,
+		LastUsedAt: now,
+	}
+}
+
+func (s *Session) IsRevoked() bool {
+	return s.RevokedAt != nil
+}
+
+// RefreshToken is stored by the SHA-256 hash of the opaque value handed to
+// the client, never by the value itself.
+type RefreshToken struct {
+	ID        string
+	SessionID string
+	TokenHash string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
+
+func NewRefreshToken(id, sessionID, tokenHash string, now time.Time, ttl time.Duration) *RefreshToken {
+	return &RefreshToken{
+		ID:        id,
+		SessionID: sessionID,
+		TokenHash: tokenHash,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+}
+
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// IsUsed reports whether the token was already exchanged for a new one.
+// Presenting a used token means it leaked.
+func (t *RefreshToken) IsUsed() bool {
+	return t.UsedAt != nil
+}
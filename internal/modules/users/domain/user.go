@@ -13,6 +13,7 @@ type User struct {
 	LastName  string
 	Email     string
 	Password  string
+	Role      domain.Role
 }
 
 func NewUser(id, firstName, lastName, email, password, createdBy string) *User {
@@ -23,6 +24,7 @@ func NewUser(id, firstName, lastName, email, password, createdBy string) *User {
 		LastName:  lastName,
 		Email:     email,
 		Password:  password,
+		Role:      domain.RoleUser,
 	}
 }
 
@@ -35,5 +37,6 @@ func NewUserWithAuthID(id, authID, firstName, lastName, email, password, created
 		LastName:  lastName,
 		Email:     email,
 		Password:  password,
+		Role:      domain.RoleUser,
 	}
 }
\ No newline at end of file
@@ -47,6 +47,10 @@ func TestNewUser(t *testing.T) {
 		t.Errorf("expected ModifiedBy %s, got %s", createdBy, user.ModifiedBy)
 	}
 
+	if user.Role != "user" {
+		t.Errorf("expected Role user, got %s", user.Role)
+	}
+
 	if user.CreatedAt.IsZero() {
 		t.Error("CreatedAt should not be zero")
 	}
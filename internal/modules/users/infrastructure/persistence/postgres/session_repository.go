@@ -0,0 +1,248 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"fin-flow-api/internal/modules/users/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const sessionColumns = `s.id, s.user_id, s.user_agent, s.ip_address, s.created_at, s.last_used_at, s.revoked_at, s.revoked_reason`
+
+type SessionRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewSessionRepository(pool *pgxpool.Pool) *SessionRepository {
+	return &SessionRepository{pool: pool}
+}
+
+func (r *SessionRepository) Create(session *domain.Session, token *domain.RefreshToken) error {
+	ctx := context.Background()
+
+	dbTx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	query := `
+		INSERT INTO sessions (id, user_id, user_agent, ip_address, created_at, last_used_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	if _, err := dbTx.Exec(ctx, query, session.ID, session.UserID, session.UserAgent, session.IPAddress, session.CreatedAt, session.LastUsedAt); err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	if err := insertRefreshToken(ctx, dbTx, token); err != nil {
+		return err
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) GetByTokenHash(tokenHash string) (*domain.Session, *domain.RefreshToken, error) {
+	query := `
+		SELECT ` + sessionColumns + `, t.id, t.session_id, t.token_hash, t.created_at, t.expires_at, t.used_at
+		FROM refresh_tokens t
+		JOIN sessions s ON s.id = t.session_id
+		WHERE t.token_hash = $1
+	`
+
+	var session domain.Session
+	var token domain.RefreshToken
+	var revokedReason *string
+
+	err := r.pool.QueryRow(context.Background(), query, tokenHash).Scan(
+		&session.ID,
+		&session.UserID,
+		&session.UserAgent,
+		&session.IPAddress,
+		&session.CreatedAt,
+		&session.LastUsedAt,
+		&session.RevokedAt,
+		&revokedReason,
+		&token.ID,
+		&token.SessionID,
+		&token.TokenHash,
+		&token.CreatedAt,
+		&token.ExpiresAt,
+		&token.UsedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, domain.ErrSessionNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if revokedReason != nil {
+		session.RevokedReason = *revokedReason
+	}
+
+	return &session, &token, nil
+}
+
+// Rotate consumes current and stores next in one transaction. The update
+// only matches an unused token, so of two concurrent refreshes with the
+// same token exactly one wins.
+func (r *SessionRepository) Rotate(current *domain.RefreshToken, next *domain.RefreshToken, session *domain.Session) error {
+	ctx := context.Background()
+
+	dbTx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	result, err := dbTx.Exec(ctx, `UPDATE refresh_tokens SET used_at = $2 WHERE id = $1 AND used_at IS NULL`, current.ID, next.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrRefreshTokenReused
+	}
+
+	if err := insertRefreshToken(ctx, dbTx, next); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE sessions
+		SET last_used_at = $2, user_agent = $3, ip_address = $4
+		WHERE id = $1 AND revoked_at IS NULL
+	`
+	result, err = dbTx.Exec(ctx, query, session.ID, session.LastUsedAt, session.UserAgent, session.IPAddress)
+	if err != nil {
+		return fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrInvalidRefreshToken
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) GetByID(id string, userID string) (*domain.Session, error) {
+	checkQuery := `SELECT user_id FROM sessions WHERE id = $1`
+	var sessionUserID string
+	err := r.pool.QueryRow(context.Background(), checkQuery, id).Scan(&sessionUserID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if sessionUserID != userID {
+		return nil, fmt.Errorf("unauthorized access to session")
+	}
+
+	query := `SELECT ` + sessionColumns + ` FROM sessions s WHERE s.id = $1 AND s.user_id = $2`
+
+	session, err := scanSession(r.pool.QueryRow(context.Background(), query, id, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return session, nil
+}
+
+// ListActive returns the sessions that are not revoked and still hold an
+// unexpired, unused refresh token, most recently used first.
+func (r *SessionRepository) ListActive(userID string) ([]*domain.Session, error) {
+	query := `
+		SELECT ` + sessionColumns + `
+		FROM sessions s
+		WHERE s.user_id = $1 AND s.revoked_at IS NULL
+			AND EXISTS (
+				SELECT 1 FROM refresh_tokens t
+				WHERE t.session_id = s.id AND t.used_at IS NULL AND t.expires_at > $2
+			)
+		ORDER BY s.last_used_at DESC
+	`
+
+	rows, err := r.pool.Query(context.Background(), query, userID, time.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*domain.Session
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+func (r *SessionRepository) Revoke(id string, reason string, at time.Time) error {
+	query := `UPDATE sessions SET revoked_at = $2, revoked_reason = $3 WHERE id = $1 AND revoked_at IS NULL`
+
+	if _, err := r.pool.Exec(context.Background(), query, id, at, reason); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	return nil
+}
+
+func insertRefreshToken(ctx context.Context, dbTx pgx.Tx, token *domain.RefreshToken) error {
+	query := `
+		INSERT INTO refresh_tokens (id, session_id, token_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	if _, err := dbTx.Exec(ctx, query, token.ID, token.SessionID, token.TokenHash, token.CreatedAt, token.ExpiresAt); err != nil {
+		return fmt.Errorf("failed to store refresh token: %w", err)
+	}
+	return nil
+}
+
+func scanSession(row pgx.Row) (*domain.Session, error) {
+	var session domain.Session
+	var revokedReason *string
+
+	err := row.Scan(
+		&session.ID,
+		&session.UserID,
+		&session.UserAgent,
+		&session.IPAddress,
+		&session.CreatedAt,
+		&session.LastUsedAt,
+		&session.RevokedAt,
+		&revokedReason,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if revokedReason != nil {
+		session.RevokedReason = *revokedReason
+	}
+
+	return &session, nil
+}
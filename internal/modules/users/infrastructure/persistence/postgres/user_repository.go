@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"fin-flow-api/internal/modules/users/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -23,8 +24,8 @@ func NewRepository(pool *pgxpool.Pool) *Repository {
 
 func (r *Repository) Create(user *domain.User) error {
 	query := `
-		INSERT INTO users (id, auth_id, first_name, last_name, email, password, created_at, modified_at, created_by, modified_by)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO users (id, auth_id, first_name, last_name, email, password, role, created_at, modified_at, created_by, modified_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
 
 	var authID *string
@@ -41,6 +42,7 @@ func (r *Repository) Create(user *domain.User) error {
 		user.LastName,
 		user.Email,
 		user.Password,
+		string(user.Role),
 		user.CreatedAt,
 		user.ModifiedAt,
 		user.CreatedBy,
@@ -70,13 +72,14 @@ func (r *Repository) Create(user *domain.User) error {
 
 func (r *Repository) GetByID(id string) (*domain.User, error) {
 	query := `
-		SELECT id, auth_id, first_name, last_name, email, password, created_at, modified_at, created_by, modified_by
+		SELECT id, auth_id, first_name, last_name, email, password, role, created_at, modified_at, created_by, modified_by
 		FROM users
 		WHERE id = $1
 	`
 
 	var user domain.User
 	var authID *string
+	var role string
 	err := r.pool.QueryRow(context.Background(), query, id).Scan(
 		&user.ID,
 		&authID,
@@ -84,6 +87,7 @@ func (r *Repository) GetByID(id string) (*domain.User, error) {
 		&user.LastName,
 		&user.Email,
 		&user.Password,
+		&role,
 		&user.CreatedAt,
 		&user.ModifiedAt,
 		&user.CreatedBy,
@@ -100,19 +104,21 @@ func (r *Repository) GetByID(id string) (*domain.User, error) {
 	if authID != nil {
 		user.AuthID = *authID
 	}
+	user.Role = shareddomain.ParseRole(role)
 
 	return &user, nil
 }
 
 func (r *Repository) GetByEmail(email string) (*domain.User, error) {
 	query := `
-		SELECT id, auth_id, first_name, last_name, email, password, created_at, modified_at, created_by, modified_by
+		SELECT id, auth_id, first_name, last_name, email, password, role, created_at, modified_at, created_by, modified_by
 		FROM users
 		WHERE email = $1
 	`
 
 	var user domain.User
 	var authID *string
+	var role string
 	err := r.pool.QueryRow(context.Background(), query, email).Scan(
 		&user.ID,
 		&authID,
@@ -120,6 +126,7 @@ func (r *Repository) GetByEmail(email string) (*domain.User, error) {
 		&user.LastName,
 		&user.Email,
 		&user.Password,
+		&role,
 		&user.CreatedAt,
 		&user.ModifiedAt,
 		&user.CreatedBy,
@@ -136,19 +143,21 @@ func (r *Repository) GetByEmail(email string) (*domain.User, error) {
 	if authID != nil {
 		user.AuthID = *authID
 	}
+	user.Role = shareddomain.ParseRole(role)
 
 	return &user, nil
 }
 
 func (r *Repository) GetByAuthID(authID string) (*domain.User, error) {
 	query := `
-		SELECT id, auth_id, first_name, last_name, email, password, created_at, modified_at, created_by, modified_by
+		SELECT id, auth_id, first_name, last_name, email, password, role, created_at, modified_at, created_by, modified_by
 		FROM users
 		WHERE auth_id = $1
 	`
 
 	var user domain.User
 	var authIDValue *string
+	var role string
 	err := r.pool.QueryRow(context.Background(), query, authID).Scan(
 		&user.ID,
 		&authIDValue,
@@ -156,6 +165,7 @@ func (r *Repository) GetByAuthID(authID string) (*domain.User, error) {
 		&user.LastName,
 		&user.Email,
 		&user.Password,
+		&role,
 		&user.CreatedAt,
 		&user.ModifiedAt,
 		&user.CreatedBy,
@@ -172,6 +182,7 @@ func (r *Repository) GetByAuthID(authID string) (*domain.User, error) {
 	if authIDValue != nil {
 		user.AuthID = *authIDValue
 	}
+	user.Role = shareddomain.ParseRole(role)
 
 	return &user, nil
 }
@@ -179,7 +190,7 @@ func (r *Repository) GetByAuthID(authID string) (*domain.User, error) {
 func (r *Repository) Update(user *domain.User) error {
 	query := `
 		UPDATE users
-		SET first_name = $2, last_name = $3, email = $4, password = $5, modified_at = $6, modified_by = $7
+		SET first_name = $2, last_name = $3, email = $4, password = $5, modified_at = $6, modified_by = $7, role = $8
 		WHERE id = $1
 	`
 
@@ -193,6 +204,7 @@ func (r *Repository) Update(user *domain.User) error {
 		user.Password,
 		user.ModifiedAt,
 		user.ModifiedBy,
+		string(user.Role),
 	)
 
 	if err != nil {
@@ -232,7 +244,7 @@ func (r *Repository) Delete(id string) error {
 
 func (r *Repository) List() ([]*domain.User, error) {
 	query := `
-		SELECT id, auth_id, first_name, last_name, email, password, created_at, modified_at, created_by, modified_by
+		SELECT id, auth_id, first_name, last_name, email, password, role, created_at, modified_at, created_by, modified_by
 		FROM users
 		ORDER BY created_at DESC
 	`
@@ -247,6 +259,7 @@ func (r *Repository) List() ([]*domain.User, error) {
 	for rows.Next() {
 		var user domain.User
 		var authID *string
+		var role string
 		err := rows.Scan(
 			&user.ID,
 			&authID,
@@ -254,6 +267,7 @@ func (r *Repository) List() ([]*domain.User, error) {
 			&user.LastName,
 			&user.Email,
 			&user.Password,
+			&role,
 			&user.CreatedAt,
 			&user.ModifiedAt,
 			&user.CreatedBy,
@@ -265,6 +279,7 @@ func (r *Repository) List() ([]*domain.User, error) {
 		if authID != nil {
 			user.AuthID = *authID
 		}
+		user.Role = shareddomain.ParseRole(role)
 		users = append(users, &user)
 	}
 
@@ -1,26 +1,49 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"net/netip"
+	"strings"
+	"time"
 
+	"fin-flow-api/internal/modules/users/application/contracts/commands"
+	"fin-flow-api/internal/modules/users/application/contracts/queries"
+	"fin-flow-api/internal/modules/users/domain"
 	basehandler "fin-flow-api/internal/shared/http"
 	"fin-flow-api/internal/shared/interface/hash"
 	"fin-flow-api/internal/shared/interface/jwt"
-	"fin-flow-api/internal/modules/users/domain"
 )
 
+const maxUserAgentLength = 500
+
+type sessionService interface {
+	Start(userID string, client commands.SessionClient) (*queries.RefreshTokenResponse, error)
+	Refresh(refreshToken string, client commands.SessionClient) (*queries.RefreshTokenResponse, error)
+	Logout(refreshToken string) error
+	List(ctx context.Context) ([]*queries.SessionResponse, error)
+	Revoke(ctx context.Context, id string) error
+}
+
 type AuthHandler struct {
-	userRepo   domain.UserRepository
-	hashService hash.Service
-	jwtService jwt.Service
+	userRepo       domain.UserRepository
+	hashService    hash.Service
+	jwtService     jwt.Service
+	sessionService sessionService
+	trustedProxies []netip.Prefix
 }
 
-func NewAuthHandler(userRepo domain.UserRepository, hashService hash.Service, jwtService jwt.Service) *AuthHandler {
+// NewAuthHandler returns the auth handler. X-Forwarded-For is only read
+// from requests that come through one of trustedProxies.
+func NewAuthHandler(userRepo domain.UserRepository, hashService hash.Service, jwtService jwt.Service, sessionService sessionService, trustedProxies []netip.Prefix) *AuthHandler {
 	return &AuthHandler{
-		userRepo:   userRepo,
-		hashService: hashService,
-		jwtService: jwtService,
+		userRepo:       userRepo,
+		hashService:    hashService,
+		jwtService:     jwtService,
+		sessionService: sessionService,
+		trustedProxies: trustedProxies,
 	}
 }
 
@@ -30,8 +53,28 @@ type LoginRequest struct {
 }
 
 type LoginResponse struct {
-	Token string      `json:"token"`
-	User  UserResponse `json:"user"`
+	Token                 string       `json:"token"`
+	RefreshToken          string       `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time    `json:"refresh_token_expires_at"`
+	User                  UserResponse `json:"user"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type TokenResponse struct {
+	Token                 string    `json:"token"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	Device     string    `json:"device"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
 }
 
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
@@ -57,21 +100,207 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := h.jwtService.GenerateToken(user.ID)
+	token, err := h.jwtService.GenerateToken(user.ID, string(user.Role))
 	if err != nil {
 		basehandler.WriteError(w, http.StatusInternalServerError, "Failed to generate token")
 		return
 	}
 
+	refreshToken, err := h.sessionService.Start(user.ID, h.sessionClient(r))
+	if err != nil {
+		basehandler.WriteError(w, http.StatusInternalServerError, "Failed to start session")
+		return
+	}
+
 	response := LoginResponse{
-		Token: token,
+		Token:                 token,
+		RefreshToken:          refreshToken.Token,
+		RefreshTokenExpiresAt: refreshToken.ExpiresAt,
 		User: UserResponse{
 			ID:        user.ID,
 			FirstName: user.FirstName,
 			LastName:  user.LastName,
 			Email:     user.Email,
+			Role:      string(user.Role),
 		},
 	}
 
 	basehandler.WriteJSON(w, http.StatusOK, response)
-}
\ No newline at end of file
+}
+
+// Refresh exchanges a refresh token for a new access token and the next
+// refresh token. The presented refresh token cannot be used again.
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req RefreshTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	refreshToken, err := h.sessionService.Refresh(req.RefreshToken, h.sessionClient(r))
+	if err != nil {
+		statusCode, errorMsg := sessionErrorResponse(err)
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	// The role is read again so that role changes reach the user with the
+	// next refresh rather than at their next login.
+	user, err := h.userRepo.GetByID(refreshToken.UserID)
+	if err != nil {
+		basehandler.WriteError(w, http.StatusUnauthorized, "Invalid or expired refresh token")
+		return
+	}
+
+	token, err := h.jwtService.GenerateToken(user.ID, string(user.Role))
+	if err != nil {
+		basehandler.WriteError(w, http.StatusInternalServerError, "Failed to generate token")
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, TokenResponse{
+		Token:                 token,
+		RefreshToken:          refreshToken.Token,
+		RefreshTokenExpiresAt: refreshToken.ExpiresAt,
+	})
+}
+
+// Logout revokes the session of the given refresh token. Access tokens
+// already issued stay valid until they expire.
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req RefreshTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.sessionService.Logout(req.RefreshToken); err != nil {
+		statusCode, errorMsg := sessionErrorResponse(err)
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Logged out successfully")
+}
+
+func (h *AuthHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	sessions, err := h.sessionService.List(r.Context())
+	if err != nil {
+		statusCode, errorMsg := sessionErrorResponse(err)
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	responses := make([]SessionResponse, len(sessions))
+	for i, session := range sessions {
+		responses[i] = SessionResponse{
+			ID:         session.ID,
+			Device:     session.UserAgent,
+			IPAddress:  session.IPAddress,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+		}
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, responses)
+}
+
+func (h *AuthHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/auth/sessions/")
+	id := strings.Split(path, "/")[0]
+
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "Session ID is required in the URL path")
+		return
+	}
+
+	if err := h.sessionService.Revoke(r.Context(), id); err != nil {
+		statusCode, errorMsg := sessionErrorResponse(err)
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "Session revoked successfully")
+}
+
+func sessionErrorResponse(err error) (int, string) {
+	errorMsg := err.Error()
+
+	switch {
+	case errors.Is(err, domain.ErrRefreshTokenReused):
+		return http.StatusUnauthorized, "Refresh token was already used; the session has been revoked"
+	case errors.Is(err, domain.ErrInvalidRefreshToken):
+		return http.StatusUnauthorized, "Invalid or expired refresh token"
+	case strings.Contains(errorMsg, "user not authenticated"):
+		return http.StatusUnauthorized, "Authentication required"
+	case strings.Contains(errorMsg, "unauthorized access to session"):
+		return http.StatusForbidden, "You do not have permission to revoke this session"
+	case strings.Contains(errorMsg, "session not found"):
+		return http.StatusNotFound, "Session not found"
+	}
+
+	return http.StatusInternalServerError, errorMsg
+}
+
+// sessionClient describes the caller for the session list. The address is
+// only informational and is not used for any access decision.
+func (h *AuthHandler) sessionClient(r *http.Request) commands.SessionClient {
+	userAgent := r.UserAgent()
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	return commands.SessionClient{UserAgent: userAgent, IPAddress: h.clientIP(r)}
+}
+
+// clientIP returns the address of the caller, or "" if it is not a valid
+// one. Behind trusted proxies it is the last X-Forwarded-For hop that was
+// not added by one of them; a header from anyone else is ignored, since
+// the caller could put any address in it.
+func (h *AuthHandler) clientIP(r *http.Request) string {
+	addrPort, err := netip.ParseAddrPort(r.RemoteAddr)
+	if err != nil {
+		return ""
+	}
+	client := addrPort.Addr().Unmap().WithZone("")
+
+	hops := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+	for i := len(hops) - 1; i >= 0 && h.isTrustedProxy(client); i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			break
+		}
+		client = hop.Unmap().WithZone("")
+	}
+
+	return client.String()
+}
+
+func (h *AuthHandler) isTrustedProxy(addr netip.Addr) bool {
+	for _, prefix := range h.trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -3,12 +3,19 @@ package http
 import (
 	"bytes"
 	"encoding/json"
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/netip"
+	"strings"
 	"testing"
 
+	"fin-flow-api/internal/modules/users/application/contracts/commands"
+	"fin-flow-api/internal/modules/users/application/contracts/queries"
 	"fin-flow-api/internal/modules/users/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
 )
 
 func TestLogin_Success(t *testing.T) {
@@ -22,11 +29,11 @@ func TestLogin_Success(t *testing.T) {
 	}
 
 	jwtService := newMockJWTService()
-	jwtService.generateTokenFunc = func(userID string) (string, error) {
+	jwtService.generateTokenFunc = func(userID string, role string) (string, error) {
 		return "jwt-token-123", nil
 	}
 
-	handler := NewAuthHandler(userRepo, hashService, jwtService)
+	handler := NewAuthHandler(userRepo, hashService, jwtService, newMockSessionService(), nil)
 
 	body := LoginRequest{
 		Email:    "john@example.com",
@@ -59,7 +66,7 @@ func TestLogin_InvalidMethod(t *testing.T) {
 	hashService := newMockHashService()
 	jwtService := newMockJWTService()
 
-	handler := NewAuthHandler(userRepo, hashService, jwtService)
+	handler := NewAuthHandler(userRepo, hashService, jwtService, newMockSessionService(), nil)
 
 	req := httptest.NewRequest("GET", "/auth/login", nil)
 	rr := httptest.NewRecorder()
@@ -75,7 +82,7 @@ func TestLogin_InvalidBody(t *testing.T) {
 	hashService := newMockHashService()
 	jwtService := newMockJWTService()
 
-	handler := NewAuthHandler(userRepo, hashService, jwtService)
+	handler := NewAuthHandler(userRepo, hashService, jwtService, newMockSessionService(), nil)
 
 	req := httptest.NewRequest("POST", "/auth/login", bytes.NewBufferString("invalid json"))
 	rr := httptest.NewRecorder()
@@ -95,7 +102,7 @@ func TestLogin_UserNotFound(t *testing.T) {
 	hashService := newMockHashService()
 	jwtService := newMockJWTService()
 
-	handler := NewAuthHandler(userRepo, hashService, jwtService)
+	handler := NewAuthHandler(userRepo, hashService, jwtService, newMockSessionService(), nil)
 
 	body := LoginRequest{
 		Email:    "nonexistent@example.com",
@@ -126,7 +133,7 @@ func TestLogin_WrongPassword(t *testing.T) {
 
 	jwtService := newMockJWTService()
 
-	handler := NewAuthHandler(userRepo, hashService, jwtService)
+	handler := NewAuthHandler(userRepo, hashService, jwtService, newMockSessionService(), nil)
 
 	body := LoginRequest{
 		Email:    "john@example.com",
@@ -156,11 +163,11 @@ func TestLogin_TokenGenerationError(t *testing.T) {
 	}
 
 	jwtService := newMockJWTService()
-	jwtService.generateTokenFunc = func(userID string) (string, error) {
+	jwtService.generateTokenFunc = func(userID string, role string) (string, error) {
 		return "", errors.New("token generation failed")
 	}
 
-	handler := NewAuthHandler(userRepo, hashService, jwtService)
+	handler := NewAuthHandler(userRepo, hashService, jwtService, newMockSessionService(), nil)
 
 	body := LoginRequest{
 		Email:    "john@example.com",
@@ -177,4 +184,237 @@ func TestLogin_TokenGenerationError(t *testing.T) {
 	if rr.Code != http.StatusInternalServerError {
 		t.Errorf("expected status 500, got %d", rr.Code)
 	}
-}
\ No newline at end of file
+}
+
+func TestLogin_ReturnsRefreshToken(t *testing.T) {
+	userRepo := newMockUserRepository()
+	userRepo.users["user-1"] = domain.NewUser("user-1", "John", "Doe", "john@example.com", "hashed-password123", "system")
+
+	var gotClient commands.SessionClient
+	sessionService := newMockSessionService()
+	sessionService.startFunc = func(userID string, client commands.SessionClient) (*queries.RefreshTokenResponse, error) {
+		gotClient = client
+		return &queries.RefreshTokenResponse{UserID: userID, Token: "refresh-1"}, nil
+	}
+
+	trustedProxies := []netip.Prefix{netip.MustParsePrefix("192.0.2.1/32"), netip.MustParsePrefix("10.0.0.0/8")}
+	handler := NewAuthHandler(userRepo, newMockHashService(), newMockJWTService(), sessionService, trustedProxies)
+
+	jsonBody, _ := json.Marshal(LoginRequest{Email: "john@example.com", Password: "password123"})
+	req := httptest.NewRequest("POST", "/auth/login", bytes.NewBuffer(jsonBody))
+	req.Header.Set("User-Agent", "test-agent")
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+
+	rr := httptest.NewRecorder()
+	handler.Login(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+
+	var response LoginResponse
+	json.NewDecoder(rr.Body).Decode(&response)
+	if response.RefreshToken != "refresh-1" {
+		t.Errorf("expected refresh token refresh-1, got %s", response.RefreshToken)
+	}
+	if gotClient.UserAgent != "test-agent" {
+		t.Errorf("expected user agent test-agent, got %s", gotClient.UserAgent)
+	}
+	if gotClient.IPAddress != "203.0.113.7" {
+		t.Errorf("expected IP 203.0.113.7, got %s", gotClient.IPAddress)
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	trustedProxies := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  string
+		want       string
+	}{
+		{"direct", "203.0.113.7:5000", "", "203.0.113.7"},
+		{"forwarded by an untrusted caller", "203.0.113.7:5000", "198.51.100.1", "203.0.113.7"},
+		{"behind a trusted proxy", "10.0.0.2:5000", "203.0.113.7", "203.0.113.7"},
+		{"spoofed hops before the proxy", "10.0.0.2:5000", "198.51.100.1, 203.0.113.7, 10.0.0.3", "203.0.113.7"},
+		{"invalid hop", "10.0.0.2:5000", strings.Repeat("x", 200), "10.0.0.2"},
+		{"ipv6", "[2001:db8::1]:5000", "", "2001:db8::1"},
+		{"invalid remote address", "pipe", "", ""},
+	}
+
+	handler := NewAuthHandler(newMockUserRepository(), newMockHashService(), newMockJWTService(), newMockSessionService(), trustedProxies)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/auth/login", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwarded != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwarded)
+			}
+
+			if got := handler.clientIP(req); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		refreshErr     error
+		expectedStatus int
+	}{
+		{name: "success", body: `{"refresh_token":"refresh-1"}`, expectedStatus: http.StatusOK},
+		{name: "invalid body", body: "invalid json", expectedStatus: http.StatusBadRequest},
+		{name: "invalid token", body: `{"refresh_token":"nope"}`, refreshErr: domain.ErrInvalidRefreshToken, expectedStatus: http.StatusUnauthorized},
+		{name: "reused token", body: `{"refresh_token":"old"}`, refreshErr: domain.ErrRefreshTokenReused, expectedStatus: http.StatusUnauthorized},
+		{name: "storage failure", body: `{"refresh_token":"refresh-1"}`, refreshErr: errors.New("db down"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessionService := newMockSessionService()
+			if tt.refreshErr != nil {
+				sessionService.refreshFunc = func(refreshToken string, client commands.SessionClient) (*queries.RefreshTokenResponse, error) {
+					return nil, tt.refreshErr
+				}
+			}
+
+			userRepo := newMockUserRepository()
+			userRepo.users["user-1"] = domain.NewUser("user-1", "John", "Doe", "john@example.com", "hashed-password123", "system")
+
+			handler := NewAuthHandler(userRepo, newMockHashService(), newMockJWTService(), sessionService, nil)
+
+			req := httptest.NewRequest("POST", "/auth/refresh", bytes.NewBufferString(tt.body))
+			rr := httptest.NewRecorder()
+			handler.Refresh(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+
+			if tt.expectedStatus == http.StatusOK {
+				var response TokenResponse
+				json.NewDecoder(rr.Body).Decode(&response)
+				if response.Token != "mock-token" {
+					t.Errorf("expected token mock-token, got %s", response.Token)
+				}
+				if response.RefreshToken != "next-refresh-token" {
+					t.Errorf("expected refresh token next-refresh-token, got %s", response.RefreshToken)
+				}
+			}
+		})
+	}
+}
+
+func TestLogout(t *testing.T) {
+	var loggedOut string
+	sessionService := newMockSessionService()
+	sessionService.logoutFunc = func(refreshToken string) error {
+		loggedOut = refreshToken
+		return nil
+	}
+
+	handler := NewAuthHandler(newMockUserRepository(), newMockHashService(), newMockJWTService(), sessionService, nil)
+
+	req := httptest.NewRequest("POST", "/auth/logout", bytes.NewBufferString(`{"refresh_token":"refresh-1"}`))
+	rr := httptest.NewRecorder()
+	handler.Logout(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rr.Code)
+	}
+	if loggedOut != "refresh-1" {
+		t.Errorf("expected refresh-1 to be logged out, got %q", loggedOut)
+	}
+}
+
+func TestListSessions(t *testing.T) {
+	sessionService := newMockSessionService()
+	sessionService.listFunc = func(ctx context.Context) ([]*queries.SessionResponse, error) {
+		return []*queries.SessionResponse{
+			{ID: "session-1", UserAgent: "Firefox", IPAddress: "203.0.113.7"},
+		}, nil
+	}
+
+	handler := NewAuthHandler(newMockUserRepository(), newMockHashService(), newMockJWTService(), sessionService, nil)
+
+	req := httptest.NewRequest("GET", "/auth/sessions", nil)
+	rr := httptest.NewRecorder()
+	handler.ListSessions(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+
+	var response []SessionResponse
+	json.NewDecoder(rr.Body).Decode(&response)
+	if len(response) != 1 {
+		t.Fatalf("expected 1 session, got %d", len(response))
+	}
+	if response[0].Device != "Firefox" {
+		t.Errorf("expected device Firefox, got %s", response[0].Device)
+	}
+}
+
+func TestRevokeSession(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		revokeErr      error
+		expectedStatus int
+	}{
+		{name: "success", path: "/auth/sessions/session-1", expectedStatus: http.StatusOK},
+		{name: "missing id", path: "/auth/sessions/", expectedStatus: http.StatusBadRequest},
+		{name: "not found", path: "/auth/sessions/missing", revokeErr: domain.ErrSessionNotFound, expectedStatus: http.StatusNotFound},
+		{name: "other user", path: "/auth/sessions/session-2", revokeErr: fmt.Errorf("unauthorized access to session"), expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessionService := newMockSessionService()
+			sessionService.revokeFunc = func(ctx context.Context, id string) error {
+				return tt.revokeErr
+			}
+
+			handler := NewAuthHandler(newMockUserRepository(), newMockHashService(), newMockJWTService(), sessionService, nil)
+
+			req := httptest.NewRequest("DELETE", tt.path, nil)
+			rr := httptest.NewRecorder()
+			handler.RevokeSession(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestRefresh_UsesCurrentRole(t *testing.T) {
+	userRepo := newMockUserRepository()
+	admin := domain.NewUser("user-1", "John", "Doe", "john@example.com", "hashed-password123", "system")
+	admin.Role = shareddomain.RoleAdmin
+	userRepo.users["user-1"] = admin
+
+	var gotRole string
+	jwtService := newMockJWTService()
+	jwtService.generateTokenFunc = func(userID string, role string) (string, error) {
+		gotRole = role
+		return "jwt-token", nil
+	}
+
+	handler := NewAuthHandler(userRepo, newMockHashService(), jwtService, newMockSessionService(), nil)
+
+	req := httptest.NewRequest("POST", "/auth/refresh", bytes.NewBufferString(`{"refresh_token":"refresh-1"}`))
+	rr := httptest.NewRecorder()
+	handler.Refresh(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if gotRole != "admin" {
+		t.Errorf("expected role admin in the new token, got %q", gotRole)
+	}
+}
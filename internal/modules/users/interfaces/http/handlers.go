@@ -129,8 +129,6 @@ func (h *Handler) GetUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	authenticatedUserID, _ := middleware.GetUserIDFromContext(r.Context())
-
 	path := strings.TrimPrefix(r.URL.Path, "/users/")
 	id := strings.Split(path, "/")[0]
 
@@ -139,11 +137,6 @@ func (h *Handler) GetUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if authenticatedUserID != "" && authenticatedUserID != id {
-		basehandler.WriteError(w, http.StatusForbidden, "You can only view your own profile")
-		return
-	}
-
 	user, err := h.userService.GetByID(id)
 	if err != nil {
 		statusCode := http.StatusNotFound
@@ -162,6 +155,7 @@ func (h *Handler) GetUser(w http.ResponseWriter, r *http.Request) {
 		FirstName: user.FirstName,
 		LastName:  user.LastName,
 		Email:     user.Email,
+		Role:      user.Role,
 	}
 
 		basehandler.WriteJSON(w, http.StatusOK, response)
@@ -173,8 +167,7 @@ func (h *Handler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	authenticatedUserID, ok := middleware.GetUserIDFromContext(r.Context())
-	if !ok {
+	if _, ok := middleware.GetUserIDFromContext(r.Context()); !ok {
 		basehandler.WriteError(w, http.StatusUnauthorized, "User not authenticated")
 		return
 	}
@@ -187,11 +180,6 @@ func (h *Handler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if authenticatedUserID != id {
-		basehandler.WriteError(w, http.StatusForbidden, "You can only update your own profile")
-		return
-	}
-
 	var reqDTO UserRequest
 	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
 		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
@@ -230,8 +218,7 @@ func (h *Handler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	authenticatedUserID, ok := middleware.GetUserIDFromContext(r.Context())
-	if !ok {
+	if _, ok := middleware.GetUserIDFromContext(r.Context()); !ok {
 		basehandler.WriteError(w, http.StatusUnauthorized, "User not authenticated")
 		return
 	}
@@ -244,11 +231,6 @@ func (h *Handler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if authenticatedUserID != id {
-		basehandler.WriteError(w, http.StatusForbidden, "You can only delete your own account")
-		return
-	}
-
 	if err := h.userService.Delete(id); err != nil {
 		statusCode := http.StatusInternalServerError
 		errorMsg := err.Error()
@@ -265,6 +247,52 @@ func (h *Handler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	basehandler.WriteSuccess(w, "User account deleted successfully")
 }
 
+func (h *Handler) AssignRole(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	authenticatedUserID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		basehandler.WriteError(w, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	id := userIDFromPath(r)
+	if id == "" {
+		basehandler.WriteError(w, http.StatusBadRequest, "User ID is required in the URL path")
+		return
+	}
+
+	var reqDTO RoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqDTO); err != nil {
+		basehandler.WriteError(w, http.StatusBadRequest, "Invalid JSON format in request body")
+		return
+	}
+
+	if err := h.userService.AssignRole(authenticatedUserID, id, strings.TrimSpace(reqDTO.Role)); err != nil {
+		statusCode := http.StatusInternalServerError
+		errorMsg := err.Error()
+
+		if strings.Contains(errorMsg, "invalid role") {
+			statusCode = http.StatusBadRequest
+			errorMsg = "Role must be one of: user, admin"
+		} else if strings.Contains(errorMsg, "cannot change your own role") {
+			statusCode = http.StatusForbidden
+			errorMsg = "You cannot change your own role"
+		} else if strings.Contains(errorMsg, "user not found") {
+			statusCode = http.StatusNotFound
+			errorMsg = "User not found"
+		}
+
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	basehandler.WriteSuccess(w, "User role updated successfully")
+}
+
 func (h *Handler) ListUsers(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
@@ -284,6 +312,7 @@ func (h *Handler) ListUsers(w http.ResponseWriter, r *http.Request) {
 			FirstName: user.FirstName,
 			LastName:  user.LastName,
 			Email:     user.Email,
+			Role:      user.Role,
 		}
 	}
 
@@ -11,13 +11,15 @@ import (
 
 	"fin-flow-api/internal/modules/users/application/services"
 	"fin-flow-api/internal/modules/users/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/interface/jwt"
 	"fin-flow-api/internal/shared/middleware"
 )
 
 func TestCreateUser_Success(t *testing.T) {
 	repo := newMockUserRepository()
 	hashService := newMockHashService()
-	userService := services.NewUserService(repo, hashService, "system")
+	userService := services.NewUserService(repo, hashService, nil, "system")
 	handler := NewHandler(userService)
 
 	body := UserRequest{
@@ -48,7 +50,7 @@ func TestCreateUser_Success(t *testing.T) {
 func TestCreateUser_InvalidMethod(t *testing.T) {
 	repo := newMockUserRepository()
 	hashService := newMockHashService()
-	userService := services.NewUserService(repo, hashService, "system")
+	userService := services.NewUserService(repo, hashService, nil, "system")
 	handler := NewHandler(userService)
 
 	req := httptest.NewRequest("GET", "/users", nil)
@@ -63,7 +65,7 @@ func TestCreateUser_InvalidMethod(t *testing.T) {
 func TestCreateUser_InvalidBody(t *testing.T) {
 	repo := newMockUserRepository()
 	hashService := newMockHashService()
-	userService := services.NewUserService(repo, hashService, "system")
+	userService := services.NewUserService(repo, hashService, nil, "system")
 	handler := NewHandler(userService)
 
 	req := httptest.NewRequest("POST", "/users", bytes.NewBufferString("invalid json"))
@@ -107,7 +109,7 @@ func TestCreateUser_ServiceError(t *testing.T) {
 		return errors.New("repository error")
 	}
 	hashService := newMockHashService()
-	userService := services.NewUserService(repo, hashService, "system")
+	userService := services.NewUserService(repo, hashService, nil, "system")
 	handler := NewHandler(userService)
 
 	body := UserRequest{
@@ -135,7 +137,7 @@ func TestGetUser_Success(t *testing.T) {
 		return domain.NewUser("user-1", "John", "Doe", "john@example.com", "hashed", "system"), nil
 	}
 	hashService := newMockHashService()
-	userService := services.NewUserService(repo, hashService, "system")
+	userService := services.NewUserService(repo, hashService, nil, "system")
 	handler := NewHandler(userService)
 
 	req := httptest.NewRequest("GET", "/users/user-1", nil)
@@ -162,7 +164,7 @@ func TestGetUser_NotFound(t *testing.T) {
 		return nil, errors.New("user not found")
 	}
 	hashService := newMockHashService()
-	userService := services.NewUserService(repo, hashService, "system")
+	userService := services.NewUserService(repo, hashService, nil, "system")
 	handler := NewHandler(userService)
 
 	req := httptest.NewRequest("GET", "/users/nonexistent", nil)
@@ -180,15 +182,17 @@ func TestGetUser_Forbidden(t *testing.T) {
 		return domain.NewUser("user-2", "Jane", "Doe", "jane@example.com", "hashed", "system"), nil
 	}
 	hashService := newMockHashService()
-	userService := services.NewUserService(repo, hashService, "system")
+	userService := services.NewUserService(repo, hashService, nil, "system")
 	handler := NewHandler(userService)
 
+	SetHandler(handler)
+
 	req := httptest.NewRequest("GET", "/users/user-2", nil)
 	ctx := context.WithValue(req.Context(), middleware.UserIDKey, "user-1")
 	req = req.WithContext(ctx)
 
 	rr := httptest.NewRecorder()
-	handler.GetUser(rr, req)
+	handleUsersResource(rr, req)
 
 	if rr.Code != http.StatusForbidden {
 		t.Errorf("expected status 403, got %d", rr.Code)
@@ -205,7 +209,7 @@ func TestUpdateUser_Success(t *testing.T) {
 		return nil
 	}
 	hashService := newMockHashService()
-	userService := services.NewUserService(repo, hashService, "system")
+	userService := services.NewUserService(repo, hashService, nil, "system")
 	handler := NewHandler(userService)
 
 	body := UserRequest{
@@ -231,7 +235,7 @@ func TestUpdateUser_Success(t *testing.T) {
 func TestUpdateUser_Unauthorized(t *testing.T) {
 	repo := newMockUserRepository()
 	hashService := newMockHashService()
-	userService := services.NewUserService(repo, hashService, "system")
+	userService := services.NewUserService(repo, hashService, nil, "system")
 	handler := NewHandler(userService)
 
 	req := httptest.NewRequest("PUT", "/users/user-1", nil)
@@ -246,15 +250,17 @@ func TestUpdateUser_Unauthorized(t *testing.T) {
 func TestUpdateUser_Forbidden(t *testing.T) {
 	repo := newMockUserRepository()
 	hashService := newMockHashService()
-	userService := services.NewUserService(repo, hashService, "system")
+	userService := services.NewUserService(repo, hashService, nil, "system")
 	handler := NewHandler(userService)
 
+	SetHandler(handler)
+
 	req := httptest.NewRequest("PUT", "/users/user-2", nil)
 	ctx := context.WithValue(req.Context(), middleware.UserIDKey, "user-1")
 	req = req.WithContext(ctx)
 
 	rr := httptest.NewRecorder()
-	handler.UpdateUser(rr, req)
+	handleUsersResource(rr, req)
 
 	if rr.Code != http.StatusForbidden {
 		t.Errorf("expected status 403, got %d", rr.Code)
@@ -267,7 +273,7 @@ func TestDeleteUser_Success(t *testing.T) {
 		return nil
 	}
 	hashService := newMockHashService()
-	userService := services.NewUserService(repo, hashService, "system")
+	userService := services.NewUserService(repo, hashService, nil, "system")
 	handler := NewHandler(userService)
 
 	req := httptest.NewRequest("DELETE", "/users/user-1", nil)
@@ -285,7 +291,7 @@ func TestDeleteUser_Success(t *testing.T) {
 func TestDeleteUser_Unauthorized(t *testing.T) {
 	repo := newMockUserRepository()
 	hashService := newMockHashService()
-	userService := services.NewUserService(repo, hashService, "system")
+	userService := services.NewUserService(repo, hashService, nil, "system")
 	handler := NewHandler(userService)
 
 	req := httptest.NewRequest("DELETE", "/users/user-1", nil)
@@ -306,7 +312,7 @@ func TestListUsers_Success(t *testing.T) {
 		}, nil
 	}
 	hashService := newMockHashService()
-	userService := services.NewUserService(repo, hashService, "system")
+	userService := services.NewUserService(repo, hashService, nil, "system")
 	handler := NewHandler(userService)
 
 	req := httptest.NewRequest("GET", "/users", nil)
@@ -324,6 +330,89 @@ func TestListUsers_Success(t *testing.T) {
 	}
 }
 
+func TestUsersResource_Authorization(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		callerID       string
+		role           shareddomain.Role
+		expectedStatus int
+	}{
+		{name: "user reads self", method: "GET", path: "/users/user-1", callerID: "user-1", role: shareddomain.RoleUser, expectedStatus: http.StatusOK},
+		{name: "user reads other", method: "GET", path: "/users/user-2", callerID: "user-1", role: shareddomain.RoleUser, expectedStatus: http.StatusForbidden},
+		{name: "admin reads other", method: "GET", path: "/users/user-2", callerID: "admin-1", role: shareddomain.RoleAdmin, expectedStatus: http.StatusOK},
+		{name: "user deletes other", method: "DELETE", path: "/users/user-2", callerID: "user-1", role: shareddomain.RoleUser, expectedStatus: http.StatusForbidden},
+		{name: "admin deletes other", method: "DELETE", path: "/users/user-2", callerID: "admin-1", role: shareddomain.RoleAdmin, expectedStatus: http.StatusOK},
+		{name: "admin updates other", method: "PUT", path: "/users/user-2", body: `{"first_name":"Jane","last_name":"Doe","email":"jane@example.com"}`, callerID: "admin-1", role: shareddomain.RoleAdmin, expectedStatus: http.StatusOK},
+		{name: "user promotes self", method: "PUT", path: "/users/user-1/role", body: `{"role":"admin"}`, callerID: "user-1", role: shareddomain.RoleUser, expectedStatus: http.StatusForbidden},
+		{name: "admin promotes other", method: "PUT", path: "/users/user-2/role", body: `{"role":"admin"}`, callerID: "admin-1", role: shareddomain.RoleAdmin, expectedStatus: http.StatusOK},
+		{name: "admin demotes self", method: "PUT", path: "/users/admin-1/role", body: `{"role":"user"}`, callerID: "admin-1", role: shareddomain.RoleAdmin, expectedStatus: http.StatusForbidden},
+		{name: "admin assigns unknown role", method: "PUT", path: "/users/user-2/role", body: `{"role":"owner"}`, callerID: "admin-1", role: shareddomain.RoleAdmin, expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockUserRepository()
+			repo.users["user-1"] = domain.NewUser("user-1", "John", "Doe", "john@example.com", "hashed", "system")
+			repo.users["user-2"] = domain.NewUser("user-2", "Jane", "Doe", "jane@example.com", "hashed", "system")
+			admin := domain.NewUser("admin-1", "Ada", "Admin", "ada@example.com", "hashed", "system")
+			admin.Role = shareddomain.RoleAdmin
+			repo.users["admin-1"] = admin
+
+			userService := services.NewUserService(repo, newMockHashService(), nil, "system")
+			SetHandler(NewHandler(userService))
+
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			ctx := context.WithValue(req.Context(), middleware.UserIDKey, tt.callerID)
+			ctx = context.WithValue(ctx, middleware.RoleKey, tt.role)
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			handleUsersResource(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestUsersCollection_ListRequiresAdmin(t *testing.T) {
+	repo := newMockUserRepository()
+	userService := services.NewUserService(repo, newMockHashService(), nil, "system")
+	SetHandler(NewHandler(userService))
+
+	tests := []struct {
+		role           string
+		expectedStatus int
+	}{
+		{role: "user", expectedStatus: http.StatusForbidden},
+		{role: "", expectedStatus: http.StatusForbidden},
+		{role: "admin", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run("role "+tt.role, func(t *testing.T) {
+			jwtService := newMockJWTService()
+			jwtService.validateTokenFunc = func(tokenString string) (*jwt.AccessClaims, error) {
+				return &jwt.AccessClaims{UserID: "user-1", Role: tt.role}, nil
+			}
+
+			req := httptest.NewRequest("GET", "/users", nil)
+			req.Header.Set("Authorization", "Bearer token")
+
+			rr := httptest.NewRecorder()
+			handleUsersCollection(jwtService)(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
+
 func TestIsValidEmail(t *testing.T) {
 	tests := []struct {
 		email string
@@ -1,8 +1,14 @@
 package http
 
 import (
+	"context"
 	"errors"
+	"time"
+
+	"fin-flow-api/internal/modules/users/application/contracts/commands"
+	"fin-flow-api/internal/modules/users/application/contracts/queries"
 	"fin-flow-api/internal/modules/users/domain"
+	"fin-flow-api/internal/shared/interface/jwt"
 )
 
 type mockUserRepository struct {
@@ -129,31 +135,88 @@ func (m *mockHashService) Verify(password, hash string) bool {
 }
 
 type mockJWTService struct {
-	generateTokenFunc func(userID string) (string, error)
-	validateTokenFunc func(tokenString string) (string, error)
+	generateTokenFunc func(userID string, role string) (string, error)
+	validateTokenFunc func(tokenString string) (*jwt.AccessClaims, error)
 }
 
 func newMockJWTService() *mockJWTService {
 	return &mockJWTService{
-		generateTokenFunc: func(userID string) (string, error) {
+		generateTokenFunc: func(userID string, role string) (string, error) {
 			return "mock-token", nil
 		},
-		validateTokenFunc: func(tokenString string) (string, error) {
-			return "user-123", nil
+		validateTokenFunc: func(tokenString string) (*jwt.AccessClaims, error) {
+			return &jwt.AccessClaims{UserID: "user-123", Role: "user"}, nil
 		},
 	}
 }
 
-func (m *mockJWTService) GenerateToken(userID string) (string, error) {
+func (m *mockJWTService) GenerateToken(userID string, role string) (string, error) {
 	if m.generateTokenFunc != nil {
-		return m.generateTokenFunc(userID)
+		return m.generateTokenFunc(userID, role)
 	}
 	return "mock-token", nil
 }
 
-func (m *mockJWTService) ValidateToken(tokenString string) (string, error) {
+func (m *mockJWTService) ValidateToken(tokenString string) (*jwt.AccessClaims, error) {
 	if m.validateTokenFunc != nil {
 		return m.validateTokenFunc(tokenString)
 	}
-	return "user-123", nil
-}
\ No newline at end of file
+	return &jwt.AccessClaims{UserID: "user-123", Role: "user"}, nil
+}
+
+type mockSessionService struct {
+	startFunc   func(userID string, client commands.SessionClient) (*queries.RefreshTokenResponse, error)
+	refreshFunc func(refreshToken string, client commands.SessionClient) (*queries.RefreshTokenResponse, error)
+	logoutFunc  func(refreshToken string) error
+	listFunc    func(ctx context.Context) ([]*queries.SessionResponse, error)
+	revokeFunc  func(ctx context.Context, id string) error
+}
+
+func newMockSessionService() *mockSessionService {
+	return &mockSessionService{}
+}
+
+func (m *mockSessionService) Start(userID string, client commands.SessionClient) (*queries.RefreshTokenResponse, error) {
+	if m.startFunc != nil {
+		return m.startFunc(userID, client)
+	}
+	return &queries.RefreshTokenResponse{
+		UserID:    userID,
+		SessionID: "session-1",
+		Token:     "refresh-token",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, nil
+}
+
+func (m *mockSessionService) Refresh(refreshToken string, client commands.SessionClient) (*queries.RefreshTokenResponse, error) {
+	if m.refreshFunc != nil {
+		return m.refreshFunc(refreshToken, client)
+	}
+	return &queries.RefreshTokenResponse{
+		UserID:    "user-1",
+		SessionID: "session-1",
+		Token:     "next-refresh-token",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, nil
+}
+
+func (m *mockSessionService) Logout(refreshToken string) error {
+	if m.logoutFunc != nil {
+		return m.logoutFunc(refreshToken)
+	}
+	return nil
+}
+
+func (m *mockSessionService) List(ctx context.Context) ([]*queries.SessionResponse, error) {
+	if m.listFunc != nil {
+		return m.listFunc(ctx)
+	}
+	return []*queries.SessionResponse{}, nil
+}
+
+func (m *mockSessionService) Revoke(ctx context.Context, id string) error {
+	if m.revokeFunc != nil {
+		return m.revokeFunc(ctx, id)
+	}
+	return nil
+}
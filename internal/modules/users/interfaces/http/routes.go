@@ -2,6 +2,7 @@ package http
 
 import (
 	"net/http"
+	"strings"
 
 	"fin-flow-api/internal/shared/interface/jwt"
 	"fin-flow-api/internal/shared/middleware"
@@ -9,10 +10,11 @@ import (
 
 var userHandler *Handler
 var authHandler *AuthHandler
+var clerkVerifier jwt.IdentityVerifier
 
 func SetupRoutes(mux *http.ServeMux, jwtService jwt.Service) {
 	mountUsers(mux, jwtService)
-	mountAuth(mux)
+	mountAuth(mux, jwtService)
 }
 
 func mountUsers(mux *http.ServeMux, jwtService jwt.Service) {
@@ -21,7 +23,7 @@ func mountUsers(mux *http.ServeMux, jwtService jwt.Service) {
 	protectedHandler := middleware.RequireAuth(jwtService)(http.HandlerFunc(handleUsersResource))
 	mux.Handle("/users/", protectedHandler)
 	
-	syncHandler := middleware.RequireClerkAuth(http.HandlerFunc(userHandler.SyncUser))
+	syncHandler := middleware.RequireClerkAuth(clerkVerifier)(http.HandlerFunc(userHandler.SyncUser))
 	mux.Handle("/users/sync", syncHandler)
 }
 
@@ -29,7 +31,8 @@ func handleUsersCollection(jwtService jwt.Service) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodGet:
-			middleware.RequireAuth(jwtService)(http.HandlerFunc(userHandler.ListUsers)).ServeHTTP(w, r)
+			listHandler := middleware.RequirePermission(middleware.PermissionListUsers, nil)(http.HandlerFunc(userHandler.ListUsers))
+			middleware.RequireAuth(jwtService)(listHandler).ServeHTTP(w, r)
 		case http.MethodPost:
 			userHandler.CreateUser(w, r)
 		default:
@@ -39,19 +42,39 @@ func handleUsersCollection(jwtService jwt.Service) http.HandlerFunc {
 }
 
 func handleUsersResource(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(strings.TrimSuffix(r.URL.Path, "/"), "/role") {
+		if r.Method == http.MethodPut {
+			requireUserPermission(middleware.PermissionAssignRole, userHandler.AssignRole).ServeHTTP(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
 	switch r.Method {
 	case http.MethodGet:
-		userHandler.GetUser(w, r)
+		requireUserPermission(middleware.PermissionReadUser, userHandler.GetUser).ServeHTTP(w, r)
 	case http.MethodPut:
-		userHandler.UpdateUser(w, r)
+		requireUserPermission(middleware.PermissionUpdateUser, userHandler.UpdateUser).ServeHTTP(w, r)
 	case http.MethodDelete:
-		userHandler.DeleteUser(w, r)
+		requireUserPermission(middleware.PermissionDeleteUser, userHandler.DeleteUser).ServeHTTP(w, r)
 	default:
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 	}
 }
 
-func mountAuth(mux *http.ServeMux) {
+func requireUserPermission(permission middleware.Permission, handler http.HandlerFunc) http.Handler {
+	return middleware.RequirePermission(permission, userIDFromPath)(handler)
+}
+
+// userIDFromPath returns the {id} of /users/{id}, the user who owns the
+// addressed resource.
+func userIDFromPath(r *http.Request) string {
+	path := strings.TrimPrefix(r.URL.Path, "/users/")
+	return strings.Split(path, "/")[0]
+}
+
+func mountAuth(mux *http.ServeMux, jwtService jwt.Service) {
 	mux.HandleFunc("/auth/login", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodPost {
 			authHandler.Login(w, r)
@@ -59,6 +82,25 @@ func mountAuth(mux *http.ServeMux) {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		}
 	})
+
+	mux.HandleFunc("/auth/refresh", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			authHandler.Refresh(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/auth/logout", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			authHandler.Logout(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.Handle("/auth/sessions", middleware.RequireAuth(jwtService)(http.HandlerFunc(authHandler.ListSessions)))
+	mux.Handle("/auth/sessions/", middleware.RequireAuth(jwtService)(http.HandlerFunc(authHandler.RevokeSession)))
 }
 
 func SetHandler(handler *Handler) {
@@ -67,4 +109,8 @@ func SetHandler(handler *Handler) {
 
 func SetAuthHandler(handler *AuthHandler) {
 	authHandler = handler
+}
+
+func SetClerkVerifier(verifier jwt.IdentityVerifier) {
+	clerkVerifier = verifier
 }
\ No newline at end of file
@@ -41,6 +41,7 @@ func (h *Handler) SyncUser(w http.ResponseWriter, r *http.Request) {
 		FirstName: user.FirstName,
 		LastName:  user.LastName,
 		Email:     user.Email,
+		Role:      user.Role,
 	}
 
 	basehandler.WriteJSON(w, http.StatusOK, response)
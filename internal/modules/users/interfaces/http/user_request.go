@@ -5,4 +5,8 @@ type UserRequest struct {
 	LastName  string `json:"last_name"`
 	Email     string `json:"email"`
 	Password  string `json:"password"`
+}
+
+type RoleRequest struct {
+	Role string `json:"role"`
 }
\ No newline at end of file
@@ -1,8 +1,24 @@
 package commands
 
+import "fin-flow-api/internal/shared/domain"
+
 type WalletRequest struct {
-	Name     string  `json:"name"`
-	Type     int     `json:"type"`
-	Balance  float64 `json:"balance"`
-	Currency string  `json:"currency"`
-}
\ No newline at end of file
+	Name string `json:"name"`
+	Type int    `json:"type"`
+	// Balance is the opening balance. Updates ignore it because balances
+	// are kept in sync by transactions.
+	Balance  domain.Amount `json:"balance"`
+	Currency string        `json:"currency"`
+	// RateSeries is optional. Creates default it to the official series
+	// and updates keep the current one when it is empty.
+	RateSeries string `json:"rate_series"`
+	// CreditLimit, StatementClosingDay and PaymentDueDay are the billing
+	// terms of credit card wallets. Creating a card requires them; updates
+	// keep the current terms when CreditLimit is nil.
+	CreditLimit         *domain.Amount `json:"credit_limit"`
+	StatementClosingDay int            `json:"statement_closing_day"`
+	PaymentDueDay       int            `json:"payment_due_day"`
+	// AccountID links the wallet to a bank account. Updates keep the
+	// current one when it is nil and unlink the wallet when it is empty.
+	AccountID *string `json:"account_id"`
+}
@@ -0,0 +1,31 @@
+package queries
+
+import (
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+// ValuationResponse is the caller's wallets valued in one currency.
+type ValuationResponse struct {
+	Currency string
+	Date     time.Time
+	Total    domain.Amount
+	Wallets  []*WalletValuationResponse
+}
+
+type WalletValuationResponse struct {
+	WalletID   string
+	Name       string
+	Currency   string
+	Balance    domain.Amount
+	RateSeries string
+	Converted  domain.Amount
+	Rate       domain.Amount
+	// SeriesUsed is the series of the rates that valued the wallet, which
+	// is the official one when RateSeries is not quoted for the pair. It is
+	// empty for wallets already in the target currency.
+	SeriesUsed string
+	AsOf       time.Time
+	Via        string
+}
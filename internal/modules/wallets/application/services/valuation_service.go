@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	exchangeratedomain "fin-flow-api/internal/modules/exchangerates/domain"
+	"fin-flow-api/internal/modules/wallets/application/contracts/queries"
+	"fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/middleware"
+)
+
+type currencyConverter interface {
+	Base() domain.Currency
+	Convert(amount shareddomain.Amount, from, to domain.Currency, series domain.RateSeries, date time.Time) (shareddomain.Amount, *exchangeratedomain.CrossRate, error)
+}
+
+// ValuationService values the caller's wallets in a single currency. Each
+// wallet is converted with the rate series it picked.
+type ValuationService struct {
+	repository domain.WalletRepository
+	converter  currencyConverter
+}
+
+func NewValuationService(repository domain.WalletRepository, converter currencyConverter) *ValuationService {
+	return &ValuationService{
+		repository: repository,
+		converter:  converter,
+	}
+}
+
+func (s *ValuationService) getUserIDFromContext(ctx context.Context) (string, error) {
+	userID, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok {
+		return "", errors.New("user not authenticated")
+	}
+	return userID, nil
+}
+
+// Value converts the current balance of every wallet into currency with the
+// rates in effect on date. An empty currency means the converter's base
+// currency. The valuation fails if any wallet cannot be converted, rather
+// than report a partial total.
+func (s *ValuationService) Value(ctx context.Context, currency string, date time.Time) (*queries.ValuationResponse, error) {
+	userID, err := s.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	target := s.converter.Base()
+	if currency != "" {
+		target = domain.Currency(strings.ToUpper(currency))
+	}
+	if !domain.IsValidCurrency(target.String()) {
+		return nil, domain.ErrInvalidCurrency
+	}
+
+	wallets, err := s.repository.List(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &queries.ValuationResponse{
+		Currency: target.String(),
+		Date:     exchangeratedomain.DayOf(date),
+		Wallets:  make([]*queries.WalletValuationResponse, len(wallets)),
+	}
+
+	for i, wallet := range wallets {
+		converted, rate, err := s.converter.Convert(wallet.Balance, wallet.Currency, target, wallet.RateSeries, date)
+		if err != nil {
+			if errors.Is(err, exchangeratedomain.ErrRateNotFound) {
+				return nil, fmt.Errorf("%w for wallet %q (%s/%s, %s series)", err, wallet.Name, wallet.Currency, target, wallet.RateSeries)
+			}
+			return nil, err
+		}
+
+		response.Wallets[i] = &queries.WalletValuationResponse{
+			WalletID:   wallet.ID,
+			Name:       wallet.Name,
+			Currency:   wallet.Currency.String(),
+			Balance:    wallet.Balance,
+			RateSeries: wallet.RateSeries.String(),
+			Converted:  converted,
+			Rate:       rate.Rate,
+			SeriesUsed: rate.Series.String(),
+			AsOf:       rate.AsOf,
+			Via:        rate.Via.String(),
+		}
+		response.Total = response.Total.Add(converted)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	exchangeratedomain "fin-flow-api/internal/modules/exchangerates/domain"
+	"fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+// mockConverter quotes fixed rates into the target currency per source
+// currency and series.
+type mockConverter struct {
+	base   domain.Currency
+	rates  map[string]string
+	series []domain.RateSeries
+}
+
+func (m *mockConverter) Base() domain.Currency {
+	return m.base
+}
+
+func (m *mockConverter) Convert(amount shareddomain.Amount, from, to domain.Currency, series domain.RateSeries, date time.Time) (shareddomain.Amount, *exchangeratedomain.CrossRate, error) {
+	m.series = append(m.series, series)
+	rate := &exchangeratedomain.CrossRate{From: from, To: to, Rate: shareddomain.MustParseAmount("1"), AsOf: exchangeratedomain.DayOf(date)}
+	if from != to {
+		value, ok := m.rates[from.String()+"/"+series.String()]
+		if !ok {
+			return shareddomain.Amount{}, nil, exchangeratedomain.ErrRateNotFound
+		}
+		rate.Rate = shareddomain.MustParseAmount(value)
+		rate.Series = series
+	}
+	return rate.Convert(amount), rate, nil
+}
+
+func newValuationFixture(wallets ...*domain.Wallet) (*ValuationService, *mockConverter) {
+	repo := newMockWalletRepository()
+	for _, wallet := range wallets {
+		repo.wallets[wallet.ID] = wallet
+	}
+	converter := &mockConverter{base: domain.CurrencyUSD, rates: map[string]string{
+		"ARS/official": "0.001",
+		"ARS/blue":     "0.0008",
+	}}
+	return NewValuationService(repo, converter), converter
+}
+
+func TestValuationService_Value_UsesWalletSeries(t *testing.T) {
+	pesos := domain.NewWallet("wallet1", "user1", "Pesos", domain.WalletTypeCash, shareddomain.MustParseAmount("100000"), domain.CurrencyARS, "system")
+	pesos.RateSeries = domain.RateSeriesBlue
+	dollars := domain.NewWallet("wallet2", "user1", "Dollars", domain.WalletTypeBank, shareddomain.MustParseAmount("250.50"), domain.CurrencyUSD, "system")
+	service, converter := newValuationFixture(pesos, dollars)
+
+	valuation, err := service.Value(&mockContext{userID: "user1", hasID: true}, "", time.Date(2026, 1, 5, 15, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Value failed: %v", err)
+	}
+
+	if valuation.Currency != "USD" {
+		t.Errorf("expected the base currency USD, got %s", valuation.Currency)
+	}
+	if valuation.Total.String() != "330.5" {
+		t.Errorf("expected total 330.5, got %s", valuation.Total)
+	}
+	if len(valuation.Wallets) != 2 {
+		t.Fatalf("expected 2 wallets, got %d", len(valuation.Wallets))
+	}
+	for _, wallet := range valuation.Wallets {
+		if wallet.WalletID == "wallet1" && (wallet.SeriesUsed != "blue" || wallet.Converted.String() != "80") {
+			t.Errorf("unexpected valuation for the pesos wallet %+v", wallet)
+		}
+	}
+
+	if len(converter.series) != 2 || converter.series[0] == converter.series[1] {
+		t.Errorf("expected each wallet to be converted with its own series, got %v", converter.series)
+	}
+}
+
+func TestValuationService_Value_RateNotFoundNamesWallet(t *testing.T) {
+	pesos := domain.NewWallet("wallet1", "user1", "Pesos", domain.WalletTypeCash, shareddomain.MustParseAmount("100000"), domain.CurrencyARS, "system")
+	pesos.RateSeries = domain.RateSeriesCCL
+	service, _ := newValuationFixture(pesos)
+
+	_, err := service.Value(&mockContext{userID: "user1", hasID: true}, "usd", time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC))
+	if !errors.Is(err, exchangeratedomain.ErrRateNotFound) {
+		t.Fatalf("expected %v, got %v", exchangeratedomain.ErrRateNotFound, err)
+	}
+	if !strings.Contains(err.Error(), `"Pesos" (ARS/USD, ccl series)`) {
+		t.Errorf("expected the wallet and series in the error, got %q", err.Error())
+	}
+}
+
+func TestValuationService_Value_Errors(t *testing.T) {
+	service, _ := newValuationFixture()
+
+	if _, err := service.Value(&mockContext{hasID: false}, "", time.Now()); err == nil || err.Error() != "user not authenticated" {
+		t.Errorf("expected 'user not authenticated', got %v", err)
+	}
+	if _, err := service.Value(&mockContext{userID: "user1", hasID: true}, "XXX", time.Now()); err != domain.ErrInvalidCurrency {
+		t.Errorf("expected ErrInvalidCurrency, got %v", err)
+	}
+}
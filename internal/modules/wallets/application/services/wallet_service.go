@@ -6,20 +6,23 @@ import (
 	"fin-flow-api/internal/modules/wallets/application/contracts/commands"
 	"fin-flow-api/internal/modules/wallets/application/contracts/queries"
 	"fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
 	"fin-flow-api/internal/shared/middleware"
 
 	"github.com/google/uuid"
 )
 
 type WalletService struct {
-	repository domain.WalletRepository
-	systemUser string
+	repository         domain.WalletRepository
+	activityRepository domain.WalletActivityRepository
+	systemUser         string
 }
 
-func NewWalletService(repository domain.WalletRepository, systemUser string) *WalletService {
+func NewWalletService(repository domain.WalletRepository, activityRepository domain.WalletActivityRepository, systemUser string) *WalletService {
 	return &WalletService{
-		repository: repository,
-		systemUser: systemUser,
+		repository:         repository,
+		activityRepository: activityRepository,
+		systemUser:         systemUser,
 	}
 }
 
@@ -46,6 +49,14 @@ func (s *WalletService) Create(ctx context.Context, req commands.WalletRequest)
 		return domain.ErrInvalidCurrency
 	}
 
+	if !req.Balance.FitsCurrency(req.Currency) {
+		return shareddomain.ErrAmountPrecision
+	}
+
+	if req.RateSeries != "" && !domain.IsValidRateSeries(req.RateSeries) {
+		return domain.ErrInvalidRateSeries
+	}
+
 	id := uuid.New().String()
 
 	wallet := domain.NewWallet(
@@ -57,6 +68,20 @@ func (s *WalletService) Create(ctx context.Context, req commands.WalletRequest)
 		domain.Currency(req.Currency),
 		s.systemUser,
 	)
+	if req.RateSeries != "" {
+		wallet.RateSeries = domain.RateSeries(req.RateSeries)
+	}
+	if req.AccountID != nil {
+		wallet.AccountID = *req.AccountID
+	}
+
+	terms := creditCardTerms(req)
+	if walletType == domain.WalletTypeCreditCard && terms == nil {
+		return domain.ErrCreditCardTermsRequired
+	}
+	if err := wallet.SetCreditCardTerms(terms); err != nil {
+		return err
+	}
 
 	return s.repository.Create(wallet)
 }
@@ -81,10 +106,40 @@ func (s *WalletService) Update(ctx context.Context, walletID string, req command
 		return domain.ErrInvalidCurrency
 	}
 
+	if req.RateSeries != "" && !domain.IsValidRateSeries(req.RateSeries) {
+		return domain.ErrInvalidRateSeries
+	}
+
+	if currency := domain.Currency(req.Currency); currency != wallet.Currency || walletType != wallet.Type {
+		activity, err := s.activityRepository.Activity(wallet.ID, userID)
+		if err != nil {
+			return err
+		}
+		if err := wallet.ChangeCurrency(currency, activity); err != nil {
+			return err
+		}
+		if err := wallet.ChangeType(walletType, activity); err != nil {
+			return err
+		}
+	}
+
 	wallet.Name = req.Name
-	wallet.Type = walletType
-	wallet.Balance = req.Balance
-	wallet.Currency = domain.Currency(req.Currency)
+	if req.RateSeries != "" {
+		wallet.RateSeries = domain.RateSeries(req.RateSeries)
+	}
+	if req.AccountID != nil {
+		wallet.AccountID = *req.AccountID
+	}
+
+	// Cards keep their terms when the request leaves them out; any other
+	// type drops them, so a card turned into a bank account loses its limit.
+	terms := creditCardTerms(req)
+	if terms == nil && walletType == domain.WalletTypeCreditCard {
+		terms = wallet.CreditCard
+	}
+	if err := wallet.SetCreditCardTerms(terms); err != nil {
+		return err
+	}
 	wallet.Entity.UpdateModified(s.systemUser)
 
 	return s.repository.Update(wallet)
@@ -109,18 +164,7 @@ func (s *WalletService) GetByID(ctx context.Context, walletID string) (*queries.
 		return nil, err
 	}
 
-	return &queries.WalletResponse{
-		ID:        wallet.ID,
-		Name:      wallet.Name,
-		Type:      wallet.Type.Value(),
-		TypeName:  wallet.Type.String(),
-		Balance:   wallet.Balance,
-		Currency:  wallet.Currency.String(),
-		CreatedAt: wallet.CreatedAt,
-		UpdatedAt: wallet.ModifiedAt,
-		CreatedBy: wallet.CreatedBy,
-		UpdatedBy: wallet.ModifiedBy,
-	}, nil
+	return toWalletResponse(wallet), nil
 }
 
 func (s *WalletService) List(ctx context.Context) ([]*queries.WalletResponse, error) {
@@ -136,19 +180,50 @@ func (s *WalletService) List(ctx context.Context) ([]*queries.WalletResponse, er
 
 	responses := make([]*queries.WalletResponse, len(wallets))
 	for i, wallet := range wallets {
-		responses[i] = &queries.WalletResponse{
-			ID:        wallet.ID,
-			Name:      wallet.Name,
-			Type:      wallet.Type.Value(),
-			TypeName:  wallet.Type.String(),
-			Balance:   wallet.Balance,
-			Currency:  wallet.Currency.String(),
-			CreatedAt: wallet.CreatedAt,
-			UpdatedAt: wallet.ModifiedAt,
-			CreatedBy: wallet.CreatedBy,
-			UpdatedBy: wallet.ModifiedBy,
-		}
+		responses[i] = toWalletResponse(wallet)
 	}
 
 	return responses, nil
-}
\ No newline at end of file
+}
+
+func toWalletResponse(wallet *domain.Wallet) *queries.WalletResponse {
+	response := &queries.WalletResponse{
+		ID:         wallet.ID,
+		Name:       wallet.Name,
+		Type:       wallet.Type.Value(),
+		TypeName:   wallet.Type.String(),
+		Balance:    wallet.Balance,
+		Currency:   wallet.Currency.String(),
+		RateSeries: wallet.RateSeries.String(),
+		AccountID:  wallet.AccountID,
+		CreatedAt:  wallet.CreatedAt,
+		UpdatedAt:  wallet.ModifiedAt,
+		CreatedBy:  wallet.CreatedBy,
+		UpdatedBy:  wallet.ModifiedBy,
+	}
+
+	if wallet.CreditCard != nil {
+		creditLimit := wallet.CreditCard.CreditLimit
+		response.CreditLimit = &creditLimit
+		response.StatementClosingDay = wallet.CreditCard.ClosingDay
+		response.PaymentDueDay = wallet.CreditCard.DueDay
+		if available, err := wallet.AvailableCredit(); err == nil {
+			response.AvailableCredit = &available
+		}
+	}
+
+	return response
+}
+
+// creditCardTerms returns the billing terms sent with the request, or nil
+// when the request carries no credit limit.
+func creditCardTerms(req commands.WalletRequest) *domain.CreditCardTerms {
+	if req.CreditLimit == nil {
+		return nil
+	}
+	return &domain.CreditCardTerms{
+		CreditLimit: *req.CreditLimit,
+		ClosingDay:  req.StatementClosingDay,
+		DueDay:      req.PaymentDueDay,
+	}
+}
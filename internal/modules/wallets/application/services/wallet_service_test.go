@@ -5,12 +5,14 @@ import (
 	"errors"
 	"fin-flow-api/internal/modules/wallets/application/contracts/commands"
 	"fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
 	"fin-flow-api/internal/shared/middleware"
 	"testing"
 )
 
 type mockWalletRepository struct {
 	wallets  map[string]*domain.Wallet
+	activity map[string]domain.Activity
 	createErr  error
 	getByIDErr error
 	updateErr  error
@@ -20,7 +22,8 @@ type mockWalletRepository struct {
 
 func newMockWalletRepository() *mockWalletRepository {
 	return &mockWalletRepository{
-		wallets: make(map[string]*domain.Wallet),
+		wallets:  make(map[string]*domain.Wallet),
+		activity: make(map[string]domain.Activity),
 	}
 }
 
@@ -89,6 +92,10 @@ func (m *mockWalletRepository) Delete(id string, userID string) error {
 	return nil
 }
 
+func (m *mockWalletRepository) Activity(id string, userID string) (domain.Activity, error) {
+	return m.activity[id], nil
+}
+
 type mockContext struct {
 	context.Context
 	userID string
@@ -107,7 +114,7 @@ func (m *mockContext) Value(key interface{}) interface{} {
 
 func TestNewWalletService(t *testing.T) {
 	repo := newMockWalletRepository()
-	service := NewWalletService(repo, "system")
+	service := NewWalletService(repo, repo, "system")
 
 	if service == nil {
 		t.Fatal("NewWalletService returned nil")
@@ -122,14 +129,14 @@ func TestNewWalletService(t *testing.T) {
 
 func TestWalletService_Create(t *testing.T) {
 	repo := newMockWalletRepository()
-	service := NewWalletService(repo, "system")
+	service := NewWalletService(repo, repo, "system")
 
 	ctx := &mockContext{userID: "user1", hasID: true}
 
 	req := commands.WalletRequest{
 		Name:     "Main Account",
 		Type:     0,
-		Balance:  1000.50,
+		Balance:  shareddomain.MustParseAmount("1000.50"),
 		Currency: "USD",
 	}
 
@@ -145,14 +152,14 @@ func TestWalletService_Create(t *testing.T) {
 
 func TestWalletService_Create_InvalidType(t *testing.T) {
 	repo := newMockWalletRepository()
-	service := NewWalletService(repo, "system")
+	service := NewWalletService(repo, repo, "system")
 
 	ctx := &mockContext{userID: "user1", hasID: true}
 
 	req := commands.WalletRequest{
 		Name:     "Main Account",
 		Type:     99,
-		Balance:  1000.50,
+		Balance:  shareddomain.MustParseAmount("1000.50"),
 		Currency: "USD",
 	}
 
@@ -167,14 +174,14 @@ func TestWalletService_Create_InvalidType(t *testing.T) {
 
 func TestWalletService_Create_InvalidCurrency(t *testing.T) {
 	repo := newMockWalletRepository()
-	service := NewWalletService(repo, "system")
+	service := NewWalletService(repo, repo, "system")
 
 	ctx := &mockContext{userID: "user1", hasID: true}
 
 	req := commands.WalletRequest{
 		Name:     "Main Account",
 		Type:     0,
-		Balance:  1000.50,
+		Balance:  shareddomain.MustParseAmount("1000.50"),
 		Currency: "INVALID",
 	}
 
@@ -187,16 +194,99 @@ func TestWalletService_Create_InvalidCurrency(t *testing.T) {
 	}
 }
 
+func TestWalletService_Create_BalancePrecision(t *testing.T) {
+	tests := []struct {
+		name     string
+		balance  string
+		currency string
+		wantErr  error
+	}{
+		{"usd cents", "1000.50", "USD", nil},
+		{"usd fraction of cent", "10.005", "USD", shareddomain.ErrAmountPrecision},
+		{"jpy whole", "1500", "JPY", nil},
+		{"jpy fraction", "1500.5", "JPY", shareddomain.ErrAmountPrecision},
+		{"btc satoshis", "0.00012345", "BTC", nil},
+		{"eth wei", "0.000000000000000001", "ETH", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockWalletRepository()
+			service := NewWalletService(repo, repo, "system")
+			ctx := &mockContext{userID: "user1", hasID: true}
+
+			req := commands.WalletRequest{
+				Name:     "Main Account",
+				Type:     0,
+				Balance:  shareddomain.MustParseAmount(tt.balance),
+				Currency: tt.currency,
+			}
+
+			err := service.Create(ctx, req)
+			if err != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			for _, wallet := range repo.wallets {
+				if !wallet.Balance.Equal(shareddomain.MustParseAmount(tt.balance)) {
+					t.Errorf("expected balance %s, got %s", tt.balance, wallet.Balance)
+				}
+			}
+		})
+	}
+}
+
+func TestWalletService_Create_RateSeries(t *testing.T) {
+	tests := []struct {
+		name     string
+		series   string
+		expected domain.RateSeries
+		wantErr  error
+	}{
+		{"defaults to official", "", domain.RateSeriesOfficial, nil},
+		{"blue", "blue", domain.RateSeriesBlue, nil},
+		{"invalid", "tarjeta", "", domain.ErrInvalidRateSeries},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockWalletRepository()
+			service := NewWalletService(repo, repo, "system")
+			ctx := &mockContext{userID: "user1", hasID: true}
+
+			req := commands.WalletRequest{
+				Name:       "Pesos",
+				Type:       0,
+				Balance:    shareddomain.MustParseAmount("1000"),
+				Currency:   "ARS",
+				RateSeries: tt.series,
+			}
+
+			err := service.Create(ctx, req)
+			if err != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			for _, wallet := range repo.wallets {
+				if wallet.RateSeries != tt.expected {
+					t.Errorf("expected series %s, got %s", tt.expected, wallet.RateSeries)
+				}
+			}
+		})
+	}
+}
+
 func TestWalletService_Create_NotAuthenticated(t *testing.T) {
 	repo := newMockWalletRepository()
-	service := NewWalletService(repo, "system")
+	service := NewWalletService(repo, repo, "system")
 
 	ctx := &mockContext{hasID: false}
 
 	req := commands.WalletRequest{
 		Name:     "Main Account",
 		Type:     0,
-		Balance:  1000.50,
+		Balance:  shareddomain.MustParseAmount("1000.50"),
 		Currency: "USD",
 	}
 
@@ -211,9 +301,9 @@ func TestWalletService_Create_NotAuthenticated(t *testing.T) {
 
 func TestWalletService_GetByID(t *testing.T) {
 	repo := newMockWalletRepository()
-	service := NewWalletService(repo, "system")
+	service := NewWalletService(repo, repo, "system")
 
-	wallet := domain.NewWallet("wallet1", "user1", "Main Account", domain.WalletTypeBank, 1000.50, domain.CurrencyUSD, "system")
+	wallet := domain.NewWallet("wallet1", "user1", "Main Account", domain.WalletTypeBank, shareddomain.MustParseAmount("1000.50"), domain.CurrencyUSD, "system")
 	repo.wallets["wallet1"] = wallet
 
 	ctx := &mockContext{userID: "user1", hasID: true}
@@ -230,7 +320,7 @@ func TestWalletService_GetByID(t *testing.T) {
 
 func TestWalletService_GetByID_NotFound(t *testing.T) {
 	repo := newMockWalletRepository()
-	service := NewWalletService(repo, "system")
+	service := NewWalletService(repo, repo, "system")
 
 	ctx := &mockContext{userID: "user1", hasID: true}
 
@@ -245,9 +335,9 @@ func TestWalletService_GetByID_NotFound(t *testing.T) {
 
 func TestWalletService_GetByID_Unauthorized(t *testing.T) {
 	repo := newMockWalletRepository()
-	service := NewWalletService(repo, "system")
+	service := NewWalletService(repo, repo, "system")
 
-	wallet := domain.NewWallet("wallet1", "user1", "Main Account", domain.WalletTypeBank, 1000.50, domain.CurrencyUSD, "system")
+	wallet := domain.NewWallet("wallet1", "user1", "Main Account", domain.WalletTypeBank, shareddomain.MustParseAmount("1000.50"), domain.CurrencyUSD, "system")
 	repo.wallets["wallet1"] = wallet
 
 	ctx := &mockContext{userID: "user2", hasID: true}
@@ -263,9 +353,9 @@ func TestWalletService_GetByID_Unauthorized(t *testing.T) {
 
 func TestWalletService_Update(t *testing.T) {
 	repo := newMockWalletRepository()
-	service := NewWalletService(repo, "system")
+	service := NewWalletService(repo, repo, "system")
 
-	wallet := domain.NewWallet("wallet1", "user1", "Main Account", domain.WalletTypeBank, 1000.50, domain.CurrencyUSD, "system")
+	wallet := domain.NewWallet("wallet1", "user1", "Main Account", domain.WalletTypeBank, shareddomain.MustParseAmount("1000.50"), domain.CurrencyUSD, "system")
 	repo.wallets["wallet1"] = wallet
 
 	ctx := &mockContext{userID: "user1", hasID: true}
@@ -273,8 +363,8 @@ func TestWalletService_Update(t *testing.T) {
 	req := commands.WalletRequest{
 		Name:     "Savings Account",
 		Type:     4,
-		Balance:  5000.00,
-		Currency: "EUR",
+		Balance:  shareddomain.MustParseAmount("5000.00"),
+		Currency: "USD",
 	}
 
 	err := service.Update(ctx, "wallet1", req)
@@ -286,21 +376,160 @@ func TestWalletService_Update(t *testing.T) {
 	if updated.Name != "Savings Account" {
 		t.Errorf("expected name 'Savings Account', got %s", updated.Name)
 	}
-	if updated.Balance != 5000.00 {
-		t.Errorf("expected balance 5000.00, got %f", updated.Balance)
+	if !updated.Balance.Equal(shareddomain.MustParseAmount("1000.50")) {
+		t.Errorf("expected balance to stay 1000.50, got %s", updated.Balance)
+	}
+}
+
+func TestWalletService_Update_CurrencyLocked(t *testing.T) {
+	tests := []struct {
+		name     string
+		balance  string
+		activity domain.Activity
+		wantErr  error
+	}{
+		{"unused wallet", "0", domain.Activity{}, nil},
+		{"balance", "10", domain.Activity{}, domain.ErrCurrencyLocked},
+		{"transactions", "0", domain.Activity{Transactions: 2}, domain.ErrCurrencyLocked},
+		{"trades", "0", domain.Activity{Trades: 1}, domain.ErrCurrencyLocked},
+		{"reconciliations", "0", domain.Activity{Reconciliations: 1}, domain.ErrCurrencyLocked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockWalletRepository()
+			service := NewWalletService(repo, repo, "system")
+
+			repo.wallets["wallet1"] = domain.NewWallet("wallet1", "user1", "Bitcoin", domain.WalletTypeInvestment, shareddomain.MustParseAmount(tt.balance), domain.CurrencyBTC, "system")
+			repo.activity["wallet1"] = tt.activity
+
+			ctx := &mockContext{userID: "user1", hasID: true}
+			req := commands.WalletRequest{Name: "Bitcoin", Type: 5, Currency: "USD"}
+
+			if err := service.Update(ctx, "wallet1", req); err != tt.wantErr {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+
+			want := domain.CurrencyUSD
+			if tt.wantErr != nil {
+				want = domain.CurrencyBTC
+			}
+			if got := repo.wallets["wallet1"].Currency; got != want {
+				t.Errorf("expected currency %s, got %s", want, got)
+			}
+		})
+	}
+}
+
+func TestWalletService_Update_InvestmentWithTrades(t *testing.T) {
+	repo := newMockWalletRepository()
+	service := NewWalletService(repo, repo, "system")
+
+	repo.wallets["wallet1"] = domain.NewWallet("wallet1", "user1", "Broker", domain.WalletTypeInvestment, shareddomain.MustParseAmount("2500"), domain.CurrencyUSD, "system")
+	repo.activity["wallet1"] = domain.Activity{Transactions: 3, Trades: 2}
+
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	req := commands.WalletRequest{Name: "Broker", Type: 4, Currency: "USD"}
+	if err := service.Update(ctx, "wallet1", req); err != domain.ErrInvestmentWalletHasTrades {
+		t.Fatalf("expected ErrInvestmentWalletHasTrades, got %v", err)
+	}
+	if repo.wallets["wallet1"].Type != domain.WalletTypeInvestment {
+		t.Errorf("expected the wallet to stay an investment wallet, got %v", repo.wallets["wallet1"].Type)
+	}
+
+	req.Name = "Brokerage"
+	req.Type = 5
+	if err := service.Update(ctx, "wallet1", req); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	repo.activity["wallet1"] = domain.Activity{Transactions: 3}
+	req.Type = 4
+	if err := service.Update(ctx, "wallet1", req); err != nil {
+		t.Fatalf("expected a wallet without trades to change type, got %v", err)
+	}
+	if repo.wallets["wallet1"].Type != domain.WalletTypeSavings {
+		t.Errorf("expected a savings wallet, got %v", repo.wallets["wallet1"].Type)
+	}
+}
+
+func TestWalletService_Update_RateSeries(t *testing.T) {
+	repo := newMockWalletRepository()
+	service := NewWalletService(repo, repo, "system")
+
+	wallet := domain.NewWallet("wallet1", "user1", "Pesos", domain.WalletTypeBank, shareddomain.MustParseAmount("1000"), domain.CurrencyARS, "system")
+	repo.wallets["wallet1"] = wallet
+
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	req := commands.WalletRequest{Name: "Pesos", Type: 0, Currency: "ARS", RateSeries: "mep"}
+	if err := service.Update(ctx, "wallet1", req); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if series := repo.wallets["wallet1"].RateSeries; series != domain.RateSeriesMEP {
+		t.Errorf("expected series mep, got %s", series)
+	}
+
+	req.RateSeries = ""
+	if err := service.Update(ctx, "wallet1", req); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if series := repo.wallets["wallet1"].RateSeries; series != domain.RateSeriesMEP {
+		t.Errorf("expected series to stay mep, got %s", series)
+	}
+
+	req.RateSeries = "tarjeta"
+	if err := service.Update(ctx, "wallet1", req); err != domain.ErrInvalidRateSeries {
+		t.Errorf("expected ErrInvalidRateSeries, got %v", err)
+	}
+}
+
+func TestWalletService_AccountID(t *testing.T) {
+	repo := newMockWalletRepository()
+	service := NewWalletService(repo, repo, "system")
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	accountID := "0001-234"
+	err := service.Create(ctx, commands.WalletRequest{Name: "Checking", Type: 0, Currency: "USD", AccountID: &accountID})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	var wallet *domain.Wallet
+	for _, created := range repo.wallets {
+		wallet = created
+	}
+	if wallet.AccountID != "0001-234" {
+		t.Fatalf("expected account id 0001-234, got %q", wallet.AccountID)
+	}
+
+	if err := service.Update(ctx, wallet.ID, commands.WalletRequest{Name: "Checking", Type: 0, Currency: "USD"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if wallet.AccountID != "0001-234" {
+		t.Errorf("expected the account id to be kept, got %q", wallet.AccountID)
+	}
+
+	empty := ""
+	if err := service.Update(ctx, wallet.ID, commands.WalletRequest{Name: "Checking", Type: 0, Currency: "USD", AccountID: &empty}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if wallet.AccountID != "" {
+		t.Errorf("expected the account id to be cleared, got %q", wallet.AccountID)
 	}
 }
 
 func TestWalletService_Update_NotFound(t *testing.T) {
 	repo := newMockWalletRepository()
-	service := NewWalletService(repo, "system")
+	service := NewWalletService(repo, repo, "system")
 
 	ctx := &mockContext{userID: "user1", hasID: true}
 
 	req := commands.WalletRequest{
 		Name:     "Savings Account",
 		Type:     4,
-		Balance:  5000.00,
+		Balance:  shareddomain.MustParseAmount("5000.00"),
 		Currency: "EUR",
 	}
 
@@ -315,9 +544,9 @@ func TestWalletService_Update_NotFound(t *testing.T) {
 
 func TestWalletService_Update_Unauthorized(t *testing.T) {
 	repo := newMockWalletRepository()
-	service := NewWalletService(repo, "system")
+	service := NewWalletService(repo, repo, "system")
 
-	wallet := domain.NewWallet("wallet1", "user1", "Main Account", domain.WalletTypeBank, 1000.50, domain.CurrencyUSD, "system")
+	wallet := domain.NewWallet("wallet1", "user1", "Main Account", domain.WalletTypeBank, shareddomain.MustParseAmount("1000.50"), domain.CurrencyUSD, "system")
 	repo.wallets["wallet1"] = wallet
 
 	ctx := &mockContext{userID: "user2", hasID: true}
@@ -325,7 +554,7 @@ func TestWalletService_Update_Unauthorized(t *testing.T) {
 	req := commands.WalletRequest{
 		Name:     "Savings Account",
 		Type:     4,
-		Balance:  5000.00,
+		Balance:  shareddomain.MustParseAmount("5000.00"),
 		Currency: "EUR",
 	}
 
@@ -340,9 +569,9 @@ func TestWalletService_Update_Unauthorized(t *testing.T) {
 
 func TestWalletService_Delete(t *testing.T) {
 	repo := newMockWalletRepository()
-	service := NewWalletService(repo, "system")
+	service := NewWalletService(repo, repo, "system")
 
-	wallet := domain.NewWallet("wallet1", "user1", "Main Account", domain.WalletTypeBank, 1000.50, domain.CurrencyUSD, "system")
+	wallet := domain.NewWallet("wallet1", "user1", "Main Account", domain.WalletTypeBank, shareddomain.MustParseAmount("1000.50"), domain.CurrencyUSD, "system")
 	repo.wallets["wallet1"] = wallet
 
 	ctx := &mockContext{userID: "user1", hasID: true}
@@ -359,7 +588,7 @@ func TestWalletService_Delete(t *testing.T) {
 
 func TestWalletService_Delete_NotFound(t *testing.T) {
 	repo := newMockWalletRepository()
-	service := NewWalletService(repo, "system")
+	service := NewWalletService(repo, repo, "system")
 
 	ctx := &mockContext{userID: "user1", hasID: true}
 
@@ -374,9 +603,9 @@ func TestWalletService_Delete_NotFound(t *testing.T) {
 
 func TestWalletService_Delete_Unauthorized(t *testing.T) {
 	repo := newMockWalletRepository()
-	service := NewWalletService(repo, "system")
+	service := NewWalletService(repo, repo, "system")
 
-	wallet := domain.NewWallet("wallet1", "user1", "Main Account", domain.WalletTypeBank, 1000.50, domain.CurrencyUSD, "system")
+	wallet := domain.NewWallet("wallet1", "user1", "Main Account", domain.WalletTypeBank, shareddomain.MustParseAmount("1000.50"), domain.CurrencyUSD, "system")
 	repo.wallets["wallet1"] = wallet
 
 	ctx := &mockContext{userID: "user2", hasID: true}
@@ -392,11 +621,11 @@ func TestWalletService_Delete_Unauthorized(t *testing.T) {
 
 func TestWalletService_List(t *testing.T) {
 	repo := newMockWalletRepository()
-	service := NewWalletService(repo, "system")
+	service := NewWalletService(repo, repo, "system")
 
-	wallet1 := domain.NewWallet("wallet1", "user1", "Main Account", domain.WalletTypeBank, 1000.50, domain.CurrencyUSD, "system")
-	wallet2 := domain.NewWallet("wallet2", "user1", "Savings", domain.WalletTypeSavings, 5000.00, domain.CurrencyEUR, "system")
-	wallet3 := domain.NewWallet("wallet3", "user2", "Cash", domain.WalletTypeCash, 100.00, domain.CurrencyUSD, "system")
+	wallet1 := domain.NewWallet("wallet1", "user1", "Main Account", domain.WalletTypeBank, shareddomain.MustParseAmount("1000.50"), domain.CurrencyUSD, "system")
+	wallet2 := domain.NewWallet("wallet2", "user1", "Savings", domain.WalletTypeSavings, shareddomain.MustParseAmount("5000.00"), domain.CurrencyEUR, "system")
+	wallet3 := domain.NewWallet("wallet3", "user2", "Cash", domain.WalletTypeCash, shareddomain.MustParseAmount("100.00"), domain.CurrencyUSD, "system")
 	repo.wallets["wallet1"] = wallet1
 	repo.wallets["wallet2"] = wallet2
 	repo.wallets["wallet3"] = wallet3
@@ -415,7 +644,7 @@ func TestWalletService_List(t *testing.T) {
 
 func TestWalletService_List_Empty(t *testing.T) {
 	repo := newMockWalletRepository()
-	service := NewWalletService(repo, "system")
+	service := NewWalletService(repo, repo, "system")
 
 	ctx := &mockContext{userID: "user1", hasID: true}
 
@@ -427,4 +656,91 @@ func TestWalletService_List_Empty(t *testing.T) {
 	if len(result) != 0 {
 		t.Errorf("expected 0 wallets, got %d", len(result))
 	}
-}
\ No newline at end of file
+}
+func TestWalletService_Create_CreditCardTerms(t *testing.T) {
+	limit := shareddomain.MustParseAmount("500000")
+	tooPrecise := shareddomain.MustParseAmount("100.005")
+
+	tests := []struct {
+		name    string
+		req     commands.WalletRequest
+		wantErr error
+	}{
+		{"card with terms", commands.WalletRequest{Name: "Visa", Type: 2, Currency: "ARS", CreditLimit: &limit, StatementClosingDay: 25, PaymentDueDay: 5}, nil},
+		{"card without terms", commands.WalletRequest{Name: "Visa", Type: 2, Currency: "ARS"}, domain.ErrCreditCardTermsRequired},
+		{"bank with terms", commands.WalletRequest{Name: "Bank", Type: 0, Currency: "ARS", CreditLimit: &limit, StatementClosingDay: 25, PaymentDueDay: 5}, domain.ErrCreditCardTermsNotAllowed},
+		{"closing day out of range", commands.WalletRequest{Name: "Visa", Type: 2, Currency: "ARS", CreditLimit: &limit, StatementClosingDay: 32, PaymentDueDay: 5}, domain.ErrInvalidClosingDay},
+		{"due day missing", commands.WalletRequest{Name: "Visa", Type: 2, Currency: "ARS", CreditLimit: &limit, StatementClosingDay: 25}, domain.ErrInvalidDueDay},
+		{"limit too precise", commands.WalletRequest{Name: "Visa", Type: 2, Currency: "USD", CreditLimit: &tooPrecise, StatementClosingDay: 25, PaymentDueDay: 5}, shareddomain.ErrAmountPrecision},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockWalletRepository()
+			service := NewWalletService(repo, repo, "system")
+			ctx := &mockContext{userID: "user1", hasID: true}
+
+			err := service.Create(ctx, tt.req)
+			if err != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr != nil {
+				if len(repo.wallets) != 0 {
+					t.Error("expected no wallet to be created")
+				}
+				return
+			}
+			for _, wallet := range repo.wallets {
+				if wallet.CreditCard == nil || wallet.CreditCard.ClosingDay != 25 || wallet.CreditCard.DueDay != 5 {
+					t.Errorf("unexpected credit card terms %+v", wallet.CreditCard)
+				}
+			}
+		})
+	}
+}
+
+func TestWalletService_Update_CreditCardTerms(t *testing.T) {
+	repo := newMockWalletRepository()
+	service := NewWalletService(repo, repo, "system")
+
+	wallet := domain.NewWallet("wallet1", "user1", "Visa", domain.WalletTypeCreditCard, shareddomain.MustParseAmount("-120000"), domain.CurrencyARS, "system")
+	wallet.CreditCard = &domain.CreditCardTerms{CreditLimit: shareddomain.MustParseAmount("500000"), ClosingDay: 25, DueDay: 5}
+	repo.wallets["wallet1"] = wallet
+
+	ctx := &mockContext{userID: "user1", hasID: true}
+
+	req := commands.WalletRequest{Name: "Visa Gold", Type: 2, Currency: "ARS"}
+	if err := service.Update(ctx, "wallet1", req); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if terms := repo.wallets["wallet1"].CreditCard; terms == nil || terms.ClosingDay != 25 {
+		t.Fatalf("expected terms to be kept, got %+v", terms)
+	}
+
+	limit := shareddomain.MustParseAmount("800000")
+	req.CreditLimit = &limit
+	req.StatementClosingDay = 20
+	req.PaymentDueDay = 2
+	if err := service.Update(ctx, "wallet1", req); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	response, err := service.GetByID(ctx, "wallet1")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if response.CreditLimit == nil || !response.CreditLimit.Equal(limit) {
+		t.Errorf("expected credit limit 800000, got %v", response.CreditLimit)
+	}
+	if response.AvailableCredit == nil || !response.AvailableCredit.Equal(shareddomain.MustParseAmount("680000")) {
+		t.Errorf("expected available credit 680000, got %v", response.AvailableCredit)
+	}
+
+	req = commands.WalletRequest{Name: "Visa Gold", Type: 0, Currency: "ARS"}
+	if err := service.Update(ctx, "wallet1", req); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if terms := repo.wallets["wallet1"].CreditCard; terms != nil {
+		t.Errorf("expected terms to be dropped for a bank wallet, got %+v", terms)
+	}
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"fin-flow-api/internal/shared/domain"
+)
+
+var (
+	ErrCreditCardTermsRequired   = errors.New("credit card wallets need a credit limit, a statement closing day and a payment due day")
+	ErrCreditCardTermsNotAllowed = errors.New("only credit card wallets have a credit limit and billing cycle")
+	ErrCreditCardNotConfigured   = errors.New("credit card billing cycle is not configured")
+	ErrNotCreditCard             = errors.New("wallet is not a credit card")
+	ErrInvalidCreditLimit        = errors.New("credit limit must be greater than zero")
+	ErrInvalidClosingDay         = errors.New("statement closing day must be between 1 and 31")
+	ErrInvalidDueDay             = errors.New("payment due day must be between 1 and 31")
+)
+
+// CreditCardTerms describe how a credit card bills. Statements close on
+// ClosingDay and are due on the first DueDay after closing; both are clamped
+// to the last day of shorter months.
+type CreditCardTerms struct {
+	CreditLimit domain.Amount
+	ClosingDay  int
+	DueDay      int
+}
+
+func (t *CreditCardTerms) Validate(currency Currency) error {
+	if !t.CreditLimit.IsPositive() {
+		return ErrInvalidCreditLimit
+	}
+	if !t.CreditLimit.FitsCurrency(currency.String()) {
+		return domain.ErrAmountPrecision
+	}
+	if t.ClosingDay < 1 || t.ClosingDay > 31 {
+		return ErrInvalidClosingDay
+	}
+	if t.DueDay < 1 || t.DueDay > 31 {
+		return ErrInvalidDueDay
+	}
+	return nil
+}
+
+// BillingCycle is the period one statement covers, from Start to Closing
+// inclusive, and the day its balance is due.
+type BillingCycle struct {
+	Start   time.Time
+	Closing time.Time
+	Due     time.Time
+}
+
+// Contains reports whether date falls within the cycle.
+func (c BillingCycle) Contains(date time.Time) bool {
+	date = truncateToDate(date)
+	return !date.Before(c.Start) && !date.After(c.Closing)
+}
+
+// CycleFor returns the billing cycle the given date is charged to.
+func (t *CreditCardTerms) CycleFor(date time.Time) BillingCycle {
+	date = truncateToDate(date)
+	closing := dayInMonth(date.Year(), date.Month(), t.ClosingDay)
+	if date.After(closing) {
+		closing = dayInMonth(date.Year(), date.Month()+1, t.ClosingDay)
+	}
+	return t.cycleClosingIn(closing.Year(), closing.Month())
+}
+
+// CycleClosingIn returns the billing cycle whose statement closes in the
+// given month.
+func (t *CreditCardTerms) CycleClosingIn(month time.Time) BillingCycle {
+	return t.cycleClosingIn(month.Year(), month.Month())
+}
+
+// Next returns the cycle that follows c.
+func (t *CreditCardTerms) Next(c BillingCycle) BillingCycle {
+	return t.cycleClosingIn(c.Closing.Year(), c.Closing.Month()+1)
+}
+
+func (t *CreditCardTerms) cycleClosingIn(year int, month time.Month) BillingCycle {
+	closing := dayInMonth(year, month, t.ClosingDay)
+	previous := dayInMonth(year, month-1, t.ClosingDay)
+
+	due := dayInMonth(year, month, t.DueDay)
+	if !due.After(closing) {
+		due = dayInMonth(year, month+1, t.DueDay)
+	}
+
+	return BillingCycle{
+		Start:   previous.AddDate(0, 0, 1),
+		Closing: closing,
+		Due:     due,
+	}
+}
+
+// dayInMonth returns the given day of a month, clamped to the last day of
+// shorter months. Months outside 1-12 roll over into the neighbouring years.
+func dayInMonth(year int, month time.Month, day int) time.Time {
+	firstOfMonth := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
+
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
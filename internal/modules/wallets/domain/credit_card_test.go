@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCreditCardTerms_CycleFor(t *testing.T) {
+	tests := []struct {
+		name    string
+		terms   CreditCardTerms
+		date    time.Time
+		start   time.Time
+		closing time.Time
+		due     time.Time
+	}{
+		{"before closing", CreditCardTerms{ClosingDay: 25, DueDay: 5}, date(2026, 3, 10), date(2026, 2, 26), date(2026, 3, 25), date(2026, 4, 5)},
+		{"on closing day", CreditCardTerms{ClosingDay: 25, DueDay: 5}, date(2026, 3, 25), date(2026, 2, 26), date(2026, 3, 25), date(2026, 4, 5)},
+		{"after closing", CreditCardTerms{ClosingDay: 25, DueDay: 5}, date(2026, 3, 26), date(2026, 3, 26), date(2026, 4, 25), date(2026, 5, 5)},
+		{"due in closing month", CreditCardTerms{ClosingDay: 5, DueDay: 20}, date(2026, 3, 1), date(2026, 2, 6), date(2026, 3, 5), date(2026, 3, 20)},
+		{"closing clamped to february", CreditCardTerms{ClosingDay: 31, DueDay: 10}, date(2026, 2, 15), date(2026, 2, 1), date(2026, 2, 28), date(2026, 3, 10)},
+		{"year rollover", CreditCardTerms{ClosingDay: 20, DueDay: 1}, date(2026, 12, 28), date(2026, 12, 21), date(2027, 1, 20), date(2027, 2, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cycle := tt.terms.CycleFor(tt.date)
+			if !cycle.Start.Equal(tt.start) || !cycle.Closing.Equal(tt.closing) || !cycle.Due.Equal(tt.due) {
+				t.Errorf("expected %s..%s due %s, got %s..%s due %s",
+					tt.start.Format("2006-01-02"), tt.closing.Format("2006-01-02"), tt.due.Format("2006-01-02"),
+					cycle.Start.Format("2006-01-02"), cycle.Closing.Format("2006-01-02"), cycle.Due.Format("2006-01-02"))
+			}
+			if !cycle.Contains(tt.date) {
+				t.Errorf("cycle should contain %s", tt.date.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestCreditCardTerms_Next(t *testing.T) {
+	terms := CreditCardTerms{ClosingDay: 31, DueDay: 10}
+
+	cycle := terms.CycleClosingIn(date(2026, 1, 1))
+	next := terms.Next(cycle)
+
+	if !next.Start.Equal(date(2026, 2, 1)) || !next.Closing.Equal(date(2026, 2, 28)) {
+		t.Errorf("unexpected next cycle %s..%s", next.Start.Format("2006-01-02"), next.Closing.Format("2006-01-02"))
+	}
+	if !next.Start.Equal(cycle.Closing.AddDate(0, 0, 1)) {
+		t.Error("next cycle should start the day after the previous one closes")
+	}
+}
+
+func TestCreditCardTerms_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		terms   CreditCardTerms
+		wantErr error
+	}{
+		{"valid", CreditCardTerms{CreditLimit: shareddomain.MustParseAmount("1000"), ClosingDay: 25, DueDay: 5}, nil},
+		{"zero limit", CreditCardTerms{ClosingDay: 25, DueDay: 5}, ErrInvalidCreditLimit},
+		{"negative limit", CreditCardTerms{CreditLimit: shareddomain.MustParseAmount("-1"), ClosingDay: 25, DueDay: 5}, ErrInvalidCreditLimit},
+		{"closing day zero", CreditCardTerms{CreditLimit: shareddomain.MustParseAmount("1000"), DueDay: 5}, ErrInvalidClosingDay},
+		{"due day too large", CreditCardTerms{CreditLimit: shareddomain.MustParseAmount("1000"), ClosingDay: 25, DueDay: 32}, ErrInvalidDueDay},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.terms.Validate(CurrencyUSD); err != tt.wantErr {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestWallet_AvailableCredit(t *testing.T) {
+	card := NewWallet("card", "user", "Visa", WalletTypeCreditCard, shareddomain.MustParseAmount("-250.50"), CurrencyUSD, "system")
+
+	if _, err := card.AvailableCredit(); err != ErrCreditCardNotConfigured {
+		t.Errorf("expected ErrCreditCardNotConfigured, got %v", err)
+	}
+
+	if err := card.SetCreditCardTerms(&CreditCardTerms{CreditLimit: shareddomain.MustParseAmount("1000"), ClosingDay: 25, DueDay: 5}); err != nil {
+		t.Fatalf("SetCreditCardTerms failed: %v", err)
+	}
+	available, err := card.AvailableCredit()
+	if err != nil {
+		t.Fatalf("AvailableCredit failed: %v", err)
+	}
+	if !available.Equal(shareddomain.MustParseAmount("749.50")) {
+		t.Errorf("expected 749.50, got %s", available)
+	}
+
+	bank := NewWallet("bank", "user", "Bank", WalletTypeBank, shareddomain.Amount{}, CurrencyUSD, "system")
+	if err := bank.SetCreditCardTerms(&CreditCardTerms{CreditLimit: shareddomain.MustParseAmount("1000"), ClosingDay: 25, DueDay: 5}); err != ErrCreditCardTermsNotAllowed {
+		t.Errorf("expected ErrCreditCardTermsNotAllowed, got %v", err)
+	}
+	if _, err := bank.AvailableCredit(); err != ErrNotCreditCard {
+		t.Errorf("expected ErrNotCreditCard, got %v", err)
+	}
+}
@@ -1,6 +1,10 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+
+	"fin-flow-api/internal/shared/domain"
+)
 
 type Currency string
 
@@ -34,6 +38,11 @@ func (c Currency) String() string {
 	return string(c)
 }
 
+// Scale returns the number of decimal places balances in c are kept at.
+func (c Currency) Scale() int32 {
+	return domain.CurrencyScale(string(c))
+}
+
 func IsValidCurrency(currency string) bool {
 	validCurrencies := map[string]bool{
 		// Fiat currencies
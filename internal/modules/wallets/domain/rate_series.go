@@ -0,0 +1,43 @@
+package domain
+
+import "errors"
+
+// RateSeries names one of several exchange rates quoted for the same
+// currency pair, such as Argentina's official, MEP, CCL and blue dollar.
+type RateSeries string
+
+const (
+	RateSeriesOfficial RateSeries = "official"
+	RateSeriesMEP      RateSeries = "mep"
+	RateSeriesCCL      RateSeries = "ccl"
+	RateSeriesBlue     RateSeries = "blue"
+	RateSeriesCrypto   RateSeries = "crypto"
+)
+
+// DefaultRateSeries is used for pairs that are only quoted once and for
+// wallets that did not pick a series.
+const DefaultRateSeries = RateSeriesOfficial
+
+var ErrInvalidRateSeries = errors.New("invalid rate series")
+
+func (s RateSeries) String() string {
+	return string(s)
+}
+
+func IsValidRateSeries(series string) bool {
+	switch RateSeries(series) {
+	case RateSeriesOfficial, RateSeriesMEP, RateSeriesCCL, RateSeriesBlue, RateSeriesCrypto:
+		return true
+	}
+	return false
+}
+
+func GetAllRateSeries() []string {
+	return []string{
+		string(RateSeriesOfficial),
+		string(RateSeriesMEP),
+		string(RateSeriesCCL),
+		string(RateSeriesBlue),
+		string(RateSeriesCrypto),
+	}
+}
@@ -6,4 +6,10 @@ type WalletRepository interface {
 	List(userID string) ([]*Wallet, error)
 	Update(wallet *Wallet) error
 	Delete(id string, userID string) error
-}
\ No newline at end of file
+}
+
+// WalletActivityRepository reports what has been recorded against a
+// wallet.
+type WalletActivityRepository interface {
+	Activity(id string, userID string) (Activity, error)
+}
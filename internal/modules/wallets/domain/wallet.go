@@ -1,9 +1,16 @@
 package domain
 
 import (
+	"errors"
+
 	"fin-flow-api/internal/shared/domain"
 )
 
+var (
+	ErrCurrencyLocked            = errors.New("the currency of a wallet with a balance, transactions, trades or reconciliations cannot be changed")
+	ErrInvestmentWalletHasTrades = errors.New("a wallet with trades must remain an investment wallet")
+)
+
 type Wallet struct {
 	domain.Entity
 
@@ -11,18 +18,100 @@ type Wallet struct {
 	UserID   string
 	Name     string
 	Type     WalletType
-	Balance  float64
+	Balance  domain.Amount
 	Currency Currency
+	// RateSeries is the exchange-rate series the wallet is valued with
+	// when converted to another currency.
+	RateSeries RateSeries
+	// CreditCard holds the billing terms of credit card wallets. It is nil
+	// for every other wallet type and for cards created before terms
+	// existed.
+	CreditCard *CreditCardTerms
+	// AccountID is the number the bank gives the account in the files it
+	// exports, which statement imports match wallets by. It is unique per
+	// user and empty when the wallet is not linked to a bank account.
+	AccountID string
 }
 
-func NewWallet(id, userID, name string, walletType WalletType, balance float64, currency Currency, createdBy string) *Wallet {
+func NewWallet(id, userID, name string, walletType WalletType, balance domain.Amount, currency Currency, createdBy string) *Wallet {
 	return &Wallet{
-		Entity:   domain.NewEntity(id, createdBy),
-		ID:       id,
-		UserID:   userID,
-		Name:     name,
-		Type:     walletType,
-		Balance:  balance,
-		Currency: currency,
-	}
-}
\ No newline at end of file
+		Entity:     domain.NewEntity(id, createdBy),
+		ID:         id,
+		UserID:     userID,
+		Name:       name,
+		Type:       walletType,
+		Balance:    balance,
+		Currency:   currency,
+		RateSeries: DefaultRateSeries,
+	}
+}
+
+// Activity is what has been recorded against a wallet. Trades counts the
+// investment trades and income of the wallet.
+type Activity struct {
+	Transactions    int
+	Trades          int
+	Reconciliations int
+}
+
+func (a Activity) IsEmpty() bool {
+	return a.Transactions == 0 && a.Trades == 0 && a.Reconciliations == 0
+}
+
+// ChangeCurrency switches the currency of the wallet. Amounts are stored
+// without their currency, so it can only change while nothing has been
+// recorded in the old one.
+func (w *Wallet) ChangeCurrency(currency Currency, activity Activity) error {
+	if currency == w.Currency {
+		return nil
+	}
+	if !w.Balance.IsZero() || !activity.IsEmpty() {
+		return ErrCurrencyLocked
+	}
+	w.Currency = currency
+	return nil
+}
+
+// ChangeType switches the type of the wallet. Holdings are only allowed in
+// investment wallets, so an investment wallet keeps its type while it has
+// trades.
+func (w *Wallet) ChangeType(walletType WalletType, activity Activity) error {
+	if walletType == w.Type {
+		return nil
+	}
+	if w.Type == WalletTypeInvestment && activity.Trades > 0 {
+		return ErrInvestmentWalletHasTrades
+	}
+	w.Type = walletType
+	return nil
+}
+
+// SetCreditCardTerms replaces the billing terms of the wallet. Only credit
+// card wallets accept terms.
+func (w *Wallet) SetCreditCardTerms(terms *CreditCardTerms) error {
+	if terms == nil {
+		w.CreditCard = nil
+		return nil
+	}
+	if w.Type != WalletTypeCreditCard {
+		return ErrCreditCardTermsNotAllowed
+	}
+	if err := terms.Validate(w.Currency); err != nil {
+		return err
+	}
+	w.CreditCard = terms
+	return nil
+}
+
+// AvailableCredit is the credit limit minus what is owed on the card. Card
+// balances are negative while money is owed, so an overpaid card has more
+// available credit than its limit.
+func (w *Wallet) AvailableCredit() (domain.Amount, error) {
+	if w.Type != WalletTypeCreditCard {
+		return domain.Amount{}, ErrNotCreditCard
+	}
+	if w.CreditCard == nil {
+		return domain.Amount{}, ErrCreditCardNotConfigured
+	}
+	return w.CreditCard.CreditLimit.Add(w.Balance), nil
+}
@@ -2,6 +2,8 @@ package domain
 
 import (
 	"testing"
+
+	shareddomain "fin-flow-api/internal/shared/domain"
 )
 
 func TestNewWallet(t *testing.T) {
@@ -9,7 +11,7 @@ func TestNewWallet(t *testing.T) {
 	userID := "user-1"
 	name := "Main Account"
 	walletType := WalletTypeBank
-	balance := 1000.50
+	balance := shareddomain.MustParseAmount("1000.50")
 	currency := CurrencyUSD
 	createdBy := "system"
 
@@ -35,8 +37,8 @@ func TestNewWallet(t *testing.T) {
 		t.Errorf("expected Type %v, got %v", walletType, wallet.Type)
 	}
 
-	if wallet.Balance != balance {
-		t.Errorf("expected Balance %f, got %f", balance, wallet.Balance)
+	if !wallet.Balance.Equal(balance) {
+		t.Errorf("expected Balance %s, got %s", balance, wallet.Balance)
 	}
 
 	if wallet.Currency != currency {
@@ -46,6 +48,10 @@ func TestNewWallet(t *testing.T) {
 	if wallet.CreatedBy != createdBy {
 		t.Errorf("expected CreatedBy %s, got %s", createdBy, wallet.CreatedBy)
 	}
+
+	if wallet.RateSeries != RateSeriesOfficial {
+		t.Errorf("expected RateSeries %s, got %s", RateSeriesOfficial, wallet.RateSeries)
+	}
 }
 
 func TestWalletType_String(t *testing.T) {
@@ -124,4 +130,84 @@ func TestIsValidWalletType(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestIsValidRateSeries(t *testing.T) {
+	for _, series := range GetAllRateSeries() {
+		if !IsValidRateSeries(series) {
+			t.Errorf("expected %s to be valid", series)
+		}
+	}
+
+	for _, series := range []string{"", "Blue", "tarjeta"} {
+		if IsValidRateSeries(series) {
+			t.Errorf("expected %q to be invalid", series)
+		}
+	}
+}
+func TestWallet_ChangeCurrency(t *testing.T) {
+	tests := []struct {
+		name     string
+		balance  string
+		activity Activity
+		currency Currency
+		wantErr  error
+	}{
+		{"empty wallet", "0", Activity{}, CurrencyEUR, nil},
+		{"same currency", "100", Activity{Transactions: 3}, CurrencyUSD, nil},
+		{"balance", "100", Activity{}, CurrencyEUR, ErrCurrencyLocked},
+		{"transactions", "0", Activity{Transactions: 1}, CurrencyEUR, ErrCurrencyLocked},
+		{"trades", "0", Activity{Trades: 1}, CurrencyEUR, ErrCurrencyLocked},
+		{"reconciliations", "0", Activity{Reconciliations: 1}, CurrencyEUR, ErrCurrencyLocked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wallet := NewWallet("wallet-1", "user-1", "Main", WalletTypeBank, shareddomain.MustParseAmount(tt.balance), CurrencyUSD, "system")
+
+			err := wallet.ChangeCurrency(tt.currency, tt.activity)
+			if err != tt.wantErr {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if err == nil && wallet.Currency != tt.currency {
+				t.Errorf("expected currency %s, got %s", tt.currency, wallet.Currency)
+			}
+			if err != nil && wallet.Currency != CurrencyUSD {
+				t.Errorf("expected currency to stay USD, got %s", wallet.Currency)
+			}
+		})
+	}
+}
+
+func TestWallet_ChangeType(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     WalletType
+		to       WalletType
+		activity Activity
+		wantErr  error
+	}{
+		{"investment without trades", WalletTypeInvestment, WalletTypeSavings, Activity{Transactions: 4}, nil},
+		{"investment with trades", WalletTypeInvestment, WalletTypeSavings, Activity{Trades: 2}, ErrInvestmentWalletHasTrades},
+		{"stays investment", WalletTypeInvestment, WalletTypeInvestment, Activity{Trades: 2}, nil},
+		{"bank with transactions", WalletTypeBank, WalletTypeSavings, Activity{Transactions: 4}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wallet := NewWallet("wallet-1", "user-1", "Broker", tt.from, shareddomain.Amount{}, CurrencyUSD, "system")
+
+			if err := wallet.ChangeType(tt.to, tt.activity); err != tt.wantErr {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+
+			want := tt.to
+			if tt.wantErr != nil {
+				want = tt.from
+			}
+			if wallet.Type != want {
+				t.Errorf("expected type %v, got %v", want, wallet.Type)
+			}
+		})
+	}
+}
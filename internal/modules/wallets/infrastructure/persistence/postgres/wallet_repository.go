@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -23,10 +24,12 @@ func NewRepository(pool *pgxpool.Pool) *Repository {
 
 func (r *Repository) Create(wallet *domain.Wallet) error {
 	query := `
-		INSERT INTO wallets (id, user_id, name, type, balance, currency, created_at, modified_at, created_by, modified_by)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO wallets (id, user_id, name, type, balance, currency, rate_series, credit_limit, statement_closing_day, payment_due_day, account_id, created_at, modified_at, created_by, modified_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 	`
 
+	creditLimit, closingDay, dueDay := creditCardColumns(wallet)
+
 	_, err := r.pool.Exec(
 		context.Background(),
 		query,
@@ -36,6 +39,11 @@ func (r *Repository) Create(wallet *domain.Wallet) error {
 		wallet.Type.Value(),
 		wallet.Balance,
 		wallet.Currency.String(),
+		wallet.RateSeries.String(),
+		creditLimit,
+		closingDay,
+		dueDay,
+		nullableString(wallet.AccountID),
 		wallet.CreatedAt,
 		wallet.ModifiedAt,
 		wallet.CreatedBy,
@@ -46,12 +54,19 @@ func (r *Repository) Create(wallet *domain.Wallet) error {
 		if pgErr, ok := err.(*pgconn.PgError); ok {
 			switch pgErr.Code {
 			case "23505": // unique_violation
+				if strings.Contains(pgErr.ConstraintName, "account_id") {
+					return fmt.Errorf("account id is already used by another wallet")
+				}
 				if strings.Contains(pgErr.ConstraintName, "name") {
 					return fmt.Errorf("wallet name already exists")
 				}
 				return fmt.Errorf("duplicate entry")
 			case "23503": // foreign_key_violation
 				return fmt.Errorf("invalid user reference")
+			case "23514": // check_violation
+				if pgErr.ConstraintName == "chk_wallets_credit_card_terms" {
+					return domain.ErrCreditCardTermsNotAllowed
+				}
 			}
 		}
 		return fmt.Errorf("failed to create wallet: %w", err)
@@ -77,14 +92,17 @@ func (r *Repository) GetByID(id string, userID string) (*domain.Wallet, error) {
 	}
 
 	query := `
-		SELECT id, user_id, name, type, balance, currency, created_at, modified_at, created_by, modified_by
+		SELECT id, user_id, name, type, balance, currency, rate_series, credit_limit, statement_closing_day, payment_due_day, account_id, created_at, modified_at, created_by, modified_by
 		FROM wallets
 		WHERE id = $1 AND user_id = $2
 	`
 
 	var wallet domain.Wallet
 	var typeValue int
-	var currencyStr string
+	var currencyStr, rateSeries string
+	var creditLimit *shareddomain.Amount
+	var closingDay, dueDay *int
+	var accountID *string
 	err = r.pool.QueryRow(context.Background(), query, id, userID).Scan(
 		&wallet.ID,
 		&wallet.UserID,
@@ -92,6 +110,11 @@ func (r *Repository) GetByID(id string, userID string) (*domain.Wallet, error) {
 		&typeValue,
 		&wallet.Balance,
 		&currencyStr,
+		&rateSeries,
+		&creditLimit,
+		&closingDay,
+		&dueDay,
+		&accountID,
 		&wallet.CreatedAt,
 		&wallet.ModifiedAt,
 		&wallet.CreatedBy,
@@ -107,13 +130,18 @@ func (r *Repository) GetByID(id string, userID string) (*domain.Wallet, error) {
 
 	wallet.Type = domain.WalletType(typeValue)
 	wallet.Currency = domain.Currency(currencyStr)
+	wallet.RateSeries = domain.RateSeries(rateSeries)
+	wallet.CreditCard = creditCardTerms(creditLimit, closingDay, dueDay)
+	if accountID != nil {
+		wallet.AccountID = *accountID
+	}
 
 	return &wallet, nil
 }
 
 func (r *Repository) List(userID string) ([]*domain.Wallet, error) {
 	query := `
-		SELECT id, user_id, name, type, balance, currency, created_at, modified_at, created_by, modified_by
+		SELECT id, user_id, name, type, balance, currency, rate_series, credit_limit, statement_closing_day, payment_due_day, account_id, created_at, modified_at, created_by, modified_by
 		FROM wallets
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -129,7 +157,10 @@ func (r *Repository) List(userID string) ([]*domain.Wallet, error) {
 	for rows.Next() {
 		var wallet domain.Wallet
 		var typeValue int
-		var currencyStr string
+		var currencyStr, rateSeries string
+		var creditLimit *shareddomain.Amount
+		var closingDay, dueDay *int
+		var accountID *string
 		err := rows.Scan(
 			&wallet.ID,
 			&wallet.UserID,
@@ -137,6 +168,11 @@ func (r *Repository) List(userID string) ([]*domain.Wallet, error) {
 			&typeValue,
 			&wallet.Balance,
 			&currencyStr,
+			&rateSeries,
+			&creditLimit,
+			&closingDay,
+			&dueDay,
+			&accountID,
 			&wallet.CreatedAt,
 			&wallet.ModifiedAt,
 			&wallet.CreatedBy,
@@ -147,6 +183,11 @@ func (r *Repository) List(userID string) ([]*domain.Wallet, error) {
 		}
 		wallet.Type = domain.WalletType(typeValue)
 		wallet.Currency = domain.Currency(currencyStr)
+		wallet.RateSeries = domain.RateSeries(rateSeries)
+		wallet.CreditCard = creditCardTerms(creditLimit, closingDay, dueDay)
+		if accountID != nil {
+			wallet.AccountID = *accountID
+		}
 		wallets = append(wallets, &wallet)
 	}
 
@@ -175,31 +216,45 @@ func (r *Repository) Update(wallet *domain.Wallet) error {
 
 	query := `
 		UPDATE wallets
-		SET name = $2, type = $3, balance = $4, currency = $5, modified_at = $6, modified_by = $7
+		SET name = $2, type = $3, currency = $4, rate_series = $5, modified_at = $6, modified_by = $7,
+			credit_limit = $9, statement_closing_day = $10, payment_due_day = $11, account_id = $12
 		WHERE id = $1 AND user_id = $8
 	`
 
+	creditLimit, closingDay, dueDay := creditCardColumns(wallet)
+
 	result, err := r.pool.Exec(
 		context.Background(),
 		query,
 		wallet.ID,
 		wallet.Name,
 		wallet.Type.Value(),
-		wallet.Balance,
 		wallet.Currency.String(),
+		wallet.RateSeries.String(),
 		wallet.ModifiedAt,
 		wallet.ModifiedBy,
 		wallet.UserID,
+		creditLimit,
+		closingDay,
+		dueDay,
+		nullableString(wallet.AccountID),
 	)
 
 	if err != nil {
 		if pgErr, ok := err.(*pgconn.PgError); ok {
 			switch pgErr.Code {
 			case "23505": // unique_violation
+				if strings.Contains(pgErr.ConstraintName, "account_id") {
+					return fmt.Errorf("account id is already used by another wallet")
+				}
 				if strings.Contains(pgErr.ConstraintName, "name") {
 					return fmt.Errorf("wallet name already exists")
 				}
 				return fmt.Errorf("duplicate entry")
+			case "23514": // check_violation
+				if pgErr.ConstraintName == "chk_wallets_credit_card_terms" {
+					return domain.ErrCreditCardTermsNotAllowed
+				}
 			}
 		}
 		return fmt.Errorf("failed to update wallet: %w", err)
@@ -232,6 +287,9 @@ func (r *Repository) Delete(id string, userID string) error {
 
 	result, err := r.pool.Exec(context.Background(), query, id, userID)
 	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23503" { // foreign_key_violation
+			return fmt.Errorf("wallet has transactions")
+		}
 		return fmt.Errorf("failed to delete wallet: %w", err)
 	}
 
@@ -240,4 +298,52 @@ func (r *Repository) Delete(id string, userID string) error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// Activity counts what has been recorded against a wallet of the user.
+func (r *Repository) Activity(id string, userID string) (domain.Activity, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM transactions WHERE wallet_id = $1 AND user_id = $2),
+			(SELECT COUNT(*) FROM investment_trades WHERE wallet_id = $1 AND user_id = $2)
+				+ (SELECT COUNT(*) FROM investment_income WHERE wallet_id = $1 AND user_id = $2),
+			(SELECT COUNT(*) FROM reconciliations WHERE wallet_id = $1 AND user_id = $2)
+	`
+
+	var activity domain.Activity
+	err := r.pool.QueryRow(context.Background(), query, id, userID).Scan(
+		&activity.Transactions,
+		&activity.Trades,
+		&activity.Reconciliations,
+	)
+	if err != nil {
+		return domain.Activity{}, fmt.Errorf("failed to get wallet activity: %w", err)
+	}
+
+	return activity, nil
+}
+
+func nullableString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func creditCardColumns(wallet *domain.Wallet) (*shareddomain.Amount, *int, *int) {
+	if wallet.CreditCard == nil {
+		return nil, nil, nil
+	}
+	return &wallet.CreditCard.CreditLimit, &wallet.CreditCard.ClosingDay, &wallet.CreditCard.DueDay
+}
+
+func creditCardTerms(creditLimit *shareddomain.Amount, closingDay, dueDay *int) *domain.CreditCardTerms {
+	if creditLimit == nil || closingDay == nil || dueDay == nil {
+		return nil
+	}
+	return &domain.CreditCardTerms{
+		CreditLimit: *creditLimit,
+		ClosingDay:  *closingDay,
+		DueDay:      *dueDay,
+	}
+}
@@ -3,12 +3,15 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strings"
+	"time"
 
 	"fin-flow-api/internal/modules/wallets/application/contracts/commands"
 	"fin-flow-api/internal/modules/wallets/application/contracts/queries"
 	"fin-flow-api/internal/modules/wallets/domain"
+	shareddomain "fin-flow-api/internal/shared/domain"
 	basehandler "fin-flow-api/internal/shared/http"
 )
 
@@ -20,13 +23,19 @@ type walletService interface {
 	List(ctx context.Context) ([]*queries.WalletResponse, error)
 }
 
+type valuationService interface {
+	Value(ctx context.Context, currency string, date time.Time) (*queries.ValuationResponse, error)
+}
+
 type Handler struct {
-	walletService walletService
+	walletService    walletService
+	valuationService valuationService
 }
 
-func NewHandler(walletService walletService) *Handler {
+func NewHandler(walletService walletService, valuationService valuationService) *Handler {
 	return &Handler{
-		walletService: walletService,
+		walletService:    walletService,
+		valuationService: valuationService,
 	}
 }
 
@@ -47,11 +56,21 @@ func (h *Handler) CreateWallet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var openingBalance shareddomain.Amount
+	if reqDTO.Balance != nil {
+		openingBalance = *reqDTO.Balance
+	}
+
 	cmd := commands.WalletRequest{
-		Name:     reqDTO.Name,
-		Type:     *reqDTO.Type,
-		Balance:  *reqDTO.Balance,
-		Currency: *reqDTO.Currency,
+		Name:                reqDTO.Name,
+		Type:                *reqDTO.Type,
+		Balance:             openingBalance,
+		Currency:            *reqDTO.Currency,
+		RateSeries:          rateSeries(reqDTO),
+		CreditLimit:         reqDTO.CreditLimit,
+		StatementClosingDay: intValue(reqDTO.StatementClosingDay),
+		PaymentDueDay:       intValue(reqDTO.PaymentDueDay),
+		AccountID:           accountID(reqDTO),
 	}
 
 	if err := h.walletService.Create(r.Context(), cmd); err != nil {
@@ -64,14 +83,26 @@ func (h *Handler) CreateWallet(w http.ResponseWriter, r *http.Request) {
 		} else if strings.Contains(errorMsg, "invalid currency") {
 			statusCode = http.StatusBadRequest
 			errorMsg = "Invalid currency code"
+		} else if strings.Contains(errorMsg, "decimal places") {
+			statusCode = http.StatusBadRequest
+			errorMsg = "Balance and credit limit must not have more decimal places than the currency allows"
+		} else if isCreditCardTermsError(err) {
+			statusCode = http.StatusBadRequest
+			errorMsg = creditCardTermsMessage(err)
+		} else if strings.Contains(errorMsg, "invalid rate series") {
+			statusCode = http.StatusBadRequest
+			errorMsg = "Invalid rate series"
 		} else if strings.Contains(errorMsg, "user not authenticated") {
 			statusCode = http.StatusUnauthorized
 			errorMsg = "Authentication required"
+		} else if strings.Contains(errorMsg, "account id is already used") {
+			statusCode = http.StatusConflict
+			errorMsg = "Another wallet already uses this account ID"
 		} else if strings.Contains(errorMsg, "duplicate") || strings.Contains(errorMsg, "unique") || strings.Contains(errorMsg, "name already exists") {
 			statusCode = http.StatusConflict
 			errorMsg = "A wallet with this name already exists"
 		}
-		
+
 		basehandler.WriteError(w, statusCode, errorMsg)
 		return
 	}
@@ -112,20 +143,7 @@ func (h *Handler) GetWallet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response := WalletResponse{
-		ID:        wallet.ID,
-		Name:      wallet.Name,
-		Type:      wallet.Type,
-		TypeName:  wallet.TypeName,
-		Balance:   wallet.Balance,
-		Currency:  wallet.Currency,
-		CreatedAt: wallet.CreatedAt,
-		UpdatedAt: wallet.UpdatedAt,
-		CreatedBy: wallet.CreatedBy,
-		UpdatedBy: wallet.UpdatedBy,
-	}
-
-	basehandler.WriteJSON(w, http.StatusOK, response)
+	basehandler.WriteJSON(w, http.StatusOK, toWalletResponse(wallet))
 }
 
 func (h *Handler) UpdateWallet(w http.ResponseWriter, r *http.Request) {
@@ -154,10 +172,14 @@ func (h *Handler) UpdateWallet(w http.ResponseWriter, r *http.Request) {
 	}
 
 	cmd := commands.WalletRequest{
-		Name:     reqDTO.Name,
-		Type:     *reqDTO.Type,
-		Balance:  *reqDTO.Balance,
-		Currency: *reqDTO.Currency,
+		Name:                reqDTO.Name,
+		Type:                *reqDTO.Type,
+		Currency:            *reqDTO.Currency,
+		RateSeries:          rateSeries(reqDTO),
+		CreditLimit:         reqDTO.CreditLimit,
+		StatementClosingDay: intValue(reqDTO.StatementClosingDay),
+		PaymentDueDay:       intValue(reqDTO.PaymentDueDay),
+		AccountID:           accountID(reqDTO),
 	}
 
 	if err := h.walletService.Update(r.Context(), id, cmd); err != nil {
@@ -170,6 +192,15 @@ func (h *Handler) UpdateWallet(w http.ResponseWriter, r *http.Request) {
 		} else if strings.Contains(errorMsg, "invalid currency") {
 			statusCode = http.StatusBadRequest
 			errorMsg = "Invalid currency code"
+		} else if strings.Contains(errorMsg, "decimal places") {
+			statusCode = http.StatusBadRequest
+			errorMsg = "Credit limit has more decimal places than the currency allows"
+		} else if isCreditCardTermsError(err) {
+			statusCode = http.StatusBadRequest
+			errorMsg = creditCardTermsMessage(err)
+		} else if strings.Contains(errorMsg, "invalid rate series") {
+			statusCode = http.StatusBadRequest
+			errorMsg = "Invalid rate series"
 		} else if strings.Contains(errorMsg, "user not authenticated") {
 			statusCode = http.StatusUnauthorized
 			errorMsg = "Authentication required"
@@ -179,6 +210,15 @@ func (h *Handler) UpdateWallet(w http.ResponseWriter, r *http.Request) {
 		} else if strings.Contains(errorMsg, "wallet not found") {
 			statusCode = http.StatusNotFound
 			errorMsg = "Wallet not found"
+		} else if strings.Contains(errorMsg, "currency of a wallet") {
+			statusCode = http.StatusConflict
+			errorMsg = "The currency of a wallet with a balance, transactions, trades or reconciliations cannot be changed"
+		} else if strings.Contains(errorMsg, "must remain an investment wallet") {
+			statusCode = http.StatusConflict
+			errorMsg = "A wallet with trades must remain an investment wallet"
+		} else if strings.Contains(errorMsg, "account id is already used") {
+			statusCode = http.StatusConflict
+			errorMsg = "Another wallet already uses this account ID"
 		} else if strings.Contains(errorMsg, "duplicate") || strings.Contains(errorMsg, "unique") || strings.Contains(errorMsg, "name already exists") {
 			statusCode = http.StatusConflict
 			errorMsg = "A wallet with this name already exists"
@@ -218,6 +258,9 @@ func (h *Handler) DeleteWallet(w http.ResponseWriter, r *http.Request) {
 		} else if strings.Contains(errorMsg, "wallet not found") {
 			statusCode = http.StatusNotFound
 			errorMsg = "Wallet not found"
+		} else if strings.Contains(errorMsg, "wallet has transactions") {
+			statusCode = http.StatusConflict
+			errorMsg = "Wallet has transactions and cannot be deleted"
 		}
 		
 		basehandler.WriteError(w, statusCode, errorMsg)
@@ -241,23 +284,66 @@ func (h *Handler) ListWallets(w http.ResponseWriter, r *http.Request) {
 
 	responses := make([]WalletResponse, len(wallets))
 	for i, wallet := range wallets {
-		responses[i] = WalletResponse{
-			ID:        wallet.ID,
-			Name:      wallet.Name,
-			Type:      wallet.Type,
-			TypeName:  wallet.TypeName,
-			Balance:   wallet.Balance,
-			Currency:  wallet.Currency,
-			CreatedAt: wallet.CreatedAt,
-			UpdatedAt: wallet.UpdatedAt,
-			CreatedBy: wallet.CreatedBy,
-			UpdatedBy: wallet.UpdatedBy,
-		}
+		responses[i] = toWalletResponse(wallet)
 	}
 
 	basehandler.WriteJSON(w, http.StatusOK, responses)
 }
 
+// GetValuation handles GET /wallets/valuation?currency=EUR and values the
+// current balance of every wallet in one currency at today's rates. The
+// currency defaults to the exchange-rate base currency.
+func (h *Handler) GetValuation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	currency := strings.TrimSpace(r.URL.Query().Get("currency"))
+
+	valuation, err := h.valuationService.Value(r.Context(), currency, time.Now().UTC())
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		errorMsg := err.Error()
+
+		if strings.Contains(errorMsg, "user not authenticated") {
+			statusCode = http.StatusUnauthorized
+			errorMsg = "Authentication required"
+		} else if strings.Contains(errorMsg, "invalid currency") {
+			statusCode = http.StatusBadRequest
+			errorMsg = "Invalid currency code"
+		} else if strings.Contains(errorMsg, "exchange rate not found") {
+			statusCode = http.StatusNotFound
+		}
+
+		basehandler.WriteError(w, statusCode, errorMsg)
+		return
+	}
+
+	response := ValuationResponse{
+		Currency: valuation.Currency,
+		Date:     valuation.Date.Format("2006-01-02"),
+		Total:    valuation.Total,
+		Wallets:  make([]WalletValuationResponse, len(valuation.Wallets)),
+	}
+	for i, wallet := range valuation.Wallets {
+		response.Wallets[i] = WalletValuationResponse{
+			WalletID:   wallet.WalletID,
+			Name:       wallet.Name,
+			Currency:   wallet.Currency,
+			Balance:    wallet.Balance,
+			RateSeries: wallet.RateSeries,
+			Converted:  wallet.Converted,
+			Rate:       wallet.Rate,
+			SeriesUsed: wallet.SeriesUsed,
+			AsOf:       wallet.AsOf.Format("2006-01-02"),
+			Via:        wallet.Via,
+		}
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, response)
+}
+
 func (h *Handler) GetCurrencies(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
@@ -273,6 +359,15 @@ func (h *Handler) GetCurrencies(w http.ResponseWriter, r *http.Request) {
 	basehandler.WriteJSON(w, http.StatusOK, response)
 }
 
+func (h *Handler) GetRateSeries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	basehandler.WriteJSON(w, http.StatusOK, domain.GetAllRateSeries())
+}
+
 func (h *Handler) GetWalletTypes(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		basehandler.WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
@@ -312,10 +407,6 @@ func validateWalletRequest(req WalletRequest) error {
 		return &ValidationError{Field: "type", Message: "Wallet type must be 0 (Bank), 1 (Cash), 2 (CreditCard), 3 (DebitCard), 4 (Savings), 5 (Investment), or 6 (Other)"}
 	}
 
-	if req.Balance == nil {
-		return &ValidationError{Field: "balance", Message: "Balance is required"}
-	}
-
 	if req.Currency == nil {
 		return &ValidationError{Field: "currency", Message: "Currency is required"}
 	}
@@ -324,9 +415,104 @@ func validateWalletRequest(req WalletRequest) error {
 		return &ValidationError{Field: "currency", Message: "Invalid currency code"}
 	}
 
+	if req.RateSeries != nil && !domain.IsValidRateSeries(*req.RateSeries) {
+		return &ValidationError{Field: "rate_series", Message: "Rate series must be one of: " + strings.Join(domain.GetAllRateSeries(), ", ")}
+	}
+
+	if req.AccountID != nil && len(strings.TrimSpace(*req.AccountID)) > 64 {
+		return &ValidationError{Field: "account_id", Message: "Account ID must not exceed 64 characters"}
+	}
+
+	hasTerms := req.CreditLimit != nil || req.StatementClosingDay != nil || req.PaymentDueDay != nil
+	if hasTerms && *req.Type != domain.WalletTypeCreditCard.Value() {
+		return &ValidationError{Field: "credit_limit", Message: "Only credit card wallets have a credit limit, statement closing day and payment due day"}
+	}
+	if hasTerms {
+		if req.CreditLimit == nil {
+			return &ValidationError{Field: "credit_limit", Message: "Credit limit is required"}
+		}
+		if !req.CreditLimit.IsPositive() {
+			return &ValidationError{Field: "credit_limit", Message: "Credit limit must be greater than zero"}
+		}
+		if req.StatementClosingDay == nil || *req.StatementClosingDay < 1 || *req.StatementClosingDay > 31 {
+			return &ValidationError{Field: "statement_closing_day", Message: "Statement closing day must be between 1 and 31"}
+		}
+		if req.PaymentDueDay == nil || *req.PaymentDueDay < 1 || *req.PaymentDueDay > 31 {
+			return &ValidationError{Field: "payment_due_day", Message: "Payment due day must be between 1 and 31"}
+		}
+	}
+
 	return nil
 }
 
+func toWalletResponse(wallet *queries.WalletResponse) WalletResponse {
+	return WalletResponse{
+		ID:                  wallet.ID,
+		Name:                wallet.Name,
+		Type:                wallet.Type,
+		TypeName:            wallet.TypeName,
+		Balance:             wallet.Balance,
+		Currency:            wallet.Currency,
+		RateSeries:          wallet.RateSeries,
+		AccountID:           wallet.AccountID,
+		CreatedAt:           wallet.CreatedAt,
+		UpdatedAt:           wallet.UpdatedAt,
+		CreatedBy:           wallet.CreatedBy,
+		UpdatedBy:           wallet.UpdatedBy,
+		CreditLimit:         wallet.CreditLimit,
+		StatementClosingDay: wallet.StatementClosingDay,
+		PaymentDueDay:       wallet.PaymentDueDay,
+		AvailableCredit:     wallet.AvailableCredit,
+	}
+}
+
+func isCreditCardTermsError(err error) bool {
+	return errors.Is(err, domain.ErrCreditCardTermsRequired) ||
+		errors.Is(err, domain.ErrCreditCardTermsNotAllowed) ||
+		errors.Is(err, domain.ErrInvalidCreditLimit) ||
+		errors.Is(err, domain.ErrInvalidClosingDay) ||
+		errors.Is(err, domain.ErrInvalidDueDay)
+}
+
+func creditCardTermsMessage(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrCreditCardTermsRequired):
+		return "Credit card wallets require credit_limit, statement_closing_day and payment_due_day"
+	case errors.Is(err, domain.ErrCreditCardTermsNotAllowed):
+		return "Only credit card wallets have a credit limit, statement closing day and payment due day"
+	case errors.Is(err, domain.ErrInvalidCreditLimit):
+		return "Credit limit must be greater than zero"
+	case errors.Is(err, domain.ErrInvalidClosingDay):
+		return "Statement closing day must be between 1 and 31"
+	default:
+		return "Payment due day must be between 1 and 31"
+	}
+}
+
+func intValue(value *int) int {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
+func rateSeries(req WalletRequest) string {
+	if req.RateSeries == nil {
+		return ""
+	}
+	return *req.RateSeries
+}
+
+// accountID returns the trimmed account ID of the request, or nil when the
+// request leaves it out.
+func accountID(req WalletRequest) *string {
+	if req.AccountID == nil {
+		return nil
+	}
+	value := strings.TrimSpace(*req.AccountID)
+	return &value
+}
+
 func isValidWalletType(typeValue int) bool {
 	return typeValue >= 0 && typeValue <= 6
 }
@@ -7,11 +7,13 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"fin-flow-api/internal/modules/wallets/application/contracts/commands"
 	"fin-flow-api/internal/modules/wallets/application/contracts/queries"
+	shareddomain "fin-flow-api/internal/shared/domain"
 	"fin-flow-api/internal/shared/middleware"
 )
 
@@ -23,6 +25,7 @@ type mockWalletService struct {
 	listErr    error
 	wallet     *queries.WalletResponse
 	wallets    []*queries.WalletResponse
+	lastCreate commands.WalletRequest
 }
 
 func newMockWalletService() *mockWalletService {
@@ -32,6 +35,7 @@ func newMockWalletService() *mockWalletService {
 }
 
 func (m *mockWalletService) Create(ctx context.Context, req commands.WalletRequest) error {
+	m.lastCreate = req
 	return m.createErr
 }
 
@@ -57,6 +61,17 @@ func (m *mockWalletService) List(ctx context.Context) ([]*queries.WalletResponse
 	return m.wallets, nil
 }
 
+type mockValuationService struct {
+	valuation    *queries.ValuationResponse
+	err          error
+	lastCurrency string
+}
+
+func (m *mockValuationService) Value(ctx context.Context, currency string, date time.Time) (*queries.ValuationResponse, error) {
+	m.lastCurrency = currency
+	return m.valuation, m.err
+}
+
 func createContextWithUserID(userID string) context.Context {
 	ctx := context.Background()
 	return context.WithValue(ctx, middleware.UserIDKey, userID)
@@ -67,7 +82,7 @@ func TestCreateWallet_Success(t *testing.T) {
 	handler := &Handler{walletService: service}
 
 	typeValue := 0
-	balanceValue := 1000.50
+	balanceValue := shareddomain.MustParseAmount("1000.50")
 	currencyValue := "USD"
 	body := WalletRequest{
 		Name:     "Main Account",
@@ -95,6 +110,50 @@ func TestCreateWallet_Success(t *testing.T) {
 	}
 }
 
+func TestCreateWallet_ExactBalance(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		balance string
+	}{
+		{"string balance", `{"name":"Cold Storage","type":5,"balance":"0.00012345","currency":"BTC"}`, "0.00012345"},
+		{"number balance", `{"name":"Cold Storage","type":5,"balance":0.1,"currency":"BTC"}`, "0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newMockWalletService()
+			handler := &Handler{walletService: service}
+
+			req := httptest.NewRequest("POST", "/wallets", bytes.NewBufferString(tt.body))
+			rr := httptest.NewRecorder()
+			handler.CreateWallet(rr, req)
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d", rr.Code)
+			}
+			if service.lastCreate.Balance.String() != tt.balance {
+				t.Errorf("expected balance %s, got %s", tt.balance, service.lastCreate.Balance)
+			}
+		})
+	}
+}
+
+func TestCreateWallet_BalancePrecision(t *testing.T) {
+	service := newMockWalletService()
+	service.createErr = errors.New("amount has more decimal places than the currency allows")
+	handler := &Handler{walletService: service}
+
+	body := `{"name":"Main Account","type":0,"balance":"10.005","currency":"USD"}`
+	req := httptest.NewRequest("POST", "/wallets", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+	handler.CreateWallet(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rr.Code)
+	}
+}
+
 func TestCreateWallet_InvalidMethod(t *testing.T) {
 	service := newMockWalletService()
 	handler := &Handler{walletService: service}
@@ -127,14 +186,18 @@ func TestCreateWallet_ValidationErrors(t *testing.T) {
 		body    WalletRequest
 		wantErr bool
 	}{
-		{"empty name", WalletRequest{Type: intPtr(0), Balance: floatPtr(100.0), Currency: stringPtr("USD")}, true},
-		{"short name", WalletRequest{Name: "A", Type: intPtr(0), Balance: floatPtr(100.0), Currency: stringPtr("USD")}, true},
-		{"missing type", WalletRequest{Name: "Main Account", Balance: floatPtr(100.0), Currency: stringPtr("USD")}, true},
-		{"invalid type", WalletRequest{Name: "Main Account", Type: intPtr(99), Balance: floatPtr(100.0), Currency: stringPtr("USD")}, true},
-		{"missing balance", WalletRequest{Name: "Main Account", Type: intPtr(0), Currency: stringPtr("USD")}, true},
-		{"missing currency", WalletRequest{Name: "Main Account", Type: intPtr(0), Balance: floatPtr(100.0)}, true},
-		{"invalid currency", WalletRequest{Name: "Main Account", Type: intPtr(0), Balance: floatPtr(100.0), Currency: stringPtr("INVALID")}, true},
-		{"valid request", WalletRequest{Name: "Main Account", Type: intPtr(0), Balance: floatPtr(1000.50), Currency: stringPtr("USD")}, false},
+		{"empty name", WalletRequest{Type: intPtr(0), Balance: amountPtr("100.0"), Currency: stringPtr("USD")}, true},
+		{"short name", WalletRequest{Name: "A", Type: intPtr(0), Balance: amountPtr("100.0"), Currency: stringPtr("USD")}, true},
+		{"missing type", WalletRequest{Name: "Main Account", Balance: amountPtr("100.0"), Currency: stringPtr("USD")}, true},
+		{"invalid type", WalletRequest{Name: "Main Account", Type: intPtr(99), Balance: amountPtr("100.0"), Currency: stringPtr("USD")}, true},
+		{"missing balance", WalletRequest{Name: "Main Account", Type: intPtr(0), Currency: stringPtr("USD")}, false},
+		{"missing currency", WalletRequest{Name: "Main Account", Type: intPtr(0), Balance: amountPtr("100.0")}, true},
+		{"invalid currency", WalletRequest{Name: "Main Account", Type: intPtr(0), Balance: amountPtr("100.0"), Currency: stringPtr("INVALID")}, true},
+		{"invalid rate series", WalletRequest{Name: "Main Account", Type: intPtr(0), Currency: stringPtr("ARS"), RateSeries: stringPtr("tarjeta")}, true},
+		{"valid rate series", WalletRequest{Name: "Main Account", Type: intPtr(0), Currency: stringPtr("ARS"), RateSeries: stringPtr("blue")}, false},
+		{"long account id", WalletRequest{Name: "Main Account", Type: intPtr(0), Currency: stringPtr("USD"), AccountID: stringPtr(strings.Repeat("9", 65))}, true},
+		{"valid account id", WalletRequest{Name: "Main Account", Type: intPtr(0), Currency: stringPtr("USD"), AccountID: stringPtr("0001-234")}, false},
+		{"valid request", WalletRequest{Name: "Main Account", Type: intPtr(0), Balance: amountPtr("1000.50"), Currency: stringPtr("USD")}, false},
 	}
 
 	for _, tt := range tests {
@@ -147,13 +210,37 @@ func TestCreateWallet_ValidationErrors(t *testing.T) {
 	}
 }
 
+func TestCreateWallet_AccountIDInUse(t *testing.T) {
+	service := newMockWalletService()
+	service.createErr = errors.New("account id is already used by another wallet")
+	handler := &Handler{walletService: service}
+
+	body := WalletRequest{
+		Name:      "Main Account",
+		Type:      intPtr(0),
+		Currency:  stringPtr("USD"),
+		AccountID: stringPtr("0001-234"),
+	}
+	jsonBody, _ := json.Marshal(body)
+
+	req := httptest.NewRequest("POST", "/wallets", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(createContextWithUserID("user1"))
+	rr := httptest.NewRecorder()
+	handler.CreateWallet(rr, req)
+
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409, got %d", rr.Code)
+	}
+}
+
 func TestCreateWallet_ServiceError(t *testing.T) {
 	service := newMockWalletService()
 	service.createErr = errors.New("user not authenticated")
 	handler := &Handler{walletService: service}
 
 	typeValue := 0
-	balanceValue := 1000.50
+	balanceValue := shareddomain.MustParseAmount("1000.50")
 	currencyValue := "USD"
 	body := WalletRequest{
 		Name:     "Main Account",
@@ -180,7 +267,7 @@ func TestGetWallet_Success(t *testing.T) {
 		Name:      "Main Account",
 		Type:      0,
 		TypeName:  "Bank",
-		Balance:   1000.50,
+		Balance:   shareddomain.MustParseAmount("1000.50"),
 		Currency:  "USD",
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
@@ -198,10 +285,13 @@ func TestGetWallet_Success(t *testing.T) {
 		t.Errorf("expected status 200, got %d", rr.Code)
 	}
 
-	var response WalletResponse
+	var response map[string]interface{}
 	json.NewDecoder(rr.Body).Decode(&response)
-	if response.ID != "wallet1" {
-		t.Errorf("expected ID 'wallet1', got %s", response.ID)
+	if response["id"] != "wallet1" {
+		t.Errorf("expected ID 'wallet1', got %v", response["id"])
+	}
+	if response["balance"] != "1000.5" {
+		t.Errorf("expected balance encoded as the string 1000.5, got %v", response["balance"])
 	}
 }
 
@@ -246,7 +336,7 @@ func TestUpdateWallet_Success(t *testing.T) {
 	handler := &Handler{walletService: service}
 
 	typeValue := 4
-	balanceValue := 5000.00
+	balanceValue := shareddomain.MustParseAmount("5000.00")
 	currencyValue := "EUR"
 	body := WalletRequest{
 		Name:     "Savings Account",
@@ -273,7 +363,7 @@ func TestUpdateWallet_Forbidden(t *testing.T) {
 	handler := &Handler{walletService: service}
 
 	typeValue := 4
-	balanceValue := 5000.00
+	balanceValue := shareddomain.MustParseAmount("5000.00")
 	currencyValue := "EUR"
 	body := WalletRequest{
 		Name:     "Savings Account",
@@ -294,6 +384,37 @@ func TestUpdateWallet_Forbidden(t *testing.T) {
 	}
 }
 
+func TestUpdateWallet_Conflict(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"currency locked", errors.New("the currency of a wallet with a balance, transactions, trades or reconciliations cannot be changed")},
+		{"investment with trades", errors.New("a wallet with trades must remain an investment wallet")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newMockWalletService()
+			service.updateErr = tt.err
+			handler := &Handler{walletService: service}
+
+			typeValue := 0
+			currencyValue := "EUR"
+			jsonBody, _ := json.Marshal(WalletRequest{Name: "Checking", Type: &typeValue, Currency: &currencyValue})
+
+			req := httptest.NewRequest("PUT", "/wallets/wallet1", bytes.NewBuffer(jsonBody))
+			req = req.WithContext(createContextWithUserID("user1"))
+			rr := httptest.NewRecorder()
+			handler.UpdateWallet(rr, req)
+
+			if rr.Code != http.StatusConflict {
+				t.Errorf("expected status 409, got %d: %s", rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
 func TestDeleteWallet_Success(t *testing.T) {
 	service := newMockWalletService()
 	handler := &Handler{walletService: service}
@@ -323,6 +444,21 @@ func TestDeleteWallet_Forbidden(t *testing.T) {
 	}
 }
 
+func TestDeleteWallet_HasTransactions(t *testing.T) {
+	service := newMockWalletService()
+	service.deleteErr = errors.New("wallet has transactions")
+	handler := &Handler{walletService: service}
+
+	req := httptest.NewRequest("DELETE", "/wallets/wallet1", nil)
+	req = req.WithContext(createContextWithUserID("user1"))
+	rr := httptest.NewRecorder()
+	handler.DeleteWallet(rr, req)
+
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected status 409, got %d", rr.Code)
+	}
+}
+
 func TestListWallets_Success(t *testing.T) {
 	service := newMockWalletService()
 	service.wallets = []*queries.WalletResponse{
@@ -331,7 +467,7 @@ func TestListWallets_Success(t *testing.T) {
 			Name:      "Main Account",
 			Type:      0,
 			TypeName:  "Bank",
-			Balance:   1000.50,
+			Balance:   shareddomain.MustParseAmount("1000.50"),
 			Currency:  "USD",
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
@@ -357,12 +493,82 @@ func TestListWallets_Success(t *testing.T) {
 	}
 }
 
+func TestGetValuation_Success(t *testing.T) {
+	asOf := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	service := &mockValuationService{valuation: &queries.ValuationResponse{
+		Currency: "USD",
+		Date:     time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+		Total:    shareddomain.MustParseAmount("80"),
+		Wallets: []*queries.WalletValuationResponse{{
+			WalletID:   "wallet1",
+			Name:       "Pesos",
+			Currency:   "ARS",
+			Balance:    shareddomain.MustParseAmount("100000"),
+			RateSeries: "blue",
+			Converted:  shareddomain.MustParseAmount("80"),
+			Rate:       shareddomain.MustParseAmount("0.0008"),
+			SeriesUsed: "blue",
+			AsOf:       asOf,
+		}},
+	}}
+	handler := &Handler{valuationService: service}
+
+	req := httptest.NewRequest("GET", "/wallets/valuation?currency=usd", nil)
+	req = req.WithContext(createContextWithUserID("user1"))
+	rr := httptest.NewRecorder()
+	handler.GetValuation(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if service.lastCurrency != "usd" {
+		t.Errorf("expected currency usd to be passed through, got %s", service.lastCurrency)
+	}
+
+	var response ValuationResponse
+	json.NewDecoder(rr.Body).Decode(&response)
+	if response.Date != "2026-01-05" || response.Total.String() != "80" || len(response.Wallets) != 1 {
+		t.Fatalf("unexpected valuation %+v", response)
+	}
+	if wallet := response.Wallets[0]; wallet.SeriesUsed != "blue" || wallet.AsOf != "2026-01-02" {
+		t.Errorf("unexpected wallet valuation %+v", wallet)
+	}
+}
+
+func TestGetValuation_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"not authenticated", errors.New("user not authenticated"), http.StatusUnauthorized},
+		{"invalid currency", errors.New("invalid currency"), http.StatusBadRequest},
+		{"missing rate", errors.New(`exchange rate not found for wallet "Pesos" (ARS/USD, blue series)`), http.StatusNotFound},
+		{"other", errors.New("database error"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &Handler{valuationService: &mockValuationService{err: tt.err}}
+
+			req := httptest.NewRequest("GET", "/wallets/valuation", nil)
+			rr := httptest.NewRecorder()
+			handler.GetValuation(rr, req)
+
+			if rr.Code != tt.expected {
+				t.Errorf("expected status %d, got %d", tt.expected, rr.Code)
+			}
+		})
+	}
+}
+
 func intPtr(i int) *int {
 	return &i
 }
 
-func floatPtr(f float64) *float64 {
-	return &f
+func amountPtr(value string) *shareddomain.Amount {
+	amount := shareddomain.MustParseAmount(value)
+	return &amount
 }
 
 func stringPtr(s string) *string {
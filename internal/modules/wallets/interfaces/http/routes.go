@@ -16,12 +16,15 @@ func SetupRoutes(mux *http.ServeMux, jwtService jwt.Service) {
 func mountWallets(mux *http.ServeMux, jwtService jwt.Service) {
 	mux.HandleFunc("/wallets/currencies", walletHandler.GetCurrencies)
 	mux.HandleFunc("/wallets/types", walletHandler.GetWalletTypes)
+	mux.HandleFunc("/wallets/rate-series", walletHandler.GetRateSeries)
 
 	mux.HandleFunc("/wallets", handleWalletsCollection(jwtService))
 
+	mux.Handle("/wallets/valuation", middleware.RequireAuth(jwtService)(http.HandlerFunc(walletHandler.GetValuation)))
+
 	protectedHandler := middleware.RequireAuth(jwtService)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		path := r.URL.Path
-		if path == "/wallets/currencies" || path == "/wallets/types" {
+		if path == "/wallets/currencies" || path == "/wallets/types" || path == "/wallets/rate-series" {
 			http.NotFound(w, r)
 			return
 		}
@@ -1,8 +1,16 @@
 package http
 
+import shareddomain "fin-flow-api/internal/shared/domain"
+
 type WalletRequest struct {
-	Name     string  `json:"name"`
-	Type     *int    `json:"type"`
-	Balance  *float64 `json:"balance"`
-	Currency *string  `json:"currency"`
-}
\ No newline at end of file
+	Name       string               `json:"name"`
+	Type       *int                 `json:"type"`
+	Balance    *shareddomain.Amount `json:"balance"`
+	Currency   *string              `json:"currency"`
+	RateSeries *string              `json:"rate_series"`
+	AccountID  *string              `json:"account_id"`
+	// Billing terms, only accepted for credit card wallets.
+	CreditLimit         *shareddomain.Amount `json:"credit_limit"`
+	StatementClosingDay *int                 `json:"statement_closing_day"`
+	PaymentDueDay       *int                 `json:"payment_due_day"`
+}
@@ -1,16 +1,28 @@
 package http
 
-import "time"
+import (
+	"time"
+
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
 
 type WalletResponse struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
-	Type      int       `json:"type"`
-	TypeName  string    `json:"type_name"`
-	Balance   float64   `json:"balance"`
-	Currency  string    `json:"currency"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	CreatedBy string    `json:"created_by"`
-	UpdatedBy string    `json:"updated_by"`
-}
\ No newline at end of file
+	ID         string              `json:"id"`
+	Name       string              `json:"name"`
+	Type       int                 `json:"type"`
+	TypeName   string              `json:"type_name"`
+	Balance    shareddomain.Amount `json:"balance"`
+	Currency   string              `json:"currency"`
+	RateSeries string              `json:"rate_series"`
+	AccountID  string              `json:"account_id,omitempty"`
+	CreatedAt  time.Time           `json:"created_at"`
+	UpdatedAt  time.Time           `json:"updated_at"`
+	CreatedBy  string              `json:"created_by"`
+	UpdatedBy  string              `json:"updated_by"`
+	// Credit card wallets also report their billing terms and how much of
+	// the limit is still available.
+	CreditLimit         *shareddomain.Amount `json:"credit_limit,omitempty"`
+	StatementClosingDay int                  `json:"statement_closing_day,omitempty"`
+	PaymentDueDay       int                  `json:"payment_due_day,omitempty"`
+	AvailableCredit     *shareddomain.Amount `json:"available_credit,omitempty"`
+}
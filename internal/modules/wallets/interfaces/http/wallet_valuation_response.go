@@ -0,0 +1,23 @@
+package http
+
+import shareddomain "fin-flow-api/internal/shared/domain"
+
+type ValuationResponse struct {
+	Currency string                    `json:"currency"`
+	Date     string                    `json:"date"`
+	Total    shareddomain.Amount       `json:"total"`
+	Wallets  []WalletValuationResponse `json:"wallets"`
+}
+
+type WalletValuationResponse struct {
+	WalletID   string              `json:"wallet_id"`
+	Name       string              `json:"name"`
+	Currency   string              `json:"currency"`
+	Balance    shareddomain.Amount `json:"balance"`
+	RateSeries string              `json:"rate_series"`
+	Converted  shareddomain.Amount `json:"converted"`
+	Rate       shareddomain.Amount `json:"rate"`
+	SeriesUsed string              `json:"series_used,omitempty"`
+	AsOf       string              `json:"as_of"`
+	Via        string              `json:"via,omitempty"`
+}
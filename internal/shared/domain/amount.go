@@ -0,0 +1,183 @@
+package domain
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrInvalidAmountFormat = errors.New("invalid amount format")
+	ErrAmountPrecision     = errors.New("amount has more decimal places than the currency allows")
+)
+
+// Amount is an exact decimal quantity. It is used for money and for the
+// rates applied between currencies, so it never goes through float64.
+// The zero value is 0.
+type Amount struct {
+	value decimal.Decimal
+}
+
+// defaultCurrencyScale is the number of decimal places used by currencies
+// that are not listed in currencyScales.
+const defaultCurrencyScale int32 = 2
+
+var currencyScales = map[string]int32{
+	"JPY":  0,
+	"BTC":  8,
+	"ETH":  18,
+	"USDT": 6,
+	"USDC": 6,
+	"NEXO": 18,
+	"BNB":  18,
+}
+
+// CurrencyScale returns the number of decimal places amounts in the given
+// currency are kept at.
+func CurrencyScale(currency string) int32 {
+	if scale, ok := currencyScales[currency]; ok {
+		return scale
+	}
+	return defaultCurrencyScale
+}
+
+func ParseAmount(value string) (Amount, error) {
+	d, err := decimal.NewFromString(value)
+	if err != nil {
+		return Amount{}, ErrInvalidAmountFormat
+	}
+	return Amount{value: d}, nil
+}
+
+// MustParseAmount is like ParseAmount but panics on invalid input. It is
+// meant for constants and tests.
+func MustParseAmount(value string) Amount {
+	amount, err := ParseAmount(value)
+	if err != nil {
+		panic(fmt.Sprintf("invalid amount %q", value))
+	}
+	return amount
+}
+
+func NewAmountFromInt(value int64) Amount {
+	return Amount{value: decimal.NewFromInt(value)}
+}
+
+func (a Amount) Add(other Amount) Amount {
+	return Amount{value: a.value.Add(other.value)}
+}
+
+func (a Amount) Sub(other Amount) Amount {
+	return Amount{value: a.value.Sub(other.value)}
+}
+
+func (a Amount) Mul(other Amount) Amount {
+	return Amount{value: a.value.Mul(other.value)}
+}
+
+// DivRound divides a by other and rounds the result to scale decimal places.
+func (a Amount) DivRound(other Amount, scale int32) Amount {
+	return Amount{value: a.value.DivRound(other.value, scale)}
+}
+
+func (a Amount) Neg() Amount {
+	return Amount{value: a.value.Neg()}
+}
+
+func (a Amount) Abs() Amount {
+	return Amount{value: a.value.Abs()}
+}
+
+// Round rounds half away from zero to scale decimal places.
+func (a Amount) Round(scale int32) Amount {
+	return Amount{value: a.value.Round(scale)}
+}
+
+// RoundToCurrency rounds a to the scale of the given currency.
+func (a Amount) RoundToCurrency(currency string) Amount {
+	return a.Round(CurrencyScale(currency))
+}
+
+// FitsCurrency reports whether a can be represented in the given currency
+// without losing decimal places.
+func (a Amount) FitsCurrency(currency string) bool {
+	return a.value.Equal(a.value.Round(CurrencyScale(currency)))
+}
+
+func (a Amount) Cmp(other Amount) int {
+	return a.value.Cmp(other.value)
+}
+
+func (a Amount) Equal(other Amount) bool {
+	return a.value.Equal(other.value)
+}
+
+func (a Amount) IsZero() bool {
+	return a.value.IsZero()
+}
+
+func (a Amount) IsPositive() bool {
+	return a.value.IsPositive()
+}
+
+func (a Amount) IsNegative() bool {
+	return a.value.IsNegative()
+}
+
+// String returns the canonical decimal representation, without trailing
+// zeros or exponent notation.
+func (a Amount) String() string {
+	return a.value.String()
+}
+
+// StringFixed returns a with exactly scale decimal places.
+func (a Amount) StringFixed(scale int32) string {
+	return a.value.StringFixed(scale)
+}
+
+// MarshalJSON encodes the amount as a JSON string so clients never parse it
+// into a binary float.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.value.String())
+}
+
+// UnmarshalJSON accepts both JSON strings and JSON numbers.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	raw := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return ErrInvalidAmountFormat
+		}
+	}
+
+	amount, err := ParseAmount(raw)
+	if err != nil {
+		return err
+	}
+	*a = amount
+	return nil
+}
+
+// Scan implements sql.Scanner for NUMERIC columns.
+func (a *Amount) Scan(src interface{}) error {
+	if src == nil {
+		*a = Amount{}
+		return nil
+	}
+	return a.value.Scan(src)
+}
+
+// Value implements driver.Valuer. Amounts are sent as text so the database
+// parses them without going through float64.
+func (a Amount) Value() (driver.Value, error) {
+	return a.value.String(), nil
+}
@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"integer", "100", "100", false},
+		{"cents", "1000.50", "1000.5", false},
+		{"satoshis", "0.00012345", "0.00012345", false},
+		{"wei", "0.000000000000000001", "0.000000000000000001", false},
+		{"negative", "-25.10", "-25.1", false},
+		{"empty", "", "", true},
+		{"not a number", "abc", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, err := ParseAmount(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAmount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && amount.String() != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, amount.String())
+			}
+		})
+	}
+}
+
+func TestAmount_ArithmeticIsExact(t *testing.T) {
+	total := Amount{}
+	for i := 0; i < 10; i++ {
+		total = total.Add(MustParseAmount("0.1"))
+	}
+
+	if !total.Equal(NewAmountFromInt(1)) {
+		t.Errorf("expected 1, got %s", total)
+	}
+
+	if got := MustParseAmount("0.3").Sub(MustParseAmount("0.1")).String(); got != "0.2" {
+		t.Errorf("expected 0.2, got %s", got)
+	}
+}
+
+func TestCurrencyScale(t *testing.T) {
+	tests := []struct {
+		currency string
+		want     int32
+	}{
+		{"USD", 2},
+		{"EUR", 2},
+		{"JPY", 0},
+		{"BTC", 8},
+		{"ETH", 18},
+		{"UNKNOWN", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.currency, func(t *testing.T) {
+			if got := CurrencyScale(tt.currency); got != tt.want {
+				t.Errorf("CurrencyScale(%s) = %d, want %d", tt.currency, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmount_FitsCurrency(t *testing.T) {
+	tests := []struct {
+		amount   string
+		currency string
+		want     bool
+	}{
+		{"10.50", "USD", true},
+		{"10.505", "USD", false},
+		{"1500", "JPY", true},
+		{"1500.5", "JPY", false},
+		{"0.00012345", "BTC", true},
+		{"0.000123456", "BTC", false},
+		{"0.000000000000000001", "ETH", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.amount+" "+tt.currency, func(t *testing.T) {
+			if got := MustParseAmount(tt.amount).FitsCurrency(tt.currency); got != tt.want {
+				t.Errorf("FitsCurrency() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmount_RoundToCurrency(t *testing.T) {
+	if got := MustParseAmount("1579.335").RoundToCurrency("JPY").String(); got != "1579" {
+		t.Errorf("expected 1579, got %s", got)
+	}
+	if got := MustParseAmount("3.335").RoundToCurrency("USD").String(); got != "3.34" {
+		t.Errorf("expected 3.34, got %s", got)
+	}
+}
+
+func TestAmount_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Balance Amount `json:"balance"`
+	}{Balance: MustParseAmount("0.00012345")})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	if string(data) != `{"balance":"0.00012345"}` {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+}
+
+func TestAmount_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"string", `{"amount":"0.00012345"}`, "0.00012345", false},
+		{"number", `{"amount":1000.50}`, "1000.5", false},
+		{"long number", `{"amount":0.000000000000000001}`, "0.000000000000000001", false},
+		{"null", `{"amount":null}`, "0", false},
+		{"invalid string", `{"amount":"ten"}`, "", true},
+		{"boolean", `{"amount":true}`, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body struct {
+				Amount Amount `json:"amount"`
+			}
+			err := json.Unmarshal([]byte(tt.input), &body)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && body.Amount.String() != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, body.Amount.String())
+			}
+		})
+	}
+}
+
+func TestAmount_ScanAndValue(t *testing.T) {
+	var amount Amount
+	if err := amount.Scan("1000.500000000000000000"); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if amount.String() != "1000.5" {
+		t.Errorf("expected 1000.5, got %s", amount.String())
+	}
+
+	value, err := MustParseAmount("0.00012345").Value()
+	if err != nil {
+		t.Fatalf("Value failed: %v", err)
+	}
+	if value != "0.00012345" {
+		t.Errorf("expected 0.00012345, got %v", value)
+	}
+
+	if err := amount.Scan(nil); err != nil {
+		t.Fatalf("Scan(nil) failed: %v", err)
+	}
+	if !amount.IsZero() {
+		t.Errorf("expected zero after scanning NULL, got %s", amount.String())
+	}
+}
@@ -0,0 +1,23 @@
+package domain
+
+// Role decides what a user may do with resources that are not their own.
+type Role string
+
+const (
+	RoleUser  Role = "user"
+	RoleAdmin Role = "admin"
+)
+
+func (r Role) IsValid() bool {
+	return r == RoleUser || r == RoleAdmin
+}
+
+// ParseRole returns the role named by value. Anything unknown, including
+// the empty string carried by tokens issued before roles existed, is the
+// least privileged role.
+func ParseRole(value string) Role {
+	if role := Role(value); role.IsValid() {
+		return role
+	}
+	return RoleUser
+}
@@ -0,0 +1,10 @@
+package jobs
+
+import "context"
+
+// Job is a background task that runs alongside the HTTP server. Run blocks
+// until ctx is cancelled and must return promptly once it is.
+type Job interface {
+	Name() string
+	Run(ctx context.Context) error
+}
@@ -0,0 +1,18 @@
+package jwt
+
+import "context"
+
+// IdentityClaims are the user details carried by a verified token from an
+// external identity provider.
+type IdentityClaims struct {
+	Subject   string
+	FirstName string
+	LastName  string
+	Email     string
+}
+
+// IdentityVerifier checks the signature and standard claims of a token
+// issued by an external identity provider.
+type IdentityVerifier interface {
+	Verify(ctx context.Context, tokenString string) (*IdentityClaims, error)
+}
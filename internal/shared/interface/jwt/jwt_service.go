@@ -1,6 +1,12 @@
 package jwt
 
+// AccessClaims identify the caller of an access token.
+type AccessClaims struct {
+	UserID string
+	Role   string
+}
+
 type Service interface {
-	GenerateToken(userID string) (string, error)
-	ValidateToken(tokenString string) (string, error)
-}
\ No newline at end of file
+	GenerateToken(userID string, role string) (string, error)
+	ValidateToken(tokenString string) (*AccessClaims, error)
+}
@@ -5,13 +5,17 @@ import (
 	"net/http"
 	"strings"
 
+	shareddomain "fin-flow-api/internal/shared/domain"
 	basehandler "fin-flow-api/internal/shared/http"
 	"fin-flow-api/internal/shared/interface/jwt"
 )
 
 type contextKey string
 
-const UserIDKey contextKey = "userID"
+const (
+	UserIDKey contextKey = "userID"
+	RoleKey   contextKey = "role"
+)
 
 func RequireAuth(jwtService jwt.Service) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -30,13 +34,14 @@ func RequireAuth(jwtService jwt.Service) func(http.Handler) http.Handler {
 
 			tokenString := parts[1]
 
-			userID, err := jwtService.ValidateToken(tokenString)
+			claims, err := jwtService.ValidateToken(tokenString)
 			if err != nil {
 				basehandler.WriteError(w, http.StatusUnauthorized, "Invalid or expired token")
 				return
 			}
 
-			ctx := context.WithValue(r.Context(), UserIDKey, userID)
+			ctx := context.WithValue(r.Context(), UserIDKey, claims.UserID)
+			ctx = context.WithValue(ctx, RoleKey, shareddomain.ParseRole(claims.Role))
 			r = r.WithContext(ctx)
 
 			next.ServeHTTP(w, r)
@@ -47,4 +52,13 @@ func RequireAuth(jwtService jwt.Service) func(http.Handler) http.Handler {
 func GetUserIDFromContext(ctx context.Context) (string, bool) {
 	userID, ok := ctx.Value(UserIDKey).(string)
 	return userID, ok
-}
\ No newline at end of file
+}
+
+// GetRoleFromContext returns the caller's role. Requests that did not go
+// through RequireAuth have the least privileged role.
+func GetRoleFromContext(ctx context.Context) shareddomain.Role {
+	if role, ok := ctx.Value(RoleKey).(shareddomain.Role); ok {
+		return role
+	}
+	return shareddomain.RoleUser
+}
@@ -4,37 +4,40 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	shareddomain "fin-flow-api/internal/shared/domain"
+	"fin-flow-api/internal/shared/interface/jwt"
 )
 
 type mockJWTService struct {
-	generateTokenFunc func(userID string) (string, error)
-	validateTokenFunc func(tokenString string) (string, error)
+	generateTokenFunc func(userID string, role string) (string, error)
+	validateTokenFunc func(tokenString string) (*jwt.AccessClaims, error)
 }
 
-func (m *mockJWTService) GenerateToken(userID string) (string, error) {
+func (m *mockJWTService) GenerateToken(userID string, role string) (string, error) {
 	if m.generateTokenFunc != nil {
-		return m.generateTokenFunc(userID)
+		return m.generateTokenFunc(userID, role)
 	}
 	return "mock-token", nil
 }
 
-func (m *mockJWTService) ValidateToken(tokenString string) (string, error) {
+func (m *mockJWTService) ValidateToken(tokenString string) (*jwt.AccessClaims, error) {
 	if m.validateTokenFunc != nil {
 		return m.validateTokenFunc(tokenString)
 	}
 	if tokenString == "valid-token" {
-		return "user-123", nil
+		return &jwt.AccessClaims{UserID: "user-123", Role: "user"}, nil
 	}
-	return "", http.ErrAbortHandler
+	return nil, http.ErrAbortHandler
 }
 
 func TestRequireAuth_ValidToken(t *testing.T) {
 	jwtService := &mockJWTService{
-		validateTokenFunc: func(tokenString string) (string, error) {
+		validateTokenFunc: func(tokenString string) (*jwt.AccessClaims, error) {
 			if tokenString == "valid-token" {
-				return "user-123", nil
+				return &jwt.AccessClaims{UserID: "user-123", Role: "user"}, nil
 			}
-			return "", http.ErrAbortHandler
+			return nil, http.ErrAbortHandler
 		},
 	}
 
@@ -103,8 +106,8 @@ func TestRequireAuth_InvalidFormat(t *testing.T) {
 
 func TestRequireAuth_InvalidToken(t *testing.T) {
 	jwtService := &mockJWTService{
-		validateTokenFunc: func(tokenString string) (string, error) {
-			return "", http.ErrAbortHandler
+		validateTokenFunc: func(tokenString string) (*jwt.AccessClaims, error) {
+			return nil, http.ErrAbortHandler
 		},
 	}
 
@@ -127,8 +130,8 @@ func TestRequireAuth_InvalidToken(t *testing.T) {
 
 func TestGetUserIDFromContext(t *testing.T) {
 	jwtService := &mockJWTService{
-		validateTokenFunc: func(tokenString string) (string, error) {
-			return "user-456", nil
+		validateTokenFunc: func(tokenString string) (*jwt.AccessClaims, error) {
+			return &jwt.AccessClaims{UserID: "user-456", Role: "user"}, nil
 		},
 	}
 
@@ -168,4 +171,41 @@ func TestGetUserIDFromContext_NotInContext(t *testing.T) {
 	if userID != "" {
 		t.Errorf("expected empty userID, got %s", userID)
 	}
-}
\ No newline at end of file
+}
+
+func TestGetRoleFromContext(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     string
+		expected shareddomain.Role
+	}{
+		{name: "admin", role: "admin", expected: shareddomain.RoleAdmin},
+		{name: "user", role: "user", expected: shareddomain.RoleUser},
+		{name: "token without role", role: "", expected: shareddomain.RoleUser},
+		{name: "unknown role", role: "superuser", expected: shareddomain.RoleUser},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jwtService := &mockJWTService{
+				validateTokenFunc: func(tokenString string) (*jwt.AccessClaims, error) {
+					return &jwt.AccessClaims{UserID: "user-1", Role: tt.role}, nil
+				},
+			}
+
+			var captured shareddomain.Role
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				captured = GetRoleFromContext(r.Context())
+			})
+
+			req := httptest.NewRequest("GET", "/test", nil)
+			req.Header.Set("Authorization", "Bearer valid-token")
+
+			RequireAuth(jwtService)(handler).ServeHTTP(httptest.NewRecorder(), req)
+
+			if captured != tt.expected {
+				t.Errorf("expected role %s, got %s", tt.expected, captured)
+			}
+		})
+	}
+}
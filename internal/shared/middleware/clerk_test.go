@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"fin-flow-api/internal/shared/interface/jwt"
+)
+
+type mockIdentityVerifier struct {
+	claims    *jwt.IdentityClaims
+	err       error
+	lastToken string
+}
+
+func (m *mockIdentityVerifier) Verify(ctx context.Context, tokenString string) (*jwt.IdentityClaims, error) {
+	m.lastToken = tokenString
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.claims, nil
+}
+
+func TestRequireClerkAuth_ValidToken(t *testing.T) {
+	verifier := &mockIdentityVerifier{
+		claims: &jwt.IdentityClaims{Subject: "user_2abc", FirstName: "Ada", LastName: "Lovelace", Email: "ada@example.com"},
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authID, ok := GetClerkAuthIDFromContext(r)
+		if !ok || authID != "user_2abc" {
+			t.Errorf("expected auth id user_2abc, got %s", authID)
+		}
+
+		firstName, lastName, ok := GetClerkNameFromContext(r)
+		if !ok || firstName != "Ada" || lastName != "Lovelace" {
+			t.Errorf("unexpected name %s %s", firstName, lastName)
+		}
+
+		email, ok := GetClerkEmailFromContext(r)
+		if !ok || email != "ada@example.com" {
+			t.Errorf("expected email ada@example.com, got %s", email)
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest("POST", "/users/sync", nil)
+	req.Header.Set("Authorization", "Bearer clerk-token")
+	rr := httptest.NewRecorder()
+	RequireClerkAuth(verifier)(handler).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rr.Code)
+	}
+	if verifier.lastToken != "clerk-token" {
+		t.Errorf("expected token clerk-token to be verified, got %s", verifier.lastToken)
+	}
+}
+
+func TestRequireClerkAuth_RejectedToken(t *testing.T) {
+	verifier := &mockIdentityVerifier{err: errors.New("invalid signature")}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Handler should not be called when the token is rejected")
+	})
+
+	req := httptest.NewRequest("POST", "/users/sync", nil)
+	req.Header.Set("Authorization", "Bearer forged-token")
+	rr := httptest.NewRecorder()
+	RequireClerkAuth(verifier)(handler).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", rr.Code)
+	}
+}
+
+func TestRequireClerkAuth_NoAuthorizationHeader(t *testing.T) {
+	verifier := &mockIdentityVerifier{}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Handler should not be called when authorization header is missing")
+	})
+
+	req := httptest.NewRequest("POST", "/users/sync", nil)
+	rr := httptest.NewRecorder()
+	RequireClerkAuth(verifier)(handler).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", rr.Code)
+	}
+	if verifier.lastToken != "" {
+		t.Error("verifier should not be called without a token")
+	}
+}
@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	shareddomain "fin-flow-api/internal/shared/domain"
+	basehandler "fin-flow-api/internal/shared/http"
+)
+
+type Permission string
+
+const (
+	PermissionListUsers  Permission = "users:list"
+	PermissionReadUser   Permission = "users:read"
+	PermissionUpdateUser Permission = "users:update"
+	PermissionDeleteUser Permission = "users:delete"
+	PermissionAssignRole Permission = "users:assign_role"
+
+	PermissionManageExchangeRates Permission = "exchange_rates:manage"
+	PermissionManagePriceIndexes  Permission = "price_indexes:manage"
+	PermissionManageAssetPrices   Permission = "asset_prices:manage"
+)
+
+// Scope is how far a granted permission reaches.
+type Scope int
+
+const (
+	ScopeNone Scope = iota
+	// ScopeOwn grants the permission on resources owned by the caller.
+	ScopeOwn
+	// ScopeAny grants the permission on every resource.
+	ScopeAny
+)
+
+// permissionMatrix lists what each role is granted. A permission that is
+// missing for a role is ScopeNone.
+var permissionMatrix = map[shareddomain.Role]map[Permission]Scope{
+	shareddomain.RoleUser: {
+		PermissionReadUser:   ScopeOwn,
+		PermissionUpdateUser: ScopeOwn,
+		PermissionDeleteUser: ScopeOwn,
+	},
+	shareddomain.RoleAdmin: {
+		PermissionListUsers:  ScopeAny,
+		PermissionReadUser:   ScopeAny,
+		PermissionUpdateUser: ScopeAny,
+		PermissionDeleteUser: ScopeAny,
+		PermissionAssignRole: ScopeAny,
+
+		PermissionManageExchangeRates: ScopeAny,
+		PermissionManagePriceIndexes:  ScopeAny,
+		PermissionManageAssetPrices:   ScopeAny,
+	},
+}
+
+// ScopeOf returns the scope the role is granted for the permission.
+func ScopeOf(role shareddomain.Role, permission Permission) Scope {
+	return permissionMatrix[role][permission]
+}
+
+// Can reports whether a caller with the given ID and role may use the
+// permission on a resource owned by ownerID. An empty ownerID means the
+// permission applies to resources in general, which only ScopeAny allows.
+func Can(role shareddomain.Role, permission Permission, callerID, ownerID string) bool {
+	switch ScopeOf(role, permission) {
+	case ScopeAny:
+		return true
+	case ScopeOwn:
+		return ownerID != "" && callerID != "" && ownerID == callerID
+	default:
+		return false
+	}
+}
+
+// Authorize checks the caller stored in ctx by RequireAuth against the
+// permission matrix.
+func Authorize(ctx context.Context, permission Permission, ownerID string) bool {
+	callerID, ok := GetUserIDFromContext(ctx)
+	if !ok {
+		return false
+	}
+	return Can(GetRoleFromContext(ctx), permission, callerID, ownerID)
+}
+
+// RequirePermission rejects requests whose caller is not granted the
+// permission. owner extracts the ID of the user owning the addressed
+// resource; pass nil for endpoints that are not about a single resource.
+// It must be mounted behind RequireAuth.
+func RequirePermission(permission Permission, owner func(r *http.Request) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := GetUserIDFromContext(r.Context()); !ok {
+				basehandler.WriteError(w, http.StatusUnauthorized, "User not authenticated")
+				return
+			}
+
+			ownerID := ""
+			if owner != nil {
+				ownerID = owner(r)
+			}
+
+			if !Authorize(r.Context(), permission, ownerID) {
+				basehandler.WriteError(w, http.StatusForbidden, "You do not have permission to perform this action")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	shareddomain "fin-flow-api/internal/shared/domain"
+)
+
+func TestCan_PermissionMatrix(t *testing.T) {
+	tests := []struct {
+		role       shareddomain.Role
+		permission Permission
+		own        bool
+		other      bool
+		general    bool
+	}{
+		{role: shareddomain.RoleUser, permission: PermissionListUsers, own: false, other: false, general: false},
+		{role: shareddomain.RoleUser, permission: PermissionReadUser, own: true, other: false, general: false},
+		{role: shareddomain.RoleUser, permission: PermissionUpdateUser, own: true, other: false, general: false},
+		{role: shareddomain.RoleUser, permission: PermissionDeleteUser, own: true, other: false, general: false},
+		{role: shareddomain.RoleUser, permission: PermissionAssignRole, own: false, other: false, general: false},
+		{role: shareddomain.RoleAdmin, permission: PermissionListUsers, own: true, other: true, general: true},
+		{role: shareddomain.RoleAdmin, permission: PermissionReadUser, own: true, other: true, general: true},
+		{role: shareddomain.RoleAdmin, permission: PermissionUpdateUser, own: true, other: true, general: true},
+		{role: shareddomain.RoleAdmin, permission: PermissionDeleteUser, own: true, other: true, general: true},
+		{role: shareddomain.RoleAdmin, permission: PermissionAssignRole, own: true, other: true, general: true},
+		{role: shareddomain.RoleUser, permission: PermissionManageExchangeRates, own: false, other: false, general: false},
+		{role: shareddomain.RoleAdmin, permission: PermissionManageExchangeRates, own: true, other: true, general: true},
+		{role: shareddomain.RoleUser, permission: PermissionManagePriceIndexes, own: false, other: false, general: false},
+		{role: shareddomain.RoleAdmin, permission: PermissionManagePriceIndexes, own: true, other: true, general: true},
+		{role: shareddomain.RoleUser, permission: PermissionManageAssetPrices, own: false, other: false, general: false},
+		{role: shareddomain.RoleAdmin, permission: PermissionManageAssetPrices, own: true, other: true, general: true},
+		{role: shareddomain.Role("guest"), permission: PermissionReadUser, own: false, other: false, general: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role)+"/"+string(tt.permission), func(t *testing.T) {
+			if got := Can(tt.role, tt.permission, "user-1", "user-1"); got != tt.own {
+				t.Errorf("own resource: expected %v, got %v", tt.own, got)
+			}
+			if got := Can(tt.role, tt.permission, "user-1", "user-2"); got != tt.other {
+				t.Errorf("other user's resource: expected %v, got %v", tt.other, got)
+			}
+			if got := Can(tt.role, tt.permission, "user-1", ""); got != tt.general {
+				t.Errorf("no owner: expected %v, got %v", tt.general, got)
+			}
+		})
+	}
+}
+
+func TestCan_OwnScopeRequiresCaller(t *testing.T) {
+	if Can(shareddomain.RoleUser, PermissionReadUser, "", "") {
+		t.Error("expected an anonymous caller not to match an empty owner")
+	}
+}
+
+func TestRequirePermission(t *testing.T) {
+	ownerFromPath := func(r *http.Request) string {
+		return r.URL.Query().Get("owner")
+	}
+
+	tests := []struct {
+		name           string
+		userID         string
+		role           shareddomain.Role
+		permission     Permission
+		owner          func(r *http.Request) string
+		target         string
+		expectedStatus int
+	}{
+		{name: "unauthenticated", permission: PermissionReadUser, owner: ownerFromPath, target: "/test?owner=user-1", expectedStatus: http.StatusUnauthorized},
+		{name: "user reads self", userID: "user-1", role: shareddomain.RoleUser, permission: PermissionReadUser, owner: ownerFromPath, target: "/test?owner=user-1", expectedStatus: http.StatusOK},
+		{name: "user reads other", userID: "user-1", role: shareddomain.RoleUser, permission: PermissionReadUser, owner: ownerFromPath, target: "/test?owner=user-2", expectedStatus: http.StatusForbidden},
+		{name: "admin reads other", userID: "admin-1", role: shareddomain.RoleAdmin, permission: PermissionReadUser, owner: ownerFromPath, target: "/test?owner=user-2", expectedStatus: http.StatusOK},
+		{name: "user lists", userID: "user-1", role: shareddomain.RoleUser, permission: PermissionListUsers, target: "/test", expectedStatus: http.StatusForbidden},
+		{name: "admin lists", userID: "admin-1", role: shareddomain.RoleAdmin, permission: PermissionListUsers, target: "/test", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest("GET", tt.target, nil)
+			if tt.userID != "" {
+				ctx := context.WithValue(req.Context(), UserIDKey, tt.userID)
+				ctx = context.WithValue(ctx, RoleKey, tt.role)
+				req = req.WithContext(ctx)
+			}
+
+			rr := httptest.NewRecorder()
+			RequirePermission(tt.permission, tt.owner)(handler).ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}